@@ -18,13 +18,18 @@ var (
 )
 
 func main() {
-	// Acquire PID lock first to prevent concurrent execution
-	pidLock, err := state.AcquirePIDLock()
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-		os.Exit(1)
+	rootCmd := cli.NewRootCommand(Version, Commit, Date, BuiltBy)
+
+	// Acquire PID lock first to prevent concurrent execution; long-running
+	// commands such as watch run alongside other commands instead
+	if cli.NeedsPIDLock(rootCmd, os.Args[1:]) {
+		pidLock, err := state.AcquirePIDLock()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		defer func() { _ = pidLock.Release() }()
 	}
-	defer func() { _ = pidLock.Release() }()
 
 	// Create cancellable context for graceful shutdown
 	ctx, cancel := context.WithCancel(context.Background())
@@ -38,7 +43,6 @@ func main() {
 	}()
 
 	// Execute CLI commands with context
-	rootCmd := cli.NewRootCommand(Version, Commit, Date, BuiltBy)
 	if err := rootCmd.ExecuteContext(ctx); err != nil {
 		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 		os.Exit(1)
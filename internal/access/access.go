@@ -0,0 +1,84 @@
+// Package access keeps the player access files of managed servers
+// (whitelist.json, ops.json, banned-players.json and banned-ips.json) in
+// sync with the lists stored in go-mc state, and pushes changes to running
+// servers over RCON.
+package access
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/steviee/go-mc/internal/rcon"
+	"github.com/steviee/go-mc/internal/state"
+)
+
+// rconTimeout bounds each RCON round trip while syncing.
+const rconTimeout = 10 * time.Second
+
+// connect opens an RCON connection to a server that is marked as running.
+// It returns nil without an error when the server is not running.
+func connect(ctx context.Context, serverState *state.ServerState) (*rcon.Client, error) {
+	if serverState.Status != state.StatusRunning {
+		return nil, nil
+	}
+
+	rc, err := rcon.NewServerClient(serverState, rconTimeout)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := rc.Connect(ctx); err != nil {
+		_ = rc.Close()
+		return nil, fmt.Errorf("rcon unavailable: %w", err)
+	}
+
+	return rc, nil
+}
+
+// dataPath returns the path of a file in a server's data directory.
+func dataPath(serverState *state.ServerState, name string) (string, error) {
+	if serverState.Volumes.Data == "" {
+		return "", fmt.Errorf("server %q has no data directory", serverState.Name)
+	}
+	return filepath.Join(serverState.Volumes.Data, name), nil
+}
+
+// readJSONFile decodes a JSON file into v. A missing file leaves v unchanged.
+func readJSONFile(path string, v interface{}) error {
+	//nolint:gosec // G304: path points into a managed server's data directory
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	if err := json.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+
+	return nil
+}
+
+// writeJSONFile writes v as indented JSON, the format the server itself uses.
+func writeJSONFile(path string, v interface{}) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal %s: %w", filepath.Base(path), err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0750); err != nil {
+		return fmt.Errorf("failed to create data directory: %w", err)
+	}
+
+	if err := state.AtomicWrite(path, append(data, '\n'), 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,439 @@
+package access
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/steviee/go-mc/internal/rcon"
+	"github.com/steviee/go-mc/internal/state"
+)
+
+const (
+	// BannedPlayersFile is the player ban file in a server's data directory.
+	BannedPlayersFile = "banned-players.json"
+
+	// BannedIPsFile is the IP ban file in a server's data directory.
+	BannedIPsFile = "banned-ips.json"
+
+	// BanSource is recorded as the source of bans created by go-mc.
+	BanSource = "go-mc"
+
+	// banTimeFormat is the date format the server uses in its ban files.
+	banTimeFormat = "2006-01-02 15:04:05 -0700"
+
+	// banForever marks a permanent ban in the server's ban files.
+	banForever = "forever"
+
+	// defaultBanReason is the reason the server shows when none was given.
+	defaultBanReason = "Banned by an operator."
+)
+
+// BannedPlayerEntry is an entry of a server's banned-players.json.
+type BannedPlayerEntry struct {
+	UUID    string `json:"uuid"`
+	Name    string `json:"name"`
+	Created string `json:"created"`
+	Source  string `json:"source"`
+	Expires string `json:"expires"`
+	Reason  string `json:"reason"`
+}
+
+// BannedIPEntry is an entry of a server's banned-ips.json.
+type BannedIPEntry struct {
+	IP      string `json:"ip"`
+	Created string `json:"created"`
+	Source  string `json:"source"`
+	Expires string `json:"expires"`
+	Reason  string `json:"reason"`
+}
+
+// BanSyncResult describes the outcome of syncing the bans of one server.
+type BanSyncResult struct {
+	Server   string   `json:"server"`
+	Players  int      `json:"players"`
+	IPs      int      `json:"ips"`
+	Banned   []string `json:"banned,omitempty"`
+	Pardoned []string `json:"pardoned,omitempty"`
+
+	// Live is true when the changes were pushed to the running server.
+	Live bool `json:"live"`
+
+	// Warning explains why a running server could not be updated live.
+	Warning string `json:"warning,omitempty"`
+
+	// Error is set when the server could not be synced at all.
+	Error string `json:"error,omitempty"`
+}
+
+// serverBans holds the bans go-mc manages for a server.
+type serverBans struct {
+	players []BannedPlayerEntry
+	ips     []BannedIPEntry
+
+	// managed holds the keys (lowercase UUIDs and IPs) of every ban in the
+	// server's scopes, including expired ones. Entries in the server files
+	// with other keys were banned on the server itself and are kept.
+	managed map[string]bool
+}
+
+// ReadBanFiles reads a server's banned-players.json and banned-ips.json.
+// Missing files yield no entries.
+func ReadBanFiles(serverState *state.ServerState) ([]BannedPlayerEntry, []BannedIPEntry, error) {
+	playersPath, err := dataPath(serverState, BannedPlayersFile)
+	if err != nil {
+		return nil, nil, err
+	}
+	ipsPath, err := dataPath(serverState, BannedIPsFile)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	players := []BannedPlayerEntry{}
+	if err := readJSONFile(playersPath, &players); err != nil {
+		return nil, nil, err
+	}
+
+	ips := []BannedIPEntry{}
+	if err := readJSONFile(ipsPath, &ips); err != nil {
+		return nil, nil, err
+	}
+
+	return players, ips, nil
+}
+
+// SyncBans writes the global and server bans of a server to its ban files.
+// Bans that are missing on a running server are applied with "ban" and
+// "ban-ip", which also kicks the player. Bans that were lifted, either by
+// expiring or because their key is listed in unbanned (UUIDs or IPs), are
+// pardoned. Bans made on the server itself are kept until they expire.
+func SyncBans(ctx context.Context, serverState *state.ServerState, unbanned ...string) (*BanSyncResult, error) {
+	now := time.Now()
+
+	desired, err := loadServerBans(ctx, serverState.Name, now)
+	if err != nil {
+		return nil, err
+	}
+	for _, key := range unbanned {
+		desired.managed[strings.ToLower(key)] = true
+	}
+
+	currentPlayers, currentIPs, err := ReadBanFiles(serverState)
+	if err != nil {
+		return nil, err
+	}
+
+	// Desired player bans first, then bans made on the server itself
+	players := desired.players
+	inDesired := make(map[string]bool, len(players))
+	for _, entry := range players {
+		inDesired[strings.ToLower(entry.UUID)] = true
+	}
+	var banPlayers, pardonPlayers []string
+	inCurrent := make(map[string]bool, len(currentPlayers))
+	for _, entry := range currentPlayers {
+		key := strings.ToLower(entry.UUID)
+		inCurrent[key] = true
+		switch {
+		case inDesired[key]:
+		case desired.managed[key] || entryExpired(entry.Expires, now):
+			pardonPlayers = append(pardonPlayers, entry.Name)
+		default:
+			players = append(players, entry)
+		}
+	}
+	for _, entry := range desired.players {
+		if !inCurrent[strings.ToLower(entry.UUID)] {
+			banPlayers = append(banPlayers, entry.Name)
+		}
+	}
+
+	// The same for IP bans
+	ips := desired.ips
+	inDesired = make(map[string]bool, len(ips))
+	for _, entry := range ips {
+		inDesired[entry.IP] = true
+	}
+	var banIPs, pardonIPs []string
+	inCurrent = make(map[string]bool, len(currentIPs))
+	for _, entry := range currentIPs {
+		inCurrent[entry.IP] = true
+		switch {
+		case inDesired[entry.IP]:
+		case desired.managed[entry.IP] || entryExpired(entry.Expires, now):
+			pardonIPs = append(pardonIPs, entry.IP)
+		default:
+			ips = append(ips, entry)
+		}
+	}
+	for _, entry := range desired.ips {
+		if !inCurrent[entry.IP] {
+			banIPs = append(banIPs, entry.IP)
+		}
+	}
+
+	result := &BanSyncResult{
+		Server:   serverState.Name,
+		Players:  len(players),
+		IPs:      len(ips),
+		Banned:   append(banPlayers, banIPs...),
+		Pardoned: append(pardonPlayers, pardonIPs...),
+	}
+
+	rc, err := connect(ctx, serverState)
+	if err != nil {
+		result.Warning = fmt.Sprintf("changes apply on next start: %v", err)
+	}
+	if rc != nil {
+		defer func() { _ = rc.Close() }()
+
+		// Let the server apply the changes first; the files written below
+		// then replace whatever the server wrote, adding expiry dates.
+		if err := pushBanChanges(ctx, rc, desired, banPlayers, banIPs, pardonPlayers, pardonIPs); err != nil {
+			result.Warning = fmt.Sprintf("changes apply on next start: %v", err)
+		} else {
+			result.Live = true
+		}
+	}
+
+	if err := writeBanFiles(serverState, players, ips); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// SyncBanServers syncs the bans of every server affected by a scope: all
+// servers for the global scope, otherwise the named server. Failures are
+// reported per server.
+func SyncBanServers(ctx context.Context, scope string, unbanned ...string) ([]BanSyncResult, error) {
+	names := []string{scope}
+	if scope == state.GlobalBanScope {
+		var err error
+		names, err = state.ListServerStates(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list servers: %w", err)
+		}
+		sort.Strings(names)
+	}
+
+	results := []BanSyncResult{}
+	for _, name := range names {
+		serverState, err := state.LoadServerState(ctx, name)
+		if err != nil {
+			results = append(results, BanSyncResult{Server: name, Error: err.Error()})
+			continue
+		}
+
+		result, err := SyncBans(ctx, serverState, unbanned...)
+		if err != nil {
+			results = append(results, BanSyncResult{Server: name, Error: err.Error()})
+			continue
+		}
+		results = append(results, *result)
+	}
+
+	return results, nil
+}
+
+// LiftExpiredBans pardons temporary bans that have expired on the servers
+// they apply to and then removes them from their ban lists. It returns the
+// results of the servers that were synced.
+func LiftExpiredBans(ctx context.Context) ([]BanSyncResult, error) {
+	now := time.Now()
+
+	servers, err := state.ListServerStates(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list servers: %w", err)
+	}
+
+	var expiredScopes []string
+	for _, scope := range append([]string{state.GlobalBanScope}, servers...) {
+		list, err := state.LoadBanList(ctx, scope)
+		if err != nil {
+			return nil, err
+		}
+		if hasExpiredBans(list, now) {
+			expiredScopes = append(expiredScopes, scope)
+		}
+	}
+
+	results := []BanSyncResult{}
+	for _, scope := range expiredScopes {
+		// Expired bans are still in the list, so syncing pardons them
+		synced, err := SyncBanServers(ctx, scope)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, synced...)
+
+		if _, err := state.PruneExpiredBans(ctx, scope, now); err != nil {
+			return nil, err
+		}
+	}
+
+	return results, nil
+}
+
+// loadServerBans merges the global and server ban lists into ban file
+// entries. Server bans take precedence over global bans of the same player
+// or address; expired bans are left out.
+func loadServerBans(ctx context.Context, serverName string, now time.Time) (*serverBans, error) {
+	players := make(map[string]BannedPlayerEntry)
+	ips := make(map[string]BannedIPEntry)
+	managed := make(map[string]bool)
+
+	for _, scope := range []string{state.GlobalBanScope, serverName} {
+		list, err := state.LoadBanList(ctx, scope)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, ban := range list.Players {
+			key := strings.ToLower(ban.UUID)
+			managed[key] = true
+			if ban.Expired(now) {
+				continue
+			}
+			created, expires, reason := banFields(ban.BanInfo)
+			players[key] = BannedPlayerEntry{
+				UUID:    key,
+				Name:    ban.Name,
+				Created: created,
+				Source:  ban.Source,
+				Expires: expires,
+				Reason:  reason,
+			}
+		}
+
+		for _, ban := range list.IPs {
+			managed[ban.IP] = true
+			if ban.Expired(now) {
+				continue
+			}
+			created, expires, reason := banFields(ban.BanInfo)
+			ips[ban.IP] = BannedIPEntry{
+				IP:      ban.IP,
+				Created: created,
+				Source:  ban.Source,
+				Expires: expires,
+				Reason:  reason,
+			}
+		}
+	}
+
+	bans := &serverBans{
+		players: make([]BannedPlayerEntry, 0, len(players)),
+		ips:     make([]BannedIPEntry, 0, len(ips)),
+		managed: managed,
+	}
+	for _, entry := range players {
+		bans.players = append(bans.players, entry)
+	}
+	for _, entry := range ips {
+		bans.ips = append(bans.ips, entry)
+	}
+
+	sort.Slice(bans.players, func(i, j int) bool {
+		return strings.ToLower(bans.players[i].Name) < strings.ToLower(bans.players[j].Name)
+	})
+	sort.Slice(bans.ips, func(i, j int) bool {
+		return bans.ips[i].IP < bans.ips[j].IP
+	})
+
+	return bans, nil
+}
+
+// banFields renders the details of a ban in the server's file format.
+func banFields(info state.BanInfo) (created, expires, reason string) {
+	created = info.Created.Format(banTimeFormat)
+	expires = banForever
+	if info.Expires != nil {
+		expires = info.Expires.Format(banTimeFormat)
+	}
+	reason = info.Reason
+	if reason == "" {
+		reason = defaultBanReason
+	}
+	return created, expires, reason
+}
+
+// entryExpired reports whether a ban file entry has expired. Entries with
+// an unreadable date are treated as permanent, like the server does.
+func entryExpired(expires string, now time.Time) bool {
+	if expires == "" || expires == banForever {
+		return false
+	}
+	t, err := time.Parse(banTimeFormat, expires)
+	if err != nil {
+		return false
+	}
+	return !now.Before(t)
+}
+
+// hasExpiredBans reports whether a ban list contains expired bans.
+func hasExpiredBans(list *state.BanList, now time.Time) bool {
+	for _, ban := range list.Players {
+		if ban.Expired(now) {
+			return true
+		}
+	}
+	for _, ban := range list.IPs {
+		if ban.Expired(now) {
+			return true
+		}
+	}
+	return false
+}
+
+// pushBanChanges sends ban changes to a running server.
+func pushBanChanges(ctx context.Context, rc *rcon.Client, desired *serverBans, banPlayers, banIPs, pardonPlayers, pardonIPs []string) error {
+	reasons := make(map[string]string, len(desired.players)+len(desired.ips))
+	for _, entry := range desired.players {
+		reasons[entry.Name] = entry.Reason
+	}
+	for _, entry := range desired.ips {
+		reasons[entry.IP] = entry.Reason
+	}
+
+	commands := make([]string, 0, len(banPlayers)+len(banIPs)+len(pardonPlayers)+len(pardonIPs))
+	for _, name := range banPlayers {
+		commands = append(commands, "ban "+name+" "+reasons[name])
+	}
+	for _, ip := range banIPs {
+		commands = append(commands, "ban-ip "+ip+" "+reasons[ip])
+	}
+	for _, name := range pardonPlayers {
+		commands = append(commands, "pardon "+name)
+	}
+	for _, ip := range pardonIPs {
+		commands = append(commands, "pardon-ip "+ip)
+	}
+
+	for _, command := range commands {
+		if _, err := rc.Execute(ctx, command); err != nil {
+			return fmt.Errorf("failed to run %q: %w", strings.Fields(command)[0], err)
+		}
+	}
+
+	return nil
+}
+
+// writeBanFiles writes a server's banned-players.json and banned-ips.json.
+func writeBanFiles(serverState *state.ServerState, players []BannedPlayerEntry, ips []BannedIPEntry) error {
+	playersPath, err := dataPath(serverState, BannedPlayersFile)
+	if err != nil {
+		return err
+	}
+	if err := writeJSONFile(playersPath, players); err != nil {
+		return err
+	}
+
+	ipsPath, err := dataPath(serverState, BannedIPsFile)
+	if err != nil {
+		return err
+	}
+	return writeJSONFile(ipsPath, ips)
+}
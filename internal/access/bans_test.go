@@ -0,0 +1,187 @@
+package access
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/steviee/go-mc/internal/rcon/rcontest"
+	"github.com/steviee/go-mc/internal/state"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// banPlayer bans a player in a scope.
+func banPlayer(t *testing.T, ctx context.Context, scope string, player state.PlayerInfo, reason string, expires *time.Time) {
+	t.Helper()
+
+	created := time.Now().Add(-2 * time.Hour)
+	require.NoError(t, state.AddPlayerBan(ctx, scope, state.PlayerBan{
+		UUID:    player.UUID,
+		Name:    player.Name,
+		BanInfo: state.BanInfo{Reason: reason, Source: BanSource, Created: created, Expires: expires},
+	}))
+}
+
+func TestSyncBans_StoppedServer(t *testing.T) {
+	ctx := setupState(t)
+	serverState := newServer(t, ctx, "survival")
+	newServer(t, ctx, "creative")
+
+	expires := time.Date(2030, 1, 2, 3, 4, 5, 0, time.UTC)
+	banPlayer(t, ctx, state.GlobalBanScope, notch, "griefing", nil)
+	banPlayer(t, ctx, "survival", jeb, "", &expires)
+	banPlayer(t, ctx, "creative", dinn, "creative only", nil)
+	require.NoError(t, state.AddIPBan(ctx, "survival", state.IPBan{
+		IP:      "203.0.113.7",
+		BanInfo: state.BanInfo{Source: BanSource},
+	}))
+
+	result, err := SyncBans(ctx, serverState)
+	require.NoError(t, err)
+	assert.False(t, result.Live)
+	assert.Equal(t, 2, result.Players)
+	assert.Equal(t, 1, result.IPs)
+	assert.ElementsMatch(t, []string{"jeb_", "Notch", "203.0.113.7"}, result.Banned)
+
+	players, ips, err := ReadBanFiles(serverState)
+	require.NoError(t, err)
+	require.Len(t, players, 2)
+	assert.Equal(t, "jeb_", players[0].Name)
+	assert.Equal(t, defaultBanReason, players[0].Reason)
+	assert.Equal(t, expires.Format(banTimeFormat), players[0].Expires)
+	assert.Equal(t, "Notch", players[1].Name)
+	assert.Equal(t, "griefing", players[1].Reason)
+	assert.Equal(t, banForever, players[1].Expires)
+	assert.Equal(t, BanSource, players[1].Source)
+
+	require.Len(t, ips, 1)
+	assert.Equal(t, "203.0.113.7", ips[0].IP)
+}
+
+func TestSyncBans_KeepsServerBans(t *testing.T) {
+	ctx := setupState(t)
+	serverState := newServer(t, ctx, "survival")
+
+	// Bans made on the server itself: one permanent, one expired
+	require.NoError(t, writeJSONFile(filepath.Join(serverState.Volumes.Data, BannedPlayersFile), []BannedPlayerEntry{
+		{UUID: jeb.UUID, Name: "jeb_", Created: "2024-01-01 00:00:00 +0000", Source: "Server", Expires: banForever, Reason: "x"},
+		{UUID: dinn.UUID, Name: "Dinnerbone", Created: "2024-01-01 00:00:00 +0000", Source: "Server", Expires: "2024-01-02 00:00:00 +0000", Reason: "x"},
+	}))
+
+	banPlayer(t, ctx, "survival", notch, "", nil)
+
+	result, err := SyncBans(ctx, serverState)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Notch"}, result.Banned)
+	assert.Equal(t, []string{"Dinnerbone"}, result.Pardoned)
+
+	players, _, err := ReadBanFiles(serverState)
+	require.NoError(t, err)
+	require.Len(t, players, 2)
+	assert.Equal(t, "Notch", players[0].Name)
+	assert.Equal(t, "jeb_", players[1].Name)
+}
+
+func TestSyncBans_RunningServer(t *testing.T) {
+	ctx := setupState(t)
+	srv := rcontest.NewServer(t, "secret", func(command string) string { return "" })
+
+	serverState := newServer(t, ctx, "survival")
+	serverState.Status = state.StatusRunning
+	serverState.Minecraft.RconPort = srv.Port()
+	serverState.Minecraft.RconPassword = "secret"
+
+	banPlayer(t, ctx, "survival", notch, "griefing", nil)
+	require.NoError(t, state.AddIPBan(ctx, "survival", state.IPBan{
+		IP:      "203.0.113.7",
+		BanInfo: state.BanInfo{Source: BanSource},
+	}))
+
+	result, err := SyncBans(ctx, serverState)
+	require.NoError(t, err)
+	assert.True(t, result.Live)
+	assert.Equal(t, []string{"ban Notch griefing", "ban-ip 203.0.113.7 " + defaultBanReason}, srv.Commands())
+
+	// Syncing again changes nothing
+	_, err = SyncBans(ctx, serverState)
+	require.NoError(t, err)
+	assert.Len(t, srv.Commands(), 2)
+
+	// Unbanning pardons the player even though its ban is gone from state
+	_, err = state.RemovePlayerBan(ctx, "survival", "Notch")
+	require.NoError(t, err)
+	result, err = SyncBans(ctx, serverState, notch.UUID)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Notch"}, result.Pardoned)
+	assert.Equal(t, "pardon Notch", srv.Commands()[2])
+}
+
+func TestSyncBanServers_Global(t *testing.T) {
+	ctx := setupState(t)
+	newServer(t, ctx, "survival")
+	newServer(t, ctx, "creative")
+
+	banPlayer(t, ctx, state.GlobalBanScope, notch, "", nil)
+
+	results, err := SyncBanServers(ctx, state.GlobalBanScope)
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+	assert.Equal(t, "creative", results[0].Server)
+	assert.Equal(t, "survival", results[1].Server)
+	for _, result := range results {
+		assert.Empty(t, result.Error)
+		assert.Equal(t, 1, result.Players)
+	}
+
+	results, err = SyncBanServers(ctx, "survival")
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.Equal(t, "survival", results[0].Server)
+}
+
+func TestLiftExpiredBans(t *testing.T) {
+	ctx := setupState(t)
+	srv := rcontest.NewServer(t, "secret", func(command string) string { return "" })
+
+	serverState := newServer(t, ctx, "survival")
+	serverState.Status = state.StatusRunning
+	serverState.Minecraft.RconPort = srv.Port()
+	serverState.Minecraft.RconPassword = "secret"
+	require.NoError(t, state.SaveServerState(ctx, serverState))
+
+	soon := time.Now().Add(time.Hour)
+	banPlayer(t, ctx, "survival", notch, "", &soon)
+	banPlayer(t, ctx, "survival", jeb, "", nil)
+	_, err := SyncBans(ctx, serverState)
+	require.NoError(t, err)
+
+	// Nothing has expired yet
+	results, err := LiftExpiredBans(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, results)
+
+	// Let the temporary ban run out
+	list, err := state.LoadBanList(ctx, "survival")
+	require.NoError(t, err)
+	past := time.Now().Add(-time.Minute)
+	list.Players[0].Expires = &past
+	require.NoError(t, state.SaveBanList(ctx, list))
+
+	results, err = LiftExpiredBans(ctx)
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.Equal(t, []string{"Notch"}, results[0].Pardoned)
+	assert.Contains(t, srv.Commands(), "pardon Notch")
+
+	list, err = state.LoadBanList(ctx, "survival")
+	require.NoError(t, err)
+	require.Len(t, list.Players, 1)
+	assert.Equal(t, "jeb_", list.Players[0].Name)
+
+	players, _, err := ReadBanFiles(serverState)
+	require.NoError(t, err)
+	require.Len(t, players, 1)
+	assert.Equal(t, "jeb_", players[0].Name)
+}
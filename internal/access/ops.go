@@ -0,0 +1,124 @@
+package access
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/steviee/go-mc/internal/state"
+)
+
+const (
+	// OpsFile is the operator file in a server's data directory.
+	OpsFile = "ops.json"
+
+	// DefaultOpLevel is the permission level the server grants for "op".
+	DefaultOpLevel = 4
+)
+
+// OpEntry is an entry of a server's ops.json.
+type OpEntry struct {
+	UUID                string `json:"uuid"`
+	Name                string `json:"name"`
+	Level               int    `json:"level"`
+	BypassesPlayerLimit bool   `json:"bypassesPlayerLimit"`
+}
+
+// ApplyResult describes how a change reached a server.
+type ApplyResult struct {
+	Server string `json:"server"`
+
+	// Live is true when the change was pushed to the running server.
+	Live bool `json:"live"`
+
+	// Warning explains why (part of) the change only applies on next start.
+	Warning string `json:"warning,omitempty"`
+}
+
+// FindOp returns the operator with the given name (case-insensitive) or UUID.
+func FindOp(serverState *state.ServerState, player string) (*state.OpInfo, bool) {
+	for i := range serverState.Ops {
+		op := &serverState.Ops[i]
+		if strings.EqualFold(op.Name, player) || strings.EqualFold(op.UUID, player) {
+			return op, true
+		}
+	}
+	return nil, false
+}
+
+// WriteOpsFile renders the operators of a server to its ops.json.
+func WriteOpsFile(serverState *state.ServerState) error {
+	entries := make([]OpEntry, 0, len(serverState.Ops))
+	for _, op := range serverState.Ops {
+		entries = append(entries, OpEntry{
+			UUID:                strings.ToLower(op.UUID),
+			Name:                op.Name,
+			Level:               op.Level,
+			BypassesPlayerLimit: op.BypassesPlayerLimit,
+		})
+	}
+
+	path, err := dataPath(serverState, OpsFile)
+	if err != nil {
+		return err
+	}
+	return writeJSONFile(path, entries)
+}
+
+// ReadOpsFile reads a server's ops.json. A missing file yields no entries.
+func ReadOpsFile(serverState *state.ServerState) ([]OpEntry, error) {
+	path, err := dataPath(serverState, OpsFile)
+	if err != nil {
+		return nil, err
+	}
+
+	entries := []OpEntry{}
+	if err := readJSONFile(path, &entries); err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+// ApplyOp writes ops.json and, for a running server, grants operator status
+// over RCON. The console "op" command always uses the server's default
+// op-permission-level, so other levels take effect on the next start.
+func ApplyOp(ctx context.Context, serverState *state.ServerState, op state.OpInfo) (*ApplyResult, error) {
+	return applyOpsChange(ctx, serverState, "op "+op.Name, op.Level != DefaultOpLevel,
+		fmt.Sprintf("level %d applies on next start", op.Level))
+}
+
+// ApplyDeop writes ops.json and, for a running server, revokes operator
+// status over RCON.
+func ApplyDeop(ctx context.Context, serverState *state.ServerState, name string) (*ApplyResult, error) {
+	return applyOpsChange(ctx, serverState, "deop "+name, false, "")
+}
+
+// applyOpsChange runs command on a running server and then writes ops.json,
+// replacing whatever the server wrote. When partial is set, note is reported
+// as a warning even if the command succeeded.
+func applyOpsChange(ctx context.Context, serverState *state.ServerState, command string, partial bool, note string) (*ApplyResult, error) {
+	result := &ApplyResult{Server: serverState.Name}
+
+	rc, err := connect(ctx, serverState)
+	if err != nil {
+		result.Warning = fmt.Sprintf("change applies on next start: %v", err)
+	}
+	if rc != nil {
+		defer func() { _ = rc.Close() }()
+
+		if _, err := rc.Execute(ctx, command); err != nil {
+			result.Warning = fmt.Sprintf("change applies on next start: %v", err)
+		} else {
+			result.Live = true
+			if partial {
+				result.Warning = note
+			}
+		}
+	}
+
+	if err := WriteOpsFile(serverState); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
@@ -0,0 +1,81 @@
+package access
+
+import (
+	"testing"
+
+	"github.com/steviee/go-mc/internal/rcon/rcontest"
+	"github.com/steviee/go-mc/internal/state"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFindOp(t *testing.T) {
+	serverState := state.NewServerState("survival")
+	serverState.Ops = []state.OpInfo{{UUID: notch.UUID, Name: "Notch", Level: 4}}
+
+	op, ok := FindOp(serverState, "notch")
+	require.True(t, ok)
+	assert.Equal(t, "Notch", op.Name)
+
+	_, ok = FindOp(serverState, notch.UUID)
+	assert.True(t, ok)
+
+	_, ok = FindOp(serverState, "jeb_")
+	assert.False(t, ok)
+}
+
+func TestApplyOp_StoppedServer(t *testing.T) {
+	ctx := setupState(t)
+	serverState := newServer(t, ctx, "survival")
+	serverState.Ops = []state.OpInfo{{UUID: notch.UUID, Name: "Notch", Level: 2, BypassesPlayerLimit: true}}
+
+	result, err := ApplyOp(ctx, serverState, serverState.Ops[0])
+	require.NoError(t, err)
+	assert.False(t, result.Live)
+
+	entries, err := ReadOpsFile(serverState)
+	require.NoError(t, err)
+	assert.Equal(t, []OpEntry{{UUID: notch.UUID, Name: "Notch", Level: 2, BypassesPlayerLimit: true}}, entries)
+}
+
+func TestApplyOp_RunningServer(t *testing.T) {
+	ctx := setupState(t)
+	srv := rcontest.NewServer(t, "secret", func(command string) string { return "" })
+
+	serverState := newServer(t, ctx, "survival")
+	serverState.Status = state.StatusRunning
+	serverState.Minecraft.RconPort = srv.Port()
+	serverState.Minecraft.RconPassword = "secret"
+
+	tests := []struct {
+		name        string
+		level       int
+		wantWarning bool
+	}{
+		{name: "default level", level: DefaultOpLevel},
+		{name: "custom level", level: 2, wantWarning: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			op := state.OpInfo{UUID: notch.UUID, Name: "Notch", Level: tt.level}
+			serverState.Ops = []state.OpInfo{op}
+
+			result, err := ApplyOp(ctx, serverState, op)
+			require.NoError(t, err)
+			assert.True(t, result.Live)
+			assert.Equal(t, tt.wantWarning, result.Warning != "")
+			assert.Equal(t, "op Notch", srv.Commands()[len(srv.Commands())-1])
+		})
+	}
+
+	serverState.Ops = nil
+	result, err := ApplyDeop(ctx, serverState, "Notch")
+	require.NoError(t, err)
+	assert.True(t, result.Live)
+	assert.Equal(t, "deop Notch", srv.Commands()[len(srv.Commands())-1])
+
+	entries, err := ReadOpsFile(serverState)
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+}
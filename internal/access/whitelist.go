@@ -0,0 +1,324 @@
+package access
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/steviee/go-mc/internal/minecraft"
+	"github.com/steviee/go-mc/internal/rcon"
+	"github.com/steviee/go-mc/internal/state"
+)
+
+const (
+	// GlobalWhitelist is the list that applies to every server.
+	GlobalWhitelist = "default"
+
+	// WhitelistFile is the whitelist file in a server's data directory.
+	WhitelistFile = "whitelist.json"
+)
+
+// WhitelistEntry is an entry of a server's whitelist.json.
+type WhitelistEntry struct {
+	UUID string `json:"uuid"`
+	Name string `json:"name"`
+}
+
+// WhitelistSyncResult describes the outcome of syncing one server.
+type WhitelistSyncResult struct {
+	Server  string   `json:"server"`
+	Enabled bool     `json:"enabled"`
+	Players int      `json:"players"`
+	Added   []string `json:"added,omitempty"`
+	Removed []string `json:"removed,omitempty"`
+
+	// Live is true when the changes were pushed to the running server.
+	Live bool `json:"live"`
+
+	// Warning explains why a running server could not be updated live.
+	Warning string `json:"warning,omitempty"`
+
+	// Error is set when the server could not be synced at all.
+	Error string `json:"error,omitempty"`
+}
+
+// ServerWhitelists returns the lists that make up a server's whitelist: the
+// global list followed by the lists attached to the server.
+func ServerWhitelists(serverState *state.ServerState) []string {
+	lists := []string{GlobalWhitelist}
+	for _, name := range serverState.Whitelist.Lists {
+		if name != GlobalWhitelist {
+			lists = append(lists, name)
+		}
+	}
+	return lists
+}
+
+// MergeWhitelists merges the players of the named lists, without duplicates
+// and sorted by name. Lists that do not exist are skipped.
+func MergeWhitelists(ctx context.Context, names []string) ([]WhitelistEntry, error) {
+	byUUID := make(map[string]WhitelistEntry)
+
+	for _, name := range names {
+		exists, err := state.WhitelistExists(ctx, name)
+		if err != nil {
+			return nil, err
+		}
+		if !exists {
+			if name != GlobalWhitelist {
+				slog.Warn("attached whitelist does not exist", "whitelist", name)
+			}
+			continue
+		}
+
+		players, err := state.ListPlayers(ctx, name)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, p := range players {
+			byUUID[strings.ToLower(p.UUID)] = WhitelistEntry{UUID: strings.ToLower(p.UUID), Name: p.Name}
+		}
+	}
+
+	entries := make([]WhitelistEntry, 0, len(byUUID))
+	for _, entry := range byUUID {
+		entries = append(entries, entry)
+	}
+	sortWhitelist(entries)
+
+	return entries, nil
+}
+
+// ReadWhitelistFile reads a server's whitelist.json. A missing file yields no entries.
+func ReadWhitelistFile(serverState *state.ServerState) ([]WhitelistEntry, error) {
+	path, err := dataPath(serverState, WhitelistFile)
+	if err != nil {
+		return nil, err
+	}
+
+	entries := []WhitelistEntry{}
+	if err := readJSONFile(path, &entries); err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+// SyncWhitelist writes the merged lists of a server to its whitelist.json.
+// Running servers receive "whitelist add/remove" for every change followed by
+// "whitelist reload"; stopped servers pick the file up on their next start.
+// Entries that were added on the server itself are removed, since go-mc
+// state is the source of truth.
+func SyncWhitelist(ctx context.Context, serverState *state.ServerState) (*WhitelistSyncResult, error) {
+	desired, err := MergeWhitelists(ctx, ServerWhitelists(serverState))
+	if err != nil {
+		return nil, err
+	}
+
+	current, err := ReadWhitelistFile(serverState)
+	if err != nil {
+		return nil, err
+	}
+
+	added, removed := diffWhitelist(current, desired)
+
+	result := &WhitelistSyncResult{
+		Server:  serverState.Name,
+		Enabled: serverState.Whitelist.Enabled,
+		Players: len(desired),
+		Added:   entryNames(added),
+		Removed: entryNames(removed),
+	}
+
+	rc, err := connect(ctx, serverState)
+	if err != nil {
+		result.Warning = fmt.Sprintf("changes apply on next start: %v", err)
+	}
+
+	live := rc != nil
+	if live {
+		defer func() { _ = rc.Close() }()
+
+		// Let the server apply the changes first; the file written below
+		// then replaces whatever the server wrote and is reloaded.
+		if err := pushWhitelistChanges(ctx, rc, added, removed); err != nil {
+			result.Warning = fmt.Sprintf("changes apply on next start: %v", err)
+			live = false
+		}
+	}
+
+	path, err := dataPath(serverState, WhitelistFile)
+	if err != nil {
+		return nil, err
+	}
+	if err := writeJSONFile(path, desired); err != nil {
+		return nil, err
+	}
+
+	if live {
+		if _, err := rc.Execute(ctx, "whitelist reload"); err != nil {
+			result.Warning = fmt.Sprintf("changes apply on next start: %v", err)
+		} else {
+			result.Live = true
+		}
+	}
+
+	return result, nil
+}
+
+// SetWhitelistEnabled turns whitelist enforcement on or off for a server. The
+// setting is saved to the server state and server.properties, the whitelist
+// file is synced, and running servers are switched over RCON.
+func SetWhitelistEnabled(ctx context.Context, serverState *state.ServerState, enabled bool) (*WhitelistSyncResult, error) {
+	serverState.Whitelist.Enabled = enabled
+
+	if err := state.SaveServerState(ctx, serverState); err != nil {
+		return nil, fmt.Errorf("failed to save server state: %w", err)
+	}
+
+	// Populate the whitelist before it is enforced
+	result, err := SyncWhitelist(ctx, serverState)
+	if err != nil {
+		return nil, err
+	}
+
+	propsPath, err := dataPath(serverState, minecraft.ServerPropertiesFile)
+	if err != nil {
+		return nil, err
+	}
+	if err := minecraft.UpdateProperties(propsPath, map[string]string{
+		"white-list":        strconv.FormatBool(enabled),
+		"enforce-whitelist": strconv.FormatBool(enabled),
+	}); err != nil {
+		return nil, err
+	}
+
+	if !result.Live {
+		return result, nil
+	}
+
+	command := "whitelist off"
+	if enabled {
+		command = "whitelist on"
+	}
+
+	rc, err := connect(ctx, serverState)
+	if err == nil && rc != nil {
+		defer func() { _ = rc.Close() }()
+		_, err = rc.Execute(ctx, command)
+	}
+	if err != nil {
+		result.Live = false
+		result.Warning = fmt.Sprintf("change applies on next start: %v", err)
+	}
+
+	return result, nil
+}
+
+// SyncServers syncs the whitelist of every server that uses the named list.
+// An empty name syncs all servers. Failures are reported per server.
+func SyncServers(ctx context.Context, listName string) ([]WhitelistSyncResult, error) {
+	names, err := state.ListServerStates(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list servers: %w", err)
+	}
+	sort.Strings(names)
+
+	results := []WhitelistSyncResult{}
+	for _, name := range names {
+		serverState, err := state.LoadServerState(ctx, name)
+		if err != nil {
+			results = append(results, WhitelistSyncResult{Server: name, Error: err.Error()})
+			continue
+		}
+
+		if listName != "" && !usesWhitelist(serverState, listName) {
+			continue
+		}
+
+		result, err := SyncWhitelist(ctx, serverState)
+		if err != nil {
+			results = append(results, WhitelistSyncResult{Server: name, Error: err.Error()})
+			continue
+		}
+		results = append(results, *result)
+	}
+
+	return results, nil
+}
+
+// usesWhitelist reports whether a list is part of a server's whitelist.
+func usesWhitelist(serverState *state.ServerState, listName string) bool {
+	for _, name := range ServerWhitelists(serverState) {
+		if name == listName {
+			return true
+		}
+	}
+	return false
+}
+
+// pushWhitelistChanges sends whitelist changes to a running server.
+func pushWhitelistChanges(ctx context.Context, rc *rcon.Client, added, removed []WhitelistEntry) error {
+	for _, entry := range added {
+		if _, err := rc.Execute(ctx, "whitelist add "+entry.Name); err != nil {
+			return fmt.Errorf("failed to add %s: %w", entry.Name, err)
+		}
+	}
+
+	for _, entry := range removed {
+		if _, err := rc.Execute(ctx, "whitelist remove "+entry.Name); err != nil {
+			return fmt.Errorf("failed to remove %s: %w", entry.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// diffWhitelist returns the entries of desired missing from current and the
+// entries of current missing from desired.
+func diffWhitelist(current, desired []WhitelistEntry) (added, removed []WhitelistEntry) {
+	inCurrent := make(map[string]bool, len(current))
+	for _, entry := range current {
+		inCurrent[strings.ToLower(entry.UUID)] = true
+	}
+
+	inDesired := make(map[string]bool, len(desired))
+	for _, entry := range desired {
+		inDesired[strings.ToLower(entry.UUID)] = true
+		if !inCurrent[strings.ToLower(entry.UUID)] {
+			added = append(added, entry)
+		}
+	}
+
+	for _, entry := range current {
+		if !inDesired[strings.ToLower(entry.UUID)] {
+			removed = append(removed, entry)
+		}
+	}
+
+	sortWhitelist(removed)
+	return added, removed
+}
+
+// sortWhitelist sorts entries by player name, case-insensitively.
+func sortWhitelist(entries []WhitelistEntry) {
+	sort.Slice(entries, func(i, j int) bool {
+		return strings.ToLower(entries[i].Name) < strings.ToLower(entries[j].Name)
+	})
+}
+
+// entryNames returns the player names of entries.
+func entryNames(entries []WhitelistEntry) []string {
+	if len(entries) == 0 {
+		return nil
+	}
+
+	names := make([]string, len(entries))
+	for i, entry := range entries {
+		names[i] = entry.Name
+	}
+	return names
+}
@@ -0,0 +1,220 @@
+package access
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/steviee/go-mc/internal/minecraft"
+	"github.com/steviee/go-mc/internal/rcon/rcontest"
+	"github.com/steviee/go-mc/internal/state"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	notch = state.PlayerInfo{UUID: "069a79f4-44e9-4726-a5be-fca90e38aaf5", Name: "Notch"}
+	jeb   = state.PlayerInfo{UUID: "853c80ef-3c37-49fd-aa49-938b674adae6", Name: "jeb_"}
+	dinn  = state.PlayerInfo{UUID: "61699b2e-d327-4a01-9f1e-0ea8c3f06bc6", Name: "Dinnerbone"}
+)
+
+// setupState points go-mc state at a temporary directory.
+func setupState(t *testing.T) context.Context {
+	t.Helper()
+
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	require.NoError(t, state.InitDirs())
+
+	return context.Background()
+}
+
+// newServer saves a stopped server with its own data directory.
+func newServer(t *testing.T, ctx context.Context, name string, lists ...string) *state.ServerState {
+	t.Helper()
+
+	serverState := state.NewServerState(name)
+	serverState.Volumes.Data = filepath.Join(t.TempDir(), "data")
+	serverState.Whitelist.Lists = append([]string{}, lists...)
+	require.NoError(t, state.SaveServerState(ctx, serverState))
+
+	return serverState
+}
+
+// addPlayers creates a whitelist with players.
+func addPlayers(t *testing.T, ctx context.Context, list string, players ...state.PlayerInfo) {
+	t.Helper()
+
+	for _, p := range players {
+		require.NoError(t, state.AddPlayer(ctx, list, p))
+	}
+}
+
+// readWhitelist reads a server's whitelist.json.
+func readWhitelist(t *testing.T, serverState *state.ServerState) []WhitelistEntry {
+	t.Helper()
+
+	data, err := os.ReadFile(filepath.Join(serverState.Volumes.Data, WhitelistFile))
+	require.NoError(t, err)
+
+	var entries []WhitelistEntry
+	require.NoError(t, json.Unmarshal(data, &entries))
+	return entries
+}
+
+func TestServerWhitelists(t *testing.T) {
+	serverState := state.NewServerState("test")
+	assert.Equal(t, []string{GlobalWhitelist}, ServerWhitelists(serverState))
+
+	serverState.Whitelist.Lists = []string{"friends", GlobalWhitelist, "family"}
+	assert.Equal(t, []string{GlobalWhitelist, "friends", "family"}, ServerWhitelists(serverState))
+}
+
+func TestMergeWhitelists(t *testing.T) {
+	ctx := setupState(t)
+	addPlayers(t, ctx, GlobalWhitelist, notch)
+	addPlayers(t, ctx, "friends", jeb, notch)
+
+	entries, err := MergeWhitelists(ctx, []string{GlobalWhitelist, "friends", "missing"})
+	require.NoError(t, err)
+	assert.Equal(t, []WhitelistEntry{
+		{UUID: jeb.UUID, Name: "jeb_"},
+		{UUID: notch.UUID, Name: "Notch"},
+	}, entries)
+}
+
+func TestSyncWhitelist_StoppedServer(t *testing.T) {
+	ctx := setupState(t)
+	addPlayers(t, ctx, GlobalWhitelist, notch)
+	addPlayers(t, ctx, "friends", jeb)
+	addPlayers(t, ctx, "other", dinn)
+
+	serverState := newServer(t, ctx, "survival", "friends")
+
+	result, err := SyncWhitelist(ctx, serverState)
+	require.NoError(t, err)
+	assert.Equal(t, 2, result.Players)
+	assert.Equal(t, []string{"jeb_", "Notch"}, result.Added)
+	assert.Empty(t, result.Removed)
+	assert.False(t, result.Live)
+	assert.Empty(t, result.Warning)
+
+	assert.Equal(t, []WhitelistEntry{
+		{UUID: jeb.UUID, Name: "jeb_"},
+		{UUID: notch.UUID, Name: "Notch"},
+	}, readWhitelist(t, serverState))
+
+	// Detaching the list removes its players on the next sync
+	serverState.Whitelist.Lists = nil
+	result, err = SyncWhitelist(ctx, serverState)
+	require.NoError(t, err)
+	assert.Empty(t, result.Added)
+	assert.Equal(t, []string{"jeb_"}, result.Removed)
+	assert.Len(t, readWhitelist(t, serverState), 1)
+}
+
+func TestSyncWhitelist_RunningServer(t *testing.T) {
+	ctx := setupState(t)
+	addPlayers(t, ctx, GlobalWhitelist, notch)
+
+	srv := rcontest.NewServer(t, "secret", func(command string) string { return "" })
+
+	serverState := newServer(t, ctx, "survival")
+	serverState.Status = state.StatusRunning
+	serverState.Minecraft.RconPort = srv.Port()
+	serverState.Minecraft.RconPassword = "secret"
+
+	// A player that was whitelisted on the server itself
+	require.NoError(t, os.MkdirAll(serverState.Volumes.Data, 0750))
+	require.NoError(t, os.WriteFile(
+		filepath.Join(serverState.Volumes.Data, WhitelistFile),
+		[]byte(`[{"uuid":"`+dinn.UUID+`","name":"Dinnerbone"}]`), 0600))
+
+	result, err := SyncWhitelist(ctx, serverState)
+	require.NoError(t, err)
+	assert.True(t, result.Live)
+	assert.Equal(t, []string{"Notch"}, result.Added)
+	assert.Equal(t, []string{"Dinnerbone"}, result.Removed)
+
+	assert.Equal(t, []string{
+		"whitelist add Notch",
+		"whitelist remove Dinnerbone",
+		"whitelist reload",
+	}, srv.Commands())
+	assert.Equal(t, []WhitelistEntry{{UUID: notch.UUID, Name: "Notch"}}, readWhitelist(t, serverState))
+}
+
+func TestSyncWhitelist_RCONUnavailable(t *testing.T) {
+	ctx := setupState(t)
+	addPlayers(t, ctx, GlobalWhitelist, notch)
+
+	serverState := newServer(t, ctx, "survival")
+	serverState.Status = state.StatusRunning
+
+	result, err := SyncWhitelist(ctx, serverState)
+	require.NoError(t, err)
+	assert.False(t, result.Live)
+	assert.Contains(t, result.Warning, "next start")
+
+	// The file is written regardless
+	assert.Len(t, readWhitelist(t, serverState), 1)
+}
+
+func TestSetWhitelistEnabled(t *testing.T) {
+	ctx := setupState(t)
+	addPlayers(t, ctx, GlobalWhitelist, notch)
+
+	srv := rcontest.NewServer(t, "secret", func(command string) string { return "" })
+
+	serverState := newServer(t, ctx, "survival")
+	serverState.Status = state.StatusRunning
+	serverState.Minecraft.RconPort = srv.Port()
+	serverState.Minecraft.RconPassword = "secret"
+
+	result, err := SetWhitelistEnabled(ctx, serverState, true)
+	require.NoError(t, err)
+	assert.True(t, result.Live)
+	assert.True(t, result.Enabled)
+	assert.Contains(t, srv.Commands(), "whitelist on")
+
+	saved, err := state.LoadServerState(ctx, "survival")
+	require.NoError(t, err)
+	assert.True(t, saved.Whitelist.Enabled)
+
+	props, err := minecraft.ReadProperties(filepath.Join(serverState.Volumes.Data, minecraft.ServerPropertiesFile))
+	require.NoError(t, err)
+	assert.Equal(t, "true", props["white-list"])
+	assert.Equal(t, "true", props["enforce-whitelist"])
+
+	_, err = SetWhitelistEnabled(ctx, serverState, false)
+	require.NoError(t, err)
+	assert.Contains(t, srv.Commands(), "whitelist off")
+
+	props, err = minecraft.ReadProperties(filepath.Join(serverState.Volumes.Data, minecraft.ServerPropertiesFile))
+	require.NoError(t, err)
+	assert.Equal(t, "false", props["white-list"])
+}
+
+func TestSyncServers(t *testing.T) {
+	ctx := setupState(t)
+	addPlayers(t, ctx, "friends", jeb)
+
+	withList := newServer(t, ctx, "survival", "friends")
+	newServer(t, ctx, "creative")
+
+	results, err := SyncServers(ctx, "friends")
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.Equal(t, "survival", results[0].Server)
+	assert.Len(t, readWhitelist(t, withList), 1)
+
+	// The global list and an empty name reach every server
+	results, err = SyncServers(ctx, GlobalWhitelist)
+	require.NoError(t, err)
+	assert.Len(t, results, 2)
+
+	results, err = SyncServers(ctx, "")
+	require.NoError(t, err)
+	assert.Len(t, results, 2)
+}
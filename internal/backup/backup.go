@@ -28,8 +28,9 @@ func NewService() *Service {
 // CreateBackupOptions holds options for creating a backup.
 type CreateBackupOptions struct {
 	ServerName string
-	Compress   bool // Default: true
-	KeepCount  int  // Retention policy: keep last N backups (default: 5)
+	Compress   bool   // Default: true
+	KeepCount  int    // Retention policy: keep last N backups (default: 5)
+	Directory  string // Archive directory, "~/" is expanded (default: state.GetArchivesDir())
 }
 
 // CreateBackupResult holds the result of a backup operation.
@@ -70,7 +71,7 @@ func (s *Service) CreateBackup(ctx context.Context, opts CreateBackupOptions) (*
 	backupID := state.GenerateBackupID(opts.ServerName, now)
 	filename := backupID + ".tar.gz"
 
-	archivesDir, err := state.GetArchivesDir()
+	archivesDir, err := resolveArchivesDir(opts.Directory)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get archives directory: %w", err)
 	}
@@ -133,6 +134,14 @@ func (s *Service) CreateBackup(ctx context.Context, opts CreateBackupOptions) (*
 	}, nil
 }
 
+// resolveArchivesDir returns the directory backups are written to.
+func resolveArchivesDir(dir string) (string, error) {
+	if dir == "" {
+		return state.GetArchivesDir()
+	}
+	return state.ExpandHome(dir)
+}
+
 // RestoreBackupOptions holds options for restoring a backup.
 type RestoreBackupOptions struct {
 	BackupID   string
@@ -13,15 +13,19 @@ func NewCommand() *cobra.Command {
 
 Commands in this group allow you to view current configuration,
 modify settings, and reset to defaults. Configuration is stored
-in ~/.config/go-mc/config.yaml by default.`,
+in ~/.config/go-mc/config.yaml by default.
+
+Keys are dotted paths that match the config file, for example
+defaults.memory or backups.keep_count. Every key can also be overridden
+with an environment variable such as GOMC_DEFAULTS_MEMORY.`,
 		Example: `  # View current configuration
   go-mc config show
 
   # Set a configuration value
-  go-mc config set default_memory 4G
+  go-mc config set defaults.memory 4G
 
   # Get a specific value
-  go-mc config get default_memory
+  go-mc config get defaults.memory
 
   # Reset to defaults
   go-mc config reset
@@ -30,17 +34,21 @@ in ~/.config/go-mc/config.yaml by default.`,
   go-mc config edit
 
   # Show configuration file path
-  go-mc config path`,
+  go-mc config path
+
+  # Validate the configuration
+  go-mc config validate`,
 		Aliases: []string{"cfg"},
 	}
 
-	// Subcommands will be added in future phases
-	// cmd.AddCommand(NewShowCommand())
-	// cmd.AddCommand(NewSetCommand())
-	// cmd.AddCommand(NewGetCommand())
-	// cmd.AddCommand(NewResetCommand())
-	// cmd.AddCommand(NewEditCommand())
-	// cmd.AddCommand(NewPathCommand())
+	// Add subcommands
+	cmd.AddCommand(NewShowCommand())
+	cmd.AddCommand(NewSetCommand())
+	cmd.AddCommand(NewGetCommand())
+	cmd.AddCommand(NewResetCommand())
+	cmd.AddCommand(NewEditCommand())
+	cmd.AddCommand(NewPathCommand())
+	cmd.AddCommand(NewValidateCommand())
 
 	return cmd
 }
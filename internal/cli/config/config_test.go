@@ -1,6 +1,7 @@
 package config
 
 import (
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -23,3 +24,29 @@ func TestNewCommand_HasHelp(t *testing.T) {
 	assert.NotEmpty(t, cmd.Long, "command should have long description")
 	assert.NotEmpty(t, cmd.Example, "command should have examples")
 }
+
+func TestNewCommand_Subcommands(t *testing.T) {
+	cmd := NewCommand()
+
+	names := []string{}
+	for _, sub := range cmd.Commands() {
+		names = append(names, sub.Name())
+	}
+
+	for _, want := range []string{"show", "get", "set", "reset", "edit", "path", "validate"} {
+		assert.Contains(t, names, want)
+	}
+}
+
+// setupConfigHome points the config path at a temporary directory and
+// clears environment that would change command behavior.
+func setupConfigHome(t *testing.T) string {
+	t.Helper()
+
+	tmpDir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", tmpDir)
+	t.Setenv("GOMC_CONFIG", "")
+	t.Setenv("GOMC_JSON", "")
+
+	return filepath.Join(tmpDir, "go-mc", "config.yaml")
+}
@@ -0,0 +1,175 @@
+package config
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/steviee/go-mc/internal/state"
+)
+
+// defaultEditor is used when neither $VISUAL nor $EDITOR is set
+const defaultEditor = "vi"
+
+// NewEditCommand creates the config edit subcommand
+func NewEditCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "edit",
+		Short: "Edit the configuration in $EDITOR",
+		Long: `Open the config file in $VISUAL or $EDITOR (falling back to vi).
+
+The file is edited as a temporary copy. When the editor exits, the copy is
+parsed and validated; only a valid configuration replaces the config file.
+If validation fails the config file is left untouched and the edited copy
+is kept so no changes are lost.`,
+		Example: `  # Edit configuration
+  go-mc config edit
+
+  # Edit with a specific editor
+  EDITOR=nano go-mc config edit`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runEdit(cmd.Context(), cmd.InOrStdin(), cmd.OutOrStdout(), cmd.ErrOrStderr(), editorCommand())
+		},
+	}
+
+	return cmd
+}
+
+// editorCommand returns the user's preferred editor
+func editorCommand() string {
+	if editor := os.Getenv("VISUAL"); editor != "" {
+		return editor
+	}
+	if editor := os.Getenv("EDITOR"); editor != "" {
+		return editor
+	}
+	return defaultEditor
+}
+
+// runEdit executes the edit command
+func runEdit(ctx context.Context, stdin io.Reader, stdout, stderr io.Writer, editor string) error {
+	jsonMode := isJSONMode()
+
+	configPath, err := state.GetConfigPath()
+	if err != nil {
+		return outputError(stdout, jsonMode, err)
+	}
+
+	// Make sure there is a file to edit
+	if _, err := os.Stat(configPath); os.IsNotExist(err) {
+		if err := state.SaveConfig(ctx, state.DefaultConfig()); err != nil {
+			return outputError(stdout, jsonMode, err)
+		}
+	}
+
+	//nolint:gosec // G304: configPath is generated by GetConfigPath(), not user input
+	original, err := os.ReadFile(configPath)
+	if err != nil {
+		return outputError(stdout, jsonMode, fmt.Errorf("failed to read config file: %w", err))
+	}
+
+	tmpPath, err := writeEditCopy(configPath, original)
+	if err != nil {
+		return outputError(stdout, jsonMode, err)
+	}
+
+	if err := launchEditor(ctx, editor, tmpPath, stdin, stdout, stderr); err != nil {
+		_ = os.Remove(tmpPath)
+		return outputError(stdout, jsonMode, err)
+	}
+
+	//nolint:gosec // G304: tmpPath was created by writeEditCopy
+	edited, err := os.ReadFile(tmpPath)
+	if err != nil {
+		return outputError(stdout, jsonMode, fmt.Errorf("failed to read edited config: %w", err))
+	}
+
+	if bytes.Equal(original, edited) {
+		_ = os.Remove(tmpPath)
+		return outputEditResult(stdout, jsonMode, configPath, false)
+	}
+
+	// Re-validate before replacing the config file
+	if _, err := state.ParseConfig(edited); err != nil {
+		return outputError(stdout, jsonMode, fmt.Errorf("config not saved: %w (your changes are kept in %s)", err, tmpPath))
+	}
+
+	if err := state.AtomicWrite(configPath, edited, 0644); err != nil {
+		return outputError(stdout, jsonMode, fmt.Errorf("failed to write config: %w", err))
+	}
+	_ = os.Remove(tmpPath)
+
+	return outputEditResult(stdout, jsonMode, configPath, true)
+}
+
+// writeEditCopy writes data to a temporary file next to the config file
+func writeEditCopy(configPath string, data []byte) (string, error) {
+	tmp, err := os.CreateTemp(filepath.Dir(configPath), "config-edit-*.yaml")
+	if err != nil {
+		return "", fmt.Errorf("failed to create temporary file: %w", err)
+	}
+
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		_ = os.Remove(tmp.Name())
+		return "", fmt.Errorf("failed to write temporary file: %w", err)
+	}
+
+	if err := tmp.Close(); err != nil {
+		_ = os.Remove(tmp.Name())
+		return "", fmt.Errorf("failed to close temporary file: %w", err)
+	}
+
+	return tmp.Name(), nil
+}
+
+// launchEditor runs the editor on path and waits for it to exit.
+// The editor may include arguments (e.g. "code --wait").
+func launchEditor(ctx context.Context, editor, path string, stdin io.Reader, stdout, stderr io.Writer) error {
+	fields := strings.Fields(editor)
+	if len(fields) == 0 {
+		return fmt.Errorf("no editor configured (set $EDITOR)")
+	}
+
+	args := append(fields[1:], path)
+	//nolint:gosec // G204: the editor is chosen by the user via $VISUAL/$EDITOR
+	cmd := exec.CommandContext(ctx, fields[0], args...)
+	cmd.Stdin = stdin
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
+
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("editor %q failed: %w", fields[0], err)
+	}
+
+	return nil
+}
+
+// outputEditResult reports the outcome of an edit
+func outputEditResult(stdout io.Writer, jsonMode bool, configPath string, changed bool) error {
+	message := "No changes made"
+	if changed {
+		message = fmt.Sprintf("Configuration saved to %s", configPath)
+	}
+
+	if jsonMode {
+		return outputJSON(stdout, Output{
+			Status: "success",
+			Data: map[string]interface{}{
+				"path":    configPath,
+				"changed": changed,
+			},
+			Message: message,
+		})
+	}
+
+	_, _ = fmt.Fprintln(stdout, message)
+	return nil
+}
@@ -0,0 +1,110 @@
+package config
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/steviee/go-mc/internal/state"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewEditCommand(t *testing.T) {
+	cmd := NewEditCommand()
+
+	assert.Equal(t, "edit", cmd.Use)
+	assert.NotEmpty(t, cmd.Short)
+	assert.NotEmpty(t, cmd.Long)
+	assert.NotEmpty(t, cmd.Example)
+}
+
+func TestEditorCommand(t *testing.T) {
+	t.Setenv("VISUAL", "")
+	t.Setenv("EDITOR", "")
+	assert.Equal(t, defaultEditor, editorCommand())
+
+	t.Setenv("EDITOR", "nano")
+	assert.Equal(t, "nano", editorCommand())
+
+	t.Setenv("VISUAL", "code --wait")
+	assert.Equal(t, "code --wait", editorCommand())
+}
+
+func TestRunEdit(t *testing.T) {
+	if _, err := os.Stat("/bin/sed"); err != nil {
+		t.Skip("sed not available")
+	}
+
+	tests := []struct {
+		name       string
+		editor     string
+		wantErr    string
+		wantMemory string
+		contains   string
+	}{
+		{
+			name:       "valid change is saved",
+			editor:     "sed -i s/memory:.2G/memory:\\x204G/",
+			wantMemory: "4G",
+			contains:   "Configuration saved",
+		},
+		{
+			name:       "no changes",
+			editor:     "true",
+			wantMemory: "2G",
+			contains:   "No changes made",
+		},
+		{
+			name:       "invalid change is refused",
+			editor:     "sed -i s/memory:.2G/memory:\\x20lots/",
+			wantErr:    "config not saved",
+			wantMemory: "2G",
+		},
+		{
+			name:       "syntax error is refused",
+			editor:     "sed -i s/^defaults:/defaults:\\x20[/",
+			wantErr:    "config not saved",
+			wantMemory: "2G",
+		},
+		{
+			name:       "editor failure",
+			editor:     "false",
+			wantErr:    "editor \"false\" failed",
+			wantMemory: "2G",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			configPath := setupConfigHome(t)
+			ctx := context.Background()
+
+			var stdout, stderr bytes.Buffer
+			err := runEdit(ctx, strings.NewReader(""), &stdout, &stderr, tt.editor)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+			} else {
+				require.NoError(t, err)
+				assert.Contains(t, stdout.String(), tt.contains)
+			}
+
+			cfg, err := state.LoadConfig(ctx)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantMemory, cfg.Defaults.Memory)
+
+			// Edited copies are only kept when validation fails
+			copies, err := filepath.Glob(filepath.Join(filepath.Dir(configPath), "config-edit-*.yaml"))
+			require.NoError(t, err)
+			if strings.Contains(tt.wantErr, "config not saved") {
+				assert.Len(t, copies, 1)
+			} else {
+				assert.Empty(t, copies)
+			}
+		})
+	}
+}
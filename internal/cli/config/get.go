@@ -0,0 +1,74 @@
+package config
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+	"github.com/steviee/go-mc/internal/state"
+)
+
+// NewGetCommand creates the config get subcommand
+func NewGetCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "get <key>",
+		Short: "Get a configuration value",
+		Long: `Print the effective value of a single configuration key.
+
+Keys are dotted paths that match the config file, for example
+defaults.memory or backups.keep_count. Values overridden by GOMC_*
+environment variables are reported as such.`,
+		Example: `  # Get the default memory for new servers
+  go-mc config get defaults.memory
+
+  # Get the TUI refresh interval as JSON
+  go-mc config get tui.refresh_interval --json`,
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completeKeys,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runGet(cmd.Context(), cmd.OutOrStdout(), args[0])
+		},
+	}
+
+	return cmd
+}
+
+// runGet executes the get command
+func runGet(ctx context.Context, stdout io.Writer, key string) error {
+	jsonMode := isJSONMode()
+
+	cfg, err := state.ResolveConfig(ctx)
+	if err != nil {
+		return outputError(stdout, jsonMode, err)
+	}
+
+	value, err := state.GetConfigValue(cfg, key)
+	if err != nil {
+		return outputError(stdout, jsonMode, err)
+	}
+
+	envVar, overridden := envOverrides()[key]
+
+	if jsonMode {
+		data := map[string]interface{}{
+			"key":   key,
+			"value": formatValue(value),
+		}
+		if overridden {
+			data["env"] = envVar
+		}
+		return outputJSON(stdout, Output{Status: "success", Data: data})
+	}
+
+	_, _ = fmt.Fprintln(stdout, formatValue(value))
+	return nil
+}
+
+// completeKeys provides shell completion for config keys
+func completeKeys(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	return state.ConfigKeys(), cobra.ShellCompDirectiveNoFileComp
+}
@@ -0,0 +1,84 @@
+package config
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewGetCommand(t *testing.T) {
+	cmd := NewGetCommand()
+
+	assert.Equal(t, "get <key>", cmd.Use)
+	assert.NotEmpty(t, cmd.Short)
+	assert.NotEmpty(t, cmd.Example)
+	assert.Error(t, cmd.Args(cmd, []string{}))
+	assert.NoError(t, cmd.Args(cmd, []string{"defaults.memory"}))
+}
+
+func TestRunGet(t *testing.T) {
+	setupConfigHome(t)
+
+	tests := []struct {
+		name    string
+		key     string
+		want    string
+		wantErr string
+	}{
+		{name: "string", key: "defaults.memory", want: "2G\n"},
+		{name: "int", key: "backups.keep_count", want: "5\n"},
+		{name: "duration", key: "tui.refresh_interval", want: "1s\n"},
+		{name: "unknown key", key: "defaults.nope", wantErr: "unknown config key"},
+		{name: "section", key: "defaults", wantErr: "unknown config key"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			err := runGet(context.Background(), &buf, tt.key)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, buf.String())
+		})
+	}
+}
+
+func TestRunGet_JSON(t *testing.T) {
+	setupConfigHome(t)
+	t.Setenv("GOMC_JSON", "true")
+	t.Setenv("GOMC_BACKUPS_KEEP_COUNT", "9")
+
+	var buf bytes.Buffer
+	require.NoError(t, runGet(context.Background(), &buf, "backups.keep_count"))
+
+	var out Output
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &out))
+	assert.Equal(t, "success", out.Status)
+
+	data, ok := out.Data.(map[string]interface{})
+	require.True(t, ok)
+	assert.Equal(t, "backups.keep_count", data["key"])
+	assert.Equal(t, float64(9), data["value"])
+	assert.Equal(t, "GOMC_BACKUPS_KEEP_COUNT", data["env"])
+}
+
+func TestRunGet_JSONError(t *testing.T) {
+	setupConfigHome(t)
+	t.Setenv("GOMC_JSON", "true")
+
+	var buf bytes.Buffer
+	require.Error(t, runGet(context.Background(), &buf, "nope"))
+
+	var out Output
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &out))
+	assert.Equal(t, "error", out.Status)
+	assert.Contains(t, out.Error, "unknown config key")
+}
@@ -0,0 +1,56 @@
+package config
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/steviee/go-mc/internal/state"
+)
+
+// NewPathCommand creates the config path subcommand
+func NewPathCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "path",
+		Short: "Show the configuration file path",
+		Long: `Print the path of the config file in use.
+
+The path honors the --config flag, GOMC_CONFIG and XDG_CONFIG_HOME.`,
+		Example: `  # Show configuration file path
+  go-mc config path
+
+  # Open the config directory
+  cd "$(dirname "$(go-mc config path)")"`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runPath(cmd.OutOrStdout())
+		},
+	}
+
+	return cmd
+}
+
+// runPath executes the path command
+func runPath(stdout io.Writer) error {
+	jsonMode := isJSONMode()
+
+	configPath, err := state.GetConfigPath()
+	if err != nil {
+		return outputError(stdout, jsonMode, err)
+	}
+
+	if jsonMode {
+		_, statErr := os.Stat(configPath)
+		return outputJSON(stdout, Output{
+			Status: "success",
+			Data: map[string]interface{}{
+				"path":   configPath,
+				"exists": statErr == nil,
+			},
+		})
+	}
+
+	_, _ = fmt.Fprintln(stdout, configPath)
+	return nil
+}
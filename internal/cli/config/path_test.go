@@ -0,0 +1,44 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewPathCommand(t *testing.T) {
+	cmd := NewPathCommand()
+
+	assert.Equal(t, "path", cmd.Use)
+	assert.NotEmpty(t, cmd.Short)
+	assert.NotEmpty(t, cmd.Example)
+}
+
+func TestRunPath(t *testing.T) {
+	configPath := setupConfigHome(t)
+
+	var buf bytes.Buffer
+	require.NoError(t, runPath(&buf))
+	assert.Equal(t, configPath+"\n", buf.String())
+}
+
+func TestRunPath_ConfigEnv(t *testing.T) {
+	setupConfigHome(t)
+	custom := filepath.Join(t.TempDir(), "custom.yaml")
+	t.Setenv("GOMC_CONFIG", custom)
+	t.Setenv("GOMC_JSON", "true")
+
+	var buf bytes.Buffer
+	require.NoError(t, runPath(&buf))
+
+	var out Output
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &out))
+	data, ok := out.Data.(map[string]interface{})
+	require.True(t, ok)
+	assert.Equal(t, custom, data["path"])
+	assert.Equal(t, false, data["exists"])
+}
@@ -0,0 +1,130 @@
+package config
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/steviee/go-mc/internal/state"
+)
+
+// ResetFlags holds all flags for the reset command
+type ResetFlags struct {
+	Force bool
+}
+
+// NewResetCommand creates the config reset subcommand
+func NewResetCommand() *cobra.Command {
+	flags := &ResetFlags{}
+
+	cmd := &cobra.Command{
+		Use:   "reset [key...]",
+		Short: "Reset configuration to defaults",
+		Long: `Reset configuration values to their built-in defaults.
+
+With one or more keys, only those keys are reset. Without arguments the
+whole config file is replaced by the defaults after confirmation.`,
+		Example: `  # Reset a single key
+  go-mc config reset defaults.memory
+
+  # Reset the whole configuration
+  go-mc config reset
+
+  # Reset without confirmation
+  go-mc config reset --force`,
+		ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+			return state.ConfigKeys(), cobra.ShellCompDirectiveNoFileComp
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runReset(cmd.Context(), cmd.OutOrStdout(), cmd.InOrStdin(), args, flags)
+		},
+	}
+
+	cmd.Flags().BoolVarP(&flags.Force, "force", "f", false, "Skip confirmation prompts")
+
+	return cmd
+}
+
+// runReset executes the reset command
+func runReset(ctx context.Context, stdout io.Writer, stdin io.Reader, keys []string, flags *ResetFlags) error {
+	jsonMode := isJSONMode()
+
+	var cfg *state.Config
+	if len(keys) == 0 {
+		// Confirmation prompt (unless --force or --json)
+		if !flags.Force && !jsonMode {
+			confirmed, err := confirmReset(stdin, stdout)
+			if err != nil {
+				return outputError(stdout, jsonMode, err)
+			}
+			if !confirmed {
+				_, _ = fmt.Fprintln(stdout, "Reset cancelled")
+				return nil
+			}
+		}
+
+		cfg = state.DefaultConfig()
+	} else {
+		var err error
+		cfg, err = state.LoadConfig(ctx)
+		if err != nil {
+			return outputError(stdout, jsonMode, err)
+		}
+
+		for _, key := range keys {
+			if err := state.ResetConfigValue(cfg, key); err != nil {
+				return outputError(stdout, jsonMode, err)
+			}
+		}
+	}
+
+	if err := state.SaveConfig(ctx, cfg); err != nil {
+		return outputError(stdout, jsonMode, err)
+	}
+
+	message := "Configuration reset to defaults"
+	if len(keys) > 0 {
+		message = fmt.Sprintf("Reset %s to default", strings.Join(keys, ", "))
+	}
+
+	if jsonMode {
+		data := map[string]interface{}{}
+		if len(keys) > 0 {
+			values := make(map[string]interface{}, len(keys))
+			for _, key := range keys {
+				value, err := state.GetConfigValue(cfg, key)
+				if err != nil {
+					return outputError(stdout, jsonMode, err)
+				}
+				values[key] = formatValue(value)
+			}
+			data["reset"] = values
+		}
+		return outputJSON(stdout, Output{Status: "success", Data: data, Message: message})
+	}
+
+	_, _ = fmt.Fprintln(stdout, message)
+	return nil
+}
+
+// confirmReset prompts the user for confirmation before a full reset
+func confirmReset(stdin io.Reader, stdout io.Writer) (bool, error) {
+	configPath, err := state.GetConfigPath()
+	if err != nil {
+		return false, err
+	}
+
+	_, _ = fmt.Fprintf(stdout, "This will replace %s with the default configuration.\n", configPath)
+	_, _ = fmt.Fprint(stdout, "Continue? [y/N]: ")
+
+	scanner := bufio.NewScanner(stdin)
+	if !scanner.Scan() {
+		return false, fmt.Errorf("failed to read confirmation")
+	}
+
+	response := strings.ToLower(strings.TrimSpace(scanner.Text()))
+	return response == "y" || response == "yes", nil
+}
@@ -0,0 +1,99 @@
+package config
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/steviee/go-mc/internal/state"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewResetCommand(t *testing.T) {
+	cmd := NewResetCommand()
+
+	assert.Equal(t, "reset [key...]", cmd.Use)
+	assert.NotEmpty(t, cmd.Short)
+	assert.NotEmpty(t, cmd.Example)
+	assert.NotNil(t, cmd.Flags().Lookup("force"))
+}
+
+// customizeConfig saves a config that differs from the defaults
+func customizeConfig(t *testing.T) {
+	t.Helper()
+
+	cfg := state.DefaultConfig()
+	cfg.Defaults.Memory = "8G"
+	cfg.Backups.KeepCount = 10
+	require.NoError(t, state.SaveConfig(context.Background(), cfg))
+}
+
+func TestRunReset(t *testing.T) {
+	tests := []struct {
+		name       string
+		keys       []string
+		force      bool
+		input      string
+		wantMemory string
+		wantKeep   int
+		contains   string
+	}{
+		{
+			name:       "single key",
+			keys:       []string{"defaults.memory"},
+			wantMemory: "2G",
+			wantKeep:   10,
+			contains:   "Reset defaults.memory to default",
+		},
+		{
+			name:       "full reset confirmed",
+			input:      "y\n",
+			wantMemory: "2G",
+			wantKeep:   5,
+			contains:   "Configuration reset to defaults",
+		},
+		{
+			name:       "full reset cancelled",
+			input:      "n\n",
+			wantMemory: "8G",
+			wantKeep:   10,
+			contains:   "Reset cancelled",
+		},
+		{
+			name:       "full reset forced",
+			force:      true,
+			wantMemory: "2G",
+			wantKeep:   5,
+			contains:   "Configuration reset to defaults",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setupConfigHome(t)
+			customizeConfig(t)
+			ctx := context.Background()
+
+			var buf bytes.Buffer
+			err := runReset(ctx, &buf, strings.NewReader(tt.input), tt.keys, &ResetFlags{Force: tt.force})
+			require.NoError(t, err)
+			assert.Contains(t, buf.String(), tt.contains)
+
+			cfg, err := state.LoadConfig(ctx)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantMemory, cfg.Defaults.Memory)
+			assert.Equal(t, tt.wantKeep, cfg.Backups.KeepCount)
+		})
+	}
+}
+
+func TestRunReset_UnknownKey(t *testing.T) {
+	setupConfigHome(t)
+
+	var buf bytes.Buffer
+	err := runReset(context.Background(), &buf, strings.NewReader(""), []string{"nope.nope"}, &ResetFlags{})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unknown config key")
+}
@@ -0,0 +1,93 @@
+package config
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+	"github.com/steviee/go-mc/internal/state"
+)
+
+// NewSetCommand creates the config set subcommand
+func NewSetCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "set <key> <value>",
+		Short: "Set a configuration value",
+		Long: `Set a single configuration key in the config file.
+
+Values are parsed according to the type of the key:
+  - durations use Go syntax (500ms, 2s, 1m)
+  - integers and booleans (true/false) are parsed strictly
+  - memory sizes are normalized (4g, 4GB and 4G are all stored as 4G)
+
+The resulting configuration is validated before it is saved; an invalid
+value leaves the config file unchanged.`,
+		Example: `  # Set the default memory for new servers
+  go-mc config set defaults.memory 4G
+
+  # Keep ten backups per server
+  go-mc config set backups.keep_count 10
+
+  # Refresh the dashboard twice per second
+  go-mc config set tui.refresh_interval 500ms`,
+		Args:              cobra.ExactArgs(2),
+		ValidArgsFunction: completeKeys,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runSet(cmd.Context(), cmd.OutOrStdout(), args[0], args[1])
+		},
+	}
+
+	return cmd
+}
+
+// runSet executes the set command
+func runSet(ctx context.Context, stdout io.Writer, key, value string) error {
+	jsonMode := isJSONMode()
+
+	cfg, err := state.LoadConfig(ctx)
+	if err != nil {
+		return outputError(stdout, jsonMode, err)
+	}
+
+	if err := state.SetConfigValue(cfg, key, value); err != nil {
+		return outputError(stdout, jsonMode, err)
+	}
+
+	if err := state.ValidateConfig(cfg); err != nil {
+		return outputError(stdout, jsonMode, fmt.Errorf("invalid value for %s: %w", key, err))
+	}
+
+	if err := state.SaveConfig(ctx, cfg); err != nil {
+		return outputError(stdout, jsonMode, err)
+	}
+
+	newValue, err := state.GetConfigValue(cfg, key)
+	if err != nil {
+		return outputError(stdout, jsonMode, err)
+	}
+
+	envVar, overridden := envOverrides()[key]
+
+	if jsonMode {
+		data := map[string]interface{}{
+			"key":   key,
+			"value": formatValue(newValue),
+		}
+		if overridden {
+			data["env"] = envVar
+		}
+		return outputJSON(stdout, Output{
+			Status:  "success",
+			Data:    data,
+			Message: fmt.Sprintf("Set %s", key),
+		})
+	}
+
+	_, _ = fmt.Fprintf(stdout, "Set %s = %v\n", key, formatValue(newValue))
+	if overridden {
+		_, _ = fmt.Fprintf(stdout, "Note: %s is set and overrides this value\n", envVar)
+	}
+
+	return nil
+}
@@ -0,0 +1,94 @@
+package config
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/steviee/go-mc/internal/state"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewSetCommand(t *testing.T) {
+	cmd := NewSetCommand()
+
+	assert.Equal(t, "set <key> <value>", cmd.Use)
+	assert.NotEmpty(t, cmd.Short)
+	assert.NotEmpty(t, cmd.Long)
+	assert.NotEmpty(t, cmd.Example)
+	assert.Error(t, cmd.Args(cmd, []string{"defaults.memory"}))
+}
+
+func TestRunSet(t *testing.T) {
+	tests := []struct {
+		name    string
+		key     string
+		value   string
+		check   func(t *testing.T, cfg *state.Config)
+		wantErr string
+	}{
+		{
+			name:  "memory is normalized",
+			key:   "defaults.memory",
+			value: "4gb",
+			check: func(t *testing.T, cfg *state.Config) { assert.Equal(t, "4G", cfg.Defaults.Memory) },
+		},
+		{
+			name:  "int",
+			key:   "backups.keep_count",
+			value: "10",
+			check: func(t *testing.T, cfg *state.Config) { assert.Equal(t, 10, cfg.Backups.KeepCount) },
+		},
+		{
+			name:  "duration",
+			key:   "tui.refresh_interval",
+			value: "500ms",
+			check: func(t *testing.T, cfg *state.Config) { assert.Equal(t, 500*time.Millisecond, cfg.TUI.RefreshInterval) },
+		},
+		{
+			name:  "bool",
+			key:   "mods.auto_resolve_dependencies",
+			value: "false",
+			check: func(t *testing.T, cfg *state.Config) { assert.False(t, cfg.Mods.AutoResolveDependencies) },
+		},
+		{name: "invalid memory", key: "defaults.memory", value: "lots", wantErr: "invalid memory size"},
+		{name: "invalid int", key: "backups.keep_count", value: "ten", wantErr: "invalid integer"},
+		{name: "fails validation", key: "tui.refresh_interval", value: "10ms", wantErr: "refresh interval"},
+		{name: "unknown key", key: "defaults.colour", value: "red", wantErr: "unknown config key"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setupConfigHome(t)
+			ctx := context.Background()
+
+			var buf bytes.Buffer
+			err := runSet(ctx, &buf, tt.key, tt.value)
+
+			cfg, loadErr := state.LoadConfig(ctx)
+			require.NoError(t, loadErr)
+
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				// The config file must be unchanged
+				assert.Equal(t, state.DefaultConfig(), cfg)
+				return
+			}
+			require.NoError(t, err)
+			assert.Contains(t, buf.String(), "Set "+tt.key)
+			tt.check(t, cfg)
+		})
+	}
+}
+
+func TestRunSet_EnvOverrideNote(t *testing.T) {
+	setupConfigHome(t)
+	t.Setenv("GOMC_DEFAULTS_MEMORY", "8G")
+
+	var buf bytes.Buffer
+	require.NoError(t, runSet(context.Background(), &buf, "defaults.memory", "4G"))
+	assert.Contains(t, buf.String(), "GOMC_DEFAULTS_MEMORY is set")
+}
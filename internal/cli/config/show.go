@@ -0,0 +1,93 @@
+package config
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/spf13/cobra"
+	"github.com/steviee/go-mc/internal/state"
+	"gopkg.in/yaml.v3"
+)
+
+// NewShowCommand creates the config show subcommand
+func NewShowCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "show",
+		Short: "Show the current configuration",
+		Long: `Show the effective configuration as YAML.
+
+The effective configuration is the config file on top of the built-in
+defaults, with GOMC_* environment variables applied over it. Values that
+are overridden by the environment are listed after the configuration.`,
+		Example: `  # Show configuration
+  go-mc config show
+
+  # JSON output
+  go-mc config show --json`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runShow(cmd.Context(), cmd.OutOrStdout())
+		},
+	}
+
+	return cmd
+}
+
+// runShow executes the show command
+func runShow(ctx context.Context, stdout io.Writer) error {
+	jsonMode := isJSONMode()
+
+	cfg, err := state.ResolveConfig(ctx)
+	if err != nil {
+		return outputError(stdout, jsonMode, err)
+	}
+
+	configPath, err := state.GetConfigPath()
+	if err != nil {
+		return outputError(stdout, jsonMode, err)
+	}
+
+	overrides := envOverrides()
+
+	if jsonMode {
+		values, err := configToMap(cfg)
+		if err != nil {
+			return outputError(stdout, jsonMode, err)
+		}
+
+		return outputJSON(stdout, Output{
+			Status: "success",
+			Data: map[string]interface{}{
+				"path":      configPath,
+				"config":    values,
+				"overrides": overrides,
+			},
+		})
+	}
+
+	data, err := yaml.Marshal(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to marshal config: %w", err)
+	}
+
+	_, _ = fmt.Fprintf(stdout, "# %s\n", configPath)
+	_, _ = fmt.Fprint(stdout, string(data))
+
+	if len(overrides) > 0 {
+		keys := make([]string, 0, len(overrides))
+		for key := range overrides {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		_, _ = fmt.Fprintln(stdout)
+		_, _ = fmt.Fprintln(stdout, "# Overridden by environment:")
+		for _, key := range keys {
+			_, _ = fmt.Fprintf(stdout, "#   %s (%s)\n", key, overrides[key])
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,55 @@
+package config
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewShowCommand(t *testing.T) {
+	cmd := NewShowCommand()
+
+	assert.Equal(t, "show", cmd.Use)
+	assert.NotEmpty(t, cmd.Short)
+	assert.NotEmpty(t, cmd.Long)
+	assert.NotEmpty(t, cmd.Example)
+}
+
+func TestRunShow(t *testing.T) {
+	setupConfigHome(t)
+	t.Setenv("GOMC_DEFAULTS_MEMORY", "8G")
+
+	t.Run("human output", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, runShow(context.Background(), &buf))
+
+		output := buf.String()
+		assert.Contains(t, output, "memory: 8G")
+		assert.Contains(t, output, "refresh_interval: 1s")
+		assert.Contains(t, output, "defaults.memory (GOMC_DEFAULTS_MEMORY)")
+	})
+
+	t.Run("JSON output", func(t *testing.T) {
+		t.Setenv("GOMC_JSON", "true")
+
+		var buf bytes.Buffer
+		require.NoError(t, runShow(context.Background(), &buf))
+
+		var out struct {
+			Status string `json:"status"`
+			Data   struct {
+				Config    map[string]map[string]interface{} `json:"config"`
+				Overrides map[string]string                 `json:"overrides"`
+			} `json:"data"`
+		}
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &out))
+		assert.Equal(t, "success", out.Status)
+		assert.Equal(t, "8G", out.Data.Config["defaults"]["memory"])
+		assert.Equal(t, "1s", out.Data.Config["tui"]["refresh_interval"])
+		assert.Equal(t, "GOMC_DEFAULTS_MEMORY", out.Data.Overrides["defaults.memory"])
+	})
+}
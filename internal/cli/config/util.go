@@ -0,0 +1,79 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/steviee/go-mc/internal/state"
+	"gopkg.in/yaml.v3"
+)
+
+// Output is the JSON envelope used by all config subcommands
+type Output struct {
+	Status  string      `json:"status"`
+	Data    interface{} `json:"data,omitempty"`
+	Message string      `json:"message,omitempty"`
+	Error   string      `json:"error,omitempty"`
+}
+
+// isJSONMode checks if JSON output mode is enabled
+func isJSONMode() bool {
+	return os.Getenv("GOMC_JSON") == "true"
+}
+
+// outputError writes an error in JSON mode and returns it
+func outputError(w io.Writer, jsonMode bool, err error) error {
+	if jsonMode {
+		out := Output{
+			Status: "error",
+			Error:  err.Error(),
+		}
+		_ = outputJSON(w, out)
+	}
+	return err
+}
+
+// outputJSON writes v as indented JSON
+func outputJSON(w io.Writer, v interface{}) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
+
+// configToMap converts a config to nested maps keyed by the YAML names,
+// so JSON output uses the same keys as the config file
+func configToMap(cfg *state.Config) (map[string]interface{}, error) {
+	data, err := yaml.Marshal(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal config: %w", err)
+	}
+
+	var m map[string]interface{}
+	if err := yaml.Unmarshal(data, &m); err != nil {
+		return nil, fmt.Errorf("failed to convert config: %w", err)
+	}
+	return m, nil
+}
+
+// formatValue renders a config value the way it is written in the config file
+func formatValue(value interface{}) interface{} {
+	if d, ok := value.(time.Duration); ok {
+		return d.String()
+	}
+	return value
+}
+
+// envOverrides returns the keys that are overridden by GOMC_* environment variables
+func envOverrides() map[string]string {
+	overrides := make(map[string]string)
+	for _, key := range state.ConfigKeys() {
+		envVar := state.ConfigEnvVar(key)
+		if _, ok := os.LookupEnv(envVar); ok {
+			overrides[key] = envVar
+		}
+	}
+	return overrides
+}
@@ -0,0 +1,100 @@
+package config
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/steviee/go-mc/internal/state"
+)
+
+// NewValidateCommand creates the config validate subcommand
+func NewValidateCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "validate [file]",
+		Short: "Validate a configuration file",
+		Long: `Parse and validate a config file without changing it.
+
+Without arguments the config file in use is validated. GOMC_* environment
+overrides are checked as well, since they are applied to every command.`,
+		Example: `  # Validate the current configuration
+  go-mc config validate
+
+  # Validate another file before installing it
+  go-mc config validate ./config.yaml`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			path := ""
+			if len(args) > 0 {
+				path = args[0]
+			}
+			return runValidate(cmd.OutOrStdout(), path)
+		},
+	}
+
+	return cmd
+}
+
+// runValidate executes the validate command
+func runValidate(stdout io.Writer, path string) error {
+	jsonMode := isJSONMode()
+
+	if path == "" {
+		configPath, err := state.GetConfigPath()
+		if err != nil {
+			return outputError(stdout, jsonMode, err)
+		}
+		path = configPath
+	} else if _, err := os.Stat(path); err != nil {
+		// An explicitly named file must exist
+		return outputError(stdout, jsonMode, fmt.Errorf("failed to read config file: %w", err))
+	}
+
+	if err := validateFile(path); err != nil {
+		return outputError(stdout, jsonMode, fmt.Errorf("%s: %w", path, err))
+	}
+
+	if jsonMode {
+		return outputJSON(stdout, Output{
+			Status: "success",
+			Data: map[string]interface{}{
+				"path":  path,
+				"valid": true,
+			},
+			Message: "Configuration is valid",
+		})
+	}
+
+	_, _ = fmt.Fprintf(stdout, "%s: configuration is valid\n", path)
+	return nil
+}
+
+// validateFile parses and validates a config file together with the
+// environment overrides. A missing file is valid, as the defaults apply.
+func validateFile(path string) error {
+	cfg := state.DefaultConfig()
+
+	//nolint:gosec // G304: validating a user-chosen file is the purpose of this command
+	data, err := os.ReadFile(path)
+	switch {
+	case err == nil:
+		parsed, err := state.ParseConfig(data)
+		if err != nil {
+			return err
+		}
+		cfg = parsed
+	case !os.IsNotExist(err):
+		return fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	if err := state.ApplyEnvOverrides(cfg); err != nil {
+		return fmt.Errorf("invalid environment override: %w", err)
+	}
+
+	if err := state.ValidateConfig(cfg); err != nil {
+		return fmt.Errorf("invalid config with environment overrides: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,74 @@
+package config
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewValidateCommand(t *testing.T) {
+	cmd := NewValidateCommand()
+
+	assert.Equal(t, "validate [file]", cmd.Use)
+	assert.NotEmpty(t, cmd.Short)
+	assert.NotEmpty(t, cmd.Example)
+	assert.Error(t, cmd.Args(cmd, []string{"a", "b"}))
+}
+
+func TestRunValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		env     map[string]string
+		wantErr string
+	}{
+		{name: "partial file", content: "defaults:\n  memory: 4G\n"},
+		{name: "invalid memory", content: "defaults:\n  memory: lots\n", wantErr: "invalid default memory"},
+		{name: "invalid yaml", content: "defaults: [\n", wantErr: "failed to parse config"},
+		{name: "invalid duration", content: "tui:\n  refresh_interval: soon\n", wantErr: "failed to parse config"},
+		{
+			name:    "invalid environment override",
+			content: "defaults:\n  memory: 4G\n",
+			env:     map[string]string{"GOMC_BACKUPS_KEEP_COUNT": "many"},
+			wantErr: "invalid environment override",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setupConfigHome(t)
+			for k, v := range tt.env {
+				t.Setenv(k, v)
+			}
+
+			path := filepath.Join(t.TempDir(), "config.yaml")
+			require.NoError(t, os.WriteFile(path, []byte(tt.content), 0600))
+
+			var buf bytes.Buffer
+			err := runValidate(&buf, path)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Contains(t, buf.String(), "configuration is valid")
+		})
+	}
+}
+
+func TestRunValidate_MissingFile(t *testing.T) {
+	setupConfigHome(t)
+
+	// The default config file may be missing; defaults apply
+	var buf bytes.Buffer
+	require.NoError(t, runValidate(&buf, ""))
+
+	// An explicitly named file must exist
+	err := runValidate(&buf, filepath.Join(t.TempDir(), "missing.yaml"))
+	require.Error(t, err)
+}
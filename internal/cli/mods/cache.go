@@ -0,0 +1,274 @@
+package mods
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"text/tabwriter"
+
+	"github.com/docker/go-units"
+	"github.com/spf13/cobra"
+	"github.com/steviee/go-mc/internal/mods"
+	"github.com/steviee/go-mc/internal/state"
+)
+
+// CacheFlags holds all flags for the cache prune command
+type CacheFlags struct {
+	MaxSize        string
+	KeepReferenced bool
+}
+
+// CacheOutput holds the output for JSON mode
+type CacheOutput struct {
+	Status    string            `json:"status"`
+	Dir       string            `json:"dir,omitempty"`
+	Entries   []mods.CacheEntry `json:"entries,omitempty"`
+	Removed   []mods.CacheEntry `json:"removed,omitempty"`
+	Count     int               `json:"count"`
+	SizeBytes int64             `json:"size_bytes"`
+	Message   string            `json:"message,omitempty"`
+	Error     string            `json:"error,omitempty"`
+}
+
+// NewCacheCommand creates the mods cache command group
+func NewCacheCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "cache",
+		Short: "Manage the shared mod download cache",
+		Long: `Manage the cache of downloaded mod jars shared by all servers.
+
+Jars are stored in mods.cache_dir (default ~/.cache/go-mc/mods/) under their
+SHA-512 hash. Installing or updating a mod takes the jar from the cache when
+it is there and downloads it from Modrinth otherwise. Servers get hardlinks to
+cached jars where the cache and the server share a filesystem, and copies
+(reflinks on filesystems that support them) elsewhere.`,
+		Example: `  # List cached jars, least recently used first
+  go-mc mods cache ls
+
+  # Show how much space the cache takes
+  go-mc mods cache size
+
+  # Shrink the cache to 500 MB, keeping jars any server uses
+  go-mc mods cache prune --max-size 500M --keep-referenced`,
+	}
+
+	cmd.AddCommand(newCacheListCommand())
+	cmd.AddCommand(newCacheSizeCommand())
+	cmd.AddCommand(newCachePruneCommand())
+
+	return cmd
+}
+
+// newCacheListCommand creates the mods cache ls command
+func newCacheListCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:     "ls",
+		Aliases: []string{"list"},
+		Short:   "List cached mod jars",
+		Long: `List the jars in the mod cache, least recently used first.
+
+Jars installed on any server are marked as used and shown with their filename.`,
+		Example: `  go-mc mods cache ls
+  go-mc mods cache ls --json`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cache, err := resolveCache(cmd.Context())
+			if err != nil {
+				return outputCacheError(cmd.OutOrStdout(), isJSONMode(), err)
+			}
+			return runCacheList(cmd.Context(), cmd.OutOrStdout(), cache)
+		},
+	}
+}
+
+// newCacheSizeCommand creates the mods cache size command
+func newCacheSizeCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:     "size",
+		Short:   "Show the size of the mod cache",
+		Example: `  go-mc mods cache size`,
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cache, err := resolveCache(cmd.Context())
+			if err != nil {
+				return outputCacheError(cmd.OutOrStdout(), isJSONMode(), err)
+			}
+			return runCacheSize(cmd.Context(), cmd.OutOrStdout(), cache)
+		},
+	}
+}
+
+// newCachePruneCommand creates the mods cache prune command
+func newCachePruneCommand() *cobra.Command {
+	flags := &CacheFlags{}
+
+	cmd := &cobra.Command{
+		Use:   "prune",
+		Short: "Remove least recently used jars from the mod cache",
+		Long: `Remove the least recently used jars from the mod cache until it is no
+larger than --max-size. Without --max-size the cache is emptied.
+
+With --keep-referenced, jars installed on any server are kept even if the
+cache stays larger than --max-size. Pruning never touches the mods
+directories of servers.`,
+		Example: `  # Empty the cache
+  go-mc mods cache prune
+
+  # Remove jars no server uses
+  go-mc mods cache prune --keep-referenced
+
+  # Shrink the cache to 500 MB
+  go-mc mods cache prune --max-size 500M`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cache, err := resolveCache(cmd.Context())
+			if err != nil {
+				return outputCacheError(cmd.OutOrStdout(), isJSONMode(), err)
+			}
+			return runCachePrune(cmd.Context(), cmd.OutOrStdout(), cache, flags)
+		},
+	}
+
+	cmd.Flags().StringVar(&flags.MaxSize, "max-size", "", "Size to shrink the cache to, e.g. 500M (default: empty it)")
+	cmd.Flags().BoolVar(&flags.KeepReferenced, "keep-referenced", false, "Keep jars installed on any server")
+
+	return cmd
+}
+
+// resolveCache returns the mod cache of the effective config
+func resolveCache(ctx context.Context) (*mods.Cache, error) {
+	cfg, err := state.ResolveConfig(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return mods.NewCacheFromConfig(cfg)
+}
+
+// runCacheList executes the cache ls command
+func runCacheList(ctx context.Context, stdout io.Writer, cache *mods.Cache) error {
+	jsonMode := isJSONMode()
+
+	entries, err := cache.List(ctx)
+	if err != nil {
+		return outputCacheError(stdout, jsonMode, err)
+	}
+
+	if jsonMode {
+		return json.NewEncoder(stdout).Encode(CacheOutput{
+			Status:    "success",
+			Dir:       cache.Dir(),
+			Entries:   entries,
+			Count:     len(entries),
+			SizeBytes: totalCacheSize(entries),
+		})
+	}
+
+	if len(entries) == 0 {
+		_, _ = fmt.Fprintf(stdout, "Mod cache is empty (%s)\n", cache.Dir())
+		return nil
+	}
+
+	w := tabwriter.NewWriter(stdout, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(w, "SHA512\tSIZE\tLAST USED\tIN USE AS")
+	for _, e := range entries {
+		used := "-"
+		if e.Referenced {
+			used = e.Filename
+		}
+		_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\n",
+			e.SHA512[:12],
+			units.BytesSize(float64(e.Size)),
+			e.LastUsed.Format("2006-01-02 15:04"),
+			used)
+	}
+	_ = w.Flush()
+
+	_, _ = fmt.Fprintf(stdout, "\nTotal: %d jar(s), %s in %s\n",
+		len(entries), units.BytesSize(float64(totalCacheSize(entries))), cache.Dir())
+
+	return nil
+}
+
+// runCacheSize executes the cache size command
+func runCacheSize(ctx context.Context, stdout io.Writer, cache *mods.Cache) error {
+	jsonMode := isJSONMode()
+
+	count, size, err := cache.Size(ctx)
+	if err != nil {
+		return outputCacheError(stdout, jsonMode, err)
+	}
+
+	if jsonMode {
+		return json.NewEncoder(stdout).Encode(CacheOutput{
+			Status:    "success",
+			Dir:       cache.Dir(),
+			Count:     count,
+			SizeBytes: size,
+		})
+	}
+
+	_, _ = fmt.Fprintf(stdout, "%s in %d jar(s) (%s)\n", units.BytesSize(float64(size)), count, cache.Dir())
+	return nil
+}
+
+// runCachePrune executes the cache prune command
+func runCachePrune(ctx context.Context, stdout io.Writer, cache *mods.Cache, flags *CacheFlags) error {
+	jsonMode := isJSONMode()
+
+	var maxSize int64
+	if flags.MaxSize != "" {
+		size, err := units.RAMInBytes(flags.MaxSize)
+		if err != nil || size < 0 {
+			return outputCacheError(stdout, jsonMode, fmt.Errorf("invalid --max-size %q: use a size like 500M or 2G", flags.MaxSize))
+		}
+		maxSize = size
+	}
+
+	removed, err := cache.Prune(ctx, maxSize, flags.KeepReferenced)
+	if err != nil {
+		return outputCacheError(stdout, jsonMode, err)
+	}
+
+	count, size, err := cache.Size(ctx)
+	if err != nil {
+		return outputCacheError(stdout, jsonMode, err)
+	}
+
+	freed := totalCacheSize(removed)
+	if jsonMode {
+		return json.NewEncoder(stdout).Encode(CacheOutput{
+			Status:    "success",
+			Dir:       cache.Dir(),
+			Removed:   removed,
+			Count:     count,
+			SizeBytes: size,
+			Message:   fmt.Sprintf("Removed %d jar(s), freed %s", len(removed), units.BytesSize(float64(freed))),
+		})
+	}
+
+	_, _ = fmt.Fprintf(stdout, "Removed %d jar(s), freed %s\n", len(removed), units.BytesSize(float64(freed)))
+	_, _ = fmt.Fprintf(stdout, "Cache: %s in %d jar(s)\n", units.BytesSize(float64(size)), count)
+	return nil
+}
+
+// totalCacheSize returns the total size of cache entries
+func totalCacheSize(entries []mods.CacheEntry) int64 {
+	var total int64
+	for _, e := range entries {
+		total += e.Size
+	}
+	return total
+}
+
+// outputCacheError outputs an error message
+func outputCacheError(stdout io.Writer, jsonMode bool, err error) error {
+	if jsonMode {
+		output := CacheOutput{
+			Status: "error",
+			Error:  err.Error(),
+		}
+		_ = json.NewEncoder(stdout).Encode(output)
+	}
+	return err
+}
@@ -0,0 +1,60 @@
+package mods
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/steviee/go-mc/internal/mods"
+	"github.com/steviee/go-mc/internal/state"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newCacheWithJars creates a cache holding jars with the given contents
+func newCacheWithJars(t *testing.T, contents ...string) *mods.Cache {
+	t.Helper()
+
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	require.NoError(t, state.InitDirs())
+
+	cache, err := mods.NewCache(t.TempDir())
+	require.NoError(t, err)
+
+	for _, content := range contents {
+		src := filepath.Join(t.TempDir(), "mod.jar")
+		require.NoError(t, os.WriteFile(src, []byte(content), 0600))
+		require.NoError(t, cache.Add(src, sha512Of(content)))
+	}
+	return cache
+}
+
+func TestRunCacheList(t *testing.T) {
+	cache := newCacheWithJars(t, "lithium", "sodium")
+	t.Setenv("GOMC_JSON", "true")
+
+	var stdout bytes.Buffer
+	require.NoError(t, runCacheList(context.Background(), &stdout, cache))
+
+	var output CacheOutput
+	require.NoError(t, json.Unmarshal(stdout.Bytes(), &output))
+	assert.Equal(t, "success", output.Status)
+	assert.Equal(t, 2, output.Count)
+	assert.Equal(t, int64(len("lithium")+len("sodium")), output.SizeBytes)
+}
+
+func TestRunCachePrune(t *testing.T) {
+	cache := newCacheWithJars(t, "lithium", "sodium")
+	t.Setenv("GOMC_JSON", "")
+
+	var stdout bytes.Buffer
+	require.NoError(t, runCachePrune(context.Background(), &stdout, cache, &CacheFlags{}))
+	assert.Contains(t, stdout.String(), "Removed 2 jar(s), freed 13B")
+	assert.Contains(t, stdout.String(), "Cache: 0B in 0 jar(s)")
+
+	err := runCachePrune(context.Background(), &stdout, cache, &CacheFlags{MaxSize: "lots"})
+	assert.ErrorContains(t, err, "invalid --max-size")
+}
@@ -3,50 +3,88 @@ package mods
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"strings"
 
 	"github.com/spf13/cobra"
 	"github.com/steviee/go-mc/internal/mods"
 	"github.com/steviee/go-mc/internal/state"
 )
 
+// InstallFlags holds all flags for the install command
+type InstallFlags struct {
+	Force bool
+}
+
 // InstallOutput holds the output for JSON mode
 type InstallOutput struct {
-	Status    string   `json:"status"`
-	Installed []string `json:"installed,omitempty"`
-	Message   string   `json:"message,omitempty"`
-	Error     string   `json:"error,omitempty"`
+	Status      string            `json:"status"`
+	Installed   []string          `json:"installed,omitempty"`
+	Suggestions []mods.Suggestion `json:"suggestions,omitempty"`
+	Conflicts   []mods.Conflict   `json:"conflicts,omitempty"`
+	Ports       *PortsOutput      `json:"ports,omitempty"`
+	Message     string            `json:"message,omitempty"`
+	Error       string            `json:"error,omitempty"`
 }
 
 // NewInstallCommand creates the mods install subcommand
 func NewInstallCommand() *cobra.Command {
+	flags := &InstallFlags{}
+
 	cmd := &cobra.Command{
 		Use:   "install <server> <mod-slug...>",
 		Short: "Install mods on a server",
 		Long: `Install one or more mods on an existing server from Modrinth.
 
-Dependencies are automatically resolved and installed. If a mod is already
-installed, it will be skipped. The server must be stopped before installing mods.`,
+Any Modrinth project can be installed by slug or project ID; the latest
+version compatible with the server's Minecraft version is picked.
+Dependencies are automatically resolved and installed unless
+mods.auto_resolve_dependencies is disabled in the config. If a mod is already
+installed, it will be skipped. The server must be stopped before installing mods.
+
+Before anything is downloaded, the mods to install and the installed mods are
+checked against each other: a mod that declares another incompatible, or that
+requires a version of a mod other than the one installed or required by
+another mod, stops the install with an explanation of which mod declares the
+conflict. Mods that Modrinth marks as client-side only (server_side:
+unsupported), such as Sodium or Iris, are refused too, since they crash a
+dedicated server or do nothing on it. Use --force to install anyway.
+Optional dependencies that are not installed are listed as suggestions.
+
+Mods that need a port (e.g. simple-voice-chat, geyser, bluemap) get one
+allocated and published by the server's container. A stopped container is
+recreated right away; a running server publishes the port on its next
+start or restart.`,
 		Example: `  # Install a single mod
   go-mc mods install myserver fabric-api
 
   # Install multiple mods at once
-  go-mc mods install myserver lithium sodium phosphor
+  go-mc mods install myserver lithium ferritecore simple-voice-chat
 
   # Install with JSON output
-  go-mc mods install myserver fabric-api --json`,
+  go-mc mods install myserver fabric-api --json
+
+  # Install despite conflicts
+  go-mc mods install myserver optifabric --force`,
 		Args: cobra.MinimumNArgs(2),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runInstall(cmd.Context(), cmd.OutOrStdout(), args[0], args[1:])
+			cfg, err := state.ResolveConfig(cmd.Context())
+			if err != nil {
+				return err
+			}
+			return runInstall(cmd.Context(), cmd.OutOrStdout(), args[0], args[1:], flags, cfg)
 		},
 	}
 
+	cmd.Flags().BoolVar(&flags.Force, "force", false, "Install even if mods conflict or are client-side only")
+
 	return cmd
 }
 
 // runInstall executes the install command
-func runInstall(ctx context.Context, stdout io.Writer, serverName string, modSlugs []string) error {
+func runInstall(ctx context.Context, stdout io.Writer, serverName string, modSlugs []string, flags *InstallFlags, cfg *state.Config) error {
 	jsonMode := isJSONMode()
 
 	// Validate server name
@@ -64,23 +102,38 @@ func runInstall(ctx context.Context, stdout io.Writer, serverName string, modSlu
 	}
 
 	// Install mods
-	installer := mods.NewInstaller()
-	installed, err := installer.InstallMods(ctx, serverName, modSlugs)
+	installer := mods.NewInstallerFromConfig(cfg)
+	installer.SetForce(flags.Force)
+	result, err := installer.Install(ctx, serverName, modSlugs)
 	if err != nil {
+		var conflictErr *mods.ConflictError
+		if errors.As(err, &conflictErr) {
+			return outputInstallConflict(stdout, jsonMode, conflictErr)
+		}
 		return outputInstallError(stdout, jsonMode, fmt.Errorf("failed to install mods: %w", err))
 	}
 
+	// Publish the ports of newly installed mods
+	var ports *PortsOutput
+	if serverState, err := state.LoadServerState(ctx, serverName); err == nil && hasModPorts(serverState, result.Installed) {
+		ports = reconcileModPorts(ctx, serverName)
+	}
+
 	// Output success
-	return outputInstallSuccess(stdout, jsonMode, installed)
+	return outputInstallSuccess(stdout, jsonMode, result, ports)
 }
 
 // outputInstallSuccess outputs a success message
-func outputInstallSuccess(stdout io.Writer, jsonMode bool, installed []string) error {
+func outputInstallSuccess(stdout io.Writer, jsonMode bool, result *mods.InstallResult, ports *PortsOutput) error {
+	installed := result.Installed
 	if jsonMode {
 		output := InstallOutput{
-			Status:    "success",
-			Installed: installed,
-			Message:   fmt.Sprintf("Installed %d mod(s)", len(installed)),
+			Status:      "success",
+			Installed:   installed,
+			Suggestions: result.Suggestions,
+			Conflicts:   result.Conflicts,
+			Ports:       ports,
+			Message:     fmt.Sprintf("Installed %d mod(s)", len(installed)),
 		}
 		return json.NewEncoder(stdout).Encode(output)
 	}
@@ -94,10 +147,39 @@ func outputInstallSuccess(stdout io.Writer, jsonMode bool, installed []string) e
 	for _, slug := range installed {
 		_, _ = fmt.Fprintf(stdout, "  • %s\n", slug)
 	}
+	outputPortsHuman(stdout, ports)
+
+	if len(result.Conflicts) > 0 {
+		_, _ = fmt.Fprintf(stdout, "\nWarning: installed despite conflicts:\n")
+		for _, c := range result.Conflicts {
+			_, _ = fmt.Fprintf(stdout, "  - %s\n", c.Message)
+		}
+	}
+
+	if len(result.Suggestions) > 0 {
+		_, _ = fmt.Fprintf(stdout, "\nSuggested (optional) mods:\n")
+		for _, s := range result.Suggestions {
+			_, _ = fmt.Fprintf(stdout, "  • %s (suggested by %s)\n", s.Slug, strings.Join(s.SuggestedBy, ", "))
+		}
+	}
 
 	return nil
 }
 
+// outputInstallConflict outputs the conflicts that stopped an install
+func outputInstallConflict(stdout io.Writer, jsonMode bool, conflictErr *mods.ConflictError) error {
+	err := fmt.Errorf("refusing to install conflicting mods (use --force to install anyway): %w", conflictErr)
+	if jsonMode {
+		output := InstallOutput{
+			Status:    "error",
+			Conflicts: conflictErr.Conflicts,
+			Error:     err.Error(),
+		}
+		_ = json.NewEncoder(stdout).Encode(output)
+	}
+	return err
+}
+
 // outputInstallError outputs an error message
 func outputInstallError(stdout io.Writer, jsonMode bool, err error) error {
 	if jsonMode {
@@ -0,0 +1,55 @@
+package mods
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/steviee/go-mc/internal/mods"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOutputInstallSuccess(t *testing.T) {
+	result := &mods.InstallResult{
+		Installed:   []string{"optifabric"},
+		Suggestions: []mods.Suggestion{{Slug: "modmenu", Name: "Mod Menu", SuggestedBy: []string{"optifabric"}}},
+		Conflicts: []mods.Conflict{{
+			Kind:    mods.ConflictIncompatible,
+			Mod:     "optifabric",
+			With:    "sodium",
+			Message: "optifabric 1.0 declares it is incompatible with sodium 0.5",
+		}},
+	}
+
+	var stdout bytes.Buffer
+	require.NoError(t, outputInstallSuccess(&stdout, false, result, nil))
+	assert.Contains(t, stdout.String(), "  - optifabric 1.0 declares it is incompatible with sodium 0.5")
+	assert.Contains(t, stdout.String(), "  • modmenu (suggested by optifabric)")
+
+	stdout.Reset()
+	require.NoError(t, outputInstallSuccess(&stdout, true, result, nil))
+	var output InstallOutput
+	require.NoError(t, json.Unmarshal(stdout.Bytes(), &output))
+	assert.Equal(t, result.Suggestions, output.Suggestions)
+	assert.Equal(t, result.Conflicts, output.Conflicts)
+}
+
+func TestOutputInstallConflict(t *testing.T) {
+	conflictErr := &mods.ConflictError{Conflicts: []mods.Conflict{{
+		Kind:    mods.ConflictVersion,
+		Mod:     "extras",
+		With:    "sodium",
+		Message: "extras 1.0 requires version abc of sodium, but sodium 0.5 is installed",
+	}}}
+
+	var stdout bytes.Buffer
+	err := outputInstallConflict(&stdout, true, conflictErr)
+	assert.ErrorIs(t, err, mods.ErrConflict)
+	assert.ErrorContains(t, err, "use --force")
+
+	var output InstallOutput
+	require.NoError(t, json.Unmarshal(stdout.Bytes(), &output))
+	assert.Equal(t, "error", output.Status)
+	assert.Equal(t, conflictErr.Conflicts, output.Conflicts)
+}
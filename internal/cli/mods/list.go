@@ -8,16 +8,22 @@ import (
 	"strings"
 
 	"github.com/spf13/cobra"
+	"github.com/steviee/go-mc/internal/mods"
+	"github.com/steviee/go-mc/internal/server"
 	"github.com/steviee/go-mc/internal/state"
 )
 
+var listRefresh bool
+
 // ListOutput holds the output for JSON mode
 type ListOutput struct {
-	Status  string          `json:"status"`
-	Mods    []state.ModInfo `json:"mods,omitempty"`
-	Count   int             `json:"count"`
-	Message string          `json:"message,omitempty"`
-	Error   string          `json:"error,omitempty"`
+	Status     string          `json:"status"`
+	Mods       []state.ModInfo `json:"mods,omitempty"`
+	Ports      []PortOutput    `json:"ports,omitempty"`
+	ClientOnly []string        `json:"client_only,omitempty"`
+	Count      int             `json:"count"`
+	Message    string          `json:"message,omitempty"`
+	Error      string          `json:"error,omitempty"`
 }
 
 // NewListCommand creates the mods list subcommand
@@ -27,10 +33,22 @@ func NewListCommand() *cobra.Command {
 		Short: "List installed mods on a server",
 		Long: `List all mods installed on a server.
 
-Shows mod name, slug, version, and port information (if applicable).`,
+Shows mod name, slug, version, and port information (if applicable).
+Ports are shown as host->container/protocol. Ports the server's container
+does not publish yet are marked "pending"; they are published on the next
+start or restart.
+
+Mods that Modrinth marks as client-side only (server_side: unsupported),
+such as Sodium or Iris, are flagged: they crash a dedicated server or do
+nothing on it. The listing only reads local state; --refresh looks up mods
+installed before go-mc recorded their server-side support on Modrinth and
+records it.`,
 		Example: `  # List all installed mods
   go-mc mods list myserver
 
+  # Look up the server-side support of older mods first
+  go-mc mods list myserver --refresh
+
   # List with JSON output
   go-mc mods list myserver --json`,
 		Args: cobra.ExactArgs(1),
@@ -39,6 +57,8 @@ Shows mod name, slug, version, and port information (if applicable).`,
 		},
 	}
 
+	cmd.Flags().BoolVar(&listRefresh, "refresh", false, "Look up the server-side support of mods on Modrinth")
+
 	return cmd
 }
 
@@ -57,17 +77,26 @@ func runList(ctx context.Context, stdout io.Writer, serverName string) error {
 		return outputListError(stdout, jsonMode, fmt.Errorf("failed to load server: %w", err))
 	}
 
-	// Output mods
-	return outputListSuccess(stdout, jsonMode, serverState.Mods)
+	if listRefresh {
+		if _, err := mods.NewInstaller().RefreshServerSide(ctx, serverState); err != nil {
+			return outputListError(stdout, jsonMode, err)
+		}
+	}
+	clientOnly := mods.ClientOnlyMods(serverState)
+
+	// Output mods with the status of their ports
+	return outputListSuccess(stdout, jsonMode, serverState.Mods, modPortStatuses(ctx, serverState), clientOnly)
 }
 
 // outputListSuccess outputs the list of mods
-func outputListSuccess(stdout io.Writer, jsonMode bool, modList []state.ModInfo) error {
+func outputListSuccess(stdout io.Writer, jsonMode bool, modList []state.ModInfo, ports []PortOutput, clientOnly []string) error {
 	if jsonMode {
 		output := ListOutput{
-			Status: "success",
-			Mods:   modList,
-			Count:  len(modList),
+			Status:     "success",
+			Mods:       modList,
+			Ports:      ports,
+			Count:      len(modList),
+			ClientOnly: clientOnly,
 		}
 		return json.NewEncoder(stdout).Encode(output)
 	}
@@ -107,11 +136,25 @@ func outputListSuccess(stdout io.Writer, jsonMode bool, modList []state.ModInfo)
 		strings.Repeat("-", maxVersion),
 		strings.Repeat("-", 13))
 
-	// Print mods
+	// Print mods, marking client-only ones
+	isClientOnly := make(map[string]bool, len(clientOnly))
+	for _, slug := range clientOnly {
+		isClientOnly[slug] = true
+	}
 	for _, mod := range modList {
 		portInfo := "-"
-		if mod.Port > 0 {
-			portInfo = fmt.Sprintf("%d/%s", mod.Port, mod.Protocol)
+		for _, p := range ports {
+			if p.Name != mod.Slug {
+				continue
+			}
+			portInfo = fmt.Sprintf("%d->%d/%s", p.HostPort, p.ContainerPort, p.Protocol)
+			if p.Status == server.PortPending {
+				portInfo += " (pending)"
+			}
+		}
+
+		if isClientOnly[mod.Slug] {
+			portInfo += "  (client-only)"
 		}
 
 		_, _ = fmt.Fprintf(stdout, "%-*s  %-*s  %-*s  %s\n",
@@ -123,6 +166,11 @@ func outputListSuccess(stdout io.Writer, jsonMode bool, modList []state.ModInfo)
 
 	_, _ = fmt.Fprintf(stdout, "\nTotal: %d mod(s)\n", len(modList))
 
+	if len(clientOnly) > 0 {
+		_, _ = fmt.Fprintf(stdout, "\nWarning: client-only mods do not run on servers: %s\n", strings.Join(clientOnly, ", "))
+		_, _ = fmt.Fprintf(stdout, "Remove them with 'go-mc mods remove <server> <mod-slug...>'\n")
+	}
+
 	return nil
 }
 
@@ -0,0 +1,252 @@
+package mods
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+	"github.com/steviee/go-mc/internal/mods"
+	"github.com/steviee/go-mc/internal/state"
+)
+
+// defaultLockfile is the lockfile lock writes and sync reads by default
+const defaultLockfile = "mods.lock"
+
+// LockFlags holds all flags for the lock command
+type LockFlags struct {
+	Output string
+}
+
+// SyncFlags holds all flags for the sync command
+type SyncFlags struct {
+	Lockfile string
+}
+
+// LockOutput holds the output for JSON mode
+type LockOutput struct {
+	Status  string `json:"status"`
+	Path    string `json:"path,omitempty"`
+	Count   int    `json:"count"`
+	Message string `json:"message,omitempty"`
+	Error   string `json:"error,omitempty"`
+}
+
+// SyncOutput holds the output for JSON mode
+type SyncOutput struct {
+	Status    string       `json:"status"`
+	Installed []string     `json:"installed,omitempty"`
+	Updated   []string     `json:"updated,omitempty"`
+	Removed   []string     `json:"removed,omitempty"`
+	Unchanged int          `json:"unchanged"`
+	Ports     *PortsOutput `json:"ports,omitempty"`
+	Message   string       `json:"message,omitempty"`
+	Error     string       `json:"error,omitempty"`
+}
+
+// NewLockCommand creates the mods lock subcommand
+func NewLockCommand() *cobra.Command {
+	flags := &LockFlags{}
+
+	cmd := &cobra.Command{
+		Use:   "lock <server>",
+		Short: "Write a lockfile freezing the mods of a server",
+		Long: `Write a lockfile with every mod installed on a server: its project and
+version ID, filename, download URL, SHA-512 and SHA-1 hashes, size and the
+mods it depends on.
+
+The jars on disk are hashed, so the lockfile pins exactly what the server
+runs. Locking fails if a jar is missing or no longer matches the hash
+recorded when it was installed; check with 'go-mc mods verify'.
+
+Apply a lockfile to any server with 'go-mc mods sync'.`,
+		Example: `  # Freeze the mods of staging into mods.lock
+  go-mc mods lock staging
+
+  # Write the lockfile elsewhere
+  go-mc mods lock staging -o locks/survival.lock`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runLock(cmd.Context(), cmd.OutOrStdout(), args[0], flags)
+		},
+	}
+
+	cmd.Flags().StringVarP(&flags.Output, "output", "o", defaultLockfile, "Path to write the lockfile to")
+
+	return cmd
+}
+
+// NewSyncCommand creates the mods sync subcommand
+func NewSyncCommand() *cobra.Command {
+	flags := &SyncFlags{}
+
+	cmd := &cobra.Command{
+		Use:   "sync <server>",
+		Short: "Install exactly the mods of a lockfile",
+		Long: `Make the mods of a server exactly the set of a lockfile written by
+'go-mc mods lock'.
+
+Locked mods that are missing or installed in another version are downloaded
+and verified against the hashes in the lockfile. Mods the lockfile does not
+list, and any other jar in the mods directory, are removed. Mods that need a
+port keep theirs or get one allocated.
+
+Before anything changes, jars already at their locked version are hashed;
+if any has drifted from the lockfile, the sync fails and the server is left
+as it was. The server's Minecraft version must match the lockfile.`,
+		Example: `  # Make production match staging
+  go-mc mods lock staging
+  go-mc mods sync production --lockfile mods.lock
+
+  # Restore the mods of a server after a wipe
+  go-mc mods sync survival --lockfile backups/survival.lock`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := state.ResolveConfig(cmd.Context())
+			if err != nil {
+				return err
+			}
+			return runSync(cmd.Context(), cmd.OutOrStdout(), args[0], flags, cfg)
+		},
+	}
+
+	cmd.Flags().StringVar(&flags.Lockfile, "lockfile", defaultLockfile, "Lockfile to install")
+
+	return cmd
+}
+
+// runLock executes the lock command
+func runLock(ctx context.Context, stdout io.Writer, serverName string, flags *LockFlags) error {
+	jsonMode := isJSONMode()
+
+	// Validate server name
+	if err := state.ValidateServerName(serverName); err != nil {
+		return outputLockError(stdout, jsonMode, fmt.Errorf("invalid server name: %w", err))
+	}
+
+	// Load server state
+	serverState, err := state.LoadServerState(ctx, serverName)
+	if err != nil {
+		return outputLockError(stdout, jsonMode, fmt.Errorf("failed to load server: %w", err))
+	}
+
+	lf, err := mods.Lock(serverState)
+	if err != nil {
+		return outputLockError(stdout, jsonMode, fmt.Errorf("failed to lock mods: %w", err))
+	}
+	if err := mods.WriteLockfile(flags.Output, lf); err != nil {
+		return outputLockError(stdout, jsonMode, err)
+	}
+
+	message := fmt.Sprintf("Locked %d mod(s) of '%s' for Minecraft %s in %s", len(lf.Mods), serverName, lf.Minecraft, flags.Output)
+	if jsonMode {
+		return json.NewEncoder(stdout).Encode(LockOutput{
+			Status:  "success",
+			Path:    flags.Output,
+			Count:   len(lf.Mods),
+			Message: message,
+		})
+	}
+
+	_, _ = fmt.Fprintln(stdout, message)
+	return nil
+}
+
+// runSync executes the sync command
+func runSync(ctx context.Context, stdout io.Writer, serverName string, flags *SyncFlags, cfg *state.Config) error {
+	jsonMode := isJSONMode()
+
+	// Validate server name
+	if err := state.ValidateServerName(serverName); err != nil {
+		return outputSyncError(stdout, jsonMode, fmt.Errorf("invalid server name: %w", err))
+	}
+
+	lf, err := mods.ReadLockfile(flags.Lockfile)
+	if err != nil {
+		return outputSyncError(stdout, jsonMode, err)
+	}
+
+	// Load server state to tell whether removed mods had ports
+	before, err := state.LoadServerState(ctx, serverName)
+	if err != nil {
+		return outputSyncError(stdout, jsonMode, fmt.Errorf("failed to load server: %w", err))
+	}
+
+	installer := mods.NewInstallerFromConfig(cfg)
+	result, err := installer.Sync(ctx, serverName, lf)
+	if err != nil {
+		return outputSyncError(stdout, jsonMode, fmt.Errorf("failed to sync mods: %w", err))
+	}
+
+	// Publish the ports of installed mods and stop publishing removed ones
+	var ports *PortsOutput
+	after, err := state.LoadServerState(ctx, serverName)
+	if err == nil && (hasModPorts(after, result.Installed) || hasModPorts(before, result.Removed)) {
+		ports = reconcileModPorts(ctx, serverName)
+	}
+
+	return outputSyncSuccess(stdout, jsonMode, result, ports)
+}
+
+// outputSyncSuccess outputs a success message
+func outputSyncSuccess(stdout io.Writer, jsonMode bool, result *mods.SyncResult, ports *PortsOutput) error {
+	message := fmt.Sprintf("Installed %d, updated %d, removed %d, unchanged %d mod(s)",
+		len(result.Installed), len(result.Updated), len(result.Removed), result.Unchanged)
+
+	if jsonMode {
+		output := SyncOutput{
+			Status:    "success",
+			Installed: result.Installed,
+			Updated:   result.Updated,
+			Removed:   result.Removed,
+			Unchanged: result.Unchanged,
+			Ports:     ports,
+			Message:   message,
+		}
+		return json.NewEncoder(stdout).Encode(output)
+	}
+
+	if len(result.Installed)+len(result.Updated)+len(result.Removed) == 0 {
+		_, _ = fmt.Fprintf(stdout, "Mods already match the lockfile (%d mod(s))\n", result.Unchanged)
+		return nil
+	}
+
+	_, _ = fmt.Fprintln(stdout, message+":")
+	for _, slug := range result.Installed {
+		_, _ = fmt.Fprintf(stdout, "  + %s\n", slug)
+	}
+	for _, slug := range result.Updated {
+		_, _ = fmt.Fprintf(stdout, "  ~ %s\n", slug)
+	}
+	for _, slug := range result.Removed {
+		_, _ = fmt.Fprintf(stdout, "  - %s\n", slug)
+	}
+	outputPortsHuman(stdout, ports)
+
+	return nil
+}
+
+// outputLockError outputs an error message
+func outputLockError(stdout io.Writer, jsonMode bool, err error) error {
+	if jsonMode {
+		output := LockOutput{
+			Status: "error",
+			Error:  err.Error(),
+		}
+		_ = json.NewEncoder(stdout).Encode(output)
+	}
+	return err
+}
+
+// outputSyncError outputs an error message
+func outputSyncError(stdout io.Writer, jsonMode bool, err error) error {
+	if jsonMode {
+		output := SyncOutput{
+			Status: "error",
+			Error:  err.Error(),
+		}
+		_ = json.NewEncoder(stdout).Encode(output)
+	}
+	return err
+}
@@ -0,0 +1,68 @@
+package mods
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/steviee/go-mc/internal/mods"
+	"github.com/steviee/go-mc/internal/state"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunLockAndSync(t *testing.T) {
+	saveModdedServer(t, []state.ModInfo{
+		{Slug: "lithium", Filename: "lithium.jar", URL: "https://cdn/lithium.jar", SHA512: sha512Of("lithium")},
+	}, map[string]string{
+		"lithium.jar": "lithium",
+		"stray.jar":   "stray",
+	})
+	serverState, err := state.LoadServerState(context.Background(), "survival")
+	require.NoError(t, err)
+	serverState.Minecraft.Version = "1.21.1"
+	require.NoError(t, state.SaveServerState(context.Background(), serverState))
+
+	lockfile := filepath.Join(t.TempDir(), "mods.lock")
+	var stdout bytes.Buffer
+	require.NoError(t, runLock(context.Background(), &stdout, "survival", &LockFlags{Output: lockfile}))
+	assert.Contains(t, stdout.String(), "Locked 1 mod(s) of 'survival'")
+
+	lf, err := mods.ReadLockfile(lockfile)
+	require.NoError(t, err)
+	require.Len(t, lf.Mods, 1)
+	assert.Equal(t, sha512Of("lithium"), lf.Mods[0].SHA512)
+
+	// Syncing the server to its own lockfile removes the stray jar only
+	t.Setenv("GOMC_JSON", "true")
+	cfg := state.DefaultConfig()
+	cfg.Mods.CacheDir = t.TempDir()
+
+	stdout.Reset()
+	require.NoError(t, runSync(context.Background(), &stdout, "survival", &SyncFlags{Lockfile: lockfile}, cfg))
+
+	var output SyncOutput
+	require.NoError(t, json.Unmarshal(stdout.Bytes(), &output))
+	assert.Equal(t, "success", output.Status)
+	assert.Equal(t, []string{"stray.jar"}, output.Removed)
+	assert.Equal(t, 1, output.Unchanged)
+}
+
+func TestRunSync_InvalidLockfile(t *testing.T) {
+	saveModdedServer(t, nil, nil)
+	t.Setenv("GOMC_JSON", "true")
+
+	lockfile := filepath.Join(t.TempDir(), "mods.lock")
+	require.NoError(t, os.WriteFile(lockfile, []byte("version: 2\n"), 0600))
+
+	var stdout bytes.Buffer
+	err := runSync(context.Background(), &stdout, "survival", &SyncFlags{Lockfile: lockfile}, state.DefaultConfig())
+	assert.ErrorIs(t, err, mods.ErrInvalidLockfile)
+
+	var output SyncOutput
+	require.NoError(t, json.Unmarshal(stdout.Bytes(), &output))
+	assert.Equal(t, "error", output.Status)
+}
@@ -21,7 +21,7 @@ on the server's Minecraft and Fabric versions.`,
   go-mc mods install myserver fabric-api
 
   # Install with specific version
-  go-mc mods install myserver sodium --version 0.5.5
+  go-mc mods install myserver lithium --version mc1.20.4-0.12.1
 
   # List installed mods
   go-mc mods list myserver
@@ -33,7 +33,17 @@ on the server's Minecraft and Fabric versions.`,
   go-mc mods update myserver --all
 
   # Remove a mod
-  go-mc mods remove myserver sodium`,
+  go-mc mods remove myserver lithium
+
+  # Verify installed mod files
+  go-mc mods verify myserver
+
+  # Freeze the mods of one server and install them on another
+  go-mc mods lock staging
+  go-mc mods sync production --lockfile mods.lock
+
+  # Show the shared download cache
+  go-mc mods cache ls`,
 		Aliases: []string{"mod"},
 	}
 
@@ -43,6 +53,10 @@ on the server's Minecraft and Fabric versions.`,
 	cmd.AddCommand(NewListCommand())
 	cmd.AddCommand(NewRemoveCommand())
 	cmd.AddCommand(NewUpdateCommand())
+	cmd.AddCommand(NewVerifyCommand())
+	cmd.AddCommand(NewLockCommand())
+	cmd.AddCommand(NewSyncCommand())
+	cmd.AddCommand(NewCacheCommand())
 
 	return cmd
 }
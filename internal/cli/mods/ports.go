@@ -0,0 +1,154 @@
+package mods
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+
+	"github.com/steviee/go-mc/internal/container"
+	"github.com/steviee/go-mc/internal/server"
+	"github.com/steviee/go-mc/internal/state"
+)
+
+// newContainerClient connects to the container runtime. Tests replace it.
+var newContainerClient = func(ctx context.Context) (container.Client, error) {
+	client, err := server.ConnectContainerRuntime(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to container runtime: %w", err)
+	}
+	return client, nil
+}
+
+// PortOutput is a port a server publishes
+type PortOutput struct {
+	Name          string `json:"name"`
+	HostIP        string `json:"host_ip,omitempty"`
+	HostPort      int    `json:"host_port"`
+	ContainerPort int    `json:"container_port"`
+	Protocol      string `json:"protocol"`
+	Status        string `json:"status,omitempty"`
+}
+
+// newPortOutput converts a server port for output
+func newPortOutput(p server.Port) PortOutput {
+	return PortOutput{
+		Name:          p.Name,
+		HostIP:        p.HostIP,
+		HostPort:      p.HostPort,
+		ContainerPort: p.ContainerPort,
+		Protocol:      p.Protocol,
+	}
+}
+
+// PortsOutput reports how the published ports of a server were updated
+// after mods with ports were installed or removed
+type PortsOutput struct {
+	Ports     []PortOutput `json:"ports"`
+	Recreated bool         `json:"recreated"`
+	Pending   bool         `json:"pending"`
+	Warning   string       `json:"warning,omitempty"`
+}
+
+// hasModPorts reports whether any of the given mods has a port allocated
+func hasModPorts(serverState *state.ServerState, slugs []string) bool {
+	for _, mod := range serverState.Mods {
+		for _, slug := range slugs {
+			if mod.Slug == slug && mod.Port > 0 {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// modPortStatuses returns the ports of a server's mods and whether its
+// container publishes them. The container is optional: when it cannot be
+// inspected, the status is unknown.
+func modPortStatuses(ctx context.Context, serverState *state.ServerState) []PortOutput {
+	modPorts := server.ModPorts(serverState)
+	if len(modPorts) == 0 {
+		return nil
+	}
+
+	var info *container.ContainerInfo
+	if serverState.ContainerID != "" {
+		if client, err := newContainerClient(ctx); err != nil {
+			slog.Debug("cannot check published ports", "error", err)
+		} else {
+			info, err = client.InspectContainer(ctx, serverState.ContainerID)
+			if err != nil {
+				slog.Debug("cannot check published ports", "error", err)
+			}
+			_ = client.Close()
+		}
+	}
+
+	ports := make([]PortOutput, 0, len(modPorts))
+	for _, p := range modPorts {
+		out := newPortOutput(p)
+		out.Status = server.PortStatus(p.PortMapping, info)
+		ports = append(ports, out)
+	}
+	return ports
+}
+
+// reconcileModPorts makes the server's container publish the ports of its
+// mods. A stopped container is recreated; a running one picks up the change
+// on its next start or restart. Problems are reported but never fail the mod
+// change itself.
+func reconcileModPorts(ctx context.Context, serverName string) *PortsOutput {
+	output := &PortsOutput{Ports: []PortOutput{}}
+
+	serverState, err := state.LoadServerState(ctx, serverName)
+	if err != nil {
+		output.Warning = fmt.Sprintf("ports not updated: %v", err)
+		return output
+	}
+
+	for _, p := range server.Ports(serverState) {
+		output.Ports = append(output.Ports, newPortOutput(p))
+	}
+
+	client, err := newContainerClient(ctx)
+	if err != nil {
+		output.Warning = fmt.Sprintf("ports not updated: %v", err)
+		return output
+	}
+	defer func() { _ = client.Close() }()
+
+	result, err := server.Reconcile(ctx, client, serverState)
+	if err != nil {
+		slog.Warn("failed to reconcile ports", "server", serverName, "error", err)
+		output.Warning = fmt.Sprintf("ports not updated: %v", err)
+		return output
+	}
+
+	output.Recreated = result.Recreated
+	output.Pending = result.Pending
+
+	return output
+}
+
+// outputPortsHuman prints the outcome of reconcileModPorts
+func outputPortsHuman(stdout io.Writer, ports *PortsOutput) {
+	if ports == nil {
+		return
+	}
+
+	switch {
+	case ports.Warning != "":
+		_, _ = fmt.Fprintf(stdout, "\nWarning: %s\n", ports.Warning)
+		return
+	case ports.Recreated:
+		_, _ = fmt.Fprintf(stdout, "\nContainer recreated to publish ports:\n")
+	case ports.Pending:
+		_, _ = fmt.Fprintf(stdout, "\nRestart the server to publish ports:\n")
+	default:
+		return
+	}
+
+	for _, p := range ports.Ports {
+		_, _ = fmt.Fprintf(stdout, "  • %s %d->%d/%s\n", p.Name, p.HostPort, p.ContainerPort, p.Protocol)
+	}
+}
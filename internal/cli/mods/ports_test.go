@@ -0,0 +1,177 @@
+package mods
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/steviee/go-mc/internal/container"
+	"github.com/steviee/go-mc/internal/state"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// portsClient is a container client that answers inspect and recreates
+type portsClient struct {
+	container.Client
+	info    *container.ContainerInfo
+	created int
+}
+
+func (c *portsClient) InspectContainer(ctx context.Context, containerID string) (*container.ContainerInfo, error) {
+	return c.info, nil
+}
+
+func (c *portsClient) RemoveContainer(ctx context.Context, containerID string, opts *container.RemoveOptions) error {
+	return nil
+}
+
+func (c *portsClient) CreateContainer(ctx context.Context, config *container.ContainerConfig) (string, error) {
+	c.created++
+	return "recreated", nil
+}
+
+func (c *portsClient) Close() error {
+	return nil
+}
+
+// useContainerClient replaces the container runtime for a test
+func useContainerClient(t *testing.T, client container.Client, err error) {
+	t.Helper()
+
+	orig := newContainerClient
+	newContainerClient = func(ctx context.Context) (container.Client, error) {
+		return client, err
+	}
+	t.Cleanup(func() { newContainerClient = orig })
+}
+
+// saveVoiceServer saves a server with voice chat installed
+func saveVoiceServer(t *testing.T) *state.ServerState {
+	t.Helper()
+
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	require.NoError(t, state.InitDirs())
+
+	serverState := state.NewServerState("survival")
+	serverState.ContainerID = "abc123"
+	serverState.Minecraft.GamePort = 25565
+	serverState.Minecraft.RconPort = 25575
+	serverState.Volumes.Data = filepath.Join(t.TempDir(), "data")
+	serverState.Mods = []state.ModInfo{
+		{Name: "Fabric API", Slug: "fabric-api", Version: "0.102.0"},
+		{Name: "Simple Voice Chat", Slug: "simple-voice-chat", Version: "2.5.0", Port: 24455, Protocol: "udp"},
+	}
+	require.NoError(t, state.SaveServerState(context.Background(), serverState))
+
+	return serverState
+}
+
+// gameAndRcon are the ports of a container created before voice chat
+var gameAndRcon = []container.PortMapping{
+	{HostPort: 25565, ContainerPort: 25565, Protocol: "tcp"},
+	{HostPort: 25575, ContainerPort: 25575, Protocol: "tcp"},
+}
+
+func TestHasModPorts(t *testing.T) {
+	serverState := state.NewServerState("survival")
+	serverState.Mods = []state.ModInfo{
+		{Slug: "fabric-api"},
+		{Slug: "geyser", Port: 19132, Protocol: "udp"},
+	}
+
+	assert.True(t, hasModPorts(serverState, []string{"fabric-api", "geyser"}))
+	assert.False(t, hasModPorts(serverState, []string{"fabric-api"}))
+	assert.False(t, hasModPorts(serverState, nil))
+}
+
+func TestReconcileModPorts(t *testing.T) {
+	t.Run("stopped container is recreated", func(t *testing.T) {
+		saveVoiceServer(t)
+		client := &portsClient{info: &container.ContainerInfo{State: "exited", Ports: gameAndRcon}}
+		useContainerClient(t, client, nil)
+
+		ports := reconcileModPorts(context.Background(), "survival")
+
+		assert.True(t, ports.Recreated)
+		assert.False(t, ports.Pending)
+		assert.Empty(t, ports.Warning)
+		require.Len(t, ports.Ports, 3)
+		assert.Equal(t, PortOutput{Name: "simple-voice-chat", HostPort: 24455, ContainerPort: 24454, Protocol: "udp"}, ports.Ports[2])
+		assert.Equal(t, 1, client.created)
+
+		saved, err := state.LoadServerState(context.Background(), "survival")
+		require.NoError(t, err)
+		assert.Equal(t, "recreated", saved.ContainerID)
+	})
+
+	t.Run("running container is pending", func(t *testing.T) {
+		saveVoiceServer(t)
+		client := &portsClient{info: &container.ContainerInfo{State: "running", Ports: gameAndRcon}}
+		useContainerClient(t, client, nil)
+
+		ports := reconcileModPorts(context.Background(), "survival")
+
+		assert.True(t, ports.Pending)
+		assert.Zero(t, client.created)
+
+		var stdout bytes.Buffer
+		outputPortsHuman(&stdout, ports)
+		assert.Contains(t, stdout.String(), "Restart the server to publish ports")
+		assert.Contains(t, stdout.String(), "simple-voice-chat 24455->24454/udp")
+	})
+
+	t.Run("runtime unavailable", func(t *testing.T) {
+		saveVoiceServer(t)
+		useContainerClient(t, nil, fmt.Errorf("failed to connect to container runtime"))
+
+		ports := reconcileModPorts(context.Background(), "survival")
+
+		assert.Contains(t, ports.Warning, "failed to connect")
+		assert.False(t, ports.Recreated)
+	})
+}
+
+func TestRunList_PortStatus(t *testing.T) {
+	saveVoiceServer(t)
+	t.Setenv("GOMC_JSON", "")
+	useContainerClient(t, &portsClient{info: &container.ContainerInfo{State: "running", Ports: gameAndRcon}}, nil)
+
+	var stdout bytes.Buffer
+	require.NoError(t, runList(context.Background(), &stdout, "survival"))
+	assert.Contains(t, stdout.String(), "24455->24454/udp (pending)")
+
+	t.Setenv("GOMC_JSON", "true")
+	stdout.Reset()
+	require.NoError(t, runList(context.Background(), &stdout, "survival"))
+
+	var out ListOutput
+	require.NoError(t, json.Unmarshal(stdout.Bytes(), &out))
+	require.Len(t, out.Ports, 1)
+	assert.Equal(t, "pending", out.Ports[0].Status)
+	assert.Equal(t, 2, out.Count)
+}
+
+func TestRunList_ClientOnly(t *testing.T) {
+	serverState := saveVoiceServer(t)
+	serverState.Mods = append(serverState.Mods, state.ModInfo{Name: "Iris", Slug: "iris", Version: "1.7.0", ServerSide: "unsupported"})
+	require.NoError(t, state.SaveServerState(context.Background(), serverState))
+	useContainerClient(t, &portsClient{info: &container.ContainerInfo{State: "running", Ports: gameAndRcon}}, nil)
+
+	t.Setenv("GOMC_JSON", "")
+	var stdout bytes.Buffer
+	require.NoError(t, runList(context.Background(), &stdout, "survival"))
+	assert.Contains(t, stdout.String(), "-  (client-only)")
+	assert.Contains(t, stdout.String(), "client-only mods do not run on servers: iris")
+
+	t.Setenv("GOMC_JSON", "true")
+	stdout.Reset()
+	require.NoError(t, runList(context.Background(), &stdout, "survival"))
+
+	var out ListOutput
+	require.NoError(t, json.Unmarshal(stdout.Bytes(), &out))
+	assert.Equal(t, []string{"iris"}, out.ClientOnly)
+}
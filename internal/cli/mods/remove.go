@@ -14,10 +14,11 @@ import (
 
 // RemoveOutput holds the output for JSON mode
 type RemoveOutput struct {
-	Status  string   `json:"status"`
-	Removed []string `json:"removed,omitempty"`
-	Message string   `json:"message,omitempty"`
-	Error   string   `json:"error,omitempty"`
+	Status  string       `json:"status"`
+	Removed []string     `json:"removed,omitempty"`
+	Ports   *PortsOutput `json:"ports,omitempty"`
+	Message string       `json:"message,omitempty"`
+	Error   string       `json:"error,omitempty"`
 }
 
 // NewRemoveCommand creates the mods remove subcommand
@@ -29,15 +30,16 @@ func NewRemoveCommand() *cobra.Command {
 
 The mod files will be deleted from the server's mods directory and the
 mod will be removed from the server state. The server must be stopped
-before removing mods.
+before removing mods. Ports allocated to removed mods are released and no
+longer published.
 
 Note: This command does NOT check for reverse dependencies. If you remove
 a mod that other mods depend on, those mods may fail to load.`,
 		Example: `  # Remove a single mod
-  go-mc mods remove myserver sodium
+  go-mc mods remove myserver lithium
 
   # Remove multiple mods at once
-  go-mc mods remove myserver sodium phosphor
+  go-mc mods remove myserver lithium ferritecore
 
   # Remove with JSON output
   go-mc mods remove myserver lithium --json`,
@@ -73,6 +75,7 @@ func runRemove(ctx context.Context, stdout io.Writer, serverName string, modSlug
 	modsDir := filepath.Join(serverDir, "mods")
 
 	removed := []string{}
+	portsChanged := false
 	for _, slug := range modSlugs {
 		// Find mod in state
 		var modInfo *state.ModInfo
@@ -102,21 +105,29 @@ func runRemove(ctx context.Context, stdout io.Writer, serverName string, modSlug
 		// Release port if allocated
 		if modInfo.Port > 0 {
 			_ = state.ReleasePort(ctx, modInfo.Port)
+			portsChanged = true
 		}
 
 		removed = append(removed, slug)
 	}
 
+	// Stop publishing the ports of removed mods
+	var ports *PortsOutput
+	if portsChanged {
+		ports = reconcileModPorts(ctx, serverName)
+	}
+
 	// Output success
-	return outputRemoveSuccess(stdout, jsonMode, removed)
+	return outputRemoveSuccess(stdout, jsonMode, removed, ports)
 }
 
 // outputRemoveSuccess outputs a success message
-func outputRemoveSuccess(stdout io.Writer, jsonMode bool, removed []string) error {
+func outputRemoveSuccess(stdout io.Writer, jsonMode bool, removed []string, ports *PortsOutput) error {
 	if jsonMode {
 		output := RemoveOutput{
 			Status:  "success",
 			Removed: removed,
+			Ports:   ports,
 			Message: fmt.Sprintf("Removed %d mod(s)", len(removed)),
 		}
 		return json.NewEncoder(stdout).Encode(output)
@@ -131,6 +142,7 @@ func outputRemoveSuccess(stdout io.Writer, jsonMode bool, removed []string) erro
 	for _, slug := range removed {
 		_, _ = fmt.Fprintf(stdout, "  • %s\n", slug)
 	}
+	outputPortsHuman(stdout, ports)
 
 	return nil
 }
@@ -14,9 +14,10 @@ import (
 )
 
 var (
-	searchVersion string
-	searchLimit   int
-	searchSort    string
+	searchVersion    string
+	searchLimit      int
+	searchSort       string
+	searchServerSide bool
 )
 
 // SearchOutput holds the output structure for JSON mode
@@ -37,6 +38,8 @@ type SearchResultData struct {
 	Author      string   `json:"author"`
 	Categories  []string `json:"categories"`
 	ProjectID   string   `json:"project_id"`
+	ClientSide  string   `json:"client_side"`
+	ServerSide  string   `json:"server_side"`
 }
 
 // NewSearchCommand creates the mods search subcommand
@@ -49,6 +52,11 @@ func NewSearchCommand() *cobra.Command {
 The search defaults to showing only Fabric mods. Results can be filtered
 by Minecraft version and sorted by different criteria.
 
+The SERVER column tells whether a mod runs on a dedicated server: "yes"
+when Modrinth lists it as required or optional on the server side, "no"
+for client-only mods such as Sodium or Iris, and "?" when unknown. Use
+--server-side to leave client-only mods out.
+
 Sort options:
   - relevance: Best match for search query (default)
   - downloads: Most downloaded mods first
@@ -62,6 +70,9 @@ Sort options:
   # Search with custom limit and sort
   go-mc mods search optimization --limit 50 --sort downloads
 
+  # Only mods that run on a server
+  go-mc mods search optimization --server-side
+
   # Get JSON output for scripting
   go-mc mods search lithium --json`,
 		Args: requireSearchQuery,
@@ -74,6 +85,7 @@ Sort options:
 	cmd.Flags().StringVarP(&searchVersion, "version", "v", "", "Filter by Minecraft version (e.g., 1.21.1)")
 	cmd.Flags().IntVarP(&searchLimit, "limit", "l", 20, "Maximum results to show (1-100)")
 	cmd.Flags().StringVar(&searchSort, "sort", "relevance", "Sort by: relevance, downloads, updated")
+	cmd.Flags().BoolVar(&searchServerSide, "server-side", false, "Only show mods that run on a server")
 
 	return cmd
 }
@@ -97,19 +109,9 @@ func runSearch(ctx context.Context, stdout, stderr io.Writer, query string) erro
 
 	// Build search options
 	opts := &modrinth.SearchOptions{
-		Query: query,
-		Limit: searchLimit,
-		Facets: [][]string{
-			{"project_type:mod"},
-			{"categories:fabric"},
-		},
-	}
-
-	// Add version filter if specified
-	if searchVersion != "" {
-		opts.Facets = append(opts.Facets, []string{
-			fmt.Sprintf("versions:%s", searchVersion),
-		})
+		Query:  query,
+		Limit:  searchLimit,
+		Facets: searchFacets(),
 	}
 
 	// Perform search
@@ -130,6 +132,31 @@ func runSearch(ctx context.Context, stdout, stderr io.Writer, query string) erro
 	return outputSearchTable(stdout, results)
 }
 
+// searchFacets builds the search filters from the flags
+func searchFacets() [][]string {
+	facets := [][]string{
+		{"project_type:mod"},
+		{"categories:fabric"},
+	}
+
+	// Add version filter if specified
+	if searchVersion != "" {
+		facets = append(facets, []string{
+			fmt.Sprintf("versions:%s", searchVersion),
+		})
+	}
+
+	// Facets within a group are ORed
+	if searchServerSide {
+		facets = append(facets, []string{
+			"server_side:" + modrinth.SideRequired,
+			"server_side:" + modrinth.SideOptional,
+		})
+	}
+
+	return facets
+}
+
 // sortResults sorts the search results by the specified field
 func sortResults(hits []modrinth.Project, sortBy string) {
 	switch sortBy {
@@ -153,9 +180,9 @@ func outputSearchTable(stdout io.Writer, results *modrinth.SearchResult) error {
 	}
 
 	// Table header
-	_, _ = fmt.Fprintf(stdout, "%-20s %-25s %-10s %s\n",
-		"SLUG", "NAME", "DOWNLOADS", "DESCRIPTION")
-	_, _ = fmt.Fprintf(stdout, "%s\n", strings.Repeat("-", 100))
+	_, _ = fmt.Fprintf(stdout, "%-20s %-25s %-10s %-6s %s\n",
+		"SLUG", "NAME", "DOWNLOADS", "SERVER", "DESCRIPTION")
+	_, _ = fmt.Fprintf(stdout, "%s\n", strings.Repeat("-", 107))
 
 	// Table rows
 	for _, mod := range results.Hits {
@@ -164,8 +191,8 @@ func outputSearchTable(stdout io.Writer, results *modrinth.SearchResult) error {
 		downloads := formatDownloads(mod.Downloads)
 		description := truncate(mod.Description, 40)
 
-		_, _ = fmt.Fprintf(stdout, "%-20s %-25s %-10s %s\n",
-			slug, name, downloads, description)
+		_, _ = fmt.Fprintf(stdout, "%-20s %-25s %-10s %-6s %s\n",
+			slug, name, downloads, formatServerSide(mod.ServerSide), description)
 	}
 
 	// Footer with result count
@@ -193,6 +220,8 @@ func outputSearchJSON(stdout io.Writer, results *modrinth.SearchResult) error {
 			Author:      hit.Author,
 			Categories:  hit.Categories,
 			ProjectID:   hit.ProjectID,
+			ClientSide:  hit.ClientSide,
+			ServerSide:  hit.ServerSide,
 		}
 	}
 
@@ -237,6 +266,18 @@ func truncate(s string, maxLen int) string {
 	return s[:maxLen-3] + "..."
 }
 
+// formatServerSide tells whether a mod runs on a server
+func formatServerSide(serverSide string) string {
+	switch serverSide {
+	case modrinth.SideRequired, modrinth.SideOptional:
+		return "yes"
+	case modrinth.SideUnsupported:
+		return "no"
+	default:
+		return "?"
+	}
+}
+
 // formatDownloads formats download counts in human-readable format
 func formatDownloads(n int) string {
 	if n >= 1000000 {
@@ -298,12 +298,14 @@ func TestOutputSearchTable_WithResults(t *testing.T) {
 				Title:       "Sodium",
 				Description: "Modern rendering engine and optimization mod for Minecraft",
 				Downloads:   45234567,
+				ServerSide:  modrinth.SideUnsupported,
 			},
 			{
 				Slug:        "fabric-api",
 				Title:       "Fabric API",
 				Description: "Essential hooks for Fabric mods",
 				Downloads:   98400000,
+				ServerSide:  modrinth.SideRequired,
 			},
 		},
 		TotalHits: 2,
@@ -321,6 +323,7 @@ func TestOutputSearchTable_WithResults(t *testing.T) {
 	assert.Contains(t, output, "NAME")
 	assert.Contains(t, output, "DOWNLOADS")
 	assert.Contains(t, output, "DESCRIPTION")
+	assert.Contains(t, output, "SERVER")
 
 	// Check content
 	assert.Contains(t, output, "sodium")
@@ -329,6 +332,8 @@ func TestOutputSearchTable_WithResults(t *testing.T) {
 	assert.Contains(t, output, "fabric-api")
 	assert.Contains(t, output, "Fabric API")
 	assert.Contains(t, output, "98.4M")
+	assert.Contains(t, output, "45.2M      no")
+	assert.Contains(t, output, "98.4M      yes")
 
 	// Check footer
 	assert.Contains(t, output, "Found 2 result(s)")
@@ -397,6 +402,25 @@ func TestNewSearchCommand(t *testing.T) {
 
 	sortFlag := cmd.Flags().Lookup("sort")
 	require.NotNil(t, sortFlag)
+
+	serverSideFlag := cmd.Flags().Lookup("server-side")
+	require.NotNil(t, serverSideFlag)
+}
+
+func TestSearchFacets(t *testing.T) {
+	searchVersion = "1.21.1"
+	searchServerSide = true
+	t.Cleanup(func() {
+		searchVersion = ""
+		searchServerSide = false
+	})
+
+	assert.Equal(t, [][]string{
+		{"project_type:mod"},
+		{"categories:fabric"},
+		{"versions:1.21.1"},
+		{"server_side:required", "server_side:optional"},
+	}, searchFacets())
 }
 
 func TestTruncate_EdgeCases(t *testing.T) {
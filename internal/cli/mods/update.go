@@ -64,7 +64,11 @@ update all installed mods. The server must be stopped before updating mods.`,
 			if len(args) > 1 {
 				modSlug = args[1]
 			}
-			return runUpdate(cmd.Context(), cmd.OutOrStdout(), serverName, modSlug, flags)
+			cfg, err := state.ResolveConfig(cmd.Context())
+			if err != nil {
+				return err
+			}
+			return runUpdate(cmd.Context(), cmd.OutOrStdout(), serverName, modSlug, flags, cfg)
 		},
 	}
 
@@ -74,7 +78,7 @@ update all installed mods. The server must be stopped before updating mods.`,
 }
 
 // runUpdate executes the update command
-func runUpdate(ctx context.Context, stdout io.Writer, serverName string, modSlug string, flags *UpdateFlags) error {
+func runUpdate(ctx context.Context, stdout io.Writer, serverName string, modSlug string, flags *UpdateFlags, cfg *state.Config) error {
 	jsonMode := isJSONMode()
 
 	// Validate server name
@@ -113,7 +117,7 @@ func runUpdate(ctx context.Context, stdout io.Writer, serverName string, modSlug
 	}
 
 	// Update each mod
-	installer := mods.NewInstaller()
+	installer := mods.NewInstallerFromConfig(cfg)
 	modrinthClient := modrinth.NewClient(nil)
 	updated := []string{}
 
@@ -137,22 +141,25 @@ func runUpdate(ctx context.Context, stdout io.Writer, serverName string, modSlug
 			continue
 		}
 
-		// Delete old mod file
-		oldPath := filepath.Join(modsDir, currentMod.Filename)
-		_ = os.Remove(oldPath)
-
-		// Download new version
+		// Download new version; the old file is kept if it fails verification
 		newPath := filepath.Join(modsDir, file.Filename)
-		if err := installer.DownloadFile(ctx, file.URL, newPath); err != nil {
+		if err := installer.DownloadFile(ctx, *file, newPath); err != nil {
 			return outputUpdateError(stdout, jsonMode, fmt.Errorf("failed to download %s: %w", file.Filename, err))
 		}
 
+		// Delete old mod file
+		if currentMod.Filename != file.Filename {
+			oldPath := filepath.Join(modsDir, currentMod.Filename)
+			_ = os.Remove(oldPath)
+		}
+
 		// Update state
 		updatedMod := currentMod
 		updatedMod.Version = latestVersion.VersionNumber
 		updatedMod.VersionID = latestVersion.ID
 		updatedMod.URL = file.URL
 		updatedMod.Filename = file.Filename
+		updatedMod.SHA512 = file.Hashes.SHA512
 		updatedMod.SizeBytes = file.Size
 
 		// Remove old mod from state
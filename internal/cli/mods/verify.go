@@ -0,0 +1,193 @@
+package mods
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+	"github.com/steviee/go-mc/internal/mods"
+	"github.com/steviee/go-mc/internal/server"
+	"github.com/steviee/go-mc/internal/state"
+)
+
+// Verification statuses of a mod file
+const (
+	VerifyOK         = "ok"
+	VerifyMismatch   = "mismatch"
+	VerifyMissing    = "missing"
+	VerifyUnverified = "unverified"
+	VerifyFailed     = "error"
+)
+
+// VerifyResult is the verification of one mod file
+type VerifyResult struct {
+	Slug     string `json:"slug"`
+	Filename string `json:"filename"`
+	Status   string `json:"status"`
+	Detail   string `json:"detail,omitempty"`
+}
+
+// VerifyOutput holds the output for JSON mode
+type VerifyOutput struct {
+	Status  string         `json:"status"`
+	Mods    []VerifyResult `json:"mods,omitempty"`
+	Count   int            `json:"count"`
+	Message string         `json:"message,omitempty"`
+	Error   string         `json:"error,omitempty"`
+}
+
+// NewVerifyCommand creates the mods verify subcommand
+func NewVerifyCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "verify <server>",
+		Short: "Verify installed mod files against their Modrinth hashes",
+		Long: `Re-hash every installed mod file and compare it with the SHA-512 hash and
+size Modrinth published for it.
+
+A file is "ok" when it matches, "mismatch" when it was changed or corrupted,
+"missing" when it is gone from the mods directory, and "unverified" when no
+hash was recorded (mods installed by older versions of go-mc). Reinstall
+mods that fail with 'go-mc mods remove' and 'go-mc mods install'.
+
+Exits with an error if any file is missing or does not match.`,
+		Example: `  # Verify all mods of a server
+  go-mc mods verify myserver
+
+  # Verify with JSON output
+  go-mc mods verify myserver --json`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runVerify(cmd.Context(), cmd.OutOrStdout(), args[0])
+		},
+	}
+
+	return cmd
+}
+
+// runVerify executes the verify command
+func runVerify(ctx context.Context, stdout io.Writer, serverName string) error {
+	jsonMode := isJSONMode()
+
+	// Validate server name
+	if err := state.ValidateServerName(serverName); err != nil {
+		return outputVerifyError(stdout, jsonMode, nil, fmt.Errorf("invalid server name: %w", err))
+	}
+
+	// Load server state
+	serverState, err := state.LoadServerState(ctx, serverName)
+	if err != nil {
+		return outputVerifyError(stdout, jsonMode, nil, fmt.Errorf("failed to load server: %w", err))
+	}
+
+	if serverState.Volumes.Data == "" {
+		return outputVerifyError(stdout, jsonMode, nil, fmt.Errorf("server data volume not configured"))
+	}
+	modsDir := server.ModsDir(serverState)
+
+	results := make([]VerifyResult, 0, len(serverState.Mods))
+	failed := 0
+	for _, mod := range serverState.Mods {
+		result := verifyMod(modsDir, mod)
+		if result.Status != VerifyOK && result.Status != VerifyUnverified {
+			failed++
+		}
+		results = append(results, result)
+	}
+
+	if failed > 0 {
+		return outputVerifyError(stdout, jsonMode, results,
+			fmt.Errorf("%d of %d mod file(s) failed verification", failed, len(results)))
+	}
+
+	return outputVerifySuccess(stdout, jsonMode, results)
+}
+
+// verifyMod hashes the file of a mod and compares it with the hash recorded
+// when it was installed
+func verifyMod(modsDir string, mod state.ModInfo) VerifyResult {
+	result := VerifyResult{Slug: mod.Slug, Filename: mod.Filename}
+
+	err := mods.VerifyFile(filepath.Join(modsDir, mod.Filename), mods.Checksum{
+		SHA512: mod.SHA512,
+		Size:   mod.SizeBytes,
+	})
+	switch {
+	case err == nil && mod.SHA512 == "":
+		result.Status = VerifyUnverified
+		result.Detail = "no hash recorded"
+	case err == nil:
+		result.Status = VerifyOK
+	case errors.Is(err, mods.ErrChecksumMismatch):
+		result.Status = VerifyMismatch
+		result.Detail = err.Error()
+	case errors.Is(err, os.ErrNotExist):
+		result.Status = VerifyMissing
+	default:
+		result.Status = VerifyFailed
+		result.Detail = err.Error()
+	}
+
+	return result
+}
+
+// outputVerifySuccess outputs the verification of all mod files
+func outputVerifySuccess(stdout io.Writer, jsonMode bool, results []VerifyResult) error {
+	if jsonMode {
+		output := VerifyOutput{
+			Status:  "success",
+			Mods:    results,
+			Count:   len(results),
+			Message: fmt.Sprintf("Verified %d mod(s)", len(results)),
+		}
+		return json.NewEncoder(stdout).Encode(output)
+	}
+
+	if len(results) == 0 {
+		_, _ = fmt.Fprintf(stdout, "No mods installed\n")
+		return nil
+	}
+
+	printVerifyResults(stdout, results)
+	return nil
+}
+
+// printVerifyResults prints a table of verification results
+func printVerifyResults(stdout io.Writer, results []VerifyResult) {
+	w := tabwriter.NewWriter(stdout, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(w, "SLUG\tFILE\tSTATUS")
+	for _, r := range results {
+		status := r.Status
+		if r.Detail != "" {
+			status += " (" + r.Detail + ")"
+		}
+		_, _ = fmt.Fprintf(w, "%s\t%s\t%s\n", r.Slug, r.Filename, status)
+	}
+	_ = w.Flush()
+
+	_, _ = fmt.Fprintf(stdout, "\nTotal: %d mod(s)\n", len(results))
+}
+
+// outputVerifyError outputs an error message with the results so far
+func outputVerifyError(stdout io.Writer, jsonMode bool, results []VerifyResult, err error) error {
+	if jsonMode {
+		output := VerifyOutput{
+			Status: "error",
+			Mods:   results,
+			Count:  len(results),
+			Error:  err.Error(),
+		}
+		_ = json.NewEncoder(stdout).Encode(output)
+		return err
+	}
+
+	if len(results) > 0 {
+		printVerifyResults(stdout, results)
+	}
+	return err
+}
@@ -0,0 +1,90 @@
+package mods
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha512"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/steviee/go-mc/internal/state"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// saveModdedServer saves a server with mod files in its mods directory
+func saveModdedServer(t *testing.T, mods []state.ModInfo, files map[string]string) {
+	t.Helper()
+
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	require.NoError(t, state.InitDirs())
+
+	serverDir := t.TempDir()
+	modsDir := filepath.Join(serverDir, "mods")
+	require.NoError(t, os.MkdirAll(modsDir, 0750))
+	for name, content := range files {
+		require.NoError(t, os.WriteFile(filepath.Join(modsDir, name), []byte(content), 0600))
+	}
+
+	serverState := state.NewServerState("survival")
+	serverState.Volumes.Data = filepath.Join(serverDir, "data")
+	serverState.Mods = mods
+	require.NoError(t, state.SaveServerState(context.Background(), serverState))
+}
+
+// sha512Of returns the hex-encoded SHA-512 of content
+func sha512Of(content string) string {
+	sum := sha512.Sum512([]byte(content))
+	return hex.EncodeToString(sum[:])
+}
+
+func TestRunVerify(t *testing.T) {
+	saveModdedServer(t, []state.ModInfo{
+		{Slug: "fabric-api", Filename: "fabric-api.jar", SHA512: sha512Of("fabric"), SizeBytes: 6},
+		{Slug: "lithium", Filename: "lithium.jar", SHA512: sha512Of("lithium")},
+		{Slug: "sodium", Filename: "sodium.jar", SHA512: sha512Of("sodium")},
+		{Slug: "legacy", Filename: "legacy.jar"},
+	}, map[string]string{
+		"fabric-api.jar": "fabric",
+		"lithium.jar":    "tampered",
+		"legacy.jar":     "legacy",
+	})
+	t.Setenv("GOMC_JSON", "true")
+
+	var stdout bytes.Buffer
+	err := runVerify(context.Background(), &stdout, "survival")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "2 of 4 mod file(s) failed verification")
+
+	var output VerifyOutput
+	require.NoError(t, json.Unmarshal(stdout.Bytes(), &output))
+	assert.Equal(t, "error", output.Status)
+	require.Len(t, output.Mods, 4)
+
+	statuses := map[string]string{}
+	for _, m := range output.Mods {
+		statuses[m.Slug] = m.Status
+	}
+	assert.Equal(t, map[string]string{
+		"fabric-api": VerifyOK,
+		"lithium":    VerifyMismatch,
+		"sodium":     VerifyMissing,
+		"legacy":     VerifyUnverified,
+	}, statuses)
+	assert.Contains(t, output.Mods[1].Detail, "checksum mismatch")
+}
+
+func TestRunVerify_AllOK(t *testing.T) {
+	saveModdedServer(t, []state.ModInfo{
+		{Slug: "fabric-api", Filename: "fabric-api.jar", SHA512: sha512Of("fabric")},
+	}, map[string]string{"fabric-api.jar": "fabric"})
+	t.Setenv("GOMC_JSON", "")
+
+	var stdout bytes.Buffer
+	require.NoError(t, runVerify(context.Background(), &stdout, "survival"))
+	assert.Contains(t, stdout.String(), "fabric-api  fabric-api.jar  ok")
+	assert.Contains(t, stdout.String(), "Total: 1 mod(s)")
+}
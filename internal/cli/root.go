@@ -15,6 +15,7 @@ import (
 	"github.com/steviee/go-mc/internal/cli/system"
 	"github.com/steviee/go-mc/internal/cli/users"
 	"github.com/steviee/go-mc/internal/cli/whitelist"
+	"github.com/steviee/go-mc/internal/state"
 )
 
 var (
@@ -65,6 +66,16 @@ All servers run in rootless Podman containers for security and isolation.`,
 				return fmt.Errorf("failed to initialize logger: %w", err)
 			}
 
+			// Command groups that have no --json flag of their own read GOMC_JSON
+			if jsonOut {
+				_ = os.Setenv("GOMC_JSON", "true")
+			}
+
+			// Make --config visible to state.GetConfigPath
+			if cfgFile != "" {
+				_ = os.Setenv(state.ConfigFileEnv, cfgFile)
+			}
+
 			// Initialize config
 			if err := initConfig(); err != nil {
 				logger.Error("failed to initialize config", "error", err)
@@ -95,6 +106,7 @@ All servers run in rootless Podman containers for security and isolation.`,
 	rootCmd.AddCommand(NewModsCommand())
 	rootCmd.AddCommand(NewSystemCommand())
 	rootCmd.AddCommand(NewConfigCommand())
+	rootCmd.AddCommand(NewWatchCommand())
 
 	return rootCmd
 }
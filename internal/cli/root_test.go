@@ -124,6 +124,11 @@ func TestRootCommand_Subcommands(t *testing.T) {
 			commandName: "config",
 			wantShort:   "Manage configuration",
 		},
+		{
+			name:        "has watch command",
+			commandName: "watch",
+			wantShort:   "Restart crashed and hung servers according to their restart policy",
+		},
 	}
 
 	for _, tt := range tests {
@@ -0,0 +1,215 @@
+package servers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/steviee/go-mc/internal/server"
+	"github.com/steviee/go-mc/internal/state"
+	"github.com/steviee/go-mc/internal/systemd"
+)
+
+// Replaced in tests, which cannot talk to systemd
+var (
+	syncAutostart  = server.SyncAutostart
+	checkLingering = systemd.Lingering
+)
+
+// AutostartFlags holds all flags for the autostart enable command
+type AutostartFlags struct {
+	Order    int
+	OrderSet bool
+}
+
+// AutostartInfo describes the autostart settings after a change
+type AutostartInfo struct {
+	Servers []AutostartServer `json:"servers"`
+	UnitDir string            `json:"unit_dir"`
+
+	// Lingering is nil when it could not be checked
+	Lingering *bool `json:"lingering,omitempty"`
+}
+
+// AutostartServer describes the autostart settings of a server
+type AutostartServer struct {
+	Name    string `json:"name"`
+	Enabled bool   `json:"enabled"`
+	Order   int    `json:"order"`
+	Service string `json:"service,omitempty"`
+}
+
+// AutostartOutput is the JSON output of the autostart commands
+type AutostartOutput struct {
+	Status string         `json:"status"`
+	Data   *AutostartInfo `json:"data"`
+}
+
+// NewAutostartCommand creates the servers autostart command group
+func NewAutostartCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "autostart",
+		Short: "Start servers on boot with systemd",
+		Long: `Start servers on boot with systemd.
+
+Enabling autostart writes a Quadlet unit for the server to
+~/.config/containers/systemd. Podman turns it into the systemd user service
+go-mc-<name>.service, which creates the server container with the same image,
+environment, ports, mounts and labels as go-mc and starts it on boot.
+
+The systemd user instance only runs at boot when lingering is enabled for your
+user; 'go-mc system setup' enables it.
+
+Use 'servers list' to see which servers start on boot.`,
+		Example: `  # Start a server on boot
+  go-mc servers autostart enable survival
+
+  # Start the lobby before the other servers
+  go-mc servers autostart enable lobby --order 0
+  go-mc servers autostart enable survival creative --order 10
+
+  # Stop starting a server on boot
+  go-mc servers autostart disable survival`,
+	}
+
+	cmd.AddCommand(NewAutostartEnableCommand())
+	cmd.AddCommand(NewAutostartDisableCommand())
+
+	return cmd
+}
+
+// NewAutostartEnableCommand creates the servers autostart enable command
+func NewAutostartEnableCommand() *cobra.Command {
+	flags := &AutostartFlags{}
+
+	cmd := &cobra.Command{
+		Use:   "enable <name...>",
+		Short: "Start servers on boot",
+		Long: `Write the Quadlet units that start servers on boot.
+
+Servers start in the order given by --order: a server starts after all servers
+with a lower order, and servers with the same order start together. Without
+--order, a server keeps its order (0 for new ones). Running enable again
+updates the order.`,
+		Example: `  go-mc servers autostart enable survival
+  go-mc servers autostart enable survival --order 10`,
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			flags.OrderSet = cmd.Flags().Changed("order")
+			return runAutostart(cmd.Context(), cmd.OutOrStdout(), args, true, flags)
+		},
+	}
+
+	cmd.Flags().IntVar(&flags.Order, "order", 0, "Start order; servers with a lower order start first")
+
+	return cmd
+}
+
+// NewAutostartDisableCommand creates the servers autostart disable command
+func NewAutostartDisableCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "disable <name...>",
+		Short: "Stop starting servers on boot",
+		Long: `Remove the Quadlet units of servers, so they no longer start on boot.
+
+Running servers keep running.`,
+		Example: `  go-mc servers autostart disable survival`,
+		Args:    cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runAutostart(cmd.Context(), cmd.OutOrStdout(), args, false, &AutostartFlags{})
+		},
+	}
+}
+
+// runAutostart enables or disables autostart for servers and syncs the
+// Quadlet units
+func runAutostart(ctx context.Context, stdout io.Writer, names []string, enable bool, flags *AutostartFlags) error {
+	jsonMode := isJSONMode()
+
+	if flags.OrderSet && flags.Order < 0 {
+		return outputLifecycleError(stdout, jsonMode, fmt.Errorf("invalid order: %d (must be 0 or greater)", flags.Order))
+	}
+
+	// Load all servers first, so a typo changes nothing
+	states := make([]*state.ServerState, 0, len(names))
+	for _, name := range names {
+		serverState, err := state.LoadServerState(ctx, name)
+		if err != nil {
+			return outputLifecycleError(stdout, jsonMode, err)
+		}
+		states = append(states, serverState)
+	}
+
+	info := &AutostartInfo{Servers: []AutostartServer{}}
+	for _, serverState := range states {
+		serverState.Autostart.Enabled = enable
+		if flags.OrderSet {
+			serverState.Autostart.Order = flags.Order
+		}
+		if serverState.ContainerID != "" && enable {
+			// The unit replaces the container on boot
+			serverState.ContainerID = server.ContainerRef(serverState, serverState.ContainerID)
+		}
+
+		if err := state.SaveServerState(ctx, serverState); err != nil {
+			return outputLifecycleError(stdout, jsonMode, fmt.Errorf("failed to save server state: %w", err))
+		}
+
+		item := AutostartServer{Name: serverState.Name, Enabled: enable, Order: serverState.Autostart.Order}
+		if enable {
+			item.Service = systemd.ServiceName(serverState.Name)
+		}
+		info.Servers = append(info.Servers, item)
+	}
+
+	if err := syncAutostart(ctx); err != nil {
+		return outputLifecycleError(stdout, jsonMode, fmt.Errorf("failed to update autostart units: %w", err))
+	}
+
+	dir, err := systemd.QuadletDir()
+	if err != nil {
+		return outputLifecycleError(stdout, jsonMode, err)
+	}
+	info.UnitDir = dir
+
+	if enable {
+		lingering, err := checkLingering(ctx)
+		if err != nil {
+			slog.Debug("failed to check lingering", "error", err)
+		} else {
+			info.Lingering = &lingering
+		}
+	}
+
+	return outputAutostart(stdout, jsonMode, info)
+}
+
+// outputAutostart outputs the result of an autostart change
+func outputAutostart(stdout io.Writer, jsonMode bool, info *AutostartInfo) error {
+	if jsonMode {
+		enc := json.NewEncoder(stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(AutostartOutput{Status: "success", Data: info})
+	}
+
+	for _, s := range info.Servers {
+		if s.Enabled {
+			_, _ = fmt.Fprintf(stdout, "Autostart enabled for server '%s' (order %d, %s)\n", s.Name, s.Order, s.Service)
+		} else {
+			_, _ = fmt.Fprintf(stdout, "Autostart disabled for server '%s'\n", s.Name)
+		}
+	}
+	_, _ = fmt.Fprintf(stdout, "Units: %s\n", info.UnitDir)
+
+	if info.Lingering != nil && !*info.Lingering {
+		user := os.Getenv("USER")
+		_, _ = fmt.Fprintf(stdout, "\nWarning: lingering is disabled for user '%s', so servers only start on boot once %s logs in.\n", user, user)
+		_, _ = fmt.Fprintln(stdout, "Run 'go-mc system setup' to enable it.")
+	}
+
+	return nil
+}
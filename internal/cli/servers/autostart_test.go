@@ -0,0 +1,95 @@
+package servers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/steviee/go-mc/internal/state"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// stubAutostart replaces the systemd calls and returns the number of syncs
+func stubAutostart(t *testing.T, lingering bool) *int {
+	t.Helper()
+
+	syncs := 0
+	origSync, origLingering := syncAutostart, checkLingering
+	syncAutostart = func(ctx context.Context) error {
+		syncs++
+		return nil
+	}
+	checkLingering = func(ctx context.Context) (bool, error) { return lingering, nil }
+	t.Cleanup(func() { syncAutostart, checkLingering = origSync, origLingering })
+
+	return &syncs
+}
+
+func TestRunAutostart_Enable(t *testing.T) {
+	setupTestStateDir(t, t.TempDir())
+	t.Setenv("USER", "steve")
+	syncs := stubAutostart(t, false)
+
+	serverState := state.NewServerState("survival")
+	serverState.ContainerID = "abc123"
+	require.NoError(t, state.SaveServerState(context.Background(), serverState))
+
+	var buf bytes.Buffer
+	err := runAutostart(context.Background(), &buf, []string{"survival"}, true, &AutostartFlags{Order: 10, OrderSet: true})
+	require.NoError(t, err)
+	assert.Equal(t, 1, *syncs)
+	assert.Contains(t, buf.String(), "Autostart enabled for server 'survival' (order 10, go-mc-survival.service)\n")
+	assert.Contains(t, buf.String(), "Warning: lingering is disabled for user 'steve'")
+
+	saved, err := state.LoadServerState(context.Background(), "survival")
+	require.NoError(t, err)
+	assert.Equal(t, state.AutostartConfig{Enabled: true, Order: 10}, saved.Autostart)
+	assert.Equal(t, "survival", saved.ContainerID, "tracked by name")
+
+	// Without --order, the order is kept
+	buf.Reset()
+	require.NoError(t, runAutostart(context.Background(), &buf, []string{"survival"}, false, &AutostartFlags{}))
+	assert.Contains(t, buf.String(), "Autostart disabled for server 'survival'\n")
+	assert.NotContains(t, buf.String(), "lingering")
+
+	saved, err = state.LoadServerState(context.Background(), "survival")
+	require.NoError(t, err)
+	assert.Equal(t, state.AutostartConfig{Order: 10}, saved.Autostart)
+}
+
+func TestOutputAutostart_JSON(t *testing.T) {
+	lingering := true
+	info := &AutostartInfo{
+		Servers:   []AutostartServer{{Name: "survival", Enabled: true, Service: "go-mc-survival.service"}},
+		UnitDir:   "/home/steve/.config/containers/systemd",
+		Lingering: &lingering,
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, outputAutostart(&buf, true, info))
+
+	var output AutostartOutput
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &output))
+	assert.Equal(t, "success", output.Status)
+	assert.Equal(t, info, output.Data)
+}
+
+func TestRunAutostart_Errors(t *testing.T) {
+	setupTestStateDir(t, t.TempDir())
+	syncs := stubAutostart(t, true)
+	require.NoError(t, state.SaveServerState(context.Background(), state.NewServerState("survival")))
+
+	var buf bytes.Buffer
+	err := runAutostart(context.Background(), &buf, []string{"survival", "missing"}, true, &AutostartFlags{})
+	assert.ErrorContains(t, err, `server "missing" does not exist`)
+	assert.Zero(t, *syncs)
+
+	saved, err := state.LoadServerState(context.Background(), "survival")
+	require.NoError(t, err)
+	assert.False(t, saved.Autostart.Enabled, "nothing changed")
+
+	err = runAutostart(context.Background(), &buf, []string{"survival"}, true, &AutostartFlags{Order: -1, OrderSet: true})
+	assert.ErrorContains(t, err, "invalid order")
+}
@@ -11,7 +11,7 @@ import (
 
 	"github.com/spf13/cobra"
 	"github.com/steviee/go-mc/internal/backup"
-	"github.com/steviee/go-mc/internal/container"
+	"github.com/steviee/go-mc/internal/server"
 	"github.com/steviee/go-mc/internal/state"
 )
 
@@ -40,10 +40,12 @@ func NewBackupCommand() *cobra.Command {
 		Short: "Create a backup of a server",
 		Long: `Create a compressed backup of a server's data and mods directories.
 
-Backups are stored in ~/.config/go-mc/backups/archives/ as compressed tar.gz files.
+Backups are stored as compressed tar.gz files in backups.directory from the config
+(default: ~/.config/go-mc/backups/archives/).
 A backup registry tracks all backups with metadata (version, size, date, etc.).
 
-Automatic retention policy keeps only the last N backups (default: 5).`,
+Automatic retention policy keeps only the last N backups (default: 5, configurable
+via backups.keep_count).`,
 		Example: `  # Backup a single server
   go-mc servers backup myserver
 
@@ -73,24 +75,40 @@ Automatic retention policy keeps only the last N backups (default: 5).`,
 			return nil
 		},
 		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := state.ResolveConfig(cmd.Context())
+			if err != nil {
+				return err
+			}
+			applyBackupConfig(cmd.Flags().Changed, flags, cfg)
+
 			var serverName string
 			if len(args) > 0 {
 				serverName = args[0]
 			}
-			return runBackup(cmd.Context(), cmd.OutOrStdout(), serverName, flags)
+			return runBackup(cmd.Context(), cmd.OutOrStdout(), serverName, flags, cfg)
 		},
 	}
 
 	cmd.Flags().BoolVarP(&flags.All, "all", "a", false, "Backup all servers")
 	cmd.Flags().BoolVar(&flags.List, "list", false, "List available backups")
-	cmd.Flags().StringVarP(&flags.Output, "output", "o", "", "Output directory (default: ~/.config/go-mc/backups/archives/)")
-	cmd.Flags().IntVar(&flags.Keep, "keep", 5, "Keep last N backups per server")
+	cmd.Flags().StringVarP(&flags.Output, "output", "o", "", "Output directory (default: backups.directory from config)")
+	cmd.Flags().IntVar(&flags.Keep, "keep", 0, "Keep last N backups per server (default: backups.keep_count from config)")
 
 	return cmd
 }
 
+// applyBackupConfig fills the flags the user did not set from the config.
+func applyBackupConfig(changed func(name string) bool, flags *BackupFlags, cfg *state.Config) {
+	if !changed("keep") {
+		flags.Keep = cfg.Backups.KeepCount
+	}
+	if !changed("output") {
+		flags.Output = cfg.Backups.Directory
+	}
+}
+
 // runBackup executes the backup command.
-func runBackup(ctx context.Context, stdout io.Writer, serverName string, flags *BackupFlags) error {
+func runBackup(ctx context.Context, stdout io.Writer, serverName string, flags *BackupFlags, cfg *state.Config) error {
 	jsonMode := isJSONMode()
 
 	// Handle --list flag
@@ -132,8 +150,9 @@ func runBackup(ctx context.Context, stdout io.Writer, serverName string, flags *
 	for _, name := range serverNames {
 		result, err := backupService.CreateBackup(ctx, backup.CreateBackupOptions{
 			ServerName: name,
-			Compress:   true,
+			Compress:   cfg.Backups.Compress,
 			KeepCount:  flags.Keep,
+			Directory:  flags.Output,
 		})
 		if err != nil {
 			errors = append(errors, fmt.Sprintf("%s: %v", name, err))
@@ -203,7 +222,7 @@ func warnIfServerRunning(ctx context.Context, serverName string, stdout io.Write
 	}
 
 	// Check container status
-	containerClient, err := container.NewClient(ctx, container.DefaultConfig())
+	containerClient, err := server.ConnectContainerRuntime(ctx)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,30 @@
+package servers
+
+import (
+	"testing"
+
+	"github.com/steviee/go-mc/internal/state"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestApplyBackupConfig(t *testing.T) {
+	cfg := state.DefaultConfig()
+	cfg.Backups.KeepCount = 10
+	cfg.Backups.Directory = "/srv/backups"
+
+	t.Run("unset flags come from config", func(t *testing.T) {
+		flags := &BackupFlags{}
+		applyBackupConfig(func(string) bool { return false }, flags, cfg)
+
+		assert.Equal(t, 10, flags.Keep)
+		assert.Equal(t, "/srv/backups", flags.Output)
+	})
+
+	t.Run("explicit flags win", func(t *testing.T) {
+		flags := &BackupFlags{Keep: 3, Output: "/mnt/backups"}
+		applyBackupConfig(func(string) bool { return true }, flags, cfg)
+
+		assert.Equal(t, 3, flags.Keep)
+		assert.Equal(t, "/mnt/backups", flags.Output)
+	})
+}
@@ -0,0 +1,286 @@
+package servers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/chzyer/readline"
+	"github.com/spf13/cobra"
+	"github.com/steviee/go-mc/internal/container"
+	"github.com/steviee/go-mc/internal/rcon"
+	"github.com/steviee/go-mc/internal/state"
+)
+
+// consoleExitCommands leave the console without sending anything to the server
+var consoleExitCommands = []string{"exit", "quit"}
+
+// ConsoleFlags holds all flags for the console command
+type ConsoleFlags struct {
+	NoLogs  bool
+	Tail    int
+	Timeout time.Duration
+}
+
+// NewConsoleCommand creates the servers console subcommand
+func NewConsoleCommand() *cobra.Command {
+	flags := &ConsoleFlags{}
+
+	cmd := &cobra.Command{
+		Use:   "console <name>",
+		Short: "Open an interactive RCON console for a running server",
+		Long: `Open an interactive console for a running Minecraft server.
+
+Commands are sent over RCON and their responses are printed inline. The container
+log stream is interleaved in the same view, so there is no need for 'podman attach'
+or a second terminal.
+
+The console supports line editing, tab completion of common vanilla commands, and
+keeps a per-server command history under the go-mc config directory.
+
+Type 'exit' or 'quit', or press Ctrl+D, to leave the console. The server keeps running.`,
+		Example: `  # Open a console
+  go-mc servers console myserver
+
+  # Open a console without the log stream
+  go-mc servers console myserver --no-logs
+
+  # Show the last 50 log lines when the console opens
+  go-mc servers console myserver --tail 50`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runConsole(cmd.Context(), nil, cmd.OutOrStdout(), args[0], flags)
+		},
+	}
+
+	cmd.Flags().BoolVar(&flags.NoLogs, "no-logs", false, "Do not interleave the container log stream")
+	cmd.Flags().IntVarP(&flags.Tail, "tail", "n", 20, "Number of log lines to show when the console opens")
+	cmd.Flags().DurationVar(&flags.Timeout, "timeout", rcon.DefaultTimeout, "Timeout for each command round trip")
+
+	return cmd
+}
+
+// runConsole executes the console command. A nil stdin reads from the terminal.
+func runConsole(ctx context.Context, stdin io.ReadCloser, stdout io.Writer, name string, flags *ConsoleFlags) error {
+	if err := state.ValidateServerName(name); err != nil {
+		return fmt.Errorf("invalid server name: %w", err)
+	}
+
+	serverState, err := state.LoadServerState(ctx, name)
+	if err != nil {
+		return fmt.Errorf("failed to load server state: %w", err)
+	}
+
+	client, err := rcon.NewServerClient(serverState, flags.Timeout)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = client.Close() }()
+
+	// Fail early with a clear message instead of at the first command
+	if err := client.Connect(ctx); err != nil {
+		return fmt.Errorf("failed to connect to server '%s' (is it running?): %w", name, err)
+	}
+
+	historyPath, err := consoleHistoryPath(name)
+	if err != nil {
+		return err
+	}
+
+	rl, err := readline.NewEx(&readline.Config{
+		Prompt:          fmt.Sprintf("%s> ", name),
+		HistoryFile:     historyPath,
+		AutoComplete:    newConsoleCompleter(),
+		InterruptPrompt: "^C",
+		EOFPrompt:       "exit",
+		Stdin:           stdin,
+		Stdout:          stdout,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to initialize console: %w", err)
+	}
+	defer func() { _ = rl.Close() }()
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var wg sync.WaitGroup
+	if !flags.NoLogs && serverState.ContainerID != "" {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := followContainerLogs(ctx, serverState.ContainerID, flags.Tail, rl.Stdout()); err != nil {
+				slog.Debug("console log stream ended", "server", name, "error", err)
+			}
+		}()
+	}
+
+	_, _ = fmt.Fprintf(rl.Stdout(), "Connected to '%s'. Type 'exit' or press Ctrl+D to leave.\n", name)
+
+	err = consoleLoop(ctx, rl, client, rl.Stdout())
+
+	cancel()
+	wg.Wait()
+
+	return err
+}
+
+// consoleLoop reads commands until EOF or an exit command and sends each one over RCON
+func consoleLoop(ctx context.Context, rl *readline.Instance, client *rcon.Client, out io.Writer) error {
+	for {
+		line, err := rl.Readline()
+		if errors.Is(err, readline.ErrInterrupt) {
+			if line == "" {
+				return nil
+			}
+			continue
+		}
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read input: %w", err)
+		}
+
+		command := strings.TrimPrefix(strings.TrimSpace(line), "/")
+		if command == "" {
+			continue
+		}
+
+		if isConsoleExitCommand(command) {
+			return nil
+		}
+
+		response, err := client.Execute(ctx, command)
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			_, _ = fmt.Fprintf(out, "Error: %v\n", err)
+			continue
+		}
+
+		if response != "" {
+			_, _ = fmt.Fprint(out, response)
+			if !strings.HasSuffix(response, "\n") {
+				_, _ = fmt.Fprintln(out)
+			}
+		}
+	}
+}
+
+// isConsoleExitCommand reports whether a command should close the console
+func isConsoleExitCommand(command string) bool {
+	for _, c := range consoleExitCommands {
+		if strings.EqualFold(command, c) {
+			return true
+		}
+	}
+	return false
+}
+
+// consoleHistoryPath returns the history file for a server, creating its directory
+func consoleHistoryPath(name string) (string, error) {
+	path, err := state.GetConsoleHistoryPath(name)
+	if err != nil {
+		return "", fmt.Errorf("failed to get history path: %w", err)
+	}
+
+	if err := state.EnsureDir(filepath.Dir(path)); err != nil {
+		return "", err
+	}
+
+	return path, nil
+}
+
+// followContainerLogs streams the container logs into w until ctx is cancelled
+func followContainerLogs(ctx context.Context, containerID string, tail int, w io.Writer) error {
+	client, err := createContainerClient(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = client.Close() }()
+
+	opts := container.LogOptions{Follow: true, Tail: tail}
+	if err := streamLogs(ctx, client, containerID, opts, logsView{}, w, w); err != nil && ctx.Err() == nil {
+		return err
+	}
+
+	return nil
+}
+
+// consoleCommands lists common vanilla commands and their first-level arguments
+var consoleCommands = map[string][]string{
+	"advancement":     {"grant", "revoke"},
+	"ban":             nil,
+	"ban-ip":          nil,
+	"banlist":         {"ips", "players"},
+	"clear":           nil,
+	"defaultgamemode": {"survival", "creative", "adventure", "spectator"},
+	"deop":            nil,
+	"difficulty":      {"peaceful", "easy", "normal", "hard"},
+	"effect":          {"give", "clear"},
+	"enchant":         nil,
+	"gamemode":        {"survival", "creative", "adventure", "spectator"},
+	"gamerule":        nil,
+	"give":            nil,
+	"help":            nil,
+	"kick":            nil,
+	"kill":            nil,
+	"list":            {"uuids"},
+	"locate":          {"structure", "biome", "poi"},
+	"me":              nil,
+	"msg":             nil,
+	"op":              nil,
+	"pardon":          nil,
+	"pardon-ip":       nil,
+	"save-all":        {"flush"},
+	"save-off":        nil,
+	"save-on":         nil,
+	"say":             nil,
+	"seed":            nil,
+	"setblock":        nil,
+	"setidletimeout":  nil,
+	"setworldspawn":   nil,
+	"spawnpoint":      nil,
+	"stop":            nil,
+	"summon":          nil,
+	"tell":            nil,
+	"tellraw":         nil,
+	"time":            {"add", "query", "set"},
+	"title":           nil,
+	"tp":              nil,
+	"weather":         {"clear", "rain", "thunder"},
+	"whitelist":       {"add", "list", "off", "on", "reload", "remove"},
+	"worldborder":     {"add", "center", "damage", "get", "set", "warning"},
+	"xp":              {"add", "query", "set"},
+}
+
+// newConsoleCompleter builds the tab completer for console commands
+func newConsoleCompleter() *readline.PrefixCompleter {
+	names := make([]string, 0, len(consoleCommands)+len(consoleExitCommands))
+	for name := range consoleCommands {
+		names = append(names, name)
+	}
+	names = append(names, consoleExitCommands...)
+	sort.Strings(names)
+
+	items := make([]readline.PrefixCompleterInterface, 0, len(names))
+	for _, name := range names {
+		args := consoleCommands[name]
+		children := make([]readline.PrefixCompleterInterface, 0, len(args))
+		for _, arg := range args {
+			children = append(children, readline.PcItem(arg))
+		}
+		items = append(items, readline.PcItem(name, children...))
+	}
+
+	return readline.NewPrefixCompleter(items...)
+}
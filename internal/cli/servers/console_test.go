@@ -0,0 +1,158 @@
+package servers
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/steviee/go-mc/internal/rcon/rcontest"
+	"github.com/steviee/go-mc/internal/state"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewConsoleCommand(t *testing.T) {
+	cmd := NewConsoleCommand()
+
+	assert.Equal(t, "console <name>", cmd.Use)
+	assert.NotEmpty(t, cmd.Short)
+	assert.NotEmpty(t, cmd.Long)
+	assert.NotEmpty(t, cmd.Example)
+
+	for _, flag := range []string{"no-logs", "tail", "timeout"} {
+		assert.NotNil(t, cmd.Flags().Lookup(flag), "missing flag %q", flag)
+	}
+
+	assert.Error(t, cmd.Args(cmd, []string{}))
+	assert.NoError(t, cmd.Args(cmd, []string{"myserver"}))
+}
+
+func TestRunConsole(t *testing.T) {
+	tmpDir := t.TempDir()
+	setupTestStateDir(t, tmpDir)
+
+	srv := rcontest.NewServer(t, "secret", func(command string) string {
+		if command == "list" {
+			return "There are 0 of a max of 20 players online: "
+		}
+		return ""
+	})
+	saveRCONServerState(t, "myserver", srv)
+
+	stdin := io.NopCloser(strings.NewReader("list\n/say hello\n\nexit\nseed\n"))
+	var stdout bytes.Buffer
+
+	err := runConsole(context.Background(), stdin, &stdout, "myserver", &ConsoleFlags{NoLogs: true, Timeout: time.Second})
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"list", "say hello"}, srv.Commands())
+	assert.Contains(t, stdout.String(), "Connected to 'myserver'")
+	assert.Contains(t, stdout.String(), "There are 0 of a max of 20 players online: ")
+
+	historyPath, err := state.GetConsoleHistoryPath("myserver")
+	require.NoError(t, err)
+	history, err := os.ReadFile(historyPath)
+	require.NoError(t, err)
+	assert.Contains(t, string(history), "list")
+}
+
+func TestRunConsole_ServerNotReachable(t *testing.T) {
+	tmpDir := t.TempDir()
+	setupTestStateDir(t, tmpDir)
+
+	srv := rcontest.NewServer(t, "secret", nil)
+	saveRCONServerState(t, "myserver", srv)
+	srv.Close()
+
+	stdin := io.NopCloser(strings.NewReader("list\n"))
+	var stdout bytes.Buffer
+
+	err := runConsole(context.Background(), stdin, &stdout, "myserver", &ConsoleFlags{NoLogs: true, Timeout: time.Second})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "is it running?")
+}
+
+func TestIsConsoleExitCommand(t *testing.T) {
+	tests := []struct {
+		command string
+		want    bool
+	}{
+		{"exit", true},
+		{"quit", true},
+		{"EXIT", true},
+		{"stop", false},
+		{"list", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.command, func(t *testing.T) {
+			assert.Equal(t, tt.want, isConsoleExitCommand(tt.command))
+		})
+	}
+}
+
+func TestNewConsoleCompleter(t *testing.T) {
+	completer := newConsoleCompleter()
+
+	tests := []struct {
+		name string
+		line string
+		want []string
+	}{
+		{
+			name: "completes command",
+			line: "gamem",
+			want: []string{"ode "},
+		},
+		{
+			name: "completes argument",
+			line: "weather th",
+			want: []string{"under "},
+		},
+		{
+			name: "lists multiple candidates",
+			line: "save-",
+			want: []string{"all ", "off ", "on "},
+		},
+		{
+			name: "no candidates",
+			line: "nosuchcommand",
+			want: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			candidates, _ := completer.Do([]rune(tt.line), len(tt.line))
+
+			got := make([]string, 0, len(candidates))
+			for _, c := range candidates {
+				got = append(got, string(c))
+			}
+
+			if tt.want == nil {
+				assert.Empty(t, got)
+				return
+			}
+			assert.ElementsMatch(t, tt.want, got)
+		})
+	}
+}
+
+func TestConsoleHistoryPath(t *testing.T) {
+	tmpDir := t.TempDir()
+	setupTestStateDir(t, tmpDir)
+
+	path, err := consoleHistoryPath("myserver")
+	require.NoError(t, err)
+	assert.Equal(t, "myserver", filepath.Base(path))
+
+	info, err := os.Stat(filepath.Dir(path))
+	require.NoError(t, err)
+	assert.True(t, info.IsDir())
+}
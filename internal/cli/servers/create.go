@@ -9,44 +9,51 @@ import (
 	"log/slog"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/steviee/go-mc/internal/container"
 	"github.com/steviee/go-mc/internal/minecraft"
 	"github.com/steviee/go-mc/internal/mods"
+	"github.com/steviee/go-mc/internal/mrpack"
+	"github.com/steviee/go-mc/internal/server"
 	"github.com/steviee/go-mc/internal/state"
 )
 
 const (
-	// Default Minecraft version (hardcoded for now as per requirements)
+	// Fallback Minecraft version when the latest release cannot be fetched
 	defaultMinecraftVersion = "1.21.1"
 
-	// Default memory allocation
-	defaultMemory = "2G"
-
-	// Default starting port
-	defaultStartPort = 25565
-
-	// RCON port offset from game port
-	rconPortOffset = 10000
-
-	// Container image
-	containerImage = "itzg/minecraft-server:latest"
+	// Version value that selects the latest Minecraft release
+	latestMinecraftVersion = "latest"
 )
 
 // CreateFlags holds all flags for the create command
 type CreateFlags struct {
 	Version       string
+	VersionSet    bool
 	Memory        string
 	Port          int
 	Mods          []string
+	FromMrpack    string
 	Start         bool
 	DryRun        bool
 	WithLithium   bool
 	WithVoiceChat bool
 	WithGeyser    bool
 	WithBlueMap   bool
+
+	Restart        string
+	RestartRetries int
+	RestartBackoff time.Duration
+
+	CPUs       float64
+	CPUShares  uint64
+	PidsLimit  int64
+	MemorySwap string
+	IOWeight   uint16
 }
 
 // ServerConfig holds the configuration for creating a server
@@ -54,11 +61,20 @@ type ServerConfig struct {
 	Name        string
 	Version     string
 	Memory      string
+	Image       string
+	PullPolicy  string
 	Port        int
 	RCONPort    int
+	QueryPort   int
 	Mods        []string
 	RCONPass    string
 	ContainerID string
+	Restart     state.RestartPolicy
+	Resources   state.ResourcesConfig
+
+	// Set when the server is created from a modpack
+	FabricLoaderVersion string
+	Pack                string
 }
 
 // CreateOutput holds the output for JSON mode
@@ -82,12 +98,22 @@ The server will be created in a Podman container with the itzg/minecraft-server
 All configuration is stored in YAML files under ~/.config/go-mc/.
 
 Smart defaults (Omakase principle):
-  - Minecraft version: 1.21.1 (latest stable)
+  - Minecraft version: latest stable release
   - Memory: 2G
   - Port: Auto-allocated starting from 25565
   - Fabric: Latest compatible version
   - RCON: Auto-generated secure password
 
+Defaults for the image, version, memory, port range and RCON password length
+are read from the go-mc config file (see 'go-mc config') and can be overridden
+with GOMC_* environment variables, e.g. GOMC_DEFAULTS_MEMORY=4G.
+
+With --from-mrpack the server is set up from a Modrinth modpack: a local
+.mrpack file, a version ID or a project ID or slug (latest Fabric version).
+The pack pins the Minecraft and Fabric loader versions, its server-side files
+are downloaded and verified against their hashes, and its overrides and
+server overrides are copied into the data directory.
+
 The server is created in a stopped state. Use --start to start it immediately.`,
 		Example: `  # Create a server with defaults (includes Fabric API automatically)
   go-mc servers create myserver
@@ -104,6 +130,9 @@ The server is created in a stopped state. Use --start to start it immediately.`,
   # Create with Bedrock support and web map (allocates ports automatically)
   go-mc servers create myserver --with-geyser --with-bluemap
 
+  # Create with a share of the host: 2 CPUs, 6G of memory plus swap
+  go-mc servers create myserver --memory 4G --cpus 2 --memory-swap 6G
+
   # Create with multiple mods and start immediately
   go-mc servers create myserver --with-lithium --with-voice-chat --start
 
@@ -111,30 +140,56 @@ The server is created in a stopped state. Use --start to start it immediately.`,
   go-mc servers create myserver --dry-run
 
   # Create with custom mods via slug
-  go-mc servers create myserver --mods sodium,phosphor`,
+  go-mc servers create myserver --mods lithium,ferritecore
+
+  # Create from a Modrinth modpack file, version ID or project
+  go-mc servers create myserver --from-mrpack pack.mrpack
+  go-mc servers create myserver --from-mrpack fabulously-optimized`,
 		Args: requireServerName,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runCreate(cmd.Context(), cmd.OutOrStdout(), cmd.ErrOrStderr(), args[0], flags)
+			cfg, err := state.ResolveConfig(cmd.Context())
+			if err != nil {
+				return err
+			}
+			applyCreateConfig(cmd.Flags().Changed, flags, cfg)
+			flags.VersionSet = cmd.Flags().Changed("version")
+
+			return runCreate(cmd.Context(), cmd.OutOrStdout(), cmd.ErrOrStderr(), args[0], flags, cfg)
 		},
 	}
 
 	// Add flags
-	cmd.Flags().StringVar(&flags.Version, "version", defaultMinecraftVersion, "Minecraft version")
-	cmd.Flags().StringVar(&flags.Memory, "memory", defaultMemory, "RAM allocation (e.g., 2G, 4G, 512M)")
-	cmd.Flags().IntVar(&flags.Port, "port", 0, "Server port (default: auto-allocate from 25565)")
+	cmd.Flags().StringVar(&flags.Version, "version", "", "Minecraft version (default: defaults.minecraft_version from config)")
+	cmd.Flags().StringVar(&flags.Memory, "memory", "", "RAM allocation, e.g. 2G, 4G, 512M (default: defaults.memory from config)")
+	cmd.Flags().IntVar(&flags.Port, "port", 0, "Server port (default: auto-allocate from ports.game_port_start)")
 	cmd.Flags().StringSliceVar(&flags.Mods, "mods", []string{}, "Comma-separated mod slugs for initial installation")
+	cmd.Flags().StringVar(&flags.FromMrpack, "from-mrpack", "", "Install a Modrinth modpack: .mrpack file, version ID or project")
 	cmd.Flags().BoolVar(&flags.Start, "start", false, "Start server immediately after creation")
 	cmd.Flags().BoolVar(&flags.DryRun, "dry-run", false, "Show configuration without creating")
 	cmd.Flags().BoolVar(&flags.WithLithium, "with-lithium", false, "Install Lithium (performance optimization)")
 	cmd.Flags().BoolVar(&flags.WithVoiceChat, "with-voice-chat", false, "Install Simple Voice Chat (proximity voice)")
 	cmd.Flags().BoolVar(&flags.WithGeyser, "with-geyser", false, "Install Geyser (Bedrock client support)")
 	cmd.Flags().BoolVar(&flags.WithBlueMap, "with-bluemap", false, "Install BlueMap (3D web map)")
+	cmd.Flags().StringVar(&flags.Restart, "restart", state.RestartNo, "Restart policy when the server crashes or hangs: no, on-failure, always")
+	cmd.Flags().IntVar(&flags.RestartRetries, "restart-retries", 0, "Consecutive restarts before giving up, for --restart on-failure (0 = unlimited)")
+	cmd.Flags().DurationVar(&flags.RestartBackoff, "restart-backoff", state.DefaultRestartBackoff, "Delay before the first restart, doubled for each further one")
+	addResourceFlags(cmd, flags)
 
 	return cmd
 }
 
+// applyCreateConfig fills the flags the user did not set from the config
+func applyCreateConfig(changed func(name string) bool, flags *CreateFlags, cfg *state.Config) {
+	if !changed("version") {
+		flags.Version = cfg.Defaults.MinecraftVersion
+	}
+	if !changed("memory") {
+		flags.Memory = cfg.Defaults.Memory
+	}
+}
+
 // runCreate executes the create command
-func runCreate(ctx context.Context, stdout, stderr io.Writer, name string, flags *CreateFlags) error {
+func runCreate(ctx context.Context, stdout, stderr io.Writer, name string, flags *CreateFlags, cfg *state.Config) error {
 	jsonMode := isJSONMode()
 
 	// Validate server name
@@ -162,11 +217,31 @@ func runCreate(ctx context.Context, stdout, stderr io.Writer, name string, flags
 		_, _ = fmt.Fprintf(stdout, "Cleaned up orphaned registration for %q\n", name)
 	}
 
+	// Pin the versions of the modpack
+	var pack *mrpack.Pack
+	var fabricLoader string
+	if flags.FromMrpack != "" {
+		var closePack func()
+		pack, closePack, err = openMrpack(ctx, flags.FromMrpack, flags.Version)
+		if err != nil {
+			return outputError(stdout, jsonMode, err)
+		}
+		defer closePack()
+
+		if fabricLoader, err = applyMrpack(flags, &pack.Index); err != nil {
+			return outputError(stdout, jsonMode, err)
+		}
+	}
+
 	// Validate and build configuration
-	config, err := buildServerConfig(ctx, name, flags)
+	config, err := buildServerConfig(ctx, name, flags, cfg)
 	if err != nil {
 		return outputError(stdout, jsonMode, err)
 	}
+	if pack != nil {
+		config.FabricLoaderVersion = fabricLoader
+		config.Pack = strings.TrimSpace(pack.Index.Name + " " + pack.Index.VersionID)
+	}
 
 	// If dry-run, just show configuration
 	if flags.DryRun {
@@ -184,7 +259,7 @@ func runCreate(ctx context.Context, stdout, stderr io.Writer, name string, flags
 	}
 
 	// Create container
-	containerClient, err := container.NewClient(ctx, container.DefaultConfig())
+	containerClient, err := server.NewContainerClient(ctx, cfg)
 	if err != nil {
 		return outputError(stdout, jsonMode, fmt.Errorf("failed to create container client: %w", err))
 	}
@@ -198,7 +273,7 @@ func runCreate(ctx context.Context, stdout, stderr io.Writer, name string, flags
 	config.ContainerID = containerID
 
 	// Allocate ports in global state
-	if err := allocatePorts(ctx, config.Port, config.RCONPort); err != nil {
+	if err := allocatePorts(ctx, config.Port, config.RCONPort, config.QueryPort); err != nil {
 		// Cleanup container on failure
 		_ = containerClient.RemoveContainer(ctx, containerID, &container.RemoveOptions{Force: true})
 		return outputError(stdout, jsonMode, fmt.Errorf("failed to allocate ports: %w", err))
@@ -209,6 +284,7 @@ func runCreate(ctx context.Context, stdout, stderr io.Writer, name string, flags
 		// Cleanup on failure
 		_ = state.ReleasePort(ctx, config.Port)
 		_ = state.ReleasePort(ctx, config.RCONPort)
+		_ = state.ReleasePort(ctx, config.QueryPort)
 		_ = containerClient.RemoveContainer(ctx, containerID, &container.RemoveOptions{Force: true})
 		return outputError(stdout, jsonMode, fmt.Errorf("failed to register server: %w", err))
 	}
@@ -220,12 +296,23 @@ func runCreate(ctx context.Context, stdout, stderr io.Writer, name string, flags
 		_ = state.UnregisterServer(ctx, name)
 		_ = state.ReleasePort(ctx, config.Port)
 		_ = state.ReleasePort(ctx, config.RCONPort)
+		_ = state.ReleasePort(ctx, config.QueryPort)
 		_ = containerClient.RemoveContainer(ctx, containerID, &container.RemoveOptions{Force: true})
 		return outputError(stdout, jsonMode, fmt.Errorf("failed to save server state: %w", err))
 	}
 
+	// Install the modpack
+	if pack != nil {
+		if err := installMrpack(ctx, name, pack, cfg, stdout, jsonMode); err != nil {
+			slog.Warn("failed to install modpack", "error", err)
+			if !jsonMode {
+				_, _ = fmt.Fprintf(stderr, "Warning: Failed to install modpack: %v\n", err)
+			}
+		}
+	}
+
 	// Install mods if requested
-	if err := installModsIfRequested(ctx, name, flags, stdout, stderr, jsonMode); err != nil {
+	if err := installModsIfRequested(ctx, name, flags, cfg, stdout, stderr, jsonMode); err != nil {
 		// Don't fail completely, just log the error
 		slog.Warn("failed to install mods", "error", err)
 		if !jsonMode {
@@ -233,6 +320,19 @@ func runCreate(ctx context.Context, stdout, stderr io.Writer, name string, flags
 		}
 	}
 
+	// Publish the ports of installed mods (e.g. voice chat)
+	if updated, err := state.LoadServerState(ctx, name); err == nil {
+		serverState = updated
+		if _, err := server.Reconcile(ctx, containerClient, serverState); err != nil {
+			slog.Warn("failed to publish mod ports", "error", err)
+			if !jsonMode {
+				_, _ = fmt.Fprintf(stderr, "Warning: Failed to publish mod ports: %v\n", err)
+			}
+		}
+		containerID = serverState.ContainerID
+		config.ContainerID = containerID
+	}
+
 	// Start container if requested
 	if flags.Start {
 		if err := containerClient.StartContainer(ctx, containerID); err != nil {
@@ -255,11 +355,25 @@ func runCreate(ctx context.Context, stdout, stderr io.Writer, name string, flags
 }
 
 // buildServerConfig builds and validates the server configuration
-func buildServerConfig(ctx context.Context, name string, flags *CreateFlags) (*ServerConfig, error) {
+func buildServerConfig(ctx context.Context, name string, flags *CreateFlags, cfg *state.Config) (*ServerConfig, error) {
+	// Enforce the configured server limit
+	existing, err := state.ListServers(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list servers: %w", err)
+	}
+	if len(existing) >= cfg.Limits.MaxServers {
+		return nil, fmt.Errorf("server limit reached (%d of %d, see limits.max_servers)", len(existing), cfg.Limits.MaxServers)
+	}
+
+	// Enforce the configured port limit for the game, RCON and query ports
+	if err := state.CheckPortLimit(ctx, 3, cfg.Limits.MaxPorts); err != nil {
+		return nil, err
+	}
+
 	version := flags.Version
 
-	// Auto-detect latest Minecraft version if not specified or using default
-	if version == "" || version == defaultMinecraftVersion {
+	// Auto-detect latest Minecraft version if not specified
+	if version == "" || version == latestMinecraftVersion {
 		slog.Debug("fetching latest Minecraft version")
 		mcClient := minecraft.NewClient(nil)
 		manifest, err := mcClient.GetVersionManifest(ctx)
@@ -273,10 +387,29 @@ func buildServerConfig(ctx context.Context, name string, flags *CreateFlags) (*S
 	}
 
 	config := &ServerConfig{
-		Name:    name,
-		Version: version,
-		Memory:  flags.Memory,
-		Mods:    flags.Mods,
+		Name:       name,
+		Version:    version,
+		Memory:     flags.Memory,
+		Image:      cfg.Container.Image,
+		PullPolicy: cfg.Container.PullPolicy,
+		Mods:       flags.Mods,
+		Restart: state.RestartPolicy{
+			Policy:     flags.Restart,
+			MaxRetries: flags.RestartRetries,
+			Backoff:    flags.RestartBackoff,
+		},
+		Resources: state.ResourcesConfig{
+			CPUs:       flags.CPUs,
+			CPUShares:  flags.CPUShares,
+			PidsLimit:  flags.PidsLimit,
+			MemorySwap: flags.MemorySwap,
+			IOWeight:   flags.IOWeight,
+		},
+	}
+
+	// Validate restart policy
+	if err := state.ValidateRestartPolicy(config.Restart); err != nil {
+		return nil, fmt.Errorf("invalid restart policy: %w", err)
 	}
 
 	// Validate version
@@ -288,6 +421,17 @@ func buildServerConfig(ctx context.Context, name string, flags *CreateFlags) (*S
 	if err := state.ValidateMemory(config.Memory); err != nil {
 		return nil, fmt.Errorf("invalid memory format: %w", err)
 	}
+	if err := state.ValidateMemoryLimit(config.Memory, cfg.Limits.MaxMemoryPerServer); err != nil {
+		return nil, fmt.Errorf("invalid memory: %w (see limits.max_memory_per_server)", err)
+	}
+
+	// Validate resource limits
+	if err := state.ValidateResources(config.Resources, config.Memory); err != nil {
+		return nil, fmt.Errorf("invalid resources: %w", err)
+	}
+	if err := state.ValidateResourceLimits(config.Resources, cfg.Limits); err != nil {
+		return nil, err
+	}
 
 	// Allocate port
 	if flags.Port != 0 {
@@ -308,40 +452,50 @@ func buildServerConfig(ctx context.Context, name string, flags *CreateFlags) (*S
 		config.Port = flags.Port
 	} else {
 		// Auto-allocate port
-		port, err := state.GetNextAvailablePort(ctx, defaultStartPort)
+		port, err := state.GetNextAvailablePort(ctx, cfg.Ports.GamePortStart)
 		if err != nil {
 			return nil, fmt.Errorf("failed to allocate port: %w", err)
 		}
 		config.Port = port
 	}
 
-	// Calculate RCON port
-	config.RCONPort = config.Port + rconPortOffset
-
-	// Validate RCON port
-	if err := state.ValidatePort(config.RCONPort); err != nil {
-		return nil, fmt.Errorf("invalid RCON port %d (calculated from game port): %w", config.RCONPort, err)
+	// Allocate RCON and query ports, skipping the ports already chosen
+	rconPort, err := nextFreePort(ctx, cfg.Ports.RconPortStart, config.Port)
+	if err != nil {
+		return nil, fmt.Errorf("failed to allocate RCON port: %w", err)
 	}
+	config.RCONPort = rconPort
 
-	// Check if RCON port is already allocated
-	allocated, err := state.IsPortAllocated(ctx, config.RCONPort)
+	queryPort, err := nextFreePort(ctx, cfg.Ports.QueryPortStart, config.Port, config.RCONPort)
 	if err != nil {
-		return nil, fmt.Errorf("failed to check RCON port allocation: %w", err)
-	}
-	if allocated {
-		return nil, fmt.Errorf("RCON port %d is already allocated (calculated from game port %d)", config.RCONPort, config.Port)
+		return nil, fmt.Errorf("failed to allocate query port: %w", err)
 	}
+	config.QueryPort = queryPort
 
 	// Generate RCON password
-	config.RCONPass = generateRCONPassword()
+	config.RCONPass = generateRCONPassword(cfg.Ports.RconPasswordLength)
 
 	return config, nil
 }
 
+// nextFreePort returns the first unallocated port from start that is not
+// one of the reserved ports
+func nextFreePort(ctx context.Context, start int, reserved ...int) (int, error) {
+	for port := start; ; port++ {
+		next, err := state.GetNextAvailablePort(ctx, port)
+		if err != nil {
+			return 0, err
+		}
+		if !slices.Contains(reserved, next) {
+			return next, nil
+		}
+		port = next
+	}
+}
+
 // generateRCONPassword generates a secure random password for RCON
-func generateRCONPassword() string {
+func generateRCONPassword(length int) string {
 	const charset = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
-	const length = 16
 
 	b := make([]byte, length)
 	if _, err := rand.Read(b); err != nil {
@@ -387,45 +541,8 @@ func createServerDirectories(name string) error {
 
 // createContainer creates the container with the given configuration
 func createContainer(ctx context.Context, client container.Client, config *ServerConfig, name string) (string, error) {
-	homeDir, err := os.UserHomeDir()
-	if err != nil {
-		return "", fmt.Errorf("failed to get home directory: %w", err)
-	}
-
-	// Use XDG_DATA_HOME or default to ~/.local/share
-	dataHome := os.Getenv("XDG_DATA_HOME")
-	if dataHome == "" {
-		dataHome = filepath.Join(homeDir, ".local", "share")
-	}
-
-	dataDir := filepath.Join(dataHome, "go-mc", "servers", name, "data")
-	modsDir := filepath.Join(dataHome, "go-mc", "servers", name, "mods")
-
-	containerConfig := &container.ContainerConfig{
-		Name:  name,
-		Image: containerImage,
-		Env: map[string]string{
-			"TYPE":          "FABRIC",
-			"EULA":          "TRUE",
-			"VERSION":       config.Version,
-			"MEMORY":        config.Memory,
-			"RCON_PASSWORD": config.RCONPass,
-			"ENABLE_RCON":   "true",
-		},
-		Ports: map[int]int{
-			config.Port:     25565, // Game port
-			config.RCONPort: 25575, // RCON port
-		},
-		Volumes: map[string]string{
-			dataDir: "/data",
-			modsDir: "/data/mods",
-		},
-		Labels: map[string]string{
-			"go-mc.server":  name,
-			"go-mc.version": config.Version,
-			"go-mc.managed": "true",
-		},
-	}
+	containerConfig := server.ContainerConfig(buildServerState(config, name))
+	containerConfig.PullPolicy = config.PullPolicy
 
 	containerID, err := client.CreateContainer(ctx, containerConfig)
 	if err != nil {
@@ -436,13 +553,14 @@ func createContainer(ctx context.Context, client container.Client, config *Serve
 		"name", name,
 		"id", containerID,
 		"port", config.Port,
-		"rcon_port", config.RCONPort)
+		"rcon_port", config.RCONPort,
+		"query_port", config.QueryPort)
 
 	return containerID, nil
 }
 
-// allocatePorts allocates the game port and RCON port in global state
-func allocatePorts(ctx context.Context, gamePort, rconPort int) error {
+// allocatePorts allocates the game, RCON and query ports in global state
+func allocatePorts(ctx context.Context, gamePort, rconPort, queryPort int) error {
 	if err := state.AllocatePort(ctx, gamePort); err != nil {
 		return fmt.Errorf("failed to allocate game port: %w", err)
 	}
@@ -453,6 +571,13 @@ func allocatePorts(ctx context.Context, gamePort, rconPort int) error {
 		return fmt.Errorf("failed to allocate RCON port: %w", err)
 	}
 
+	if err := state.AllocatePort(ctx, queryPort); err != nil {
+		// Cleanup game and RCON port allocations
+		_ = state.ReleasePort(ctx, gamePort)
+		_ = state.ReleasePort(ctx, rconPort)
+		return fmt.Errorf("failed to allocate query port: %w", err)
+	}
+
 	return nil
 }
 
@@ -461,16 +586,20 @@ func buildServerState(config *ServerConfig, name string) *state.ServerState {
 	serverState := state.NewServerState(name)
 
 	serverState.ContainerID = config.ContainerID
-	serverState.Image = containerImage
+	serverState.Image = config.Image
 	serverState.Status = state.StatusStopped
 
 	serverState.Minecraft = state.MinecraftConfig{
-		Version:      config.Version,
-		Memory:       config.Memory,
-		GamePort:     config.Port,
-		RconPort:     config.RCONPort,
-		RconPassword: config.RCONPass,
+		Version:             config.Version,
+		FabricLoaderVersion: config.FabricLoaderVersion,
+		Memory:              config.Memory,
+		GamePort:            config.Port,
+		RconPort:            config.RCONPort,
+		RconPassword:        config.RCONPass,
+		QueryPort:           config.QueryPort,
 	}
+	serverState.Restart = config.Restart
+	serverState.Resources = config.Resources
 
 	homeDir, _ := os.UserHomeDir()
 	dataHome := os.Getenv("XDG_DATA_HOME")
@@ -492,12 +621,17 @@ func showDryRun(stdout io.Writer, jsonMode bool, config *ServerConfig) error {
 		output := CreateOutput{
 			Status: "dry-run",
 			Data: map[string]interface{}{
-				"name":      config.Name,
-				"version":   config.Version,
-				"port":      config.Port,
-				"rcon_port": config.RCONPort,
-				"memory":    config.Memory,
-				"mods":      config.Mods,
+				"name":       config.Name,
+				"version":    config.Version,
+				"port":       config.Port,
+				"rcon_port":  config.RCONPort,
+				"query_port": config.QueryPort,
+				"restart":    config.Restart.Name(),
+				"memory":     config.Memory,
+				"resources":  newResourceLimits(config.Resources),
+				"image":      config.Image,
+				"mods":       config.Mods,
+				"mrpack":     config.Pack,
 			},
 			Message: "Dry run - no changes made",
 		}
@@ -509,9 +643,15 @@ func showDryRun(stdout io.Writer, jsonMode bool, config *ServerConfig) error {
 	_, _ = fmt.Fprintf(stdout, "  Version:     %s (Fabric)\n", config.Version)
 	_, _ = fmt.Fprintf(stdout, "  Port:        %d\n", config.Port)
 	_, _ = fmt.Fprintf(stdout, "  RCON Port:   %d\n", config.RCONPort)
+	_, _ = fmt.Fprintf(stdout, "  Query Port:  %d/udp\n", config.QueryPort)
+	_, _ = fmt.Fprintf(stdout, "  Restart:     %s\n", formatRestartPolicy(config.Restart))
 	_, _ = fmt.Fprintf(stdout, "  Memory:      %s\n", config.Memory)
-	_, _ = fmt.Fprintf(stdout, "  Container:   %s\n", containerImage)
+	_, _ = fmt.Fprintf(stdout, "  Resources:   %s\n", formatResources(config.Resources))
+	_, _ = fmt.Fprintf(stdout, "  Container:   %s\n", config.Image)
 
+	if config.Pack != "" {
+		_, _ = fmt.Fprintf(stdout, "  Modpack:     %s (Fabric loader %s)\n", config.Pack, valueOrDash(config.FabricLoaderVersion))
+	}
 	if len(config.Mods) > 0 {
 		_, _ = fmt.Fprintf(stdout, "  Mods:        %s\n", strings.Join(config.Mods, ", "))
 	}
@@ -536,6 +676,7 @@ func outputSuccess(stdout io.Writer, jsonMode bool, config *ServerConfig, starte
 				"version":      config.Version,
 				"port":         config.Port,
 				"rcon_port":    config.RCONPort,
+				"query_port":   config.QueryPort,
 				"memory":       config.Memory,
 				"container_id": config.ContainerID,
 				"state":        status,
@@ -548,6 +689,7 @@ func outputSuccess(stdout io.Writer, jsonMode bool, config *ServerConfig, starte
 	_, _ = fmt.Fprintf(stdout, "  Minecraft: %s (Fabric)\n", config.Version)
 	_, _ = fmt.Fprintf(stdout, "  Port:      %d\n", config.Port)
 	_, _ = fmt.Fprintf(stdout, "  RCON:      %d\n", config.RCONPort)
+	_, _ = fmt.Fprintf(stdout, "  Query:     %d/udp\n", config.QueryPort)
 	_, _ = fmt.Fprintf(stdout, "  Memory:    %s\n", config.Memory)
 	_, _ = fmt.Fprintf(stdout, "  Container: %s\n", config.ContainerID[:12])
 
@@ -573,12 +715,15 @@ func outputError(stdout io.Writer, jsonMode bool, err error) error {
 }
 
 // installModsIfRequested installs mods based on flags
-func installModsIfRequested(ctx context.Context, serverName string, flags *CreateFlags, stdout, stderr io.Writer, jsonMode bool) error {
-	installer := mods.NewInstaller()
-
-	// Always ensure Fabric API is installed (Omakase principle)
-	if err := installer.EnsureFabricAPI(ctx, serverName); err != nil {
-		return fmt.Errorf("ensure Fabric API: %w", err)
+func installModsIfRequested(ctx context.Context, serverName string, flags *CreateFlags, cfg *state.Config, stdout, stderr io.Writer, jsonMode bool) error {
+	installer := mods.NewInstallerFromConfig(cfg)
+
+	// Always ensure Fabric API is installed (Omakase principle), unless a
+	// modpack defines the mods
+	if flags.FromMrpack == "" {
+		if err := installer.EnsureFabricAPI(ctx, serverName); err != nil {
+			return fmt.Errorf("ensure Fabric API: %w", err)
+		}
 	}
 
 	// Build list of mods to install
@@ -619,3 +764,26 @@ func installModsIfRequested(ctx context.Context, serverName string, flags *Creat
 
 	return nil
 }
+
+// installMrpack installs the server side of a modpack
+func installMrpack(ctx context.Context, serverName string, pack *mrpack.Pack, cfg *state.Config, stdout io.Writer, jsonMode bool) error {
+	if !jsonMode {
+		_, _ = fmt.Fprintf(stdout, "\nInstalling modpack %s...\n", pack.Index.Name)
+	}
+
+	installer := mods.NewInstallerFromConfig(cfg)
+	result, err := installer.InstallPack(ctx, serverName, pack)
+	if err != nil {
+		return err
+	}
+
+	if !jsonMode {
+		_, _ = fmt.Fprintf(stdout, "Installed %d mod(s), %d other file(s) and %d override(s)", len(result.Installed), result.Files, result.Overrides)
+		if result.Skipped > 0 {
+			_, _ = fmt.Fprintf(stdout, "; skipped %d client-only file(s)", result.Skipped)
+		}
+		_, _ = fmt.Fprintln(stdout)
+	}
+
+	return nil
+}
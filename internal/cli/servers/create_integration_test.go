@@ -99,7 +99,7 @@ func TestCreateCommand_Integration(t *testing.T) {
 			var stdout, stderr bytes.Buffer
 
 			// Run create command
-			err := runCreate(ctx, &stdout, &stderr, tt.serverName, tt.flags)
+			err := runCreate(ctx, &stdout, &stderr, tt.serverName, tt.flags, state.DefaultConfig())
 
 			if tt.wantErr {
 				require.Error(t, err)
@@ -134,7 +134,7 @@ func TestCreateCommand_Integration(t *testing.T) {
 			if tt.flags.Port != 0 {
 				assert.Equal(t, tt.flags.Port, serverState.Minecraft.GamePort)
 			} else {
-				assert.GreaterOrEqual(t, serverState.Minecraft.GamePort, defaultStartPort)
+				assert.GreaterOrEqual(t, serverState.Minecraft.GamePort, state.DefaultConfig().Ports.GamePortStart)
 			}
 
 			allocated, err := state.IsPortAllocated(ctx, serverState.Minecraft.GamePort)
@@ -196,7 +196,7 @@ func TestCreateCommand_Integration(t *testing.T) {
 		}
 
 		// Create first server
-		err := runCreate(ctx, &stdout, &stderr, serverName, flags)
+		err := runCreate(ctx, &stdout, &stderr, serverName, flags, state.DefaultConfig())
 		require.NoError(t, err)
 
 		// Load state for cleanup
@@ -206,7 +206,7 @@ func TestCreateCommand_Integration(t *testing.T) {
 		// Try to create duplicate
 		stdout.Reset()
 		stderr.Reset()
-		err = runCreate(ctx, &stdout, &stderr, serverName, flags)
+		err = runCreate(ctx, &stdout, &stderr, serverName, flags, state.DefaultConfig())
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "already exists")
 
@@ -252,7 +252,7 @@ func TestCreateCommand_StartFlag_Integration(t *testing.T) {
 	}
 
 	// Create and start server
-	err = runCreate(ctx, &stdout, &stderr, serverName, flags)
+	err = runCreate(ctx, &stdout, &stderr, serverName, flags, state.DefaultConfig())
 	require.NoError(t, err, "stdout: %s\nstderr: %s", stdout.String(), stderr.String())
 
 	// Load server state
@@ -6,6 +6,7 @@ import (
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/steviee/go-mc/internal/state"
 	"github.com/stretchr/testify/assert"
@@ -23,7 +24,7 @@ func TestGenerateRCONPassword(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			password := generateRCONPassword()
+			password := generateRCONPassword(16)
 
 			// Check length
 			assert.Len(t, password, 16, "password should be 16 characters")
@@ -40,7 +41,7 @@ func TestGenerateRCONPassword(t *testing.T) {
 	// Test uniqueness
 	passwords := make(map[string]bool)
 	for i := 0; i < 100; i++ {
-		password := generateRCONPassword()
+		password := generateRCONPassword(16)
 		assert.False(t, passwords[password], "passwords should be unique")
 		passwords[password] = true
 	}
@@ -123,7 +124,7 @@ func TestBuildServerConfig(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			config, err := buildServerConfig(ctx, "testserver", tt.flags)
+			config, err := buildServerConfig(ctx, "testserver", tt.flags, state.DefaultConfig())
 
 			if tt.wantErr {
 				require.Error(t, err)
@@ -142,11 +143,12 @@ func TestBuildServerConfig(t *testing.T) {
 				if tt.flags.Port != 0 {
 					assert.Equal(t, tt.flags.Port, config.Port)
 				} else {
-					assert.GreaterOrEqual(t, config.Port, defaultStartPort)
+					assert.GreaterOrEqual(t, config.Port, state.DefaultConfig().Ports.GamePortStart)
 				}
 
-				// Check RCON port calculation
-				assert.Equal(t, config.Port+rconPortOffset, config.RCONPort)
+				// Check RCON port allocation
+				assert.GreaterOrEqual(t, config.RCONPort, state.DefaultConfig().Ports.RconPortStart)
+				assert.NotEqual(t, config.Port, config.RCONPort)
 			}
 
 			// Check RCON password is generated
@@ -178,12 +180,68 @@ func TestBuildServerConfig_PortAllocation(t *testing.T) {
 		Port:    0, // auto-allocate
 	}
 
-	config, err := buildServerConfig(ctx, "testserver", flags)
+	config, err := buildServerConfig(ctx, "testserver", flags, state.DefaultConfig())
 	require.NoError(t, err)
 
 	// Should allocate next available port (25567)
 	assert.Equal(t, 25567, config.Port)
-	assert.Equal(t, 25567+rconPortOffset, config.RCONPort)
+	assert.Equal(t, 25575, config.RCONPort)
+	assert.Equal(t, 25665, config.QueryPort)
+}
+
+func TestBuildServerConfig_QueryPort(t *testing.T) {
+	ctx := context.Background()
+	setupTestStateDir(t, t.TempDir())
+
+	// The next free query port would be the game port
+	require.NoError(t, state.AllocatePort(ctx, 25665))
+	cfg := state.DefaultConfig()
+
+	config, err := buildServerConfig(ctx, "testserver", &CreateFlags{Version: "1.20.4", Memory: "2G", Port: 25666}, cfg)
+	require.NoError(t, err)
+	assert.Equal(t, 25667, config.QueryPort)
+}
+
+func TestBuildServerConfig_RestartPolicy(t *testing.T) {
+	ctx := context.Background()
+	setupTestStateDir(t, t.TempDir())
+
+	flags := &CreateFlags{Version: "1.20.4", Memory: "2G", Restart: state.RestartOnFailure, RestartRetries: 3, RestartBackoff: time.Minute}
+	config, err := buildServerConfig(ctx, "testserver", flags, state.DefaultConfig())
+	require.NoError(t, err)
+	assert.Equal(t, state.RestartPolicy{Policy: state.RestartOnFailure, MaxRetries: 3, Backoff: time.Minute}, config.Restart)
+	assert.Equal(t, config.Restart, buildServerState(config, "testserver").Restart)
+
+	// Retries only apply to on-failure
+	flags.Restart = state.RestartAlways
+	_, err = buildServerConfig(ctx, "testserver", flags, state.DefaultConfig())
+	assert.ErrorContains(t, err, "invalid restart policy")
+
+	flags.Restart = "sometimes"
+	_, err = buildServerConfig(ctx, "testserver", flags, state.DefaultConfig())
+	assert.ErrorContains(t, err, "invalid restart policy")
+}
+
+func TestBuildServerConfig_Resources(t *testing.T) {
+	ctx := context.Background()
+	setupTestStateDir(t, t.TempDir())
+
+	flags := &CreateFlags{Version: "1.20.4", Memory: "4G", CPUs: 2, PidsLimit: 4096, MemorySwap: "6G", IOWeight: 200}
+	config, err := buildServerConfig(ctx, "testserver", flags, state.DefaultConfig())
+	require.NoError(t, err)
+	want := state.ResourcesConfig{CPUs: 2, PidsLimit: 4096, MemorySwap: "6G", IOWeight: 200}
+	assert.Equal(t, want, config.Resources)
+	assert.Equal(t, want, buildServerState(config, "testserver").Resources)
+
+	// Swap below the container memory limit of 5G
+	flags.MemorySwap = "4G"
+	_, err = buildServerConfig(ctx, "testserver", flags, state.DefaultConfig())
+	assert.ErrorContains(t, err, "invalid resources")
+
+	flags.MemorySwap = ""
+	flags.CPUs = 16
+	_, err = buildServerConfig(ctx, "testserver", flags, state.DefaultConfig())
+	assert.ErrorContains(t, err, "limits.max_cpus_per_server")
 }
 
 func TestBuildServerConfig_PortConflict(t *testing.T) {
@@ -202,11 +260,94 @@ func TestBuildServerConfig_PortConflict(t *testing.T) {
 		Port:    25565, // Try to use already allocated port
 	}
 
-	_, err := buildServerConfig(ctx, "testserver", flags)
+	_, err := buildServerConfig(ctx, "testserver", flags, state.DefaultConfig())
 	require.Error(t, err)
 	assert.Contains(t, err.Error(), "already allocated")
 }
 
+func TestApplyCreateConfig(t *testing.T) {
+	cfg := state.DefaultConfig()
+	cfg.Defaults.Memory = "4G"
+	cfg.Defaults.MinecraftVersion = "1.20.4"
+
+	t.Run("unset flags come from config", func(t *testing.T) {
+		flags := &CreateFlags{}
+		applyCreateConfig(func(string) bool { return false }, flags, cfg)
+
+		assert.Equal(t, "4G", flags.Memory)
+		assert.Equal(t, "1.20.4", flags.Version)
+	})
+
+	t.Run("explicit flags win", func(t *testing.T) {
+		flags := &CreateFlags{Memory: "8G", Version: "1.21.1"}
+		applyCreateConfig(func(string) bool { return true }, flags, cfg)
+
+		assert.Equal(t, "8G", flags.Memory)
+		assert.Equal(t, "1.21.1", flags.Version)
+	})
+}
+
+func TestBuildServerConfig_FromConfigFile(t *testing.T) {
+	ctx := context.Background()
+
+	tmpDir := t.TempDir()
+	setupTestStateDir(t, tmpDir)
+
+	configPath, err := state.GetConfigPath()
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(configPath, []byte(`container:
+  image: example.com/minecraft-server:stable
+defaults:
+  memory: 6G
+  minecraft_version: 1.20.4
+ports:
+  game_port_start: 30000
+  rcon_password_length: 24
+`), 0644))
+
+	cfg, err := state.ResolveConfig(ctx)
+	require.NoError(t, err)
+
+	flags := &CreateFlags{}
+	applyCreateConfig(func(string) bool { return false }, flags, cfg)
+
+	config, err := buildServerConfig(ctx, "testserver", flags, cfg)
+	require.NoError(t, err)
+
+	assert.Equal(t, "example.com/minecraft-server:stable", config.Image)
+	assert.Equal(t, "6G", config.Memory)
+	assert.Equal(t, "1.20.4", config.Version)
+	assert.Equal(t, 30000, config.Port)
+	assert.Len(t, config.RCONPass, 24)
+}
+
+func TestBuildServerConfig_Limits(t *testing.T) {
+	ctx := context.Background()
+
+	tmpDir := t.TempDir()
+	setupTestStateDir(t, tmpDir)
+
+	t.Run("memory above limit", func(t *testing.T) {
+		cfg := state.DefaultConfig()
+		cfg.Limits.MaxMemoryPerServer = "4G"
+
+		_, err := buildServerConfig(ctx, "testserver", &CreateFlags{Version: "1.20.4", Memory: "8G"}, cfg)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "limits.max_memory_per_server")
+	})
+
+	t.Run("server limit reached", func(t *testing.T) {
+		require.NoError(t, state.RegisterServer(ctx, "existing"))
+
+		cfg := state.DefaultConfig()
+		cfg.Limits.MaxServers = 1
+
+		_, err := buildServerConfig(ctx, "testserver", &CreateFlags{Version: "1.20.4", Memory: "2G"}, cfg)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "server limit reached")
+	})
+}
+
 func TestCreateServerDirectories(t *testing.T) {
 	// Use temp directory for XDG_DATA_HOME
 	tmpDir := t.TempDir()
@@ -241,7 +382,9 @@ func TestBuildServerState(t *testing.T) {
 		Memory:      "2G",
 		Port:        25565,
 		RCONPort:    35565,
+		QueryPort:   25665,
 		RCONPass:    "testpassword123",
+		Image:       "ghcr.io/itzg/minecraft-server:latest",
 		ContainerID: "abc123def456",
 	}
 
@@ -249,7 +392,7 @@ func TestBuildServerState(t *testing.T) {
 
 	assert.Equal(t, "testserver", serverState.Name)
 	assert.Equal(t, config.ContainerID, serverState.ContainerID)
-	assert.Equal(t, containerImage, serverState.Image)
+	assert.Equal(t, config.Image, serverState.Image)
 	assert.Equal(t, state.StatusStopped, serverState.Status)
 
 	// Check Minecraft config
@@ -258,6 +401,7 @@ func TestBuildServerState(t *testing.T) {
 	assert.Equal(t, config.Port, serverState.Minecraft.GamePort)
 	assert.Equal(t, config.RCONPort, serverState.Minecraft.RconPort)
 	assert.Equal(t, config.RCONPass, serverState.Minecraft.RconPassword)
+	assert.Equal(t, config.QueryPort, serverState.Minecraft.QueryPort)
 
 	// Check volumes
 	expectedDataDir := filepath.Join(tmpDir, "go-mc", "servers", "testserver", "data")
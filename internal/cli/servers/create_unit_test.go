@@ -77,6 +77,7 @@ func TestBuildServerState_AllFields(t *testing.T) {
 		Port:        25565,
 		RCONPort:    35565,
 		RCONPass:    "secure-password",
+		Image:       "ghcr.io/itzg/minecraft-server:latest",
 		ContainerID: "container-123-abc",
 		Mods:        []string{"fabric-api", "sodium"},
 	}
@@ -86,7 +87,7 @@ func TestBuildServerState_AllFields(t *testing.T) {
 	// Verify all fields are set correctly
 	assert.Equal(t, "full-config-test", serverState.Name)
 	assert.Equal(t, "container-123-abc", serverState.ContainerID)
-	assert.Equal(t, containerImage, serverState.Image)
+	assert.Equal(t, config.Image, serverState.Image)
 	assert.Equal(t, state.StatusStopped, serverState.Status)
 
 	// Verify Minecraft config
@@ -110,7 +111,7 @@ func TestGenerateRCONPassword_Fallback(t *testing.T) {
 
 	// Generate many passwords to check uniqueness
 	for i := 0; i < 1000; i++ {
-		password := generateRCONPassword()
+		password := generateRCONPassword(16)
 		passwords[password]++
 
 		// Each password should be unique
@@ -142,27 +143,22 @@ func TestBuildServerConfig_RCONPortConflict(t *testing.T) {
 
 	require.NoError(t, os.MkdirAll(configDir, 0750))
 
-	// Initialize global state
-	_, err := state.LoadGlobalState(ctx)
-	require.NoError(t, err)
-
-	// Allocate RCON port (game port + offset)
-	rconPort := 25565 + rconPortOffset
-	require.NoError(t, state.AllocatePort(ctx, rconPort))
+	// Allocate the first RCON port
+	cfg := state.DefaultConfig()
+	require.NoError(t, state.AllocatePort(ctx, cfg.Ports.RconPortStart))
 
 	flags := &CreateFlags{
 		Version: "1.21.1",
 		Memory:  "2G",
-		Port:    25565, // This would cause RCON port conflict
+		Port:    cfg.Ports.RconPortStart + 1, // Next free RCON port is the game port
 	}
 
-	_, err = buildServerConfig(ctx, "test", flags)
-	require.Error(t, err)
-	assert.Contains(t, err.Error(), "RCON port")
-	assert.Contains(t, err.Error(), "already allocated")
+	config, err := buildServerConfig(ctx, "test", flags, cfg)
+	require.NoError(t, err)
+	assert.Equal(t, cfg.Ports.RconPortStart+2, config.RCONPort)
 }
 
-func TestBuildServerConfig_InvalidRCONPort(t *testing.T) {
+func TestBuildServerConfig_PortLimit(t *testing.T) {
 	ctx := context.Background()
 
 	// Setup test state directory
@@ -173,20 +169,19 @@ func TestBuildServerConfig_InvalidRCONPort(t *testing.T) {
 
 	require.NoError(t, os.MkdirAll(configDir, 0750))
 
-	// Initialize global state
-	_, err := state.LoadGlobalState(ctx)
-	require.NoError(t, err)
+	require.NoError(t, state.AllocatePort(ctx, 25565))
 
-	// Use a port that would result in invalid RCON port (> 65535)
-	flags := &CreateFlags{
-		Version: "1.21.1",
-		Memory:  "2G",
-		Port:    65535, // RCON would be 65535 + 10000 = 75535 (invalid)
-	}
+	// A server needs a game, RCON and query port
+	cfg := state.DefaultConfig()
+	cfg.Limits.MaxPorts = 3
 
-	_, err = buildServerConfig(ctx, "test", flags)
+	_, err := buildServerConfig(ctx, "test", &CreateFlags{Version: "1.21.1", Memory: "2G"}, cfg)
 	require.Error(t, err)
-	assert.Contains(t, err.Error(), "RCON port")
+	assert.Contains(t, err.Error(), "limits.max_ports")
+
+	cfg.Limits.MaxPorts = 4
+	_, err = buildServerConfig(ctx, "test", &CreateFlags{Version: "1.21.1", Memory: "2G"}, cfg)
+	require.NoError(t, err)
 }
 
 func TestShowDryRun_WithMods(t *testing.T) {
@@ -347,7 +342,7 @@ func TestBuildServerConfig_AllEdgeCases(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			config, err := buildServerConfig(ctx, "edgecase-test", tt.flags)
+			config, err := buildServerConfig(ctx, "edgecase-test", tt.flags, state.DefaultConfig())
 
 			if tt.wantErr {
 				require.Error(t, err)
@@ -0,0 +1,136 @@
+package servers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steviee/go-mc/internal/rcon"
+	"github.com/steviee/go-mc/internal/state"
+)
+
+// ExecFlags holds all flags for the exec command
+type ExecFlags struct {
+	Timeout time.Duration
+}
+
+// ExecOutput holds the output for JSON mode
+type ExecOutput struct {
+	Status string      `json:"status"`
+	Data   *ExecResult `json:"data,omitempty"`
+	Error  string      `json:"error,omitempty"`
+}
+
+// ExecResult holds the result of a single RCON command
+type ExecResult struct {
+	Server   string `json:"server"`
+	Command  string `json:"command"`
+	Response string `json:"response"`
+}
+
+// NewExecCommand creates the servers exec subcommand
+func NewExecCommand() *cobra.Command {
+	flags := &ExecFlags{}
+
+	cmd := &cobra.Command{
+		Use:   "exec <name> <command...>",
+		Short: "Execute a console command on a running server via RCON",
+		Long: `Execute a Minecraft console command on a running server via RCON.
+
+The command is sent to the RCON port allocated for the server at creation time,
+authenticated with the server's generated RCON password. The leading slash is
+optional and is stripped before sending.`,
+		Example: `  # List online players
+  go-mc servers exec myserver list
+
+  # Broadcast a message
+  go-mc servers exec myserver say Server restarting in 5 minutes
+
+  # Flush the world to disk
+  go-mc servers exec myserver save-all flush
+
+  # JSON output for scripting
+  go-mc servers exec myserver list --json`,
+		Args: cobra.MinimumNArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runExec(cmd.Context(), cmd.OutOrStdout(), args[0], args[1:], flags)
+		},
+	}
+
+	cmd.Flags().DurationVar(&flags.Timeout, "timeout", rcon.DefaultTimeout, "Timeout for the command round trip")
+
+	return cmd
+}
+
+// runExec executes the exec command
+func runExec(ctx context.Context, stdout io.Writer, name string, args []string, flags *ExecFlags) error {
+	jsonMode := isJSONMode()
+
+	if err := state.ValidateServerName(name); err != nil {
+		return outputExecError(stdout, jsonMode, fmt.Errorf("invalid server name: %w", err))
+	}
+
+	serverState, err := state.LoadServerState(ctx, name)
+	if err != nil {
+		return outputExecError(stdout, jsonMode, fmt.Errorf("failed to load server state: %w", err))
+	}
+
+	command := strings.TrimPrefix(strings.Join(args, " "), "/")
+
+	client, err := rcon.NewServerClient(serverState, flags.Timeout)
+	if err != nil {
+		return outputExecError(stdout, jsonMode, err)
+	}
+	defer func() { _ = client.Close() }()
+
+	response, err := client.Execute(ctx, command)
+	if err != nil {
+		return outputExecError(stdout, jsonMode, fmt.Errorf("failed to execute command on server '%s': %w", name, err))
+	}
+
+	return outputExecSuccess(stdout, jsonMode, &ExecResult{
+		Server:   name,
+		Command:  command,
+		Response: response,
+	})
+}
+
+// outputExecSuccess outputs the command response in human or JSON format
+func outputExecSuccess(stdout io.Writer, jsonMode bool, result *ExecResult) error {
+	if jsonMode {
+		output := ExecOutput{
+			Status: "success",
+			Data:   result,
+		}
+		enc := json.NewEncoder(stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(output)
+	}
+
+	if result.Response == "" {
+		return nil
+	}
+
+	_, _ = fmt.Fprint(stdout, result.Response)
+	if !strings.HasSuffix(result.Response, "\n") {
+		_, _ = fmt.Fprintln(stdout)
+	}
+
+	return nil
+}
+
+// outputExecError outputs an error in JSON format if in JSON mode
+func outputExecError(stdout io.Writer, jsonMode bool, err error) error {
+	if jsonMode {
+		output := ExecOutput{
+			Status: "error",
+			Error:  err.Error(),
+		}
+		_ = json.NewEncoder(stdout).Encode(output)
+	}
+	return err
+}
@@ -0,0 +1,140 @@
+package servers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/steviee/go-mc/internal/rcon/rcontest"
+	"github.com/steviee/go-mc/internal/state"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// saveRCONServerState saves a server state that points at a fake RCON server
+func saveRCONServerState(t *testing.T, name string, srv *rcontest.Server) {
+	t.Helper()
+
+	serverState := state.NewServerState(name)
+	serverState.Minecraft.RconPort = srv.Port()
+	serverState.Minecraft.RconPassword = srv.Password
+	require.NoError(t, state.SaveServerState(context.Background(), serverState))
+}
+
+func TestNewExecCommand(t *testing.T) {
+	cmd := NewExecCommand()
+
+	assert.Equal(t, "exec <name> <command...>", cmd.Use)
+	assert.NotEmpty(t, cmd.Short)
+	assert.NotEmpty(t, cmd.Long)
+	assert.NotEmpty(t, cmd.Example)
+
+	timeout := cmd.Flags().Lookup("timeout")
+	require.NotNil(t, timeout)
+	assert.Equal(t, "10s", timeout.DefValue)
+
+	assert.Error(t, cmd.Args(cmd, []string{"myserver"}))
+	assert.NoError(t, cmd.Args(cmd, []string{"myserver", "list"}))
+}
+
+func TestRunExec(t *testing.T) {
+	tmpDir := t.TempDir()
+	setupTestStateDir(t, tmpDir)
+	t.Setenv("GOMC_JSON", "")
+
+	srv := rcontest.NewServer(t, "secret", func(command string) string {
+		if command == "list" {
+			return "There are 1 of a max of 20 players online: Notch"
+		}
+		return ""
+	})
+	saveRCONServerState(t, "myserver", srv)
+
+	ctx := context.Background()
+	flags := &ExecFlags{Timeout: time.Second}
+
+	t.Run("prints response", func(t *testing.T) {
+		var stdout bytes.Buffer
+		err := runExec(ctx, &stdout, "myserver", []string{"list"}, flags)
+		require.NoError(t, err)
+		assert.Equal(t, "There are 1 of a max of 20 players online: Notch\n", stdout.String())
+	})
+
+	t.Run("joins arguments and strips slash", func(t *testing.T) {
+		var stdout bytes.Buffer
+		err := runExec(ctx, &stdout, "myserver", []string{"/say", "hello", "world"}, flags)
+		require.NoError(t, err)
+		assert.Empty(t, stdout.String())
+		assert.Contains(t, srv.Commands(), "say hello world")
+	})
+
+	t.Run("unknown server", func(t *testing.T) {
+		var stdout bytes.Buffer
+		err := runExec(ctx, &stdout, "missing", []string{"list"}, flags)
+		assert.Error(t, err)
+	})
+
+	t.Run("invalid server name", func(t *testing.T) {
+		var stdout bytes.Buffer
+		err := runExec(ctx, &stdout, "Invalid Name", []string{"list"}, flags)
+		assert.Error(t, err)
+	})
+}
+
+func TestRunExec_JSON(t *testing.T) {
+	tmpDir := t.TempDir()
+	setupTestStateDir(t, tmpDir)
+	t.Setenv("GOMC_JSON", "true")
+
+	srv := rcontest.NewServer(t, "secret", nil)
+	saveRCONServerState(t, "myserver", srv)
+
+	var stdout bytes.Buffer
+	err := runExec(context.Background(), &stdout, "myserver", []string{"time", "query", "daytime"}, &ExecFlags{Timeout: time.Second})
+	require.NoError(t, err)
+
+	var output ExecOutput
+	require.NoError(t, json.Unmarshal(stdout.Bytes(), &output))
+	assert.Equal(t, "success", output.Status)
+	require.NotNil(t, output.Data)
+	assert.Equal(t, "myserver", output.Data.Server)
+	assert.Equal(t, "time query daytime", output.Data.Command)
+	assert.Equal(t, "time query daytime", output.Data.Response)
+}
+
+func TestRunExec_AuthFailure(t *testing.T) {
+	tmpDir := t.TempDir()
+	setupTestStateDir(t, tmpDir)
+	t.Setenv("GOMC_JSON", "true")
+
+	srv := rcontest.NewServer(t, "secret", nil)
+	serverState := state.NewServerState("myserver")
+	serverState.Minecraft.RconPort = srv.Port()
+	serverState.Minecraft.RconPassword = "wrong"
+	require.NoError(t, state.SaveServerState(context.Background(), serverState))
+
+	var stdout bytes.Buffer
+	err := runExec(context.Background(), &stdout, "myserver", []string{"list"}, &ExecFlags{Timeout: time.Second})
+	require.Error(t, err)
+
+	var output ExecOutput
+	require.NoError(t, json.Unmarshal(stdout.Bytes(), &output))
+	assert.Equal(t, "error", output.Status)
+	assert.Contains(t, output.Error, "authentication failed")
+}
+
+func TestOutputExecError(t *testing.T) {
+	var stdout bytes.Buffer
+	testErr := errors.New("boom")
+
+	err := outputExecError(&stdout, false, testErr)
+	assert.Equal(t, testErr, err)
+	assert.Empty(t, stdout.String())
+
+	err = outputExecError(&stdout, true, testErr)
+	assert.Equal(t, testErr, err)
+	assert.Contains(t, stdout.String(), `"error":"boom"`)
+}
@@ -0,0 +1,161 @@
+package servers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+	"github.com/steviee/go-mc/internal/minecraft"
+	"github.com/steviee/go-mc/internal/mods"
+	"github.com/steviee/go-mc/internal/mrpack"
+	"github.com/steviee/go-mc/internal/state"
+)
+
+// ExportMrpackFlags holds all flags for the export-mrpack command
+type ExportMrpackFlags struct {
+	Output       string
+	PackVersion  string
+	Summary      string
+	FabricLoader string
+}
+
+// ExportMrpackInfo describes an exported modpack
+type ExportMrpackInfo struct {
+	Path         string `json:"path"`
+	Name         string `json:"name"`
+	VersionID    string `json:"version_id"`
+	Minecraft    string `json:"minecraft"`
+	FabricLoader string `json:"fabric_loader"`
+	Mods         int    `json:"mods"`
+}
+
+// ExportMrpackOutput is the JSON output of the export-mrpack command
+type ExportMrpackOutput struct {
+	Status string            `json:"status"`
+	Data   *ExportMrpackInfo `json:"data"`
+}
+
+// NewExportMrpackCommand creates the servers export-mrpack subcommand
+func NewExportMrpackCommand() *cobra.Command {
+	flags := &ExportMrpackFlags{}
+
+	cmd := &cobra.Command{
+		Use:   "export-mrpack <name>",
+		Short: "Export the mods of a server as a Modrinth modpack",
+		Long: `Export the mods installed on a server as a Modrinth modpack (.mrpack).
+
+The pack pins the Minecraft version and Fabric loader of the server and lists
+every installed mod with its Modrinth download URL and hashes. The jars on
+disk are hashed, so the pack holds exactly what the server runs; run
+'go-mc mods verify' first if the export reports a mismatch.
+
+The Fabric loader defaults to the one the server was created with, or the
+latest stable loader if the server does not pin one.`,
+		Example: `  # Export to survival.mrpack
+  go-mc servers export-mrpack survival
+
+  # Export a versioned pack to a custom path
+  go-mc servers export-mrpack survival -o packs/survival-2.0.mrpack --pack-version 2.0.0`,
+		Args: requireServerName,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runExportMrpack(cmd.Context(), cmd.OutOrStdout(), args[0], flags)
+		},
+	}
+
+	cmd.Flags().StringVarP(&flags.Output, "output", "o", "", "Path to write the pack to (default: <name>.mrpack)")
+	cmd.Flags().StringVar(&flags.PackVersion, "pack-version", "1.0.0", "Version of the pack")
+	cmd.Flags().StringVar(&flags.Summary, "summary", "", "Summary of the pack")
+	cmd.Flags().StringVar(&flags.FabricLoader, "fabric-loader", "", "Fabric loader version to pin (default: the server's)")
+
+	return cmd
+}
+
+// runExportMrpack executes the export-mrpack command
+func runExportMrpack(ctx context.Context, stdout io.Writer, name string, flags *ExportMrpackFlags) error {
+	jsonMode := isJSONMode()
+
+	serverState, err := state.LoadServerState(ctx, name)
+	if err != nil {
+		return outputLifecycleError(stdout, jsonMode, err)
+	}
+
+	loader := flags.FabricLoader
+	if loader == "" {
+		loader = serverState.Minecraft.FabricLoaderVersion
+	}
+	if loader == "" {
+		latest, err := minecraft.NewClient(nil).GetLatestStableFabricLoader(ctx)
+		if err != nil {
+			return outputLifecycleError(stdout, jsonMode, fmt.Errorf("failed to get Fabric loader version (set --fabric-loader): %w", err))
+		}
+		loader = latest.Version
+	}
+
+	idx, err := mods.ExportPack(serverState, mods.PackOptions{
+		Name:                name,
+		VersionID:           flags.PackVersion,
+		Summary:             flags.Summary,
+		FabricLoaderVersion: loader,
+	})
+	if err != nil {
+		return outputLifecycleError(stdout, jsonMode, fmt.Errorf("failed to export modpack: %w", err))
+	}
+
+	output := flags.Output
+	if output == "" {
+		output = name + ".mrpack"
+	}
+	if err := writeMrpack(output, idx); err != nil {
+		return outputLifecycleError(stdout, jsonMode, err)
+	}
+
+	info := &ExportMrpackInfo{
+		Path:         output,
+		Name:         idx.Name,
+		VersionID:    idx.VersionID,
+		Minecraft:    idx.MinecraftVersion(),
+		FabricLoader: loader,
+		Mods:         len(idx.Files),
+	}
+
+	if jsonMode {
+		enc := json.NewEncoder(stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(ExportMrpackOutput{Status: "success", Data: info})
+	}
+
+	_, _ = fmt.Fprintf(stdout, "Exported %d mod(s) of '%s' to %s\n", info.Mods, name, info.Path)
+	_, _ = fmt.Fprintf(stdout, "  Minecraft %s, Fabric loader %s\n", info.Minecraft, info.FabricLoader)
+	return nil
+}
+
+// writeMrpack atomically writes a pack with the given index to path
+func writeMrpack(path string, idx *mrpack.Index) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to create %s: %w", path, err)
+	}
+	defer func() {
+		_ = os.Remove(tmp.Name())
+	}()
+
+	if err := mrpack.Write(tmp, idx); err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	if err := os.Chmod(tmp.Name(), 0644); err != nil { //nolint:gosec // G302: packs are meant to be shared
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,739 @@
+package servers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"text/template"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steviee/go-mc/internal/container"
+	"github.com/steviee/go-mc/internal/mcping"
+	"github.com/steviee/go-mc/internal/rcon"
+	"github.com/steviee/go-mc/internal/server"
+	"github.com/steviee/go-mc/internal/state"
+	"gopkg.in/yaml.v3"
+)
+
+// inspectRconTimeout bounds the RCON player lookup of inspect.
+const inspectRconTimeout = 3 * time.Second
+
+// inspectPingTimeout bounds the server list ping of inspect.
+const inspectPingTimeout = 3 * time.Second
+
+// InspectFlags holds all flags for the inspect command
+type InspectFlags struct {
+	Output string
+	Format string
+}
+
+// ServerInspect is the merged view of a server shown by inspect. Its JSON and
+// YAML field names form a stable schema for scripting.
+type ServerInspect struct {
+	Name        string    `json:"name" yaml:"name"`
+	ID          string    `json:"id" yaml:"id"`
+	Status      string    `json:"status" yaml:"status"`
+	CreatedAt   time.Time `json:"created_at" yaml:"created_at"`
+	LastStarted time.Time `json:"last_started,omitempty" yaml:"last_started,omitempty"`
+	LastStopped time.Time `json:"last_stopped,omitempty" yaml:"last_stopped,omitempty"`
+
+	Minecraft InspectMinecraft `json:"minecraft" yaml:"minecraft"`
+	Ports     []InspectPort    `json:"ports" yaml:"ports"`
+	Mods      []InspectMod     `json:"mods" yaml:"mods"`
+	Whitelist InspectWhitelist `json:"whitelist" yaml:"whitelist"`
+	Ops       []InspectOp      `json:"ops" yaml:"ops"`
+	Restart   InspectRestart   `json:"restart" yaml:"restart"`
+
+	// Container is nil when the server has no container or the container
+	// runtime is unavailable.
+	Container *InspectContainer `json:"container,omitempty" yaml:"container,omitempty"`
+
+	// Runtime is nil unless the server is running.
+	Runtime *InspectRuntime `json:"runtime,omitempty" yaml:"runtime,omitempty"`
+
+	Disk InspectDisk `json:"disk" yaml:"disk"`
+
+	// Warnings lists the sources that could not be read.
+	Warnings []string `json:"warnings,omitempty" yaml:"warnings,omitempty"`
+}
+
+// InspectMinecraft holds the Minecraft settings of a server.
+type InspectMinecraft struct {
+	Version             string `json:"version" yaml:"version"`
+	FabricLoaderVersion string `json:"fabric_loader_version" yaml:"fabric_loader_version"`
+	JavaVersion         int    `json:"java_version" yaml:"java_version"`
+	Memory              string `json:"memory" yaml:"memory"`
+}
+
+// InspectPort is a port of a server. Status tells whether the container
+// publishes it: "published", "pending" (on next start or restart) or
+// "unknown" when the container could not be inspected.
+type InspectPort struct {
+	Name          string `json:"name" yaml:"name"`
+	HostIP        string `json:"host_ip,omitempty" yaml:"host_ip,omitempty"`
+	Port          int    `json:"port" yaml:"port"`
+	ContainerPort int    `json:"container_port" yaml:"container_port"`
+	Protocol      string `json:"protocol" yaml:"protocol"`
+	Status        string `json:"status" yaml:"status"`
+}
+
+// InspectMod is an installed mod.
+type InspectMod struct {
+	Name     string `json:"name" yaml:"name"`
+	Slug     string `json:"slug" yaml:"slug"`
+	Version  string `json:"version" yaml:"version"`
+	Filename string `json:"filename" yaml:"filename"`
+}
+
+// InspectWhitelist holds the whitelist settings of a server.
+type InspectWhitelist struct {
+	Enabled bool     `json:"enabled" yaml:"enabled"`
+	Lists   []string `json:"lists" yaml:"lists"`
+}
+
+// InspectOp is an operator of a server.
+type InspectOp struct {
+	Name  string `json:"name" yaml:"name"`
+	UUID  string `json:"uuid" yaml:"uuid"`
+	Level int    `json:"level" yaml:"level"`
+}
+
+// InspectContainer holds what the container runtime reports.
+type InspectContainer struct {
+	ID        string            `json:"id" yaml:"id"`
+	Name      string            `json:"name" yaml:"name"`
+	State     string            `json:"state" yaml:"state"`
+	Image     string            `json:"image" yaml:"image"`
+	Created   time.Time         `json:"created" yaml:"created"`
+	StartedAt time.Time         `json:"started_at,omitempty" yaml:"started_at,omitempty"`
+	Labels    map[string]string `json:"labels,omitempty" yaml:"labels,omitempty"`
+	Mounts    []InspectMount    `json:"mounts,omitempty" yaml:"mounts,omitempty"`
+}
+
+// InspectRestart holds the restart policy and the recent automatic restarts
+// of a server.
+type InspectRestart struct {
+	Policy         string                 `json:"policy" yaml:"policy"`
+	MaxRetries     int                    `json:"max_retries" yaml:"max_retries"`
+	BackoffSeconds float64                `json:"backoff_seconds" yaml:"backoff_seconds"`
+	Restarts       []InspectRestartRecord `json:"restarts" yaml:"restarts"`
+}
+
+// InspectRestartRecord is an automatic restart of a server.
+type InspectRestartRecord struct {
+	Time        time.Time `json:"time" yaml:"time"`
+	By          string    `json:"by" yaml:"by"`
+	Cause       string    `json:"cause" yaml:"cause"`
+	ExitCode    int       `json:"exit_code" yaml:"exit_code"`
+	CrashReport string    `json:"crash_report,omitempty" yaml:"crash_report,omitempty"`
+}
+
+// InspectMount is a mount of the server container.
+type InspectMount struct {
+	Source      string `json:"source" yaml:"source"`
+	Destination string `json:"destination" yaml:"destination"`
+	ReadOnly    bool   `json:"read_only" yaml:"read_only"`
+}
+
+// InspectRuntime holds live data of a running server.
+type InspectRuntime struct {
+	Uptime        string          `json:"uptime" yaml:"uptime"`
+	CPUPercent    float64         `json:"cpu_percent" yaml:"cpu_percent"`
+	MemoryUsed    int64           `json:"memory_used" yaml:"memory_used"`
+	MemoryLimit   int64           `json:"memory_limit" yaml:"memory_limit"`
+	MemoryPercent float64         `json:"memory_percent" yaml:"memory_percent"`
+	Players       *InspectPlayers `json:"players,omitempty" yaml:"players,omitempty"`
+	Ping          *InspectPing    `json:"ping,omitempty" yaml:"ping,omitempty"`
+}
+
+// InspectPlayers holds the online players of a running server.
+type InspectPlayers struct {
+	Online int      `json:"online" yaml:"online"`
+	Max    int      `json:"max" yaml:"max"`
+	Names  []string `json:"names" yaml:"names"`
+}
+
+// InspectPing holds what a running server reports in its server list ping.
+type InspectPing struct {
+	Version   string  `json:"version" yaml:"version"`
+	Protocol  int     `json:"protocol" yaml:"protocol"`
+	MOTD      string  `json:"motd" yaml:"motd"`
+	LatencyMS float64 `json:"latency_ms" yaml:"latency_ms"`
+	Legacy    bool    `json:"legacy,omitempty" yaml:"legacy,omitempty"`
+}
+
+// InspectDisk holds the on-disk sizes of a server.
+type InspectDisk struct {
+	Data    InspectDir `json:"data" yaml:"data"`
+	Mods    InspectDir `json:"mods" yaml:"mods"`
+	Backups InspectDir `json:"backups" yaml:"backups"`
+}
+
+// InspectDir is the size of a directory or, for backups, of the server's
+// backup archives.
+type InspectDir struct {
+	Path  string `json:"path" yaml:"path"`
+	Bytes int64  `json:"bytes" yaml:"bytes"`
+	Files int    `json:"files" yaml:"files"`
+}
+
+// NewInspectCommand creates the servers inspect subcommand
+func NewInspectCommand() *cobra.Command {
+	flags := &InspectFlags{}
+
+	cmd := &cobra.Command{
+		Use:   "inspect <name>",
+		Short: "Show detailed information about a server",
+		Long: `Show everything go-mc knows about a server in one view.
+
+Merges the server state (version, ports, mods, whitelists, operators) with
+the container as reported by the runtime (state, image, labels, mounts),
+live data of a running server (CPU and memory usage, online players via
+RCON) and the on-disk sizes of its data, mods and backups.
+
+Sources that cannot be read are listed as warnings instead of failing the
+command, so stopped servers and missing runtimes can still be inspected.
+
+--format takes a Go template that is applied to the inspect data, using
+the field names of the Go structs (e.g. {{.Minecraft.Version}}).`,
+		Example: `  # Human-readable summary
+  go-mc servers inspect survival
+
+  # Stable JSON or YAML for scripting
+  go-mc servers inspect survival --output json
+  go-mc servers inspect survival -o yaml
+
+  # Extract single values
+  go-mc servers inspect survival --format '{{.Status}}'
+  go-mc servers inspect survival --format '{{range .Mods}}{{.Slug}} {{end}}'
+  go-mc servers inspect survival --format '{{json .Container.Mounts}}'`,
+		Args: requireServerName,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runInspect(cmd.Context(), cmd.OutOrStdout(), args[0], flags)
+		},
+	}
+
+	cmd.Flags().StringVarP(&flags.Output, "output", "o", "", "Output format (json, yaml)")
+	cmd.Flags().StringVarP(&flags.Format, "format", "f", "", "Format output using a Go template")
+
+	return cmd
+}
+
+// runInspect executes the inspect command
+func runInspect(ctx context.Context, stdout io.Writer, name string, flags *InspectFlags) error {
+	jsonMode := isJSONMode() || flags.Output == "json"
+
+	if flags.Output != "" && flags.Output != "json" && flags.Output != "yaml" {
+		return outputInspectError(stdout, jsonMode, fmt.Errorf("invalid output format %q (valid: json, yaml)", flags.Output))
+	}
+	if flags.Format != "" && flags.Output != "" {
+		return outputInspectError(stdout, jsonMode, fmt.Errorf("--format cannot be combined with --output"))
+	}
+
+	var tmpl *template.Template
+	if flags.Format != "" {
+		var err error
+		tmpl, err = parseInspectTemplate(flags.Format)
+		if err != nil {
+			return outputInspectError(stdout, jsonMode, err)
+		}
+	}
+
+	if err := state.ValidateServerName(name); err != nil {
+		return outputInspectError(stdout, jsonMode, fmt.Errorf("invalid server name: %w", err))
+	}
+
+	serverState, err := state.LoadServerState(ctx, name)
+	if err != nil {
+		return outputInspectError(stdout, jsonMode, fmt.Errorf("failed to load server state: %w", err))
+	}
+
+	// The runtime is optional: without it the state and disk data are shown
+	var client container.Client
+	var clientErr error
+	if serverState.ContainerID != "" {
+		client, clientErr = createContainerClient(ctx)
+		if clientErr == nil {
+			defer func() { _ = client.Close() }()
+		}
+	}
+
+	info := gatherInspect(ctx, serverState, client)
+	if clientErr != nil {
+		info.Warnings = append(info.Warnings, fmt.Sprintf("container: %v", clientErr))
+	}
+
+	switch {
+	case tmpl != nil:
+		if err := tmpl.Execute(stdout, info); err != nil {
+			return fmt.Errorf("failed to execute template: %w", err)
+		}
+		_, _ = fmt.Fprintln(stdout)
+		return nil
+	case flags.Output == "yaml":
+		enc := yaml.NewEncoder(stdout)
+		enc.SetIndent(2)
+		defer func() { _ = enc.Close() }()
+		return enc.Encode(info)
+	case jsonMode:
+		enc := json.NewEncoder(stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(InspectOutput{Status: "success", Data: info})
+	default:
+		return outputInspectHuman(stdout, info)
+	}
+}
+
+// InspectOutput holds the output for JSON mode
+type InspectOutput struct {
+	Status string         `json:"status"`
+	Data   *ServerInspect `json:"data,omitempty"`
+	Error  string         `json:"error,omitempty"`
+}
+
+// gatherInspect merges the server state with container, live and disk data.
+// A nil client skips the container and live data.
+func gatherInspect(ctx context.Context, serverState *state.ServerState, client container.Client) *ServerInspect {
+	info := inspectFromState(serverState)
+
+	if client != nil && serverState.ContainerID != "" {
+		inspectContainer(ctx, info, serverState, client)
+	}
+
+	if info.Status == string(state.StatusRunning) {
+		if info.Runtime == nil {
+			info.Runtime = &InspectRuntime{Uptime: formatUptime(serverState.LastStarted)}
+		}
+
+		status, pingErr := pingLocalServer(ctx, serverState, inspectPingTimeout)
+		if pingErr != nil {
+			info.Warnings = append(info.Warnings, fmt.Sprintf("ping: %v", pingErr))
+		} else {
+			info.Runtime.Ping = inspectPing(status)
+		}
+
+		players, err := fetchPlayers(ctx, serverState)
+		switch {
+		case err == nil:
+			info.Runtime.Players = players
+		case pingErr == nil:
+			// Without RCON, the ping still tells the counts and some names
+			info.Runtime.Players = playersFromPing(status)
+		default:
+			info.Warnings = append(info.Warnings, fmt.Sprintf("players: %v", err))
+		}
+	}
+
+	info.Disk = inspectDisk(ctx, info, serverState)
+
+	return info
+}
+
+// inspectFromState converts the saved server state.
+func inspectFromState(serverState *state.ServerState) *ServerInspect {
+	info := &ServerInspect{
+		Name:        serverState.Name,
+		ID:          serverState.ID,
+		Status:      string(serverState.Status),
+		CreatedAt:   serverState.CreatedAt,
+		LastStarted: serverState.LastStarted,
+		LastStopped: serverState.LastStopped,
+		Minecraft: InspectMinecraft{
+			Version:             serverState.Minecraft.Version,
+			FabricLoaderVersion: serverState.Minecraft.FabricLoaderVersion,
+			JavaVersion:         serverState.Minecraft.JavaVersion,
+			Memory:              serverState.Minecraft.Memory,
+		},
+		Ports: []InspectPort{},
+		Mods:  []InspectMod{},
+		Whitelist: InspectWhitelist{
+			Enabled: serverState.Whitelist.Enabled,
+			Lists:   append([]string{}, serverState.Whitelist.Lists...),
+		},
+		Ops: []InspectOp{},
+		Restart: InspectRestart{
+			Policy:         serverState.Restart.Name(),
+			MaxRetries:     serverState.Restart.MaxRetries,
+			BackoffSeconds: seconds(serverState.Restart.Delay(0)),
+			Restarts:       []InspectRestartRecord{},
+		},
+	}
+
+	for _, r := range serverState.Restarts {
+		info.Restart.Restarts = append(info.Restart.Restarts, InspectRestartRecord{
+			Time:        r.Time,
+			By:          r.By,
+			Cause:       r.Cause,
+			ExitCode:    r.ExitCode,
+			CrashReport: r.CrashReport,
+		})
+	}
+
+	for _, p := range server.Ports(serverState) {
+		info.Ports = append(info.Ports, InspectPort{
+			Name:          p.Name,
+			HostIP:        p.HostIP,
+			Port:          p.HostPort,
+			ContainerPort: p.ContainerPort,
+			Protocol:      p.Protocol,
+			Status:        server.PortStatus(p.PortMapping, nil),
+		})
+	}
+
+	for _, mod := range serverState.Mods {
+		info.Mods = append(info.Mods, InspectMod{
+			Name:     mod.Name,
+			Slug:     mod.Slug,
+			Version:  mod.Version,
+			Filename: mod.Filename,
+		})
+	}
+
+	for _, op := range serverState.Ops {
+		info.Ops = append(info.Ops, InspectOp{Name: op.Name, UUID: op.UUID, Level: op.Level})
+	}
+
+	return info
+}
+
+// inspectContainer adds what the runtime reports about the server container.
+// The container state replaces the saved status, which may be stale.
+func inspectContainer(ctx context.Context, info *ServerInspect, serverState *state.ServerState, client container.Client) {
+	containerInfo, err := client.InspectContainer(ctx, serverState.ContainerID)
+	if err != nil {
+		info.Warnings = append(info.Warnings, fmt.Sprintf("container: %v", err))
+		if strings.Contains(err.Error(), "not found") || strings.Contains(err.Error(), "no such container") {
+			info.Status = "missing"
+		}
+		return
+	}
+
+	info.Status = normalizeContainerState(containerInfo.State)
+	info.Container = &InspectContainer{
+		ID:        containerInfo.ID,
+		Name:      containerInfo.Name,
+		State:     containerInfo.State,
+		Image:     containerInfo.Image,
+		Created:   containerInfo.Created,
+		StartedAt: containerInfo.StartedAt,
+		Labels:    containerInfo.Labels,
+	}
+	for _, m := range containerInfo.Mounts {
+		info.Container.Mounts = append(info.Container.Mounts, InspectMount{
+			Source:      m.Source,
+			Destination: m.Destination,
+			ReadOnly:    m.ReadOnly,
+		})
+	}
+
+	for i, p := range server.Ports(serverState) {
+		info.Ports[i].Status = server.PortStatus(p.PortMapping, containerInfo)
+	}
+	if !container.SamePorts(server.PortMappings(serverState), containerInfo.Ports) {
+		info.Warnings = append(info.Warnings, "ports: the container publishes outdated ports; they are updated on next start or restart")
+	}
+
+	if info.Status != string(state.StatusRunning) {
+		return
+	}
+
+	startedAt := containerInfo.StartedAt
+	if startedAt.IsZero() {
+		startedAt = serverState.LastStarted
+	}
+	info.Runtime = &InspectRuntime{Uptime: formatUptime(startedAt)}
+
+	stats, err := client.GetContainerStats(ctx, serverState.ContainerID)
+	if err != nil {
+		info.Warnings = append(info.Warnings, fmt.Sprintf("stats: %v", err))
+		return
+	}
+	info.Runtime.CPUPercent = stats.CPUPercent
+	info.Runtime.MemoryUsed = stats.MemoryUsed
+	info.Runtime.MemoryLimit = stats.MemoryLimit
+	info.Runtime.MemoryPercent = stats.MemoryPercent
+}
+
+// playerListPattern matches the response to the "list" console command,
+// e.g. "There are 2 of a max of 20 players online: Notch, jeb_". Older
+// versions answer "There are 2/20 players online:" with names on the next line.
+var playerListPattern = regexp.MustCompile(`(?s)There are (\d+)(?: of a max of |/)(\d+) players online:?(.*)`)
+
+// fetchPlayers asks a running server for its online players over RCON.
+func fetchPlayers(ctx context.Context, serverState *state.ServerState) (*InspectPlayers, error) {
+	client, err := rcon.NewServerClient(serverState, inspectRconTimeout)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = client.Close() }()
+
+	response, err := client.Execute(ctx, "list")
+	if err != nil {
+		return nil, err
+	}
+
+	return parsePlayerList(response)
+}
+
+// parsePlayerList parses the response to the "list" console command.
+func parsePlayerList(response string) (*InspectPlayers, error) {
+	match := playerListPattern.FindStringSubmatch(response)
+	if match == nil {
+		return nil, fmt.Errorf("unexpected response to list: %q", strings.TrimSpace(response))
+	}
+
+	online, _ := strconv.Atoi(match[1])
+	maxPlayers, _ := strconv.Atoi(match[2])
+
+	players := &InspectPlayers{Online: online, Max: maxPlayers, Names: []string{}}
+	names := strings.FieldsFunc(match[3], func(r rune) bool { return r == ',' || r == '\n' })
+	for _, name := range names {
+		if name = strings.TrimSpace(name); name != "" {
+			players.Names = append(players.Names, name)
+		}
+	}
+
+	return players, nil
+}
+
+// inspectPing converts the answer to a server list ping.
+func inspectPing(status *mcping.Status) *InspectPing {
+	return &InspectPing{
+		Version:   status.Version.Name,
+		Protocol:  status.Version.Protocol,
+		MOTD:      status.MOTD,
+		LatencyMS: float64(status.Latency.Microseconds()) / 1000,
+		Legacy:    status.Legacy,
+	}
+}
+
+// playersFromPing takes the online players from a server list ping. The
+// names are the sample the server chose to send, not necessarily everyone.
+func playersFromPing(status *mcping.Status) *InspectPlayers {
+	players := &InspectPlayers{Online: status.Players.Online, Max: status.Players.Max, Names: []string{}}
+	for _, p := range status.Players.Sample {
+		players.Names = append(players.Names, p.Name)
+	}
+	return players
+}
+
+// inspectDisk measures the data and mods directories and the server's
+// backup archives.
+func inspectDisk(ctx context.Context, info *ServerInspect, serverState *state.ServerState) InspectDisk {
+	var disk InspectDisk
+
+	if serverState.Volumes.Data != "" {
+		disk.Data = measureDir(info, serverState.Volumes.Data)
+		disk.Mods = measureDir(info, server.ModsDir(serverState))
+	}
+
+	cfg, err := state.ResolveConfig(ctx)
+	if err != nil {
+		info.Warnings = append(info.Warnings, fmt.Sprintf("config: %v", err))
+	} else if archivesDir, err := state.ResolveArchivesDir(cfg); err == nil {
+		disk.Backups.Path = archivesDir
+	}
+	backups, err := state.ListBackups(ctx, serverState.Name)
+	if err != nil {
+		info.Warnings = append(info.Warnings, fmt.Sprintf("backups: %v", err))
+		return disk
+	}
+	for _, b := range backups {
+		disk.Backups.Files++
+		disk.Backups.Bytes += b.SizeBytes
+	}
+
+	return disk
+}
+
+// measureDir sums the sizes of the files in a directory. A missing
+// directory has size zero.
+func measureDir(info *ServerInspect, dir string) InspectDir {
+	result := InspectDir{Path: dir}
+
+	err := filepath.WalkDir(dir, func(path string, d os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		fi, err := d.Info()
+		if err != nil {
+			return err
+		}
+		result.Bytes += fi.Size()
+		result.Files++
+		return nil
+	})
+	if err != nil && !os.IsNotExist(err) {
+		info.Warnings = append(info.Warnings, fmt.Sprintf("disk usage of %s: %v", dir, err))
+	}
+
+	return result
+}
+
+// parseInspectTemplate parses a --format template. Besides the standard
+// functions it provides "json" to render a value as JSON.
+func parseInspectTemplate(format string) (*template.Template, error) {
+	funcs := template.FuncMap{
+		"json": func(v interface{}) (string, error) {
+			data, err := json.Marshal(v)
+			return string(data), err
+		},
+		"join": strings.Join,
+	}
+
+	tmpl, err := template.New("format").Funcs(funcs).Parse(format)
+	if err != nil {
+		return nil, fmt.Errorf("invalid format template: %w", err)
+	}
+	return tmpl, nil
+}
+
+// outputInspectHuman prints a summary of the inspect data.
+func outputInspectHuman(stdout io.Writer, info *ServerInspect) error {
+	tw := tabwriter.NewWriter(stdout, 0, 0, 2, ' ', 0)
+	row := func(label, value string) {
+		_, _ = fmt.Fprintf(tw, "  %s:\t%s\n", label, value)
+	}
+
+	_, _ = fmt.Fprintf(tw, "Server: %s\n", info.Name)
+	row("Status", info.Status)
+	row("Version", fmt.Sprintf("%s (Fabric loader %s)", info.Minecraft.Version, valueOrDash(info.Minecraft.FabricLoaderVersion)))
+	row("Memory", info.Minecraft.Memory)
+	row("Created", info.CreatedAt.Local().Format("2006-01-02 15:04:05"))
+	if info.Runtime != nil {
+		row("Uptime", info.Runtime.Uptime)
+	}
+
+	ports := make([]string, 0, len(info.Ports))
+	for _, p := range info.Ports {
+		port := fmt.Sprintf("%s %d->%d/%s", p.Name, p.Port, p.ContainerPort, p.Protocol)
+		if p.Status == server.PortPending {
+			port += " (pending)"
+		}
+		ports = append(ports, port)
+	}
+	row("Ports", valueOrDash(strings.Join(ports, ", ")))
+	row("Restart", formatRestartPolicy(state.RestartPolicy{
+		Policy:     info.Restart.Policy,
+		MaxRetries: info.Restart.MaxRetries,
+		Backoff:    time.Duration(info.Restart.BackoffSeconds * float64(time.Second)),
+	}))
+	if n := len(info.Restart.Restarts); n > 0 {
+		last := info.Restart.Restarts[n-1]
+		record := state.RestartRecord{Cause: last.Cause, ExitCode: last.ExitCode, CrashReport: last.CrashReport}
+		row("Last restart", fmt.Sprintf("%s by %s, %s", last.Time.Local().Format("2006-01-02 15:04:05"), last.By, record))
+	}
+
+	if info.Runtime != nil {
+		_, _ = fmt.Fprintln(tw, "\nRuntime:")
+		row("CPU", fmt.Sprintf("%.1f%%", info.Runtime.CPUPercent))
+		row("Memory", fmt.Sprintf("%s / %s (%.1f%%)", formatBytes(info.Runtime.MemoryUsed), formatBytes(info.Runtime.MemoryLimit), info.Runtime.MemoryPercent))
+		if p := info.Runtime.Players; p != nil {
+			players := fmt.Sprintf("%d/%d", p.Online, p.Max)
+			if len(p.Names) > 0 {
+				players += " (" + strings.Join(p.Names, ", ") + ")"
+			}
+			row("Players", players)
+		}
+		if p := info.Runtime.Ping; p != nil {
+			row("MOTD", valueOrDash(p.MOTD))
+			row("Latency", fmt.Sprintf("%.1fms", p.LatencyMS))
+		}
+	}
+
+	if c := info.Container; c != nil {
+		_, _ = fmt.Fprintln(tw, "\nContainer:")
+		row("ID", shortID(c.ID))
+		row("State", c.State)
+		row("Image", c.Image)
+		for _, m := range c.Mounts {
+			mode := "rw"
+			if m.ReadOnly {
+				mode = "ro"
+			}
+			row("Mount", fmt.Sprintf("%s -> %s (%s)", m.Source, m.Destination, mode))
+		}
+		labels := make([]string, 0, len(c.Labels))
+		for k, v := range c.Labels {
+			labels = append(labels, k+"="+v)
+		}
+		sort.Strings(labels)
+		for _, label := range labels {
+			row("Label", label)
+		}
+	}
+
+	_, _ = fmt.Fprintln(tw, "\nAccess:")
+	whitelist := "disabled"
+	if info.Whitelist.Enabled {
+		whitelist = "enabled"
+	}
+	if len(info.Whitelist.Lists) > 0 {
+		whitelist += " (" + strings.Join(info.Whitelist.Lists, ", ") + ")"
+	}
+	row("Whitelist", whitelist)
+	ops := make([]string, 0, len(info.Ops))
+	for _, op := range info.Ops {
+		ops = append(ops, fmt.Sprintf("%s (level %d)", op.Name, op.Level))
+	}
+	row("Operators", valueOrDash(strings.Join(ops, ", ")))
+
+	_, _ = fmt.Fprintf(tw, "\nMods (%d):\n", len(info.Mods))
+	for _, mod := range info.Mods {
+		_, _ = fmt.Fprintf(tw, "  %s\t%s\n", mod.Slug, mod.Version)
+	}
+
+	_, _ = fmt.Fprintln(tw, "\nDisk:")
+	row("Data", fmt.Sprintf("%s\t%s", formatBytes(info.Disk.Data.Bytes), info.Disk.Data.Path))
+	row("Mods", fmt.Sprintf("%s\t%s", formatBytes(info.Disk.Mods.Bytes), info.Disk.Mods.Path))
+	row("Backups", fmt.Sprintf("%s\t%d archive(s)", formatBytes(info.Disk.Backups.Bytes), info.Disk.Backups.Files))
+
+	if len(info.Warnings) > 0 {
+		_, _ = fmt.Fprintln(tw, "\nWarnings:")
+		for _, w := range info.Warnings {
+			_, _ = fmt.Fprintf(tw, "  - %s\n", w)
+		}
+	}
+
+	return tw.Flush()
+}
+
+// valueOrDash returns "-" for empty values.
+func valueOrDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
+
+// shortID shortens a container ID for display.
+func shortID(id string) string {
+	if len(id) > 12 {
+		return id[:12]
+	}
+	return id
+}
+
+// outputInspectError outputs an error in JSON format if in JSON mode
+func outputInspectError(stdout io.Writer, jsonMode bool, err error) error {
+	if jsonMode {
+		output := InspectOutput{
+			Status: "error",
+			Error:  err.Error(),
+		}
+		_ = json.NewEncoder(stdout).Encode(output)
+	}
+	return err
+}
@@ -0,0 +1,355 @@
+package servers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/steviee/go-mc/internal/container"
+	"github.com/steviee/go-mc/internal/mcping"
+	"github.com/steviee/go-mc/internal/mcping/mcpingtest"
+	"github.com/steviee/go-mc/internal/rcon/rcontest"
+	"github.com/steviee/go-mc/internal/server"
+	"github.com/steviee/go-mc/internal/state"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+// inspectClient is a container client that only answers inspect and stats
+type inspectClient struct {
+	container.Client
+	info    *container.ContainerInfo
+	stats   *container.ContainerStats
+	inspErr error
+}
+
+func (c *inspectClient) InspectContainer(ctx context.Context, containerID string) (*container.ContainerInfo, error) {
+	if c.inspErr != nil {
+		return nil, c.inspErr
+	}
+	return c.info, nil
+}
+
+func (c *inspectClient) GetContainerStats(ctx context.Context, containerID string) (*container.ContainerStats, error) {
+	if c.stats == nil {
+		return nil, fmt.Errorf("no stats")
+	}
+	return c.stats, nil
+}
+
+// saveInspectServer saves a stopped server with data and mods on disk
+func saveInspectServer(t *testing.T, name string) *state.ServerState {
+	t.Helper()
+
+	serverDir := t.TempDir()
+	dataDir := filepath.Join(serverDir, "data")
+	modsDir := filepath.Join(serverDir, "mods")
+	require.NoError(t, os.MkdirAll(filepath.Join(dataDir, "world"), 0750))
+	require.NoError(t, os.MkdirAll(modsDir, 0750))
+	require.NoError(t, os.WriteFile(filepath.Join(dataDir, "world", "level.dat"), make([]byte, 100), 0600))
+	require.NoError(t, os.WriteFile(filepath.Join(modsDir, "fabric-api.jar"), make([]byte, 50), 0600))
+
+	serverState := state.NewServerState(name)
+	serverState.Status = state.StatusStopped
+	serverState.Minecraft.Version = "1.21.1"
+	serverState.Minecraft.FabricLoaderVersion = "0.16.5"
+	serverState.Minecraft.Memory = "4G"
+	serverState.Minecraft.GamePort = 25565
+	serverState.Minecraft.RconPort = 25575
+	serverState.Volumes.Data = dataDir
+	serverState.Mods = []state.ModInfo{
+		{Name: "Fabric API", Slug: "fabric-api", Version: "0.102.0", Filename: "fabric-api.jar"},
+		{Name: "Simple Voice Chat", Slug: "simple-voice-chat", Version: "2.5.0", Port: 24454, Protocol: "udp"},
+	}
+	serverState.Ops = []state.OpInfo{{UUID: "069a79f4-44e9-4726-a5be-fca90e38aaf5", Name: "Notch", Level: 4}}
+	serverState.Whitelist.Enabled = true
+	serverState.Whitelist.Lists = []string{"friends"}
+	require.NoError(t, state.SaveServerState(context.Background(), serverState))
+
+	return serverState
+}
+
+func TestNewInspectCommand(t *testing.T) {
+	cmd := NewInspectCommand()
+
+	assert.Equal(t, "inspect <name>", cmd.Use)
+	assert.NotEmpty(t, cmd.Short)
+	assert.NotEmpty(t, cmd.Long)
+	assert.NotEmpty(t, cmd.Example)
+	assert.NotNil(t, cmd.Flags().Lookup("output"))
+	assert.NotNil(t, cmd.Flags().Lookup("format"))
+
+	assert.Error(t, cmd.Args(cmd, []string{}))
+	assert.NoError(t, cmd.Args(cmd, []string{"myserver"}))
+}
+
+func TestParsePlayerList(t *testing.T) {
+	tests := []struct {
+		name     string
+		response string
+		want     *InspectPlayers
+		wantErr  bool
+	}{
+		{
+			name:     "empty server",
+			response: "There are 0 of a max of 20 players online: ",
+			want:     &InspectPlayers{Online: 0, Max: 20, Names: []string{}},
+		},
+		{
+			name:     "players online",
+			response: "There are 2 of a max of 10 players online: Notch, jeb_",
+			want:     &InspectPlayers{Online: 2, Max: 10, Names: []string{"Notch", "jeb_"}},
+		},
+		{
+			name:     "legacy format",
+			response: "There are 1/20 players online:\nNotch",
+			want:     &InspectPlayers{Online: 1, Max: 20, Names: []string{"Notch"}},
+		},
+		{
+			name:     "unexpected response",
+			response: "Unknown command",
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parsePlayerList(tt.response)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestGatherInspect_StoppedServer(t *testing.T) {
+	setupTestStateDir(t, t.TempDir())
+	serverState := saveInspectServer(t, "survival")
+
+	info := gatherInspect(context.Background(), serverState, nil)
+
+	assert.Equal(t, "survival", info.Name)
+	assert.Equal(t, "stopped", info.Status)
+	assert.Equal(t, "1.21.1", info.Minecraft.Version)
+	assert.Equal(t, []InspectPort{
+		{Name: "game", Port: 25565, ContainerPort: 25565, Protocol: "tcp", Status: "unknown"},
+		{Name: "rcon", Port: 25575, ContainerPort: 25575, Protocol: "tcp", Status: "unknown"},
+		{Name: "simple-voice-chat", Port: 24454, ContainerPort: 24454, Protocol: "udp", Status: "unknown"},
+	}, info.Ports)
+	assert.Len(t, info.Mods, 2)
+	assert.Equal(t, []InspectOp{{Name: "Notch", UUID: "069a79f4-44e9-4726-a5be-fca90e38aaf5", Level: 4}}, info.Ops)
+	assert.Equal(t, InspectWhitelist{Enabled: true, Lists: []string{"friends"}}, info.Whitelist)
+	assert.Equal(t, InspectRestart{Policy: "no", BackoffSeconds: 10, Restarts: []InspectRestartRecord{}}, info.Restart)
+	assert.Nil(t, info.Container)
+	assert.Nil(t, info.Runtime)
+
+	assert.Equal(t, int64(100), info.Disk.Data.Bytes)
+	assert.Equal(t, 1, info.Disk.Data.Files)
+	assert.Equal(t, int64(50), info.Disk.Mods.Bytes)
+	assert.Equal(t, int64(0), info.Disk.Backups.Bytes)
+	assert.Empty(t, info.Warnings)
+}
+
+func TestGatherInspect_RunningServer(t *testing.T) {
+	setupTestStateDir(t, t.TempDir())
+	serverState := saveInspectServer(t, "survival")
+
+	srv := rcontest.NewServer(t, "secret", func(command string) string {
+		return "There are 1 of a max of 20 players online: Notch"
+	})
+	game := mcpingtest.NewServer(t, mcping.Status{
+		Version: mcping.Version{Name: "1.21.1", Protocol: 767},
+		Players: mcping.Players{Max: 20, Online: 1},
+		MOTD:    "Survival",
+	})
+	serverState.ContainerID = "abc123def456789"
+	serverState.Minecraft.RconPort = srv.Port()
+	serverState.Minecraft.RconPassword = "secret"
+	serverState.Minecraft.GamePort = game.Port()
+
+	client := &inspectClient{
+		info: &container.ContainerInfo{
+			ID:        "abc123def456789",
+			Name:      "survival",
+			State:     "running",
+			Image:     "docker.io/itzg/minecraft-server:java21",
+			StartedAt: time.Now().Add(-90 * time.Minute),
+			Labels:    map[string]string{"go-mc.server": "survival"},
+			Mounts:    []container.Mount{{Source: serverState.Volumes.Data, Destination: "/data"}},
+			Ports:     server.PortMappings(serverState),
+		},
+		stats: &container.ContainerStats{CPUPercent: 12.5, MemoryUsed: 1 << 30, MemoryLimit: 4 << 30, MemoryPercent: 25},
+	}
+
+	info := gatherInspect(context.Background(), serverState, client)
+
+	// The container state wins over the stale saved status
+	assert.Equal(t, "running", info.Status)
+	require.NotNil(t, info.Container)
+	assert.Equal(t, "docker.io/itzg/minecraft-server:java21", info.Container.Image)
+	assert.Equal(t, []InspectMount{{Source: serverState.Volumes.Data, Destination: "/data"}}, info.Container.Mounts)
+
+	require.NotNil(t, info.Runtime)
+	assert.Equal(t, "1h 30m", info.Runtime.Uptime)
+	assert.Equal(t, 12.5, info.Runtime.CPUPercent)
+	require.NotNil(t, info.Runtime.Players)
+	assert.Equal(t, []string{"Notch"}, info.Runtime.Players.Names)
+	require.NotNil(t, info.Runtime.Ping)
+	assert.Equal(t, "1.21.1", info.Runtime.Ping.Version)
+	assert.Equal(t, "Survival", info.Runtime.Ping.MOTD)
+	assert.Positive(t, info.Runtime.Ping.LatencyMS)
+	for _, p := range info.Ports {
+		assert.Equal(t, "published", p.Status, p.Name)
+	}
+	assert.Empty(t, info.Warnings)
+}
+
+func TestGatherInspect_PlayersFromPing(t *testing.T) {
+	setupTestStateDir(t, t.TempDir())
+	serverState := saveInspectServer(t, "survival")
+
+	// RCON is not answering, the game port is
+	game := mcpingtest.NewServer(t, mcping.Status{
+		Version: mcping.Version{Name: "1.21.1", Protocol: 767},
+		Players: mcping.Players{Max: 20, Online: 2, Sample: []mcping.Player{{Name: "Notch"}, {Name: "jeb_"}}},
+	})
+	serverState.ContainerID = "abc123def456789"
+	serverState.Minecraft.GamePort = game.Port()
+	serverState.Minecraft.RconPort = 0
+
+	client := &inspectClient{info: &container.ContainerInfo{ID: "abc123def456789", State: "running", StartedAt: time.Now()}}
+	info := gatherInspect(context.Background(), serverState, client)
+
+	require.NotNil(t, info.Runtime.Players)
+	assert.Equal(t, &InspectPlayers{Online: 2, Max: 20, Names: []string{"Notch", "jeb_"}}, info.Runtime.Players)
+	for _, w := range info.Warnings {
+		assert.NotContains(t, w, "players:")
+		assert.NotContains(t, w, "ping:")
+	}
+}
+
+func TestGatherInspect_PendingPorts(t *testing.T) {
+	setupTestStateDir(t, t.TempDir())
+	serverState := saveInspectServer(t, "survival")
+	serverState.ContainerID = "abc123def456789"
+
+	// The container was created before voice chat was installed
+	client := &inspectClient{
+		info: &container.ContainerInfo{
+			ID:    "abc123def456789",
+			State: "exited",
+			Ports: []container.PortMapping{
+				{HostIP: "0.0.0.0", HostPort: 25565, ContainerPort: 25565, Protocol: "tcp"},
+				{HostIP: "0.0.0.0", HostPort: 25575, ContainerPort: 25575, Protocol: "tcp"},
+			},
+		},
+	}
+
+	info := gatherInspect(context.Background(), serverState, client)
+
+	require.Len(t, info.Ports, 3)
+	assert.Equal(t, "published", info.Ports[0].Status)
+	assert.Equal(t, "published", info.Ports[1].Status)
+	assert.Equal(t, "pending", info.Ports[2].Status)
+	require.Len(t, info.Warnings, 1)
+	assert.Contains(t, info.Warnings[0], "ports:")
+}
+
+func TestGatherInspect_MissingContainer(t *testing.T) {
+	setupTestStateDir(t, t.TempDir())
+	serverState := saveInspectServer(t, "survival")
+	serverState.ContainerID = "gone"
+
+	client := &inspectClient{inspErr: fmt.Errorf("%w: gone", container.ErrContainerNotFound)}
+	info := gatherInspect(context.Background(), serverState, client)
+
+	assert.Equal(t, "missing", info.Status)
+	assert.Nil(t, info.Container)
+	require.Len(t, info.Warnings, 1)
+	assert.Contains(t, info.Warnings[0], "container:")
+}
+
+func TestRunInspect_Formats(t *testing.T) {
+	setupTestStateDir(t, t.TempDir())
+	t.Setenv("GOMC_JSON", "")
+	saveInspectServer(t, "survival")
+
+	ctx := context.Background()
+
+	t.Run("human", func(t *testing.T) {
+		var stdout bytes.Buffer
+		require.NoError(t, runInspect(ctx, &stdout, "survival", &InspectFlags{}))
+		out := stdout.String()
+		assert.Contains(t, out, "Server: survival")
+		assert.Contains(t, out, "1.21.1 (Fabric loader 0.16.5)")
+		assert.Contains(t, out, "simple-voice-chat 24454->24454/udp")
+		assert.Contains(t, out, "Notch (level 4)")
+		assert.Contains(t, out, "enabled (friends)")
+		assert.Contains(t, out, "Mods (2):")
+	})
+
+	t.Run("json", func(t *testing.T) {
+		var stdout bytes.Buffer
+		require.NoError(t, runInspect(ctx, &stdout, "survival", &InspectFlags{Output: "json"}))
+
+		var out InspectOutput
+		require.NoError(t, json.Unmarshal(stdout.Bytes(), &out))
+		assert.Equal(t, "success", out.Status)
+		require.NotNil(t, out.Data)
+		assert.Equal(t, "fabric-api", out.Data.Mods[0].Slug)
+		assert.Contains(t, stdout.String(), `"fabric_loader_version": "0.16.5"`)
+	})
+
+	t.Run("yaml", func(t *testing.T) {
+		var stdout bytes.Buffer
+		require.NoError(t, runInspect(ctx, &stdout, "survival", &InspectFlags{Output: "yaml"}))
+
+		var out ServerInspect
+		require.NoError(t, yaml.Unmarshal(stdout.Bytes(), &out))
+		assert.Equal(t, "survival", out.Name)
+		assert.Equal(t, int64(100), out.Disk.Data.Bytes)
+	})
+
+	t.Run("template", func(t *testing.T) {
+		var stdout bytes.Buffer
+		flags := &InspectFlags{Format: `{{.Status}} {{range .Mods}}{{.Slug}},{{end}} {{json .Whitelist.Lists}}`}
+		require.NoError(t, runInspect(ctx, &stdout, "survival", flags))
+		assert.Equal(t, "stopped fabric-api,simple-voice-chat, [\"friends\"]\n", stdout.String())
+	})
+}
+
+func TestRunInspect_Errors(t *testing.T) {
+	setupTestStateDir(t, t.TempDir())
+	t.Setenv("GOMC_JSON", "")
+	saveInspectServer(t, "survival")
+
+	tests := []struct {
+		name    string
+		server  string
+		flags   *InspectFlags
+		wantErr string
+	}{
+		{name: "unknown output", server: "survival", flags: &InspectFlags{Output: "xml"}, wantErr: "invalid output format"},
+		{name: "format and output", server: "survival", flags: &InspectFlags{Output: "json", Format: "{{.Name}}"}, wantErr: "cannot be combined"},
+		{name: "bad template", server: "survival", flags: &InspectFlags{Format: "{{.Name"}, wantErr: "invalid format template"},
+		{name: "unknown server", server: "missing", flags: &InspectFlags{}, wantErr: "does not exist"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stdout bytes.Buffer
+			err := runInspect(context.Background(), &stdout, tt.server, tt.flags)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
@@ -8,14 +8,17 @@ import (
 	"log/slog"
 
 	"github.com/steviee/go-mc/internal/container"
+	"github.com/steviee/go-mc/internal/lifecycle"
+	"github.com/steviee/go-mc/internal/server"
 	"github.com/steviee/go-mc/internal/state"
 )
 
 // OperationResult aggregates results from multiple server operations
 type OperationResult struct {
-	Success []string          // Successfully processed servers
-	Failed  map[string]string // Failed servers with error messages
-	Skipped []string          // Skipped servers (already in target state)
+	Success []string                         // Successfully processed servers
+	Failed  map[string]string                // Failed servers with error messages
+	Skipped []string                         // Skipped servers (already in target state)
+	Timing  map[string]*lifecycle.WaitResult // Phase timing of servers waited for with --wait
 }
 
 // NewOperationResult creates a new operation result
@@ -24,6 +27,7 @@ func NewOperationResult() *OperationResult {
 		Success: make([]string, 0),
 		Failed:  make(map[string]string),
 		Skipped: make([]string, 0),
+		Timing:  make(map[string]*lifecycle.WaitResult),
 	}
 }
 
@@ -115,6 +119,10 @@ func outputOperationHuman(stdout io.Writer, operation string, result *OperationR
 	// Output successful operations
 	for _, name := range result.Success {
 		verb := getOperationVerb(operation, false)
+		if timing := result.Timing[name]; timing != nil {
+			_, _ = fmt.Fprintf(stdout, "%s server '%s' (%s)\n", verb, name, formatTiming(timing))
+			continue
+		}
 		_, _ = fmt.Fprintf(stdout, "%s server '%s'\n", verb, name)
 	}
 
@@ -160,6 +168,14 @@ func outputOperationJSON(stdout io.Writer, operation string, result *OperationRe
 		data["failed"] = result.Failed
 	}
 
+	if len(result.Timing) > 0 {
+		timing := make(map[string]TimingOutput, len(result.Timing))
+		for name, t := range result.Timing {
+			timing[name] = newTimingOutput(t)
+		}
+		data["timing"] = timing
+	}
+
 	output := LifecycleOutput{
 		Status: status,
 		Data:   data,
@@ -213,7 +229,7 @@ func getSkipReason(operation string) string {
 
 // createContainerClient creates a new container client
 func createContainerClient(ctx context.Context) (container.Client, error) {
-	client, err := container.NewClient(ctx, container.DefaultConfig())
+	client, err := server.ConnectContainerRuntime(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect to container runtime: %w", err)
 	}
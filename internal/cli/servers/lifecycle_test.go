@@ -5,7 +5,9 @@ import (
 	"context"
 	"encoding/json"
 	"testing"
+	"time"
 
+	"github.com/steviee/go-mc/internal/lifecycle"
 	"github.com/steviee/go-mc/internal/state"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -206,6 +208,20 @@ func TestOutputOperationHuman(t *testing.T) {
 			wantErr:  true,
 			contains: []string{"Stopped server 'server1'", "Failed to stop", "container not found"},
 		},
+		{
+			name:      "with timing",
+			operation: "started",
+			result: &OperationResult{
+				Success: []string{"server1"},
+				Failed:  make(map[string]string),
+				Skipped: []string{},
+				Timing: map[string]*lifecycle.WaitResult{
+					"server1": {Condition: lifecycle.ConditionReady, ContainerUp: 820 * time.Millisecond, WorldLoaded: 14230 * time.Millisecond},
+				},
+			},
+			wantErr:  false,
+			contains: []string{"Started server 'server1' (container up after 800ms, world loaded after 14.2s)"},
+		},
 	}
 
 	for _, tt := range tests {
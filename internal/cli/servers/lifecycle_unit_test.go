@@ -253,20 +253,23 @@ func TestAllocatePorts(t *testing.T) {
 		name      string
 		gamePort  int
 		rconPort  int
+		queryPort int
 		setupFunc func()
 		wantErr   bool
 		errMsg    string
 	}{
 		{
-			name:     "allocate both ports successfully",
-			gamePort: 25565,
-			rconPort: 35565,
-			wantErr:  false,
+			name:      "allocate all ports successfully",
+			gamePort:  25565,
+			rconPort:  35565,
+			queryPort: 25665,
+			wantErr:   false,
 		},
 		{
-			name:     "game port already allocated",
-			gamePort: 25566,
-			rconPort: 35566,
+			name:      "game port already allocated",
+			gamePort:  25566,
+			rconPort:  35566,
+			queryPort: 25666,
 			setupFunc: func() {
 				require.NoError(t, state.AllocatePort(ctx, 25566))
 			},
@@ -274,15 +277,27 @@ func TestAllocatePorts(t *testing.T) {
 			errMsg:  "game port",
 		},
 		{
-			name:     "rcon port already allocated",
-			gamePort: 25567,
-			rconPort: 35567,
+			name:      "rcon port already allocated",
+			gamePort:  25567,
+			rconPort:  35567,
+			queryPort: 25667,
 			setupFunc: func() {
 				require.NoError(t, state.AllocatePort(ctx, 35567))
 			},
 			wantErr: true,
 			errMsg:  "RCON port",
 		},
+		{
+			name:      "query port already allocated",
+			gamePort:  25568,
+			rconPort:  35568,
+			queryPort: 25668,
+			setupFunc: func() {
+				require.NoError(t, state.AllocatePort(ctx, 25668))
+			},
+			wantErr: true,
+			errMsg:  "query port",
+		},
 	}
 
 	for _, tt := range tests {
@@ -291,11 +306,12 @@ func TestAllocatePorts(t *testing.T) {
 				tt.setupFunc()
 			}
 
-			err := allocatePorts(ctx, tt.gamePort, tt.rconPort)
+			err := allocatePorts(ctx, tt.gamePort, tt.rconPort, tt.queryPort)
 
 			// Cleanup
 			_ = state.ReleasePort(ctx, tt.gamePort)
 			_ = state.ReleasePort(ctx, tt.rconPort)
+			_ = state.ReleasePort(ctx, tt.queryPort)
 
 			if tt.wantErr {
 				require.Error(t, err)
@@ -331,7 +347,7 @@ func TestAllocatePorts_RollbackOnRCONFailure(t *testing.T) {
 
 	// Try to allocate both ports (should fail on RCON and rollback game port)
 	gamePort := 25570
-	err = allocatePorts(ctx, gamePort, rconPort)
+	err = allocatePorts(ctx, gamePort, rconPort, 25670)
 	require.Error(t, err)
 	assert.Contains(t, err.Error(), "RCON port")
 
@@ -343,3 +359,19 @@ func TestAllocatePorts_RollbackOnRCONFailure(t *testing.T) {
 	// Cleanup
 	_ = state.ReleasePort(ctx, rconPort)
 }
+
+func TestAllocatePorts_RollbackOnQueryFailure(t *testing.T) {
+	ctx := context.Background()
+	setupTestStateDir(t, t.TempDir())
+
+	require.NoError(t, state.AllocatePort(ctx, 25671))
+
+	err := allocatePorts(ctx, 25571, 35571, 25671)
+	assert.ErrorContains(t, err, "query port")
+
+	for _, port := range []int{25571, 35571} {
+		allocated, err := state.IsPortAllocated(ctx, port)
+		require.NoError(t, err)
+		assert.False(t, allocated, "port %d should have been rolled back", port)
+	}
+}
@@ -6,15 +6,22 @@ import (
 	"fmt"
 	"io"
 	"log/slog"
+	"net"
 	"sort"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/steviee/go-mc/internal/container"
+	"github.com/steviee/go-mc/internal/mcping"
+	"github.com/steviee/go-mc/internal/server"
 	"github.com/steviee/go-mc/internal/state"
 )
 
+// listPingTimeout bounds the server list ping of each running server.
+const listPingTimeout = 2 * time.Second
+
 // ListFlags holds all flags for the list command
 type ListFlags struct {
 	All      bool
@@ -25,14 +32,23 @@ type ListFlags struct {
 
 // ServerListItem represents a server in the list output
 type ServerListItem struct {
-	Name        string    `json:"name"`
-	Status      string    `json:"status"`
-	Version     string    `json:"version"`
-	Port        int       `json:"port"`
-	MemoryUsed  string    `json:"memory_used,omitempty"`
-	MemoryTotal string    `json:"memory_total"`
-	Uptime      string    `json:"uptime,omitempty"`
-	StartedAt   time.Time `json:"started_at,omitempty"`
+	Name        string       `json:"name"`
+	Status      string       `json:"status"`
+	Version     string       `json:"version"`
+	Port        int          `json:"port"`
+	Players     *ListPlayers `json:"players,omitempty"`
+	Autostart   bool         `json:"autostart"`
+	MemoryUsed  string       `json:"memory_used,omitempty"`
+	MemoryTotal string       `json:"memory_total"`
+	Uptime      string       `json:"uptime,omitempty"`
+	StartedAt   time.Time    `json:"started_at,omitempty"`
+}
+
+// ListPlayers holds the player counts a running server reports in its
+// server list ping
+type ListPlayers struct {
+	Online int `json:"online"`
+	Max    int `json:"max"`
 }
 
 // ListOutput holds the output for JSON mode
@@ -55,6 +71,9 @@ func NewListCommand() *cobra.Command {
 By default, only running servers are shown. Use --all to show all servers
 including stopped ones.
 
+The PLAYERS column shows the players online and the player limit that running
+servers report in their server list ping.
+
 The output can be filtered by status and sorted by various fields.`,
 		Example: `  # List all running servers
   go-mc servers list
@@ -68,6 +87,9 @@ The output can be filtered by status and sorted by various fields.`,
   # Sort by memory usage
   go-mc servers list --sort=memory
 
+  # Busiest servers first
+  go-mc servers list --sort=players
+
   # JSON output for scripting
   go-mc servers list --json
 
@@ -82,7 +104,7 @@ The output can be filtered by status and sorted by various fields.`,
 	// Add flags
 	cmd.Flags().BoolVarP(&flags.All, "all", "a", false, "Show all servers including stopped ones")
 	cmd.Flags().StringVar(&flags.Filter, "filter", "", "Filter by status (running, created, stopped, all)")
-	cmd.Flags().StringVar(&flags.Sort, "sort", "name", "Sort by field (name, status, port, memory, uptime)")
+	cmd.Flags().StringVar(&flags.Sort, "sort", "name", "Sort by field (name, status, port, memory, uptime, players)")
 	cmd.Flags().BoolVar(&flags.NoHeader, "no-header", false, "Omit table header")
 
 	return cmd
@@ -117,7 +139,7 @@ func runList(ctx context.Context, stdout io.Writer, flags *ListFlags) error {
 	}
 
 	// Create container client
-	containerClient, err := container.NewClient(ctx, container.DefaultConfig())
+	containerClient, err := server.ConnectContainerRuntime(ctx)
 	if err != nil {
 		return outputListError(stdout, jsonMode, fmt.Errorf("failed to create container client: %w", err))
 	}
@@ -185,6 +207,7 @@ func collectServerInfo(ctx context.Context, name string, client container.Client
 	item.Version = serverState.Minecraft.Version
 	item.Port = serverState.Minecraft.GamePort
 	item.MemoryTotal = serverState.Minecraft.Memory
+	item.Autostart = serverState.Autostart.Enabled
 
 	// If no container ID, server was never started
 	if serverState.ContainerID == "" {
@@ -198,6 +221,10 @@ func collectServerInfo(ctx context.Context, name string, client container.Client
 		// Container no longer exists
 		if strings.Contains(err.Error(), "not found") || strings.Contains(err.Error(), "no such container") {
 			item.Status = "missing"
+			if serverState.Autostart.Enabled {
+				// systemd removes the container when the unit stops
+				item.Status = "stopped"
+			}
 			return item, nil
 		}
 		return item, fmt.Errorf("failed to inspect container: %w", err)
@@ -215,9 +242,32 @@ func collectServerInfo(ctx context.Context, name string, client container.Client
 		item.MemoryUsed = "-"
 	}
 
+	// Ask the server itself for its players
+	if item.Status == "running" {
+		status, err := pingLocalServer(ctx, serverState, listPingTimeout)
+		if err != nil {
+			slog.Debug("server list ping failed", "server", name, "error", err)
+		} else {
+			item.Players = &ListPlayers{Online: status.Players.Online, Max: status.Players.Max}
+		}
+	}
+
 	return item, nil
 }
 
+// pingLocalServer sends a server list ping to the game port of a server on
+// this host
+func pingLocalServer(ctx context.Context, serverState *state.ServerState, timeout time.Duration) (*mcping.Status, error) {
+	if serverState.Minecraft.GamePort == 0 {
+		return nil, fmt.Errorf("server %q has no game port", serverState.Name)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	return mcping.Ping(ctx, net.JoinHostPort("127.0.0.1", strconv.Itoa(serverState.Minecraft.GamePort)))
+}
+
 // normalizeContainerState maps container states to simplified status strings
 func normalizeContainerState(state string) string {
 	state = strings.ToLower(state)
@@ -304,6 +354,18 @@ func sortServers(items []ServerListItem, sortBy string) {
 			}
 			return items[i].Name < items[j].Name
 		})
+	case "players":
+		sort.Slice(items, func(i, j int) bool {
+			// Most players online first, servers that did not answer last
+			pi, pj := items[i].Players, items[j].Players
+			if pi != nil && pj != nil && pi.Online != pj.Online {
+				return pi.Online > pj.Online
+			}
+			if (pi == nil) != (pj == nil) {
+				return pi != nil
+			}
+			return items[i].Name < items[j].Name
+		})
 	case "uptime":
 		sort.Slice(items, func(i, j int) bool {
 			// Sort by started time (earliest first = longest uptime)
@@ -353,6 +415,7 @@ func outputListTable(stdout io.Writer, items []ServerListItem, noHeader bool) er
 	nameWidth := len("NAME")
 	statusWidth := len("STATUS")
 	versionWidth := len("VERSION")
+	playersWidth := len("PLAYERS")
 	portWidth := len("PORT")
 	memoryWidth := len("MEMORY")
 	uptimeWidth := len("UPTIME")
@@ -367,6 +430,9 @@ func outputListTable(stdout io.Writer, items []ServerListItem, noHeader bool) er
 		if len(item.Version) > versionWidth {
 			versionWidth = len(item.Version)
 		}
+		if players := formatPlayers(item); len(players) > playersWidth {
+			playersWidth = len(players)
+		}
 		portStr := fmt.Sprintf("%d", item.Port)
 		if len(portStr) > portWidth {
 			portWidth = len(portStr)
@@ -384,13 +450,15 @@ func outputListTable(stdout io.Writer, items []ServerListItem, noHeader bool) er
 
 	// Print header
 	if !noHeader {
-		_, _ = fmt.Fprintf(stdout, "%-*s  %-*s  %-*s  %*s  %*s  %*s\n",
+		_, _ = fmt.Fprintf(stdout, "%-*s  %-*s  %-*s  %*s  %*s  %*s  %*s  %s\n",
 			nameWidth, "NAME",
 			statusWidth, "STATUS",
 			versionWidth, "VERSION",
+			playersWidth, "PLAYERS",
 			portWidth, "PORT",
 			memoryWidth, "MEMORY",
 			uptimeWidth, "UPTIME",
+			"AUTOSTART",
 		)
 	}
 
@@ -401,19 +469,37 @@ func outputListTable(stdout io.Writer, items []ServerListItem, noHeader bool) er
 			uptime = "-"
 		}
 
-		_, _ = fmt.Fprintf(stdout, "%-*s  %-*s  %-*s  %*d  %*s  %*s\n",
+		_, _ = fmt.Fprintf(stdout, "%-*s  %-*s  %-*s  %*s  %*d  %*s  %*s  %s\n",
 			nameWidth, item.Name,
 			statusWidth, item.Status,
 			versionWidth, item.Version,
+			playersWidth, formatPlayers(item),
 			portWidth, item.Port,
 			memoryWidth, formatMemoryDisplay(item),
 			uptimeWidth, uptime,
+			formatAutostart(item),
 		)
 	}
 
 	return nil
 }
 
+// formatPlayers formats the player counts for table display
+func formatPlayers(item ServerListItem) string {
+	if item.Players == nil {
+		return "-"
+	}
+	return fmt.Sprintf("%d/%d", item.Players.Online, item.Players.Max)
+}
+
+// formatAutostart formats the autostart status for table display
+func formatAutostart(item ServerListItem) string {
+	if item.Autostart {
+		return "on"
+	}
+	return "off"
+}
+
 // formatMemoryDisplay formats memory info for table display
 func formatMemoryDisplay(item ServerListItem) string {
 	if item.Status == "running" {
@@ -1,11 +1,17 @@
 package servers
 
 import (
+	"context"
 	"encoding/json"
+	"fmt"
 	"strings"
 	"testing"
 	"time"
 
+	"github.com/steviee/go-mc/internal/container"
+	"github.com/steviee/go-mc/internal/mcping"
+	"github.com/steviee/go-mc/internal/mcping/mcpingtest"
+	"github.com/steviee/go-mc/internal/state"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -207,9 +213,9 @@ func TestFilterServers(t *testing.T) {
 func TestSortServers(t *testing.T) {
 	now := time.Now()
 	items := []ServerListItem{
-		{Name: "server-c", Status: "running", Port: 25567, StartedAt: now.Add(-2 * time.Hour)},
+		{Name: "server-c", Status: "running", Port: 25567, StartedAt: now.Add(-2 * time.Hour), Players: &ListPlayers{Online: 1, Max: 20}},
 		{Name: "server-a", Status: "stopped", Port: 25565},
-		{Name: "server-b", Status: "running", Port: 25566, StartedAt: now.Add(-1 * time.Hour)},
+		{Name: "server-b", Status: "running", Port: 25566, StartedAt: now.Add(-1 * time.Hour), Players: &ListPlayers{Online: 5, Max: 20}},
 	}
 
 	tests := []struct {
@@ -265,6 +271,16 @@ func TestSortServers(t *testing.T) {
 				assert.Equal(t, "server-a", items[2].Name)
 			},
 		},
+		{
+			name:   "sort by players (most first)",
+			sortBy: "players",
+			wantFunc: func(t *testing.T, items []ServerListItem) {
+				assert.Equal(t, "server-b", items[0].Name)
+				assert.Equal(t, "server-c", items[1].Name)
+				// server-a did not answer the ping
+				assert.Equal(t, "server-a", items[2].Name)
+			},
+		},
 		{
 			name:   "default sort (name)",
 			sortBy: "invalid",
@@ -288,6 +304,47 @@ func TestSortServers(t *testing.T) {
 	}
 }
 
+func TestCollectServerInfo_Players(t *testing.T) {
+	setupTestStateDir(t, t.TempDir())
+
+	game := mcpingtest.NewServer(t, mcping.Status{
+		Version: mcping.Version{Name: "1.21.1", Protocol: 767},
+		Players: mcping.Players{Max: 20, Online: 3},
+	})
+	serverState := state.NewServerState("survival")
+	serverState.ContainerID = "abc123"
+	serverState.Minecraft.GamePort = game.Port()
+	require.NoError(t, state.SaveServerState(context.Background(), serverState))
+
+	client := &inspectClient{info: &container.ContainerInfo{ID: "abc123", State: "running"}}
+	item, err := collectServerInfo(context.Background(), "survival", client)
+	require.NoError(t, err)
+	assert.Equal(t, &ListPlayers{Online: 3, Max: 20}, item.Players)
+
+	// A server that does not answer has no player counts
+	game.Close()
+	item, err = collectServerInfo(context.Background(), "survival", client)
+	require.NoError(t, err)
+	assert.Nil(t, item.Players)
+	assert.Equal(t, "-", formatPlayers(item))
+}
+
+func TestCollectServerInfo_Autostart(t *testing.T) {
+	setupTestStateDir(t, t.TempDir())
+
+	serverState := state.NewServerState("survival")
+	serverState.ContainerID = "survival"
+	serverState.Autostart.Enabled = true
+	require.NoError(t, state.SaveServerState(context.Background(), serverState))
+
+	// systemd removed the container when the unit stopped
+	client := &inspectClient{inspErr: fmt.Errorf("%w: survival", container.ErrContainerNotFound)}
+	item, err := collectServerInfo(context.Background(), "survival", client)
+	require.NoError(t, err)
+	assert.Equal(t, "stopped", item.Status)
+	assert.True(t, item.Autostart)
+}
+
 func TestFormatMemoryDisplay(t *testing.T) {
 	tests := []struct {
 		name string
@@ -383,6 +440,8 @@ func TestOutputListTable(t *testing.T) {
 			Port:        25565,
 			MemoryTotal: "2G",
 			Uptime:      "2h 15m",
+			Players:     &ListPlayers{Online: 3, Max: 20},
+			Autostart:   true,
 		},
 		{
 			Name:        "creative",
@@ -408,16 +467,20 @@ func TestOutputListTable(t *testing.T) {
 		assert.Contains(t, lines[0], "NAME")
 		assert.Contains(t, lines[0], "STATUS")
 		assert.Contains(t, lines[0], "VERSION")
+		assert.Contains(t, lines[0], "PLAYERS")
 		assert.Contains(t, lines[0], "PORT")
 		assert.Contains(t, lines[0], "MEMORY")
 		assert.Contains(t, lines[0], "UPTIME")
+		assert.Contains(t, lines[0], "AUTOSTART")
 
 		// Verify first row
 		assert.Contains(t, lines[1], "survival")
 		assert.Contains(t, lines[1], "running")
 		assert.Contains(t, lines[1], "1.21.1")
+		assert.Contains(t, lines[1], "3/20")
 		assert.Contains(t, lines[1], "25565")
 		assert.Contains(t, lines[1], "2h 15m")
+		assert.True(t, strings.HasSuffix(lines[1], "  on"))
 
 		// Verify second row
 		assert.Contains(t, lines[2], "creative")
@@ -425,6 +488,7 @@ func TestOutputListTable(t *testing.T) {
 		assert.Contains(t, lines[2], "1.20.4")
 		assert.Contains(t, lines[2], "25566")
 		assert.Contains(t, lines[2], "-")
+		assert.True(t, strings.HasSuffix(lines[2], "  off"))
 	})
 
 	t.Run("without header", func(t *testing.T) {
@@ -2,13 +2,19 @@ package servers
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"io"
 	"os"
-	"os/exec"
 	"os/signal"
+	"regexp"
+	"strings"
 	"syscall"
+	"time"
 
 	"github.com/spf13/cobra"
+	"github.com/steviee/go-mc/internal/container"
+	"github.com/steviee/go-mc/internal/mclog"
 	"github.com/steviee/go-mc/internal/state"
 )
 
@@ -18,8 +24,37 @@ type LogsFlags struct {
 	Tail       int
 	Since      string
 	Timestamps bool
+	Level      string
+	Grep       string
+	Events     []string
 }
 
+// LogRecordOutput is a log record in JSON output, one object per line
+type LogRecordOutput struct {
+	Time    string   `json:"time,omitempty"`
+	Stream  string   `json:"stream,omitempty"`
+	Clock   string   `json:"clock,omitempty"`
+	Thread  string   `json:"thread,omitempty"`
+	Level   string   `json:"level,omitempty"`
+	Logger  string   `json:"logger,omitempty"`
+	Message string   `json:"message"`
+	Event   string   `json:"event,omitempty"`
+	Player  string   `json:"player,omitempty"`
+	Stack   []string `json:"stack,omitempty"`
+	Raw     string   `json:"raw"`
+}
+
+// logsView controls which log records streamLogs prints and how
+type logsView struct {
+	Filter     mclog.Filter
+	Timestamps bool
+	JSON       bool
+}
+
+// logsFlushDelay is how long a followed record waits for stack trace lines
+// before it is printed
+const logsFlushDelay = 250 * time.Millisecond
+
 // NewLogsCommand creates the servers logs subcommand
 func NewLogsCommand() *cobra.Command {
 	flags := &LogsFlags{}
@@ -29,7 +64,23 @@ func NewLogsCommand() *cobra.Command {
 		Short: "View container logs for a Minecraft server",
 		Long: `View container logs for a Minecraft server to debug startup issues and monitor server output.
 
-Logs are fetched directly from the container runtime (Podman/Docker).`,
+Logs are streamed through the container runtime API (Podman/Docker), so the
+podman or docker binary does not need to be installed. Lines the server writes
+to stderr are printed to stderr.
+
+Lines are parsed as Minecraft log records, so they can be filtered by level,
+by a regular expression, or by event. Stack traces stay together with the line
+that logged them. Events are:
+
+  ready       the server finished starting ("Done (3.2s)!")
+  join        a player joined
+  leave       a player left
+  chat        a player chat message
+  death       a player died
+  stacktrace  an exception with its stack trace
+  lag         "Can't keep up!" warnings
+
+With --json, every record is printed as one JSON object per line (NDJSON).`,
 		Example: `  # View last 100 lines of logs
   go-mc servers logs myserver
 
@@ -46,24 +97,36 @@ Logs are fetched directly from the container runtime (Podman/Docker).`,
   go-mc servers logs myserver --timestamps
 
   # Combine flags
-  go-mc servers logs myserver --tail 20 --follow --timestamps`,
+  go-mc servers logs myserver --tail 20 --follow --timestamps
+
+  # Show warnings and errors only
+  go-mc servers logs myserver --level warn
+
+  # Follow players joining and leaving
+  go-mc servers logs myserver -f --events join,leave
+
+  # Search all logs and print records as NDJSON
+  go-mc servers logs myserver --tail 0 --grep "(?i)exception" --json`,
 		Args: cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runLogs(cmd.Context(), args[0], flags)
+			return runLogs(cmd.Context(), cmd.OutOrStdout(), cmd.ErrOrStderr(), args[0], flags)
 		},
 	}
 
 	// Add flags
 	cmd.Flags().BoolVarP(&flags.Follow, "follow", "f", false, "Follow log output (stream in real-time)")
-	cmd.Flags().IntVarP(&flags.Tail, "tail", "n", 100, "Number of lines to show from the end of the logs")
+	cmd.Flags().IntVarP(&flags.Tail, "tail", "n", 100, "Number of lines to show from the end of the logs (0 for all)")
 	cmd.Flags().StringVar(&flags.Since, "since", "", "Show logs since timestamp (e.g. 5m, 1h, 2025-01-01)")
 	cmd.Flags().BoolVarP(&flags.Timestamps, "timestamps", "t", false, "Show timestamps")
+	cmd.Flags().StringVar(&flags.Level, "level", "", "Show records of this level or more severe (trace, debug, info, warn, error, fatal)")
+	cmd.Flags().StringVar(&flags.Grep, "grep", "", "Show records matching this regular expression")
+	cmd.Flags().StringSliceVar(&flags.Events, "events", []string{}, "Show only these events (ready, join, leave, chat, death, stacktrace, lag)")
 
 	return cmd
 }
 
 // runLogs executes the logs command
-func runLogs(ctx context.Context, serverName string, flags *LogsFlags) error {
+func runLogs(ctx context.Context, stdout, stderr io.Writer, serverName string, flags *LogsFlags) error {
 	// Load server state
 	serverState, err := state.LoadServerState(ctx, serverName)
 	if err != nil {
@@ -75,82 +138,195 @@ func runLogs(ctx context.Context, serverName string, flags *LogsFlags) error {
 		return fmt.Errorf("server '%s' has no container (never started)", serverName)
 	}
 
-	// Try podman first, then docker
-	runtime := detectContainerRuntime()
+	filter, err := buildLogFilter(flags)
+	if err != nil {
+		return err
+	}
+
+	view := logsView{
+		Filter:     filter,
+		Timestamps: flags.Timestamps,
+		JSON:       isJSONMode(),
+	}
+
+	opts := container.LogOptions{
+		Follow: flags.Follow,
+		Tail:   flags.Tail,
+	}
 
-	// Build logs command
-	args := []string{"logs"}
+	if flags.Since != "" {
+		opts.Since, err = parseLogsSince(flags.Since, time.Now())
+		if err != nil {
+			return err
+		}
+	}
 
+	client, err := createContainerClient(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = client.Close() }()
+
+	// Stop following on Ctrl+C without reporting an error
 	if flags.Follow {
-		args = append(args, "--follow")
+		var stop context.CancelFunc
+		ctx, stop = signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+		defer stop()
 	}
 
-	if flags.Tail > 0 {
-		args = append(args, "--tail", fmt.Sprintf("%d", flags.Tail))
+	if err := streamLogs(ctx, client, serverState.ContainerID, opts, view, stdout, stderr); err != nil {
+		return fmt.Errorf("failed to get logs: %w", err)
 	}
 
-	if flags.Since != "" {
-		args = append(args, "--since", flags.Since)
+	return nil
+}
+
+// buildLogFilter builds the record filter from the --level, --grep and
+// --events flags
+func buildLogFilter(flags *LogsFlags) (mclog.Filter, error) {
+	var filter mclog.Filter
+
+	if flags.Level != "" {
+		level, err := mclog.ParseLevel(flags.Level)
+		if err != nil {
+			return filter, fmt.Errorf("invalid --level: %w", err)
+		}
+		filter.MinLevel = level
+	}
+
+	if flags.Grep != "" {
+		re, err := regexp.Compile(flags.Grep)
+		if err != nil {
+			return filter, fmt.Errorf("invalid --grep pattern: %w", err)
+		}
+		filter.Grep = re
 	}
 
-	if flags.Timestamps {
-		args = append(args, "--timestamps")
+	events, err := mclog.ParseEvents(strings.Join(flags.Events, ","))
+	if err != nil {
+		return filter, fmt.Errorf("invalid --events: %w", err)
 	}
+	filter.Events = events
 
-	args = append(args, serverState.ContainerID)
+	return filter, nil
+}
 
-	// Execute container runtime logs command
-	cmd := exec.CommandContext(ctx, runtime, args...)
-	cmd.Stdout = os.Stdout
-	cmd.Stderr = os.Stderr
+// streamLogs parses the log lines of a container into records and prints
+// those matching the view. Records go to stdout or stderr, matching the
+// stream the container wrote them to; JSON records all go to stdout. It
+// returns when the logs end or, in follow mode, when ctx is canceled.
+func streamLogs(ctx context.Context, client container.Client, containerID string, opts container.LogOptions, view logsView, stdout, stderr io.Writer) error {
+	lines, err := client.Logs(ctx, containerID, opts)
+	if err != nil {
+		return err
+	}
 
-	// Handle Ctrl+C gracefully for follow mode
-	if flags.Follow {
-		sigChan := make(chan os.Signal, 1)
-		signal.Notify(sigChan, os.Interrupt, syscall.SIGTERM)
-		defer signal.Stop(sigChan)
+	parser := mclog.NewParser()
+	encoder := json.NewEncoder(stdout)
 
-		// Start command
-		if err := cmd.Start(); err != nil {
-			return fmt.Errorf("failed to start logs: %w", err)
+	printRecord := func(record *mclog.Record) error {
+		if record == nil || !view.Filter.Match(*record) {
+			return nil
 		}
 
-		// Wait for either command completion or interrupt signal
-		done := make(chan error, 1)
-		go func() {
-			done <- cmd.Wait()
-		}()
+		if view.JSON {
+			if err := encoder.Encode(newLogRecordOutput(*record)); err != nil {
+				return fmt.Errorf("failed to encode log record: %w", err)
+			}
+			return nil
+		}
 
+		w := stdout
+		if record.Stream == container.LogStderr {
+			w = stderr
+		}
+		_, _ = fmt.Fprintln(w, formatLogRecord(*record, view.Timestamps))
+		return nil
+	}
+
+	// A followed record may be the last one for a while; print it once no
+	// stack trace lines arrived for logsFlushDelay
+	flushTimer := time.NewTimer(logsFlushDelay)
+	flushTimer.Stop()
+	defer flushTimer.Stop()
+
+	for {
 		select {
-		case <-sigChan:
-			// Interrupt received - kill process
-			if err := cmd.Process.Kill(); err != nil {
-				return fmt.Errorf("failed to kill logs process: %w", err)
+		case line, ok := <-lines:
+			if !ok {
+				return printRecord(parser.Flush())
 			}
-			<-done // Wait for process to finish
-			return nil
-		case err := <-done:
-			if err != nil {
-				return fmt.Errorf("logs command failed: %w", err)
+			if line.Err != nil {
+				_ = printRecord(parser.Flush())
+				return line.Err
+			}
+
+			if err := printRecord(parser.Feed(line.Time, line.Stream, line.Text)); err != nil {
+				return err
+			}
+			if opts.Follow {
+				flushTimer.Reset(logsFlushDelay)
+			}
+
+		case <-flushTimer.C:
+			if err := printRecord(parser.Flush()); err != nil {
+				return err
 			}
-			return nil
 		}
 	}
+}
 
-	// Non-follow mode - just run and wait
-	if err := cmd.Run(); err != nil {
-		return fmt.Errorf("failed to get logs: %w", err)
+// formatLogRecord returns a record as printed by the server, with the
+// timestamp of its first line if requested
+func formatLogRecord(record mclog.Record, timestamps bool) string {
+	if timestamps && !record.Time.IsZero() {
+		return record.Time.Format(time.RFC3339Nano) + " " + record.String()
 	}
+	return record.String()
+}
 
-	return nil
+// newLogRecordOutput converts a log record for JSON output
+func newLogRecordOutput(record mclog.Record) LogRecordOutput {
+	output := LogRecordOutput{
+		Stream:  record.Stream,
+		Clock:   record.Clock,
+		Thread:  record.Thread,
+		Level:   string(record.Level),
+		Logger:  record.Logger,
+		Message: record.Message,
+		Event:   string(record.Event),
+		Player:  record.Player,
+		Stack:   record.Stack,
+		Raw:     record.Raw,
+	}
+
+	if !record.Time.IsZero() {
+		output.Time = record.Time.Format(time.RFC3339Nano)
+	}
+
+	return output
+}
+
+// logsSinceLayouts are the absolute times accepted by --since
+var logsSinceLayouts = []string{
+	time.RFC3339,
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05",
+	"2006-01-02",
 }
 
-// detectContainerRuntime detects which container runtime is available
-func detectContainerRuntime() string {
-	// Try podman first
-	if _, err := exec.LookPath("podman"); err == nil {
-		return "podman"
+// parseLogsSince parses a --since value: a duration before now (e.g. 5m, 1h)
+// or an absolute time in local time unless it carries a zone
+func parseLogsSince(value string, now time.Time) (time.Time, error) {
+	if d, err := time.ParseDuration(value); err == nil && d > 0 {
+		return now.Add(-d), nil
 	}
-	// Fall back to docker
-	return "docker"
+
+	for _, layout := range logsSinceLayouts {
+		if t, err := time.ParseInLocation(layout, value, time.Local); err == nil {
+			return t, nil
+		}
+	}
+
+	return time.Time{}, fmt.Errorf("invalid --since value %q: use a duration like 5m or a time like 2025-01-01", value)
 }
@@ -0,0 +1,283 @@
+package servers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/steviee/go-mc/internal/container"
+	"github.com/steviee/go-mc/internal/mclog"
+	"github.com/steviee/go-mc/internal/state"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// logsClient is a container client that replays a fixed log stream
+type logsClient struct {
+	container.Client
+	lines []container.LogLine
+	err   error
+	opts  container.LogOptions
+}
+
+func (c *logsClient) Logs(ctx context.Context, containerID string, opts container.LogOptions) (<-chan container.LogLine, error) {
+	if c.err != nil {
+		return nil, c.err
+	}
+	c.opts = opts
+
+	lines := make(chan container.LogLine, len(c.lines))
+	for _, line := range c.lines {
+		lines <- line
+	}
+	close(lines)
+	return lines, nil
+}
+
+func TestStreamLogs(t *testing.T) {
+	ts := time.Date(2025, 1, 2, 12, 0, 0, 0, time.UTC)
+	client := &logsClient{lines: []container.LogLine{
+		{Stream: container.LogStdout, Time: ts, Text: "[Server thread/INFO]: Starting"},
+		{Stream: container.LogStderr, Time: ts, Text: "WARNING: unsafe"},
+		{Stream: container.LogStdout, Time: ts.Add(time.Second), Text: "[Server thread/INFO]: Done (1.0s)!"},
+	}}
+
+	t.Run("splits streams", func(t *testing.T) {
+		var stdout, stderr bytes.Buffer
+		opts := container.LogOptions{Tail: 50}
+
+		require.NoError(t, streamLogs(context.Background(), client, "abc123", opts, logsView{}, &stdout, &stderr))
+
+		assert.Equal(t, "[Server thread/INFO]: Starting\n[Server thread/INFO]: Done (1.0s)!\n", stdout.String())
+		assert.Equal(t, "WARNING: unsafe\n", stderr.String())
+		assert.Equal(t, opts, client.opts)
+	})
+
+	t.Run("timestamps", func(t *testing.T) {
+		var stdout bytes.Buffer
+
+		require.NoError(t, streamLogs(context.Background(), client, "abc123", container.LogOptions{}, logsView{Timestamps: true}, &stdout, &stdout))
+
+		assert.Contains(t, stdout.String(), "2025-01-02T12:00:00Z [Server thread/INFO]: Starting\n")
+		assert.Contains(t, stdout.String(), "2025-01-02T12:00:01Z [Server thread/INFO]: Done (1.0s)!\n")
+	})
+
+	t.Run("stream failure", func(t *testing.T) {
+		failing := &logsClient{lines: []container.LogLine{
+			{Stream: container.LogStdout, Text: "first"},
+			{Err: fmt.Errorf("connection reset")},
+		}}
+		var stdout bytes.Buffer
+
+		err := streamLogs(context.Background(), failing, "abc123", container.LogOptions{}, logsView{}, &stdout, &stdout)
+		assert.ErrorContains(t, err, "connection reset")
+		assert.Equal(t, "first\n", stdout.String())
+	})
+
+	t.Run("missing container", func(t *testing.T) {
+		missing := &logsClient{err: fmt.Errorf("%w: abc123", container.ErrContainerNotFound)}
+		var stdout bytes.Buffer
+
+		err := streamLogs(context.Background(), missing, "abc123", container.LogOptions{}, logsView{}, &stdout, &stdout)
+		assert.ErrorIs(t, err, container.ErrContainerNotFound)
+	})
+}
+
+// serverLog is the output of a server that hit an exception
+var serverLog = []container.LogLine{
+	{Stream: container.LogStdout, Text: "[init] Starting the Minecraft server..."},
+	{Stream: container.LogStdout, Text: "[12:00:00] [Server thread/INFO]: Done (3.2s)! For help, type \"help\""},
+	{Stream: container.LogStdout, Text: "[12:00:05] [Server thread/INFO]: Notch joined the game"},
+	{Stream: container.LogStdout, Text: "[12:00:06] [Server thread/ERROR]: Failed to save chunk"},
+	{Stream: container.LogStdout, Text: "java.io.IOException: No space left on device"},
+	{Stream: container.LogStdout, Text: "\tat net.minecraft.world.ChunkStorage.write(ChunkStorage.java:42)"},
+	{Stream: container.LogStdout, Text: "[12:00:07] [Server thread/WARN]: Can't keep up! Is the server overloaded? Running 2034ms or 40 ticks behind"},
+	{Stream: container.LogStdout, Text: "[12:00:09] [Server thread/INFO]: Notch left the game"},
+}
+
+func TestStreamLogs_Filters(t *testing.T) {
+	client := &logsClient{lines: serverLog}
+
+	tests := []struct {
+		name  string
+		flags LogsFlags
+		want  string
+	}{
+		{
+			name:  "level",
+			flags: LogsFlags{Level: "warn"},
+			want: "[12:00:06] [Server thread/ERROR]: Failed to save chunk\n" +
+				"java.io.IOException: No space left on device\n" +
+				"\tat net.minecraft.world.ChunkStorage.write(ChunkStorage.java:42)\n" +
+				"[12:00:07] [Server thread/WARN]: Can't keep up! Is the server overloaded? Running 2034ms or 40 ticks behind\n",
+		},
+		{
+			name:  "events",
+			flags: LogsFlags{Events: []string{"join", "leave"}},
+			want: "[12:00:05] [Server thread/INFO]: Notch joined the game\n" +
+				"[12:00:09] [Server thread/INFO]: Notch left the game\n",
+		},
+		{
+			name:  "grep matches stack traces",
+			flags: LogsFlags{Grep: "No space"},
+			want: "[12:00:06] [Server thread/ERROR]: Failed to save chunk\n" +
+				"java.io.IOException: No space left on device\n" +
+				"\tat net.minecraft.world.ChunkStorage.write(ChunkStorage.java:42)\n",
+		},
+		{
+			name:  "combined",
+			flags: LogsFlags{Level: "info", Grep: "Notch", Events: []string{"leave"}},
+			want:  "[12:00:09] [Server thread/INFO]: Notch left the game\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filter, err := buildLogFilter(&tt.flags)
+			require.NoError(t, err)
+
+			var stdout bytes.Buffer
+			require.NoError(t, streamLogs(context.Background(), client, "abc123", container.LogOptions{}, logsView{Filter: filter}, &stdout, &stdout))
+			assert.Equal(t, tt.want, stdout.String())
+		})
+	}
+}
+
+func TestStreamLogs_JSON(t *testing.T) {
+	ts := time.Date(2025, 1, 2, 12, 0, 0, 0, time.UTC)
+	lines := make([]container.LogLine, len(serverLog))
+	for i, line := range serverLog {
+		line.Time = ts
+		lines[i] = line
+	}
+
+	var stdout, stderr bytes.Buffer
+	view := logsView{Filter: mclog.Filter{Events: []mclog.Event{mclog.EventReady, mclog.EventStackTrace}}, JSON: true}
+	require.NoError(t, streamLogs(context.Background(), &logsClient{lines: lines}, "abc123", container.LogOptions{}, view, &stdout, &stderr))
+
+	records := strings.Split(strings.TrimSpace(stdout.String()), "\n")
+	require.Len(t, records, 2)
+	assert.Empty(t, stderr.String())
+
+	var ready LogRecordOutput
+	require.NoError(t, json.Unmarshal([]byte(records[0]), &ready))
+	assert.Equal(t, LogRecordOutput{
+		Time:    "2025-01-02T12:00:00Z",
+		Stream:  "stdout",
+		Clock:   "12:00:00",
+		Thread:  "Server thread",
+		Level:   "INFO",
+		Message: "Done (3.2s)! For help, type \"help\"",
+		Event:   "ready",
+		Raw:     serverLog[1].Text,
+	}, ready)
+
+	var failure LogRecordOutput
+	require.NoError(t, json.Unmarshal([]byte(records[1]), &failure))
+	assert.Equal(t, "stacktrace", failure.Event)
+	assert.Equal(t, "ERROR", failure.Level)
+	assert.Len(t, failure.Stack, 2)
+}
+
+// safeBuffer is a bytes.Buffer that can be written and read concurrently
+type safeBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *safeBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *safeBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+// followClient is a container client whose log stream is fed by the test
+type followClient struct {
+	container.Client
+	lines chan container.LogLine
+}
+
+func (c *followClient) Logs(ctx context.Context, containerID string, opts container.LogOptions) (<-chan container.LogLine, error) {
+	return c.lines, nil
+}
+
+func TestStreamLogs_FollowFlushesLastRecord(t *testing.T) {
+	client := &followClient{lines: make(chan container.LogLine)}
+	var stdout safeBuffer
+
+	done := make(chan error, 1)
+	go func() {
+		done <- streamLogs(context.Background(), client, "abc123", container.LogOptions{Follow: true}, logsView{}, &stdout, &stdout)
+	}()
+
+	client.lines <- container.LogLine{Stream: container.LogStdout, Text: "[12:00:05] [Server thread/INFO]: Notch joined the game"}
+
+	// The record is printed although no further line arrived
+	assert.Eventually(t, func() bool {
+		return stdout.String() == "[12:00:05] [Server thread/INFO]: Notch joined the game\n"
+	}, 5*time.Second, 10*time.Millisecond)
+
+	close(client.lines)
+	require.NoError(t, <-done)
+}
+
+func TestBuildLogFilter_Errors(t *testing.T) {
+	_, err := buildLogFilter(&LogsFlags{Level: "loud"})
+	assert.ErrorContains(t, err, "invalid --level")
+
+	_, err = buildLogFilter(&LogsFlags{Grep: "("})
+	assert.ErrorContains(t, err, "invalid --grep")
+
+	_, err = buildLogFilter(&LogsFlags{Events: []string{"join", "quit"}})
+	assert.ErrorContains(t, err, "invalid --events")
+}
+
+func TestParseLogsSince(t *testing.T) {
+	now := time.Date(2025, 1, 2, 12, 0, 0, 0, time.Local)
+
+	tests := []struct {
+		value   string
+		want    time.Time
+		wantErr bool
+	}{
+		{value: "5m", want: now.Add(-5 * time.Minute)},
+		{value: "1h30m", want: now.Add(-90 * time.Minute)},
+		{value: "2025-01-01", want: time.Date(2025, 1, 1, 0, 0, 0, 0, time.Local)},
+		{value: "2025-01-01T08:30:00", want: time.Date(2025, 1, 1, 8, 30, 0, 0, time.Local)},
+		{value: "2025-01-01T08:30:00Z", want: time.Date(2025, 1, 1, 8, 30, 0, 0, time.UTC)},
+		{value: "-5m", wantErr: true},
+		{value: "yesterday", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, err := parseLogsSince(tt.value, now)
+			if tt.wantErr {
+				assert.ErrorContains(t, err, "invalid --since value")
+				return
+			}
+			require.NoError(t, err)
+			assert.True(t, tt.want.Equal(got), "got %s, want %s", got, tt.want)
+		})
+	}
+}
+
+func TestRunLogs_NoContainer(t *testing.T) {
+	setupTestStateDir(t, t.TempDir())
+	require.NoError(t, state.SaveServerState(context.Background(), state.NewServerState("fresh")))
+
+	var stdout, stderr bytes.Buffer
+	err := runLogs(context.Background(), &stdout, &stderr, "fresh", &LogsFlags{Tail: 100})
+	assert.ErrorContains(t, err, "has no container")
+}
@@ -0,0 +1,100 @@
+package servers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/steviee/go-mc/internal/modrinth"
+	"github.com/steviee/go-mc/internal/mods"
+	"github.com/steviee/go-mc/internal/mrpack"
+)
+
+// openMrpack opens a modpack from a local .mrpack file or downloads it from
+// Modrinth by version ID or by project ID or slug. For a project, the latest
+// Fabric version is used, for mcVersion if set. The returned cleanup closes
+// the pack and removes any download.
+func openMrpack(ctx context.Context, ref, mcVersion string) (*mrpack.Pack, func(), error) {
+	if _, err := os.Stat(ref); err == nil || strings.HasSuffix(ref, ".mrpack") {
+		pack, err := mrpack.Open(ref)
+		if err != nil {
+			return nil, nil, fmt.Errorf("open modpack %s: %w", ref, err)
+		}
+		return pack, func() { _ = pack.Close() }, nil
+	}
+
+	client := modrinth.NewClient(nil)
+	version, err := client.GetVersion(ctx, ref)
+	if errors.Is(err, modrinth.ErrProjectNotFound) {
+		version, err = latestPackVersion(ctx, client, ref, mcVersion)
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("find modpack %q on Modrinth: %w", ref, err)
+	}
+
+	file, err := modrinth.GetPrimaryFile(version)
+	if err != nil {
+		return nil, nil, fmt.Errorf("modpack %q: %w", ref, err)
+	}
+
+	tmpDir, err := os.MkdirTemp("", "go-mc-mrpack-")
+	if err != nil {
+		return nil, nil, fmt.Errorf("create temporary directory: %w", err)
+	}
+	removeTmp := func() { _ = os.RemoveAll(tmpDir) }
+
+	path := filepath.Join(tmpDir, filepath.Base(file.Filename))
+	if err := mods.NewInstaller().DownloadFile(ctx, *file, path); err != nil {
+		removeTmp()
+		return nil, nil, fmt.Errorf("download modpack %s: %w", file.Filename, err)
+	}
+
+	pack, err := mrpack.Open(path)
+	if err != nil {
+		removeTmp()
+		return nil, nil, fmt.Errorf("open modpack %s: %w", file.Filename, err)
+	}
+
+	return pack, func() {
+		_ = pack.Close()
+		removeTmp()
+	}, nil
+}
+
+// latestPackVersion returns the latest Fabric version of a modpack project
+func latestPackVersion(ctx context.Context, client *modrinth.Client, project, mcVersion string) (*modrinth.Version, error) {
+	filter := &modrinth.VersionFilter{Loaders: []string{"fabric"}}
+	if mcVersion != "" && mcVersion != latestMinecraftVersion {
+		filter.GameVersions = []string{mcVersion}
+	}
+
+	versions, err := client.GetVersions(ctx, project, filter)
+	if err != nil {
+		return nil, err
+	}
+	if len(versions) == 0 {
+		return nil, fmt.Errorf("no Fabric version of %q: %w", project, modrinth.ErrNoCompatibleVersion)
+	}
+	return &versions[0], nil
+}
+
+// applyMrpack pins the Minecraft version of a server to the one the pack
+// requires and returns the Fabric loader version it pins. An explicit
+// --version must match the pack.
+func applyMrpack(flags *CreateFlags, idx *mrpack.Index) (string, error) {
+	loader, err := idx.FabricLoaderVersion()
+	if err != nil {
+		return "", err
+	}
+
+	version := idx.MinecraftVersion()
+	if flags.VersionSet && flags.Version != version {
+		return "", fmt.Errorf("--version %s conflicts with Minecraft %s required by modpack %q", flags.Version, version, idx.Name)
+	}
+	flags.Version = version
+
+	return loader, nil
+}
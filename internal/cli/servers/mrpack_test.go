@@ -0,0 +1,103 @@
+package servers
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha512"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/steviee/go-mc/internal/mrpack"
+	"github.com/steviee/go-mc/internal/state"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testMrpackIndex returns the index of a Fabric pack for Minecraft 1.21.1
+func testMrpackIndex() *mrpack.Index {
+	return &mrpack.Index{
+		FormatVersion: mrpack.FormatVersion,
+		Game:          mrpack.Game,
+		VersionID:     "1.0.0",
+		Name:          "Test Pack",
+		Files:         []mrpack.File{},
+		Dependencies: map[string]string{
+			mrpack.DependencyMinecraft:    "1.21.1",
+			mrpack.DependencyFabricLoader: "0.16.9",
+		},
+	}
+}
+
+func TestApplyMrpack(t *testing.T) {
+	flags := &CreateFlags{Version: "latest"}
+	loader, err := applyMrpack(flags, testMrpackIndex())
+	require.NoError(t, err)
+	assert.Equal(t, "0.16.9", loader)
+	assert.Equal(t, "1.21.1", flags.Version)
+
+	// A matching --version is fine, a different one is not
+	_, err = applyMrpack(&CreateFlags{Version: "1.21.1", VersionSet: true}, testMrpackIndex())
+	require.NoError(t, err)
+	_, err = applyMrpack(&CreateFlags{Version: "1.20.4", VersionSet: true}, testMrpackIndex())
+	assert.ErrorContains(t, err, "conflicts")
+
+	forge := testMrpackIndex()
+	forge.Dependencies[mrpack.DependencyForge] = "47.2.0"
+	_, err = applyMrpack(&CreateFlags{}, forge)
+	assert.Error(t, err)
+}
+
+func TestOpenMrpack_LocalFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "pack.mrpack")
+	require.NoError(t, writeMrpack(path, testMrpackIndex()))
+
+	pack, cleanup, err := openMrpack(context.Background(), path, "")
+	require.NoError(t, err)
+	defer cleanup()
+	assert.Equal(t, "Test Pack", pack.Index.Name)
+
+	// A missing .mrpack file is not looked up on Modrinth
+	_, _, err = openMrpack(context.Background(), filepath.Join(t.TempDir(), "missing.mrpack"), "")
+	assert.ErrorIs(t, err, mrpack.ErrInvalidPack)
+}
+
+func TestRunExportMrpack(t *testing.T) {
+	tmpDir := t.TempDir()
+	setupTestStateDir(t, tmpDir)
+
+	modsDir := filepath.Join(tmpDir, "servers", "survival", "mods")
+	require.NoError(t, os.MkdirAll(modsDir, 0750))
+	require.NoError(t, os.WriteFile(filepath.Join(modsDir, "lithium.jar"), []byte("jar"), 0600))
+	sum := sha512.Sum512([]byte("jar"))
+
+	serverState := state.NewServerState("survival")
+	serverState.Minecraft.Version = "1.21.1"
+	serverState.Minecraft.FabricLoaderVersion = "0.16.9"
+	serverState.Volumes.Data = filepath.Join(tmpDir, "servers", "survival", "data")
+	serverState.Mods = []state.ModInfo{{
+		Slug:     "lithium",
+		Filename: "lithium.jar",
+		URL:      "https://cdn.modrinth.com/lithium.jar",
+		SHA512:   hex.EncodeToString(sum[:]),
+	}}
+	require.NoError(t, state.SaveServerState(context.Background(), serverState))
+
+	output := filepath.Join(tmpDir, "survival.mrpack")
+	var buf bytes.Buffer
+	err := runExportMrpack(context.Background(), &buf, "survival", &ExportMrpackFlags{Output: output, PackVersion: "2.0.0"})
+	require.NoError(t, err)
+	assert.Contains(t, buf.String(), "Exported 1 mod(s) of 'survival'")
+
+	pack, err := mrpack.Open(output)
+	require.NoError(t, err)
+	defer func() { _ = pack.Close() }()
+
+	assert.Equal(t, "survival", pack.Index.Name)
+	assert.Equal(t, "2.0.0", pack.Index.VersionID)
+	assert.Equal(t, "1.21.1", pack.Index.MinecraftVersion())
+	assert.Equal(t, "0.16.9", pack.Index.Dependencies[mrpack.DependencyFabricLoader])
+	require.Len(t, pack.Index.Files, 1)
+	assert.Equal(t, "mods/lithium.jar", pack.Index.Files[0].Path)
+}
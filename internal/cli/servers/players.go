@@ -0,0 +1,149 @@
+package servers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steviee/go-mc/internal/mcquery"
+	"github.com/steviee/go-mc/internal/state"
+)
+
+// PlayersFlags holds all flags for the players command
+type PlayersFlags struct {
+	Timeout time.Duration
+}
+
+// PlayersInfo describes the players online on a server
+type PlayersInfo struct {
+	Server  string   `json:"server"`
+	Online  int      `json:"online"`
+	Max     int      `json:"max"`
+	Players []string `json:"players"`
+	Map     string   `json:"map,omitempty"`
+	Plugins string   `json:"plugins,omitempty"`
+	Version string   `json:"version,omitempty"`
+	MOTD    string   `json:"motd,omitempty"`
+}
+
+// PlayersOutput holds the output for JSON mode
+type PlayersOutput struct {
+	Status string       `json:"status"`
+	Data   *PlayersInfo `json:"data,omitempty"`
+}
+
+// NewPlayersCommand creates the servers players subcommand
+func NewPlayersCommand() *cobra.Command {
+	flags := &PlayersFlags{}
+
+	cmd := &cobra.Command{
+		Use:   "players <name>",
+		Short: "List all players online on a Minecraft server",
+		Long: `List all players online on a Minecraft server.
+
+The list comes from the server's query endpoint (UDP), which unlike the server
+list ping reports every online player along with the map and the plugins.
+go-mc enables the query for new servers; servers created by older versions
+have no query port and need to be recreated.`,
+		Example: `  # Show who is online
+  go-mc servers players myserver
+
+  # Feed the player list to a moderation script
+  go-mc servers players myserver --json | jq -r '.data.players[]'`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runPlayers(cmd.Context(), cmd.OutOrStdout(), args[0], flags)
+		},
+	}
+
+	cmd.Flags().DurationVar(&flags.Timeout, "timeout", mcquery.DefaultTimeout, "How long to wait for the server to answer")
+
+	return cmd
+}
+
+// runPlayers executes the players command
+func runPlayers(ctx context.Context, stdout io.Writer, name string, flags *PlayersFlags) error {
+	jsonMode := isJSONMode()
+
+	serverState, err := loadServerForOperation(ctx, name)
+	if err != nil {
+		return outputLifecycleError(stdout, jsonMode, err)
+	}
+
+	stat, err := queryLocalServer(ctx, serverState, flags.Timeout)
+	if err != nil {
+		return outputLifecycleError(stdout, jsonMode, err)
+	}
+
+	return outputPlayers(stdout, jsonMode, newPlayersInfo(name, stat))
+}
+
+// queryLocalServer sends a full stat query to a server on its published
+// query port
+func queryLocalServer(ctx context.Context, serverState *state.ServerState, timeout time.Duration) (*mcquery.FullStat, error) {
+	port := serverState.Minecraft.QueryPort
+	if port == 0 {
+		return nil, fmt.Errorf("server %q has no query port (recreate it to enable the query)", serverState.Name)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	stat, err := mcquery.Query(ctx, net.JoinHostPort("127.0.0.1", strconv.Itoa(port)))
+	if err != nil {
+		if mcquery.IsTimeout(err) {
+			return nil, fmt.Errorf("server %q did not answer the query on port %d/udp (is it running?): %w", serverState.Name, port, err)
+		}
+		return nil, fmt.Errorf("failed to query server %q: %w", serverState.Name, err)
+	}
+
+	return stat, nil
+}
+
+// newPlayersInfo converts a full stat for output
+func newPlayersInfo(name string, stat *mcquery.FullStat) *PlayersInfo {
+	players := stat.Players
+	if players == nil {
+		players = []string{}
+	}
+
+	return &PlayersInfo{
+		Server:  name,
+		Online:  stat.NumPlayers,
+		Max:     stat.MaxPlayers,
+		Players: players,
+		Map:     stat.Map,
+		Plugins: stat.Plugins,
+		Version: stat.Version,
+		MOTD:    stat.MOTD,
+	}
+}
+
+// outputPlayers outputs the players of a server
+func outputPlayers(stdout io.Writer, jsonMode bool, info *PlayersInfo) error {
+	if jsonMode {
+		enc := json.NewEncoder(stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(PlayersOutput{Status: "success", Data: info})
+	}
+
+	_, _ = fmt.Fprintf(stdout, "Server '%s': %d/%d players online\n", info.Server, info.Online, info.Max)
+	if info.Map != "" {
+		_, _ = fmt.Fprintf(stdout, "  Map:     %s\n", info.Map)
+	}
+	if info.Plugins != "" {
+		_, _ = fmt.Fprintf(stdout, "  Plugins: %s\n", info.Plugins)
+	}
+
+	if len(info.Players) > 0 {
+		_, _ = fmt.Fprintf(stdout, "\n%s\n", strings.Join(info.Players, "\n"))
+	}
+
+	return nil
+}
@@ -0,0 +1,75 @@
+package servers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/steviee/go-mc/internal/mcquery"
+	"github.com/steviee/go-mc/internal/mcquery/mcquerytest"
+	"github.com/steviee/go-mc/internal/state"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// queriedServer saves a server whose query port is answered by srv
+func queriedServer(t *testing.T, srv *mcquerytest.Server) {
+	t.Helper()
+
+	setupTestStateDir(t, t.TempDir())
+
+	serverState := state.NewServerState("survival")
+	serverState.ContainerID = "abc123"
+	serverState.Minecraft.QueryPort = srv.Port()
+	require.NoError(t, state.SaveServerState(context.Background(), serverState))
+}
+
+func TestRunPlayers(t *testing.T) {
+	srv := mcquerytest.NewServer(t, mcquery.FullStat{
+		Version:    "1.21.1",
+		Plugins:    "Paper on 1.21.1: LuckPerms 5.4.141",
+		Map:        "world",
+		NumPlayers: 2,
+		MaxPlayers: 20,
+		Players:    []string{"Notch", "jeb_"},
+	})
+	queriedServer(t, srv)
+
+	var buf bytes.Buffer
+	require.NoError(t, runPlayers(context.Background(), &buf, "survival", &PlayersFlags{Timeout: time.Second}))
+
+	assert.Equal(t, "Server 'survival': 2/20 players online\n"+
+		"  Map:     world\n"+
+		"  Plugins: Paper on 1.21.1: LuckPerms 5.4.141\n"+
+		"\nNotch\njeb_\n", buf.String())
+}
+
+func TestOutputPlayers_JSON(t *testing.T) {
+	var buf bytes.Buffer
+	info := newPlayersInfo("survival", &mcquery.FullStat{Version: "1.21.1", MaxPlayers: 20})
+	require.NoError(t, outputPlayers(&buf, true, info))
+
+	var output PlayersOutput
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &output))
+	assert.Equal(t, "success", output.Status)
+	assert.Equal(t, &PlayersInfo{Server: "survival", Max: 20, Players: []string{}, Version: "1.21.1"}, output.Data)
+
+	// An empty list is an array, not null
+	assert.Contains(t, buf.String(), `"players": []`)
+}
+
+func TestQueryLocalServer_Errors(t *testing.T) {
+	noQuery := state.NewServerState("legacy")
+	_, err := queryLocalServer(context.Background(), noQuery, time.Second)
+	assert.ErrorContains(t, err, `server "legacy" has no query port`)
+
+	srv := mcquerytest.NewServer(t, mcquery.FullStat{})
+	srv.Silent()
+	silent := state.NewServerState("survival")
+	silent.Minecraft.QueryPort = srv.Port()
+
+	_, err = queryLocalServer(context.Background(), silent, 50*time.Millisecond)
+	assert.ErrorContains(t, err, "did not answer the query")
+}
@@ -0,0 +1,230 @@
+package servers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+	"github.com/steviee/go-mc/internal/container"
+	"github.com/steviee/go-mc/internal/server"
+)
+
+// Reconcile statuses of a server
+const (
+	ReconcileUpToDate    = "up-to-date"
+	ReconcileRecreated   = "recreated"
+	ReconcilePending     = "pending"
+	ReconcileDrifted     = "drifted"
+	ReconcileNoContainer = "no-container"
+	ReconcileFailed      = "error"
+)
+
+// ReconcileFlags holds all flags for the reconcile command
+type ReconcileFlags struct {
+	All    bool
+	DryRun bool
+}
+
+// ReconcileDifference is a setting in which a container differs from the
+// spec of its server
+type ReconcileDifference struct {
+	Field string `json:"field"`
+	Want  string `json:"want"`
+	Have  string `json:"have"`
+}
+
+// ReconcileServer describes the drift of the container of a server
+type ReconcileServer struct {
+	Name        string                `json:"name"`
+	Status      string                `json:"status"`
+	Differences []ReconcileDifference `json:"differences"`
+	Error       string                `json:"error,omitempty"`
+}
+
+// ReconcileInfo describes the drift of the containers of servers
+type ReconcileInfo struct {
+	Servers []ReconcileServer `json:"servers"`
+	DryRun  bool              `json:"dry_run"`
+}
+
+// ReconcileOutput is the JSON output of the reconcile command
+type ReconcileOutput struct {
+	Status string         `json:"status"`
+	Data   *ReconcileInfo `json:"data"`
+}
+
+// NewReconcileCommand creates the servers reconcile subcommand
+func NewReconcileCommand() *cobra.Command {
+	flags := &ReconcileFlags{}
+
+	cmd := &cobra.Command{
+		Use:   "reconcile [name...]",
+		Short: "Recreate containers that drifted from their server's configuration",
+		Long: `Compare the containers of servers with the spec go-mc derives from their
+configuration and recreate the ones that drifted.
+
+The image, the environment go-mc sets, go-mc labels, mounts, published ports,
+the restart policy and resource limits are compared. Each difference is shown
+as a diff: "-" is the container, "+" is the spec.
+
+A stopped container that drifted is recreated; volumes are kept. A running
+container is left alone and the spec is applied the next time the server is
+started or restarted. Containers that are up to date are not touched.`,
+		Example: `  # Show what drifted without changing anything
+  go-mc servers reconcile --all --dry-run
+
+  # Recreate the container of a server if it drifted
+  go-mc servers reconcile survival
+
+  # Reconcile all servers
+  go-mc servers reconcile --all`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runReconcile(cmd.Context(), cmd.OutOrStdout(), args, flags)
+		},
+	}
+
+	cmd.Flags().BoolVar(&flags.All, "all", false, "Reconcile all servers")
+	cmd.Flags().BoolVar(&flags.DryRun, "dry-run", false, "Show the differences without recreating containers")
+
+	return cmd
+}
+
+// runReconcile executes the reconcile command
+func runReconcile(ctx context.Context, stdout io.Writer, args []string, flags *ReconcileFlags) error {
+	jsonMode := isJSONMode()
+
+	names, err := getServerNamesFromArgs(ctx, args, flags.All)
+	if err != nil {
+		return outputLifecycleError(stdout, jsonMode, err)
+	}
+
+	client, err := createContainerClient(ctx)
+	if err != nil {
+		return outputLifecycleError(stdout, jsonMode, err)
+	}
+	defer func() { _ = client.Close() }()
+
+	info := &ReconcileInfo{Servers: []ReconcileServer{}, DryRun: flags.DryRun}
+	failed := 0
+	for _, name := range names {
+		result := reconcileServer(ctx, client, name, flags.DryRun)
+		if result.Status == ReconcileFailed {
+			failed++
+		}
+		info.Servers = append(info.Servers, result)
+	}
+
+	if err := outputReconcile(stdout, jsonMode, info); err != nil {
+		return err
+	}
+	if failed > 0 {
+		return fmt.Errorf("failed to reconcile %d of %d servers", failed, len(names))
+	}
+	return nil
+}
+
+// reconcileServer compares the container of a server with its spec and,
+// unless dryRun is set, recreates it when it drifted
+func reconcileServer(ctx context.Context, client container.Client, name string, dryRun bool) ReconcileServer {
+	result := ReconcileServer{Name: name, Differences: []ReconcileDifference{}}
+
+	serverState, err := loadServerForOperation(ctx, name)
+	if err != nil {
+		result.Status = ReconcileFailed
+		result.Error = err.Error()
+		return result
+	}
+	if serverState.ContainerID == "" {
+		result.Status = ReconcileNoContainer
+		return result
+	}
+
+	var differences []server.Difference
+	if dryRun {
+		info, err := client.InspectContainer(ctx, serverState.ContainerID)
+		if err != nil {
+			if errors.Is(err, container.ErrContainerNotFound) {
+				err = fmt.Errorf("container of server %q is missing: %w", name, err)
+			}
+			result.Status = ReconcileFailed
+			result.Error = err.Error()
+			return result
+		}
+		differences = server.Drift(serverState, info)
+		result.Status = ReconcileDrifted
+	} else {
+		reconciled, err := server.Reconcile(ctx, client, serverState)
+		if err != nil {
+			result.Status = ReconcileFailed
+			result.Error = err.Error()
+			return result
+		}
+		differences = reconciled.Differences
+		switch {
+		case reconciled.Recreated:
+			result.Status = ReconcileRecreated
+		case reconciled.Pending:
+			result.Status = ReconcilePending
+		}
+	}
+
+	for _, d := range differences {
+		result.Differences = append(result.Differences, ReconcileDifference(d))
+	}
+	if len(result.Differences) == 0 && result.Status != ReconcileRecreated {
+		result.Status = ReconcileUpToDate
+	}
+
+	return result
+}
+
+// outputReconcile outputs the drift of the containers of servers
+func outputReconcile(stdout io.Writer, jsonMode bool, info *ReconcileInfo) error {
+	if jsonMode {
+		enc := json.NewEncoder(stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(ReconcileOutput{Status: "success", Data: info})
+	}
+
+	for _, s := range info.Servers {
+		switch s.Status {
+		case ReconcileUpToDate:
+			_, _ = fmt.Fprintf(stdout, "%s: up to date\n", s.Name)
+			continue
+		case ReconcileNoContainer:
+			_, _ = fmt.Fprintf(stdout, "%s: no container\n", s.Name)
+			continue
+		case ReconcileFailed:
+			_, _ = fmt.Fprintf(stdout, "%s: error: %s\n", s.Name, s.Error)
+			continue
+		}
+
+		// systemd removed the container of an autostart server
+		if len(s.Differences) == 0 {
+			_, _ = fmt.Fprintf(stdout, "%s: missing container recreated\n", s.Name)
+			continue
+		}
+
+		_, _ = fmt.Fprintf(stdout, "%s: drifted\n", s.Name)
+		for _, d := range s.Differences {
+			if d.Have != "" {
+				_, _ = fmt.Fprintf(stdout, "  - %s: %s\n", d.Field, d.Have)
+			}
+			if d.Want != "" {
+				_, _ = fmt.Fprintf(stdout, "  + %s: %s\n", d.Field, d.Want)
+			}
+		}
+
+		switch s.Status {
+		case ReconcileRecreated:
+			_, _ = fmt.Fprintln(stdout, "  Container recreated")
+		case ReconcilePending:
+			_, _ = fmt.Fprintln(stdout, "  Applied the next time the server is started or restarted")
+		}
+	}
+
+	return nil
+}
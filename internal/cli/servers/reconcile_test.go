@@ -0,0 +1,141 @@
+package servers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/steviee/go-mc/internal/container"
+	"github.com/steviee/go-mc/internal/server"
+	"github.com/steviee/go-mc/internal/state"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// reconcileClient is a container client that inspects a fixed container
+// and records recreations
+type reconcileClient struct {
+	inspectClient
+	created []*container.ContainerConfig
+}
+
+func (c *reconcileClient) RemoveContainer(ctx context.Context, containerID string, opts *container.RemoveOptions) error {
+	return nil
+}
+
+func (c *reconcileClient) CreateContainer(ctx context.Context, config *container.ContainerConfig) (string, error) {
+	c.created = append(c.created, config)
+	return "new", nil
+}
+
+// saveReconcileServer saves a server and returns its container as Podman
+// reports it after creating it from the spec
+func saveReconcileServer(t *testing.T) (*state.ServerState, *container.ContainerInfo) {
+	t.Helper()
+
+	serverState := saveInspectServer(t, "survival")
+	serverState.ContainerID = "old"
+	require.NoError(t, state.SaveServerState(context.Background(), serverState))
+
+	config := server.ContainerConfig(serverState)
+	info := &container.ContainerInfo{
+		State:         "exited",
+		Image:         "docker.io/" + config.Image,
+		Env:           config.Env,
+		Labels:        config.Labels,
+		Ports:         config.Ports,
+		RestartPolicy: state.RestartNo,
+	}
+	for source, destination := range config.Volumes {
+		info.Mounts = append(info.Mounts, container.Mount{Source: source, Destination: destination})
+	}
+
+	return serverState, info
+}
+
+func TestReconcileServer(t *testing.T) {
+	setupTestStateDir(t, t.TempDir())
+	_, info := saveReconcileServer(t)
+	client := &reconcileClient{inspectClient: inspectClient{info: info}}
+
+	result := reconcileServer(context.Background(), client, "survival", false)
+	assert.Equal(t, ReconcileUpToDate, result.Status)
+	assert.Empty(t, result.Differences)
+	assert.Empty(t, client.created)
+
+	// Left behind by 'servers update' before the spec was shared
+	info.Env["RCON_PORT"] = "25575"
+
+	result = reconcileServer(context.Background(), client, "survival", true)
+	assert.Equal(t, ReconcileDrifted, result.Status)
+	assert.Equal(t, []ReconcileDifference{{Field: "env RCON_PORT", Have: "25575"}}, result.Differences)
+	assert.Empty(t, client.created, "dry run")
+
+	result = reconcileServer(context.Background(), client, "survival", false)
+	assert.Equal(t, ReconcileRecreated, result.Status)
+	require.Len(t, client.created, 1)
+	assert.NotContains(t, client.created[0].Env, "RCON_PORT")
+
+	saved, err := state.LoadServerState(context.Background(), "survival")
+	require.NoError(t, err)
+	assert.Equal(t, "new", saved.ContainerID)
+}
+
+func TestReconcileServer_Running(t *testing.T) {
+	setupTestStateDir(t, t.TempDir())
+	_, info := saveReconcileServer(t)
+	info.State = "running"
+	info.Image = "itzg/minecraft-server:java17"
+	client := &reconcileClient{inspectClient: inspectClient{info: info}}
+
+	result := reconcileServer(context.Background(), client, "survival", false)
+	assert.Equal(t, ReconcilePending, result.Status)
+	require.Len(t, result.Differences, 1)
+	assert.Equal(t, "image", result.Differences[0].Field)
+	assert.Empty(t, client.created)
+}
+
+func TestReconcileServer_Errors(t *testing.T) {
+	setupTestStateDir(t, t.TempDir())
+	client := &reconcileClient{inspectClient: inspectClient{inspErr: container.ErrContainerNotFound}}
+
+	result := reconcileServer(context.Background(), client, "missing", false)
+	assert.Equal(t, ReconcileFailed, result.Status)
+
+	saveReconcileServer(t)
+	result = reconcileServer(context.Background(), client, "survival", true)
+	assert.Equal(t, ReconcileFailed, result.Status)
+	assert.Contains(t, result.Error, "is missing")
+}
+
+func TestOutputReconcile(t *testing.T) {
+	info := &ReconcileInfo{Servers: []ReconcileServer{
+		{Name: "lobby", Status: ReconcileUpToDate},
+		{Name: "survival", Status: ReconcileRecreated, Differences: []ReconcileDifference{
+			{Field: "env RCON_PORT", Have: "25575"},
+			{Field: "image", Want: "itzg/minecraft-server:java21", Have: "itzg/minecraft-server:latest"},
+			{Field: "mount /data/mods", Want: "/srv/survival/mods"},
+		}},
+	}}
+
+	var buf bytes.Buffer
+	require.NoError(t, outputReconcile(&buf, false, info))
+	assert.Equal(t, `lobby: up to date
+survival: drifted
+  - env RCON_PORT: 25575
+  - image: itzg/minecraft-server:latest
+  + image: itzg/minecraft-server:java21
+  + mount /data/mods: /srv/survival/mods
+  Container recreated
+`, buf.String())
+
+	buf.Reset()
+	require.NoError(t, outputReconcile(&buf, true, info))
+
+	var output ReconcileOutput
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &output))
+	require.Len(t, output.Data.Servers, 2)
+	assert.Equal(t, ReconcileRecreated, output.Data.Servers[1].Status)
+	assert.Len(t, output.Data.Servers[1].Differences, 3)
+}
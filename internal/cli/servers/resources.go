@@ -0,0 +1,393 @@
+package servers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+	"github.com/steviee/go-mc/internal/container"
+	"github.com/steviee/go-mc/internal/server"
+	"github.com/steviee/go-mc/internal/state"
+)
+
+// resourceFlagNames are the flags of the resource limits, shared by the
+// create and resources commands
+var resourceFlagNames = []string{"cpus", "cpu-shares", "pids-limit", "memory-swap", "io-weight"}
+
+// ResourceLimits describes the resource limits of a server. Zero values
+// mean no limit.
+type ResourceLimits struct {
+	CPUs       float64 `json:"cpus"`
+	CPUShares  uint64  `json:"cpu_shares"`
+	PidsLimit  int64   `json:"pids_limit"`
+	MemorySwap string  `json:"memory_swap"`
+	IOWeight   uint16  `json:"io_weight"`
+}
+
+// ResourcesServer describes the memory and resource limits of a server
+type ResourcesServer struct {
+	Name   string         `json:"name"`
+	Memory string         `json:"memory"`
+	Limits ResourceLimits `json:"limits"`
+}
+
+// ResourcesCommitted sums the resources of all servers against the host.
+// Host values are 0 when the container runtime could not be reached.
+type ResourcesCommitted struct {
+	CPUs            float64  `json:"cpus"`
+	HostCPUs        int      `json:"host_cpus"`
+	UnlimitedCPUs   []string `json:"unlimited_cpus"`
+	MemoryBytes     int64    `json:"memory_bytes"`
+	HostMemoryBytes int64    `json:"host_memory_bytes"`
+}
+
+// ResourcesInfo describes the resource limits of servers
+type ResourcesInfo struct {
+	Servers   []ResourcesServer   `json:"servers"`
+	Committed *ResourcesCommitted `json:"committed,omitempty"`
+
+	// Set when the limits were changed
+	Recreated bool `json:"recreated,omitempty"`
+	Pending   bool `json:"pending,omitempty"`
+}
+
+// ResourcesOutput is the JSON output of the resources command
+type ResourcesOutput struct {
+	Status string         `json:"status"`
+	Data   *ResourcesInfo `json:"data"`
+}
+
+// NewResourcesCommand creates the servers resources subcommand
+func NewResourcesCommand() *cobra.Command {
+	flags := &CreateFlags{}
+
+	cmd := &cobra.Command{
+		Use:   "resources [name]",
+		Short: "Show or change the CPU, memory, PID and I/O limits of servers",
+		Long: `Show or change the container resource limits of servers.
+
+Limits:
+  --cpus         CPU cores the server may use, e.g. 1.5
+  --cpu-shares   Relative CPU weight when servers compete for CPU (default 1024)
+  --pids-limit   Maximum number of processes and threads
+  --memory-swap  Memory plus swap the container may use, e.g. 6G. Also limits
+                 the container memory to the heap plus a quarter, at least 512M
+  --io-weight    Relative block I/O weight, 10-1000
+
+A limit of 0 (or an empty --memory-swap) removes it. Limits may not exceed
+limits.max_cpus_per_server, limits.max_pids_per_server and
+limits.max_memory_swap_per_server of the config.
+
+Without flags, the limits of all servers (or the named one) are shown with the
+CPUs and memory they commit in total against the host.
+
+Limits are set on the container when it is created: a stopped server's
+container is recreated right away, a running one when it is next started or
+restarted.`,
+		Example: `  # Show the limits of all servers and the committed totals
+  go-mc servers resources
+
+  # Limit a server to 2 CPUs and at most 2G of swap on top of its memory
+  go-mc servers resources survival --cpus 2 --memory-swap 7G
+
+  # Favor the lobby when servers compete for CPU and disk
+  go-mc servers resources lobby --cpu-shares 2048 --io-weight 500
+
+  # Remove the CPU limit
+  go-mc servers resources survival --cpus 0`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			name := ""
+			if len(args) == 1 {
+				name = args[0]
+			}
+
+			changed := []string{}
+			for _, flag := range resourceFlagNames {
+				if cmd.Flags().Changed(flag) {
+					changed = append(changed, flag)
+				}
+			}
+			if len(changed) == 0 {
+				return runShowResources(cmd.Context(), cmd.OutOrStdout(), name)
+			}
+			if name == "" {
+				return fmt.Errorf("server name is required to change limits\nUsage: %s\n\nRun '%s --help' for more information", cmd.UseLine(), cmd.CommandPath())
+			}
+
+			cfg, err := state.ResolveConfig(cmd.Context())
+			if err != nil {
+				return err
+			}
+			return runSetResources(cmd.Context(), cmd.OutOrStdout(), name, flags, changed, cfg)
+		},
+	}
+
+	addResourceFlags(cmd, flags)
+
+	return cmd
+}
+
+// addResourceFlags adds the resource limit flags to a command
+func addResourceFlags(cmd *cobra.Command, flags *CreateFlags) {
+	cmd.Flags().Float64Var(&flags.CPUs, "cpus", 0, "CPU cores the server may use, e.g. 1.5 (0 = no limit)")
+	cmd.Flags().Uint64Var(&flags.CPUShares, "cpu-shares", 0, "Relative CPU weight under contention, 2-262144 (0 = default 1024)")
+	cmd.Flags().Int64Var(&flags.PidsLimit, "pids-limit", 0, "Maximum number of processes and threads (0 = runtime default)")
+	cmd.Flags().StringVar(&flags.MemorySwap, "memory-swap", "", "Memory plus swap, e.g. 6G; also limits the container memory")
+	cmd.Flags().Uint16Var(&flags.IOWeight, "io-weight", 0, "Relative block I/O weight, 10-1000 (0 = default)")
+}
+
+// runShowResources shows the resource limits of one or all servers with
+// the resources all servers commit
+func runShowResources(ctx context.Context, stdout io.Writer, name string) error {
+	jsonMode := isJSONMode()
+
+	names, err := state.ListServers(ctx)
+	if err != nil {
+		return outputLifecycleError(stdout, jsonMode, err)
+	}
+
+	servers := make([]*state.ServerState, 0, len(names))
+	for _, n := range names {
+		serverState, err := state.LoadServerState(ctx, n)
+		if err != nil {
+			slog.Warn("failed to load server state", "server", n, "error", err)
+			continue
+		}
+		servers = append(servers, serverState)
+	}
+
+	// The host totals are optional
+	var host *container.RuntimeInfo
+	client, err := createContainerClient(ctx)
+	if err == nil {
+		defer func() { _ = client.Close() }()
+		host, err = client.Info(ctx)
+	}
+	if err != nil {
+		slog.Debug("failed to get host resources", "error", err)
+	}
+
+	info := &ResourcesInfo{
+		Servers:   []ResourcesServer{},
+		Committed: commitResources(servers, host),
+	}
+	for _, serverState := range servers {
+		if name == "" || serverState.Name == name {
+			info.Servers = append(info.Servers, newResourcesServer(serverState))
+		}
+	}
+	if name != "" && len(info.Servers) == 0 {
+		return outputLifecycleError(stdout, jsonMode, fmt.Errorf("server %q not found", name))
+	}
+
+	return outputResources(stdout, jsonMode, info)
+}
+
+// runSetResources changes the resource limits given by the changed flags
+// of a server
+func runSetResources(ctx context.Context, stdout io.Writer, name string, flags *CreateFlags, changed []string, cfg *state.Config) error {
+	jsonMode := isJSONMode()
+
+	serverState, err := state.LoadServerState(ctx, name)
+	if err != nil {
+		return outputLifecycleError(stdout, jsonMode, err)
+	}
+
+	resources := &serverState.Resources
+	for _, flag := range changed {
+		switch flag {
+		case "cpus":
+			resources.CPUs = flags.CPUs
+		case "cpu-shares":
+			resources.CPUShares = flags.CPUShares
+		case "pids-limit":
+			resources.PidsLimit = flags.PidsLimit
+		case "memory-swap":
+			resources.MemorySwap = flags.MemorySwap
+			if resources.MemorySwap == "0" {
+				resources.MemorySwap = ""
+			}
+		case "io-weight":
+			resources.IOWeight = flags.IOWeight
+		}
+	}
+
+	if err := state.ValidateResources(*resources, serverState.Minecraft.Memory); err != nil {
+		return outputLifecycleError(stdout, jsonMode, fmt.Errorf("invalid resources: %w", err))
+	}
+	if err := state.ValidateResourceLimits(*resources, cfg.Limits); err != nil {
+		return outputLifecycleError(stdout, jsonMode, err)
+	}
+	if err := state.SaveServerState(ctx, serverState); err != nil {
+		return outputLifecycleError(stdout, jsonMode, fmt.Errorf("failed to save server state: %w", err))
+	}
+
+	info := &ResourcesInfo{Servers: []ResourcesServer{newResourcesServer(serverState)}}
+
+	if serverState.ContainerID != "" {
+		client, err := createContainerClient(ctx)
+		if err != nil {
+			return outputLifecycleError(stdout, jsonMode, err)
+		}
+		defer func() { _ = client.Close() }()
+
+		result, err := server.Reconcile(ctx, client, serverState)
+		if err != nil {
+			return outputLifecycleError(stdout, jsonMode, fmt.Errorf("failed to apply resource limits: %w", err))
+		}
+		info.Recreated = result.Recreated
+		info.Pending = result.Pending
+	}
+
+	return outputResources(stdout, jsonMode, info)
+}
+
+// newResourceLimits converts resource limits for output
+func newResourceLimits(r state.ResourcesConfig) ResourceLimits {
+	return ResourceLimits{
+		CPUs:       r.CPUs,
+		CPUShares:  r.CPUShares,
+		PidsLimit:  r.PidsLimit,
+		MemorySwap: r.MemorySwap,
+		IOWeight:   r.IOWeight,
+	}
+}
+
+// newResourcesServer converts the memory and resource limits of a server
+// for output
+func newResourcesServer(serverState *state.ServerState) ResourcesServer {
+	return ResourcesServer{
+		Name:   serverState.Name,
+		Memory: serverState.Minecraft.Memory,
+		Limits: newResourceLimits(serverState.Resources),
+	}
+}
+
+// commitResources sums the CPUs and memory servers may use. The memory of
+// a server is its heap plus the JVM overhead; servers without a CPU limit
+// are listed separately as they may use every CPU.
+func commitResources(servers []*state.ServerState, host *container.RuntimeInfo) *ResourcesCommitted {
+	committed := &ResourcesCommitted{UnlimitedCPUs: []string{}}
+	if host != nil {
+		committed.HostCPUs = host.CPUs
+		committed.HostMemoryBytes = host.MemTotal
+	}
+
+	for _, serverState := range servers {
+		if serverState.Resources.CPUs > 0 {
+			committed.CPUs += serverState.Resources.CPUs
+		} else {
+			committed.UnlimitedCPUs = append(committed.UnlimitedCPUs, serverState.Name)
+		}
+
+		if limit, err := state.ContainerMemoryLimit(serverState.Minecraft.Memory); err == nil {
+			committed.MemoryBytes += limit
+		}
+	}
+
+	return committed
+}
+
+// outputResources outputs the resource limits of servers
+func outputResources(stdout io.Writer, jsonMode bool, info *ResourcesInfo) error {
+	if jsonMode {
+		enc := json.NewEncoder(stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(ResourcesOutput{Status: "success", Data: info})
+	}
+
+	// A change shows the limits of the changed server only
+	if info.Committed == nil {
+		s := info.Servers[0]
+		_, _ = fmt.Fprintf(stdout, "Server '%s' resources: %s\n", s.Name, formatResourceLimits(s.Limits))
+
+		switch {
+		case info.Recreated:
+			_, _ = fmt.Fprintln(stdout, "  Container recreated with the new limits")
+		case info.Pending:
+			_, _ = fmt.Fprintln(stdout, "  Applied to the container the next time the server is started or restarted")
+		}
+		return nil
+	}
+
+	w := tabwriter.NewWriter(stdout, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(w, "NAME\tMEMORY\tCPUS\tCPU SHARES\tPIDS\tMEMORY+SWAP\tIO WEIGHT")
+	for _, s := range info.Servers {
+		_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+			s.Name,
+			s.Memory,
+			formatLimit(strconv.FormatFloat(s.Limits.CPUs, 'g', -1, 64)),
+			formatLimit(strconv.FormatUint(s.Limits.CPUShares, 10)),
+			formatLimit(strconv.FormatInt(s.Limits.PidsLimit, 10)),
+			formatLimit(s.Limits.MemorySwap),
+			formatLimit(strconv.FormatUint(uint64(s.Limits.IOWeight), 10)),
+		)
+	}
+	_ = w.Flush()
+
+	c := info.Committed
+	_, _ = fmt.Fprintf(stdout, "\nCommitted:\n")
+
+	cpus := strconv.FormatFloat(c.CPUs, 'g', -1, 64)
+	if c.HostCPUs > 0 {
+		cpus += fmt.Sprintf(" of %d", c.HostCPUs)
+	}
+	cpus += " CPUs"
+	if len(c.UnlimitedCPUs) > 0 {
+		cpus += fmt.Sprintf(" (no limit: %s)", strings.Join(c.UnlimitedCPUs, ", "))
+	}
+	_, _ = fmt.Fprintf(stdout, "  CPU:     %s\n", cpus)
+
+	memory := formatBytes(c.MemoryBytes)
+	if c.HostMemoryBytes > 0 {
+		memory += " of " + formatBytes(c.HostMemoryBytes)
+	}
+	_, _ = fmt.Fprintf(stdout, "  Memory:  %s (heap plus JVM overhead)\n", memory)
+
+	return nil
+}
+
+// formatLimit shows unset limits as "-"
+func formatLimit(value string) string {
+	if value == "" || value == "0" {
+		return "-"
+	}
+	return value
+}
+
+// formatResources describes resource limits, e.g. "2 CPUs, memory+swap 6G"
+func formatResources(r state.ResourcesConfig) string {
+	return formatResourceLimits(newResourceLimits(r))
+}
+
+// formatResourceLimits describes resource limits for output
+func formatResourceLimits(l ResourceLimits) string {
+	parts := []string{}
+	if l.CPUs > 0 {
+		parts = append(parts, strconv.FormatFloat(l.CPUs, 'g', -1, 64)+" CPUs")
+	}
+	if l.CPUShares > 0 {
+		parts = append(parts, fmt.Sprintf("cpu shares %d", l.CPUShares))
+	}
+	if l.PidsLimit > 0 {
+		parts = append(parts, fmt.Sprintf("pids %d", l.PidsLimit))
+	}
+	if l.MemorySwap != "" {
+		parts = append(parts, "memory+swap "+l.MemorySwap)
+	}
+	if l.IOWeight > 0 {
+		parts = append(parts, fmt.Sprintf("io weight %d", l.IOWeight))
+	}
+
+	if len(parts) == 0 {
+		return "no limits"
+	}
+	return strings.Join(parts, ", ")
+}
@@ -0,0 +1,114 @@
+package servers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/steviee/go-mc/internal/container"
+	"github.com/steviee/go-mc/internal/state"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunSetResources(t *testing.T) {
+	setupTestStateDir(t, t.TempDir())
+	serverState := state.NewServerState("survival")
+	serverState.Minecraft.Memory = "4G"
+	serverState.Resources = state.ResourcesConfig{CPUs: 4, IOWeight: 100}
+	require.NoError(t, state.SaveServerState(context.Background(), serverState))
+
+	// Only the given limits change; 0 removes one
+	flags := &CreateFlags{CPUs: 2, MemorySwap: "6G"}
+	var buf bytes.Buffer
+	err := runSetResources(context.Background(), &buf, "survival", flags, []string{"cpus", "memory-swap"}, state.DefaultConfig())
+	require.NoError(t, err)
+	assert.Equal(t, "Server 'survival' resources: 2 CPUs, memory+swap 6G, io weight 100\n", buf.String())
+
+	saved, err := state.LoadServerState(context.Background(), "survival")
+	require.NoError(t, err)
+	assert.Equal(t, state.ResourcesConfig{CPUs: 2, MemorySwap: "6G", IOWeight: 100}, saved.Resources)
+
+	buf.Reset()
+	err = runSetResources(context.Background(), &buf, "survival", &CreateFlags{}, []string{"io-weight", "memory-swap"}, state.DefaultConfig())
+	require.NoError(t, err)
+	assert.Equal(t, "Server 'survival' resources: 2 CPUs\n", buf.String())
+}
+
+func TestRunSetResources_Invalid(t *testing.T) {
+	setupTestStateDir(t, t.TempDir())
+	serverState := state.NewServerState("survival")
+	serverState.Minecraft.Memory = "4G"
+	require.NoError(t, state.SaveServerState(context.Background(), serverState))
+
+	var buf bytes.Buffer
+	err := runSetResources(context.Background(), &buf, "survival", &CreateFlags{MemorySwap: "4G"}, []string{"memory-swap"}, state.DefaultConfig())
+	assert.ErrorContains(t, err, "below the container memory limit")
+
+	err = runSetResources(context.Background(), &buf, "survival", &CreateFlags{PidsLimit: 100000}, []string{"pids-limit"}, state.DefaultConfig())
+	assert.ErrorContains(t, err, "limits.max_pids_per_server")
+
+	saved, err := state.LoadServerState(context.Background(), "survival")
+	require.NoError(t, err)
+	assert.Equal(t, state.ResourcesConfig{}, saved.Resources, "not saved")
+}
+
+func TestCommitResources(t *testing.T) {
+	survival := state.NewServerState("survival")
+	survival.Minecraft.Memory = "4G"
+	survival.Resources.CPUs = 2.5
+	lobby := state.NewServerState("lobby")
+	lobby.Minecraft.Memory = "1G"
+
+	committed := commitResources([]*state.ServerState{survival, lobby}, &container.RuntimeInfo{CPUs: 8, MemTotal: 32 << 30})
+	assert.Equal(t, 2.5, committed.CPUs)
+	assert.Equal(t, 8, committed.HostCPUs)
+	assert.Equal(t, []string{"lobby"}, committed.UnlimitedCPUs)
+	assert.Equal(t, int64(5<<30+1<<30+512<<20), committed.MemoryBytes)
+	assert.Equal(t, int64(32<<30), committed.HostMemoryBytes)
+
+	// Without the runtime, the host is unknown
+	committed = commitResources([]*state.ServerState{survival}, nil)
+	assert.Zero(t, committed.HostCPUs)
+	assert.Empty(t, committed.UnlimitedCPUs)
+}
+
+func TestOutputResources(t *testing.T) {
+	survival := state.NewServerState("survival")
+	survival.Minecraft.Memory = "4G"
+	survival.Resources = state.ResourcesConfig{CPUs: 2, MemorySwap: "6G"}
+	lobby := state.NewServerState("lobby")
+	lobby.Minecraft.Memory = "1G"
+	servers := []*state.ServerState{survival, lobby}
+
+	info := &ResourcesInfo{
+		Servers:   []ResourcesServer{newResourcesServer(survival), newResourcesServer(lobby)},
+		Committed: commitResources(servers, &container.RuntimeInfo{CPUs: 8, MemTotal: 32 << 30}),
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, outputResources(&buf, false, info))
+	assert.Contains(t, buf.String(), "NAME      MEMORY  CPUS  CPU SHARES  PIDS  MEMORY+SWAP  IO WEIGHT\n")
+	assert.Contains(t, buf.String(), "survival  4G      2     -           -     6G           -\n")
+	assert.Contains(t, buf.String(), "  CPU:     2 of 8 CPUs (no limit: lobby)\n")
+	assert.Contains(t, buf.String(), "  Memory:  6.5 GB of 32.0 GB (heap plus JVM overhead)\n")
+
+	buf.Reset()
+	require.NoError(t, outputResources(&buf, true, info))
+
+	var output ResourcesOutput
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &output))
+	assert.Equal(t, "success", output.Status)
+	require.Len(t, output.Data.Servers, 2)
+	assert.Equal(t, 2.0, output.Data.Servers[0].Limits.CPUs)
+	assert.Equal(t, "6G", output.Data.Servers[0].Limits.MemorySwap)
+	assert.Equal(t, 8, output.Data.Committed.HostCPUs)
+}
+
+func TestFormatResources(t *testing.T) {
+	assert.Equal(t, "no limits", formatResources(state.ResourcesConfig{}))
+	assert.Equal(t, "1.5 CPUs, cpu shares 512, pids 4096, memory+swap 6G, io weight 200", formatResources(state.ResourcesConfig{
+		CPUs: 1.5, CPUShares: 512, PidsLimit: 4096, MemorySwap: "6G", IOWeight: 200,
+	}))
+}
@@ -8,14 +8,19 @@ import (
 
 	"github.com/spf13/cobra"
 	"github.com/steviee/go-mc/internal/container"
+	"github.com/steviee/go-mc/internal/lifecycle"
+	"github.com/steviee/go-mc/internal/server"
 	"github.com/steviee/go-mc/internal/state"
 )
 
 // RestartFlags holds all flags for the restart command
 type RestartFlags struct {
-	All     bool
-	Wait    bool
-	Timeout time.Duration
+	All         bool
+	Wait        bool
+	Timeout     time.Duration
+	WaitTimeout time.Duration
+	Countdown   time.Duration
+	Message     string
 }
 
 // NewRestartCommand creates the servers restart subcommand
@@ -25,15 +30,21 @@ func NewRestartCommand() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "restart <name...>",
 		Short: "Restart one or more Minecraft servers",
-		Long: `Restart one or more Minecraft servers by restarting their containers.
+		Long: `Restart one or more Minecraft servers.
 
-This is equivalent to stopping and then starting the server. The container will
-be sent a SIGTERM signal, given time to gracefully shut down, and then started again.
+This is equivalent to stopping and then starting the server. Players are warned with
+a countdown broadcast over RCON, the world is flushed to disk with 'save-all flush',
+and the server is told to stop before its container is started again. If RCON is
+unavailable, the container is sent a SIGTERM signal and, once the timeout expires,
+SIGKILL.
 
 You can restart multiple servers by specifying multiple names, or use --all to restart
 all running servers.
 
-If a server is already stopped, it will be skipped with a warning.`,
+If a server is already stopped, it will be skipped with a warning.
+
+With --wait, the command returns once each server is ready again, the same way as
+'servers start --wait', and reports the timing of the start phases.`,
 		Example: `  # Restart a single server
   go-mc servers restart myserver
 
@@ -43,8 +54,11 @@ If a server is already stopped, it will be skipped with a warning.`,
   # Restart all running servers
   go-mc servers restart --all
 
-  # Restart and wait for container to be fully running
-  go-mc servers restart myserver --wait
+  # Warn players for five minutes before restarting
+  go-mc servers restart myserver --countdown 5m --message "Applying updates"
+
+  # Restart and wait until players can join again
+  go-mc servers restart myserver --wait --wait-timeout 10m
 
   # Restart with custom timeout
   go-mc servers restart myserver --timeout 2m
@@ -58,8 +72,11 @@ If a server is already stopped, it will be skipped with a warning.`,
 
 	// Add flags
 	cmd.Flags().BoolVar(&flags.All, "all", false, "Restart all running servers")
-	cmd.Flags().BoolVar(&flags.Wait, "wait", false, "Wait until containers are fully restarted")
+	cmd.Flags().BoolVar(&flags.Wait, "wait", false, "Wait until servers are ready for players again")
 	cmd.Flags().DurationVar(&flags.Timeout, "timeout", 60*time.Second, "Timeout for restart operation")
+	cmd.Flags().DurationVar(&flags.WaitTimeout, "wait-timeout", lifecycle.DefaultWaitTimeout, "Timeout for --wait")
+	cmd.Flags().DurationVar(&flags.Countdown, "countdown", lifecycle.DefaultCountdown, "Warn players for this long before restarting")
+	cmd.Flags().StringVar(&flags.Message, "message", "", "Countdown broadcast message (default \"Server restarting\")")
 
 	return cmd
 }
@@ -117,25 +134,38 @@ func restartServer(ctx context.Context, client container.Client, name string, fl
 		return nil
 	}
 
-	// Restart container
-	timeout := flags.Timeout
-	if err := client.RestartContainer(ctx, serverState.ContainerID, &timeout); err != nil {
+	// Restart gracefully over RCON, falling back to the container runtime
+	var started time.Time
+	shutdown, err := lifecycle.Restart(ctx, client, serverState, lifecycle.ShutdownOptions{
+		Countdown: flags.Countdown,
+		Message:   flags.Message,
+		Timeout:   flags.Timeout,
+		BeforeStart: func(ctx context.Context) error {
+			// Apply changes made while the server was running
+			_, err := server.Reconcile(ctx, client, serverState)
+			started = time.Now()
+			return err
+		},
+	})
+	if err != nil {
 		result.Failed[name] = err.Error()
 		return err
 	}
+	if !shutdown.Graceful {
+		slog.Debug("server stopped without RCON", "name", name, "reason", shutdown.Fallback)
+	}
 
-	// Wait if requested
+	// Wait until the world is loaded if requested
 	if flags.Wait {
-		waitCtx, cancel := context.WithTimeout(ctx, flags.Timeout)
-		defer cancel()
-
-		if err := client.WaitForContainer(waitCtx, serverState.ContainerID, "running"); err != nil {
-			// Container restarted but wait failed
-			result.Failed[name] = "restarted but wait timed out: " + err.Error()
+		timing, err := waitForServer(ctx, client, serverState, lifecycle.ConditionReady, flags.WaitTimeout, started)
+		if err != nil {
+			// Container restarted but the server did not become ready
+			result.Failed[name] = "restarted but not ready: " + err.Error()
 			// Still update state since container did restart
 			_ = updateServerStatus(ctx, serverState, state.StatusRunning)
 			return err
 		}
+		result.Timing[name] = timing
 	}
 
 	// Update server state (status should remain running, but update timestamp)
@@ -0,0 +1,227 @@
+package servers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steviee/go-mc/internal/server"
+	"github.com/steviee/go-mc/internal/state"
+)
+
+// RestartPolicyFlags holds all flags for the restart-policy command
+type RestartPolicyFlags struct {
+	MaxRetries int
+	Backoff    time.Duration
+}
+
+// RestartPolicyInfo describes the restart policy and restart history of a
+// server
+type RestartPolicyInfo struct {
+	Server         string                `json:"server"`
+	Policy         string                `json:"policy"`
+	MaxRetries     int                   `json:"max_retries"`
+	BackoffSeconds float64               `json:"backoff_seconds"`
+	Restarts       []RestartRecordOutput `json:"restarts"`
+
+	// Set when the policy was changed
+	Recreated bool `json:"recreated,omitempty"`
+	Pending   bool `json:"pending,omitempty"`
+}
+
+// RestartPolicyOutput is the JSON output of the restart-policy command
+type RestartPolicyOutput struct {
+	Status string             `json:"status"`
+	Data   *RestartPolicyInfo `json:"data"`
+}
+
+// RestartRecordOutput describes an automatic restart
+type RestartRecordOutput struct {
+	Time        time.Time `json:"time"`
+	By          string    `json:"by"`
+	Cause       string    `json:"cause"`
+	ExitCode    int       `json:"exit_code"`
+	CrashReport string    `json:"crash_report,omitempty"`
+}
+
+// NewRestartPolicyCommand creates the servers restart-policy subcommand
+func NewRestartPolicyCommand() *cobra.Command {
+	flags := &RestartPolicyFlags{}
+
+	cmd := &cobra.Command{
+		Use:   "restart-policy <name> [no|on-failure|always]",
+		Short: "Show or set what happens when a server crashes or hangs",
+		Long: `Show or set the restart policy of a server.
+
+Policies:
+  no          Never restart automatically (default)
+  on-failure  Restart when the server exits with a non-zero code or hangs,
+              up to --max-retries consecutive times
+  always      Restart whenever the server exits or hangs, unless it was
+              stopped with go-mc
+
+Podman restarts crashed containers right away. 'go-mc watch' also restarts
+servers that hang, applies the backoff and records every restart with its cause.
+Without a policy, the current policy and the recent restarts are shown.
+
+The policy is set on the container when it is created: a stopped server's
+container is recreated right away, a running one when it is next started or
+restarted.`,
+		Example: `  # Show the policy and recent restarts
+  go-mc servers restart-policy myserver
+
+  # Restart crashed servers, at most 5 times in a row
+  go-mc servers restart-policy myserver on-failure --max-retries 5
+
+  # Always keep the server up, waiting 30s before the first restart
+  go-mc servers restart-policy myserver always --backoff 30s`,
+		Args: cobra.RangeArgs(1, 2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) == 1 {
+				return runShowRestartPolicy(cmd.Context(), cmd.OutOrStdout(), args[0])
+			}
+			return runSetRestartPolicy(cmd.Context(), cmd.OutOrStdout(), args[0], args[1], flags)
+		},
+	}
+
+	cmd.Flags().IntVar(&flags.MaxRetries, "max-retries", 0, "Consecutive restarts before giving up, for on-failure (0 = unlimited)")
+	cmd.Flags().DurationVar(&flags.Backoff, "backoff", state.DefaultRestartBackoff, "Delay before the first restart, doubled for each further one")
+
+	return cmd
+}
+
+// runShowRestartPolicy shows the restart policy and history of a server
+func runShowRestartPolicy(ctx context.Context, stdout io.Writer, name string) error {
+	jsonMode := isJSONMode()
+
+	serverState, err := state.LoadServerState(ctx, name)
+	if err != nil {
+		return outputLifecycleError(stdout, jsonMode, err)
+	}
+
+	return outputRestartPolicy(stdout, jsonMode, newRestartPolicyInfo(serverState))
+}
+
+// runSetRestartPolicy changes the restart policy of a server
+func runSetRestartPolicy(ctx context.Context, stdout io.Writer, name, policy string, flags *RestartPolicyFlags) error {
+	jsonMode := isJSONMode()
+
+	serverState, err := state.LoadServerState(ctx, name)
+	if err != nil {
+		return outputLifecycleError(stdout, jsonMode, err)
+	}
+
+	serverState.Restart = state.RestartPolicy{
+		Policy:     policy,
+		MaxRetries: flags.MaxRetries,
+		Backoff:    flags.Backoff,
+	}
+	if err := state.ValidateRestartPolicy(serverState.Restart); err != nil {
+		return outputLifecycleError(stdout, jsonMode, fmt.Errorf("invalid restart policy: %w", err))
+	}
+	if err := state.SaveServerState(ctx, serverState); err != nil {
+		return outputLifecycleError(stdout, jsonMode, fmt.Errorf("failed to save server state: %w", err))
+	}
+
+	info := newRestartPolicyInfo(serverState)
+
+	if serverState.ContainerID != "" {
+		client, err := createContainerClient(ctx)
+		if err != nil {
+			return outputLifecycleError(stdout, jsonMode, err)
+		}
+		defer func() { _ = client.Close() }()
+
+		result, err := server.Reconcile(ctx, client, serverState)
+		if err != nil {
+			return outputLifecycleError(stdout, jsonMode, fmt.Errorf("failed to apply restart policy: %w", err))
+		}
+		info.Recreated = result.Recreated
+		info.Pending = result.Pending
+	}
+
+	return outputRestartPolicy(stdout, jsonMode, info)
+}
+
+// newRestartPolicyInfo converts the restart settings of a server for
+// output
+func newRestartPolicyInfo(serverState *state.ServerState) *RestartPolicyInfo {
+	info := &RestartPolicyInfo{
+		Server:         serverState.Name,
+		Policy:         serverState.Restart.Name(),
+		MaxRetries:     serverState.Restart.MaxRetries,
+		BackoffSeconds: seconds(serverState.Restart.Delay(0)),
+		Restarts:       []RestartRecordOutput{},
+	}
+
+	for _, r := range serverState.Restarts {
+		info.Restarts = append(info.Restarts, RestartRecordOutput{
+			Time:        r.Time,
+			By:          r.By,
+			Cause:       r.Cause,
+			ExitCode:    r.ExitCode,
+			CrashReport: r.CrashReport,
+		})
+	}
+
+	return info
+}
+
+// outputRestartPolicy outputs the restart policy of a server
+func outputRestartPolicy(stdout io.Writer, jsonMode bool, info *RestartPolicyInfo) error {
+	if jsonMode {
+		enc := json.NewEncoder(stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(RestartPolicyOutput{Status: "success", Data: info})
+	}
+
+	policy := state.RestartPolicy{
+		Policy:     info.Policy,
+		MaxRetries: info.MaxRetries,
+		Backoff:    time.Duration(info.BackoffSeconds * float64(time.Second)),
+	}
+	_, _ = fmt.Fprintf(stdout, "Server '%s' restart policy: %s\n", info.Server, formatRestartPolicy(policy))
+
+	switch {
+	case info.Recreated:
+		_, _ = fmt.Fprintln(stdout, "  Container recreated with the new policy")
+	case info.Pending:
+		_, _ = fmt.Fprintln(stdout, "  Applied to the container the next time the server is started or restarted")
+	}
+
+	if len(info.Restarts) == 0 {
+		return nil
+	}
+
+	_, _ = fmt.Fprintf(stdout, "\nRecent restarts:\n")
+	for _, r := range info.Restarts {
+		record := state.RestartRecord{Cause: r.Cause, ExitCode: r.ExitCode, CrashReport: r.CrashReport}
+		_, _ = fmt.Fprintf(stdout, "  %s  by %-6s  %s\n", r.Time.Local().Format(time.DateTime), r.By, record)
+	}
+
+	return nil
+}
+
+// formatRestartPolicy describes a restart policy, e.g.
+// "on-failure (max 5 retries, backoff 10s)"
+func formatRestartPolicy(policy state.RestartPolicy) string {
+	if policy.Name() == state.RestartNo {
+		return state.RestartNo
+	}
+
+	details := []string{}
+	if policy.Name() == state.RestartOnFailure {
+		if policy.MaxRetries > 0 {
+			details = append(details, fmt.Sprintf("max %d retries", policy.MaxRetries))
+		} else {
+			details = append(details, "unlimited retries")
+		}
+	}
+	details = append(details, "backoff "+policy.Delay(0).String())
+
+	return fmt.Sprintf("%s (%s)", policy.Name(), strings.Join(details, ", "))
+}
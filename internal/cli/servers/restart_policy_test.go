@@ -0,0 +1,90 @@
+package servers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/steviee/go-mc/internal/state"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunSetRestartPolicy(t *testing.T) {
+	setupTestStateDir(t, t.TempDir())
+	require.NoError(t, state.SaveServerState(context.Background(), state.NewServerState("survival")))
+
+	var buf bytes.Buffer
+	err := runSetRestartPolicy(context.Background(), &buf, "survival", state.RestartOnFailure,
+		&RestartPolicyFlags{MaxRetries: 5, Backoff: 30 * time.Second})
+	require.NoError(t, err)
+	assert.Equal(t, "Server 'survival' restart policy: on-failure (max 5 retries, backoff 30s)\n", buf.String())
+
+	saved, err := state.LoadServerState(context.Background(), "survival")
+	require.NoError(t, err)
+	assert.Equal(t, state.RestartPolicy{Policy: state.RestartOnFailure, MaxRetries: 5, Backoff: 30 * time.Second}, saved.Restart)
+}
+
+func TestRunSetRestartPolicy_Invalid(t *testing.T) {
+	setupTestStateDir(t, t.TempDir())
+	require.NoError(t, state.SaveServerState(context.Background(), state.NewServerState("survival")))
+
+	var buf bytes.Buffer
+	err := runSetRestartPolicy(context.Background(), &buf, "survival", state.RestartAlways,
+		&RestartPolicyFlags{MaxRetries: 5, Backoff: state.DefaultRestartBackoff})
+	assert.ErrorContains(t, err, "invalid restart policy")
+
+	saved, err := state.LoadServerState(context.Background(), "survival")
+	require.NoError(t, err)
+	assert.Equal(t, state.RestartPolicy{}, saved.Restart, "not saved")
+}
+
+func TestOutputRestartPolicy(t *testing.T) {
+	serverState := state.NewServerState("survival")
+	serverState.Restart = state.RestartPolicy{Policy: state.RestartAlways}
+	serverState.RecordRestart(state.RestartRecord{
+		Time:        time.Date(2025, 1, 2, 12, 0, 0, 0, time.UTC),
+		By:          state.RestartedByWatch,
+		Cause:       state.RestartCauseExit,
+		ExitCode:    1,
+		CrashReport: "crash-2025-01-02_11.59.58-server.txt",
+	})
+	info := newRestartPolicyInfo(serverState)
+
+	var buf bytes.Buffer
+	require.NoError(t, outputRestartPolicy(&buf, false, info))
+	assert.Contains(t, buf.String(), "restart policy: always (backoff 10s)\n")
+	assert.Contains(t, buf.String(), "by watch   exit code 1 (crash-2025-01-02_11.59.58-server.txt)\n")
+
+	buf.Reset()
+	require.NoError(t, outputRestartPolicy(&buf, true, info))
+
+	var output RestartPolicyOutput
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &output))
+	assert.Equal(t, "success", output.Status)
+	assert.Equal(t, "always", output.Data.Policy)
+	assert.Equal(t, 10.0, output.Data.BackoffSeconds)
+	require.Len(t, output.Data.Restarts, 1)
+	assert.Equal(t, state.RestartCauseExit, output.Data.Restarts[0].Cause)
+}
+
+func TestFormatRestartPolicy(t *testing.T) {
+	tests := []struct {
+		policy state.RestartPolicy
+		want   string
+	}{
+		{state.RestartPolicy{}, "no"},
+		{state.RestartPolicy{Policy: state.RestartNo}, "no"},
+		{state.RestartPolicy{Policy: state.RestartAlways, Backoff: time.Minute}, "always (backoff 1m0s)"},
+		{state.RestartPolicy{Policy: state.RestartOnFailure}, "on-failure (unlimited retries, backoff 10s)"},
+		{state.RestartPolicy{Policy: state.RestartOnFailure, MaxRetries: 3}, "on-failure (max 3 retries, backoff 10s)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			assert.Equal(t, tt.want, formatRestartPolicy(tt.policy))
+		})
+	}
+}
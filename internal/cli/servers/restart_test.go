@@ -206,3 +206,19 @@ func TestRestartCommandAliases(t *testing.T) {
 	cmd := NewRestartCommand()
 	assert.Empty(t, cmd.Aliases)
 }
+
+func TestRestartFlags_Countdown(t *testing.T) {
+	cmd := NewRestartCommand()
+	cmd.SetArgs([]string{"server1", "--countdown", "5m", "--message", "Applying updates"})
+	cmd.RunE = func(cmd *cobra.Command, args []string) error {
+		return nil
+	}
+
+	require.NoError(t, cmd.Execute())
+
+	countdown, _ := cmd.Flags().GetDuration("countdown")
+	message, _ := cmd.Flags().GetString("message")
+
+	assert.Equal(t, 5*time.Minute, countdown)
+	assert.Equal(t, "Applying updates", message)
+}
@@ -12,7 +12,7 @@ import (
 
 	"github.com/spf13/cobra"
 	"github.com/steviee/go-mc/internal/backup"
-	"github.com/steviee/go-mc/internal/container"
+	"github.com/steviee/go-mc/internal/server"
 	"github.com/steviee/go-mc/internal/state"
 )
 
@@ -126,7 +126,7 @@ func runRestore(ctx context.Context, stdout io.Writer, stdin io.Reader, serverNa
 	}
 
 	// Create container client
-	containerClient, err := container.NewClient(ctx, container.DefaultConfig())
+	containerClient, err := server.ConnectContainerRuntime(ctx)
 	if err != nil {
 		return outputRestoreError(stdout, jsonMode, fmt.Errorf("failed to create container client: %w", err))
 	}
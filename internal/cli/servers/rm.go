@@ -177,6 +177,9 @@ func removeServer(ctx context.Context, client container.Client, name string, fla
 	if serverState.Minecraft.RconPort > 0 {
 		releasedPorts = append(releasedPorts, serverState.Minecraft.RconPort)
 	}
+	if serverState.Minecraft.QueryPort > 0 {
+		releasedPorts = append(releasedPorts, serverState.Minecraft.QueryPort)
+	}
 	for _, mod := range serverState.Mods {
 		if mod.Port > 0 {
 			releasedPorts = append(releasedPorts, mod.Port)
@@ -233,6 +236,11 @@ func removeServer(ctx context.Context, client container.Client, name string, fla
 		return releasedPorts, fmt.Errorf("failed to delete server state: %w", err)
 	}
 
+	// Remove server bans
+	if err := state.DeleteBanList(ctx, name); err != nil {
+		slog.Warn("failed to delete ban list", "server", name, "error", err)
+	}
+
 	// Remove server from global registry
 	globalState, err := state.LoadGlobalState(ctx)
 	if err != nil {
@@ -252,6 +260,13 @@ func removeServer(ctx context.Context, client container.Client, name string, fla
 		return releasedPorts, fmt.Errorf("failed to update global state: %w", err)
 	}
 
+	// Remove the autostart unit, so systemd does not start a removed server
+	if serverState.Autostart.Enabled {
+		if err := syncAutostart(ctx); err != nil {
+			slog.Warn("failed to remove autostart unit", "server", name, "error", err)
+		}
+	}
+
 	slog.Debug("server removed", "name", name)
 	return releasedPorts, nil
 }
@@ -28,6 +28,18 @@ You can also view server status, logs, and configuration.`,
   # View server status
   go-mc servers status myserver
 
+  # Wait until a server accepts players
+  go-mc servers wait myserver
+
+  # Show everything about a server
+  go-mc servers inspect myserver
+
+  # Run a console command via RCON
+  go-mc servers exec myserver list
+
+  # Open an interactive console
+  go-mc servers console myserver
+
   # Remove a server
   go-mc servers rm myserver`,
 		Aliases: []string{"server", "srv"},
@@ -42,14 +54,23 @@ You can also view server status, logs, and configuration.`,
 	cmd.AddCommand(NewRestartCommand())
 	cmd.AddCommand(NewRmCommand())
 	cmd.AddCommand(NewLogsCommand())
+	cmd.AddCommand(NewExecCommand())
+	cmd.AddCommand(NewConsoleCommand())
 	cmd.AddCommand(NewTopCommand())
 	cmd.AddCommand(NewBackupCommand())
 	cmd.AddCommand(NewRestoreCommand())
 	cmd.AddCommand(NewUpdateCommand())
+	cmd.AddCommand(NewInspectCommand())
+	cmd.AddCommand(NewWaitCommand())
+	cmd.AddCommand(NewPlayersCommand())
+	cmd.AddCommand(NewRestartPolicyCommand())
+	cmd.AddCommand(NewAutostartCommand())
+	cmd.AddCommand(NewResourcesCommand())
+	cmd.AddCommand(NewReconcileCommand())
+	cmd.AddCommand(NewExportMrpackCommand())
 
 	// Future subcommands
 	// cmd.AddCommand(NewStatusCommand())
-	// cmd.AddCommand(NewInspectCommand())
 
 	return cmd
 }
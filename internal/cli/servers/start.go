@@ -2,21 +2,32 @@ package servers
 
 import (
 	"context"
+	"errors"
 	"io"
 	"log/slog"
 	"strings"
 	"time"
 
 	"github.com/spf13/cobra"
+	"github.com/steviee/go-mc/internal/access"
 	"github.com/steviee/go-mc/internal/container"
+	"github.com/steviee/go-mc/internal/lifecycle"
+	"github.com/steviee/go-mc/internal/server"
 	"github.com/steviee/go-mc/internal/state"
 )
 
+// reconcileContainer applies the spec of a server to its container and
+// startContainer starts it. Replaced in tests.
+var (
+	reconcileContainer = server.Reconcile
+	startContainer     = lifecycle.Start
+)
+
 // StartFlags holds all flags for the start command
 type StartFlags struct {
-	All     bool
-	Wait    bool
-	Timeout time.Duration
+	All         bool
+	Wait        bool
+	WaitTimeout time.Duration
 }
 
 // NewStartCommand creates the servers start subcommand
@@ -31,7 +42,11 @@ func NewStartCommand() *cobra.Command {
 You can start multiple servers by specifying multiple names, or use --all to start
 all stopped servers.
 
-If a server is already running, it will be skipped with a warning.`,
+If a server is already running, it will be skipped with a warning.
+
+With --wait, the command returns once each server is ready: it answers a server
+list ping on its game port or logged its "Done (…)!" line. The time until the
+container was up and until the world was loaded is reported (in JSON as "timing").`,
 		Example: `  # Start a single server
   go-mc servers start myserver
 
@@ -41,11 +56,11 @@ If a server is already running, it will be skipped with a warning.`,
   # Start all stopped servers
   go-mc servers start --all
 
-  # Start and wait for container to be fully running
+  # Start and wait until the world is loaded and players can join
   go-mc servers start myserver --wait
 
   # Start with custom timeout
-  go-mc servers start myserver --wait --timeout 2m
+  go-mc servers start myserver --wait --wait-timeout 10m
 
   # JSON output for scripting
   go-mc servers start myserver --json`,
@@ -56,8 +71,12 @@ If a server is already running, it will be skipped with a warning.`,
 
 	// Add flags
 	cmd.Flags().BoolVar(&flags.All, "all", false, "Start all stopped servers")
-	cmd.Flags().BoolVar(&flags.Wait, "wait", false, "Wait until containers are fully started")
-	cmd.Flags().DurationVar(&flags.Timeout, "timeout", 60*time.Second, "Timeout for --wait")
+	cmd.Flags().BoolVar(&flags.Wait, "wait", false, "Wait until servers are ready for players")
+	cmd.Flags().DurationVar(&flags.WaitTimeout, "wait-timeout", lifecycle.DefaultWaitTimeout, "Timeout for --wait")
+
+	// Older name of --wait-timeout
+	cmd.Flags().DurationVar(&flags.WaitTimeout, "timeout", lifecycle.DefaultWaitTimeout, "Timeout for --wait")
+	_ = cmd.Flags().MarkDeprecated("timeout", "use --wait-timeout instead")
 
 	return cmd
 }
@@ -82,6 +101,11 @@ func runStart(ctx context.Context, stdout io.Writer, args []string, flags *Start
 	}
 	defer func() { _ = client.Close() }()
 
+	// Servers load their ban files on start, so drop expired bans first
+	if _, err := access.LiftExpiredBans(ctx); err != nil {
+		slog.Warn("failed to lift expired bans", "error", err)
+	}
+
 	// Process each server
 	for _, name := range serverNames {
 		if err := startServer(ctx, client, name, flags, result); err != nil {
@@ -102,37 +126,49 @@ func startServer(ctx context.Context, client container.Client, name string, flag
 		return err
 	}
 
-	// Check if container exists
+	// Check if container exists. systemd removes the container of a server
+	// with autostart enabled when its unit stops; it is recreated below.
 	info, err := checkContainerExists(ctx, client, serverState.ContainerID)
-	if err != nil {
+	missing := serverState.Autostart.Enabled && errors.Is(err, container.ErrContainerNotFound)
+	if err != nil && !missing {
 		result.Failed[name] = err.Error()
 		return err
 	}
 
 	// Check if already running
-	if isContainerRunning(info.State) {
+	if !missing && isContainerRunning(info.State) {
 		result.Skipped = append(result.Skipped, name)
 		return nil
 	}
 
+	// Apply changes made while the server was stopped (e.g. a mod was installed)
+	reconciled, err := reconcileContainer(ctx, client, serverState)
+	if err != nil {
+		result.Failed[name] = err.Error()
+		return err
+	}
+	if reconciled.Recreated {
+		slog.Info("recreated container to apply its spec", "name", name, "differences", len(reconciled.Differences))
+	}
+
 	// Start container
-	if err := client.StartContainer(ctx, serverState.ContainerID); err != nil {
+	started := time.Now()
+	if err := startContainer(ctx, client, serverState); err != nil {
 		result.Failed[name] = err.Error()
 		return err
 	}
 
-	// Wait if requested
+	// Wait until the world is loaded if requested
 	if flags.Wait {
-		waitCtx, cancel := context.WithTimeout(ctx, flags.Timeout)
-		defer cancel()
-
-		if err := client.WaitForContainer(waitCtx, serverState.ContainerID, "running"); err != nil {
-			// Container started but wait failed
-			result.Failed[name] = "started but wait timed out: " + err.Error()
+		timing, err := waitForServer(ctx, client, serverState, lifecycle.ConditionReady, flags.WaitTimeout, started)
+		if err != nil {
+			// Container started but the server did not become ready
+			result.Failed[name] = "started but not ready: " + err.Error()
 			// Still update state since container did start
 			_ = updateServerStatus(ctx, serverState, state.StatusRunning)
 			return err
 		}
+		result.Timing[name] = timing
 	}
 
 	// Update server state
@@ -4,11 +4,15 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"fmt"
 	"os"
 	"testing"
 	"time"
 
 	"github.com/spf13/cobra"
+	"github.com/steviee/go-mc/internal/container"
+	"github.com/steviee/go-mc/internal/server"
+	"github.com/steviee/go-mc/internal/state"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -41,36 +45,43 @@ func TestStartFlags(t *testing.T) {
 			args:        []string{"server1"},
 			wantAll:     false,
 			wantWait:    false,
-			wantTimeout: 60 * time.Second,
+			wantTimeout: 5 * time.Minute,
 		},
 		{
 			name:        "with all flag",
 			args:        []string{"--all"},
 			wantAll:     true,
 			wantWait:    false,
-			wantTimeout: 60 * time.Second,
+			wantTimeout: 5 * time.Minute,
 		},
 		{
 			name:        "with wait flag",
 			args:        []string{"server1", "--wait"},
 			wantAll:     false,
 			wantWait:    true,
-			wantTimeout: 60 * time.Second,
+			wantTimeout: 5 * time.Minute,
 		},
 		{
 			name:        "with custom timeout",
-			args:        []string{"server1", "--timeout", "2m"},
+			args:        []string{"server1", "--wait-timeout", "2m"},
 			wantAll:     false,
 			wantWait:    false,
 			wantTimeout: 2 * time.Minute,
 		},
 		{
 			name:        "all flags together",
-			args:        []string{"--all", "--wait", "--timeout", "30s"},
+			args:        []string{"--all", "--wait", "--wait-timeout", "30s"},
 			wantAll:     true,
 			wantWait:    true,
 			wantTimeout: 30 * time.Second,
 		},
+		{
+			name:        "with deprecated timeout flag",
+			args:        []string{"server1", "--wait", "--timeout", "3m"},
+			wantAll:     false,
+			wantWait:    true,
+			wantTimeout: 3 * time.Minute,
+		},
 	}
 
 	for _, tt := range tests {
@@ -92,7 +103,7 @@ func TestStartFlags(t *testing.T) {
 
 			all, _ := cmd.Flags().GetBool("all")
 			wait, _ := cmd.Flags().GetBool("wait")
-			timeout, _ := cmd.Flags().GetDuration("timeout")
+			timeout, _ := cmd.Flags().GetDuration("wait-timeout")
 
 			assert.Equal(t, tt.wantAll, all)
 			assert.Equal(t, tt.wantWait, wait)
@@ -150,6 +161,48 @@ func TestStartServer_InvalidServerName(t *testing.T) {
 	assert.Contains(t, result.Failed["invalid@name"], "invalid server name")
 }
 
+func TestStartServer_AutostartContainerRemoved(t *testing.T) {
+	setupTestStateDir(t, t.TempDir())
+
+	serverState := saveInspectServer(t, "survival")
+	serverState.Autostart.Enabled = true
+	serverState.ContainerID = "survival"
+	require.NoError(t, state.SaveServerState(context.Background(), serverState))
+
+	// systemd removed the container when the unit stopped
+	reconciled := 0
+	orig := reconcileContainer
+	reconcileContainer = func(ctx context.Context, client container.Client, serverState *state.ServerState) (*server.ReconcileResult, error) {
+		reconciled++
+		return &server.ReconcileResult{Recreated: true}, nil
+	}
+	t.Cleanup(func() { reconcileContainer = orig })
+
+	var started []string
+	origStart := startContainer
+	startContainer = func(ctx context.Context, client container.Client, serverState *state.ServerState) error {
+		started = append(started, serverState.Name)
+		return nil
+	}
+	t.Cleanup(func() { startContainer = origStart })
+
+	client := &inspectClient{inspErr: fmt.Errorf("%w: survival", container.ErrContainerNotFound)}
+	result := NewOperationResult()
+	require.NoError(t, startServer(context.Background(), client, "survival", &StartFlags{}, result))
+	assert.Equal(t, []string{"survival"}, result.Success)
+	assert.Equal(t, 1, reconciled)
+	assert.Equal(t, []string{"survival"}, started)
+
+	// Without autostart a missing container is an error
+	serverState.Autostart.Enabled = false
+	require.NoError(t, state.SaveServerState(context.Background(), serverState))
+
+	result = NewOperationResult()
+	require.Error(t, startServer(context.Background(), client, "survival", &StartFlags{}, result))
+	assert.Contains(t, result.Failed["survival"], "container not found")
+	assert.Equal(t, 1, reconciled)
+}
+
 func TestStartFlags_Validation(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -159,16 +212,16 @@ func TestStartFlags_Validation(t *testing.T) {
 		{
 			name: "valid flags",
 			flags: &StartFlags{
-				Wait:    true,
-				Timeout: 60 * time.Second,
+				Wait:        true,
+				WaitTimeout: 60 * time.Second,
 			},
 			wantErr: false,
 		},
 		{
 			name: "wait without explicit timeout uses default",
 			flags: &StartFlags{
-				Wait:    true,
-				Timeout: 0, // Will use default
+				Wait:        true,
+				WaitTimeout: 0, // Will use default
 			},
 			wantErr: false,
 		},
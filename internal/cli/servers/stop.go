@@ -9,14 +9,17 @@ import (
 
 	"github.com/spf13/cobra"
 	"github.com/steviee/go-mc/internal/container"
+	"github.com/steviee/go-mc/internal/lifecycle"
 	"github.com/steviee/go-mc/internal/state"
 )
 
 // StopFlags holds all flags for the stop command
 type StopFlags struct {
-	All     bool
-	Force   bool
-	Timeout time.Duration
+	All       bool
+	Force     bool
+	Timeout   time.Duration
+	Countdown time.Duration
+	Message   string
 }
 
 // NewStopCommand creates the servers stop subcommand
@@ -26,10 +29,15 @@ func NewStopCommand() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "stop <name...>",
 		Short: "Stop one or more running Minecraft servers",
-		Long: `Stop one or more running Minecraft servers by stopping their containers.
+		Long: `Stop one or more running Minecraft servers.
 
-The container will be sent a SIGTERM signal and given time to gracefully shut down.
-If the timeout expires, SIGKILL will be sent.
+The shutdown is driven over RCON: players are warned with a countdown broadcast,
+the world is flushed to disk with 'save-all flush', and the server is told to stop.
+If RCON is unavailable or the server does not exit in time, the container is sent
+a SIGTERM signal and, once the timeout expires, SIGKILL.
+
+Servers with a restart policy or autostart are stopped by the container runtime
+or systemd once the world is saved, so they are not restarted afterwards.
 
 You can stop multiple servers by specifying multiple names, or use --all to stop
 all running servers.
@@ -44,6 +52,12 @@ If a server is already stopped, it will be skipped with a warning.`,
   # Stop all running servers
   go-mc servers stop --all
 
+  # Warn players for one minute before stopping
+  go-mc servers stop myserver --countdown 1m
+
+  # Use a custom broadcast message
+  go-mc servers stop myserver --countdown 30s --message "Maintenance"
+
   # Force immediate stop (SIGKILL)
   go-mc servers stop myserver --force
 
@@ -60,7 +74,9 @@ If a server is already stopped, it will be skipped with a warning.`,
 	// Add flags
 	cmd.Flags().BoolVar(&flags.All, "all", false, "Stop all running servers")
 	cmd.Flags().BoolVar(&flags.Force, "force", false, "Force immediate stop (SIGKILL)")
-	cmd.Flags().DurationVar(&flags.Timeout, "timeout", 30*time.Second, "Graceful shutdown timeout")
+	cmd.Flags().DurationVar(&flags.Timeout, "timeout", lifecycle.DefaultStopTimeout, "Graceful shutdown timeout")
+	cmd.Flags().DurationVar(&flags.Countdown, "countdown", lifecycle.DefaultCountdown, "Warn players for this long before stopping")
+	cmd.Flags().StringVar(&flags.Message, "message", "", "Countdown broadcast message (default \"Server stopping\")")
 
 	return cmd
 }
@@ -118,18 +134,20 @@ func stopServer(ctx context.Context, client container.Client, name string, flags
 		return nil
 	}
 
-	// Determine timeout
-	timeout := flags.Timeout
-	if flags.Force {
-		// Force immediate stop with zero timeout
-		timeout = 0
-	}
-
-	// Stop container
-	if err := client.StopContainer(ctx, serverState.ContainerID, &timeout); err != nil {
+	// Stop gracefully over RCON, falling back to the container runtime
+	shutdown, err := lifecycle.Stop(ctx, client, serverState, lifecycle.ShutdownOptions{
+		Countdown: flags.Countdown,
+		Message:   flags.Message,
+		Timeout:   flags.Timeout,
+		Force:     flags.Force,
+	})
+	if err != nil {
 		result.Failed[name] = err.Error()
 		return err
 	}
+	if !shutdown.Graceful {
+		slog.Debug("server stopped without RCON", "name", name, "reason", shutdown.Fallback)
+	}
 
 	// Update server state
 	if err := updateServerStatus(ctx, serverState, state.StatusStopped); err != nil {
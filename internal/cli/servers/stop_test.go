@@ -230,3 +230,31 @@ func TestIsContainerStopped_EdgeCases(t *testing.T) {
 		})
 	}
 }
+
+func TestStopFlags_Countdown(t *testing.T) {
+	cmd := NewStopCommand()
+	cmd.SetArgs([]string{"server1", "--countdown", "1m", "--message", "Maintenance"})
+	cmd.RunE = func(cmd *cobra.Command, args []string) error {
+		return nil
+	}
+
+	require.NoError(t, cmd.Execute())
+
+	countdown, _ := cmd.Flags().GetDuration("countdown")
+	message, _ := cmd.Flags().GetString("message")
+
+	assert.Equal(t, time.Minute, countdown)
+	assert.Equal(t, "Maintenance", message)
+}
+
+func TestStopFlags_CountdownDefault(t *testing.T) {
+	cmd := NewStopCommand()
+
+	countdown := cmd.Flags().Lookup("countdown")
+	require.NotNil(t, countdown)
+	assert.Equal(t, "0s", countdown.DefValue)
+
+	message := cmd.Flags().Lookup("message")
+	require.NotNil(t, message)
+	assert.Empty(t, message.DefValue)
+}
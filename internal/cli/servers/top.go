@@ -6,7 +6,8 @@ import (
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/spf13/cobra"
-	"github.com/steviee/go-mc/internal/container"
+	"github.com/steviee/go-mc/internal/server"
+	"github.com/steviee/go-mc/internal/state"
 	"github.com/steviee/go-mc/internal/tui"
 )
 
@@ -37,7 +38,11 @@ Keyboard shortcuts:
   go-mc servers dashboard`,
 		Aliases: []string{"dashboard"},
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runTop(cmd.Context())
+			cfg, err := state.ResolveConfig(cmd.Context())
+			if err != nil {
+				return err
+			}
+			return runTop(cmd.Context(), cfg)
 		},
 	}
 
@@ -45,9 +50,9 @@ Keyboard shortcuts:
 }
 
 // runTop executes the top command
-func runTop(ctx context.Context) error {
+func runTop(ctx context.Context, cfg *state.Config) error {
 	// Create container client
-	containerClient, err := container.NewClient(ctx, container.DefaultConfig())
+	containerClient, err := server.NewContainerClient(ctx, cfg)
 	if err != nil {
 		return fmt.Errorf("failed to create container client: %w", err)
 	}
@@ -55,6 +60,7 @@ func runTop(ctx context.Context) error {
 
 	// Create TUI model
 	model := tui.NewModel(containerClient)
+	model.SetRefreshInterval(cfg.TUI.RefreshInterval)
 
 	// Start bubbletea program
 	p := tea.NewProgram(model, tea.WithAltScreen())
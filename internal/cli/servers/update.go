@@ -12,10 +12,10 @@ import (
 
 	"github.com/spf13/cobra"
 	"github.com/steviee/go-mc/internal/backup"
-	"github.com/steviee/go-mc/internal/container"
 	"github.com/steviee/go-mc/internal/minecraft"
 	"github.com/steviee/go-mc/internal/modrinth"
 	"github.com/steviee/go-mc/internal/mods"
+	"github.com/steviee/go-mc/internal/server"
 	"github.com/steviee/go-mc/internal/state"
 )
 
@@ -97,15 +97,21 @@ Use --dry-run to preview changes without applying them.`,
   go-mc servers update myserver --latest --json`,
 		Args: cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := state.ResolveConfig(cmd.Context())
+			if err != nil {
+				return err
+			}
+			applyUpdateConfig(cmd.Flags().Changed, flags, cfg)
+
 			serverName := args[0]
-			return runUpdate(cmd.Context(), cmd.OutOrStdout(), serverName, flags)
+			return runUpdate(cmd.Context(), cmd.OutOrStdout(), serverName, flags, cfg)
 		},
 	}
 
 	cmd.Flags().StringVar(&flags.Version, "version", "", "Update to specific Minecraft version")
 	cmd.Flags().BoolVar(&flags.Latest, "latest", false, "Update to latest Minecraft + Fabric")
 	cmd.Flags().BoolVar(&flags.ModsOnly, "mods-only", false, "Update mods only (preserve MC version)")
-	cmd.Flags().BoolVar(&flags.Backup, "backup", true, "Create backup before update")
+	cmd.Flags().BoolVar(&flags.Backup, "backup", false, "Create backup before update (default: backups.auto_backup_before_update from config)")
 	cmd.Flags().BoolVar(&flags.Restart, "restart", false, "Restart server after update")
 	cmd.Flags().BoolVar(&flags.DryRun, "dry-run", false, "Show what would be updated without applying")
 
@@ -115,8 +121,15 @@ Use --dry-run to preview changes without applying them.`,
 	return cmd
 }
 
+// applyUpdateConfig fills the flags the user did not set from the config.
+func applyUpdateConfig(changed func(name string) bool, flags *UpdateFlags, cfg *state.Config) {
+	if !changed("backup") {
+		flags.Backup = cfg.Backups.AutoBackupBeforeUpdate
+	}
+}
+
 // runUpdate executes the update command.
-func runUpdate(ctx context.Context, stdout io.Writer, serverName string, flags *UpdateFlags) error {
+func runUpdate(ctx context.Context, stdout io.Writer, serverName string, flags *UpdateFlags, cfg *state.Config) error {
 	jsonMode := isJSONMode()
 
 	// Validate flags
@@ -173,7 +186,7 @@ func runUpdate(ctx context.Context, stdout io.Writer, serverName string, flags *
 	}
 
 	// Real update: execute the full workflow
-	summary, err := executeUpdate(ctx, stdout, serverState, targetMCVersion, targetFabricVersion, minecraftClient, modrinthClient, flags, jsonMode)
+	summary, err := executeUpdate(ctx, stdout, serverState, targetMCVersion, targetFabricVersion, minecraftClient, modrinthClient, flags, cfg, jsonMode)
 	if err != nil {
 		return outputUpdateError(stdout, jsonMode, err)
 	}
@@ -354,6 +367,7 @@ func executeUpdate(
 	minecraftClient *minecraft.Client,
 	modrinthClient *modrinth.Client,
 	flags *UpdateFlags,
+	cfg *state.Config,
 	jsonMode bool,
 ) (*UpdateSummary, error) {
 	summary := &UpdateSummary{
@@ -373,8 +387,9 @@ func executeUpdate(
 		backupService := backup.NewService()
 		result, err := backupService.CreateBackup(ctx, backup.CreateBackupOptions{
 			ServerName: serverState.Name,
-			Compress:   true,
-			KeepCount:  5,
+			Compress:   cfg.Backups.Compress,
+			KeepCount:  cfg.Backups.KeepCount,
+			Directory:  cfg.Backups.Directory,
 		})
 		if err != nil {
 			return nil, fmt.Errorf("backup failed: %w", err)
@@ -388,7 +403,7 @@ func executeUpdate(
 	}
 
 	// Step 2: Stop server if running
-	containerClient, err := container.NewClient(ctx, container.DefaultConfig())
+	containerClient, err := server.NewContainerClient(ctx, cfg)
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect to container runtime: %w", err)
 	}
@@ -437,7 +452,7 @@ func executeUpdate(
 			_, _ = fmt.Fprintln(stdout, "Updating mods...")
 		}
 
-		modResults, err := updateMods(ctx, serverState, targetMCVersion, modrinthClient, jsonMode, stdout)
+		modResults, err := updateMods(ctx, serverState, targetMCVersion, modrinthClient, cfg, jsonMode, stdout)
 		if err != nil {
 			return nil, fmt.Errorf("mod update failed: %w", err)
 		}
@@ -466,7 +481,7 @@ func executeUpdate(
 		_, _ = fmt.Fprintln(stdout, "Recreating container...")
 	}
 
-	if err := recreateContainer(ctx, serverState, containerClient); err != nil {
+	if err := server.RecreateContainer(ctx, containerClient, serverState); err != nil {
 		return nil, fmt.Errorf("failed to recreate container: %w", err)
 	}
 
@@ -500,11 +515,12 @@ func updateMods(
 	serverState *state.ServerState,
 	targetMCVersion string,
 	modrinthClient *modrinth.Client,
+	cfg *state.Config,
 	jsonMode bool,
 	stdout io.Writer,
 ) ([]ModUpdateResult, error) {
 	results := []ModUpdateResult{}
-	modInstaller := mods.NewInstaller()
+	modInstaller := mods.NewInstallerFromConfig(cfg)
 
 	for i := range serverState.Mods {
 		mod := &serverState.Mods[i]
@@ -549,11 +565,11 @@ func updateMods(
 		serverDir := filepath.Dir(serverState.Volumes.Data)
 		modsDir := filepath.Join(serverDir, "mods")
 
-		// Remove old mod file
-		oldPath := filepath.Join(modsDir, mod.Filename)
-		if err := os.Remove(oldPath); err != nil && !os.IsNotExist(err) {
+		// Download new version; the old file is kept if it fails verification
+		file := latestVersion.Files[0]
+		if err := modInstaller.DownloadFile(ctx, file, filepath.Join(modsDir, file.Filename)); err != nil {
 			result.Status = "failed"
-			result.Reason = fmt.Sprintf("failed to remove old file: %v", err)
+			result.Reason = fmt.Sprintf("download failed: %v", err)
 			results = append(results, result)
 
 			if !jsonMode {
@@ -562,22 +578,27 @@ func updateMods(
 			continue
 		}
 
-		// Download new version
-		if err := modInstaller.DownloadFile(ctx, latestVersion.Files[0].URL, filepath.Join(modsDir, latestVersion.Files[0].Filename)); err != nil {
-			result.Status = "failed"
-			result.Reason = fmt.Sprintf("download failed: %v", err)
-			results = append(results, result)
-
-			if !jsonMode {
-				_, _ = fmt.Fprintf(stdout, "  ✗ %s: %s\n", mod.Slug, result.Reason)
+		// Remove old mod file
+		if mod.Filename != file.Filename {
+			oldPath := filepath.Join(modsDir, mod.Filename)
+			if err := os.Remove(oldPath); err != nil && !os.IsNotExist(err) {
+				result.Status = "failed"
+				result.Reason = fmt.Sprintf("failed to remove old file: %v", err)
+				results = append(results, result)
+
+				if !jsonMode {
+					_, _ = fmt.Fprintf(stdout, "  ✗ %s: %s\n", mod.Slug, result.Reason)
+				}
+				continue
 			}
-			continue
 		}
 
 		// Update mod metadata
 		mod.Version = latestVersion.VersionNumber
 		mod.VersionID = latestVersion.ID
-		mod.Filename = latestVersion.Files[0].Filename
+		mod.Filename = file.Filename
+		mod.SHA512 = file.Hashes.SHA512
+		mod.SizeBytes = file.Size
 
 		result.Status = "success"
 		result.NewVersion = latestVersion.VersionNumber
@@ -592,59 +613,6 @@ func updateMods(
 	return results, nil
 }
 
-// recreateContainer removes and recreates the container with updated configuration.
-func recreateContainer(ctx context.Context, serverState *state.ServerState, client container.Client) error {
-	// Remove old container (keep volumes!)
-	if serverState.ContainerID != "" {
-		opts := &container.RemoveOptions{
-			Force: true,
-		}
-		if err := client.RemoveContainer(ctx, serverState.ContainerID, opts); err != nil {
-			slog.Warn("failed to remove old container",
-				"container_id", serverState.ContainerID,
-				"error", err,
-			)
-		}
-	}
-
-	// Create new container with updated environment
-	config := &container.ContainerConfig{
-		Name:  serverState.Name,
-		Image: "itzg/minecraft-server:latest",
-		Env: map[string]string{
-			"EULA":                  "TRUE",
-			"TYPE":                  "FABRIC",
-			"VERSION":               serverState.Minecraft.Version,
-			"FABRIC_LOADER_VERSION": serverState.Minecraft.FabricLoaderVersion,
-			"MEMORY":                serverState.Minecraft.Memory,
-			"RCON_PASSWORD":         serverState.Minecraft.RconPassword,
-			"ENABLE_RCON":           "true",
-			"RCON_PORT":             fmt.Sprintf("%d", serverState.Minecraft.RconPort),
-		},
-		Ports: map[int]int{
-			serverState.Minecraft.GamePort: 25565,
-			serverState.Minecraft.RconPort: 25575,
-		},
-		Volumes: map[string]string{
-			serverState.Volumes.Data: "/data",
-		},
-		Labels: map[string]string{
-			"go-mc.managed":        "true",
-			"go-mc.server.name":    serverState.Name,
-			"go-mc.server.version": serverState.Minecraft.Version,
-			"go-mc.fabric.version": serverState.Minecraft.FabricLoaderVersion,
-		},
-	}
-
-	containerID, err := client.CreateContainer(ctx, config)
-	if err != nil {
-		return fmt.Errorf("failed to create container: %w", err)
-	}
-
-	serverState.ContainerID = containerID
-	return nil
-}
-
 // outputUpdateSummary outputs the update summary.
 func outputUpdateSummary(stdout io.Writer, summary *UpdateSummary, jsonMode bool) error {
 	if jsonMode {
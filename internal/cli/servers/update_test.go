@@ -498,3 +498,16 @@ func TestOutputUpdateError(t *testing.T) {
 		})
 	}
 }
+
+func TestApplyUpdateConfig(t *testing.T) {
+	cfg := state.DefaultConfig()
+	cfg.Backups.AutoBackupBeforeUpdate = false
+
+	flags := &UpdateFlags{Backup: true}
+	applyUpdateConfig(func(string) bool { return false }, flags, cfg)
+	assert.False(t, flags.Backup, "unset --backup should follow backups.auto_backup_before_update")
+
+	flags = &UpdateFlags{Backup: true}
+	applyUpdateConfig(func(name string) bool { return name == "backup" }, flags, cfg)
+	assert.True(t, flags.Backup, "explicit --backup should win over config")
+}
@@ -0,0 +1,158 @@
+package servers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steviee/go-mc/internal/container"
+	"github.com/steviee/go-mc/internal/lifecycle"
+	"github.com/steviee/go-mc/internal/state"
+)
+
+// WaitFlags holds all flags for the wait command
+type WaitFlags struct {
+	For     string
+	Timeout time.Duration
+}
+
+// TimingOutput reports how long the phases of a server start took, in seconds
+// from the start of the operation
+type TimingOutput struct {
+	ReadyBy            string  `json:"ready_by,omitempty"`
+	ContainerUpSeconds float64 `json:"container_up_seconds,omitempty"`
+	WorldLoadedSeconds float64 `json:"world_loaded_seconds,omitempty"`
+	ElapsedSeconds     float64 `json:"elapsed_seconds"`
+}
+
+// NewWaitCommand creates the servers wait subcommand
+func NewWaitCommand() *cobra.Command {
+	flags := &WaitFlags{}
+
+	cmd := &cobra.Command{
+		Use:   "wait <name>",
+		Short: "Wait until a Minecraft server is ready, healthy or stopped",
+		Long: `Wait until a Minecraft server reaches a condition, for use in scripts.
+
+Conditions:
+  ready     The world is loaded: the server answers a server list ping on its
+            game port, or logged its "Done (…)!" line
+  healthy   The server answers a server list ping on its game port
+  stopped   The container is no longer running
+
+Waiting for ready or healthy fails early if the server stops while it is waited
+for. The command exits with an error if the condition is not met before the timeout.`,
+		Example: `  # Wait until the server accepts players
+  go-mc servers wait myserver
+
+  # Wait for a server list ping to succeed
+  go-mc servers wait myserver --for healthy --timeout 2m
+
+  # Wait until the server has stopped
+  go-mc servers wait myserver --for stopped
+
+  # JSON output with phase timing
+  go-mc servers wait myserver --json`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runWait(cmd.Context(), cmd.OutOrStdout(), args[0], flags)
+		},
+	}
+
+	cmd.Flags().StringVar(&flags.For, "for", lifecycle.ConditionReady, "Condition to wait for: "+strings.Join(lifecycle.Conditions, ", "))
+	cmd.Flags().DurationVar(&flags.Timeout, "timeout", lifecycle.DefaultWaitTimeout, "How long to wait")
+
+	// Same name as the readiness timeout of start and restart
+	cmd.Flags().DurationVar(&flags.Timeout, "wait-timeout", lifecycle.DefaultWaitTimeout, "How long to wait (alias of --timeout)")
+
+	return cmd
+}
+
+// runWait executes the wait command
+func runWait(ctx context.Context, stdout io.Writer, name string, flags *WaitFlags) error {
+	jsonMode := isJSONMode()
+
+	serverState, err := loadServerForOperation(ctx, name)
+	if err != nil {
+		return outputLifecycleError(stdout, jsonMode, err)
+	}
+
+	client, err := createContainerClient(ctx)
+	if err != nil {
+		return outputLifecycleError(stdout, jsonMode, err)
+	}
+	defer func() { _ = client.Close() }()
+
+	result, err := waitForServer(ctx, client, serverState, flags.For, flags.Timeout, time.Time{})
+	if err != nil {
+		return outputLifecycleError(stdout, jsonMode, err)
+	}
+
+	return outputWaitResult(stdout, jsonMode, name, result)
+}
+
+// waitForServer waits up to timeout for a server to meet a condition. Phase
+// durations are measured from since, or from now when since is zero.
+func waitForServer(ctx context.Context, client container.Client, serverState *state.ServerState, condition string, timeout time.Duration, since time.Time) (*lifecycle.WaitResult, error) {
+	waitCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	return lifecycle.Wait(waitCtx, client, serverState, condition, lifecycle.WaitOptions{Since: since})
+}
+
+// outputWaitResult outputs the outcome of the wait command
+func outputWaitResult(stdout io.Writer, jsonMode bool, name string, result *lifecycle.WaitResult) error {
+	if jsonMode {
+		output := LifecycleOutput{
+			Status: "success",
+			Data: map[string]interface{}{
+				"server":    name,
+				"condition": result.Condition,
+				"timing":    newTimingOutput(result),
+			},
+		}
+
+		enc := json.NewEncoder(stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(output)
+	}
+
+	_, _ = fmt.Fprintf(stdout, "Server '%s' is %s (%s)\n", name, result.Condition, formatTiming(result))
+	return nil
+}
+
+// newTimingOutput converts a wait result for JSON output
+func newTimingOutput(result *lifecycle.WaitResult) TimingOutput {
+	return TimingOutput{
+		ReadyBy:            result.ReadyBy,
+		ContainerUpSeconds: seconds(result.ContainerUp),
+		WorldLoadedSeconds: seconds(result.WorldLoaded),
+		ElapsedSeconds:     seconds(result.Elapsed),
+	}
+}
+
+// formatTiming describes the phases of a wait result, e.g.
+// "container up after 0.8s, world loaded after 14.2s"
+func formatTiming(result *lifecycle.WaitResult) string {
+	if result.Condition == lifecycle.ConditionStopped {
+		return fmt.Sprintf("after %s", formatPhase(result.Elapsed))
+	}
+
+	return fmt.Sprintf("container up after %s, world loaded after %s",
+		formatPhase(result.ContainerUp), formatPhase(result.WorldLoaded))
+}
+
+// formatPhase formats a phase duration to a tenth of a second
+func formatPhase(d time.Duration) string {
+	return d.Round(100 * time.Millisecond).String()
+}
+
+// seconds converts a duration to seconds with millisecond precision
+func seconds(d time.Duration) float64 {
+	return math.Round(d.Seconds()*1000) / 1000
+}
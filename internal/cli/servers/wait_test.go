@@ -0,0 +1,127 @@
+package servers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/steviee/go-mc/internal/lifecycle"
+	"github.com/steviee/go-mc/internal/state"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewWaitCommand(t *testing.T) {
+	cmd := NewWaitCommand()
+
+	assert.Equal(t, "wait <name>", cmd.Use)
+	assert.NotEmpty(t, cmd.Short)
+	assert.NotEmpty(t, cmd.Example)
+
+	require.NoError(t, cmd.ParseFlags([]string{}))
+	forFlag, err := cmd.Flags().GetString("for")
+	require.NoError(t, err)
+	assert.Equal(t, lifecycle.ConditionReady, forFlag)
+
+	timeout, err := cmd.Flags().GetDuration("timeout")
+	require.NoError(t, err)
+	assert.Equal(t, lifecycle.DefaultWaitTimeout, timeout)
+
+	// --wait-timeout is an alias of --timeout
+	require.NoError(t, cmd.ParseFlags([]string{"--timeout", "2m"}))
+	timeout, err = cmd.Flags().GetDuration("timeout")
+	require.NoError(t, err)
+	assert.Equal(t, 2*time.Minute, timeout)
+
+	require.NoError(t, cmd.ParseFlags([]string{"--wait-timeout", "3m"}))
+	timeout, err = cmd.Flags().GetDuration("timeout")
+	require.NoError(t, err)
+	assert.Equal(t, 3*time.Minute, timeout)
+}
+
+func TestOutputWaitResult(t *testing.T) {
+	ready := &lifecycle.WaitResult{
+		Condition:   lifecycle.ConditionReady,
+		ReadyBy:     lifecycle.ReadyByPing,
+		ContainerUp: 1234567 * time.Microsecond,
+		WorldLoaded: 15 * time.Second,
+		Elapsed:     15 * time.Second,
+	}
+
+	t.Run("human", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, outputWaitResult(&buf, false, "survival", ready))
+		assert.Equal(t, "Server 'survival' is ready (container up after 1.2s, world loaded after 15s)\n", buf.String())
+
+		buf.Reset()
+		stopped := &lifecycle.WaitResult{Condition: lifecycle.ConditionStopped, Elapsed: 3 * time.Second}
+		require.NoError(t, outputWaitResult(&buf, false, "survival", stopped))
+		assert.Equal(t, "Server 'survival' is stopped (after 3s)\n", buf.String())
+	})
+
+	t.Run("json", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, outputWaitResult(&buf, true, "survival", ready))
+
+		var output struct {
+			Status string `json:"status"`
+			Data   struct {
+				Server    string       `json:"server"`
+				Condition string       `json:"condition"`
+				Timing    TimingOutput `json:"timing"`
+			} `json:"data"`
+		}
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &output))
+
+		assert.Equal(t, "success", output.Status)
+		assert.Equal(t, "survival", output.Data.Server)
+		assert.Equal(t, "ready", output.Data.Condition)
+		assert.Equal(t, TimingOutput{
+			ReadyBy:            "ping",
+			ContainerUpSeconds: 1.235,
+			WorldLoadedSeconds: 15,
+			ElapsedSeconds:     15,
+		}, output.Data.Timing)
+	})
+}
+
+func TestOutputOperationJSON_Timing(t *testing.T) {
+	result := NewOperationResult()
+	result.Success = []string{"survival", "creative"}
+	result.Timing["survival"] = &lifecycle.WaitResult{
+		Condition:   lifecycle.ConditionReady,
+		ReadyBy:     lifecycle.ReadyByLog,
+		ContainerUp: time.Second,
+		WorldLoaded: 20 * time.Second,
+		Elapsed:     20 * time.Second,
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, outputOperationJSON(&buf, "started", result))
+
+	var output struct {
+		Data struct {
+			Timing map[string]TimingOutput `json:"timing"`
+		} `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &output))
+
+	require.Len(t, output.Data.Timing, 1)
+	assert.Equal(t, TimingOutput{
+		ReadyBy:            "log",
+		ContainerUpSeconds: 1,
+		WorldLoadedSeconds: 20,
+		ElapsedSeconds:     20,
+	}, output.Data.Timing["survival"])
+}
+
+func TestRunWait_NoContainer(t *testing.T) {
+	setupTestStateDir(t, t.TempDir())
+	require.NoError(t, state.SaveServerState(context.Background(), state.NewServerState("fresh")))
+
+	var stdout bytes.Buffer
+	err := runWait(context.Background(), &stdout, "fresh", &WaitFlags{For: lifecycle.ConditionReady, Timeout: time.Second})
+	assert.ErrorContains(t, err, "has no container")
+}
@@ -13,8 +13,9 @@ import (
 	"strings"
 
 	"github.com/spf13/cobra"
-	"github.com/steviee/go-mc/internal/container"
+	"github.com/steviee/go-mc/internal/server"
 	"github.com/steviee/go-mc/internal/state"
+	"github.com/steviee/go-mc/internal/systemd"
 )
 
 const (
@@ -177,7 +178,7 @@ func runSetup(cmd *cobra.Command, args []string) error {
 	if !jsonMode {
 		_, _ = fmt.Fprintln(stdout, "Configuring Podman for rootless operation...")
 	}
-	if err := configurePodmanRootless(stdout, stderr); err != nil {
+	if err := configurePodmanRootless(ctx, stdout, stderr); err != nil {
 		return outputError(stdout, jsonMode, fmt.Errorf("failed to configure Podman: %w", err))
 	}
 	if !jsonMode {
@@ -389,7 +390,7 @@ func installDependencies(stdout, stderr io.Writer, deps []Dependency, nonInterac
 }
 
 // configurePodmanRootless configures Podman for rootless operation
-func configurePodmanRootless(stdout, stderr io.Writer) error {
+func configurePodmanRootless(ctx context.Context, stdout, stderr io.Writer) error {
 	currentUser := os.Getenv("USER")
 	if currentUser == "" {
 		return fmt.Errorf("USER environment variable not set")
@@ -406,6 +407,17 @@ func configurePodmanRootless(stdout, stderr io.Writer) error {
 		_, _ = fmt.Fprintf(stderr, "  Warning: Failed to enable lingering (non-fatal): %v\n", err)
 	}
 
+	// Servers with autostart only start on boot with lingering
+	lingering, err := systemd.Lingering(ctx)
+	switch {
+	case err != nil:
+		slog.Warn("failed to check user lingering", "error", err)
+	case lingering:
+		_, _ = fmt.Fprintln(stdout, "  ✓ Lingering enabled (servers with autostart start on boot)")
+	default:
+		_, _ = fmt.Fprintf(stderr, "  Warning: Lingering is disabled; servers with autostart only start once %s logs in\n", currentUser)
+	}
+
 	// Start and enable podman.socket
 	_, _ = fmt.Fprintln(stdout, "  Starting Podman socket...")
 	cmd = exec.Command("systemctl", "--user", "enable", "--now", "podman.socket")
@@ -576,7 +588,7 @@ func initializeGlobalState(ctx context.Context) error {
 // pullContainerImage pulls the default container image
 func pullContainerImage(ctx context.Context, stdout, stderr io.Writer, image string) error {
 	// Try using the container client first (more reliable)
-	client, err := container.NewClient(ctx, container.DefaultConfig())
+	client, err := server.ConnectContainerRuntime(ctx)
 	if err != nil {
 		// Fallback to podman command if client fails
 		slog.Debug("container client not available, using podman command", "error", err)
@@ -350,7 +350,7 @@ func TestGenerateDefaultConfig(t *testing.T) {
 		cfg, err := state.LoadConfig(ctx)
 		require.NoError(t, err)
 		assert.NotNil(t, cfg)
-		assert.Equal(t, "podman", cfg.Container.Runtime)
+		assert.Equal(t, "auto", cfg.Container.Runtime)
 		assert.Equal(t, "2G", cfg.Defaults.Memory)
 	})
 
@@ -7,6 +7,7 @@ import (
 	"io"
 
 	"github.com/spf13/cobra"
+	"github.com/steviee/go-mc/internal/access"
 	"github.com/steviee/go-mc/internal/mojang"
 	"github.com/steviee/go-mc/internal/state"
 )
@@ -103,15 +104,21 @@ func runAdd(ctx context.Context, w io.Writer, usernames []string, jsonOutput boo
 		addedUsers = append(addedUsers, player)
 	}
 
+	// Write the changed whitelist to the servers that use it
+	var synced []access.WhitelistSyncResult
+	if len(addedUsers) > 0 {
+		synced = syncServers(ctx, whitelistName)
+	}
+
 	// Output results
 	if jsonOutput {
-		return outputAddJSON(w, whitelistName, addedUsers, errors)
+		return outputAddJSON(w, whitelistName, addedUsers, errors, synced)
 	}
 
-	return outputAddHuman(w, whitelistName, addedUsers, errors)
+	return outputAddHuman(w, whitelistName, addedUsers, errors, synced)
 }
 
-func outputAddJSON(w io.Writer, whitelistName string, added []state.PlayerInfo, errors map[string]string) error {
+func outputAddJSON(w io.Writer, whitelistName string, added []state.PlayerInfo, errors map[string]string, synced []access.WhitelistSyncResult) error {
 	data := map[string]interface{}{
 		"whitelist": whitelistName,
 		"added":     added,
@@ -121,6 +128,10 @@ func outputAddJSON(w io.Writer, whitelistName string, added []state.PlayerInfo,
 		data["errors"] = errors
 	}
 
+	if len(synced) > 0 {
+		data["synced"] = synced
+	}
+
 	status := "success"
 	if len(added) == 0 {
 		status = "error"
@@ -137,7 +148,7 @@ func outputAddJSON(w io.Writer, whitelistName string, added []state.PlayerInfo,
 	return enc.Encode(out)
 }
 
-func outputAddHuman(w io.Writer, whitelistName string, added []state.PlayerInfo, errors map[string]string) error {
+func outputAddHuman(w io.Writer, whitelistName string, added []state.PlayerInfo, errors map[string]string, synced []access.WhitelistSyncResult) error {
 	if len(added) > 0 {
 		_, _ = fmt.Fprintf(w, "Added %d user(s) to whitelist %q:\n", len(added), whitelistName)
 		for _, player := range added {
@@ -152,6 +163,8 @@ func outputAddHuman(w io.Writer, whitelistName string, added []state.PlayerInfo,
 		}
 	}
 
+	outputSyncHuman(w, synced)
+
 	if len(added) == 0 && len(errors) > 0 {
 		return fmt.Errorf("failed to add any users")
 	}
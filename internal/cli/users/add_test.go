@@ -131,7 +131,7 @@ func TestOutputAddJSON(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			var buf bytes.Buffer
 
-			err := outputAddJSON(&buf, tt.whitelistName, tt.added, tt.errors)
+			err := outputAddJSON(&buf, tt.whitelistName, tt.added, tt.errors, nil)
 			require.NoError(t, err)
 
 			var out Output
@@ -182,7 +182,7 @@ func TestOutputAddHuman(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			var buf bytes.Buffer
 
-			err := outputAddHuman(&buf, tt.whitelistName, tt.added, tt.errors)
+			err := outputAddHuman(&buf, tt.whitelistName, tt.added, tt.errors, nil)
 
 			if tt.wantErr {
 				require.Error(t, err)
@@ -0,0 +1,349 @@
+package users
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"strconv"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steviee/go-mc/internal/access"
+	"github.com/steviee/go-mc/internal/state"
+)
+
+// banEntry is the JSON representation of a player or IP ban.
+type banEntry struct {
+	Scope   string     `json:"scope"`
+	UUID    string     `json:"uuid,omitempty"`
+	Name    string     `json:"name,omitempty"`
+	IP      string     `json:"ip,omitempty"`
+	Reason  string     `json:"reason,omitempty"`
+	Source  string     `json:"source"`
+	Created time.Time  `json:"created"`
+	Expires *time.Time `json:"expires,omitempty"`
+}
+
+// NewBanCommand creates the users ban command.
+func NewBanCommand() *cobra.Command {
+	var (
+		jsonOutput bool
+		reason     string
+		expires    string
+		global     bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "ban <server> <player>",
+		Short: "Ban a player",
+		Long: `Ban a player from a server, or from all servers with --global.
+
+The UUID is resolved via Mojang API. The ban is saved to go-mc state and
+written to the server's banned-players.json. Running servers ban (and kick)
+the player live over RCON.
+
+Temporary bans (--expires) are lifted automatically once they expire, by
+'go-mc watch', 'go-mc servers start' and every ban command.
+Banning a player again replaces the previous reason and expiry.`,
+		Example: `  # Ban a player from a server
+  go-mc users ban survival griefer --reason "Griefing spawn"
+
+  # Ban a player for a week
+  go-mc users ban survival griefer --expires 7d
+
+  # Ban a player from all servers
+  go-mc users ban --global griefer`,
+		Args: cobra.RangeArgs(1, 2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			scope, player, err := banScopeArgs(args, global)
+			if err != nil {
+				return outputError(cmd.OutOrStdout(), jsonOutput, err)
+			}
+			duration, err := parseBanDuration(expires)
+			if err != nil {
+				return outputError(cmd.OutOrStdout(), jsonOutput, err)
+			}
+			return runBan(cmd.Context(), cmd.OutOrStdout(), scope, player, reason, duration, jsonOutput)
+		},
+	}
+
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output in JSON format")
+	cmd.Flags().StringVar(&reason, "reason", "", "Reason shown to the player")
+	cmd.Flags().StringVar(&expires, "expires", "", "Ban duration, e.g. 30m, 24h, 7d or 2w (default: permanent)")
+	cmd.Flags().BoolVar(&global, "global", false, "Ban on all servers")
+
+	return cmd
+}
+
+// NewUnbanCommand creates the users unban command.
+func NewUnbanCommand() *cobra.Command {
+	var (
+		jsonOutput bool
+		global     bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "unban <server> <player>",
+		Short: "Unban a player",
+		Long: `Lift the ban of a player on a server, or a global ban with --global.
+
+The ban is removed from go-mc state and banned-players.json. Running
+servers pardon the player live over RCON.`,
+		Example: `  # Unban a player on a server
+  go-mc users unban survival griefer
+
+  # Lift a global ban
+  go-mc users unban --global griefer`,
+		Args:    cobra.RangeArgs(1, 2),
+		Aliases: []string{"pardon"},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			scope, player, err := banScopeArgs(args, global)
+			if err != nil {
+				return outputError(cmd.OutOrStdout(), jsonOutput, err)
+			}
+			return runUnban(cmd.Context(), cmd.OutOrStdout(), scope, player, jsonOutput)
+		},
+	}
+
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output in JSON format")
+	cmd.Flags().BoolVar(&global, "global", false, "Lift a global ban")
+
+	return cmd
+}
+
+func runBan(ctx context.Context, w io.Writer, scope, player, reason string, duration time.Duration, jsonOutput bool) error {
+	if err := checkBanScope(ctx, scope); err != nil {
+		return outputError(w, jsonOutput, err)
+	}
+
+	profile, err := newResolver().GetUUID(ctx, player)
+	if err != nil {
+		return outputError(w, jsonOutput, fmt.Errorf("failed to resolve player %q: %w", player, err))
+	}
+
+	ban := state.PlayerBan{
+		UUID: profile.UUID,
+		Name: profile.Username,
+		BanInfo: state.BanInfo{
+			Reason:  reason,
+			Source:  access.BanSource,
+			Created: time.Now(),
+		},
+	}
+	if duration > 0 {
+		until := ban.Created.Add(duration)
+		ban.Expires = &until
+	}
+
+	if err := state.AddPlayerBan(ctx, scope, ban); err != nil {
+		return outputError(w, jsonOutput, err)
+	}
+
+	results := syncBans(ctx, scope)
+
+	message := fmt.Sprintf("Banned %s %s%s", ban.Name, scopeText(scope), untilText(ban.BanInfo))
+	return outputBanChange(w, jsonOutput, message, playerBanEntry(scope, ban), results)
+}
+
+func runUnban(ctx context.Context, w io.Writer, scope, player string, jsonOutput bool) error {
+	if err := checkBanScope(ctx, scope); err != nil {
+		return outputError(w, jsonOutput, err)
+	}
+
+	removed, err := state.RemovePlayerBan(ctx, scope, player)
+	if err != nil {
+		if scope != state.GlobalBanScope && bannedGlobally(ctx, player, "") {
+			err = fmt.Errorf("%s is banned on all servers; use --global to lift the ban", player)
+		} else {
+			err = fmt.Errorf("%s is not banned %s", player, scopeText(scope))
+		}
+		return outputError(w, jsonOutput, err)
+	}
+
+	results := syncBans(ctx, scope, removed.UUID)
+
+	message := fmt.Sprintf("Unbanned %s %s", removed.Name, scopeText(scope))
+	return outputBanChange(w, jsonOutput, message, playerBanEntry(scope, *removed), results)
+}
+
+// banScopeArgs returns the ban scope and target from the arguments of a ban
+// command: "<server> <target>", or only "<target>" with --global.
+func banScopeArgs(args []string, global bool) (string, string, error) {
+	if global {
+		if len(args) != 1 {
+			return "", "", fmt.Errorf("--global takes no server argument")
+		}
+		return state.GlobalBanScope, args[0], nil
+	}
+
+	if len(args) != 2 {
+		return "", "", fmt.Errorf("requires a server and a target, or --global")
+	}
+	return args[0], args[1], nil
+}
+
+// checkBanScope makes sure the server of a scope exists and lifts expired
+// bans, so the change starts from up-to-date ban files.
+func checkBanScope(ctx context.Context, scope string) error {
+	if scope != state.GlobalBanScope {
+		if _, err := loadServer(ctx, scope); err != nil {
+			return err
+		}
+	}
+
+	if _, err := access.LiftExpiredBans(ctx); err != nil {
+		slog.Warn("failed to lift expired bans", "error", err)
+	}
+
+	return nil
+}
+
+// bannedGlobally reports whether a player (by name or UUID) or an IP is
+// banned on all servers.
+func bannedGlobally(ctx context.Context, player, ip string) bool {
+	list, err := state.LoadBanList(ctx, state.GlobalBanScope)
+	if err != nil {
+		return false
+	}
+	if player != "" {
+		_, ok := list.FindPlayer(player)
+		return ok
+	}
+	_, ok := list.FindIP(ip)
+	return ok
+}
+
+// syncBans writes changed bans to the servers of a scope. Sync problems are
+// reported but never fail the ban change itself.
+func syncBans(ctx context.Context, scope string, unbanned ...string) []access.BanSyncResult {
+	results, err := access.SyncBanServers(ctx, scope, unbanned...)
+	if err != nil {
+		slog.Warn("failed to sync bans to servers", "scope", scope, "error", err)
+		return nil
+	}
+	return results
+}
+
+// scopeText describes a ban scope for messages.
+func scopeText(scope string) string {
+	if scope == state.GlobalBanScope {
+		return "on all servers"
+	}
+	return fmt.Sprintf("on server %q", scope)
+}
+
+// untilText describes when a temporary ban expires for messages.
+func untilText(info state.BanInfo) string {
+	if info.Expires == nil {
+		return ""
+	}
+	return " until " + info.Expires.Local().Format("2006-01-02 15:04")
+}
+
+// parseBanDuration parses a ban duration. Besides Go durations ("30m",
+// "24h") it accepts days ("7d") and weeks ("2w"). An empty string means a
+// permanent ban.
+func parseBanDuration(s string) (time.Duration, error) {
+	if s == "" {
+		return 0, nil
+	}
+
+	var unit time.Duration
+	switch s[len(s)-1] {
+	case 'd':
+		unit = 24 * time.Hour
+	case 'w':
+		unit = 7 * 24 * time.Hour
+	}
+
+	var duration time.Duration
+	if unit > 0 {
+		n, err := strconv.Atoi(s[:len(s)-1])
+		if err != nil {
+			return 0, fmt.Errorf("invalid ban duration %q", s)
+		}
+		duration = time.Duration(n) * unit
+	} else {
+		d, err := time.ParseDuration(s)
+		if err != nil {
+			return 0, fmt.Errorf("invalid ban duration %q (use e.g. 30m, 24h, 7d or 2w)", s)
+		}
+		duration = d
+	}
+
+	if duration <= 0 {
+		return 0, fmt.Errorf("ban duration must be positive, got %q", s)
+	}
+
+	return duration, nil
+}
+
+// playerBanEntry converts a player ban for output.
+func playerBanEntry(scope string, ban state.PlayerBan) banEntry {
+	return banEntry{
+		Scope:   scope,
+		UUID:    ban.UUID,
+		Name:    ban.Name,
+		Reason:  ban.Reason,
+		Source:  ban.Source,
+		Created: ban.Created,
+		Expires: ban.Expires,
+	}
+}
+
+// ipBanEntry converts an IP ban for output.
+func ipBanEntry(scope string, ban state.IPBan) banEntry {
+	return banEntry{
+		Scope:   scope,
+		IP:      ban.IP,
+		Reason:  ban.Reason,
+		Source:  ban.Source,
+		Created: ban.Created,
+		Expires: ban.Expires,
+	}
+}
+
+func outputBanChange(w io.Writer, jsonOutput bool, message string, ban banEntry, results []access.BanSyncResult) error {
+	if jsonOutput {
+		out := Output{
+			Status: "success",
+			Data: map[string]interface{}{
+				"ban":     ban,
+				"servers": results,
+			},
+			Message: message,
+		}
+
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(out)
+	}
+
+	_, _ = fmt.Fprintln(w, message)
+	outputBanSyncHuman(w, results)
+
+	return nil
+}
+
+// outputBanSyncHuman prints where a ban change was applied.
+func outputBanSyncHuman(w io.Writer, results []access.BanSyncResult) {
+	if len(results) == 0 {
+		return
+	}
+
+	_, _ = fmt.Fprintf(w, "\nSynced %d server(s):\n", len(results))
+	for _, result := range results {
+		switch {
+		case result.Error != "":
+			_, _ = fmt.Fprintf(w, "  - %s: sync failed: %s\n", result.Server, result.Error)
+		case result.Live:
+			_, _ = fmt.Fprintf(w, "  - %s: applied live\n", result.Server)
+		case result.Warning != "":
+			_, _ = fmt.Fprintf(w, "  - %s: %s\n", result.Server, result.Warning)
+		default:
+			_, _ = fmt.Fprintf(w, "  - %s: applies on next start\n", result.Server)
+		}
+	}
+}
@@ -0,0 +1,170 @@
+package users
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/steviee/go-mc/internal/access"
+	"github.com/steviee/go-mc/internal/state"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewBanCommand(t *testing.T) {
+	cmd := NewBanCommand()
+
+	assert.Equal(t, "ban <server> <player>", cmd.Use)
+	assert.NotEmpty(t, cmd.Short)
+	assert.NotEmpty(t, cmd.Long)
+	assert.NotNil(t, cmd.Flags().Lookup("reason"))
+	assert.NotNil(t, cmd.Flags().Lookup("expires"))
+	assert.NotNil(t, cmd.Flags().Lookup("global"))
+	assert.NotNil(t, cmd.Flags().Lookup("json"))
+}
+
+func TestBanScopeArgs(t *testing.T) {
+	tests := []struct {
+		name       string
+		args       []string
+		global     bool
+		wantScope  string
+		wantTarget string
+		wantErr    string
+	}{
+		{name: "server", args: []string{"survival", "griefer"}, wantScope: "survival", wantTarget: "griefer"},
+		{name: "global", args: []string{"griefer"}, global: true, wantScope: state.GlobalBanScope, wantTarget: "griefer"},
+		{name: "missing server", args: []string{"griefer"}, wantErr: "requires a server"},
+		{name: "global with server", args: []string{"survival", "griefer"}, global: true, wantErr: "no server argument"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scope, target, err := banScopeArgs(tt.args, tt.global)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantScope, scope)
+			assert.Equal(t, tt.wantTarget, target)
+		})
+	}
+}
+
+func TestParseBanDuration(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    time.Duration
+		wantErr bool
+	}{
+		{input: "", want: 0},
+		{input: "30m", want: 30 * time.Minute},
+		{input: "24h", want: 24 * time.Hour},
+		{input: "7d", want: 7 * 24 * time.Hour},
+		{input: "2w", want: 14 * 24 * time.Hour},
+		{input: "0d", wantErr: true},
+		{input: "-1h", wantErr: true},
+		{input: "xd", wantErr: true},
+		{input: "soon", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := parseBanDuration(tt.input)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestRunBan(t *testing.T) {
+	ctx := context.Background()
+	serverState := setupOpServer(t)
+
+	var buf bytes.Buffer
+	require.NoError(t, runBan(ctx, &buf, "survival", "notch", "griefing", 7*24*time.Hour, false))
+	assert.Contains(t, buf.String(), `Banned Notch on server "survival" until`)
+	assert.Contains(t, buf.String(), "survival: applies on next start")
+
+	list, err := state.LoadBanList(ctx, "survival")
+	require.NoError(t, err)
+	require.Len(t, list.Players, 1)
+	assert.Equal(t, "griefing", list.Players[0].Reason)
+	assert.Equal(t, access.BanSource, list.Players[0].Source)
+	require.NotNil(t, list.Players[0].Expires)
+
+	players, _, err := access.ReadBanFiles(serverState)
+	require.NoError(t, err)
+	require.Len(t, players, 1)
+	assert.Equal(t, "Notch", players[0].Name)
+	assert.NotEqual(t, "forever", players[0].Expires)
+
+	// Global bans reach every server
+	buf.Reset()
+	require.NoError(t, runBan(ctx, &buf, state.GlobalBanScope, "notch", "", 0, true))
+
+	var out Output
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &out))
+	assert.Equal(t, "success", out.Status)
+	assert.Equal(t, "Banned Notch on all servers", out.Message)
+}
+
+func TestRunBan_Errors(t *testing.T) {
+	ctx := context.Background()
+	setupOpServer(t)
+
+	tests := []struct {
+		name    string
+		server  string
+		player  string
+		wantErr string
+	}{
+		{name: "unknown player", server: "survival", player: "nobody", wantErr: "failed to resolve"},
+		{name: "unknown server", server: "missing", player: "notch", wantErr: "does not exist"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			err := runBan(ctx, &buf, tt.server, tt.player, "", 0, false)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestRunUnban(t *testing.T) {
+	ctx := context.Background()
+	serverState := setupOpServer(t)
+
+	var buf bytes.Buffer
+	require.NoError(t, runBan(ctx, &buf, "survival", "Notch", "", 0, false))
+
+	buf.Reset()
+	require.NoError(t, runUnban(ctx, &buf, "survival", "notch", false))
+	assert.Contains(t, buf.String(), `Unbanned Notch on server "survival"`)
+
+	players, _, err := access.ReadBanFiles(serverState)
+	require.NoError(t, err)
+	assert.Empty(t, players)
+
+	err = runUnban(ctx, &buf, "survival", "notch", false)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "is not banned")
+
+	// A global ban cannot be lifted per server
+	require.NoError(t, runBan(ctx, &buf, state.GlobalBanScope, "Notch", "", 0, false))
+	err = runUnban(ctx, &buf, "survival", "notch", false)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "use --global")
+
+	require.NoError(t, runUnban(ctx, &buf, state.GlobalBanScope, "notch", false))
+}
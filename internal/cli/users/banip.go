@@ -0,0 +1,151 @@
+package users
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steviee/go-mc/internal/access"
+	"github.com/steviee/go-mc/internal/state"
+)
+
+// NewBanIPCommand creates the users ban-ip command.
+func NewBanIPCommand() *cobra.Command {
+	var (
+		jsonOutput bool
+		reason     string
+		expires    string
+		global     bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "ban-ip <server> <ip>",
+		Short: "Ban an IP address",
+		Long: `Ban an IP address from a server, or from all servers with --global.
+
+The ban is saved to go-mc state and written to the server's banned-ips.json.
+Running servers ban the address live over RCON, which also kicks players
+connected from it.
+
+Temporary bans (--expires) are lifted automatically once they expire.`,
+		Example: `  # Ban an address from a server
+  go-mc users ban-ip survival 203.0.113.7 --reason "Bot traffic"
+
+  # Ban an address from all servers for a day
+  go-mc users ban-ip --global 203.0.113.7 --expires 24h`,
+		Args: cobra.RangeArgs(1, 2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			scope, ip, err := banScopeArgs(args, global)
+			if err != nil {
+				return outputError(cmd.OutOrStdout(), jsonOutput, err)
+			}
+			duration, err := parseBanDuration(expires)
+			if err != nil {
+				return outputError(cmd.OutOrStdout(), jsonOutput, err)
+			}
+			return runBanIP(cmd.Context(), cmd.OutOrStdout(), scope, ip, reason, duration, jsonOutput)
+		},
+	}
+
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output in JSON format")
+	cmd.Flags().StringVar(&reason, "reason", "", "Reason shown to the player")
+	cmd.Flags().StringVar(&expires, "expires", "", "Ban duration, e.g. 30m, 24h, 7d or 2w (default: permanent)")
+	cmd.Flags().BoolVar(&global, "global", false, "Ban on all servers")
+
+	return cmd
+}
+
+// NewUnbanIPCommand creates the users unban-ip command.
+func NewUnbanIPCommand() *cobra.Command {
+	var (
+		jsonOutput bool
+		global     bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "unban-ip <server> <ip>",
+		Short: "Unban an IP address",
+		Long: `Lift the ban of an IP address on a server, or a global ban with --global.
+
+The ban is removed from go-mc state and banned-ips.json. Running servers
+pardon the address live over RCON.`,
+		Example: `  # Unban an address on a server
+  go-mc users unban-ip survival 203.0.113.7
+
+  # Lift a global ban
+  go-mc users unban-ip --global 203.0.113.7`,
+		Args:    cobra.RangeArgs(1, 2),
+		Aliases: []string{"pardon-ip"},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			scope, ip, err := banScopeArgs(args, global)
+			if err != nil {
+				return outputError(cmd.OutOrStdout(), jsonOutput, err)
+			}
+			return runUnbanIP(cmd.Context(), cmd.OutOrStdout(), scope, ip, jsonOutput)
+		},
+	}
+
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output in JSON format")
+	cmd.Flags().BoolVar(&global, "global", false, "Lift a global ban")
+
+	return cmd
+}
+
+func runBanIP(ctx context.Context, w io.Writer, scope, ip, reason string, duration time.Duration, jsonOutput bool) error {
+	if err := state.ValidateIP(ip); err != nil {
+		return outputError(w, jsonOutput, err)
+	}
+
+	if err := checkBanScope(ctx, scope); err != nil {
+		return outputError(w, jsonOutput, err)
+	}
+
+	ban := state.IPBan{
+		IP: ip,
+		BanInfo: state.BanInfo{
+			Reason:  reason,
+			Source:  access.BanSource,
+			Created: time.Now(),
+		},
+	}
+	if duration > 0 {
+		until := ban.Created.Add(duration)
+		ban.Expires = &until
+	}
+
+	if err := state.AddIPBan(ctx, scope, ban); err != nil {
+		return outputError(w, jsonOutput, err)
+	}
+
+	results := syncBans(ctx, scope)
+
+	message := fmt.Sprintf("Banned %s %s%s", ban.IP, scopeText(scope), untilText(ban.BanInfo))
+	return outputBanChange(w, jsonOutput, message, ipBanEntry(scope, ban), results)
+}
+
+func runUnbanIP(ctx context.Context, w io.Writer, scope, ip string, jsonOutput bool) error {
+	if err := state.ValidateIP(ip); err != nil {
+		return outputError(w, jsonOutput, err)
+	}
+
+	if err := checkBanScope(ctx, scope); err != nil {
+		return outputError(w, jsonOutput, err)
+	}
+
+	removed, err := state.RemoveIPBan(ctx, scope, ip)
+	if err != nil {
+		if scope != state.GlobalBanScope && bannedGlobally(ctx, "", ip) {
+			err = fmt.Errorf("%s is banned on all servers; use --global to lift the ban", ip)
+		} else {
+			err = fmt.Errorf("%s is not banned %s", ip, scopeText(scope))
+		}
+		return outputError(w, jsonOutput, err)
+	}
+
+	results := syncBans(ctx, scope, removed.IP)
+
+	message := fmt.Sprintf("Unbanned %s %s", removed.IP, scopeText(scope))
+	return outputBanChange(w, jsonOutput, message, ipBanEntry(scope, *removed), results)
+}
@@ -0,0 +1,72 @@
+package users
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/steviee/go-mc/internal/access"
+	"github.com/steviee/go-mc/internal/rcon/rcontest"
+	"github.com/steviee/go-mc/internal/state"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewBanIPCommand(t *testing.T) {
+	cmd := NewBanIPCommand()
+
+	assert.Equal(t, "ban-ip <server> <ip>", cmd.Use)
+	assert.NotNil(t, cmd.Flags().Lookup("reason"))
+	assert.NotNil(t, cmd.Flags().Lookup("expires"))
+	assert.NotNil(t, cmd.Flags().Lookup("global"))
+
+	cmd = NewUnbanIPCommand()
+	assert.Equal(t, "unban-ip <server> <ip>", cmd.Use)
+	assert.Contains(t, cmd.Aliases, "pardon-ip")
+}
+
+func TestRunBanIP(t *testing.T) {
+	ctx := context.Background()
+	serverState := setupOpServer(t)
+
+	srv := rcontest.NewServer(t, "secret", func(command string) string { return "" })
+	serverState.Status = state.StatusRunning
+	serverState.Minecraft.RconPort = srv.Port()
+	serverState.Minecraft.RconPassword = "secret"
+	require.NoError(t, state.SaveServerState(ctx, serverState))
+
+	var buf bytes.Buffer
+	require.NoError(t, runBanIP(ctx, &buf, "survival", "203.0.113.7", "bots", time.Hour, false))
+	assert.Contains(t, buf.String(), `Banned 203.0.113.7 on server "survival" until`)
+	assert.Contains(t, buf.String(), "survival: applied live")
+	assert.Equal(t, []string{"ban-ip 203.0.113.7 bots"}, srv.Commands())
+
+	_, ips, err := access.ReadBanFiles(serverState)
+	require.NoError(t, err)
+	require.Len(t, ips, 1)
+	assert.Equal(t, "bots", ips[0].Reason)
+
+	buf.Reset()
+	require.NoError(t, runUnbanIP(ctx, &buf, "survival", "203.0.113.7", false))
+	assert.Contains(t, buf.String(), `Unbanned 203.0.113.7 on server "survival"`)
+	assert.Equal(t, "pardon-ip 203.0.113.7", srv.Commands()[1])
+
+	_, ips, err = access.ReadBanFiles(serverState)
+	require.NoError(t, err)
+	assert.Empty(t, ips)
+}
+
+func TestRunBanIP_InvalidAddress(t *testing.T) {
+	ctx := context.Background()
+	setupOpServer(t)
+
+	var buf bytes.Buffer
+	err := runBanIP(ctx, &buf, "survival", "example.com", "", 0, false)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid IP address")
+
+	err = runUnbanIP(ctx, &buf, "survival", "203.0.113.7", false)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "is not banned")
+}
@@ -0,0 +1,122 @@
+package users
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+	"github.com/steviee/go-mc/internal/state"
+)
+
+// NewBansCommand creates the users bans command.
+func NewBansCommand() *cobra.Command {
+	var jsonOutput bool
+
+	cmd := &cobra.Command{
+		Use:   "bans [server]",
+		Short: "List banned players and IP addresses",
+		Long: `List active bans.
+
+Without a server, the global bans that apply to all servers are listed.
+With a server, its own bans are listed together with the global bans.
+Expired temporary bans are lifted before listing.`,
+		Example: `  # List global bans
+  go-mc users bans
+
+  # List all bans that apply to a server
+  go-mc users bans survival
+
+  # JSON output
+  go-mc users bans survival --json`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			scope := state.GlobalBanScope
+			if len(args) > 0 {
+				scope = args[0]
+			}
+			return runBans(cmd.Context(), cmd.OutOrStdout(), scope, jsonOutput)
+		},
+	}
+
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output in JSON format")
+
+	return cmd
+}
+
+func runBans(ctx context.Context, w io.Writer, scope string, jsonOutput bool) error {
+	if err := checkBanScope(ctx, scope); err != nil {
+		return outputError(w, jsonOutput, err)
+	}
+
+	scopes := []string{state.GlobalBanScope}
+	if scope != state.GlobalBanScope {
+		scopes = append(scopes, scope)
+	}
+
+	players := []banEntry{}
+	ips := []banEntry{}
+	for _, s := range scopes {
+		list, err := state.LoadBanList(ctx, s)
+		if err != nil {
+			return outputError(w, jsonOutput, err)
+		}
+		for _, ban := range list.Players {
+			players = append(players, playerBanEntry(s, ban))
+		}
+		for _, ban := range list.IPs {
+			ips = append(ips, ipBanEntry(s, ban))
+		}
+	}
+
+	title := "Global bans"
+	if scope != state.GlobalBanScope {
+		title = fmt.Sprintf("Bans on server %q", scope)
+	}
+
+	if jsonOutput {
+		out := Output{
+			Status: "success",
+			Data: map[string]interface{}{
+				"scope":   scope,
+				"players": players,
+				"ips":     ips,
+				"count":   len(players) + len(ips),
+			},
+			Message: fmt.Sprintf("%s: %d player(s), %d IP address(es)", title, len(players), len(ips)),
+		}
+
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(out)
+	}
+
+	if len(players) == 0 && len(ips) == 0 {
+		_, _ = fmt.Fprintf(w, "%s: none\n", title)
+		return nil
+	}
+
+	_, _ = fmt.Fprintf(w, "%s (%d):\n\n", title, len(players)+len(ips))
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(tw, "TARGET\tSCOPE\tEXPIRES\tREASON")
+	for _, ban := range append(players, ips...) {
+		target := ban.Name
+		if ban.IP != "" {
+			target = ban.IP
+		}
+		expires := "never"
+		if ban.Expires != nil {
+			expires = ban.Expires.Local().Format("2006-01-02 15:04")
+		}
+		reason := ban.Reason
+		if reason == "" {
+			reason = "-"
+		}
+		_, _ = fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", target, ban.Scope, expires, strings.ReplaceAll(reason, "\t", " "))
+	}
+	return tw.Flush()
+}
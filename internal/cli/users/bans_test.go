@@ -0,0 +1,69 @@
+package users
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/steviee/go-mc/internal/state"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewBansCommand(t *testing.T) {
+	cmd := NewBansCommand()
+
+	assert.Equal(t, "bans [server]", cmd.Use)
+	assert.NotEmpty(t, cmd.Short)
+	assert.NotNil(t, cmd.Flags().Lookup("json"))
+}
+
+func TestRunBans(t *testing.T) {
+	ctx := context.Background()
+	setupOpServer(t)
+
+	var buf bytes.Buffer
+	require.NoError(t, runBans(ctx, &buf, "survival", false))
+	assert.Contains(t, buf.String(), `Bans on server "survival": none`)
+
+	require.NoError(t, runBan(ctx, &buf, state.GlobalBanScope, "Notch", "griefing", 0, false))
+	require.NoError(t, runBanIP(ctx, &buf, "survival", "203.0.113.7", "", 24*time.Hour, false))
+
+	buf.Reset()
+	require.NoError(t, runBans(ctx, &buf, "survival", false))
+	assert.Contains(t, buf.String(), `Bans on server "survival" (2)`)
+	assert.Contains(t, buf.String(), "TARGET")
+	assert.Contains(t, buf.String(), "griefing")
+	assert.Contains(t, buf.String(), "203.0.113.7")
+
+	// The global list does not include server bans
+	buf.Reset()
+	require.NoError(t, runBans(ctx, &buf, state.GlobalBanScope, true))
+
+	var out struct {
+		Status string `json:"status"`
+		Data   struct {
+			Players []banEntry `json:"players"`
+			IPs     []banEntry `json:"ips"`
+			Count   int        `json:"count"`
+		} `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &out))
+	assert.Equal(t, "success", out.Status)
+	assert.Equal(t, 1, out.Data.Count)
+	require.Len(t, out.Data.Players, 1)
+	assert.Equal(t, "Notch", out.Data.Players[0].Name)
+	assert.Equal(t, state.GlobalBanScope, out.Data.Players[0].Scope)
+	assert.Empty(t, out.Data.IPs)
+}
+
+func TestRunBans_UnknownServer(t *testing.T) {
+	setupOpServer(t)
+
+	var buf bytes.Buffer
+	err := runBans(context.Background(), &buf, "missing", false)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "does not exist")
+}
@@ -0,0 +1,225 @@
+package users
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+	"github.com/steviee/go-mc/internal/access"
+	"github.com/steviee/go-mc/internal/mojang"
+	"github.com/steviee/go-mc/internal/state"
+)
+
+// profileResolver looks up Minecraft profiles by username.
+type profileResolver interface {
+	GetUUID(ctx context.Context, username string) (*mojang.Profile, error)
+}
+
+// newResolver creates the resolver used to look up operators.
+var newResolver = func() profileResolver {
+	return mojang.NewClient(nil)
+}
+
+// NewOpCommand creates the users op command.
+func NewOpCommand() *cobra.Command {
+	var (
+		jsonOutput  bool
+		level       int
+		bypassLimit bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "op <server> <player>",
+		Short: "Grant operator permissions",
+		Long: `Grant operator (OP) permissions to a player on a server.
+
+The UUID is resolved via Mojang API. The operator is saved to the server
+state and written to the server's ops.json. Running servers are updated
+live over RCON; the console "op" command always grants the server's default
+permission level, so a different --level takes effect on the next start.
+
+Running op again for an existing operator updates its level and flags.`,
+		Example: `  # Grant full operator permissions
+  go-mc users op survival notch
+
+  # Grant moderator permissions that can join a full server
+  go-mc users op survival jeb_ --level 2 --bypass-limit`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runOp(cmd.Context(), cmd.OutOrStdout(), args[0], args[1], level, bypassLimit, jsonOutput)
+		},
+	}
+
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output in JSON format")
+	cmd.Flags().IntVar(&level, "level", access.DefaultOpLevel, "Permission level (1-4)")
+	cmd.Flags().BoolVar(&bypassLimit, "bypass-limit", false, "Allow joining when the server is full")
+
+	return cmd
+}
+
+// NewDeopCommand creates the users deop command.
+func NewDeopCommand() *cobra.Command {
+	var jsonOutput bool
+
+	cmd := &cobra.Command{
+		Use:   "deop <server> <player>",
+		Short: "Revoke operator permissions",
+		Long: `Revoke operator (OP) permissions from a player on a server.
+
+The operator is removed from the server state and ops.json. Running
+servers are updated live over RCON.`,
+		Example: `  # Revoke operator permissions
+  go-mc users deop survival notch`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runDeop(cmd.Context(), cmd.OutOrStdout(), args[0], args[1], jsonOutput)
+		},
+	}
+
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output in JSON format")
+
+	return cmd
+}
+
+func runOp(ctx context.Context, w io.Writer, serverName, player string, level int, bypassLimit, jsonOutput bool) error {
+	serverState, err := loadServer(ctx, serverName)
+	if err != nil {
+		return outputError(w, jsonOutput, err)
+	}
+
+	if err := state.ValidateOpLevel(level); err != nil {
+		return outputError(w, jsonOutput, err)
+	}
+
+	profile, err := newResolver().GetUUID(ctx, player)
+	if err != nil {
+		return outputError(w, jsonOutput, fmt.Errorf("failed to resolve player %q: %w", player, err))
+	}
+
+	op := state.OpInfo{
+		UUID:                profile.UUID,
+		Name:                profile.Username,
+		Level:               level,
+		BypassesPlayerLimit: bypassLimit,
+	}
+
+	// Re-opping an operator updates its settings
+	if existing, ok := access.FindOp(serverState, op.UUID); ok {
+		if *existing == op {
+			return outputError(w, jsonOutput, fmt.Errorf("%s is already an operator on server %q", op.Name, serverName))
+		}
+		if err := state.RemoveOp(ctx, serverName, existing.UUID); err != nil {
+			return outputError(w, jsonOutput, err)
+		}
+	}
+
+	if err := state.AddOp(ctx, serverName, op); err != nil {
+		return outputError(w, jsonOutput, err)
+	}
+
+	serverState, err = state.LoadServerState(ctx, serverName)
+	if err != nil {
+		return outputError(w, jsonOutput, err)
+	}
+
+	result, err := access.ApplyOp(ctx, serverState, op)
+	if err != nil {
+		return outputError(w, jsonOutput, fmt.Errorf("operator saved, but failed to update server: %w", err))
+	}
+
+	message := fmt.Sprintf("Granted operator level %d to %s on server %q", op.Level, op.Name, serverName)
+	return outputOpChange(w, jsonOutput, message, op, result)
+}
+
+func runDeop(ctx context.Context, w io.Writer, serverName, player string, jsonOutput bool) error {
+	serverState, err := loadServer(ctx, serverName)
+	if err != nil {
+		return outputError(w, jsonOutput, err)
+	}
+
+	existing, ok := access.FindOp(serverState, player)
+	if !ok {
+		return outputError(w, jsonOutput, fmt.Errorf("%s is not an operator on server %q", player, serverName))
+	}
+	op := *existing
+
+	if err := state.RemoveOp(ctx, serverName, op.UUID); err != nil {
+		return outputError(w, jsonOutput, err)
+	}
+
+	serverState, err = state.LoadServerState(ctx, serverName)
+	if err != nil {
+		return outputError(w, jsonOutput, err)
+	}
+
+	result, err := access.ApplyDeop(ctx, serverState, op.Name)
+	if err != nil {
+		return outputError(w, jsonOutput, fmt.Errorf("operator removed, but failed to update server: %w", err))
+	}
+
+	message := fmt.Sprintf("Revoked operator permissions from %s on server %q", op.Name, serverName)
+	return outputOpChange(w, jsonOutput, message, op, result)
+}
+
+// loadServer validates a server name and loads its state.
+func loadServer(ctx context.Context, name string) (*state.ServerState, error) {
+	if err := state.ValidateServerName(name); err != nil {
+		return nil, fmt.Errorf("invalid server name: %w", err)
+	}
+
+	serverState, err := state.LoadServerState(ctx, name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load server state: %w", err)
+	}
+
+	return serverState, nil
+}
+
+func outputOpChange(w io.Writer, jsonOutput bool, message string, op state.OpInfo, result *access.ApplyResult) error {
+	if jsonOutput {
+		data := map[string]interface{}{
+			"server": result.Server,
+			"op":     toOpEntry(op),
+			"live":   result.Live,
+		}
+		if result.Warning != "" {
+			data["warning"] = result.Warning
+		}
+
+		out := Output{
+			Status:  "success",
+			Data:    data,
+			Message: message,
+		}
+
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(out)
+	}
+
+	_, _ = fmt.Fprintln(w, message)
+	switch {
+	case result.Live && result.Warning != "":
+		_, _ = fmt.Fprintf(w, "Applied live (%s)\n", result.Warning)
+	case result.Live:
+		_, _ = fmt.Fprintln(w, "Applied live")
+	case result.Warning != "":
+		_, _ = fmt.Fprintf(w, "Note: %s\n", result.Warning)
+	default:
+		_, _ = fmt.Fprintln(w, "Applies on next start")
+	}
+
+	return nil
+}
+
+// toOpEntry converts an operator to its ops.json representation.
+func toOpEntry(op state.OpInfo) access.OpEntry {
+	return access.OpEntry{
+		UUID:                op.UUID,
+		Name:                op.Name,
+		Level:               op.Level,
+		BypassesPlayerLimit: op.BypassesPlayerLimit,
+	}
+}
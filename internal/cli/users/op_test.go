@@ -0,0 +1,145 @@
+package users
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/steviee/go-mc/internal/access"
+	"github.com/steviee/go-mc/internal/mojang"
+	"github.com/steviee/go-mc/internal/state"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeResolver resolves usernames from a fixed table
+type fakeResolver map[string]string
+
+func (f fakeResolver) GetUUID(ctx context.Context, username string) (*mojang.Profile, error) {
+	for name, uuid := range f {
+		if strings.EqualFold(name, username) {
+			return &mojang.Profile{UUID: uuid, Username: name}, nil
+		}
+	}
+	return nil, fmt.Errorf("player not found: %s", username)
+}
+
+// setupOpServer saves a stopped server and replaces the Mojang resolver.
+func setupOpServer(t *testing.T) *state.ServerState {
+	t.Helper()
+
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	require.NoError(t, state.InitDirs())
+
+	serverState := state.NewServerState("survival")
+	serverState.Volumes.Data = filepath.Join(t.TempDir(), "data")
+	require.NoError(t, state.SaveServerState(context.Background(), serverState))
+
+	original := newResolver
+	newResolver = func() profileResolver {
+		return fakeResolver{"Notch": "069a79f4-44e9-4726-a5be-fca90e38aaf5"}
+	}
+	t.Cleanup(func() { newResolver = original })
+
+	return serverState
+}
+
+func TestNewOpCommand(t *testing.T) {
+	cmd := NewOpCommand()
+
+	assert.Equal(t, "op <server> <player>", cmd.Use)
+	assert.NotEmpty(t, cmd.Short)
+	assert.NotEmpty(t, cmd.Long)
+	assert.NotNil(t, cmd.Flags().Lookup("level"))
+	assert.NotNil(t, cmd.Flags().Lookup("bypass-limit"))
+	assert.NotNil(t, cmd.Flags().Lookup("json"))
+}
+
+func TestRunOp(t *testing.T) {
+	ctx := context.Background()
+	serverState := setupOpServer(t)
+
+	var buf bytes.Buffer
+	require.NoError(t, runOp(ctx, &buf, "survival", "notch", 4, false, false))
+	assert.Contains(t, buf.String(), `Granted operator level 4 to Notch on server "survival"`)
+	assert.Contains(t, buf.String(), "Applies on next start")
+
+	entries, err := access.ReadOpsFile(serverState)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, 4, entries[0].Level)
+
+	// Same settings again is an error
+	err = runOp(ctx, &buf, "survival", "notch", 4, false, false)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "already an operator")
+
+	// Different settings update the operator
+	buf.Reset()
+	require.NoError(t, runOp(ctx, &buf, "survival", "notch", 2, true, true))
+
+	var out Output
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &out))
+	assert.Equal(t, "success", out.Status)
+
+	saved, err := state.LoadServerState(ctx, "survival")
+	require.NoError(t, err)
+	require.Len(t, saved.Ops, 1)
+	assert.Equal(t, 2, saved.Ops[0].Level)
+	assert.True(t, saved.Ops[0].BypassesPlayerLimit)
+
+	entries, err = access.ReadOpsFile(serverState)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, 2, entries[0].Level)
+}
+
+func TestRunOp_Errors(t *testing.T) {
+	ctx := context.Background()
+	setupOpServer(t)
+
+	tests := []struct {
+		name    string
+		server  string
+		player  string
+		level   int
+		wantErr string
+	}{
+		{name: "invalid level", server: "survival", player: "notch", level: 5, wantErr: "op level"},
+		{name: "unknown player", server: "survival", player: "nobody", level: 4, wantErr: "failed to resolve"},
+		{name: "unknown server", server: "missing", player: "notch", level: 4, wantErr: "does not exist"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			err := runOp(ctx, &buf, tt.server, tt.player, tt.level, false, false)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestRunDeop(t *testing.T) {
+	ctx := context.Background()
+	serverState := setupOpServer(t)
+
+	var buf bytes.Buffer
+	require.NoError(t, runOp(ctx, &buf, "survival", "Notch", 4, false, false))
+
+	buf.Reset()
+	require.NoError(t, runDeop(ctx, &buf, "survival", "notch", false))
+	assert.Contains(t, buf.String(), "Revoked operator permissions from Notch")
+
+	entries, err := access.ReadOpsFile(serverState)
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+
+	err = runDeop(ctx, &buf, "survival", "notch", false)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "not an operator")
+}
@@ -0,0 +1,97 @@
+package users
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+	"github.com/steviee/go-mc/internal/access"
+)
+
+// NewOpsCommand creates the users ops command group.
+func NewOpsCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "ops",
+		Short: "Manage server operators",
+		Long: `View the operators of a server.
+
+Use 'users op' and 'users deop' to change operators.`,
+		Example: `  # List operators of a server
+  go-mc users ops list survival`,
+	}
+
+	cmd.AddCommand(NewOpsListCommand())
+
+	return cmd
+}
+
+// NewOpsListCommand creates the users ops list command.
+func NewOpsListCommand() *cobra.Command {
+	var jsonOutput bool
+
+	cmd := &cobra.Command{
+		Use:   "list <server>",
+		Short: "List server operators",
+		Long:  `List all operators of a server with their permission level.`,
+		Example: `  # List operators
+  go-mc users ops list survival
+
+  # JSON output
+  go-mc users ops list survival --json`,
+		Args:    cobra.ExactArgs(1),
+		Aliases: []string{"ls"},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runOpsList(cmd.Context(), cmd.OutOrStdout(), args[0], jsonOutput)
+		},
+	}
+
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output in JSON format")
+
+	return cmd
+}
+
+func runOpsList(ctx context.Context, w io.Writer, serverName string, jsonOutput bool) error {
+	serverState, err := loadServer(ctx, serverName)
+	if err != nil {
+		return outputError(w, jsonOutput, err)
+	}
+
+	ops := make([]access.OpEntry, 0, len(serverState.Ops))
+	for _, op := range serverState.Ops {
+		ops = append(ops, toOpEntry(op))
+	}
+
+	if jsonOutput {
+		out := Output{
+			Status: "success",
+			Data: map[string]interface{}{
+				"server": serverName,
+				"ops":    ops,
+				"count":  len(ops),
+			},
+			Message: fmt.Sprintf("Found %d operator(s) on server %q", len(ops), serverName),
+		}
+
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(out)
+	}
+
+	if len(ops) == 0 {
+		_, _ = fmt.Fprintf(w, "Server %q has no operators\n", serverName)
+		return nil
+	}
+
+	_, _ = fmt.Fprintf(w, "Operators on server %q (%d):\n", serverName, len(ops))
+	for i, op := range ops {
+		bypass := ""
+		if op.BypassesPlayerLimit {
+			bypass = "  bypasses player limit"
+		}
+		_, _ = fmt.Fprintf(w, "%3d. %-16s %s  level %d%s\n", i+1, op.Name, op.UUID, op.Level, bypass)
+	}
+
+	return nil
+}
@@ -0,0 +1,58 @@
+package users
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewOpsCommand(t *testing.T) {
+	cmd := NewOpsCommand()
+
+	assert.Equal(t, "ops", cmd.Use)
+	assert.NotEmpty(t, cmd.Short)
+	require.Len(t, cmd.Commands(), 1)
+	assert.Equal(t, "list", cmd.Commands()[0].Name())
+	assert.Contains(t, cmd.Commands()[0].Aliases, "ls")
+}
+
+func TestRunOpsList(t *testing.T) {
+	ctx := context.Background()
+	setupOpServer(t)
+
+	var buf bytes.Buffer
+	require.NoError(t, runOpsList(ctx, &buf, "survival", false))
+	assert.Contains(t, buf.String(), "has no operators")
+
+	require.NoError(t, runOp(ctx, &buf, "survival", "notch", 3, true, false))
+
+	buf.Reset()
+	require.NoError(t, runOpsList(ctx, &buf, "survival", false))
+	assert.Contains(t, buf.String(), "Notch")
+	assert.Contains(t, buf.String(), "level 3")
+	assert.Contains(t, buf.String(), "bypasses player limit")
+
+	buf.Reset()
+	require.NoError(t, runOpsList(ctx, &buf, "survival", true))
+
+	var out struct {
+		Status string `json:"status"`
+		Data   struct {
+			Count int `json:"count"`
+			Ops   []struct {
+				Name  string `json:"name"`
+				Level int    `json:"level"`
+			} `json:"ops"`
+		} `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &out))
+	assert.Equal(t, 1, out.Data.Count)
+	assert.Equal(t, "Notch", out.Data.Ops[0].Name)
+	assert.Equal(t, 3, out.Data.Ops[0].Level)
+
+	require.Error(t, runOpsList(ctx, &buf, "missing", false))
+}
@@ -7,6 +7,7 @@ import (
 	"io"
 
 	"github.com/spf13/cobra"
+	"github.com/steviee/go-mc/internal/access"
 	"github.com/steviee/go-mc/internal/mojang"
 	"github.com/steviee/go-mc/internal/state"
 )
@@ -101,15 +102,21 @@ func runRemove(ctx context.Context, w io.Writer, usernames []string, jsonOutput
 		removedUsers = append(removedUsers, *player)
 	}
 
+	// Write the changed whitelist to the servers that use it
+	var synced []access.WhitelistSyncResult
+	if len(removedUsers) > 0 {
+		synced = syncServers(ctx, whitelistName)
+	}
+
 	// Output results
 	if jsonOutput {
-		return outputRemoveJSON(w, whitelistName, removedUsers, errors)
+		return outputRemoveJSON(w, whitelistName, removedUsers, errors, synced)
 	}
 
-	return outputRemoveHuman(w, whitelistName, removedUsers, errors)
+	return outputRemoveHuman(w, whitelistName, removedUsers, errors, synced)
 }
 
-func outputRemoveJSON(w io.Writer, whitelistName string, removed []state.PlayerInfo, errors map[string]string) error {
+func outputRemoveJSON(w io.Writer, whitelistName string, removed []state.PlayerInfo, errors map[string]string, synced []access.WhitelistSyncResult) error {
 	data := map[string]interface{}{
 		"whitelist": whitelistName,
 		"removed":   removed,
@@ -119,6 +126,10 @@ func outputRemoveJSON(w io.Writer, whitelistName string, removed []state.PlayerI
 		data["errors"] = errors
 	}
 
+	if len(synced) > 0 {
+		data["synced"] = synced
+	}
+
 	status := "success"
 	if len(removed) == 0 {
 		status = "error"
@@ -135,7 +146,7 @@ func outputRemoveJSON(w io.Writer, whitelistName string, removed []state.PlayerI
 	return enc.Encode(out)
 }
 
-func outputRemoveHuman(w io.Writer, whitelistName string, removed []state.PlayerInfo, errors map[string]string) error {
+func outputRemoveHuman(w io.Writer, whitelistName string, removed []state.PlayerInfo, errors map[string]string, synced []access.WhitelistSyncResult) error {
 	if len(removed) > 0 {
 		_, _ = fmt.Fprintf(w, "Removed %d user(s) from whitelist %q:\n", len(removed), whitelistName)
 		for _, player := range removed {
@@ -150,6 +161,8 @@ func outputRemoveHuman(w io.Writer, whitelistName string, removed []state.Player
 		}
 	}
 
+	outputSyncHuman(w, synced)
+
 	if len(removed) == 0 && len(errors) > 0 {
 		return fmt.Errorf("failed to remove any users")
 	}
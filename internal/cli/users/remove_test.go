@@ -125,7 +125,7 @@ func TestOutputRemoveJSON(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			var buf bytes.Buffer
-			err := outputRemoveJSON(&buf, "test-whitelist", tt.removed, tt.errors)
+			err := outputRemoveJSON(&buf, "test-whitelist", tt.removed, tt.errors, nil)
 			require.NoError(t, err)
 
 			var output Output
@@ -210,7 +210,7 @@ func TestOutputRemoveHuman(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			var buf bytes.Buffer
-			err := outputRemoveHuman(&buf, "test-whitelist", tt.removed, tt.errors)
+			err := outputRemoveHuman(&buf, "test-whitelist", tt.removed, tt.errors, nil)
 
 			if tt.wantErr {
 				require.Error(t, err)
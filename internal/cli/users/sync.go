@@ -0,0 +1,42 @@
+package users
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+
+	"github.com/steviee/go-mc/internal/access"
+)
+
+// syncServers writes the changed whitelist to every server that uses it.
+// Sync problems are reported but never fail the user change itself.
+func syncServers(ctx context.Context, whitelistName string) []access.WhitelistSyncResult {
+	results, err := access.SyncServers(ctx, whitelistName)
+	if err != nil {
+		slog.Warn("failed to sync whitelist to servers", "whitelist", whitelistName, "error", err)
+		return nil
+	}
+	return results
+}
+
+// outputSyncHuman prints where a whitelist change was applied.
+func outputSyncHuman(w io.Writer, results []access.WhitelistSyncResult) {
+	if len(results) == 0 {
+		return
+	}
+
+	_, _ = fmt.Fprintf(w, "\nSynced %d server(s):\n", len(results))
+	for _, result := range results {
+		switch {
+		case result.Error != "":
+			_, _ = fmt.Fprintf(w, "  - %s: sync failed: %s\n", result.Server, result.Error)
+		case result.Live:
+			_, _ = fmt.Fprintf(w, "  - %s: applied live\n", result.Server)
+		case result.Warning != "":
+			_, _ = fmt.Fprintf(w, "  - %s: %s\n", result.Server, result.Warning)
+		default:
+			_, _ = fmt.Fprintf(w, "  - %s: applies on next start\n", result.Server)
+		}
+	}
+}
@@ -0,0 +1,29 @@
+package users
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/steviee/go-mc/internal/access"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOutputSyncHuman(t *testing.T) {
+	var buf bytes.Buffer
+	outputSyncHuman(&buf, nil)
+	assert.Empty(t, buf.String())
+
+	outputSyncHuman(&buf, []access.WhitelistSyncResult{
+		{Server: "live", Live: true},
+		{Server: "stopped"},
+		{Server: "offline", Warning: "changes apply on next start: rcon unavailable"},
+		{Server: "broken", Error: "no data directory"},
+	})
+
+	output := buf.String()
+	assert.Contains(t, output, "Synced 4 server(s)")
+	assert.Contains(t, output, "live: applied live")
+	assert.Contains(t, output, "stopped: applies on next start")
+	assert.Contains(t, output, "offline: changes apply on next start: rcon unavailable")
+	assert.Contains(t, output, "broken: sync failed: no data directory")
+}
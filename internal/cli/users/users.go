@@ -12,8 +12,8 @@ func NewCommand() *cobra.Command {
 		Long: `Manage Minecraft users with automatic UUID resolution via Mojang API.
 
 Commands in this group allow you to add users to servers, remove them,
-and manage their operator (OP) permissions. UUIDs are automatically
-resolved from usernames.`,
+manage their operator (OP) permissions, and ban players or IP addresses.
+UUIDs are automatically resolved from usernames.`,
 		Example: `  # Add a user to a server
   go-mc users add myserver notch
 
@@ -29,6 +29,18 @@ resolved from usernames.`,
   # Revoke operator permissions
   go-mc users deop myserver notch
 
+  # List operators of a server
+  go-mc users ops list myserver
+
+  # Ban a player for a week
+  go-mc users ban myserver griefer --expires 7d --reason "Griefing"
+
+  # Ban an IP address on all servers
+  go-mc users ban-ip --global 203.0.113.7
+
+  # List bans that apply to a server
+  go-mc users bans myserver
+
   # List users on a server
   go-mc users list myserver`,
 		Aliases: []string{"user"},
@@ -38,9 +50,14 @@ resolved from usernames.`,
 	cmd.AddCommand(NewAddCommand())
 	cmd.AddCommand(NewRemoveCommand())
 	cmd.AddCommand(NewListCommand())
-	// TODO: Add OP commands in future phases
-	// cmd.AddCommand(NewOpCommand())
-	// cmd.AddCommand(NewDeopCommand())
+	cmd.AddCommand(NewOpCommand())
+	cmd.AddCommand(NewDeopCommand())
+	cmd.AddCommand(NewOpsCommand())
+	cmd.AddCommand(NewBanCommand())
+	cmd.AddCommand(NewUnbanCommand())
+	cmd.AddCommand(NewBansCommand())
+	cmd.AddCommand(NewBanIPCommand())
+	cmd.AddCommand(NewUnbanIPCommand())
 
 	return cmd
 }
@@ -0,0 +1,184 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steviee/go-mc/internal/lifecycle"
+	"github.com/steviee/go-mc/internal/server"
+	"github.com/steviee/go-mc/internal/state"
+)
+
+// skipPIDLockAnnotation marks commands that run alongside other go-mc
+// commands instead of holding the PID lock
+const skipPIDLockAnnotation = "go-mc/skip-pid-lock"
+
+// WatchFlags holds all flags for the watch command
+type WatchFlags struct {
+	Interval     time.Duration
+	HangTimeout  time.Duration
+	StartupGrace time.Duration
+}
+
+// WatchEventOutput is a watch event in JSON output, one object per line
+type WatchEventOutput struct {
+	Time    string         `json:"time"`
+	Server  string         `json:"server,omitempty"`
+	Event   string         `json:"event"`
+	Message string         `json:"message"`
+	Restart *RestartOutput `json:"restart,omitempty"`
+}
+
+// RestartOutput describes an automatic restart
+type RestartOutput struct {
+	By          string `json:"by"`
+	Cause       string `json:"cause"`
+	ExitCode    int    `json:"exit_code"`
+	CrashReport string `json:"crash_report,omitempty"`
+}
+
+// NewWatchCommand creates the watch command
+func NewWatchCommand() *cobra.Command {
+	flags := &WatchFlags{}
+
+	cmd := &cobra.Command{
+		Use:   "watch [name...]",
+		Short: "Restart crashed and hung servers according to their restart policy",
+		Long: `Watch servers and enforce their restart policy until interrupted.
+
+A server is restarted when it exits (any exit for "always", a non-zero exit code
+for "on-failure") or hangs: its container runs but it has not answered a server
+list ping for --hang-timeout. Restarts are delayed by the backoff of the policy,
+doubling for each consecutive restart, and "on-failure" gives up after its
+maximum retries by setting the server status to error.
+
+Podman restarts crashed containers on its own when a policy is set; watch adds
+the backoff and hang detection, and records those restarts too. Every restart is
+saved to the server state with its cause: the exit code and the crash report
+the server wrote, or the hang.
+
+Every check also pardons temporary bans that expired, on all servers.
+
+Only servers that were started with go-mc are watched; servers stopped with
+'go-mc servers stop' stay stopped. Without names, all servers are watched.
+Unlike other commands, watch runs alongside other go-mc commands.`,
+		Example: `  # Watch all servers
+  go-mc watch
+
+  # Watch one server and detect hangs sooner
+  go-mc watch survival --hang-timeout 1m
+
+  # Log events as JSON lines
+  go-mc watch --json >> watch.log`,
+		Annotations: map[string]string{skipPIDLockAnnotation: "true"},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runWatch(cmd.Context(), cmd.OutOrStdout(), args, flags)
+		},
+	}
+
+	cmd.Flags().DurationVar(&flags.Interval, "interval", lifecycle.DefaultWatchInterval, "How often to check the servers")
+	cmd.Flags().DurationVar(&flags.HangTimeout, "hang-timeout", lifecycle.DefaultHangTimeout, "How long a running server may fail pings before it is restarted")
+	cmd.Flags().DurationVar(&flags.StartupGrace, "startup-grace", lifecycle.DefaultWaitTimeout, "How long a server may take to load its world before hangs are detected")
+
+	return cmd
+}
+
+// NeedsPIDLock reports whether the command that args resolve to must hold
+// the PID lock while it runs.
+func NeedsPIDLock(root *cobra.Command, args []string) bool {
+	cmd, _, err := root.Find(args)
+	if err != nil {
+		return true
+	}
+	return cmd.Annotations[skipPIDLockAnnotation] != "true"
+}
+
+// runWatch executes the watch command
+func runWatch(ctx context.Context, w io.Writer, names []string, flags *WatchFlags) error {
+	for _, name := range names {
+		if _, err := state.LoadServerState(ctx, name); err != nil {
+			return err
+		}
+	}
+
+	lockPath, err := state.GetWatchLockPath()
+	if err != nil {
+		return err
+	}
+	lock, err := state.TryLockFile(lockPath)
+	if err != nil {
+		if errors.Is(err, state.ErrLockHeld) {
+			return fmt.Errorf("go-mc watch is already running")
+		}
+		return err
+	}
+	defer func() { _ = lock.Unlock() }()
+
+	client, err := server.ConnectContainerRuntime(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to create container client: %w", err)
+	}
+	defer func() { _ = client.Close() }()
+
+	opts := lifecycle.WatchOptions{
+		Interval:     flags.Interval,
+		HangTimeout:  flags.HangTimeout,
+		StartupGrace: flags.StartupGrace,
+	}
+	jsonMode := IsJSONOutput()
+	dog := lifecycle.NewWatchdog(client, opts, func(event lifecycle.WatchEvent) {
+		_ = writeWatchEvent(w, jsonMode, event)
+	})
+
+	if !jsonMode && !IsQuiet() {
+		_, _ = fmt.Fprintf(w, "Watching %s every %s (Ctrl+C to stop)\n", describeWatched(names), opts.Interval)
+	}
+
+	dog.Run(ctx, names)
+	return nil
+}
+
+// describeWatched names the watched servers for the start message
+func describeWatched(names []string) string {
+	switch len(names) {
+	case 0:
+		return "all servers"
+	case 1:
+		return fmt.Sprintf("server '%s'", names[0])
+	default:
+		return fmt.Sprintf("%d servers", len(names))
+	}
+}
+
+// writeWatchEvent writes an event as a log line or a JSON object
+func writeWatchEvent(w io.Writer, jsonMode bool, event lifecycle.WatchEvent) error {
+	if jsonMode {
+		output := WatchEventOutput{
+			Time:    event.Time.UTC().Format(time.RFC3339),
+			Server:  event.Server,
+			Event:   event.Kind,
+			Message: event.Message,
+		}
+		if r := event.Restart; r != nil {
+			output.Restart = &RestartOutput{
+				By:          r.By,
+				Cause:       r.Cause,
+				ExitCode:    r.ExitCode,
+				CrashReport: r.CrashReport,
+			}
+		}
+		return json.NewEncoder(w).Encode(output)
+	}
+
+	server := event.Server
+	if server == "" {
+		server = "-"
+	}
+	_, err := fmt.Fprintf(w, "%s %s %s: %s\n", event.Time.Format(time.DateTime), server, event.Kind, event.Message)
+	return err
+}
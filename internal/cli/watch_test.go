@@ -0,0 +1,54 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/steviee/go-mc/internal/lifecycle"
+	"github.com/steviee/go-mc/internal/state"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNeedsPIDLock(t *testing.T) {
+	root := NewRootCommand("dev", "unknown", "unknown", "unknown")
+
+	assert.True(t, NeedsPIDLock(root, []string{"servers", "start", "survival"}))
+	assert.True(t, NeedsPIDLock(root, []string{}))
+	assert.True(t, NeedsPIDLock(root, []string{"no-such-command"}))
+	assert.False(t, NeedsPIDLock(root, []string{"watch"}))
+	assert.False(t, NeedsPIDLock(root, []string{"--json", "watch", "survival", "--interval", "5s"}))
+}
+
+func TestWriteWatchEvent(t *testing.T) {
+	event := lifecycle.WatchEvent{
+		Time:    time.Date(2025, 1, 2, 12, 0, 0, 0, time.UTC),
+		Server:  "survival",
+		Kind:    lifecycle.WatchRestarted,
+		Message: "restarted by watch after exit code 1",
+		Restart: &state.RestartRecord{By: "watch", Cause: "exit", ExitCode: 1, CrashReport: "crash-2025-01-02_11.59.58-server.txt"},
+	}
+
+	t.Run("human", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, writeWatchEvent(&buf, false, event))
+		assert.Equal(t, "2025-01-02 12:00:00 survival restarted: restarted by watch after exit code 1\n", buf.String())
+	})
+
+	t.Run("json", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, writeWatchEvent(&buf, true, event))
+
+		var output WatchEventOutput
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &output))
+		assert.Equal(t, WatchEventOutput{
+			Time:    "2025-01-02T12:00:00Z",
+			Server:  "survival",
+			Event:   "restarted",
+			Message: "restarted by watch after exit code 1",
+			Restart: &RestartOutput{By: "watch", Cause: "exit", ExitCode: 1, CrashReport: "crash-2025-01-02_11.59.58-server.txt"},
+		}, output)
+	})
+}
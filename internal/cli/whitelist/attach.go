@@ -0,0 +1,117 @@
+package whitelist
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+	"github.com/steviee/go-mc/internal/access"
+	"github.com/steviee/go-mc/internal/state"
+)
+
+// NewAttachCommand creates the whitelist attach command.
+func NewAttachCommand() *cobra.Command {
+	var jsonOutput bool
+
+	cmd := &cobra.Command{
+		Use:   "attach <list> <server>",
+		Short: "Attach a whitelist to a server",
+		Long: `Attach a named whitelist to a server.
+
+The players of every attached list are merged with the global whitelist
+into the server's whitelist.json, and the server is synced immediately.`,
+		Example: `  # Attach a whitelist to a server
+  go-mc whitelist attach friends survival`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runAttach(cmd.Context(), cmd.OutOrStdout(), args[0], args[1], jsonOutput)
+		},
+	}
+
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output in JSON format")
+
+	return cmd
+}
+
+func runAttach(ctx context.Context, w io.Writer, listName, serverName string, jsonOutput bool) error {
+	serverState, err := loadServer(ctx, serverName)
+	if err != nil {
+		return outputError(w, jsonOutput, err)
+	}
+
+	if err := attachList(ctx, serverState, listName); err != nil {
+		return outputError(w, jsonOutput, err)
+	}
+
+	result, err := access.SyncWhitelist(ctx, serverState)
+	if err != nil {
+		return outputError(w, jsonOutput, fmt.Errorf("attached, but failed to sync whitelist: %w", err))
+	}
+
+	message := fmt.Sprintf("Attached whitelist %q to server %q", listName, serverName)
+	return outputServerChange(w, jsonOutput, message, listName, result)
+}
+
+// errAlreadyAttached is returned when a list is already attached to a server.
+var errAlreadyAttached = errors.New("already attached")
+
+// attachList attaches an existing whitelist to a server and saves its state.
+func attachList(ctx context.Context, serverState *state.ServerState, listName string) error {
+	if err := state.ValidateWhitelistName(listName); err != nil {
+		return fmt.Errorf("invalid whitelist name: %w", err)
+	}
+	if listName == access.GlobalWhitelist {
+		return fmt.Errorf("whitelist %q is global and applies to all servers", listName)
+	}
+
+	exists, err := state.WhitelistExists(ctx, listName)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return fmt.Errorf("whitelist %q does not exist", listName)
+	}
+
+	for _, name := range serverState.Whitelist.Lists {
+		if name == listName {
+			return fmt.Errorf("whitelist %q is %w to server %q", listName, errAlreadyAttached, serverState.Name)
+		}
+	}
+
+	serverState.Whitelist.Lists = append(serverState.Whitelist.Lists, listName)
+	if err := state.SaveServerState(ctx, serverState); err != nil {
+		return fmt.Errorf("failed to save server state: %w", err)
+	}
+
+	return nil
+}
+
+// outputServerChange reports a whitelist change on a single server.
+func outputServerChange(w io.Writer, jsonOutput bool, message, listName string, result *access.WhitelistSyncResult) error {
+	if jsonOutput {
+		data := map[string]interface{}{
+			"server": result.Server,
+			"sync":   result,
+		}
+		if listName != "" {
+			data["whitelist"] = listName
+		}
+
+		out := Output{
+			Status:  "success",
+			Data:    data,
+			Message: message,
+		}
+
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(out)
+	}
+
+	_, _ = fmt.Fprintln(w, message)
+	outputSyncResultHuman(w, *result)
+	return nil
+}
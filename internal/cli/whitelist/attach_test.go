@@ -0,0 +1,99 @@
+package whitelist
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"path/filepath"
+	"testing"
+
+	"github.com/steviee/go-mc/internal/access"
+	"github.com/steviee/go-mc/internal/state"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// setupServer initializes state in a temporary directory and saves a stopped server.
+func setupServer(t *testing.T, name string) *state.ServerState {
+	t.Helper()
+
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	require.NoError(t, state.InitDirs())
+
+	serverState := state.NewServerState(name)
+	serverState.Volumes.Data = filepath.Join(t.TempDir(), "data")
+	require.NoError(t, state.SaveServerState(context.Background(), serverState))
+
+	return serverState
+}
+
+func TestNewAttachCommand(t *testing.T) {
+	cmd := NewAttachCommand()
+
+	assert.Equal(t, "attach <list> <server>", cmd.Use)
+	assert.NotEmpty(t, cmd.Short)
+	assert.NotEmpty(t, cmd.Long)
+	assert.NotEmpty(t, cmd.Example)
+	assert.NotNil(t, cmd.Flags().Lookup("json"))
+}
+
+func TestRunAttach(t *testing.T) {
+	ctx := context.Background()
+	setupServer(t, "survival")
+
+	require.NoError(t, state.AddPlayer(ctx, "friends", state.PlayerInfo{
+		UUID: "069a79f4-44e9-4726-a5be-fca90e38aaf5",
+		Name: "Notch",
+	}))
+
+	var buf bytes.Buffer
+	require.NoError(t, runAttach(ctx, &buf, "friends", "survival", false))
+	assert.Contains(t, buf.String(), `Attached whitelist "friends" to server "survival"`)
+	assert.Contains(t, buf.String(), "+Notch")
+
+	serverState, err := state.LoadServerState(ctx, "survival")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"friends"}, serverState.Whitelist.Lists)
+
+	entries, err := access.ReadWhitelistFile(serverState)
+	require.NoError(t, err)
+	assert.Len(t, entries, 1)
+
+	// Attaching twice fails
+	buf.Reset()
+	err = runAttach(ctx, &buf, "friends", "survival", true)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "already attached")
+
+	var out Output
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &out))
+	assert.Equal(t, "error", out.Status)
+}
+
+func TestRunAttach_Errors(t *testing.T) {
+	ctx := context.Background()
+	setupServer(t, "survival")
+	require.NoError(t, state.SaveWhitelistState(ctx, state.NewWhitelistState("friends")))
+
+	tests := []struct {
+		name    string
+		list    string
+		server  string
+		wantErr string
+	}{
+		{name: "global list", list: access.GlobalWhitelist, server: "survival", wantErr: "applies to all servers"},
+		{name: "missing list", list: "nope", server: "survival", wantErr: "does not exist"},
+		{name: "missing server", list: "friends", server: "nope", wantErr: "does not exist"},
+		{name: "invalid list name", list: "bad name!", server: "survival", wantErr: "invalid whitelist name"},
+		{name: "invalid server name", list: "friends", server: "bad name!", wantErr: "invalid server name"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			err := runAttach(ctx, &buf, tt.list, tt.server, false)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
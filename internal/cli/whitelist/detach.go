@@ -0,0 +1,76 @@
+package whitelist
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+	"github.com/steviee/go-mc/internal/access"
+	"github.com/steviee/go-mc/internal/state"
+)
+
+// NewDetachCommand creates the whitelist detach command.
+func NewDetachCommand() *cobra.Command {
+	var jsonOutput bool
+
+	cmd := &cobra.Command{
+		Use:   "detach <list> <server>",
+		Short: "Detach a whitelist from a server",
+		Long: `Detach a named whitelist from a server.
+
+Players that are only on the detached list are removed from the server's
+whitelist.json, and the server is synced immediately.`,
+		Example: `  # Detach a whitelist from a server
+  go-mc whitelist detach friends survival`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runDetach(cmd.Context(), cmd.OutOrStdout(), args[0], args[1], jsonOutput)
+		},
+	}
+
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output in JSON format")
+
+	return cmd
+}
+
+func runDetach(ctx context.Context, w io.Writer, listName, serverName string, jsonOutput bool) error {
+	// Validate whitelist name
+	if err := state.ValidateWhitelistName(listName); err != nil {
+		return outputError(w, jsonOutput, fmt.Errorf("invalid whitelist name: %w", err))
+	}
+	if listName == access.GlobalWhitelist {
+		return outputError(w, jsonOutput, fmt.Errorf("whitelist %q is global and cannot be detached", listName))
+	}
+
+	serverState, err := loadServer(ctx, serverName)
+	if err != nil {
+		return outputError(w, jsonOutput, err)
+	}
+
+	found := false
+	lists := make([]string, 0, len(serverState.Whitelist.Lists))
+	for _, name := range serverState.Whitelist.Lists {
+		if name == listName {
+			found = true
+			continue
+		}
+		lists = append(lists, name)
+	}
+	if !found {
+		return outputError(w, jsonOutput, fmt.Errorf("whitelist %q is not attached to server %q", listName, serverName))
+	}
+
+	serverState.Whitelist.Lists = lists
+	if err := state.SaveServerState(ctx, serverState); err != nil {
+		return outputError(w, jsonOutput, fmt.Errorf("failed to save server state: %w", err))
+	}
+
+	result, err := access.SyncWhitelist(ctx, serverState)
+	if err != nil {
+		return outputError(w, jsonOutput, fmt.Errorf("detached, but failed to sync whitelist: %w", err))
+	}
+
+	message := fmt.Sprintf("Detached whitelist %q from server %q", listName, serverName)
+	return outputServerChange(w, jsonOutput, message, listName, result)
+}
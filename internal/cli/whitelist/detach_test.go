@@ -0,0 +1,59 @@
+package whitelist
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/steviee/go-mc/internal/access"
+	"github.com/steviee/go-mc/internal/state"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewDetachCommand(t *testing.T) {
+	cmd := NewDetachCommand()
+
+	assert.Equal(t, "detach <list> <server>", cmd.Use)
+	assert.NotEmpty(t, cmd.Short)
+	assert.NotEmpty(t, cmd.Example)
+	assert.NotNil(t, cmd.Flags().Lookup("json"))
+}
+
+func TestRunDetach(t *testing.T) {
+	ctx := context.Background()
+	setupServer(t, "survival")
+
+	require.NoError(t, state.AddPlayer(ctx, "friends", state.PlayerInfo{
+		UUID: "853c80ef-3c37-49fd-aa49-938b674adae6",
+		Name: "jeb_",
+	}))
+
+	var buf bytes.Buffer
+	require.NoError(t, runAttach(ctx, &buf, "friends", "survival", false))
+
+	buf.Reset()
+	require.NoError(t, runDetach(ctx, &buf, "friends", "survival", true))
+
+	var out Output
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &out))
+	assert.Equal(t, "success", out.Status)
+
+	serverState, err := state.LoadServerState(ctx, "survival")
+	require.NoError(t, err)
+	assert.Empty(t, serverState.Whitelist.Lists)
+
+	entries, err := access.ReadWhitelistFile(serverState)
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+
+	// Detaching again fails
+	err = runDetach(ctx, &buf, "friends", "survival", false)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "not attached")
+
+	err = runDetach(ctx, &buf, access.GlobalWhitelist, "survival", false)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "cannot be detached")
+}
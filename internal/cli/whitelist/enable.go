@@ -0,0 +1,104 @@
+package whitelist
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+	"github.com/steviee/go-mc/internal/access"
+)
+
+// NewEnableCommand creates the whitelist enable command.
+func NewEnableCommand() *cobra.Command {
+	var (
+		jsonOutput bool
+		whitelist  string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "enable <server>",
+		Short: "Enforce the whitelist on a server",
+		Long: `Enable whitelist enforcement on a server.
+
+The whitelist is synced first, then white-list and enforce-whitelist are
+set in server.properties. Running servers are switched on over RCON, which
+kicks players that are not whitelisted.
+
+With --whitelist, the named list is attached to the server first.`,
+		Example: `  # Enable whitelist on a server
+  go-mc whitelist enable survival
+
+  # Attach a named whitelist and enable it
+  go-mc whitelist enable vip-server --whitelist vip-players`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runEnable(cmd.Context(), cmd.OutOrStdout(), args[0], whitelist, jsonOutput)
+		},
+	}
+
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output in JSON format")
+	cmd.Flags().StringVarP(&whitelist, "whitelist", "w", "", "Attach this whitelist before enabling")
+
+	return cmd
+}
+
+// NewDisableCommand creates the whitelist disable command.
+func NewDisableCommand() *cobra.Command {
+	var jsonOutput bool
+
+	cmd := &cobra.Command{
+		Use:   "disable <server>",
+		Short: "Stop enforcing the whitelist on a server",
+		Long: `Disable whitelist enforcement on a server.
+
+Attached whitelists are kept, so enabling the whitelist again restores the
+same set of players.`,
+		Example: `  # Disable whitelist on a server
+  go-mc whitelist disable survival`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runSetEnabled(cmd.Context(), cmd.OutOrStdout(), args[0], false, jsonOutput)
+		},
+	}
+
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output in JSON format")
+
+	return cmd
+}
+
+func runEnable(ctx context.Context, w io.Writer, serverName, listName string, jsonOutput bool) error {
+	if listName != "" && listName != access.GlobalWhitelist {
+		serverState, err := loadServer(ctx, serverName)
+		if err != nil {
+			return outputError(w, jsonOutput, err)
+		}
+
+		if err := attachList(ctx, serverState, listName); err != nil && !errors.Is(err, errAlreadyAttached) {
+			return outputError(w, jsonOutput, err)
+		}
+	}
+
+	return runSetEnabled(ctx, w, serverName, true, jsonOutput)
+}
+
+func runSetEnabled(ctx context.Context, w io.Writer, serverName string, enabled, jsonOutput bool) error {
+	serverState, err := loadServer(ctx, serverName)
+	if err != nil {
+		return outputError(w, jsonOutput, err)
+	}
+
+	result, err := access.SetWhitelistEnabled(ctx, serverState, enabled)
+	if err != nil {
+		return outputError(w, jsonOutput, err)
+	}
+
+	verb := "Disabled"
+	if enabled {
+		verb = "Enabled"
+	}
+
+	message := fmt.Sprintf("%s whitelist on server %q", verb, serverName)
+	return outputServerChange(w, jsonOutput, message, "", result)
+}
@@ -0,0 +1,79 @@
+package whitelist
+
+import (
+	"bytes"
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/steviee/go-mc/internal/minecraft"
+	"github.com/steviee/go-mc/internal/state"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewEnableCommand(t *testing.T) {
+	cmd := NewEnableCommand()
+
+	assert.Equal(t, "enable <server>", cmd.Use)
+	assert.NotEmpty(t, cmd.Short)
+	assert.NotEmpty(t, cmd.Long)
+	assert.NotNil(t, cmd.Flags().Lookup("json"))
+}
+
+func TestNewDisableCommand(t *testing.T) {
+	cmd := NewDisableCommand()
+
+	assert.Equal(t, "disable <server>", cmd.Use)
+	assert.NotEmpty(t, cmd.Short)
+	assert.NotEmpty(t, cmd.Long)
+	assert.NotNil(t, cmd.Flags().Lookup("json"))
+}
+
+func TestRunSetEnabled(t *testing.T) {
+	ctx := context.Background()
+	serverState := setupServer(t, "survival")
+	propsPath := filepath.Join(serverState.Volumes.Data, minecraft.ServerPropertiesFile)
+
+	var buf bytes.Buffer
+	require.NoError(t, runSetEnabled(ctx, &buf, "survival", true, false))
+	assert.Contains(t, buf.String(), `Enabled whitelist on server "survival"`)
+
+	saved, err := state.LoadServerState(ctx, "survival")
+	require.NoError(t, err)
+	assert.True(t, saved.Whitelist.Enabled)
+
+	props, err := minecraft.ReadProperties(propsPath)
+	require.NoError(t, err)
+	assert.Equal(t, "true", props["white-list"])
+
+	buf.Reset()
+	require.NoError(t, runSetEnabled(ctx, &buf, "survival", false, false))
+	assert.Contains(t, buf.String(), `Disabled whitelist on server "survival"`)
+
+	props, err = minecraft.ReadProperties(propsPath)
+	require.NoError(t, err)
+	assert.Equal(t, "false", props["white-list"])
+
+	err = runSetEnabled(ctx, &buf, "missing", true, false)
+	require.Error(t, err)
+}
+
+func TestRunEnable_AttachesWhitelist(t *testing.T) {
+	ctx := context.Background()
+	setupServer(t, "survival")
+	require.NoError(t, state.SaveWhitelistState(ctx, state.NewWhitelistState("vip")))
+
+	var buf bytes.Buffer
+	require.NoError(t, runEnable(ctx, &buf, "survival", "vip", false))
+
+	// Enabling again with the same list is fine
+	require.NoError(t, runEnable(ctx, &buf, "survival", "vip", false))
+
+	saved, err := state.LoadServerState(ctx, "survival")
+	require.NoError(t, err)
+	assert.True(t, saved.Whitelist.Enabled)
+	assert.Equal(t, []string{"vip"}, saved.Whitelist.Lists)
+
+	require.Error(t, runEnable(ctx, &buf, "survival", "missing", false))
+}
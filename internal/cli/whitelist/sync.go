@@ -0,0 +1,146 @@
+package whitelist
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/steviee/go-mc/internal/access"
+	"github.com/steviee/go-mc/internal/state"
+)
+
+// NewSyncCommand creates the whitelist sync command.
+func NewSyncCommand() *cobra.Command {
+	var jsonOutput bool
+
+	cmd := &cobra.Command{
+		Use:   "sync [name]",
+		Short: "Write whitelists to servers",
+		Long: `Merge the global whitelist and all attached whitelists of each server into
+its whitelist.json.
+
+With a whitelist name, only servers using that list are synced; without
+arguments all servers are. Running servers are updated live over RCON
+(whitelist add/remove/reload); stopped servers pick up the file on their
+next start. Players that were whitelisted on the server itself are removed,
+since go-mc is the source of truth.
+
+Syncing happens automatically after attach, detach, enable, disable and
+users add/remove; this command is useful after editing files by hand.`,
+		Example: `  # Sync all servers
+  go-mc whitelist sync
+
+  # Sync servers using a specific whitelist
+  go-mc whitelist sync vip-players`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			name := ""
+			if len(args) > 0 {
+				name = args[0]
+			}
+			return runSync(cmd.Context(), cmd.OutOrStdout(), name, jsonOutput)
+		},
+	}
+
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output in JSON format")
+
+	return cmd
+}
+
+func runSync(ctx context.Context, w io.Writer, listName string, jsonOutput bool) error {
+	if listName != "" {
+		if err := state.ValidateWhitelistName(listName); err != nil {
+			return outputError(w, jsonOutput, fmt.Errorf("invalid whitelist name: %w", err))
+		}
+	}
+
+	results, err := access.SyncServers(ctx, listName)
+	if err != nil {
+		return outputError(w, jsonOutput, err)
+	}
+
+	failed := 0
+	for _, result := range results {
+		if result.Error != "" {
+			failed++
+		}
+	}
+
+	if jsonOutput {
+		status := "success"
+		if failed > 0 {
+			status = "error"
+		}
+
+		out := Output{
+			Status:  status,
+			Data:    results,
+			Message: fmt.Sprintf("Synced %d server(s)", len(results)-failed),
+		}
+
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(out); err != nil {
+			return err
+		}
+	} else {
+		if len(results) == 0 {
+			_, _ = fmt.Fprintln(w, "No servers to sync")
+		}
+		for _, result := range results {
+			outputSyncResultHuman(w, result)
+		}
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("failed to sync %d server(s)", failed)
+	}
+
+	return nil
+}
+
+// loadServer validates a server name and loads its state.
+func loadServer(ctx context.Context, name string) (*state.ServerState, error) {
+	if err := state.ValidateServerName(name); err != nil {
+		return nil, fmt.Errorf("invalid server name: %w", err)
+	}
+
+	serverState, err := state.LoadServerState(ctx, name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load server state: %w", err)
+	}
+
+	return serverState, nil
+}
+
+// outputSyncResultHuman prints a one-line summary of a server sync.
+func outputSyncResultHuman(w io.Writer, result access.WhitelistSyncResult) {
+	if result.Error != "" {
+		_, _ = fmt.Fprintf(w, "%s: sync failed: %s\n", result.Server, result.Error)
+		return
+	}
+
+	changes := []string{}
+	if len(result.Added) > 0 {
+		changes = append(changes, "+"+strings.Join(result.Added, ", +"))
+	}
+	if len(result.Removed) > 0 {
+		changes = append(changes, "-"+strings.Join(result.Removed, ", -"))
+	}
+	if len(changes) == 0 {
+		changes = append(changes, "no changes")
+	}
+
+	where := "on next start"
+	if result.Live {
+		where = "live"
+	}
+
+	_, _ = fmt.Fprintf(w, "%s: %d player(s), %s (applied %s)\n", result.Server, result.Players, strings.Join(changes, " "), where)
+	if result.Warning != "" {
+		_, _ = fmt.Fprintf(w, "  warning: %s\n", result.Warning)
+	}
+}
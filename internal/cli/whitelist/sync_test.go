@@ -0,0 +1,59 @@
+package whitelist
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/steviee/go-mc/internal/access"
+	"github.com/steviee/go-mc/internal/state"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewSyncCommand(t *testing.T) {
+	cmd := NewSyncCommand()
+
+	assert.Equal(t, "sync [name]", cmd.Use)
+	assert.NotEmpty(t, cmd.Short)
+	assert.NotEmpty(t, cmd.Long)
+	assert.NotNil(t, cmd.Flags().Lookup("json"))
+}
+
+func TestRunSync(t *testing.T) {
+	ctx := context.Background()
+	setupServer(t, "survival")
+
+	require.NoError(t, state.AddPlayer(ctx, access.GlobalWhitelist, state.PlayerInfo{
+		UUID: "069a79f4-44e9-4726-a5be-fca90e38aaf5",
+		Name: "Notch",
+	}))
+
+	t.Run("all servers", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, runSync(ctx, &buf, "", true))
+
+		var out struct {
+			Status string                       `json:"status"`
+			Data   []access.WhitelistSyncResult `json:"data"`
+		}
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &out))
+		assert.Equal(t, "success", out.Status)
+		require.Len(t, out.Data, 1)
+		assert.Equal(t, "survival", out.Data[0].Server)
+		assert.Equal(t, 1, out.Data[0].Players)
+	})
+
+	t.Run("global list without changes", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, runSync(ctx, &buf, access.GlobalWhitelist, false))
+		assert.Contains(t, buf.String(), "survival: 1 player(s), no changes (applied on next start)")
+	})
+
+	t.Run("list without servers", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, runSync(ctx, &buf, "unused", false))
+		assert.Contains(t, buf.String(), "No servers to sync")
+	})
+}
@@ -12,25 +12,29 @@ func NewCommand() *cobra.Command {
 		Long: `Manage Minecraft server whitelist with automatic UUID resolution.
 
 Commands in this group allow you to enable/disable whitelist enforcement,
-add/remove users from the whitelist, and manage global whitelist configuration
-that applies to all servers.`,
-		Example: `  # Enable whitelist on a server
+manage named whitelists and attach them to servers. The global whitelist
+("default") applies to all servers; attached lists are merged into it.
+
+Each server's whitelist.json is kept in sync automatically: running servers
+are updated over RCON, stopped servers on their next start.`,
+		Example: `  # Create a named whitelist and add players to it
+  go-mc whitelist create friends
+  go-mc users add --whitelist friends notch jeb_
+
+  # Attach the whitelist to a server
+  go-mc whitelist attach friends myserver
+
+  # Enable whitelist on a server
   go-mc whitelist enable myserver
 
   # Disable whitelist
   go-mc whitelist disable myserver
 
-  # Add users to whitelist
-  go-mc whitelist add myserver notch jeb_
-
-  # Remove user from whitelist
-  go-mc whitelist remove myserver notch
-
-  # List whitelisted users
-  go-mc whitelist list myserver
+  # Detach the whitelist again
+  go-mc whitelist detach friends myserver
 
-  # Set global whitelist for all servers
-  go-mc whitelist global add notch jeb_`,
+  # Write all whitelists to their servers
+  go-mc whitelist sync`,
 		Aliases: []string{"wl"},
 	}
 
@@ -38,10 +42,11 @@ that applies to all servers.`,
 	cmd.AddCommand(NewCreateCommand())
 	cmd.AddCommand(NewDeleteCommand())
 	cmd.AddCommand(NewListCommand())
-	// TODO: Add enable/disable/sync commands in future phases
-	// cmd.AddCommand(NewEnableCommand())
-	// cmd.AddCommand(NewDisableCommand())
-	// cmd.AddCommand(NewSyncCommand())
+	cmd.AddCommand(NewAttachCommand())
+	cmd.AddCommand(NewDetachCommand())
+	cmd.AddCommand(NewEnableCommand())
+	cmd.AddCommand(NewDisableCommand())
+	cmd.AddCommand(NewSyncCommand())
 
 	return cmd
 }
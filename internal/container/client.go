@@ -213,9 +213,13 @@ func (c *client) Info(ctx context.Context) (*RuntimeInfo, error) {
 	// Get OS and architecture
 	osInfo := ""
 	arch := ""
+	cpus := 0
+	var memTotal int64
 	if info.Host != nil {
 		osInfo = info.Host.OS
 		arch = info.Host.Arch
+		cpus = info.Host.CPUs
+		memTotal = info.Host.MemTotal
 	}
 
 	return &RuntimeInfo{
@@ -226,6 +230,8 @@ func (c *client) Info(ctx context.Context) (*RuntimeInfo, error) {
 		SocketPath: c.socketPath,
 		OS:         osInfo,
 		Arch:       arch,
+		CPUs:       cpus,
+		MemTotal:   memTotal,
 	}, nil
 }
 
@@ -202,6 +202,7 @@ type MockClient struct {
 	InspectContainerFunc  func(ctx context.Context, containerID string) (*ContainerInfo, error)
 	ListContainersFunc    func(ctx context.Context, opts *ListOptions) ([]*ContainerInfo, error)
 	GetContainerStatsFunc func(ctx context.Context, containerID string) (*ContainerStats, error)
+	LogsFunc              func(ctx context.Context, containerID string, opts LogOptions) (<-chan LogLine, error)
 }
 
 func (m *MockClient) Ping(ctx context.Context) error {
@@ -292,7 +293,7 @@ func (m *MockClient) InspectContainer(ctx context.Context, containerID string) (
 		State:   "running",
 		Status:  "Up",
 		Image:   "alpine:latest",
-		Ports:   map[int]int{},
+		Ports:   []PortMapping{},
 		Created: time.Now(),
 		Labels:  map[string]string{},
 	}, nil
@@ -309,7 +310,7 @@ func (m *MockClient) ListContainers(ctx context.Context, opts *ListOptions) ([]*
 			State:   "running",
 			Status:  "Up",
 			Image:   "alpine:latest",
-			Ports:   map[int]int{},
+			Ports:   []PortMapping{},
 			Created: time.Now(),
 			Labels:  map[string]string{},
 		},
@@ -328,6 +329,15 @@ func (m *MockClient) GetContainerStats(ctx context.Context, containerID string)
 	}, nil
 }
 
+func (m *MockClient) Logs(ctx context.Context, containerID string, opts LogOptions) (<-chan LogLine, error) {
+	if m.LogsFunc != nil {
+		return m.LogsFunc(ctx, containerID, opts)
+	}
+	lines := make(chan LogLine)
+	close(lines)
+	return lines, nil
+}
+
 func TestMockClient(t *testing.T) {
 	ctx := context.Background()
 
@@ -0,0 +1,198 @@
+package container
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/containers/podman/v5/pkg/bindings/containers"
+)
+
+// Logs streams the log lines of a container.
+//
+// Lines are read through the runtime API, so no podman or docker binary is
+// needed. Every line is tagged with the stream it was written to and the
+// time the container wrote it.
+//
+// The returned channel is closed when the logs end, or when ctx is canceled
+// in follow mode. A failure after the stream started is sent as a final
+// LogLine with Err set.
+//
+// Returns an error if:
+//   - Container does not exist
+//   - The runtime cannot be reached
+func (c *client) Logs(ctx context.Context, containerID string, opts LogOptions) (<-chan LogLine, error) {
+	slog.Debug("streaming container logs",
+		"id", containerID,
+		"follow", opts.Follow,
+		"tail", opts.Tail)
+
+	// Fail before streaming so a missing container is reported as an error
+	// instead of an empty log
+	timeoutCtx, cancel := context.WithTimeout(c.conn, c.timeout)
+	exists, err := containers.Exists(timeoutCtx, containerID, nil)
+	cancel()
+	if err != nil {
+		return nil, fmt.Errorf("failed to check if container exists: %w", err)
+	}
+	if !exists {
+		return nil, fmt.Errorf("%w: %s", ErrContainerNotFound, containerID)
+	}
+
+	// Use c.conn as base context (contains Podman client from bindings.NewConnection)
+	// and stop the request when the caller's context is canceled
+	streamCtx, stop := context.WithCancel(c.conn)
+	stopOnDone := context.AfterFunc(ctx, stop)
+
+	options := buildLogOptions(opts)
+	stdout := make(chan string)
+	stderr := make(chan string)
+	done := make(chan error, 1)
+
+	go func() {
+		done <- containers.Logs(streamCtx, containerID, options, stdout, stderr)
+	}()
+
+	lines := make(chan LogLine)
+
+	go func() {
+		defer close(lines)
+		defer stop()
+		defer stopOnDone()
+
+		demux := newLogDemuxer()
+
+		// send delivers lines until the caller goes away. The frames must
+		// still be drained afterwards so the bindings goroutine can exit.
+		canceled := false
+		send := func(line LogLine) {
+			if canceled {
+				return
+			}
+			select {
+			case lines <- line:
+			case <-ctx.Done():
+				canceled = true
+			}
+		}
+
+		for {
+			select {
+			case frame := <-stdout:
+				for _, line := range demux.write(LogStdout, frame) {
+					send(line)
+				}
+			case frame := <-stderr:
+				for _, line := range demux.write(LogStderr, frame) {
+					send(line)
+				}
+			case err := <-done:
+				for _, line := range demux.flush() {
+					send(line)
+				}
+				if err != nil && ctx.Err() == nil {
+					send(LogLine{Err: fmt.Errorf("failed to stream logs: %w", err)})
+				}
+				return
+			}
+		}
+	}()
+
+	return lines, nil
+}
+
+// buildLogOptions converts LogOptions to the bindings options. Timestamps
+// are always requested so each line carries the time it was written.
+func buildLogOptions(opts LogOptions) *containers.LogOptions {
+	options := new(containers.LogOptions).
+		WithStdout(true).
+		WithStderr(true).
+		WithTimestamps(true).
+		WithFollow(opts.Follow)
+
+	if opts.Tail > 0 {
+		options.WithTail(strconv.Itoa(opts.Tail))
+	}
+	if !opts.Since.IsZero() {
+		options.WithSince(strconv.FormatInt(opts.Since.Unix(), 10))
+	}
+
+	return options
+}
+
+// logDemuxer turns the frames of a log stream into lines.
+//
+// Each frame starts with the timestamp of the line it belongs to. A long
+// line may span several frames; only the last one ends with a newline, so
+// partial lines are held per stream until they are complete.
+type logDemuxer struct {
+	pending map[string]*LogLine
+}
+
+// newLogDemuxer creates an empty logDemuxer.
+func newLogDemuxer() *logDemuxer {
+	return &logDemuxer{pending: make(map[string]*LogLine)}
+}
+
+// write adds a frame of the given stream and returns the lines it completes.
+func (d *logDemuxer) write(stream, frame string) []LogLine {
+	ts, text := splitLogTimestamp(frame)
+
+	var lines []LogLine
+	for {
+		before, after, complete := strings.Cut(text, "\n")
+
+		line := d.pending[stream]
+		if line == nil {
+			line = &LogLine{Stream: stream, Time: ts}
+			d.pending[stream] = line
+		}
+		line.Text += before
+
+		if !complete {
+			break
+		}
+
+		line.Text = strings.TrimSuffix(line.Text, "\r")
+		lines = append(lines, *line)
+		delete(d.pending, stream)
+
+		if after == "" {
+			break
+		}
+		text = after
+	}
+
+	return lines
+}
+
+// flush returns the partial lines left when the stream ends.
+func (d *logDemuxer) flush() []LogLine {
+	var lines []LogLine
+	for _, stream := range []string{LogStdout, LogStderr} {
+		if line := d.pending[stream]; line != nil && line.Text != "" {
+			lines = append(lines, *line)
+		}
+	}
+	d.pending = make(map[string]*LogLine)
+	return lines
+}
+
+// splitLogTimestamp splits the RFC 3339 timestamp the runtime puts in front
+// of a frame from its text. Frames without a timestamp are returned as is.
+func splitLogTimestamp(frame string) (time.Time, string) {
+	prefix, text, found := strings.Cut(frame, " ")
+	if !found {
+		return time.Time{}, frame
+	}
+
+	ts, err := time.Parse(time.RFC3339Nano, prefix)
+	if err != nil {
+		return time.Time{}, frame
+	}
+
+	return ts, text
+}
@@ -0,0 +1,129 @@
+package container
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLogDemuxer(t *testing.T) {
+	ts := time.Date(2025, 1, 2, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name   string
+		frames [][2]string // stream, frame
+		want   []LogLine
+	}{
+		{
+			name: "one line per frame",
+			frames: [][2]string{
+				{LogStdout, "2025-01-02T12:00:00Z [Server thread/INFO]: Starting\n"},
+				{LogStderr, "2025-01-02T12:00:00Z Exception in thread\n"},
+			},
+			want: []LogLine{
+				{Stream: LogStdout, Time: ts, Text: "[Server thread/INFO]: Starting"},
+				{Stream: LogStderr, Time: ts, Text: "Exception in thread"},
+			},
+		},
+		{
+			name: "partial frames are joined",
+			frames: [][2]string{
+				{LogStdout, "2025-01-02T12:00:00Z Done "},
+				{LogStderr, "2025-01-02T12:00:01Z warning\n"},
+				{LogStdout, "2025-01-02T12:00:01Z (1.5s)!\n"},
+			},
+			want: []LogLine{
+				{Stream: LogStderr, Time: ts.Add(time.Second), Text: "warning"},
+				{Stream: LogStdout, Time: ts, Text: "Done (1.5s)!"},
+			},
+		},
+		{
+			name: "several lines in one frame",
+			frames: [][2]string{
+				{LogStdout, "2025-01-02T12:00:00Z first\r\nsecond\n"},
+			},
+			want: []LogLine{
+				{Stream: LogStdout, Time: ts, Text: "first"},
+				{Stream: LogStdout, Time: ts, Text: "second"},
+			},
+		},
+		{
+			name: "frame without timestamp",
+			frames: [][2]string{
+				{LogStdout, "plain line\n"},
+				{LogStdout, "\n"},
+			},
+			want: []LogLine{
+				{Stream: LogStdout, Text: "plain line"},
+				{Stream: LogStdout, Text: ""},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			demux := newLogDemuxer()
+
+			var got []LogLine
+			for _, f := range tt.frames {
+				got = append(got, demux.write(f[0], f[1])...)
+			}
+
+			assert.Equal(t, tt.want, got)
+			assert.Empty(t, demux.flush())
+		})
+	}
+}
+
+func TestLogDemuxer_Flush(t *testing.T) {
+	demux := newLogDemuxer()
+
+	assert.Empty(t, demux.write(LogStdout, "2025-01-02T12:00:00Z no newline"))
+
+	lines := demux.flush()
+	require.Len(t, lines, 1)
+	assert.Equal(t, "no newline", lines[0].Text)
+	assert.Empty(t, demux.flush())
+}
+
+func TestSplitLogTimestamp(t *testing.T) {
+	ts, text := splitLogTimestamp("2025-01-02T12:00:00.123456789+01:00 hello world\n")
+	assert.Equal(t, "hello world\n", text)
+	assert.True(t, ts.Equal(time.Date(2025, 1, 2, 11, 0, 0, 123456789, time.UTC)))
+
+	ts, text = splitLogTimestamp("[12:00:00] [Server thread/INFO]: hello\n")
+	assert.True(t, ts.IsZero())
+	assert.Equal(t, "[12:00:00] [Server thread/INFO]: hello\n", text)
+}
+
+func TestBuildLogOptions(t *testing.T) {
+	options := buildLogOptions(LogOptions{})
+	assert.True(t, options.GetTimestamps())
+	assert.True(t, options.GetStdout())
+	assert.True(t, options.GetStderr())
+	assert.False(t, options.GetFollow())
+	assert.False(t, options.Changed("Tail"))
+	assert.False(t, options.Changed("Since"))
+
+	since := time.Unix(1735819200, 0)
+	options = buildLogOptions(LogOptions{Follow: true, Tail: 50, Since: since})
+	assert.True(t, options.GetFollow())
+	assert.Equal(t, "50", options.GetTail())
+	assert.Equal(t, "1735819200", options.GetSince())
+}
+
+func TestLogLine_Format(t *testing.T) {
+	line := LogLine{
+		Stream: LogStdout,
+		Time:   time.Date(2025, 1, 2, 12, 0, 0, 500000000, time.UTC),
+		Text:   "Done (1.5s)!",
+	}
+
+	assert.Equal(t, "Done (1.5s)!", line.Format(false))
+	assert.Equal(t, "2025-01-02T12:00:00.5Z Done (1.5s)!", line.Format(true))
+
+	// Lines without a time are never prefixed
+	assert.Equal(t, "text", LogLine{Text: "text"}.Format(true))
+}
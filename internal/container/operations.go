@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"net"
 	"strconv"
 	"strings"
 	"time"
@@ -11,6 +12,7 @@ import (
 	nettypes "github.com/containers/common/libnetwork/types"
 	"github.com/containers/podman/v5/libpod/define"
 	"github.com/containers/podman/v5/pkg/bindings/containers"
+	"github.com/containers/podman/v5/pkg/bindings/images"
 	"github.com/containers/podman/v5/pkg/domain/entities"
 	"github.com/containers/podman/v5/pkg/specgen"
 	"github.com/docker/go-units"
@@ -19,8 +21,9 @@ import (
 
 // CreateContainer creates a new container with the given configuration.
 //
-// It validates the configuration, converts it to a Podman spec, and creates
-// the container. The container is created in a stopped state.
+// It pulls the image according to the pull policy, if one is set, converts
+// the configuration to a Podman spec, and creates the container. The
+// container is created in a stopped state.
 //
 // Returns the container ID on success, or an error if:
 //   - A container with the same name already exists
@@ -41,6 +44,12 @@ func (c *client) CreateContainer(ctx context.Context, config *ContainerConfig) (
 		return "", fmt.Errorf("%w: %s", ErrContainerAlreadyExists, config.Name)
 	}
 
+	if config.PullPolicy != "" {
+		if err := c.pullImage(config.Image, config.PullPolicy); err != nil {
+			return "", err
+		}
+	}
+
 	// Convert config to Podman spec
 	spec, err := c.buildContainerSpec(config)
 	if err != nil {
@@ -64,6 +73,19 @@ func (c *client) CreateContainer(ctx context.Context, config *ContainerConfig) (
 	return response.ID, nil
 }
 
+// pullImage pulls image according to policy. Pulling is not bound by the
+// client timeout since large images take longer to download.
+func (c *client) pullImage(image, policy string) error {
+	slog.Debug("pulling image", "image", image, "policy", policy)
+
+	opts := new(images.PullOptions).WithPolicy(policy).WithQuiet(true)
+	if _, err := images.Pull(c.conn, image, opts); err != nil {
+		return fmt.Errorf("failed to pull image %s (pull policy %s): %w", image, policy, err)
+	}
+
+	return nil
+}
+
 // buildContainerSpec converts ContainerConfig to Podman SpecGenerator.
 func (c *client) buildContainerSpec(config *ContainerConfig) (*specgen.SpecGenerator, error) {
 	spec := &specgen.SpecGenerator{
@@ -122,26 +144,53 @@ func (c *client) buildContainerSpec(config *ContainerConfig) (*specgen.SpecGener
 		return nil, fmt.Errorf("failed to set resource limits: %w", err)
 	}
 
+	// Set restart policy
+	if err := setRestartPolicy(spec, config); err != nil {
+		return nil, err
+	}
+
 	return spec, nil
 }
 
-// buildPortMappings converts port map to Podman port mappings.
-func (c *client) buildPortMappings(ports map[int]int) ([]nettypes.PortMapping, error) {
+// buildPortMappings converts port mappings to Podman port mappings.
+// Mappings without a host IP are published on all addresses and mappings
+// without a protocol use TCP.
+func (c *client) buildPortMappings(ports []PortMapping) ([]nettypes.PortMapping, error) {
 	mappings := make([]nettypes.PortMapping, 0, len(ports))
+	seen := make(map[PortMapping]bool, len(ports))
+
+	for _, port := range ports {
+		if port.HostPort < 1 || port.HostPort > 65535 {
+			return nil, fmt.Errorf("invalid host port: %d", port.HostPort)
+		}
+		if port.ContainerPort < 1 || port.ContainerPort > 65535 {
+			return nil, fmt.Errorf("invalid container port: %d", port.ContainerPort)
+		}
 
-	for hostPort, containerPort := range ports {
-		if hostPort < 1 || hostPort > 65535 {
-			return nil, fmt.Errorf("invalid host port: %d", hostPort)
+		port = port.normalize()
+		if port.Protocol != ProtocolTCP && port.Protocol != ProtocolUDP {
+			return nil, fmt.Errorf("invalid protocol %q for port %d (must be tcp or udp)", port.Protocol, port.HostPort)
 		}
-		if containerPort < 1 || containerPort > 65535 {
-			return nil, fmt.Errorf("invalid container port: %d", containerPort)
+		if port.HostIP != "" && net.ParseIP(port.HostIP) == nil {
+			return nil, fmt.Errorf("invalid host IP %q for port %d", port.HostIP, port.HostPort)
+		}
+
+		key := PortMapping{HostIP: port.HostIP, HostPort: port.HostPort, Protocol: port.Protocol}
+		if seen[key] {
+			return nil, fmt.Errorf("host port %d/%s is published twice", port.HostPort, port.Protocol)
+		}
+		seen[key] = true
+
+		hostIP := port.HostIP
+		if hostIP == "" {
+			hostIP = "0.0.0.0"
 		}
 
 		mappings = append(mappings, nettypes.PortMapping{
-			HostPort:      uint16(hostPort),
-			ContainerPort: uint16(containerPort),
-			Protocol:      "tcp",
-			HostIP:        "0.0.0.0",
+			HostPort:      uint16(port.HostPort),
+			ContainerPort: uint16(port.ContainerPort),
+			Protocol:      port.Protocol,
+			HostIP:        hostIP,
 		})
 	}
 
@@ -174,7 +223,8 @@ func (c *client) buildVolumeMounts(volumes map[string]string) ([]spec.Mount, err
 	return mounts, nil
 }
 
-// setResourceLimits sets CPU and memory limits on the spec.
+// setResourceLimits sets the memory, swap, CPU, PID and block I/O limits on
+// the spec.
 func (c *client) setResourceLimits(s *specgen.SpecGenerator, config *ContainerConfig) error {
 	// Set memory limit
 	if config.Memory != "" {
@@ -202,6 +252,75 @@ func (c *client) setResourceLimits(s *specgen.SpecGenerator, config *ContainerCo
 		s.ResourceLimits.CPU.Quota = &config.CPUQuota
 	}
 
+	// Set memory plus swap limit
+	if config.MemorySwap != "" {
+		if s.ResourceLimits == nil || s.ResourceLimits.Memory == nil || s.ResourceLimits.Memory.Limit == nil {
+			return fmt.Errorf("memory swap limit requires a memory limit")
+		}
+		swapBytes, err := parseMemory(config.MemorySwap)
+		if err != nil {
+			return fmt.Errorf("%w: %s", ErrInvalidMemoryFormat, config.MemorySwap)
+		}
+		if swapBytes < *s.ResourceLimits.Memory.Limit {
+			return fmt.Errorf("memory swap limit %s is below the memory limit %s", config.MemorySwap, config.Memory)
+		}
+		s.ResourceLimits.Memory.Swap = &swapBytes
+	}
+
+	// Set CPU shares
+	if config.CPUShares > 0 {
+		if s.ResourceLimits == nil {
+			s.ResourceLimits = &spec.LinuxResources{}
+		}
+		if s.ResourceLimits.CPU == nil {
+			s.ResourceLimits.CPU = &spec.LinuxCPU{}
+		}
+		shares := config.CPUShares
+		s.ResourceLimits.CPU.Shares = &shares
+	}
+
+	// Set PID limit
+	if config.PidsLimit > 0 {
+		if s.ResourceLimits == nil {
+			s.ResourceLimits = &spec.LinuxResources{}
+		}
+		s.ResourceLimits.Pids = &spec.LinuxPids{Limit: config.PidsLimit}
+	}
+
+	// Set block I/O weight
+	if config.IOWeight > 0 {
+		if s.ResourceLimits == nil {
+			s.ResourceLimits = &spec.LinuxResources{}
+		}
+		weight := config.IOWeight
+		s.ResourceLimits.BlockIO = &spec.LinuxBlockIO{Weight: &weight}
+	}
+
+	return nil
+}
+
+// setRestartPolicy sets the restart policy on the spec.
+func setRestartPolicy(s *specgen.SpecGenerator, config *ContainerConfig) error {
+	switch config.RestartPolicy {
+	case "", define.RestartPolicyNo:
+		if config.RestartRetries > 0 {
+			return fmt.Errorf("restart retries require the on-failure restart policy")
+		}
+		return nil
+	case define.RestartPolicyOnFailure:
+		if config.RestartRetries > 0 {
+			retries := config.RestartRetries
+			s.RestartRetries = &retries
+		}
+	case define.RestartPolicyAlways:
+		if config.RestartRetries > 0 {
+			return fmt.Errorf("restart retries require the on-failure restart policy")
+		}
+	default:
+		return fmt.Errorf("invalid restart policy %q (must be no, on-failure or always)", config.RestartPolicy)
+	}
+
+	s.RestartPolicy = config.RestartPolicy
 	return nil
 }
 
@@ -246,6 +365,9 @@ func (c *client) StartContainer(ctx context.Context, containerID string) error {
 //   - "not-running": Wait until container stops
 //   - "removed": Wait until container is removed
 //
+// Waiting ends at the deadline of ctx, or after the client timeout if ctx
+// has none.
+//
 // Returns an error if:
 //   - Container does not exist
 //   - Invalid condition specified
@@ -291,15 +413,17 @@ func (c *client) WaitForContainer(ctx context.Context, containerID string, condi
 	}
 
 	// For other conditions, use Podman's Wait API
-	// Use c.conn as base context (contains Podman client from bindings.NewConnection)
-	timeoutCtx, cancel := context.WithTimeout(c.conn, c.timeout)
+	waitCtx, cancel := c.waitContext(ctx)
 	defer cancel()
 
 	opts := new(containers.WaitOptions)
 	opts.Conditions = []string{condition}
 
-	_, err := containers.Wait(timeoutCtx, containerID, opts)
+	_, err := containers.Wait(waitCtx, containerID, opts)
 	if err != nil {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
 		if strings.Contains(err.Error(), "no such container") {
 			return fmt.Errorf("%w: %s", ErrContainerNotFound, containerID)
 		}
@@ -309,6 +433,25 @@ func (c *client) WaitForContainer(ctx context.Context, containerID string, condi
 	return nil
 }
 
+// waitContext returns the context for a Podman wait. It is based on c.conn,
+// which carries the Podman client, and ends with ctx: at its deadline, or
+// after the client timeout if ctx has none.
+func (c *client) waitContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	var waitCtx context.Context
+	var cancel context.CancelFunc
+	if deadline, ok := ctx.Deadline(); ok {
+		waitCtx, cancel = context.WithDeadline(c.conn, deadline)
+	} else {
+		waitCtx, cancel = context.WithTimeout(c.conn, c.timeout)
+	}
+
+	stop := context.AfterFunc(ctx, cancel)
+	return waitCtx, func() {
+		stop()
+		cancel()
+	}
+}
+
 // StopContainer stops a running container with optional timeout.
 //
 // The container is sent a SIGTERM signal and given the timeout duration
@@ -465,35 +608,75 @@ func (c *client) InspectContainer(ctx context.Context, containerID string) (*Con
 // convertInspectData converts Podman inspect data to ContainerInfo.
 func (c *client) convertInspectData(data *define.InspectContainerData) *ContainerInfo {
 	info := &ContainerInfo{
-		ID:      data.ID,
-		Name:    data.Name,
-		State:   data.State.Status,
-		Status:  data.State.Status,
-		Image:   data.ImageName,
-		Labels:  data.Config.Labels,
-		Ports:   make(map[int]int),
-		Created: data.Created,
-	}
-
-	// Convert port mappings from NetworkSettings
-	if data.NetworkSettings != nil && data.NetworkSettings.Ports != nil {
+		ID:        data.ID,
+		Name:      data.Name,
+		State:     data.State.Status,
+		Status:    data.State.Status,
+		Image:     data.ImageName,
+		Labels:    data.Config.Labels,
+		Created:   data.Created,
+		StartedAt: data.State.StartedAt,
+
+		ExitCode:     int(data.State.ExitCode),
+		RestartCount: int(data.RestartCount),
+	}
+
+	if data.HostConfig != nil && data.HostConfig.RestartPolicy != nil {
+		info.RestartPolicy = data.HostConfig.RestartPolicy.Name
+		info.RestartRetries = data.HostConfig.RestartPolicy.MaximumRetryCount
+	}
+
+	if hc := data.HostConfig; hc != nil {
+		info.Resources = Resources{
+			Memory:     hc.Memory,
+			MemorySwap: hc.MemorySwap,
+			CPUQuota:   hc.CpuQuota,
+			CPUShares:  hc.CpuShares,
+			PidsLimit:  hc.PidsLimit,
+			IOWeight:   hc.BlkioWeight,
+		}
+	}
+
+	if len(data.Config.Env) > 0 {
+		info.Env = make(map[string]string, len(data.Config.Env))
+		for _, kv := range data.Config.Env {
+			key, value, _ := strings.Cut(kv, "=")
+			info.Env[key] = value
+		}
+	}
+
+	for _, m := range data.Mounts {
+		info.Mounts = append(info.Mounts, Mount{
+			Source:      m.Source,
+			Destination: m.Destination,
+			ReadOnly:    !m.RW,
+		})
+	}
+
+	// Convert port mappings from NetworkSettings ("25565/tcp" -> bindings)
+	if data.NetworkSettings != nil {
 		for portProto, bindings := range data.NetworkSettings.Ports {
-			if len(bindings) > 0 && bindings[0].HostPort != "" {
-				// Extract port number from "port/protocol" format
-				portStr := strings.Split(string(portProto), "/")[0]
-				containerPort, err := strconv.Atoi(portStr)
-				if err != nil {
-					continue
-				}
+			portStr, protocol, _ := strings.Cut(portProto, "/")
+			containerPort, err := strconv.Atoi(portStr)
+			if err != nil {
+				continue
+			}
 
-				hostPort, err := strconv.Atoi(bindings[0].HostPort)
-				if err != nil {
+			for _, binding := range bindings {
+				hostPort, err := strconv.Atoi(binding.HostPort)
+				if err != nil || hostPort == 0 {
 					continue
 				}
 
-				info.Ports[hostPort] = containerPort
+				info.Ports = append(info.Ports, PortMapping{
+					HostIP:        binding.HostIP,
+					HostPort:      hostPort,
+					ContainerPort: containerPort,
+					Protocol:      protocol,
+				}.normalize())
 			}
 		}
+		SortPorts(info.Ports)
 	}
 
 	return info
@@ -546,7 +729,6 @@ func (c *client) ListContainers(ctx context.Context, opts *ListOptions) ([]*Cont
 			Image:   container.Image,
 			Labels:  container.Labels,
 			Created: container.Created,
-			Ports:   make(map[int]int),
 		}
 
 		// Extract name (remove leading slash if present)
@@ -554,12 +736,21 @@ func (c *client) ListContainers(ctx context.Context, opts *ListOptions) ([]*Cont
 			info.Name = strings.TrimPrefix(container.Names[0], "/")
 		}
 
-		// Convert port mappings
+		// Convert port mappings, expanding port ranges
 		for _, port := range container.Ports {
-			if port.HostPort > 0 && port.ContainerPort > 0 {
-				info.Ports[int(port.HostPort)] = int(port.ContainerPort)
+			if port.HostPort == 0 || port.ContainerPort == 0 {
+				continue
+			}
+			for i := 0; i < max(int(port.Range), 1); i++ {
+				info.Ports = append(info.Ports, PortMapping{
+					HostIP:        port.HostIP,
+					HostPort:      int(port.HostPort) + i,
+					ContainerPort: int(port.ContainerPort) + i,
+					Protocol:      port.Protocol,
+				}.normalize())
 			}
 		}
+		SortPorts(info.Ports)
 
 		result = append(result, info)
 	}
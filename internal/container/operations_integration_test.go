@@ -145,8 +145,9 @@ func TestContainerWithPorts(t *testing.T) {
 	config := &ContainerConfig{
 		Name:  containerName,
 		Image: "docker.io/library/nginx:alpine",
-		Ports: map[int]int{
-			18080: 80,
+		Ports: []PortMapping{
+			{HostPort: 18080, ContainerPort: 80},
+			{HostPort: 18081, ContainerPort: 8081, Protocol: ProtocolUDP},
 		},
 	}
 
@@ -160,7 +161,54 @@ func TestContainerWithPorts(t *testing.T) {
 
 	info, err := client.InspectContainer(ctx, containerName)
 	require.NoError(t, err)
-	assert.Contains(t, info.Ports, 18080)
+	assert.True(t, SamePorts(config.Ports, info.Ports), "published ports: %v", info.Ports)
+}
+
+// TestContainerLogs tests streaming the stdout and stderr of a container.
+func TestContainerLogs(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+
+	ctx := context.Background()
+	client, err := NewClient(ctx, DefaultConfig())
+	require.NoError(t, err)
+	defer client.Close()
+
+	containerName := "go-mc-test-logs"
+	defer client.RemoveContainer(ctx, containerName, &RemoveOptions{Force: true})
+
+	config := &ContainerConfig{
+		Name:    containerName,
+		Image:   "docker.io/library/alpine:latest",
+		Command: []string{"sh", "-c", "echo out; echo err >&2; sleep 300"},
+	}
+
+	_, err = client.CreateContainer(ctx, config)
+	require.NoError(t, err)
+	require.NoError(t, client.StartContainer(ctx, containerName))
+
+	// Follow until both lines arrived, then stop the stream
+	followCtx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	lines, err := client.Logs(followCtx, containerName, LogOptions{Follow: true})
+	require.NoError(t, err)
+
+	streams := map[string]string{}
+	for line := range lines {
+		require.NoError(t, line.Err)
+		assert.False(t, line.Time.IsZero())
+		streams[line.Stream] = line.Text
+		if len(streams) == 2 {
+			cancel()
+		}
+	}
+
+	assert.Equal(t, map[string]string{LogStdout: "out", LogStderr: "err"}, streams)
+
+	_, err = client.Logs(ctx, "go-mc-test-nonexistent", LogOptions{})
+	assert.ErrorIs(t, err, ErrContainerNotFound)
 }
 
 // TestContainerWithVolumes tests container creation with volume mounts.
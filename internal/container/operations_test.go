@@ -5,6 +5,8 @@ import (
 	"testing"
 	"time"
 
+	nettypes "github.com/containers/common/libnetwork/types"
+	"github.com/containers/podman/v5/libpod/define"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -158,62 +160,94 @@ func TestBuildPortMappings(t *testing.T) {
 
 	tests := []struct {
 		name    string
-		ports   map[int]int
+		ports   []PortMapping
+		want    []nettypes.PortMapping
 		wantErr bool
 		errMsg  string
 	}{
 		{
-			name: "valid single port",
-			ports: map[int]int{
-				8080: 80,
-			},
-			wantErr: false,
+			name:  "valid single port",
+			ports: []PortMapping{{HostPort: 8080, ContainerPort: 80}},
+			want:  []nettypes.PortMapping{{HostIP: "0.0.0.0", HostPort: 8080, ContainerPort: 80, Protocol: "tcp"}},
 		},
 		{
 			name: "valid multiple ports",
-			ports: map[int]int{
-				8080:  80,
-				25565: 25565,
-				3306:  3306,
+			ports: []PortMapping{
+				{HostPort: 25565, ContainerPort: 25565},
+				{HostPort: 25575, ContainerPort: 25575, Protocol: ProtocolTCP},
+				{HostPort: 24455, ContainerPort: 24454, Protocol: ProtocolUDP},
+			},
+			want: []nettypes.PortMapping{
+				{HostIP: "0.0.0.0", HostPort: 25565, ContainerPort: 25565, Protocol: "tcp"},
+				{HostIP: "0.0.0.0", HostPort: 25575, ContainerPort: 25575, Protocol: "tcp"},
+				{HostIP: "0.0.0.0", HostPort: 24455, ContainerPort: 24454, Protocol: "udp"},
 			},
-			wantErr: false,
 		},
 		{
-			name: "invalid host port too low",
-			ports: map[int]int{
-				0: 80,
+			name:  "host IP",
+			ports: []PortMapping{{HostIP: "127.0.0.1", HostPort: 8100, ContainerPort: 8100}},
+			want:  []nettypes.PortMapping{{HostIP: "127.0.0.1", HostPort: 8100, ContainerPort: 8100, Protocol: "tcp"}},
+		},
+		{
+			name: "same port with tcp and udp",
+			ports: []PortMapping{
+				{HostPort: 19132, ContainerPort: 19132, Protocol: ProtocolTCP},
+				{HostPort: 19132, ContainerPort: 19132, Protocol: ProtocolUDP},
+			},
+			want: []nettypes.PortMapping{
+				{HostIP: "0.0.0.0", HostPort: 19132, ContainerPort: 19132, Protocol: "tcp"},
+				{HostIP: "0.0.0.0", HostPort: 19132, ContainerPort: 19132, Protocol: "udp"},
 			},
+		},
+		{
+			name:    "invalid host port too low",
+			ports:   []PortMapping{{HostPort: 0, ContainerPort: 80}},
 			wantErr: true,
 			errMsg:  "invalid host port",
 		},
 		{
-			name: "invalid host port too high",
-			ports: map[int]int{
-				65536: 80,
-			},
+			name:    "invalid host port too high",
+			ports:   []PortMapping{{HostPort: 65536, ContainerPort: 80}},
 			wantErr: true,
 			errMsg:  "invalid host port",
 		},
 		{
-			name: "invalid container port too low",
-			ports: map[int]int{
-				8080: 0,
-			},
+			name:    "invalid container port too low",
+			ports:   []PortMapping{{HostPort: 8080, ContainerPort: 0}},
 			wantErr: true,
 			errMsg:  "invalid container port",
 		},
 		{
-			name: "invalid container port too high",
-			ports: map[int]int{
-				8080: 65536,
-			},
+			name:    "invalid container port too high",
+			ports:   []PortMapping{{HostPort: 8080, ContainerPort: 65536}},
 			wantErr: true,
 			errMsg:  "invalid container port",
 		},
 		{
-			name:    "empty ports",
-			ports:   map[int]int{},
-			wantErr: false,
+			name:    "invalid protocol",
+			ports:   []PortMapping{{HostPort: 8080, ContainerPort: 80, Protocol: "sctp"}},
+			wantErr: true,
+			errMsg:  "invalid protocol",
+		},
+		{
+			name:    "invalid host IP",
+			ports:   []PortMapping{{HostIP: "localhost", HostPort: 8080, ContainerPort: 80}},
+			wantErr: true,
+			errMsg:  "invalid host IP",
+		},
+		{
+			name: "duplicate host port",
+			ports: []PortMapping{
+				{HostPort: 8080, ContainerPort: 80},
+				{HostPort: 8080, ContainerPort: 81, Protocol: ProtocolTCP},
+			},
+			wantErr: true,
+			errMsg:  "published twice",
+		},
+		{
+			name:  "empty ports",
+			ports: []PortMapping{},
+			want:  []nettypes.PortMapping{},
 		},
 	}
 
@@ -228,18 +262,65 @@ func TestBuildPortMappings(t *testing.T) {
 				return
 			}
 			require.NoError(t, err)
-			assert.Len(t, mappings, len(tt.ports))
-
-			// Verify port mappings
-			for _, mapping := range mappings {
-				hostPort := int(mapping.HostPort)
-				containerPort := int(mapping.ContainerPort)
-				expectedContainer, ok := tt.ports[hostPort]
-				assert.True(t, ok, "host port %d not found in input", hostPort)
-				assert.Equal(t, expectedContainer, containerPort)
-				assert.Equal(t, "tcp", mapping.Protocol)
-				assert.Equal(t, "0.0.0.0", mapping.HostIP)
-			}
+			assert.Equal(t, tt.want, mappings)
+		})
+	}
+}
+
+func TestPortMapping_String(t *testing.T) {
+	tests := []struct {
+		port PortMapping
+		want string
+	}{
+		{port: PortMapping{HostPort: 25565, ContainerPort: 25565}, want: "25565->25565/tcp"},
+		{port: PortMapping{HostIP: "0.0.0.0", HostPort: 24455, ContainerPort: 24454, Protocol: "udp"}, want: "24455->24454/udp"},
+		{port: PortMapping{HostIP: "127.0.0.1", HostPort: 8100, ContainerPort: 8100}, want: "127.0.0.1:8100->8100/tcp"},
+		{port: PortMapping{HostIP: "::1", HostPort: 8100, ContainerPort: 8100}, want: "[::1]:8100->8100/tcp"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.port.String())
+		})
+	}
+}
+
+func TestSamePorts(t *testing.T) {
+	game := PortMapping{HostPort: 25565, ContainerPort: 25565}
+	voice := PortMapping{HostPort: 24454, ContainerPort: 24454, Protocol: ProtocolUDP}
+
+	tests := []struct {
+		name string
+		a, b []PortMapping
+		want bool
+	}{
+		{name: "both empty", want: true},
+		{name: "different order", a: []PortMapping{game, voice}, b: []PortMapping{voice, game}, want: true},
+		{
+			name: "defaults are equal",
+			a:    []PortMapping{game},
+			b:    []PortMapping{{HostIP: "0.0.0.0", HostPort: 25565, ContainerPort: 25565, Protocol: "TCP"}},
+			want: true,
+		},
+		{name: "missing port", a: []PortMapping{game, voice}, b: []PortMapping{game}, want: false},
+		{
+			name: "different protocol",
+			a:    []PortMapping{voice},
+			b:    []PortMapping{{HostPort: 24454, ContainerPort: 24454, Protocol: ProtocolTCP}},
+			want: false,
+		},
+		{
+			name: "different host IP",
+			a:    []PortMapping{game},
+			b:    []PortMapping{{HostIP: "127.0.0.1", HostPort: 25565, ContainerPort: 25565}},
+			want: false,
+		},
+		{name: "duplicates count", a: []PortMapping{game, game}, b: []PortMapping{game, voice}, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, SamePorts(tt.a, tt.b))
 		})
 	}
 }
@@ -394,6 +475,41 @@ func TestSetResourceLimits(t *testing.T) {
 	}
 }
 
+func TestSetResourceLimits_All(t *testing.T) {
+	c := &client{}
+
+	s, err := c.buildContainerSpec(&ContainerConfig{
+		Name:       "test",
+		Image:      "alpine:latest",
+		Memory:     "5G",
+		MemorySwap: "6G",
+		CPUQuota:   150000,
+		CPUShares:  512,
+		PidsLimit:  1024,
+		IOWeight:   200,
+	})
+	require.NoError(t, err)
+
+	limits := s.ResourceLimits
+	require.NotNil(t, limits)
+	assert.Equal(t, int64(5<<30), *limits.Memory.Limit)
+	assert.Equal(t, int64(6<<30), *limits.Memory.Swap)
+	assert.Equal(t, int64(150000), *limits.CPU.Quota)
+	assert.Equal(t, uint64(512), *limits.CPU.Shares)
+	assert.Equal(t, int64(1024), limits.Pids.Limit)
+	assert.Equal(t, uint16(200), *limits.BlockIO.Weight)
+}
+
+func TestSetResourceLimits_SwapErrors(t *testing.T) {
+	c := &client{}
+
+	_, err := c.buildContainerSpec(&ContainerConfig{Name: "test", Image: "alpine", MemorySwap: "6G"})
+	assert.ErrorContains(t, err, "requires a memory limit")
+
+	_, err = c.buildContainerSpec(&ContainerConfig{Name: "test", Image: "alpine", Memory: "4G", MemorySwap: "2G"})
+	assert.ErrorContains(t, err, "below the memory limit")
+}
+
 func TestBuildContainerSpec(t *testing.T) {
 	c := &client{}
 
@@ -421,8 +537,8 @@ func TestBuildContainerSpec(t *testing.T) {
 					"FOO": "bar",
 					"BAZ": "qux",
 				},
-				Ports: map[int]int{
-					8080: 80,
+				Ports: []PortMapping{
+					{HostPort: 8080, ContainerPort: 80},
 				},
 				Volumes: map[string]string{
 					"/host/data": "/data",
@@ -440,8 +556,8 @@ func TestBuildContainerSpec(t *testing.T) {
 			config: &ContainerConfig{
 				Name:  "test-container",
 				Image: "alpine:latest",
-				Ports: map[int]int{
-					99999: 80,
+				Ports: []PortMapping{
+					{HostPort: 99999, ContainerPort: 80},
 				},
 			},
 			wantErr: true,
@@ -484,6 +600,111 @@ func TestBuildContainerSpec(t *testing.T) {
 	}
 }
 
+func TestBuildContainerSpec_RestartPolicy(t *testing.T) {
+	c := &client{}
+
+	spec, err := c.buildContainerSpec(&ContainerConfig{Name: "survival", Image: "alpine:latest"})
+	require.NoError(t, err)
+	assert.Empty(t, spec.RestartPolicy)
+	assert.Nil(t, spec.RestartRetries)
+
+	spec, err = c.buildContainerSpec(&ContainerConfig{Name: "survival", Image: "alpine:latest", RestartPolicy: "on-failure", RestartRetries: 3})
+	require.NoError(t, err)
+	assert.Equal(t, "on-failure", spec.RestartPolicy)
+	require.NotNil(t, spec.RestartRetries)
+	assert.Equal(t, uint(3), *spec.RestartRetries)
+
+	spec, err = c.buildContainerSpec(&ContainerConfig{Name: "survival", Image: "alpine:latest", RestartPolicy: "always"})
+	require.NoError(t, err)
+	assert.Equal(t, "always", spec.RestartPolicy)
+
+	_, err = c.buildContainerSpec(&ContainerConfig{Name: "survival", Image: "alpine:latest", RestartPolicy: "always", RestartRetries: 3})
+	assert.ErrorContains(t, err, "require the on-failure restart policy")
+
+	_, err = c.buildContainerSpec(&ContainerConfig{Name: "survival", Image: "alpine:latest", RestartPolicy: "unless-stopped"})
+	assert.ErrorContains(t, err, `invalid restart policy "unless-stopped"`)
+}
+
+func TestConvertInspectData_Restart(t *testing.T) {
+	c := &client{}
+
+	info := c.convertInspectData(&define.InspectContainerData{
+		State:        &define.InspectContainerState{Status: "exited", ExitCode: 137},
+		Config:       &define.InspectContainerConfig{},
+		RestartCount: 2,
+		HostConfig: &define.InspectContainerHostConfig{
+			RestartPolicy: &define.InspectRestartPolicy{Name: "on-failure", MaximumRetryCount: 5},
+		},
+	})
+
+	assert.Equal(t, 137, info.ExitCode)
+	assert.Equal(t, 2, info.RestartCount)
+	assert.Equal(t, "on-failure", info.RestartPolicy)
+	assert.Equal(t, uint(5), info.RestartRetries)
+}
+
+func TestConvertInspectData_Resources(t *testing.T) {
+	c := &client{}
+
+	info := c.convertInspectData(&define.InspectContainerData{
+		State:  &define.InspectContainerState{Status: "running"},
+		Config: &define.InspectContainerConfig{},
+		HostConfig: &define.InspectContainerHostConfig{
+			Memory:      5 << 30,
+			MemorySwap:  6 << 30,
+			CpuQuota:    150000,
+			CpuShares:   512,
+			PidsLimit:   1024,
+			BlkioWeight: 200,
+		},
+	})
+
+	assert.Equal(t, Resources{
+		Memory:     5 << 30,
+		MemorySwap: 6 << 30,
+		CPUQuota:   150000,
+		CPUShares:  512,
+		PidsLimit:  1024,
+		IOWeight:   200,
+	}, info.Resources)
+}
+
+func TestConvertInspectData_Env(t *testing.T) {
+	c := &client{}
+
+	info := c.convertInspectData(&define.InspectContainerData{
+		State:  &define.InspectContainerState{Status: "running"},
+		Config: &define.InspectContainerConfig{Env: []string{"MEMORY=4G", "JVM_OPTS=-Dfoo=bar", "EMPTY="}},
+	})
+
+	assert.Equal(t, map[string]string{"MEMORY": "4G", "JVM_OPTS": "-Dfoo=bar", "EMPTY": ""}, info.Env)
+}
+
+func TestConvertInspectData_Ports(t *testing.T) {
+	c := &client{}
+
+	data := &define.InspectContainerData{
+		ID:     "abc123",
+		Name:   "survival",
+		State:  &define.InspectContainerState{Status: "running"},
+		Config: &define.InspectContainerConfig{},
+		NetworkSettings: &define.InspectNetworkSettings{
+			Ports: map[string][]define.InspectHostPort{
+				"25565/tcp": {{HostIP: "0.0.0.0", HostPort: "25565"}},
+				"24454/udp": {{HostIP: "127.0.0.1", HostPort: "24455"}},
+				"8080/tcp":  nil, // exposed but not published
+			},
+		},
+	}
+
+	info := c.convertInspectData(data)
+
+	assert.Equal(t, []PortMapping{
+		{HostIP: "127.0.0.1", HostPort: 24455, ContainerPort: 24454, Protocol: ProtocolUDP},
+		{HostPort: 25565, ContainerPort: 25565, Protocol: ProtocolTCP},
+	}, info.Ports)
+}
+
 func TestContextCancellation(t *testing.T) {
 	// Test that operations respect context cancellation
 	ctx, cancel := context.WithCancel(context.Background())
@@ -507,3 +728,32 @@ func TestContextCancellation(t *testing.T) {
 		t.Fatal("timeout context should be canceled")
 	}
 }
+
+func TestWaitContext(t *testing.T) {
+	c := &client{conn: context.Background(), timeout: 30 * time.Second}
+
+	// The deadline of the caller wins over the client timeout
+	deadline := time.Now().Add(5 * time.Minute)
+	ctx, cancelCtx := context.WithDeadline(context.Background(), deadline)
+	waitCtx, cancel := c.waitContext(ctx)
+	defer cancel()
+
+	got, ok := waitCtx.Deadline()
+	require.True(t, ok)
+	assert.Equal(t, deadline, got)
+
+	// Canceling the caller ends the wait
+	cancelCtx()
+	select {
+	case <-waitCtx.Done():
+	case <-time.After(time.Second):
+		t.Fatal("wait context not canceled with its caller")
+	}
+
+	// Without a deadline, the client timeout applies
+	waitCtx, cancel = c.waitContext(context.Background())
+	defer cancel()
+	got, ok = waitCtx.Deadline()
+	require.True(t, ok)
+	assert.WithinDuration(t, time.Now().Add(30*time.Second), got, time.Second)
+}
@@ -2,6 +2,11 @@ package container
 
 import (
 	"context"
+	"fmt"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
 	"time"
 )
 
@@ -45,6 +50,9 @@ type Client interface {
 
 	// GetContainerStats gets current CPU and memory statistics for a container.
 	GetContainerStats(ctx context.Context, containerID string) (*ContainerStats, error)
+
+	// Logs streams the log lines of a container.
+	Logs(ctx context.Context, containerID string, opts LogOptions) (<-chan LogLine, error)
 }
 
 // RuntimeInfo contains information about the container runtime.
@@ -56,6 +64,8 @@ type RuntimeInfo struct {
 	SocketPath string // Path to Unix socket
 	OS         string // Operating system
 	Arch       string // Architecture
+	CPUs       int    // CPUs of the host
+	MemTotal   int64  // Memory of the host in bytes
 }
 
 // Config for client initialization.
@@ -78,14 +88,22 @@ func DefaultConfig() *Config {
 type ContainerConfig struct {
 	Name       string            // Container name
 	Image      string            // Container image (e.g., "docker.io/library/alpine:latest")
+	PullPolicy string            // Image pull policy: "always", "missing", "newer" or "never"; empty skips pulling
 	Env        map[string]string // Environment variables
-	Ports      map[int]int       // Port mappings: hostPort:containerPort
+	Ports      []PortMapping     // Published ports
 	Volumes    map[string]string // Volume mounts: hostPath:containerPath
 	Memory     string            // Memory limit (e.g., "2G", "512M")
-	CPUQuota   int64             // CPU quota in microseconds
+	MemorySwap string            // Memory plus swap limit (e.g., "6G"); requires Memory
+	CPUQuota   int64             // CPU time in microseconds per CPUPeriod
+	CPUShares  uint64            // Relative CPU weight under contention (default 1024)
+	PidsLimit  int64             // Maximum number of processes and threads
+	IOWeight   uint16            // Relative block I/O weight, 10-1000
 	WorkingDir string            // Working directory inside container
 	Command    []string          // Command to run
 	Labels     map[string]string // Container labels
+
+	RestartPolicy  string // Restart policy: "no", "on-failure" or "always"; empty means no
+	RestartRetries uint   // Maximum restarts for "on-failure" (0 = unlimited)
 }
 
 // ContainerInfo contains information about a container.
@@ -95,9 +113,109 @@ type ContainerInfo struct {
 	State   string            // Container state (running, stopped, paused, exited)
 	Status  string            // Human-readable status
 	Image   string            // Image name
-	Ports   map[int]int       // Port mappings
+	Ports   []PortMapping     // Published ports
 	Created time.Time         // Creation time
 	Labels  map[string]string // Container labels
+
+	// Only set by InspectContainer
+	StartedAt      time.Time         // Start time of the current or last run
+	Env            map[string]string // Environment, including variables of the image
+	Mounts         []Mount           // Volume and bind mounts
+	ExitCode       int               // Exit code of the last run
+	RestartCount   int               // Restarts performed by the runtime's restart policy
+	RestartPolicy  string            // Restart policy; empty means no
+	RestartRetries uint              // Maximum restarts for "on-failure"
+	Resources      Resources         // Resource limits
+}
+
+// CPUPeriod is the period in microseconds CPUQuota refers to.
+const CPUPeriod = 100000
+
+// Resources holds the resource limits of a container. Zero values mean no
+// limit or the runtime default.
+type Resources struct {
+	Memory     int64  // Memory limit in bytes
+	MemorySwap int64  // Memory plus swap limit in bytes
+	CPUQuota   int64  // CPU time in microseconds per CPUPeriod
+	CPUShares  uint64 // Relative CPU weight
+	PidsLimit  int64  // Maximum number of processes and threads
+	IOWeight   uint16 // Relative block I/O weight
+}
+
+// Port protocols.
+const (
+	ProtocolTCP = "tcp"
+	ProtocolUDP = "udp"
+)
+
+// PortMapping publishes a container port on the host.
+type PortMapping struct {
+	HostIP        string // Host address to bind, empty for all addresses
+	HostPort      int    // Port on the host
+	ContainerPort int    // Port inside the container
+	Protocol      string // "tcp" or "udp", empty means tcp
+}
+
+// String formats the mapping like "0.0.0.0:24454->24454/udp".
+func (p PortMapping) String() string {
+	p = p.normalize()
+	host := strconv.Itoa(p.HostPort)
+	if p.HostIP != "" {
+		host = net.JoinHostPort(p.HostIP, host)
+	}
+	return fmt.Sprintf("%s->%d/%s", host, p.ContainerPort, p.Protocol)
+}
+
+// normalize fills in the default protocol and treats the unspecified
+// addresses as "all addresses", so mappings from different sources compare equal.
+func (p PortMapping) normalize() PortMapping {
+	p.Protocol = strings.ToLower(p.Protocol)
+	if p.Protocol == "" {
+		p.Protocol = ProtocolTCP
+	}
+	if p.HostIP == "0.0.0.0" || p.HostIP == "::" {
+		p.HostIP = ""
+	}
+	return p
+}
+
+// SamePorts reports whether two sets of port mappings publish the same
+// ports, ignoring order and defaults.
+func SamePorts(a, b []PortMapping) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	counts := make(map[PortMapping]int, len(a))
+	for _, p := range a {
+		counts[p.normalize()]++
+	}
+	for _, p := range b {
+		key := p.normalize()
+		if counts[key] == 0 {
+			return false
+		}
+		counts[key]--
+	}
+
+	return true
+}
+
+// SortPorts sorts port mappings by host port and protocol.
+func SortPorts(ports []PortMapping) {
+	sort.Slice(ports, func(i, j int) bool {
+		if ports[i].HostPort != ports[j].HostPort {
+			return ports[i].HostPort < ports[j].HostPort
+		}
+		return ports[i].Protocol < ports[j].Protocol
+	})
+}
+
+// Mount describes a mount of a container.
+type Mount struct {
+	Source      string // Host path or volume name
+	Destination string // Path inside the container
+	ReadOnly    bool   // Whether the mount is read-only
 }
 
 // RemoveOptions specifies options for removing a container.
@@ -120,3 +238,36 @@ type ContainerStats struct {
 	MemoryLimit   int64   // Memory limit in bytes
 	MemoryPercent float64 // Memory usage percentage (0-100)
 }
+
+// Log streams reported in LogLine.Stream.
+const (
+	LogStdout = "stdout"
+	LogStderr = "stderr"
+)
+
+// LogOptions specifies which log lines to stream.
+type LogOptions struct {
+	Follow bool      // Keep streaming new lines until the context is canceled
+	Tail   int       // Number of lines from the end of the logs (0 for all)
+	Since  time.Time // Only lines written after this time (zero for all)
+}
+
+// LogLine is a single line of container output.
+type LogLine struct {
+	Stream string    // LogStdout or LogStderr
+	Time   time.Time // When the container wrote the line
+	Text   string    // The line without its trailing newline
+
+	// Err is set on the last value sent when the stream fails. The other
+	// fields are empty then.
+	Err error
+}
+
+// Format returns the line as printed by "podman logs", optionally prefixed
+// with its RFC 3339 timestamp.
+func (l LogLine) Format(timestamps bool) string {
+	if timestamps && !l.Time.IsZero() {
+		return l.Time.Format(time.RFC3339Nano) + " " + l.Text
+	}
+	return l.Text
+}
@@ -0,0 +1,338 @@
+// Package lifecycle implements server lifecycle operations shared by the CLI
+// and the TUI.
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/steviee/go-mc/internal/container"
+	"github.com/steviee/go-mc/internal/rcon"
+	"github.com/steviee/go-mc/internal/state"
+	"github.com/steviee/go-mc/internal/systemd"
+)
+
+const (
+	// DefaultStopTimeout is how long the server gets to exit before SIGKILL.
+	DefaultStopTimeout = 30 * time.Second
+
+	// DefaultCountdown is the default warning period before a shutdown.
+	DefaultCountdown time.Duration = 0
+
+	// rconTimeout bounds each RCON round trip during shutdown.
+	rconTimeout = 10 * time.Second
+)
+
+// Action identifies the lifecycle operation being performed.
+type Action string
+
+const (
+	// ActionStop stops the server.
+	ActionStop Action = "stop"
+
+	// ActionRestart stops the server and starts it again.
+	ActionRestart Action = "restart"
+)
+
+// ShutdownOptions controls a graceful, RCON-driven shutdown.
+type ShutdownOptions struct {
+	// Countdown is the warning period broadcast to players before shutting down.
+	Countdown time.Duration
+
+	// Message overrides the broadcast text. The remaining time is appended.
+	Message string
+
+	// Timeout is how long the server gets to exit before it is killed.
+	Timeout time.Duration
+
+	// Force skips the RCON sequence and kills the container immediately.
+	Force bool
+
+	// BeforeStart runs on restart after the server has stopped and before
+	// its container is started again. It may replace the container by
+	// changing serverState.ContainerID.
+	BeforeStart func(ctx context.Context) error
+}
+
+// ShutdownResult describes how a shutdown was carried out.
+type ShutdownResult struct {
+	// Graceful is true when the world was saved and the server stopped via RCON.
+	Graceful bool
+
+	// Fallback holds the reason the container runtime had to stop the server.
+	Fallback string
+}
+
+// sleep waits for d or until ctx is done. Tests replace it to skip waiting.
+var sleep = func(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// startService and stopService start and stop the systemd service of a
+// server. Tests replace them to avoid talking to systemd.
+var (
+	startService = systemd.StartService
+	stopService  = systemd.StopService
+)
+
+// countdownMarks are the remaining times at which a warning is repeated.
+var countdownMarks = []time.Duration{
+	10 * time.Minute,
+	5 * time.Minute,
+	time.Minute,
+	30 * time.Second,
+	10 * time.Second,
+	5 * time.Second,
+	4 * time.Second,
+	3 * time.Second,
+	2 * time.Second,
+	time.Second,
+}
+
+// Stop gracefully stops a server: it broadcasts the countdown, flushes the
+// world to disk and issues "stop" over RCON. If any RCON step fails, the
+// container runtime stops the container instead (SIGTERM, then SIGKILL after
+// the timeout).
+//
+// Servers with a restart policy are stopped by the container runtime after
+// the world is saved: Podman restarts a container whose process exits on its
+// own, but not one stopped by the user. Servers with autostart enabled are
+// likewise stopped through systemd, which would otherwise apply the Restart=
+// setting of their unit.
+func Stop(ctx context.Context, client container.Client, serverState *state.ServerState, opts ShutdownOptions) (*ShutdownResult, error) {
+	return shutdown(ctx, client, serverState, ActionStop, opts)
+}
+
+// Restart gracefully stops a server like Stop and then starts it again like
+// Start, running opts.BeforeStart in between.
+func Restart(ctx context.Context, client container.Client, serverState *state.ServerState, opts ShutdownOptions) (*ShutdownResult, error) {
+	result, err := shutdown(ctx, client, serverState, ActionRestart, opts)
+	if err != nil {
+		return result, err
+	}
+
+	if opts.BeforeStart != nil {
+		if err := opts.BeforeStart(ctx); err != nil {
+			return result, err
+		}
+	}
+
+	if err := Start(ctx, client, serverState); err != nil {
+		return result, err
+	}
+
+	return result, nil
+}
+
+// Start starts the container of a server. Servers with autostart enabled
+// are started through their systemd service, so the Restart= setting of the
+// unit applies and the next boot does not bring up a second container. If
+// the service cannot be started, the container runtime starts the container.
+func Start(ctx context.Context, client container.Client, serverState *state.ServerState) error {
+	if serverState.Autostart.Enabled {
+		err := startService(ctx, serverState.Name)
+		if err == nil {
+			return nil
+		}
+		slog.Warn("failed to start autostart service, starting container",
+			"server", serverState.Name,
+			"error", err)
+	}
+
+	if err := client.StartContainer(ctx, serverState.ContainerID); err != nil {
+		return fmt.Errorf("failed to start container: %w", err)
+	}
+
+	return nil
+}
+
+// shutdown runs the RCON sequence and falls back to the container runtime.
+func shutdown(ctx context.Context, client container.Client, serverState *state.ServerState, action Action, opts ShutdownOptions) (*ShutdownResult, error) {
+	if serverState.ContainerID == "" {
+		return nil, fmt.Errorf("server has no container ID")
+	}
+
+	timeout := opts.Timeout
+	if timeout <= 0 {
+		timeout = DefaultStopTimeout
+	}
+
+	result := &ShutdownResult{}
+
+	if opts.Force {
+		result.Fallback = "forced"
+		zero := time.Duration(0)
+		if err := stopContainer(ctx, client, serverState, &zero); err != nil {
+			return result, err
+		}
+		return result, nil
+	}
+
+	runtimeStop := serverState.Autostart.Enabled || serverState.Restart.Name() != state.RestartNo
+
+	err := stopViaRCON(ctx, serverState, action, opts, !runtimeStop)
+	if err == nil && runtimeStop {
+		if err := stopContainer(ctx, client, serverState, &timeout); err != nil {
+			return result, err
+		}
+		result.Graceful = true
+		return result, nil
+	}
+	if err == nil {
+		waitCtx, cancel := context.WithTimeout(ctx, timeout)
+		err = client.WaitForContainer(waitCtx, serverState.ContainerID, "not-running")
+		cancel()
+		if err != nil {
+			err = fmt.Errorf("server did not exit after stop command: %w", err)
+		}
+	}
+
+	if err == nil {
+		result.Graceful = true
+		return result, nil
+	}
+
+	if ctx.Err() != nil {
+		return result, ctx.Err()
+	}
+
+	slog.Warn("graceful shutdown failed, stopping container",
+		"server", serverState.Name,
+		"error", err)
+	result.Fallback = err.Error()
+
+	if err := stopContainer(ctx, client, serverState, &timeout); err != nil {
+		return result, err
+	}
+
+	return result, nil
+}
+
+// stopContainer stops the container through the container runtime. With
+// autostart enabled, the systemd service of the server is stopped first, so
+// systemd does not restart the container; the service stops it within the
+// stop timeout of the unit and removes it. A container started outside of
+// systemd is stopped by the runtime.
+func stopContainer(ctx context.Context, client container.Client, serverState *state.ServerState, timeout *time.Duration) error {
+	if !serverState.Autostart.Enabled {
+		return client.StopContainer(ctx, serverState.ContainerID, timeout)
+	}
+
+	if err := stopService(ctx, serverState.Name); err != nil {
+		slog.Warn("failed to stop autostart service, stopping container",
+			"server", serverState.Name,
+			"error", err)
+	}
+
+	err := client.StopContainer(ctx, serverState.ContainerID, timeout)
+	if errors.Is(err, container.ErrContainerNotFound) {
+		return nil
+	}
+	return err
+}
+
+// stopViaRCON warns players, saves the world and, with sendStop, sends the
+// stop command.
+func stopViaRCON(ctx context.Context, serverState *state.ServerState, action Action, opts ShutdownOptions, sendStop bool) error {
+	rc, err := rcon.NewServerClient(serverState, rconTimeout)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = rc.Close() }()
+
+	if err := rc.Connect(ctx); err != nil {
+		return fmt.Errorf("rcon unavailable: %w", err)
+	}
+
+	if err := Countdown(ctx, rc, action, opts.Message, opts.Countdown); err != nil {
+		return err
+	}
+
+	if _, err := rc.Execute(ctx, "save-all flush"); err != nil {
+		return fmt.Errorf("failed to save world: %w", err)
+	}
+
+	if !sendStop {
+		return nil
+	}
+
+	// The server closes the connection while shutting down, so a failed
+	// response does not mean the command was not received.
+	if _, err := rc.Execute(ctx, "stop"); err != nil {
+		slog.Debug("no response to stop command", "server", serverState.Name, "error", err)
+	}
+
+	return nil
+}
+
+// Countdown broadcasts warnings to players over RCON until the countdown has
+// elapsed. A zero countdown sends a single warning.
+func Countdown(ctx context.Context, rc *rcon.Client, action Action, message string, countdown time.Duration) error {
+	remaining := countdown
+	for {
+		if _, err := rc.Execute(ctx, "say "+CountdownMessage(action, message, remaining)); err != nil {
+			return fmt.Errorf("failed to broadcast countdown: %w", err)
+		}
+
+		if remaining <= 0 {
+			return nil
+		}
+
+		next := nextMark(remaining)
+		if err := sleep(ctx, remaining-next); err != nil {
+			return err
+		}
+		remaining = next
+	}
+}
+
+// CountdownMessage formats a single countdown broadcast.
+func CountdownMessage(action Action, message string, remaining time.Duration) string {
+	if message == "" {
+		message = defaultMessage(action)
+	}
+
+	if remaining <= 0 {
+		return message + " now"
+	}
+
+	return fmt.Sprintf("%s in %s", message, formatRemaining(remaining))
+}
+
+// nextMark returns the next warning time strictly below remaining.
+func nextMark(remaining time.Duration) time.Duration {
+	for _, mark := range countdownMarks {
+		if mark < remaining {
+			return mark
+		}
+	}
+	return 0
+}
+
+// defaultMessage returns the broadcast text used when no message is set.
+func defaultMessage(action Action) string {
+	if action == ActionRestart {
+		return "Server restarting"
+	}
+	return "Server stopping"
+}
+
+// formatRemaining renders a duration the way players expect to read it.
+func formatRemaining(d time.Duration) string {
+	d = d.Round(time.Second)
+	if d >= time.Minute && d%time.Minute == 0 {
+		return fmt.Sprintf("%dm", int(d/time.Minute))
+	}
+	return d.String()
+}
@@ -0,0 +1,493 @@
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/steviee/go-mc/internal/container"
+	"github.com/steviee/go-mc/internal/rcon"
+	"github.com/steviee/go-mc/internal/rcon/rcontest"
+	"github.com/steviee/go-mc/internal/state"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// mockContainerClient is a mock implementation of container.Client
+type mockContainerClient struct {
+	mock.Mock
+}
+
+func (m *mockContainerClient) Ping(ctx context.Context) error {
+	return m.Called(ctx).Error(0)
+}
+
+func (m *mockContainerClient) Info(ctx context.Context) (*container.RuntimeInfo, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*container.RuntimeInfo), args.Error(1)
+}
+
+func (m *mockContainerClient) Close() error {
+	return m.Called().Error(0)
+}
+
+func (m *mockContainerClient) Runtime() string {
+	return m.Called().String(0)
+}
+
+func (m *mockContainerClient) CreateContainer(ctx context.Context, config *container.ContainerConfig) (string, error) {
+	args := m.Called(ctx, config)
+	return args.String(0), args.Error(1)
+}
+
+func (m *mockContainerClient) StartContainer(ctx context.Context, containerID string) error {
+	return m.Called(ctx, containerID).Error(0)
+}
+
+func (m *mockContainerClient) WaitForContainer(ctx context.Context, containerID string, condition string) error {
+	return m.Called(ctx, containerID, condition).Error(0)
+}
+
+func (m *mockContainerClient) StopContainer(ctx context.Context, containerID string, timeout *time.Duration) error {
+	return m.Called(ctx, containerID, timeout).Error(0)
+}
+
+func (m *mockContainerClient) RestartContainer(ctx context.Context, containerID string, timeout *time.Duration) error {
+	return m.Called(ctx, containerID, timeout).Error(0)
+}
+
+func (m *mockContainerClient) RemoveContainer(ctx context.Context, containerID string, opts *container.RemoveOptions) error {
+	return m.Called(ctx, containerID, opts).Error(0)
+}
+
+func (m *mockContainerClient) InspectContainer(ctx context.Context, containerID string) (*container.ContainerInfo, error) {
+	args := m.Called(ctx, containerID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*container.ContainerInfo), args.Error(1)
+}
+
+func (m *mockContainerClient) ListContainers(ctx context.Context, opts *container.ListOptions) ([]*container.ContainerInfo, error) {
+	args := m.Called(ctx, opts)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*container.ContainerInfo), args.Error(1)
+}
+
+func (m *mockContainerClient) GetContainerStats(ctx context.Context, containerID string) (*container.ContainerStats, error) {
+	args := m.Called(ctx, containerID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*container.ContainerStats), args.Error(1)
+}
+
+func (m *mockContainerClient) Logs(ctx context.Context, containerID string, opts container.LogOptions) (<-chan container.LogLine, error) {
+	args := m.Called(ctx, containerID, opts)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(<-chan container.LogLine), args.Error(1)
+}
+
+// stubSleep replaces the countdown sleep and records requested durations
+func stubSleep(t *testing.T) *[]time.Duration {
+	t.Helper()
+
+	var slept []time.Duration
+	original := sleep
+	sleep = func(ctx context.Context, d time.Duration) error {
+		slept = append(slept, d)
+		return ctx.Err()
+	}
+	t.Cleanup(func() { sleep = original })
+
+	return &slept
+}
+
+// newTestServerState returns a server state pointing at the fake RCON server
+func newTestServerState(srv *rcontest.Server) *state.ServerState {
+	serverState := state.NewServerState("myserver")
+	serverState.ContainerID = "abc123"
+	serverState.Minecraft.RconPort = srv.Port()
+	serverState.Minecraft.RconPassword = srv.Password
+	return serverState
+}
+
+func TestStop_Graceful(t *testing.T) {
+	slept := stubSleep(t)
+	srv := rcontest.NewServer(t, "secret", nil)
+	serverState := newTestServerState(srv)
+
+	client := &mockContainerClient{}
+	client.On("WaitForContainer", mock.Anything, "abc123", "not-running").Return(nil)
+
+	result, err := Stop(context.Background(), client, serverState, ShutdownOptions{
+		Countdown: 30 * time.Second,
+	})
+	require.NoError(t, err)
+	assert.True(t, result.Graceful)
+	assert.Empty(t, result.Fallback)
+
+	assert.Equal(t, []string{
+		"say Server stopping in 30s",
+		"say Server stopping in 10s",
+		"say Server stopping in 5s",
+		"say Server stopping in 4s",
+		"say Server stopping in 3s",
+		"say Server stopping in 2s",
+		"say Server stopping in 1s",
+		"say Server stopping now",
+		"save-all flush",
+		"stop",
+	}, srv.Commands())
+
+	var total time.Duration
+	for _, d := range *slept {
+		total += d
+	}
+	assert.Equal(t, 30*time.Second, total)
+
+	client.AssertNotCalled(t, "StopContainer", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestStop_CustomMessage(t *testing.T) {
+	stubSleep(t)
+	srv := rcontest.NewServer(t, "secret", nil)
+
+	client := &mockContainerClient{}
+	client.On("WaitForContainer", mock.Anything, "abc123", "not-running").Return(nil)
+
+	_, err := Stop(context.Background(), client, newTestServerState(srv), ShutdownOptions{
+		Message: "Maintenance",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"say Maintenance now", "save-all flush", "stop"}, srv.Commands())
+}
+
+func TestStop_RestartPolicy(t *testing.T) {
+	for _, policy := range []string{state.RestartOnFailure, state.RestartAlways} {
+		t.Run(policy, func(t *testing.T) {
+			stubSleep(t)
+			srv := rcontest.NewServer(t, "secret", nil)
+			serverState := newTestServerState(srv)
+			serverState.Restart.Policy = policy
+
+			// Stopped by the runtime, not by a stop command the runtime
+			// would restart the server after
+			timeout := 45 * time.Second
+			client := &mockContainerClient{}
+			client.On("StopContainer", mock.Anything, "abc123", &timeout).Return(nil)
+
+			result, err := Stop(context.Background(), client, serverState, ShutdownOptions{Timeout: timeout})
+			require.NoError(t, err)
+			assert.True(t, result.Graceful)
+			assert.Empty(t, result.Fallback)
+			assert.Equal(t, []string{"say Server stopping now", "save-all flush"}, srv.Commands())
+			client.AssertExpectations(t)
+			client.AssertNotCalled(t, "WaitForContainer", mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
+
+// stubStopService replaces stopService and returns the servers whose service
+// was stopped
+func stubStopService(t *testing.T, err error) *[]string {
+	t.Helper()
+
+	var stopped []string
+	original := stopService
+	stopService = func(ctx context.Context, serverName string) error {
+		stopped = append(stopped, serverName)
+		return err
+	}
+	t.Cleanup(func() { stopService = original })
+
+	return &stopped
+}
+
+func TestStop_Autostart(t *testing.T) {
+	stubSleep(t)
+	stopped := stubStopService(t, nil)
+	srv := rcontest.NewServer(t, "secret", nil)
+	serverState := newTestServerState(srv)
+	serverState.Autostart.Enabled = true
+
+	// systemd removes the container when it stops the service
+	timeout := 45 * time.Second
+	client := &mockContainerClient{}
+	client.On("StopContainer", mock.Anything, "abc123", &timeout).Return(container.ErrContainerNotFound)
+
+	result, err := Stop(context.Background(), client, serverState, ShutdownOptions{Timeout: timeout})
+	require.NoError(t, err)
+	assert.True(t, result.Graceful)
+	assert.Equal(t, []string{serverState.Name}, *stopped)
+	assert.Equal(t, []string{"say Server stopping now", "save-all flush"}, srv.Commands())
+	client.AssertExpectations(t)
+	client.AssertNotCalled(t, "WaitForContainer", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestStop_AutostartWithoutSystemd(t *testing.T) {
+	stubSleep(t)
+	stopped := stubStopService(t, errors.New("Failed to connect to bus"))
+	srv := rcontest.NewServer(t, "secret", nil)
+	serverState := newTestServerState(srv)
+	serverState.Autostart.Enabled = true
+
+	// The container runtime still stops the server
+	client := &mockContainerClient{}
+	client.On("StopContainer", mock.Anything, "abc123", mock.Anything).Return(nil)
+
+	result, err := Stop(context.Background(), client, serverState, ShutdownOptions{})
+	require.NoError(t, err)
+	assert.True(t, result.Graceful)
+	assert.Len(t, *stopped, 1)
+	client.AssertExpectations(t)
+}
+
+func TestStop_FallbackWhenRCONUnavailable(t *testing.T) {
+	stubSleep(t)
+	srv := rcontest.NewServer(t, "secret", nil)
+	serverState := newTestServerState(srv)
+	srv.Close()
+
+	timeout := 45 * time.Second
+	client := &mockContainerClient{}
+	client.On("StopContainer", mock.Anything, "abc123", &timeout).Return(nil)
+
+	result, err := Stop(context.Background(), client, serverState, ShutdownOptions{Timeout: timeout})
+	require.NoError(t, err)
+	assert.False(t, result.Graceful)
+	assert.Contains(t, result.Fallback, "rcon unavailable")
+	client.AssertExpectations(t)
+}
+
+func TestStop_FallbackWhenServerDoesNotExit(t *testing.T) {
+	stubSleep(t)
+	srv := rcontest.NewServer(t, "secret", nil)
+
+	client := &mockContainerClient{}
+	client.On("WaitForContainer", mock.Anything, "abc123", "not-running").Return(context.DeadlineExceeded)
+	client.On("StopContainer", mock.Anything, "abc123", mock.Anything).Return(nil)
+
+	result, err := Stop(context.Background(), client, newTestServerState(srv), ShutdownOptions{})
+	require.NoError(t, err)
+	assert.False(t, result.Graceful)
+	assert.Contains(t, result.Fallback, "did not exit")
+	client.AssertExpectations(t)
+}
+
+func TestStop_Force(t *testing.T) {
+	srv := rcontest.NewServer(t, "secret", nil)
+
+	client := &mockContainerClient{}
+	client.On("StopContainer", mock.Anything, "abc123", mock.MatchedBy(func(d *time.Duration) bool {
+		return d != nil && *d == 0
+	})).Return(nil)
+
+	result, err := Stop(context.Background(), client, newTestServerState(srv), ShutdownOptions{Force: true})
+	require.NoError(t, err)
+	assert.False(t, result.Graceful)
+	assert.Empty(t, srv.Commands())
+	client.AssertExpectations(t)
+}
+
+func TestStop_StopContainerError(t *testing.T) {
+	srv := rcontest.NewServer(t, "secret", nil)
+	serverState := newTestServerState(srv)
+	srv.Close()
+
+	client := &mockContainerClient{}
+	client.On("StopContainer", mock.Anything, "abc123", mock.Anything).Return(errors.New("boom"))
+
+	_, err := Stop(context.Background(), client, serverState, ShutdownOptions{})
+	assert.EqualError(t, err, "boom")
+}
+
+func TestStop_NoContainer(t *testing.T) {
+	serverState := state.NewServerState("myserver")
+
+	_, err := Stop(context.Background(), &mockContainerClient{}, serverState, ShutdownOptions{})
+	assert.Error(t, err)
+}
+
+func TestRestart(t *testing.T) {
+	stubSleep(t)
+	srv := rcontest.NewServer(t, "secret", nil)
+
+	client := &mockContainerClient{}
+	client.On("WaitForContainer", mock.Anything, "abc123", "not-running").Return(nil)
+	client.On("StartContainer", mock.Anything, "abc123").Return(nil)
+
+	result, err := Restart(context.Background(), client, newTestServerState(srv), ShutdownOptions{Countdown: 5 * time.Second})
+	require.NoError(t, err)
+	assert.True(t, result.Graceful)
+	assert.Equal(t, "say Server restarting in 5s", srv.Commands()[0])
+	client.AssertExpectations(t)
+}
+
+// stubStartService replaces startService and returns the servers whose
+// service was started
+func stubStartService(t *testing.T, err error) *[]string {
+	t.Helper()
+
+	var started []string
+	original := startService
+	startService = func(ctx context.Context, serverName string) error {
+		started = append(started, serverName)
+		return err
+	}
+	t.Cleanup(func() { startService = original })
+
+	return &started
+}
+
+func TestRestart_Autostart(t *testing.T) {
+	stubSleep(t)
+	stopped := stubStopService(t, nil)
+	started := stubStartService(t, nil)
+	srv := rcontest.NewServer(t, "secret", nil)
+	serverState := newTestServerState(srv)
+	serverState.Autostart.Enabled = true
+
+	// The service is stopped and started again, so systemd keeps
+	// supervising the server
+	client := &mockContainerClient{}
+	client.On("StopContainer", mock.Anything, "abc123", mock.Anything).Return(container.ErrContainerNotFound)
+
+	_, err := Restart(context.Background(), client, serverState, ShutdownOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, []string{serverState.Name}, *stopped)
+	assert.Equal(t, []string{serverState.Name}, *started)
+	client.AssertExpectations(t)
+	client.AssertNotCalled(t, "StartContainer", mock.Anything, mock.Anything)
+}
+
+func TestStart(t *testing.T) {
+	serverState := &state.ServerState{Name: "survival", ContainerID: "abc123"}
+
+	t.Run("without autostart", func(t *testing.T) {
+		started := stubStartService(t, nil)
+		client := &mockContainerClient{}
+		client.On("StartContainer", mock.Anything, "abc123").Return(nil)
+
+		require.NoError(t, Start(context.Background(), client, serverState))
+		assert.Empty(t, *started)
+		client.AssertExpectations(t)
+	})
+
+	t.Run("autostart without systemd", func(t *testing.T) {
+		started := stubStartService(t, errors.New("Failed to connect to bus"))
+		serverState := *serverState
+		serverState.Autostart.Enabled = true
+
+		// The container runtime still starts the server
+		client := &mockContainerClient{}
+		client.On("StartContainer", mock.Anything, "abc123").Return(nil)
+
+		require.NoError(t, Start(context.Background(), client, &serverState))
+		assert.Len(t, *started, 1)
+		client.AssertExpectations(t)
+	})
+}
+
+func TestRestart_StartError(t *testing.T) {
+	stubSleep(t)
+	srv := rcontest.NewServer(t, "secret", nil)
+
+	client := &mockContainerClient{}
+	client.On("WaitForContainer", mock.Anything, "abc123", "not-running").Return(nil)
+	client.On("StartContainer", mock.Anything, "abc123").Return(errors.New("boom"))
+
+	_, err := Restart(context.Background(), client, newTestServerState(srv), ShutdownOptions{})
+	assert.ErrorContains(t, err, "failed to start container")
+}
+
+func TestRestart_BeforeStart(t *testing.T) {
+	stubSleep(t)
+	srv := rcontest.NewServer(t, "secret", nil)
+	serverState := newTestServerState(srv)
+
+	client := &mockContainerClient{}
+	client.On("WaitForContainer", mock.Anything, "abc123", "not-running").Return(nil)
+	client.On("StartContainer", mock.Anything, "def456").Return(nil)
+
+	// The hook replaces the container; the new one is started
+	_, err := Restart(context.Background(), client, serverState, ShutdownOptions{
+		BeforeStart: func(ctx context.Context) error {
+			serverState.ContainerID = "def456"
+			return nil
+		},
+	})
+	require.NoError(t, err)
+	client.AssertExpectations(t)
+
+	// A failing hook leaves the server stopped
+	serverState.ContainerID = "abc123"
+	_, err = Restart(context.Background(), client, serverState, ShutdownOptions{
+		BeforeStart: func(ctx context.Context) error { return errors.New("boom") },
+	})
+	assert.ErrorContains(t, err, "boom")
+	client.AssertNumberOfCalls(t, "StartContainer", 1)
+}
+
+func TestCountdown_Cancelled(t *testing.T) {
+	srv := rcontest.NewServer(t, "secret", nil)
+	rc, err := rcon.Dial(context.Background(), srv.Addr(), "secret")
+	require.NoError(t, err)
+	defer func() { _ = rc.Close() }()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err = Countdown(ctx, rc, ActionStop, "", time.Minute)
+	assert.Error(t, err)
+}
+
+func TestCountdownMessage(t *testing.T) {
+	tests := []struct {
+		name      string
+		action    Action
+		message   string
+		remaining time.Duration
+		want      string
+	}{
+		{"stop now", ActionStop, "", 0, "Server stopping now"},
+		{"restart seconds", ActionRestart, "", 30 * time.Second, "Server restarting in 30s"},
+		{"whole minutes", ActionRestart, "", 5 * time.Minute, "Server restarting in 5m"},
+		{"mixed minutes", ActionStop, "", 90 * time.Second, "Server stopping in 1m30s"},
+		{"custom message", ActionStop, "Update incoming", 10 * time.Second, "Update incoming in 10s"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, CountdownMessage(tt.action, tt.message, tt.remaining))
+		})
+	}
+}
+
+func TestNextMark(t *testing.T) {
+	tests := []struct {
+		remaining time.Duration
+		want      time.Duration
+	}{
+		{15 * time.Minute, 10 * time.Minute},
+		{90 * time.Second, time.Minute},
+		{60 * time.Second, 30 * time.Second},
+		{7 * time.Second, 5 * time.Second},
+		{time.Second, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.remaining.String(), func(t *testing.T) {
+			assert.Equal(t, tt.want, nextMark(tt.remaining))
+		})
+	}
+}
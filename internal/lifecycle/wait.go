@@ -0,0 +1,245 @@
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"strconv"
+	"time"
+
+	"github.com/steviee/go-mc/internal/container"
+	"github.com/steviee/go-mc/internal/mclog"
+	"github.com/steviee/go-mc/internal/mcping"
+	"github.com/steviee/go-mc/internal/state"
+)
+
+// Conditions accepted by Wait.
+const (
+	// ConditionReady is met once the server finished loading the world: it
+	// answers a server list ping or logged its "Done (…)!" line.
+	ConditionReady = "ready"
+
+	// ConditionHealthy is met once the server answers a server list ping on
+	// its game port.
+	ConditionHealthy = "healthy"
+
+	// ConditionStopped is met once the container is no longer running.
+	ConditionStopped = "stopped"
+)
+
+// Conditions lists the conditions accepted by Wait.
+var Conditions = []string{ConditionReady, ConditionHealthy, ConditionStopped}
+
+// How readiness was detected, reported in WaitResult.ReadyBy.
+const (
+	ReadyByPing = "ping"
+	ReadyByLog  = "log"
+)
+
+const (
+	// DefaultWaitTimeout is how long commands wait for a condition by default.
+	// Modded servers can take minutes to load their world.
+	DefaultWaitTimeout = 5 * time.Minute
+
+	// DefaultWaitInterval is how often Wait inspects the container and pings
+	// the server.
+	DefaultWaitInterval = time.Second
+)
+
+// pingServer sends a server list ping. Tests replace it.
+var pingServer = func(ctx context.Context, address string) error {
+	_, err := mcping.Ping(ctx, address)
+	return err
+}
+
+// WaitOptions controls Wait.
+type WaitOptions struct {
+	// Interval between checks; DefaultWaitInterval when zero.
+	Interval time.Duration
+
+	// Since is when the operation being waited for began, such as starting
+	// the container. Phase durations are measured from it, and a container
+	// that started after it and exited again is reported as crashed. Zero
+	// means when Wait is called.
+	Since time.Time
+}
+
+// WaitResult reports how long the phases of a server start took.
+type WaitResult struct {
+	// Condition is the condition that was met.
+	Condition string
+
+	// ReadyBy tells how readiness was detected: ReadyByPing or ReadyByLog.
+	// Empty for ConditionStopped.
+	ReadyBy string
+
+	// ContainerUp is when the container was seen running, relative to
+	// WaitOptions.Since. Zero for ConditionStopped.
+	ContainerUp time.Duration
+
+	// WorldLoaded is when the server was ready, relative to WaitOptions.Since.
+	// Zero for ConditionStopped.
+	WorldLoaded time.Duration
+
+	// Elapsed is when the condition was met, relative to WaitOptions.Since.
+	Elapsed time.Duration
+}
+
+// Wait blocks until a server meets a condition or ctx is done. Use a
+// context with a deadline to bound the wait.
+//
+// Waiting for ConditionReady or ConditionHealthy fails early when the
+// container stops after it was started.
+func Wait(ctx context.Context, client container.Client, serverState *state.ServerState, condition string, opts WaitOptions) (*WaitResult, error) {
+	if !isCondition(condition) {
+		return nil, fmt.Errorf("invalid condition %q (valid: ready, healthy, stopped)", condition)
+	}
+	if serverState.ContainerID == "" {
+		return nil, fmt.Errorf("server %q has no container", serverState.Name)
+	}
+
+	if opts.Interval <= 0 {
+		opts.Interval = DefaultWaitInterval
+	}
+	if opts.Since.IsZero() {
+		opts.Since = time.Now()
+	}
+
+	w := &waiter{
+		client:      client,
+		serverState: serverState,
+		condition:   condition,
+		opts:        opts,
+		result:      &WaitResult{Condition: condition},
+	}
+
+	logCtx, stopLogs := context.WithCancel(ctx)
+	defer stopLogs()
+
+	ticker := time.NewTicker(opts.Interval)
+	defer ticker.Stop()
+
+	for {
+		done, err := w.check(ctx, logCtx)
+		if err != nil {
+			return nil, err
+		}
+		if done {
+			w.result.Elapsed = time.Since(opts.Since)
+			return w.result, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("timed out waiting for server %q to be %s: %w", serverState.Name, condition, ctx.Err())
+		case <-w.logReady:
+			w.ready(ReadyByLog)
+			w.result.Elapsed = w.result.WorldLoaded
+			return w.result, nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// waiter holds the progress of a Wait call
+type waiter struct {
+	client      container.Client
+	serverState *state.ServerState
+	condition   string
+	opts        WaitOptions
+	result      *WaitResult
+
+	running  bool
+	logReady chan struct{}
+}
+
+// check inspects the container once and reports whether the condition is met
+func (w *waiter) check(ctx, logCtx context.Context) (bool, error) {
+	info, err := w.client.InspectContainer(ctx, w.serverState.ContainerID)
+	if err != nil {
+		if errors.Is(err, container.ErrContainerNotFound) && w.condition == ConditionStopped {
+			return true, nil
+		}
+		return false, fmt.Errorf("failed to inspect container: %w", err)
+	}
+
+	if w.condition == ConditionStopped {
+		return info.State != "running", nil
+	}
+
+	if info.State != "running" {
+		// A container that ran during this wait has crashed or was stopped
+		if w.running || info.StartedAt.After(w.opts.Since) {
+			return false, fmt.Errorf("server %q stopped before it was %s (container %s)", w.serverState.Name, w.condition, info.State)
+		}
+		return false, nil
+	}
+
+	if !w.running {
+		w.running = true
+		w.result.ContainerUp = time.Since(w.opts.Since)
+
+		if w.condition == ConditionReady {
+			w.followLogs(logCtx, info.StartedAt)
+		}
+	}
+
+	if port := w.serverState.Minecraft.GamePort; port > 0 {
+		address := net.JoinHostPort("127.0.0.1", strconv.Itoa(port))
+		err := pingServer(ctx, address)
+		if err == nil {
+			w.ready(ReadyByPing)
+			return true, nil
+		}
+		slog.Debug("server not answering pings yet", "server", w.serverState.Name, "error", err)
+	}
+
+	return false, nil
+}
+
+// ready records that the server finished loading
+func (w *waiter) ready(by string) {
+	w.result.ReadyBy = by
+	w.result.WorldLoaded = time.Since(w.opts.Since)
+}
+
+// followLogs watches the logs of the current container run for the line
+// the server prints once the world is loaded. The log stream is optional:
+// if it cannot be opened, readiness is detected by ping alone.
+func (w *waiter) followLogs(ctx context.Context, startedAt time.Time) {
+	lines, err := w.client.Logs(ctx, w.serverState.ContainerID, container.LogOptions{
+		Follow: true,
+		Since:  startedAt,
+	})
+	if err != nil {
+		slog.Debug("cannot follow logs for readiness", "server", w.serverState.Name, "error", err)
+		return
+	}
+
+	ready := make(chan struct{})
+	w.logReady = ready
+
+	go func() {
+		for line := range lines {
+			if line.Err == nil && mclog.ParseLine(line.Text).Event == mclog.EventReady {
+				close(ready)
+				break
+			}
+		}
+		// Drain so the stream can end once ctx is canceled
+		for range lines {
+		}
+	}()
+}
+
+// isCondition reports whether condition is accepted by Wait
+func isCondition(condition string) bool {
+	for _, c := range Conditions {
+		if c == condition {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,211 @@
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/steviee/go-mc/internal/container"
+	"github.com/steviee/go-mc/internal/state"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// waitClient is a container client that replays a sequence of container
+// states. The last state repeats.
+type waitClient struct {
+	container.Client
+
+	mu      sync.Mutex
+	states  []*container.ContainerInfo
+	inspErr error
+	logs    []string
+}
+
+func (c *waitClient) InspectContainer(ctx context.Context, containerID string) (*container.ContainerInfo, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.inspErr != nil {
+		return nil, c.inspErr
+	}
+	info := c.states[0]
+	if len(c.states) > 1 {
+		c.states = c.states[1:]
+	}
+	return info, nil
+}
+
+func (c *waitClient) Logs(ctx context.Context, containerID string, opts container.LogOptions) (<-chan container.LogLine, error) {
+	if c.logs == nil {
+		return nil, fmt.Errorf("logs unavailable")
+	}
+
+	lines := make(chan container.LogLine, len(c.logs))
+	for _, text := range c.logs {
+		lines <- container.LogLine{Stream: container.LogStdout, Text: text}
+	}
+	close(lines)
+	return lines, nil
+}
+
+// stubPing replaces the server list ping; it succeeds from the given call on
+// (1-based), or never when from is 0
+func stubPing(t *testing.T, from int) *[]string {
+	t.Helper()
+
+	var mu sync.Mutex
+	var addresses []string
+
+	orig := pingServer
+	pingServer = func(ctx context.Context, address string) error {
+		mu.Lock()
+		defer mu.Unlock()
+
+		addresses = append(addresses, address)
+		if from > 0 && len(addresses) >= from {
+			return nil
+		}
+		return fmt.Errorf("connection refused")
+	}
+	t.Cleanup(func() { pingServer = orig })
+
+	return &addresses
+}
+
+// waitServer returns a server whose container is being waited for
+func waitServer() *state.ServerState {
+	serverState := state.NewServerState("survival")
+	serverState.ContainerID = "abc123"
+	serverState.Minecraft.GamePort = 25566
+	return serverState
+}
+
+var (
+	running = &container.ContainerInfo{State: "running"}
+	created = &container.ContainerInfo{State: "created"}
+	exited  = &container.ContainerInfo{State: "exited"}
+)
+
+// fastWait checks every millisecond
+var fastWait = WaitOptions{Interval: time.Millisecond}
+
+func TestWait_ReadyByPing(t *testing.T) {
+	pings := stubPing(t, 3)
+	client := &waitClient{states: []*container.ContainerInfo{created, running}}
+
+	result, err := Wait(context.Background(), client, waitServer(), ConditionReady, fastWait)
+	require.NoError(t, err)
+
+	assert.Equal(t, ConditionReady, result.Condition)
+	assert.Equal(t, ReadyByPing, result.ReadyBy)
+	assert.Positive(t, result.ContainerUp)
+	assert.GreaterOrEqual(t, result.WorldLoaded, result.ContainerUp)
+	assert.GreaterOrEqual(t, result.Elapsed, result.WorldLoaded)
+	assert.Equal(t, "127.0.0.1:25566", (*pings)[0])
+}
+
+func TestWait_ReadyByLog(t *testing.T) {
+	stubPing(t, 0)
+	client := &waitClient{
+		states: []*container.ContainerInfo{running},
+		logs: []string{
+			`[12:00:00] [Server thread/INFO]: Preparing level "world"`,
+			`[12:00:09] [Server thread/INFO]: Done (9.120s)! For help, type "help"`,
+		},
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	result, err := Wait(ctx, client, waitServer(), ConditionReady, fastWait)
+	require.NoError(t, err)
+	assert.Equal(t, ReadyByLog, result.ReadyBy)
+}
+
+func TestWait_Healthy(t *testing.T) {
+	// The log line alone does not make a server healthy
+	stubPing(t, 0)
+	client := &waitClient{
+		states: []*container.ContainerInfo{running},
+		logs:   []string{`[12:00:09] [Server thread/INFO]: Done (9.120s)! For help, type "help"`},
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	_, err := Wait(ctx, client, waitServer(), ConditionHealthy, fastWait)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.ErrorContains(t, err, `timed out waiting for server "survival" to be healthy`)
+
+	stubPing(t, 1)
+	result, err := Wait(context.Background(), client, waitServer(), ConditionHealthy, fastWait)
+	require.NoError(t, err)
+	assert.Equal(t, ReadyByPing, result.ReadyBy)
+}
+
+func TestWait_Crashed(t *testing.T) {
+	stubPing(t, 0)
+
+	t.Run("seen running", func(t *testing.T) {
+		client := &waitClient{states: []*container.ContainerInfo{running, exited}}
+
+		_, err := Wait(context.Background(), client, waitServer(), ConditionReady, fastWait)
+		assert.ErrorContains(t, err, `server "survival" stopped before it was ready (container exited)`)
+	})
+
+	t.Run("exited before the first check", func(t *testing.T) {
+		since := time.Now()
+		client := &waitClient{states: []*container.ContainerInfo{
+			{State: "exited", StartedAt: since.Add(time.Second)},
+		}}
+
+		_, err := Wait(context.Background(), client, waitServer(), ConditionReady, WaitOptions{Interval: time.Millisecond, Since: since})
+		assert.ErrorContains(t, err, "stopped before it was ready")
+	})
+
+	t.Run("stopped before the wait keeps waiting", func(t *testing.T) {
+		client := &waitClient{states: []*container.ContainerInfo{
+			{State: "exited", StartedAt: time.Now().Add(-time.Hour)},
+		}}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+
+		_, err := Wait(ctx, client, waitServer(), ConditionReady, fastWait)
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+}
+
+func TestWait_Stopped(t *testing.T) {
+	client := &waitClient{states: []*container.ContainerInfo{running, running, exited}}
+
+	result, err := Wait(context.Background(), client, waitServer(), ConditionStopped, fastWait)
+	require.NoError(t, err)
+	assert.Equal(t, ConditionStopped, result.Condition)
+	assert.Empty(t, result.ReadyBy)
+
+	// A removed container is stopped too
+	client = &waitClient{inspErr: fmt.Errorf("%w: abc123", container.ErrContainerNotFound)}
+	_, err = Wait(context.Background(), client, waitServer(), ConditionStopped, fastWait)
+	assert.NoError(t, err)
+}
+
+func TestWait_Errors(t *testing.T) {
+	client := &waitClient{states: []*container.ContainerInfo{running}}
+
+	_, err := Wait(context.Background(), client, waitServer(), "online", fastWait)
+	assert.ErrorContains(t, err, `invalid condition "online"`)
+
+	noContainer := waitServer()
+	noContainer.ContainerID = ""
+	_, err = Wait(context.Background(), client, noContainer, ConditionReady, fastWait)
+	assert.ErrorContains(t, err, "has no container")
+
+	client = &waitClient{inspErr: errors.New("connection refused")}
+	_, err = Wait(context.Background(), client, waitServer(), ConditionReady, fastWait)
+	assert.ErrorContains(t, err, "failed to inspect container")
+}
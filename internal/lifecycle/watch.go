@@ -0,0 +1,488 @@
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/steviee/go-mc/internal/access"
+	"github.com/steviee/go-mc/internal/container"
+	"github.com/steviee/go-mc/internal/state"
+)
+
+const (
+	// DefaultWatchInterval is how often the watchdog checks the servers.
+	DefaultWatchInterval = 10 * time.Second
+
+	// DefaultHangTimeout is how long a running server may fail server list
+	// pings before it is considered hung.
+	DefaultHangTimeout = 2 * time.Minute
+
+	// stableAfter is how long a server has to run and answer pings before
+	// its consecutive restarts are forgotten.
+	stableAfter = 10 * time.Minute
+)
+
+// Kinds of watch events.
+const (
+	// WatchExited reports a server that exited and is not restarted.
+	WatchExited = "exited"
+
+	// WatchHung reports a running server that stopped answering pings and is
+	// not restarted.
+	WatchHung = "hung"
+
+	// WatchScheduled reports a restart that waits for its backoff.
+	WatchScheduled = "scheduled"
+
+	// WatchRestarted reports a restart by the watchdog or by Podman.
+	WatchRestarted = "restarted"
+
+	// WatchGaveUp reports a server that used up its retries. Its status is
+	// set to error and it is no longer watched until it is started again.
+	WatchGaveUp = "gave-up"
+
+	// WatchUnbanned reports temporary bans that expired and were lifted.
+	WatchUnbanned = "unbanned"
+
+	// WatchError reports a server that could not be checked.
+	WatchError = "error"
+)
+
+// WatchOptions controls a Watchdog.
+type WatchOptions struct {
+	// Interval between checks; DefaultWatchInterval when zero.
+	Interval time.Duration
+
+	// HangTimeout is how long a running server may fail pings before it is
+	// restarted; DefaultHangTimeout when zero.
+	HangTimeout time.Duration
+
+	// StartupGrace is how long after its start a server may take to load
+	// before hangs are detected; DefaultWaitTimeout when zero.
+	StartupGrace time.Duration
+
+	// StopTimeout is how long a hung server gets to exit before it is
+	// killed; DefaultStopTimeout when zero.
+	StopTimeout time.Duration
+}
+
+// WatchEvent reports something the watchdog noticed or did.
+type WatchEvent struct {
+	Time    time.Time
+	Server  string
+	Kind    string
+	Message string
+
+	// Restart is set for WatchRestarted.
+	Restart *state.RestartRecord
+}
+
+// Watchdog enforces the restart policies of servers. Unlike the restart
+// policy of Podman it applies the backoff of the policy and also restarts
+// servers that hang: the container runs but the server does not answer
+// server list pings.
+//
+// Only servers whose status is running are watched, so servers stopped with
+// go-mc stay stopped.
+//
+// Each check also lifts the temporary bans that expired on any server, since
+// a ban applied over RCON lasts until it is pardoned.
+type Watchdog struct {
+	client  container.Client
+	opts    WatchOptions
+	events  func(WatchEvent)
+	now     func() time.Time
+	servers map[string]*watched
+}
+
+// watched is what the watchdog remembers about a server between checks
+type watched struct {
+	containerID  string
+	startedAt    time.Time
+	restartCount int
+
+	// lastHealthy is when the current run last answered a ping
+	lastHealthy time.Time
+
+	// failures counts consecutive restarts
+	failures int
+
+	// nextRestart is when a scheduled restart is due
+	nextRestart time.Time
+
+	// reported is set once an exit or hang without restart was reported
+	reported bool
+}
+
+// NewWatchdog returns a watchdog that reports to events.
+func NewWatchdog(client container.Client, opts WatchOptions, events func(WatchEvent)) *Watchdog {
+	if opts.Interval <= 0 {
+		opts.Interval = DefaultWatchInterval
+	}
+	if opts.HangTimeout <= 0 {
+		opts.HangTimeout = DefaultHangTimeout
+	}
+	if opts.StartupGrace <= 0 {
+		opts.StartupGrace = DefaultWaitTimeout
+	}
+	if opts.StopTimeout <= 0 {
+		opts.StopTimeout = DefaultStopTimeout
+	}
+
+	return &Watchdog{
+		client:  client,
+		opts:    opts,
+		events:  events,
+		now:     time.Now,
+		servers: map[string]*watched{},
+	}
+}
+
+// Run checks the servers every interval until ctx is done. Without names,
+// all servers are watched, including servers created while it runs.
+func (w *Watchdog) Run(ctx context.Context, names []string) {
+	ticker := time.NewTicker(w.opts.Interval)
+	defer ticker.Stop()
+
+	for {
+		w.Check(ctx, names)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Check checks the servers once and lifts expired bans. Without names, all
+// servers are checked.
+func (w *Watchdog) Check(ctx context.Context, names []string) {
+	w.liftBans(ctx)
+
+	if len(names) == 0 {
+		all, err := state.ListServers(ctx)
+		if err != nil {
+			w.emit("", WatchError, fmt.Sprintf("failed to list servers: %v", err))
+			return
+		}
+		names = all
+	}
+
+	for _, name := range names {
+		if ctx.Err() != nil {
+			return
+		}
+		if err := w.checkServer(ctx, name); err != nil {
+			w.emit(name, WatchError, err.Error())
+		}
+	}
+}
+
+// checkServer checks one server and restarts it if its policy says so
+func (w *Watchdog) checkServer(ctx context.Context, name string) error {
+	serverState, err := state.LoadServerState(ctx, name)
+	if err != nil {
+		return err
+	}
+	if serverState.ContainerID == "" {
+		return nil
+	}
+
+	info, err := w.client.InspectContainer(ctx, serverState.ContainerID)
+	if err != nil {
+		return fmt.Errorf("failed to inspect container: %w", err)
+	}
+
+	ws := w.observe(ctx, serverState, info)
+
+	if serverState.Status != state.StatusRunning {
+		// Stopped with go-mc or given up on
+		ws.nextRestart = time.Time{}
+		return nil
+	}
+
+	switch info.State {
+	case "running":
+		ws.nextRestart = time.Time{}
+		return w.checkRunning(ctx, serverState, info, ws)
+	case "exited", "stopped":
+		return w.checkExited(ctx, serverState, info, ws)
+	default:
+		// Created, paused or in transition
+		return nil
+	}
+}
+
+// liftBans lifts expired temporary bans and reports the servers they were
+// lifted on
+func (w *Watchdog) liftBans(ctx context.Context) {
+	results, err := access.LiftExpiredBans(ctx)
+	if err != nil {
+		w.emit("", WatchError, fmt.Sprintf("failed to lift expired bans: %v", err))
+		return
+	}
+
+	for _, result := range results {
+		switch {
+		case result.Error != "":
+			w.emit(result.Server, WatchError, fmt.Sprintf("failed to lift expired bans: %s", result.Error))
+		case len(result.Pardoned) > 0:
+			message := fmt.Sprintf("lifted expired bans: %s", strings.Join(result.Pardoned, ", "))
+			if result.Warning != "" {
+				message += "; " + result.Warning
+			}
+			w.emit(result.Server, WatchUnbanned, message)
+		}
+	}
+}
+
+// observe updates what is known about a server and records restarts done
+// by Podman since the last check
+func (w *Watchdog) observe(ctx context.Context, serverState *state.ServerState, info *container.ContainerInfo) *watched {
+	ws, ok := w.servers[serverState.Name]
+	if !ok || ws.containerID != serverState.ContainerID {
+		ws = &watched{
+			containerID:  serverState.ContainerID,
+			startedAt:    info.StartedAt,
+			restartCount: info.RestartCount,
+		}
+		w.servers[serverState.Name] = ws
+		return ws
+	}
+
+	if info.RestartCount > ws.restartCount {
+		record := state.RestartRecord{
+			Time:        info.StartedAt,
+			By:          state.RestartedByPodman,
+			Cause:       state.RestartCauseExit,
+			ExitCode:    info.ExitCode,
+			CrashReport: latestCrashReport(serverState.Volumes.Data, ws.startedAt),
+		}
+		ws.failures += info.RestartCount - ws.restartCount
+		ws.restartCount = info.RestartCount
+		w.recordRestart(ctx, serverState.Name, record)
+	}
+
+	if !info.StartedAt.Equal(ws.startedAt) {
+		ws.startedAt = info.StartedAt
+		ws.lastHealthy = time.Time{}
+		ws.reported = false
+	}
+
+	return ws
+}
+
+// checkRunning restarts a running server that stopped answering pings
+func (w *Watchdog) checkRunning(ctx context.Context, serverState *state.ServerState, info *container.ContainerInfo, ws *watched) error {
+	now := w.now()
+
+	if w.healthy(ctx, serverState) {
+		ws.lastHealthy = now
+		ws.reported = false
+		if now.Sub(info.StartedAt) >= stableAfter {
+			ws.failures = 0
+		}
+		return nil
+	}
+
+	// Loading the world does not count as a hang
+	since := info.StartedAt.Add(w.opts.StartupGrace)
+	if ws.lastHealthy.After(since) {
+		since = ws.lastHealthy
+	}
+	if now.Sub(since) < w.opts.HangTimeout {
+		return nil
+	}
+
+	policy := serverState.Restart
+	if policy.Name() == state.RestartNo {
+		if !ws.reported {
+			ws.reported = true
+			w.emit(serverState.Name, WatchHung, fmt.Sprintf("no ping response for %s; restart policy is no", now.Sub(since).Round(time.Second)))
+		}
+		return nil
+	}
+	if exhausted(policy, ws.failures) {
+		return w.giveUp(ctx, serverState, "hang (no ping response)")
+	}
+
+	timeout := w.opts.StopTimeout
+	if err := w.client.RestartContainer(ctx, serverState.ContainerID, &timeout); err != nil {
+		return fmt.Errorf("failed to restart hung server: %w", err)
+	}
+
+	ws.failures++
+	ws.lastHealthy = time.Time{}
+	w.recordRestart(ctx, serverState.Name, state.RestartRecord{
+		Time:  now,
+		By:    state.RestartedByWatch,
+		Cause: state.RestartCauseHang,
+	})
+
+	return nil
+}
+
+// checkExited restarts an exited server after the backoff of its policy
+func (w *Watchdog) checkExited(ctx context.Context, serverState *state.ServerState, info *container.ContainerInfo, ws *watched) error {
+	policy := serverState.Restart
+	restart := policy.Name() == state.RestartAlways ||
+		(policy.Name() == state.RestartOnFailure && info.ExitCode != 0)
+
+	if !restart {
+		if !ws.reported {
+			ws.reported = true
+			w.emit(serverState.Name, WatchExited, fmt.Sprintf("exited with code %d; restart policy is %s", info.ExitCode, policy.Name()))
+		}
+		return nil
+	}
+
+	record := state.RestartRecord{
+		By:          state.RestartedByWatch,
+		Cause:       state.RestartCauseExit,
+		ExitCode:    info.ExitCode,
+		CrashReport: latestCrashReport(serverState.Volumes.Data, info.StartedAt),
+	}
+
+	if exhausted(policy, ws.failures) {
+		return w.giveUp(ctx, serverState, record.String())
+	}
+
+	now := w.now()
+	if ws.nextRestart.IsZero() {
+		delay := policy.Delay(ws.failures)
+		ws.nextRestart = now.Add(delay)
+		w.emit(serverState.Name, WatchScheduled, fmt.Sprintf("%s; restarting in %s", record, delay))
+		return nil
+	}
+	if now.Before(ws.nextRestart) {
+		return nil
+	}
+
+	if err := w.client.StartContainer(ctx, serverState.ContainerID); err != nil {
+		return fmt.Errorf("failed to restart crashed server: %w", err)
+	}
+
+	ws.failures++
+	ws.nextRestart = time.Time{}
+	record.Time = now
+	w.recordRestart(ctx, serverState.Name, record)
+
+	return nil
+}
+
+// healthy reports whether a server answers a server list ping. Servers
+// without a game port cannot be pinged and count as healthy.
+func (w *Watchdog) healthy(ctx context.Context, serverState *state.ServerState) bool {
+	port := serverState.Minecraft.GamePort
+	if port == 0 {
+		return true
+	}
+
+	address := net.JoinHostPort("127.0.0.1", strconv.Itoa(port))
+	if err := pingServer(ctx, address); err != nil {
+		slog.Debug("server not answering pings", "server", serverState.Name, "error", err)
+		return false
+	}
+	return true
+}
+
+// giveUp sets the status of a server that used up its retries to error.
+//
+// The watchdog runs without the PID lock, so the state is reloaded to keep
+// changes other commands made since the check, and a server that is no
+// longer running, e.g. because it was stopped meanwhile, is left alone.
+func (w *Watchdog) giveUp(ctx context.Context, serverState *state.ServerState, cause string) error {
+	current, err := state.LoadServerState(ctx, serverState.Name)
+	if err != nil {
+		return err
+	}
+	if current.Status != state.StatusRunning || current.ContainerID != serverState.ContainerID {
+		return nil
+	}
+
+	current.Status = state.StatusError
+	if err := state.SaveServerState(ctx, current); err != nil {
+		return fmt.Errorf("failed to save server state: %w", err)
+	}
+
+	w.emit(serverState.Name, WatchGaveUp, fmt.Sprintf("%s; giving up after %d restarts", cause, serverState.Restart.MaxRetries))
+	return nil
+}
+
+// recordRestart adds a restart to the history of a server and reports it
+func (w *Watchdog) recordRestart(ctx context.Context, name string, record state.RestartRecord) {
+	w.events(WatchEvent{
+		Time:    w.now(),
+		Server:  name,
+		Kind:    WatchRestarted,
+		Message: fmt.Sprintf("restarted by %s after %s", record.By, record),
+		Restart: &record,
+	})
+
+	// Reload to keep changes other commands made since the check; the
+	// watchdog runs without the PID lock
+	serverState, err := state.LoadServerState(ctx, name)
+	if err == nil {
+		serverState.RecordRestart(record)
+		if record.By == state.RestartedByWatch {
+			serverState.LastStarted = record.Time
+		}
+		err = state.SaveServerState(ctx, serverState)
+	}
+	if err != nil {
+		w.emit(name, WatchError, fmt.Sprintf("failed to record restart: %v", err))
+	}
+}
+
+// emit reports an event
+func (w *Watchdog) emit(name, kind, message string) {
+	w.events(WatchEvent{Time: w.now(), Server: name, Kind: kind, Message: message})
+}
+
+// exhausted reports whether a policy allows no further restart
+func exhausted(policy state.RestartPolicy, failures int) bool {
+	return policy.Name() == state.RestartOnFailure && policy.MaxRetries > 0 && failures >= policy.MaxRetries
+}
+
+// latestCrashReport returns the name of the newest crash report a server
+// wrote after since, or "" if there is none. Minecraft writes them to
+// crash-reports/ in the data directory.
+func latestCrashReport(dataDir string, since time.Time) string {
+	if dataDir == "" {
+		return ""
+	}
+
+	entries, err := os.ReadDir(filepath.Join(dataDir, "crash-reports"))
+	if err != nil {
+		if !errors.Is(err, os.ErrNotExist) {
+			slog.Debug("cannot read crash reports", "dir", dataDir, "error", err)
+		}
+		return ""
+	}
+
+	var latest string
+	var latestTime time.Time
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasPrefix(entry.Name(), "crash-") {
+			continue
+		}
+		fi, err := entry.Info()
+		if err != nil || fi.ModTime().Before(since) {
+			continue
+		}
+		if fi.ModTime().After(latestTime) {
+			latest, latestTime = entry.Name(), fi.ModTime()
+		}
+	}
+
+	return latest
+}
@@ -0,0 +1,320 @@
+package lifecycle
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/steviee/go-mc/internal/access"
+	"github.com/steviee/go-mc/internal/container"
+	"github.com/steviee/go-mc/internal/rcon/rcontest"
+	"github.com/steviee/go-mc/internal/state"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// watchClient is a container client whose container the test changes
+// between checks
+type watchClient struct {
+	container.Client
+
+	info      container.ContainerInfo
+	started   int
+	restarted int
+
+	// inspected runs on each inspect, after the state was loaded
+	inspected func()
+}
+
+func (c *watchClient) InspectContainer(ctx context.Context, containerID string) (*container.ContainerInfo, error) {
+	if c.inspected != nil {
+		c.inspected()
+	}
+	info := c.info
+	return &info, nil
+}
+
+func (c *watchClient) StartContainer(ctx context.Context, containerID string) error {
+	c.started++
+	c.info.State = "running"
+	c.info.StartedAt = c.info.StartedAt.Add(time.Hour)
+	return nil
+}
+
+func (c *watchClient) RestartContainer(ctx context.Context, containerID string, timeout *time.Duration) error {
+	c.restarted++
+	c.info.StartedAt = c.info.StartedAt.Add(time.Hour)
+	return nil
+}
+
+// watchTest drives a watchdog with a fake clock
+type watchTest struct {
+	t      *testing.T
+	client *watchClient
+	dog    *Watchdog
+	now    time.Time
+	events []WatchEvent
+}
+
+// newWatchTest saves a running server with the given policy and starts
+// watching it
+func newWatchTest(t *testing.T, policy state.RestartPolicy) *watchTest {
+	t.Helper()
+
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	require.NoError(t, state.InitDirs())
+
+	serverState := waitServer()
+	serverState.Status = state.StatusRunning
+	serverState.Restart = policy
+	serverState.Volumes.Data = t.TempDir()
+	require.NoError(t, state.SaveServerState(context.Background(), serverState))
+
+	start := time.Date(2025, 1, 2, 12, 0, 0, 0, time.UTC)
+	wt := &watchTest{
+		t:      t,
+		client: &watchClient{info: container.ContainerInfo{State: "running", StartedAt: start}},
+		now:    start,
+	}
+	wt.dog = NewWatchdog(wt.client, WatchOptions{}, func(e WatchEvent) { wt.events = append(wt.events, e) })
+	wt.dog.now = func() time.Time { return wt.now }
+
+	return wt
+}
+
+// check advances the clock and checks the server
+func (wt *watchTest) check(d time.Duration) {
+	wt.now = wt.now.Add(d)
+	wt.dog.Check(context.Background(), []string{"survival"})
+}
+
+// kinds returns the kinds of all events so far
+func (wt *watchTest) kinds() []string {
+	kinds := make([]string, 0, len(wt.events))
+	for _, e := range wt.events {
+		kinds = append(kinds, e.Kind)
+	}
+	return kinds
+}
+
+// saved loads the server state
+func (wt *watchTest) saved() *state.ServerState {
+	serverState, err := state.LoadServerState(context.Background(), "survival")
+	require.NoError(wt.t, err)
+	return serverState
+}
+
+// crash makes the container exit with code
+func (wt *watchTest) crash(code int) {
+	wt.client.info.State = "exited"
+	wt.client.info.ExitCode = code
+}
+
+func TestWatchdog_RestartsCrashWithBackoff(t *testing.T) {
+	stubPing(t, 1)
+	wt := newWatchTest(t, state.RestartPolicy{Policy: state.RestartOnFailure, Backoff: 10 * time.Second})
+
+	// A crash report of the crashed run
+	reports := filepath.Join(wt.saved().Volumes.Data, "crash-reports")
+	require.NoError(t, os.MkdirAll(reports, 0750))
+	require.NoError(t, os.WriteFile(filepath.Join(reports, "crash-2025-01-02_12.05.00-server.txt"), nil, 0600))
+
+	wt.check(0)
+	wt.crash(1)
+
+	wt.check(time.Minute)
+	assert.Equal(t, []string{WatchScheduled}, wt.kinds())
+	assert.Contains(t, wt.events[0].Message, "exit code 1 (crash-2025-01-02_12.05.00-server.txt); restarting in 10s")
+
+	wt.check(5 * time.Second)
+	assert.Zero(t, wt.client.started, "restarted before the backoff")
+
+	wt.check(5 * time.Second)
+	assert.Equal(t, 1, wt.client.started)
+	assert.Equal(t, []string{WatchScheduled, WatchRestarted}, wt.kinds())
+
+	saved := wt.saved()
+	require.Len(t, saved.Restarts, 1)
+	assert.Equal(t, state.RestartRecord{
+		Time:        wt.now,
+		By:          state.RestartedByWatch,
+		Cause:       state.RestartCauseExit,
+		ExitCode:    1,
+		CrashReport: "crash-2025-01-02_12.05.00-server.txt",
+	}, saved.Restarts[0])
+
+	// The second crash in a row waits twice as long
+	wt.crash(1)
+	wt.check(time.Minute)
+	assert.Contains(t, wt.events[2].Message, "restarting in 20s")
+}
+
+func TestWatchdog_OnFailureIgnoresCleanExit(t *testing.T) {
+	wt := newWatchTest(t, state.RestartPolicy{Policy: state.RestartOnFailure})
+	stubPing(t, 1)
+
+	wt.check(0)
+	wt.crash(0)
+	wt.check(time.Minute)
+	wt.check(time.Minute)
+
+	assert.Equal(t, []string{WatchExited}, wt.kinds(), "reported once")
+	assert.Zero(t, wt.client.started)
+}
+
+func TestWatchdog_GivesUp(t *testing.T) {
+	stubPing(t, 1)
+	wt := newWatchTest(t, state.RestartPolicy{Policy: state.RestartOnFailure, MaxRetries: 1, Backoff: time.Second})
+
+	wt.check(0)
+	wt.crash(137)
+	wt.check(time.Minute)
+	wt.check(time.Minute)
+	require.Equal(t, 1, wt.client.started)
+
+	wt.crash(137)
+	wt.check(time.Minute)
+	assert.Equal(t, []string{WatchScheduled, WatchRestarted, WatchGaveUp}, wt.kinds())
+	assert.Equal(t, state.StatusError, wt.saved().Status)
+
+	// Servers in error are no longer watched
+	wt.check(time.Hour)
+	assert.Len(t, wt.events, 3)
+	assert.Equal(t, 1, wt.client.started)
+}
+
+func TestWatchdog_GiveUpKeepsConcurrentStop(t *testing.T) {
+	stubPing(t, 1)
+	wt := newWatchTest(t, state.RestartPolicy{Policy: state.RestartOnFailure, MaxRetries: 1, Backoff: time.Second})
+
+	wt.check(0)
+	wt.crash(137)
+	wt.check(time.Minute)
+	wt.check(time.Minute)
+	require.Equal(t, 1, wt.client.started)
+
+	// The server is stopped and its policy changed between the check and
+	// giving up
+	wt.client.inspected = func() {
+		serverState := wt.saved()
+		serverState.Status = state.StatusStopped
+		serverState.Restart = state.RestartPolicy{Policy: state.RestartNo}
+		require.NoError(t, state.SaveServerState(context.Background(), serverState))
+		wt.client.inspected = nil
+	}
+	wt.crash(137)
+	wt.check(time.Minute)
+
+	assert.Equal(t, []string{WatchScheduled, WatchRestarted}, wt.kinds())
+	saved := wt.saved()
+	assert.Equal(t, state.StatusStopped, saved.Status)
+	assert.Equal(t, state.RestartNo, saved.Restart.Name())
+}
+
+func TestWatchdog_RestartsHungServer(t *testing.T) {
+	pings := stubPing(t, 0)
+	wt := newWatchTest(t, state.RestartPolicy{Policy: state.RestartAlways})
+
+	// Loading the world is not a hang
+	wt.check(DefaultWaitTimeout)
+	assert.Empty(t, wt.events)
+	assert.NotEmpty(t, *pings)
+
+	wt.check(DefaultHangTimeout)
+	assert.Equal(t, 1, wt.client.restarted)
+	assert.Equal(t, []string{WatchRestarted}, wt.kinds())
+	assert.Equal(t, state.RestartCauseHang, wt.saved().Restarts[0].Cause)
+
+	// The new run gets the startup grace again
+	wt.check(DefaultHangTimeout)
+	assert.Equal(t, 1, wt.client.restarted)
+}
+
+func TestWatchdog_HangWithoutPolicy(t *testing.T) {
+	stubPing(t, 0)
+	wt := newWatchTest(t, state.RestartPolicy{})
+
+	wt.check(DefaultWaitTimeout + DefaultHangTimeout)
+	wt.check(time.Minute)
+
+	assert.Equal(t, []string{WatchHung}, wt.kinds())
+	assert.Zero(t, wt.client.restarted)
+}
+
+func TestWatchdog_RecordsPodmanRestarts(t *testing.T) {
+	stubPing(t, 1)
+	wt := newWatchTest(t, state.RestartPolicy{Policy: state.RestartOnFailure, MaxRetries: 5})
+
+	wt.check(0)
+	wt.client.info.RestartCount = 1
+	wt.client.info.ExitCode = 1
+	wt.client.info.StartedAt = wt.now.Add(30 * time.Second)
+	wt.check(time.Minute)
+
+	require.Equal(t, []string{WatchRestarted}, wt.kinds())
+	restarts := wt.saved().Restarts
+	require.Len(t, restarts, 1)
+	assert.Equal(t, state.RestartedByPodman, restarts[0].By)
+	assert.Equal(t, 1, restarts[0].ExitCode)
+	assert.Zero(t, wt.client.started)
+}
+
+func TestWatchdog_SkipsStoppedServers(t *testing.T) {
+	stubPing(t, 1)
+	wt := newWatchTest(t, state.RestartPolicy{Policy: state.RestartAlways})
+
+	serverState := wt.saved()
+	serverState.Status = state.StatusStopped
+	require.NoError(t, state.SaveServerState(context.Background(), serverState))
+
+	wt.crash(0)
+	wt.check(time.Minute)
+	wt.check(time.Hour)
+
+	assert.Empty(t, wt.events)
+	assert.Zero(t, wt.client.started)
+}
+
+func TestWatchdog_LiftsExpiredBans(t *testing.T) {
+	stubPing(t, 1)
+	wt := newWatchTest(t, state.RestartPolicy{})
+	ctx := context.Background()
+
+	srv := rcontest.NewServer(t, "secret", func(command string) string { return "" })
+	serverState := wt.saved()
+	serverState.Minecraft.RconPort = srv.Port()
+	serverState.Minecraft.RconPassword = srv.Password
+	require.NoError(t, state.SaveServerState(ctx, serverState))
+
+	// A temporary ban applied to the running server
+	expires := time.Now().Add(time.Hour)
+	require.NoError(t, state.AddPlayerBan(ctx, "survival", state.PlayerBan{
+		UUID:    "069a79f4-44e9-4726-a5be-fca90e38aaf5",
+		Name:    "Notch",
+		BanInfo: state.BanInfo{Source: access.BanSource, Created: time.Now().Add(-2 * time.Hour), Expires: &expires},
+	}))
+	_, err := access.SyncBans(ctx, serverState)
+	require.NoError(t, err)
+	require.Len(t, srv.Commands(), 1)
+
+	wt.check(0)
+	assert.NotContains(t, srv.Commands(), "pardon Notch")
+
+	// It runs out; the next check pardons it without any go-mc command
+	list, err := state.LoadBanList(ctx, "survival")
+	require.NoError(t, err)
+	past := time.Now().Add(-time.Minute)
+	list.Players[0].Expires = &past
+	require.NoError(t, state.SaveBanList(ctx, list))
+
+	wt.check(wt.dog.opts.Interval)
+	assert.Contains(t, srv.Commands(), "pardon Notch")
+	assert.Equal(t, []string{WatchUnbanned}, wt.kinds())
+	assert.Contains(t, wt.events[0].Message, "lifted expired bans: Notch")
+
+	list, err = state.LoadBanList(ctx, "survival")
+	require.NoError(t, err)
+	assert.Empty(t, list.Players)
+}
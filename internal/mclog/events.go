@@ -0,0 +1,117 @@
+package mclog
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Event classifies a log record.
+type Event string
+
+// Events recognized in server logs.
+const (
+	EventReady      Event = "ready"      // the server finished starting
+	EventJoin       Event = "join"       // a player joined
+	EventLeave      Event = "leave"      // a player left
+	EventChat       Event = "chat"       // a player chat message
+	EventDeath      Event = "death"      // a player died
+	EventStackTrace Event = "stacktrace" // an exception with its stack trace
+	EventLag        Event = "lag"        // the server cannot keep up
+)
+
+// Events lists all events in the order they are documented.
+var Events = []Event{EventReady, EventJoin, EventLeave, EventChat, EventDeath, EventStackTrace, EventLag}
+
+// ParseEvents parses a comma separated list of event names.
+func ParseEvents(list string) ([]Event, error) {
+	var events []Event
+
+	for _, name := range strings.Split(list, ",") {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" {
+			continue
+		}
+
+		event, ok := parseEvent(name)
+		if !ok {
+			valid := make([]string, len(Events))
+			for i, e := range Events {
+				valid[i] = string(e)
+			}
+			return nil, fmt.Errorf("unknown event %q (valid: %s)", name, strings.Join(valid, ", "))
+		}
+		events = append(events, event)
+	}
+
+	return events, nil
+}
+
+// parseEvent looks up an event by name
+func parseEvent(name string) (Event, bool) {
+	for _, event := range Events {
+		if string(event) == name {
+			return event, true
+		}
+	}
+	return "", false
+}
+
+var (
+	// readyPattern matches "Done (3.210s)! For help, type "help""
+	readyPattern = regexp.MustCompile(`^Done \([\d.,]+m?s\)!`)
+
+	// joinPattern and leavePattern match player connection messages
+	joinPattern  = regexp.MustCompile(`^(\w{1,16}) joined the game$`)
+	leavePattern = regexp.MustCompile(`^(\w{1,16}) left the game$`)
+
+	// chatPattern matches chat, including unsigned messages
+	chatPattern = regexp.MustCompile(`^(?:\[Not Secure\] )?<(\w{1,16})> `)
+
+	// lagPattern matches "Can't keep up! Is the server overloaded? Running 2034ms or 40 ticks behind"
+	lagPattern = regexp.MustCompile(`^Can't keep up! Is the server overloaded\?`)
+
+	// deathPattern matches the vanilla death messages, which all start with
+	// the player name followed by one of these phrases
+	deathPattern = regexp.MustCompile(`^(\w{1,16}) (?:was |died|drowned|blew up|burned to death|` +
+		`fell |froze to death|hit the ground too hard|starved to death|suffocated in a wall|` +
+		`tried to swim in lava|walked into |went up in flames|went off with a bang|withered away|` +
+		`discovered the floor was lava|experienced kinetic energy|didn't want to live|` +
+		`left the confines of this world)`)
+
+	// exceptionPattern matches the first line of a Java exception, e.g.
+	// "java.lang.IllegalStateException: Unexpected value"
+	exceptionPattern = regexp.MustCompile(`^(?:Exception in thread "[^"]*" )?[a-z][\w$]*(?:\.[\w$]+)*\.[\w$]*(?:Exception|Error|Throwable)\b`)
+)
+
+// classify sets the event and player of a record from its message
+func classify(record *Record) {
+	message := record.Message
+
+	switch {
+	case exceptionPattern.MatchString(message):
+		record.Event = EventStackTrace
+		return
+	case record.Level == "":
+		// Only server log lines carry the other events
+		return
+	case readyPattern.MatchString(message):
+		record.Event = EventReady
+	case lagPattern.MatchString(message):
+		record.Event = EventLag
+	}
+
+	if record.Event != "" || record.Level != LevelInfo {
+		return
+	}
+
+	if m := joinPattern.FindStringSubmatch(message); m != nil {
+		record.Event, record.Player = EventJoin, m[1]
+	} else if m := leavePattern.FindStringSubmatch(message); m != nil {
+		record.Event, record.Player = EventLeave, m[1]
+	} else if m := chatPattern.FindStringSubmatch(message); m != nil {
+		record.Event, record.Player = EventChat, m[1]
+	} else if m := deathPattern.FindStringSubmatch(message); m != nil && record.Thread == "Server thread" {
+		record.Event, record.Player = EventDeath, m[1]
+	}
+}
@@ -0,0 +1,50 @@
+package mclog
+
+import (
+	"regexp"
+	"slices"
+)
+
+// Filter selects log records. The zero value matches every record.
+type Filter struct {
+	// MinLevel drops records less severe than this level, and records
+	// without a level. Empty keeps all levels.
+	MinLevel Level
+
+	// Grep keeps records whose line or stack trace matches.
+	Grep *regexp.Regexp
+
+	// Events keeps records classified as one of these events.
+	Events []Event
+}
+
+// Match reports whether a record passes all conditions of the filter.
+func (f Filter) Match(record Record) bool {
+	if f.MinLevel != "" && !record.Level.AtLeast(f.MinLevel) {
+		return false
+	}
+
+	if len(f.Events) > 0 && !slices.Contains(f.Events, record.Event) {
+		return false
+	}
+
+	if f.Grep != nil && !f.grepMatch(record) {
+		return false
+	}
+
+	return true
+}
+
+// grepMatch reports whether the line of a record or any of its stack trace
+// lines matches Grep
+func (f Filter) grepMatch(record Record) bool {
+	if f.Grep.MatchString(record.Raw) {
+		return true
+	}
+	for _, line := range record.Stack {
+		if f.Grep.MatchString(line) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,79 @@
+package mclog
+
+import (
+	"regexp"
+	"time"
+)
+
+// stackLinePattern matches the lines of a Java stack trace after the first:
+// frames, "... 12 more", and nested "Caused by:" and "Suppressed:" causes
+var stackLinePattern = regexp.MustCompile(`^(?:\s+at |\s*\.\.\. \d+ (?:more|common frames omitted)|\s*Caused by: |\s*Suppressed: )`)
+
+// Parser turns a stream of log lines into records, keeping multi-line stack
+// traces with the line that started them.
+//
+// A record is complete once the next line that does not continue it
+// arrives, so the last record of a stream is only returned by Flush.
+type Parser struct {
+	pending *Record
+}
+
+// NewParser creates a Parser.
+func NewParser() *Parser {
+	return &Parser{}
+}
+
+// Feed adds a line written to the given container stream at time t. It
+// returns the previous record when the line starts a new one, and nil when
+// the line continues the previous record or there is none.
+func (p *Parser) Feed(t time.Time, stream, line string) *Record {
+	record := ParseLine(line)
+
+	if p.pending != nil && p.continues(record) {
+		p.pending.Stack = append(p.pending.Stack, record.Raw)
+		if p.pending.Event == "" {
+			p.pending.Event = EventStackTrace
+		}
+		return nil
+	}
+
+	record.Time = t
+	record.Stream = stream
+
+	complete := p.pending
+	p.pending = &record
+
+	return complete
+}
+
+// Flush returns the record still waiting for continuation lines, if any.
+func (p *Parser) Flush() *Record {
+	complete := p.pending
+	p.pending = nil
+	return complete
+}
+
+// Pending reports whether a record is waiting for continuation lines.
+func (p *Parser) Pending() bool {
+	return p.pending != nil
+}
+
+// continues reports whether a line belongs to the stack trace of the
+// pending record
+func (p *Parser) continues(record Record) bool {
+	if record.Level != "" {
+		return false
+	}
+
+	if stackLinePattern.MatchString(record.Raw) {
+		return true
+	}
+
+	// An exception without a header is the cause of a failure that was just
+	// logged, or a further exception of the same trace
+	if record.Event == EventStackTrace {
+		return p.pending.Level.AtLeast(LevelWarn) || p.pending.Event == EventStackTrace
+	}
+
+	return false
+}
@@ -0,0 +1,109 @@
+package mclog
+
+import (
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// parseAll feeds lines through a Parser and returns all records
+func parseAll(lines ...string) []Record {
+	parser := NewParser()
+
+	var records []Record
+	for _, line := range lines {
+		if record := parser.Feed(time.Time{}, "stdout", line); record != nil {
+			records = append(records, *record)
+		}
+	}
+	if record := parser.Flush(); record != nil {
+		records = append(records, *record)
+	}
+
+	return records
+}
+
+func TestParser_StackTraces(t *testing.T) {
+	records := parseAll(
+		`[12:00:00] [Server thread/INFO]: Preparing level "world"`,
+		`[12:00:01] [Server thread/ERROR]: Encountered an unexpected exception`,
+		`java.lang.NullPointerException: Cannot invoke "Object.toString()"`,
+		`	at net.minecraft.server.MinecraftServer.tick(MinecraftServer.java:123)`,
+		`	at java.base/java.lang.Thread.run(Thread.java:1583)`,
+		`Caused by: java.lang.IllegalStateException: boom`,
+		`	... 2 more`,
+		`[12:00:02] [Server thread/INFO]: Stopping server`,
+	)
+
+	require.Len(t, records, 3)
+	assert.Equal(t, "Encountered an unexpected exception", records[1].Message)
+	assert.Equal(t, EventStackTrace, records[1].Event)
+	assert.Len(t, records[1].Stack, 5)
+	assert.Equal(t, `	... 2 more`, records[1].Stack[4])
+	assert.Empty(t, records[2].Stack)
+	assert.Contains(t, records[1].String(), "\nCaused by: java.lang.IllegalStateException: boom\n")
+}
+
+func TestParser_StandaloneLines(t *testing.T) {
+	records := parseAll(
+		`[init] Running as uid=1000 gid=1000`,
+		`java.lang.IllegalStateException: not part of the init line`,
+		`	at Main.main(Main.java:1)`,
+		`[12:00:00] [Server thread/INFO]: Starting minecraft server`,
+		`java.lang.Error: an INFO line is not failing`,
+	)
+
+	require.Len(t, records, 4)
+	assert.Empty(t, records[0].Stack)
+	assert.Equal(t, EventStackTrace, records[1].Event)
+	assert.Equal(t, []string{`	at Main.main(Main.java:1)`}, records[1].Stack)
+	assert.Equal(t, EventStackTrace, records[3].Event)
+}
+
+func TestParser_KeepsEvents(t *testing.T) {
+	ts := time.Date(2025, 1, 2, 12, 0, 0, 0, time.UTC)
+	parser := NewParser()
+
+	assert.Nil(t, parser.Feed(ts, "stdout", `[12:00:00] [Server thread/WARN]: Can't keep up! Is the server overloaded? Running 2034ms or 40 ticks behind`))
+	assert.True(t, parser.Pending())
+	assert.Nil(t, parser.Feed(ts, "stdout", `	at Watchdog.run(Watchdog.java:1)`))
+
+	record := parser.Flush()
+	require.NotNil(t, record)
+	assert.Equal(t, EventLag, record.Event)
+	assert.Equal(t, ts, record.Time)
+	assert.Equal(t, "stdout", record.Stream)
+	assert.False(t, parser.Pending())
+	assert.Nil(t, parser.Flush())
+}
+
+func TestFilter_Match(t *testing.T) {
+	records := parseAll(
+		`[12:00:00] [Server thread/INFO]: Notch joined the game`,
+		`[12:00:01] [Server thread/WARN]: Can't keep up! Is the server overloaded? Running 2034ms or 40 ticks behind`,
+		`[12:00:02] [Server thread/ERROR]: Failed to save chunk`,
+		`java.io.IOException: No space left on device`,
+		`[init] Starting the Minecraft server...`,
+	)
+	require.Len(t, records, 4)
+
+	match := func(f Filter) []int {
+		var matched []int
+		for i, r := range records {
+			if f.Match(r) {
+				matched = append(matched, i)
+			}
+		}
+		return matched
+	}
+
+	assert.Equal(t, []int{0, 1, 2, 3}, match(Filter{}))
+	assert.Equal(t, []int{1, 2}, match(Filter{MinLevel: LevelWarn}))
+	assert.Equal(t, []int{0, 1}, match(Filter{Events: []Event{EventJoin, EventLag}}))
+	assert.Equal(t, []int{2}, match(Filter{Grep: regexp.MustCompile(`No space`)}))
+	assert.Equal(t, []int{2}, match(Filter{MinLevel: LevelError, Events: []Event{EventStackTrace}}))
+	assert.Empty(t, match(Filter{MinLevel: LevelError, Grep: regexp.MustCompile(`Notch`)}))
+}
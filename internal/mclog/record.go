@@ -0,0 +1,143 @@
+// Package mclog parses Minecraft server log output into structured records.
+//
+// It understands the vanilla log layout
+//
+//	[12:34:56] [Server thread/INFO]: Done (3.210s)! For help, type "help"
+//
+// and the Fabric layout, which names the logger instead of using a colon
+//
+//	[12:34:56] [Server thread/INFO] (Minecraft) Done (3.210s)! For help, type "help"
+//
+// Records are classified into events such as players joining or the server
+// becoming ready, and multi-line stack traces are kept with the line that
+// started them.
+package mclog
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// Level is the severity of a log record.
+type Level string
+
+// Log levels in increasing order of severity.
+const (
+	LevelTrace Level = "TRACE"
+	LevelDebug Level = "DEBUG"
+	LevelInfo  Level = "INFO"
+	LevelWarn  Level = "WARN"
+	LevelError Level = "ERROR"
+	LevelFatal Level = "FATAL"
+)
+
+// levels lists the log levels in increasing order of severity
+var levels = []Level{LevelTrace, LevelDebug, LevelInfo, LevelWarn, LevelError, LevelFatal}
+
+// ParseLevel parses a level name case-insensitively. "warning" is accepted
+// for WARN.
+func ParseLevel(name string) (Level, error) {
+	upper := strings.ToUpper(strings.TrimSpace(name))
+	if upper == "WARNING" {
+		upper = string(LevelWarn)
+	}
+
+	for _, level := range levels {
+		if string(level) == upper {
+			return level, nil
+		}
+	}
+
+	return "", fmt.Errorf("unknown log level %q (valid: trace, debug, info, warn, error, fatal)", name)
+}
+
+// severity returns the position of a level in levels, or -1 for lines
+// without a level
+func (l Level) severity() int {
+	for i, level := range levels {
+		if level == l {
+			return i
+		}
+	}
+	return -1
+}
+
+// AtLeast reports whether l is as severe as min. Records without a level
+// are never at least any level.
+func (l Level) AtLeast(min Level) bool {
+	s := l.severity()
+	return s >= 0 && s >= min.severity()
+}
+
+// Record is a parsed log line, together with the stack trace lines that
+// followed it.
+type Record struct {
+	// Time is when the container wrote the line; zero if unknown.
+	Time time.Time
+
+	// Stream is the container stream of the line ("stdout" or "stderr").
+	Stream string
+
+	// Clock is the HH:MM:SS time printed by the server.
+	Clock string
+
+	// Thread is the thread that logged the line, e.g. "Server thread".
+	Thread string
+
+	// Level is empty for lines that are not in a known log layout, such as
+	// the output of the container entrypoint.
+	Level Level
+
+	// Logger is the logger name printed by Fabric, e.g. "Minecraft".
+	Logger string
+
+	// Message is the text after the line header, or the whole line when
+	// there is no header.
+	Message string
+
+	// Event classifies the record; empty for ordinary lines.
+	Event Event
+
+	// Player is the player a join, leave, chat or death event is about.
+	Player string
+
+	// Stack holds the stack trace lines that followed the record.
+	Stack []string
+
+	// Raw is the line as printed by the server.
+	Raw string
+}
+
+// String returns the record as printed by the server, including its stack
+// trace lines.
+func (r Record) String() string {
+	if len(r.Stack) == 0 {
+		return r.Raw
+	}
+	return r.Raw + "\n" + strings.Join(r.Stack, "\n")
+}
+
+// linePattern matches the vanilla and Fabric line headers
+var linePattern = regexp.MustCompile(`^\[(\d{2}:\d{2}:\d{2})\] \[([^\]]+)/(TRACE|DEBUG|INFO|WARN|ERROR|FATAL)\](?:: ?| \(([^)]*)\) ?)(.*)$`)
+
+// ParseLine parses a single log line. Lines that are not in a known layout
+// keep the whole line as Message and have no level.
+func ParseLine(line string) Record {
+	line = strings.TrimRight(line, "\r\n")
+	record := Record{Message: line, Raw: line}
+
+	m := linePattern.FindStringSubmatch(line)
+	if m != nil {
+		record.Clock = m[1]
+		record.Thread = m[2]
+		record.Level = Level(m[3])
+		record.Logger = m[4]
+		record.Message = m[5]
+	}
+
+	classify(&record)
+
+	return record
+}
@@ -0,0 +1,126 @@
+package mclog
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseLine(t *testing.T) {
+	tests := []struct {
+		name string
+		line string
+		want Record
+	}{
+		{
+			name: "vanilla",
+			line: `[12:34:56] [Server thread/INFO]: Starting minecraft server version 1.21.1`,
+			want: Record{
+				Clock:   "12:34:56",
+				Thread:  "Server thread",
+				Level:   LevelInfo,
+				Message: "Starting minecraft server version 1.21.1",
+			},
+		},
+		{
+			name: "fabric with logger",
+			line: `[12:34:56] [Server thread/WARN] (Minecraft) Can't keep up! Is the server overloaded? Running 2034ms or 40 ticks behind`,
+			want: Record{
+				Clock:   "12:34:56",
+				Thread:  "Server thread",
+				Level:   LevelWarn,
+				Logger:  "Minecraft",
+				Message: "Can't keep up! Is the server overloaded? Running 2034ms or 40 ticks behind",
+				Event:   EventLag,
+			},
+		},
+		{
+			name: "thread with slash in message",
+			line: `[08:00:00] [Worker-Main-3/ERROR]: Failed to load data/minecraft/INFO]: x`,
+			want: Record{
+				Clock:   "08:00:00",
+				Thread:  "Worker-Main-3",
+				Level:   LevelError,
+				Message: "Failed to load data/minecraft/INFO]: x",
+			},
+		},
+		{
+			name: "entrypoint output",
+			line: "[init] Resolving type given FABRIC\r\n",
+			want: Record{Message: "[init] Resolving type given FABRIC"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ParseLine(tt.line)
+
+			tt.want.Raw = got.Raw
+			assert.Equal(t, tt.want, got)
+			assert.NotContains(t, got.Raw, "\n")
+		})
+	}
+}
+
+func TestParseLine_Events(t *testing.T) {
+	tests := []struct {
+		line       string
+		wantEvent  Event
+		wantPlayer string
+	}{
+		{`[12:00:00] [Server thread/INFO]: Done (3.210s)! For help, type "help"`, EventReady, ""},
+		{`[12:00:00] [Server thread/INFO] (Minecraft) Done (12.5s)! For help, type "help"`, EventReady, ""},
+		{`[12:00:00] [Server thread/INFO]: Notch joined the game`, EventJoin, "Notch"},
+		{`[12:00:00] [Server thread/INFO]: Notch left the game`, EventLeave, "Notch"},
+		{`[12:00:00] [Server thread/INFO]: <Notch> hello world`, EventChat, "Notch"},
+		{`[12:00:00] [Server thread/INFO]: [Not Secure] <jeb_> hi`, EventChat, "jeb_"},
+		{`[12:00:00] [Server thread/INFO]: Notch was slain by Zombie`, EventDeath, "Notch"},
+		{`[12:00:00] [Server thread/INFO]: Notch fell from a high place`, EventDeath, "Notch"},
+		{`[12:00:00] [Server thread/INFO]: Notch drowned`, EventDeath, "Notch"},
+		{`[12:00:00] [Server thread/WARN]: Can't keep up! Is the server overloaded? Running 5000ms or 100 ticks behind`, EventLag, ""},
+		{`java.lang.NullPointerException: Cannot invoke "Object.toString()"`, EventStackTrace, ""},
+		{`Exception in thread "main" java.lang.IllegalStateException: boom`, EventStackTrace, ""},
+
+		// Not events
+		{`[12:00:00] [Server thread/INFO]: Preparing spawn area: 84%`, "", ""},
+		{`[12:00:00] [Server thread/WARN]: Notch joined the game`, "", ""},
+		{`[12:00:00] [Worker-Main-1/INFO]: Storage was upgraded`, "", ""},
+		{`Notch joined the game`, "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.line, func(t *testing.T) {
+			got := ParseLine(tt.line)
+			assert.Equal(t, tt.wantEvent, got.Event)
+			assert.Equal(t, tt.wantPlayer, got.Player)
+		})
+	}
+}
+
+func TestParseLevel(t *testing.T) {
+	for name, want := range map[string]Level{"warn": LevelWarn, "Warning": LevelWarn, "ERROR": LevelError, " info ": LevelInfo} {
+		got, err := ParseLevel(name)
+		require.NoError(t, err)
+		assert.Equal(t, want, got)
+	}
+
+	_, err := ParseLevel("loud")
+	assert.ErrorContains(t, err, "unknown log level")
+}
+
+func TestLevel_AtLeast(t *testing.T) {
+	assert.True(t, LevelError.AtLeast(LevelWarn))
+	assert.True(t, LevelWarn.AtLeast(LevelWarn))
+	assert.False(t, LevelInfo.AtLeast(LevelWarn))
+	assert.False(t, Level("").AtLeast(LevelTrace))
+}
+
+func TestParseEvents(t *testing.T) {
+	events, err := ParseEvents("join, LEAVE,,lag")
+	require.NoError(t, err)
+	assert.Equal(t, []Event{EventJoin, EventLeave, EventLag}, events)
+
+	_, err = ParseEvents("join,quit")
+	assert.ErrorContains(t, err, `unknown event "quit"`)
+}
@@ -0,0 +1,13 @@
+package mcping
+
+import "errors"
+
+// Sentinel errors for server list pings.
+var (
+	// ErrInvalidResponse is returned when the server answers with something
+	// that is not a valid status response.
+	ErrInvalidResponse = errors.New("invalid server list ping response")
+
+	// ErrPacketTooLarge is returned when a packet exceeds MaxPacketLength.
+	ErrPacketTooLarge = errors.New("server list ping packet too large")
+)
@@ -0,0 +1,143 @@
+package mcping
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"unicode/utf16"
+)
+
+// Legacy ping packets, used by servers before 1.7.
+const (
+	legacyPing       = 0xfe
+	legacyPingMagic  = 0x01
+	legacyPluginMsg  = 0xfa
+	legacyKick       = 0xff
+	legacyPingHost   = "MC|PingHost"
+	legacyProtocol   = 78 // 1.6.4
+	legacyMaxLength  = 1024
+	legacyModernMark = "§1\x00"
+)
+
+// pingLegacy sends the legacy ping of Minecraft 1.6 over conn. Servers
+// before 1.7 answer it instead of the status protocol, and most newer
+// servers still do.
+func pingLegacy(conn net.Conn, host string, port uint16) (*Status, error) {
+	if _, err := conn.Write(legacyRequest(host, port)); err != nil {
+		return nil, fmt.Errorf("failed to send legacy ping: %w", err)
+	}
+
+	r := bufio.NewReader(conn)
+	id, err := r.ReadByte()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read legacy ping response: %w", err)
+	}
+	if id != legacyKick {
+		return nil, fmt.Errorf("%w: unexpected legacy packet 0x%02x", ErrInvalidResponse, id)
+	}
+
+	var length uint16
+	if err := binary.Read(r, binary.BigEndian, &length); err != nil {
+		return nil, fmt.Errorf("failed to read legacy ping response: %w", err)
+	}
+	if length > legacyMaxLength {
+		return nil, fmt.Errorf("%w: %d characters", ErrPacketTooLarge, length)
+	}
+
+	units := make([]uint16, length)
+	if err := binary.Read(r, binary.BigEndian, units); err != nil {
+		return nil, fmt.Errorf("failed to read legacy ping response: %w", err)
+	}
+
+	return parseLegacyResponse(string(utf16.Decode(units)))
+}
+
+// legacyRequest builds the legacy ping request of Minecraft 1.6.
+func legacyRequest(host string, port uint16) []byte {
+	channel := utf16.Encode([]rune(legacyPingHost))
+	hostname := utf16.Encode([]rune(host))
+
+	buf := []byte{legacyPing, legacyPingMagic, legacyPluginMsg}
+	buf = appendUTF16(buf, channel)
+	buf = binary.BigEndian.AppendUint16(buf, uint16(7+2*len(hostname)))
+	buf = append(buf, legacyProtocol)
+	buf = appendUTF16(buf, hostname)
+	return binary.BigEndian.AppendUint32(buf, uint32(port))
+}
+
+// appendUTF16 appends a string as its length in characters followed by its
+// UTF-16BE code units.
+func appendUTF16(buf []byte, units []uint16) []byte {
+	buf = binary.BigEndian.AppendUint16(buf, uint16(len(units)))
+	for _, u := range units {
+		buf = binary.BigEndian.AppendUint16(buf, u)
+	}
+	return buf
+}
+
+// parseLegacyResponse parses the kick message a server answers a legacy ping
+// with. Servers since 1.4 send "§1", protocol, version, MOTD and player
+// counts separated by NUL; older ones send "MOTD§online§max".
+func parseLegacyResponse(response string) (*Status, error) {
+	status := &Status{Legacy: true}
+
+	if strings.HasPrefix(response, legacyModernMark) {
+		fields := strings.Split(response[len(legacyModernMark):], "\x00")
+		if len(fields) != 5 {
+			return nil, fmt.Errorf("%w: legacy response has %d fields", ErrInvalidResponse, len(fields)+1)
+		}
+
+		protocol, err := strconv.Atoi(fields[0])
+		if err != nil {
+			return nil, fmt.Errorf("%w: legacy protocol %q", ErrInvalidResponse, fields[0])
+		}
+		status.Version = Version{Name: fields[1], Protocol: protocol}
+		status.MOTD = stripFormatting(fields[2])
+
+		if err := parseLegacyPlayers(status, fields[3], fields[4]); err != nil {
+			return nil, err
+		}
+		return status, nil
+	}
+
+	fields := strings.Split(response, "§")
+	if len(fields) < 3 {
+		return nil, fmt.Errorf("%w: legacy response %q", ErrInvalidResponse, response)
+	}
+
+	// The MOTD itself may contain section signs
+	n := len(fields)
+	status.MOTD = stripFormatting(strings.Join(fields[:n-2], "§"))
+	if err := parseLegacyPlayers(status, fields[n-2], fields[n-1]); err != nil {
+		return nil, err
+	}
+
+	return status, nil
+}
+
+// parseLegacyPlayers parses the player counts of a legacy response.
+func parseLegacyPlayers(status *Status, online, maxPlayers string) error {
+	var err error
+	if status.Players.Online, err = strconv.Atoi(online); err != nil {
+		return fmt.Errorf("%w: legacy player count %q", ErrInvalidResponse, online)
+	}
+	if status.Players.Max, err = strconv.Atoi(maxPlayers); err != nil {
+		return fmt.Errorf("%w: legacy player limit %q", ErrInvalidResponse, maxPlayers)
+	}
+	return nil
+}
+
+// dialLegacy connects to host:port and sends a legacy ping.
+func dialLegacy(ctx context.Context, host string, port uint16) (*Status, error) {
+	conn, err := dial(ctx, host, port)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = conn.Close() }()
+
+	return pingLegacy(conn, host, port)
+}
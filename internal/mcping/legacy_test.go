@@ -0,0 +1,56 @@
+package mcping
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLegacyRequest(t *testing.T) {
+	req := legacyRequest("mc", 25565)
+
+	want := []byte{0xfe, 0x01, 0xfa, 0x00, 0x0b}
+	for _, r := range "MC|PingHost" {
+		want = append(want, 0x00, byte(r))
+	}
+	want = append(want,
+		0x00, 0x0b, // 7 + 2*len("mc")
+		legacyProtocol,
+		0x00, 0x02, 0x00, 'm', 0x00, 'c',
+		0x00, 0x00, 0x63, 0xdd, // 25565
+	)
+
+	assert.True(t, bytes.Equal(want, req), "got % x", req)
+}
+
+func TestParseLegacyResponse(t *testing.T) {
+	t.Run("1.4 to 1.6", func(t *testing.T) {
+		status, err := parseLegacyResponse("§1\x00127\x001.6.4\x00§6Old §rserver\x002\x0010")
+		require.NoError(t, err)
+
+		assert.True(t, status.Legacy)
+		assert.Equal(t, Version{Name: "1.6.4", Protocol: 127}, status.Version)
+		assert.Equal(t, "Old server", status.MOTD)
+		assert.Equal(t, Players{Online: 2, Max: 10}, status.Players)
+	})
+
+	t.Run("beta", func(t *testing.T) {
+		status, err := parseLegacyResponse("A §lbeta§r server§3§20")
+		require.NoError(t, err)
+
+		assert.Equal(t, "A beta server", status.MOTD)
+		assert.Equal(t, Players{Online: 3, Max: 20}, status.Players)
+	})
+
+	for _, response := range []string{
+		"§1\x0074\x001.6.2\x00motd",
+		"§1\x00new\x001.6.2\x00motd\x000\x0020",
+		"motd§many§20",
+		"motd",
+	} {
+		_, err := parseLegacyResponse(response)
+		assert.ErrorIs(t, err, ErrInvalidResponse, response)
+	}
+}
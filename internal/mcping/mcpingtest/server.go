@@ -0,0 +1,362 @@
+// Package mcpingtest provides an in-process Minecraft server that answers
+// server list pings, for tests.
+package mcpingtest
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"unicode/utf16"
+
+	"github.com/steviee/go-mc/internal/mcping"
+)
+
+// Packet IDs and markers of the status protocol and the legacy ping.
+const (
+	packetStatus = 0x00
+	packetPing   = 0x01
+	legacyPing   = 0xfe
+	legacyKick   = 0xff
+)
+
+// Server is a fake Minecraft server listening on localhost. Like a real
+// server it answers both the status protocol and the legacy ping.
+type Server struct {
+	listener net.Listener
+
+	mu         sync.Mutex
+	status     mcping.Status
+	raw        string
+	legacyOnly bool
+	noPong     bool
+	pings      []string
+	conns      []net.Conn
+	wg         sync.WaitGroup
+}
+
+// NewServer starts a fake server answering with status and registers its
+// shutdown with t. The Latency and Legacy fields of status are ignored.
+func NewServer(t testing.TB, status mcping.Status) *Server {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("mcpingtest: listen: %v", err)
+	}
+
+	s := &Server{listener: listener, status: status}
+
+	s.wg.Add(1)
+	go s.serve()
+
+	t.Cleanup(s.Close)
+
+	return s
+}
+
+// Addr returns the host:port the server listens on.
+func (s *Server) Addr() string {
+	return s.listener.Addr().String()
+}
+
+// Port returns the TCP port the server listens on.
+func (s *Server) Port() int {
+	return s.listener.Addr().(*net.TCPAddr).Port
+}
+
+// SetStatus changes the status the server answers with.
+func (s *Server) SetStatus(status mcping.Status) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.status = status
+	s.raw = ""
+}
+
+// SetRawStatus makes the server answer the status protocol with a raw JSON
+// document instead of the status.
+func (s *Server) SetRawStatus(raw string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.raw = raw
+}
+
+// LegacyOnly makes the server behave like a server before 1.7: it closes the
+// connection on a status handshake and only answers the legacy ping.
+func (s *Server) LegacyOnly() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.legacyOnly = true
+}
+
+// NoPong makes the server close the connection instead of echoing the ping
+// packet that follows a status response, leaving the latency unknown.
+func (s *Server) NoPong() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.noPong = true
+}
+
+// Pings returns the host:port sent in every handshake received so far, in
+// order.
+func (s *Server) Pings() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]string(nil), s.pings...)
+}
+
+// Close stops the server and closes all open connections.
+func (s *Server) Close() {
+	_ = s.listener.Close()
+
+	s.mu.Lock()
+	for _, conn := range s.conns {
+		_ = conn.Close()
+	}
+	s.mu.Unlock()
+
+	s.wg.Wait()
+}
+
+func (s *Server) serve() {
+	defer s.wg.Done()
+
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			return
+		}
+
+		s.mu.Lock()
+		s.conns = append(s.conns, conn)
+		s.mu.Unlock()
+
+		s.wg.Add(1)
+		go s.handle(conn)
+	}
+}
+
+func (s *Server) handle(conn net.Conn) {
+	defer s.wg.Done()
+	defer func() { _ = conn.Close() }()
+
+	r := bufio.NewReader(conn)
+	first, err := r.Peek(1)
+	if err != nil {
+		return
+	}
+
+	if first[0] == legacyPing {
+		s.handleLegacy(conn, r)
+		return
+	}
+
+	s.mu.Lock()
+	legacyOnly, noPong := s.legacyOnly, s.noPong
+	s.mu.Unlock()
+	if legacyOnly {
+		return
+	}
+
+	// Handshake: protocol, host, port and next state
+	body, err := readPacket(r)
+	if err != nil {
+		return
+	}
+	br := bytes.NewReader(body)
+	_, _ = readVarInt(br) // packet ID
+	_, _ = readVarInt(br) // protocol
+	hostLen, err := readVarInt(br)
+	if err != nil {
+		return
+	}
+	host := make([]byte, hostLen)
+	if _, err := io.ReadFull(br, host); err != nil {
+		return
+	}
+	var port uint16
+	if err := binary.Read(br, binary.BigEndian, &port); err != nil {
+		return
+	}
+	s.recordPing(string(host), int(port))
+
+	// Status request
+	if _, err := readPacket(r); err != nil {
+		return
+	}
+	response, err := s.statusResponse()
+	if err != nil {
+		return
+	}
+	payload := appendVarInt(nil, int32(len(response)))
+	payload = append(payload, response...)
+	if err := writePacket(conn, packetStatus, payload); err != nil || noPong {
+		return
+	}
+
+	// Ping, echoed back
+	body, err = readPacket(r)
+	if err != nil || len(body) == 0 || body[0] != packetPing {
+		return
+	}
+	_ = writePacket(conn, packetPing, body[1:])
+}
+
+// handleLegacy answers the legacy ping of Minecraft 1.6.
+func (s *Server) handleLegacy(conn net.Conn, r *bufio.Reader) {
+	// FE 01 FA and the "MC|PingHost" channel name
+	header := make([]byte, 3+2+2*len("MC|PingHost"))
+	if _, err := io.ReadFull(r, header); err != nil {
+		return
+	}
+
+	// Data length and protocol version, then hostname and port
+	var rest struct {
+		Length   uint16
+		Protocol byte
+		HostLen  uint16
+	}
+	if err := binary.Read(r, binary.BigEndian, &rest); err != nil {
+		return
+	}
+	host := make([]uint16, rest.HostLen)
+	if err := binary.Read(r, binary.BigEndian, host); err != nil {
+		return
+	}
+	var port uint32
+	if err := binary.Read(r, binary.BigEndian, &port); err != nil {
+		return
+	}
+	s.recordPing(string(utf16.Decode(host)), int(port))
+
+	s.mu.Lock()
+	status := s.status
+	s.mu.Unlock()
+
+	message := strings.Join([]string{
+		"§1",
+		strconv.Itoa(status.Version.Protocol),
+		status.Version.Name,
+		status.MOTD,
+		strconv.Itoa(status.Players.Online),
+		strconv.Itoa(status.Players.Max),
+	}, "\x00")
+
+	units := utf16.Encode([]rune(message))
+	response := binary.BigEndian.AppendUint16([]byte{legacyKick}, uint16(len(units)))
+	for _, u := range units {
+		response = binary.BigEndian.AppendUint16(response, u)
+	}
+	_, _ = conn.Write(response)
+}
+
+// recordPing stores the address of a received ping.
+func (s *Server) recordPing(host string, port int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.pings = append(s.pings, net.JoinHostPort(host, strconv.Itoa(port)))
+}
+
+// statusResponse builds the JSON document of the status response.
+func (s *Server) statusResponse() ([]byte, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.raw != "" {
+		return []byte(s.raw), nil
+	}
+
+	type player struct {
+		Name string `json:"name"`
+		ID   string `json:"id"`
+	}
+	var response struct {
+		Version struct {
+			Name     string `json:"name"`
+			Protocol int    `json:"protocol"`
+		} `json:"version"`
+		Players struct {
+			Max    int      `json:"max"`
+			Online int      `json:"online"`
+			Sample []player `json:"sample,omitempty"`
+		} `json:"players"`
+		Description struct {
+			Text string `json:"text"`
+		} `json:"description"`
+	}
+
+	response.Version.Name = s.status.Version.Name
+	response.Version.Protocol = s.status.Version.Protocol
+	response.Players.Max = s.status.Players.Max
+	response.Players.Online = s.status.Players.Online
+	for _, p := range s.status.Players.Sample {
+		response.Players.Sample = append(response.Players.Sample, player{Name: p.Name, ID: p.ID})
+	}
+	response.Description.Text = s.status.MOTD
+
+	return json.Marshal(response)
+}
+
+// readPacket reads a length-prefixed packet and returns its body: the packet
+// ID followed by the payload.
+func readPacket(r *bufio.Reader) ([]byte, error) {
+	length, err := readVarInt(r)
+	if err != nil {
+		return nil, err
+	}
+	if length <= 0 || length > mcping.MaxPacketLength {
+		return nil, errors.New("mcpingtest: bad packet length")
+	}
+
+	body := make([]byte, length)
+	_, err = io.ReadFull(r, body)
+	return body, err
+}
+
+// writePacket writes a packet with the given ID and payload.
+func writePacket(w io.Writer, id int32, payload []byte) error {
+	body := appendVarInt(nil, id)
+	body = append(body, payload...)
+
+	_, err := w.Write(append(appendVarInt(nil, int32(len(body))), body...))
+	return err
+}
+
+// appendVarInt appends v in the protocol's variable-length encoding.
+func appendVarInt(buf []byte, v int32) []byte {
+	u := uint32(v)
+	for u >= 0x80 {
+		buf = append(buf, byte(u)|0x80)
+		u >>= 7
+	}
+	return append(buf, byte(u))
+}
+
+// readVarInt reads a variable-length encoded int32.
+func readVarInt(r io.ByteReader) (int32, error) {
+	var result uint32
+	for shift := 0; shift < 35; shift += 7 {
+		b, err := r.ReadByte()
+		if err != nil {
+			return 0, err
+		}
+		result |= uint32(b&0x7f) << shift
+		if b&0x80 == 0 {
+			return int32(result), nil
+		}
+	}
+	return 0, errors.New("mcpingtest: varint too long")
+}
@@ -0,0 +1,64 @@
+package mcping
+
+import (
+	"encoding/json"
+	"regexp"
+	"strings"
+)
+
+// formattingCode matches legacy formatting codes such as "§a" or "§l".
+var formattingCode = regexp.MustCompile(`\x{00a7}.?`)
+
+// chatComponent is a JSON text component as used by the server description.
+type chatComponent struct {
+	Text      string            `json:"text"`
+	Translate string            `json:"translate"`
+	Extra     []json.RawMessage `json:"extra"`
+}
+
+// plainText flattens a JSON text component into plain text. Components can
+// be a string, an object with text and extra components, or a list.
+func plainText(raw json.RawMessage) string {
+	var b strings.Builder
+	writeComponent(&b, raw)
+	return stripFormatting(b.String())
+}
+
+// writeComponent writes the text of a component and its children.
+func writeComponent(b *strings.Builder, raw json.RawMessage) {
+	if len(raw) == 0 {
+		return
+	}
+
+	var s string
+	if err := json.Unmarshal(raw, &s); err == nil {
+		b.WriteString(s)
+		return
+	}
+
+	var list []json.RawMessage
+	if err := json.Unmarshal(raw, &list); err == nil {
+		for _, child := range list {
+			writeComponent(b, child)
+		}
+		return
+	}
+
+	var c chatComponent
+	if err := json.Unmarshal(raw, &c); err != nil {
+		return
+	}
+	if c.Text != "" {
+		b.WriteString(c.Text)
+	} else {
+		b.WriteString(c.Translate)
+	}
+	for _, child := range c.Extra {
+		writeComponent(b, child)
+	}
+}
+
+// stripFormatting removes legacy formatting codes.
+func stripFormatting(s string) string {
+	return formattingCode.ReplaceAllString(s, "")
+}
@@ -0,0 +1,29 @@
+package mcping
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPlainText(t *testing.T) {
+	tests := []struct {
+		name string
+		raw  string
+		want string
+	}{
+		{"string", `"§cHello §lworld"`, "Hello world"},
+		{"component", `{"text":"Hello ","extra":[{"text":"world","color":"gold"}]}`, "Hello world"},
+		{"list", `[{"text":"a"},"b",{"extra":["c"]}]`, "abc"},
+		{"translation", `{"translate":"multiplayer.status.pinging"}`, "multiplayer.status.pinging"},
+		{"missing", ``, ""},
+		{"invalid", `42`, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, plainText(json.RawMessage(tt.raw)))
+		})
+	}
+}
@@ -0,0 +1,120 @@
+package mcping
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+)
+
+// MaxPacketLength is the largest packet accepted from a server. Status
+// responses carry the MOTD and a base64 favicon, which stay well below this.
+const MaxPacketLength = 2 * 1024 * 1024
+
+// Packet IDs of the status protocol.
+const (
+	packetHandshake = 0x00
+	packetStatus    = 0x00
+	packetPing      = 0x01
+)
+
+// nextStateStatus asks the server to switch to the status protocol.
+const nextStateStatus = 1
+
+// appendVarInt appends v in the protocol's variable-length encoding.
+func appendVarInt(buf []byte, v int32) []byte {
+	u := uint32(v)
+	for u >= 0x80 {
+		buf = append(buf, byte(u)|0x80)
+		u >>= 7
+	}
+	return append(buf, byte(u))
+}
+
+// readVarInt reads a variable-length encoded int32.
+func readVarInt(r io.ByteReader) (int32, error) {
+	var result uint32
+	for shift := 0; shift < 35; shift += 7 {
+		b, err := r.ReadByte()
+		if err != nil {
+			return 0, err
+		}
+		result |= uint32(b&0x7f) << shift
+		if b&0x80 == 0 {
+			return int32(result), nil
+		}
+	}
+	return 0, fmt.Errorf("%w: varint too long", ErrInvalidResponse)
+}
+
+// appendString appends a length-prefixed UTF-8 string.
+func appendString(buf []byte, s string) []byte {
+	buf = appendVarInt(buf, int32(len(s)))
+	return append(buf, s...)
+}
+
+// readString reads a length-prefixed UTF-8 string.
+func readString(r *bytes.Reader) (string, error) {
+	n, err := readVarInt(r)
+	if err != nil {
+		return "", err
+	}
+	if n < 0 || int(n) > r.Len() {
+		return "", fmt.Errorf("%w: string length %d", ErrInvalidResponse, n)
+	}
+
+	buf := make([]byte, n)
+	if _, err := io.ReadFull(r, buf); err != nil {
+		return "", err
+	}
+	return string(buf), nil
+}
+
+// writePacket writes a packet with the given ID and payload, prefixed with
+// its length.
+func writePacket(w io.Writer, id int32, payload []byte) error {
+	body := appendVarInt(nil, id)
+	body = append(body, payload...)
+
+	packet := appendVarInt(make([]byte, 0, len(body)+5), int32(len(body)))
+	packet = append(packet, body...)
+
+	_, err := w.Write(packet)
+	return err
+}
+
+// readPacket reads a length-prefixed packet and returns its ID and payload.
+func readPacket(r *bufio.Reader) (int32, *bytes.Reader, error) {
+	length, err := readVarInt(r)
+	if err != nil {
+		return 0, nil, err
+	}
+	if length <= 0 {
+		return 0, nil, fmt.Errorf("%w: packet length %d", ErrInvalidResponse, length)
+	}
+	if length > MaxPacketLength {
+		return 0, nil, fmt.Errorf("%w: %d bytes", ErrPacketTooLarge, length)
+	}
+
+	body := make([]byte, length)
+	if _, err := io.ReadFull(r, body); err != nil {
+		return 0, nil, err
+	}
+
+	payload := bytes.NewReader(body)
+	id, err := readVarInt(payload)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	return id, payload, nil
+}
+
+// handshake builds the payload of a handshake packet for the status protocol.
+func handshake(protocol int32, host string, port uint16) []byte {
+	payload := appendVarInt(nil, protocol)
+	payload = appendString(payload, host)
+	payload = binary.BigEndian.AppendUint16(payload, port)
+	return appendVarInt(payload, nextStateStatus)
+}
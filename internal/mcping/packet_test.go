@@ -0,0 +1,80 @@
+package mcping
+
+import (
+	"bufio"
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVarInt(t *testing.T) {
+	tests := []struct {
+		value int32
+		want  []byte
+	}{
+		{0, []byte{0x00}},
+		{1, []byte{0x01}},
+		{127, []byte{0x7f}},
+		{128, []byte{0x80, 0x01}},
+		{25565, []byte{0xdd, 0xc7, 0x01}},
+		{2147483647, []byte{0xff, 0xff, 0xff, 0xff, 0x07}},
+		{-1, []byte{0xff, 0xff, 0xff, 0xff, 0x0f}},
+	}
+
+	for _, tt := range tests {
+		encoded := appendVarInt(nil, tt.value)
+		assert.Equal(t, tt.want, encoded, "encode %d", tt.value)
+
+		decoded, err := readVarInt(bytes.NewReader(encoded))
+		require.NoError(t, err)
+		assert.Equal(t, tt.value, decoded, "decode %d", tt.value)
+	}
+
+	_, err := readVarInt(bytes.NewReader([]byte{0xff, 0xff, 0xff, 0xff, 0xff, 0x01}))
+	assert.ErrorIs(t, err, ErrInvalidResponse)
+}
+
+func TestPacket_RoundTrip(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, writePacket(&buf, packetStatus, appendString(nil, `{"version":{}}`)))
+
+	id, payload, err := readPacket(bufio.NewReader(&buf))
+	require.NoError(t, err)
+	assert.Equal(t, int32(packetStatus), id)
+
+	s, err := readString(payload)
+	require.NoError(t, err)
+	assert.Equal(t, `{"version":{}}`, s)
+}
+
+func TestReadPacket_Invalid(t *testing.T) {
+	// Length larger than MaxPacketLength
+	tooLarge := appendVarInt(nil, MaxPacketLength+1)
+	_, _, err := readPacket(bufio.NewReader(bytes.NewReader(tooLarge)))
+	assert.ErrorIs(t, err, ErrPacketTooLarge)
+
+	// String longer than the packet
+	var buf bytes.Buffer
+	require.NoError(t, writePacket(&buf, packetStatus, appendVarInt(nil, 100)))
+	_, payload, err := readPacket(bufio.NewReader(&buf))
+	require.NoError(t, err)
+	_, err = readString(payload)
+	assert.ErrorIs(t, err, ErrInvalidResponse)
+}
+
+func TestSplitAddress(t *testing.T) {
+	host, port, err := splitAddress("mc.example.com")
+	require.NoError(t, err)
+	assert.Equal(t, "mc.example.com", host)
+	assert.Equal(t, uint16(DefaultPort), port)
+
+	host, port, err = splitAddress("127.0.0.1:25566")
+	require.NoError(t, err)
+	assert.Equal(t, "127.0.0.1", host)
+	assert.Equal(t, uint16(25566), port)
+
+	_, _, err = splitAddress("localhost:0")
+	assert.ErrorContains(t, err, "invalid port")
+}
@@ -0,0 +1,218 @@
+// Package mcping implements the Minecraft Server List Ping protocol, the
+// status query a client sends to show a server in its multiplayer list.
+// Servers that do not speak the status protocol of 1.7 and later are pinged
+// with the legacy ping of 1.6.
+package mcping
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+	"time"
+)
+
+const (
+	// DefaultTimeout is the default timeout for a whole ping exchange.
+	DefaultTimeout = 5 * time.Second
+
+	// DefaultPort is the default Minecraft game port.
+	DefaultPort = 25565
+)
+
+// anyProtocol is sent in the handshake when the client does not care about
+// the server's protocol version, as is customary for pings.
+const anyProtocol = -1
+
+// Version is the Minecraft version a server runs.
+type Version struct {
+	Name     string
+	Protocol int
+}
+
+// Player is an entry of the player sample of a status response.
+type Player struct {
+	Name string
+	ID   string
+}
+
+// Players holds the player counts of a server.
+type Players struct {
+	Max    int
+	Online int
+
+	// Sample holds some of the online players. Servers send at most a dozen
+	// and may hide them; legacy servers never send any.
+	Sample []Player
+}
+
+// Status is the answer of a server to a server list ping.
+type Status struct {
+	Version Version
+	Players Players
+
+	// MOTD is the message of the day as plain text, without formatting.
+	MOTD string
+
+	// Latency is the round trip time of the ping packet. Zero when the
+	// server did not answer it.
+	Latency time.Duration
+
+	// Legacy is set when the server answered the legacy ping of 1.6.
+	Legacy bool
+}
+
+// statusResponse is the JSON document of a status response.
+type statusResponse struct {
+	Version struct {
+		Name     string `json:"name"`
+		Protocol int    `json:"protocol"`
+	} `json:"version"`
+	Players struct {
+		Max    int `json:"max"`
+		Online int `json:"online"`
+		Sample []struct {
+			Name string `json:"name"`
+			ID   string `json:"id"`
+		} `json:"sample"`
+	} `json:"players"`
+	Description json.RawMessage `json:"description"`
+}
+
+// Ping sends a server list ping to address ("host:port"; the port defaults
+// to DefaultPort) and returns the server's status. If the server drops the
+// status protocol, the legacy ping is tried on a new connection.
+// The exchange is bounded by DefaultTimeout unless ctx has an earlier
+// deadline.
+func Ping(ctx context.Context, address string) (*Status, error) {
+	host, port, err := splitAddress(address)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, DefaultTimeout)
+	defer cancel()
+
+	conn, err := dial(ctx, host, port)
+	if err != nil {
+		return nil, err
+	}
+	status, err := pingStatus(conn, host, port)
+	_ = conn.Close()
+	if err == nil || ctx.Err() != nil || answeredStatus(err) {
+		return status, err
+	}
+
+	// Servers before 1.7 close the connection on the modern handshake
+	if legacy, legacyErr := dialLegacy(ctx, host, port); legacyErr == nil {
+		return legacy, nil
+	}
+
+	return nil, err
+}
+
+// answeredStatus reports whether err means the server answered the status
+// protocol, although with a broken response.
+func answeredStatus(err error) bool {
+	return errors.Is(err, ErrInvalidResponse) || errors.Is(err, ErrPacketTooLarge)
+}
+
+// dial connects to host:port and bounds the connection by the deadline of
+// ctx.
+func dial(ctx context.Context, host string, port uint16) (net.Conn, error) {
+	address := net.JoinHostPort(host, strconv.Itoa(int(port)))
+
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", address)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to %s: %w", address, err)
+	}
+
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+	}
+
+	return conn, nil
+}
+
+// pingStatus runs the status protocol over conn.
+func pingStatus(conn net.Conn, host string, port uint16) (*Status, error) {
+	if err := writePacket(conn, packetHandshake, handshake(anyProtocol, host, port)); err != nil {
+		return nil, fmt.Errorf("failed to send handshake: %w", err)
+	}
+	if err := writePacket(conn, packetStatus, nil); err != nil {
+		return nil, fmt.Errorf("failed to send status request: %w", err)
+	}
+
+	r := bufio.NewReader(conn)
+
+	status, err := readStatus(r)
+	if err != nil {
+		return nil, err
+	}
+
+	// The latency is measured with a ping packet echoed by the server. Some
+	// servers close the connection instead, which leaves it unknown.
+	sent := time.Now()
+	payload := binary.BigEndian.AppendUint64(nil, uint64(sent.UnixMilli()))
+	if err := writePacket(conn, packetPing, payload); err == nil {
+		if id, _, err := readPacket(r); err == nil && id == packetPing {
+			status.Latency = time.Since(sent)
+		}
+	}
+
+	return status, nil
+}
+
+// readStatus reads the status response packet.
+func readStatus(r *bufio.Reader) (*Status, error) {
+	id, payload, err := readPacket(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read status response: %w", err)
+	}
+	if id != packetStatus {
+		return nil, fmt.Errorf("%w: unexpected packet 0x%02x", ErrInvalidResponse, id)
+	}
+
+	raw, err := readString(payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read status response: %w", err)
+	}
+
+	var response statusResponse
+	if err := json.Unmarshal([]byte(raw), &response); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidResponse, err)
+	}
+
+	status := &Status{
+		Version: Version{Name: response.Version.Name, Protocol: response.Version.Protocol},
+		Players: Players{Max: response.Players.Max, Online: response.Players.Online},
+		MOTD:    plainText(response.Description),
+	}
+	for _, p := range response.Players.Sample {
+		status.Players.Sample = append(status.Players.Sample, Player{Name: p.Name, ID: p.ID})
+	}
+
+	return status, nil
+}
+
+// splitAddress splits "host:port" into its parts. A missing port is
+// DefaultPort.
+func splitAddress(address string) (string, uint16, error) {
+	host, portStr, err := net.SplitHostPort(address)
+	if err != nil {
+		// No port given
+		return address, DefaultPort, nil
+	}
+
+	port, err := strconv.ParseUint(portStr, 10, 16)
+	if err != nil || port == 0 {
+		return "", 0, fmt.Errorf("invalid port in address %q", address)
+	}
+
+	return host, uint16(port), nil
+}
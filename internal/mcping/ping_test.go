@@ -0,0 +1,121 @@
+package mcping_test
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/steviee/go-mc/internal/mcping"
+	"github.com/steviee/go-mc/internal/mcping/mcpingtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// survival is the status of a 1.21.1 server with one player online
+var survival = mcping.Status{
+	Version: mcping.Version{Name: "1.21.1", Protocol: 767},
+	Players: mcping.Players{
+		Max:    20,
+		Online: 1,
+		Sample: []mcping.Player{{Name: "Notch", ID: "069a79f4-44e9-4726-a5be-fca90e38aaf5"}},
+	},
+	MOTD: "A Minecraft Server",
+}
+
+func TestPing(t *testing.T) {
+	srv := mcpingtest.NewServer(t, survival)
+
+	status, err := mcping.Ping(context.Background(), srv.Addr())
+	require.NoError(t, err)
+
+	assert.Equal(t, survival.Version, status.Version)
+	assert.Equal(t, survival.Players, status.Players)
+	assert.Equal(t, "A Minecraft Server", status.MOTD)
+	assert.Positive(t, status.Latency)
+	assert.False(t, status.Legacy)
+	assert.Equal(t, []string{srv.Addr()}, srv.Pings())
+}
+
+func TestPing_ChatComponentMOTD(t *testing.T) {
+	srv := mcpingtest.NewServer(t, survival)
+	srv.SetRawStatus(`{
+		"version": {"name": "1.21.1", "protocol": 767},
+		"players": {"max": 20, "online": 0},
+		"description": {"text": "§aA ", "extra": [{"text": "Fabric", "bold": true}, " server"]}
+	}`)
+
+	status, err := mcping.Ping(context.Background(), srv.Addr())
+	require.NoError(t, err)
+	assert.Equal(t, "A Fabric server", status.MOTD)
+	assert.Empty(t, status.Players.Sample)
+}
+
+func TestPing_NoPong(t *testing.T) {
+	srv := mcpingtest.NewServer(t, survival)
+	srv.NoPong()
+
+	status, err := mcping.Ping(context.Background(), srv.Addr())
+	require.NoError(t, err)
+	assert.Equal(t, "1.21.1", status.Version.Name)
+	assert.Zero(t, status.Latency)
+}
+
+func TestPing_LegacyFallback(t *testing.T) {
+	srv := mcpingtest.NewServer(t, mcping.Status{
+		Version: mcping.Version{Name: "1.6.4", Protocol: 78},
+		Players: mcping.Players{Max: 10, Online: 2},
+		MOTD:    "§6Old §rserver",
+	})
+	srv.LegacyOnly()
+
+	status, err := mcping.Ping(context.Background(), srv.Addr())
+	require.NoError(t, err)
+
+	assert.True(t, status.Legacy)
+	assert.Equal(t, mcping.Version{Name: "1.6.4", Protocol: 78}, status.Version)
+	assert.Equal(t, "Old server", status.MOTD)
+	assert.Equal(t, mcping.Players{Max: 10, Online: 2}, status.Players)
+
+	// Only the legacy ping gets as far as sending host and port
+	assert.Equal(t, []string{srv.Addr()}, srv.Pings())
+}
+
+func TestPing_Errors(t *testing.T) {
+	t.Run("invalid json", func(t *testing.T) {
+		srv := mcpingtest.NewServer(t, survival)
+		srv.SetRawStatus(`not json`)
+
+		// The server would answer the legacy ping, but one that speaks the
+		// status protocol is not pinged the legacy way
+		_, err := mcping.Ping(context.Background(), srv.Addr())
+		assert.ErrorIs(t, err, mcping.ErrInvalidResponse)
+	})
+
+	t.Run("connection refused", func(t *testing.T) {
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		require.NoError(t, err)
+		addr := listener.Addr().String()
+		_ = listener.Close()
+
+		_, err = mcping.Ping(context.Background(), addr)
+		assert.ErrorContains(t, err, "failed to connect")
+	})
+
+	t.Run("silent server", func(t *testing.T) {
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		require.NoError(t, err)
+		t.Cleanup(func() { _ = listener.Close() })
+
+		ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+		defer cancel()
+
+		_, err = mcping.Ping(ctx, listener.Addr().String())
+		assert.Error(t, err)
+	})
+
+	t.Run("invalid port", func(t *testing.T) {
+		_, err := mcping.Ping(context.Background(), "localhost:http")
+		assert.ErrorContains(t, err, "invalid port")
+	})
+}
@@ -0,0 +1,7 @@
+package mcquery
+
+import "errors"
+
+// ErrInvalidResponse is returned when the server answers with something
+// that is not a valid query response.
+var ErrInvalidResponse = errors.New("invalid query response")
@@ -0,0 +1,165 @@
+// Package mcquerytest provides an in-process Minecraft server that answers
+// GameSpy4 queries over UDP, for tests.
+package mcquerytest
+
+import (
+	"bytes"
+	"encoding/binary"
+	"net"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/steviee/go-mc/internal/mcquery"
+)
+
+// Packet types of the query protocol.
+const (
+	typeHandshake = 0x09
+	typeStat      = 0x00
+)
+
+// token is the challenge token the server hands out.
+const token = 9513307
+
+// Server is a fake Minecraft server answering queries on localhost.
+type Server struct {
+	conn net.PacketConn
+
+	mu      sync.Mutex
+	stat    mcquery.FullStat
+	silent  bool
+	queries int
+	wg      sync.WaitGroup
+}
+
+// NewServer starts a fake server answering full stat queries with stat and
+// registers its shutdown with t.
+func NewServer(t testing.TB, stat mcquery.FullStat) *Server {
+	t.Helper()
+
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("mcquerytest: listen: %v", err)
+	}
+
+	s := &Server{conn: conn, stat: stat}
+
+	s.wg.Add(1)
+	go s.serve()
+
+	t.Cleanup(s.Close)
+
+	return s
+}
+
+// Addr returns the host:port the server listens on.
+func (s *Server) Addr() string {
+	return s.conn.LocalAddr().String()
+}
+
+// Port returns the UDP port the server listens on.
+func (s *Server) Port() int {
+	return s.conn.LocalAddr().(*net.UDPAddr).Port
+}
+
+// SetStat changes the full stat the server answers with.
+func (s *Server) SetStat(stat mcquery.FullStat) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.stat = stat
+}
+
+// Silent makes the server ignore all packets, like a server with
+// enable-query turned off.
+func (s *Server) Silent() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.silent = true
+}
+
+// Queries returns the number of full stat queries answered so far.
+func (s *Server) Queries() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.queries
+}
+
+// Close stops the server.
+func (s *Server) Close() {
+	_ = s.conn.Close()
+	s.wg.Wait()
+}
+
+func (s *Server) serve() {
+	defer s.wg.Done()
+
+	buf := make([]byte, 1500)
+	for {
+		n, addr, err := s.conn.ReadFrom(buf)
+		if err != nil {
+			return
+		}
+		if response := s.respond(buf[:n]); response != nil {
+			_, _ = s.conn.WriteTo(response, addr)
+		}
+	}
+}
+
+// respond builds the answer to a request, or nil to ignore it.
+func (s *Server) respond(request []byte) []byte {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.silent || len(request) < 7 || request[0] != 0xfe || request[1] != 0xfd {
+		return nil
+	}
+	header := append([]byte{request[2]}, request[3:7]...)
+
+	switch request[2] {
+	case typeHandshake:
+		return append(append(header, strconv.Itoa(token)...), 0)
+
+	case typeStat:
+		// Token plus the four padding bytes of a full stat request
+		if len(request) != 15 || int32(binary.BigEndian.Uint32(request[7:11])) != token {
+			return nil
+		}
+		s.queries++
+		return append(header, s.fullStat()...)
+	}
+
+	return nil
+}
+
+// fullStat encodes the payload of a full stat response.
+func (s *Server) fullStat() []byte {
+	var buf bytes.Buffer
+	buf.WriteString("splitnum\x00\x80\x00")
+
+	for _, kv := range [][2]string{
+		{"hostname", s.stat.MOTD},
+		{"gametype", s.stat.GameType},
+		{"game_id", s.stat.GameID},
+		{"version", s.stat.Version},
+		{"plugins", s.stat.Plugins},
+		{"map", s.stat.Map},
+		{"numplayers", strconv.Itoa(s.stat.NumPlayers)},
+		{"maxplayers", strconv.Itoa(s.stat.MaxPlayers)},
+		{"hostport", strconv.Itoa(s.stat.HostPort)},
+		{"hostip", s.stat.HostIP},
+	} {
+		buf.WriteString(kv[0] + "\x00" + kv[1] + "\x00")
+	}
+	buf.WriteString("\x00\x01player_\x00\x00")
+
+	for _, player := range s.stat.Players {
+		buf.WriteString(player + "\x00")
+	}
+	buf.WriteByte(0)
+
+	return buf.Bytes()
+}
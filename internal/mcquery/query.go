@@ -0,0 +1,259 @@
+// Package mcquery implements the GameSpy4 query protocol Minecraft servers
+// answer over UDP when enable-query is set. Unlike the server list ping, the
+// full stat lists every online player, the plugins and the map.
+package mcquery
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+	"time"
+)
+
+// DefaultTimeout is the default timeout for a whole query exchange.
+const DefaultTimeout = 5 * time.Second
+
+// Packet types and framing of the protocol.
+const (
+	typeHandshake = 0x09
+	typeStat      = 0x00
+
+	// sessionMask keeps the session ID in the range Minecraft accepts.
+	sessionMask = 0x0f0f0f0f
+
+	// maxPacket is the largest datagram read from a server.
+	maxPacket = 65535
+)
+
+// magic starts every request.
+var magic = []byte{0xfe, 0xfd}
+
+// Framing of the full stat response.
+var (
+	statPadding   = []byte("splitnum\x00\x80\x00")
+	playerPadding = []byte("\x01player_\x00\x00")
+)
+
+// FullStat is the answer of a server to a full stat query.
+type FullStat struct {
+	// MOTD is the message of the day ("hostname" in the protocol).
+	MOTD string
+
+	// GameType is always "SMP" and GameID always "MINECRAFT".
+	GameType string
+	GameID   string
+
+	// Version is the Minecraft version, e.g. "1.21.1".
+	Version string
+
+	// Plugins lists the server software and its plugins, e.g.
+	// "Paper on 1.21.1: LuckPerms 5.4; WorldEdit 7.3". Vanilla and Fabric
+	// servers leave it empty.
+	Plugins string
+
+	// Map is the name of the world.
+	Map string
+
+	NumPlayers int
+	MaxPlayers int
+	HostPort   int
+	HostIP     string
+
+	// Players holds the names of all online players.
+	Players []string
+}
+
+// Query sends a full stat query to address ("host:port") over UDP and
+// returns the server's answer. The exchange is bounded by DefaultTimeout
+// unless ctx has an earlier deadline.
+func Query(ctx context.Context, address string) (*FullStat, error) {
+	ctx, cancel := context.WithTimeout(ctx, DefaultTimeout)
+	defer cancel()
+
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "udp", address)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to %s: %w", address, err)
+	}
+	defer func() { _ = conn.Close() }()
+
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+	}
+
+	session, err := newSessionID()
+	if err != nil {
+		return nil, err
+	}
+
+	token, err := handshake(conn, session)
+	if err != nil {
+		return nil, err
+	}
+
+	request := statRequest(session, token)
+	if _, err := conn.Write(request); err != nil {
+		return nil, fmt.Errorf("failed to send query: %w", err)
+	}
+
+	payload, err := readResponse(conn, typeStat, session)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read query response: %w", err)
+	}
+
+	return parseFullStat(payload)
+}
+
+// newSessionID returns a random session ID.
+func newSessionID() (int32, error) {
+	var b [4]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return 0, fmt.Errorf("failed to generate session ID: %w", err)
+	}
+	return int32(binary.BigEndian.Uint32(b[:]) & sessionMask), nil
+}
+
+// handshake asks the server for a challenge token.
+func handshake(conn net.Conn, session int32) (int32, error) {
+	if _, err := conn.Write(request(typeHandshake, session)); err != nil {
+		return 0, fmt.Errorf("failed to send query handshake: %w", err)
+	}
+
+	payload, err := readResponse(conn, typeHandshake, session)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read query handshake: %w", err)
+	}
+
+	// The token is sent as a NUL-terminated decimal string
+	token, err := strconv.ParseInt(string(bytes.TrimRight(payload, "\x00")), 10, 32)
+	if err != nil {
+		return 0, fmt.Errorf("%w: challenge token %q", ErrInvalidResponse, payload)
+	}
+
+	return int32(token), nil
+}
+
+// request builds a request header of the given type.
+func request(packetType byte, session int32) []byte {
+	buf := append([]byte{}, magic...)
+	buf = append(buf, packetType)
+	return binary.BigEndian.AppendUint32(buf, uint32(session))
+}
+
+// statRequest builds a full stat request. The four padding bytes select the
+// full stat instead of the basic one.
+func statRequest(session, token int32) []byte {
+	buf := request(typeStat, session)
+	buf = binary.BigEndian.AppendUint32(buf, uint32(token))
+	return append(buf, 0x00, 0x00, 0x00, 0x00)
+}
+
+// readResponse reads a response and checks its type and session ID. It
+// returns the payload after the header.
+func readResponse(conn net.Conn, packetType byte, session int32) ([]byte, error) {
+	buf := make([]byte, maxPacket)
+	n, err := conn.Read(buf)
+	if err != nil {
+		return nil, err
+	}
+	buf = buf[:n]
+
+	if len(buf) < 5 {
+		return nil, fmt.Errorf("%w: %d bytes", ErrInvalidResponse, len(buf))
+	}
+	if buf[0] != packetType {
+		return nil, fmt.Errorf("%w: unexpected packet type 0x%02x", ErrInvalidResponse, buf[0])
+	}
+	if got := int32(binary.BigEndian.Uint32(buf[1:5])); got != session {
+		return nil, fmt.Errorf("%w: session ID mismatch", ErrInvalidResponse)
+	}
+
+	return buf[5:], nil
+}
+
+// parseFullStat parses the payload of a full stat response: padding, the
+// key/value section, padding and the player section, all NUL-terminated.
+func parseFullStat(payload []byte) (*FullStat, error) {
+	if !bytes.HasPrefix(payload, statPadding) {
+		return nil, fmt.Errorf("%w: missing stat padding", ErrInvalidResponse)
+	}
+	payload = payload[len(statPadding):]
+
+	values := map[string]string{}
+	for {
+		key, rest, ok := bytes.Cut(payload, []byte{0})
+		if !ok {
+			return nil, fmt.Errorf("%w: truncated key/value section", ErrInvalidResponse)
+		}
+		payload = rest
+		if len(key) == 0 {
+			break
+		}
+
+		value, rest, ok := bytes.Cut(payload, []byte{0})
+		if !ok {
+			return nil, fmt.Errorf("%w: truncated key/value section", ErrInvalidResponse)
+		}
+		payload = rest
+		values[string(key)] = string(value)
+	}
+
+	if !bytes.HasPrefix(payload, playerPadding) {
+		return nil, fmt.Errorf("%w: missing player section", ErrInvalidResponse)
+	}
+	payload = payload[len(playerPadding):]
+
+	stat := &FullStat{
+		MOTD:     values["hostname"],
+		GameType: values["gametype"],
+		GameID:   values["game_id"],
+		Version:  values["version"],
+		Plugins:  values["plugins"],
+		Map:      values["map"],
+		HostIP:   values["hostip"],
+		Players:  splitStrings(payload),
+	}
+
+	var err error
+	for key, dst := range map[string]*int{
+		"numplayers": &stat.NumPlayers,
+		"maxplayers": &stat.MaxPlayers,
+		"hostport":   &stat.HostPort,
+	} {
+		if values[key] == "" {
+			continue
+		}
+		if *dst, err = strconv.Atoi(values[key]); err != nil {
+			return nil, fmt.Errorf("%w: %s %q", ErrInvalidResponse, key, values[key])
+		}
+	}
+
+	return stat, nil
+}
+
+// splitStrings splits the player section into its NUL-terminated names. The
+// section ends at the first empty name.
+func splitStrings(section []byte) []string {
+	strs := []string{}
+	for len(section) > 0 {
+		s, rest, _ := bytes.Cut(section, []byte{0})
+		if len(s) == 0 {
+			break
+		}
+		strs = append(strs, string(s))
+		section = rest
+	}
+	return strs
+}
+
+// IsTimeout reports whether err means the server did not answer in time,
+// which is how a server with the query disabled behaves.
+func IsTimeout(err error) bool {
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
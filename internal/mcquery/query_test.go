@@ -0,0 +1,63 @@
+package mcquery_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/steviee/go-mc/internal/mcquery"
+	"github.com/steviee/go-mc/internal/mcquery/mcquerytest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// paper is the full stat of a Paper server with plugins and two players
+var paper = mcquery.FullStat{
+	MOTD:       "A Minecraft Server",
+	GameType:   "SMP",
+	GameID:     "MINECRAFT",
+	Version:    "1.21.1",
+	Plugins:    "Paper on 1.21.1: LuckPerms 5.4.141; WorldEdit 7.3.6",
+	Map:        "world",
+	NumPlayers: 2,
+	MaxPlayers: 20,
+	HostPort:   25565,
+	HostIP:     "0.0.0.0",
+	Players:    []string{"Notch", "jeb_"},
+}
+
+func TestQuery(t *testing.T) {
+	srv := mcquerytest.NewServer(t, paper)
+
+	stat, err := mcquery.Query(context.Background(), srv.Addr())
+	require.NoError(t, err)
+	assert.Equal(t, &paper, stat)
+	assert.Equal(t, 1, srv.Queries())
+}
+
+func TestQuery_VanillaEmpty(t *testing.T) {
+	// Vanilla servers send an empty plugins value and no players
+	vanilla := paper
+	vanilla.Plugins = ""
+	vanilla.NumPlayers = 0
+	vanilla.Players = []string{}
+	srv := mcquerytest.NewServer(t, vanilla)
+
+	stat, err := mcquery.Query(context.Background(), srv.Addr())
+	require.NoError(t, err)
+	assert.Empty(t, stat.Plugins)
+	assert.Equal(t, "world", stat.Map)
+	assert.Empty(t, stat.Players)
+}
+
+func TestQuery_Disabled(t *testing.T) {
+	srv := mcquerytest.NewServer(t, paper)
+	srv.Silent()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	_, err := mcquery.Query(ctx, srv.Addr())
+	assert.ErrorContains(t, err, "failed to read query handshake")
+	assert.True(t, mcquery.IsTimeout(err))
+}
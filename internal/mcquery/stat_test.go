@@ -0,0 +1,45 @@
+package mcquery
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseFullStat(t *testing.T) {
+	payload := "splitnum\x00\x80\x00" +
+		"hostname\x00Survival\x00plugins\x00\x00map\x00world\x00numplayers\x001\x00maxplayers\x0010\x00\x00" +
+		"\x01player_\x00\x00Notch\x00\x00"
+
+	stat, err := parseFullStat([]byte(payload))
+	require.NoError(t, err)
+	assert.Equal(t, &FullStat{
+		MOTD:       "Survival",
+		Map:        "world",
+		NumPlayers: 1,
+		MaxPlayers: 10,
+		Players:    []string{"Notch"},
+	}, stat)
+}
+
+func TestParseFullStat_Invalid(t *testing.T) {
+	tests := map[string]string{
+		"no padding":        "hostname\x00Survival\x00\x00",
+		"truncated":         "splitnum\x00\x80\x00hostname\x00Surv",
+		"no player section": "splitnum\x00\x80\x00hostname\x00Survival\x00\x00",
+		"bad number":        "splitnum\x00\x80\x00numplayers\x00many\x00\x00\x01player_\x00\x00\x00",
+	}
+
+	for name, payload := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := parseFullStat([]byte(payload))
+			assert.ErrorIs(t, err, ErrInvalidResponse)
+		})
+	}
+}
+
+func TestStatRequest(t *testing.T) {
+	got := statRequest(0x01020304, 9513307)
+	assert.Equal(t, []byte{0xfe, 0xfd, 0x00, 0x01, 0x02, 0x03, 0x04, 0x00, 0x91, 0x29, 0x5b, 0x00, 0x00, 0x00, 0x00}, got)
+}
@@ -0,0 +1,112 @@
+package minecraft
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+)
+
+// ServerPropertiesFile is the name of the server configuration file in a
+// server's data directory.
+const ServerPropertiesFile = "server.properties"
+
+// ReadProperties reads a Java properties file such as server.properties.
+// A missing file yields an empty map.
+func ReadProperties(path string) (map[string]string, error) {
+	//nolint:gosec // G304: path points into a managed server's data directory
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return map[string]string{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	props := make(map[string]string)
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		if key, value, ok := parsePropertyLine(scanner.Text()); ok {
+			props[key] = value
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+
+	return props, nil
+}
+
+// UpdateProperties sets values in a Java properties file, keeping comments
+// and the order of existing keys. Keys that are not present yet are appended
+// in sorted order; a missing file is created.
+func UpdateProperties(path string, values map[string]string) error {
+	//nolint:gosec // G304: path points into a managed server's data directory
+	data, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	var out strings.Builder
+	seen := make(map[string]bool, len(values))
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := scanner.Text()
+		if key, _, ok := parsePropertyLine(line); ok {
+			if value, update := values[key]; update {
+				line = key + "=" + value
+				seen[key] = true
+			}
+		}
+		out.WriteString(line)
+		out.WriteString("\n")
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+
+	missing := make([]string, 0, len(values))
+	for key := range values {
+		if !seen[key] {
+			missing = append(missing, key)
+		}
+	}
+	sort.Strings(missing)
+	for _, key := range missing {
+		out.WriteString(key + "=" + values[key] + "\n")
+	}
+
+	// Write through a temporary file so the server never sees a partial file
+	tmpPath := path + ".tmp"
+	if err := os.WriteFile(tmpPath, []byte(out.String()), 0644); err != nil { //nolint:gosec // G306: server.properties is not secret
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		_ = os.Remove(tmpPath)
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+
+	return nil
+}
+
+// parsePropertyLine splits a "key=value" line. Comments and blank lines are
+// reported as not ok.
+func parsePropertyLine(line string) (string, string, bool) {
+	trimmed := strings.TrimSpace(line)
+	if trimmed == "" || strings.HasPrefix(trimmed, "#") || strings.HasPrefix(trimmed, "!") {
+		return "", "", false
+	}
+
+	key, value, found := strings.Cut(trimmed, "=")
+	if !found {
+		key, value, found = strings.Cut(trimmed, ":")
+	}
+	if !found {
+		return trimmed, "", true
+	}
+
+	return strings.TrimSpace(key), strings.TrimSpace(value), true
+}
@@ -0,0 +1,76 @@
+package minecraft
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadProperties(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ServerPropertiesFile)
+	content := "#Minecraft server properties\n" +
+		"white-list=false\n" +
+		"motd = A Minecraft Server\n" +
+		"\n" +
+		"level-name:world\n"
+	require.NoError(t, os.WriteFile(path, []byte(content), 0600))
+
+	props, err := ReadProperties(path)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		"white-list": "false",
+		"motd":       "A Minecraft Server",
+		"level-name": "world",
+	}, props)
+}
+
+func TestReadProperties_MissingFile(t *testing.T) {
+	props, err := ReadProperties(filepath.Join(t.TempDir(), "missing.properties"))
+	require.NoError(t, err)
+	assert.Empty(t, props)
+}
+
+func TestUpdateProperties(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		values  map[string]string
+		want    string
+	}{
+		{
+			name:    "updates in place and keeps comments",
+			content: "#comment\nwhite-list=false\nmotd=Hello\n",
+			values:  map[string]string{"white-list": "true"},
+			want:    "#comment\nwhite-list=true\nmotd=Hello\n",
+		},
+		{
+			name:    "appends missing keys sorted",
+			content: "motd=Hello\n",
+			values:  map[string]string{"white-list": "true", "enforce-whitelist": "true"},
+			want:    "motd=Hello\nenforce-whitelist=true\nwhite-list=true\n",
+		},
+		{
+			name:   "creates missing file",
+			values: map[string]string{"white-list": "true"},
+			want:   "white-list=true\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), ServerPropertiesFile)
+			if tt.content != "" {
+				require.NoError(t, os.WriteFile(path, []byte(tt.content), 0600))
+			}
+
+			require.NoError(t, UpdateProperties(path, tt.values))
+
+			data, err := os.ReadFile(path)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, string(data))
+		})
+	}
+}
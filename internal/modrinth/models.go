@@ -19,6 +19,8 @@ type Project struct {
 	IconURL     string   `json:"icon_url"`
 	Author      string   `json:"author"`
 	Categories  []string `json:"categories"`
+	ClientSide  string   `json:"client_side"`
+	ServerSide  string   `json:"server_side"`
 }
 
 // ProjectDetails represents detailed information about a project.
@@ -32,6 +34,22 @@ type ProjectDetails struct {
 	Versions    []string `json:"versions"`
 	Downloads   int      `json:"downloads"`
 	IconURL     string   `json:"icon_url"`
+	ClientSide  string   `json:"client_side"`
+	ServerSide  string   `json:"server_side"`
+}
+
+// Support of a project on the client or server side
+const (
+	SideRequired    = "required"
+	SideOptional    = "optional"
+	SideUnsupported = "unsupported"
+	SideUnknown     = "unknown"
+)
+
+// RunsOnServer reports whether a project with the given server_side support
+// can run on a dedicated server. Projects of unknown support are assumed to.
+func RunsOnServer(serverSide string) bool {
+	return serverSide != SideUnsupported
 }
 
 // Version represents a specific version of a mod.
@@ -53,9 +71,17 @@ type Dependency struct {
 	VersionID      string `json:"version_id,omitempty"`
 	ProjectID      string `json:"project_id,omitempty"`
 	FileName       string `json:"file_name,omitempty"`
-	DependencyType string `json:"dependency_type"` // required, optional, incompatible
+	DependencyType string `json:"dependency_type"` // required, optional, incompatible, embedded
 }
 
+// Dependency types
+const (
+	DependencyRequired     = "required"
+	DependencyOptional     = "optional"
+	DependencyIncompatible = "incompatible"
+	DependencyEmbedded     = "embedded"
+)
+
 // File represents a downloadable file.
 type File struct {
 	URL      string `json:"url"`
@@ -63,6 +89,13 @@ type File struct {
 	Primary  bool   `json:"primary"`
 	Size     int64  `json:"size"`
 	FileType string `json:"file_type"`
+	Hashes   Hashes `json:"hashes"`
+}
+
+// Hashes holds the hex-encoded hashes of a file.
+type Hashes struct {
+	SHA512 string `json:"sha512"`
+	SHA1   string `json:"sha1"`
 }
 
 // VersionFilter holds version filtering criteria.
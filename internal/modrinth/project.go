@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"log/slog"
+	"net/url"
 )
 
 // GetProject fetches project details by ID or slug.
@@ -45,3 +46,42 @@ func (c *Client) GetProject(ctx context.Context, idOrSlug string) (*ProjectDetai
 
 	return &project, nil
 }
+
+// GetProjects fetches the details of several projects by ID or slug in one
+// request. Projects that do not exist are left out.
+func (c *Client) GetProjects(ctx context.Context, idsOrSlugs []string) ([]ProjectDetails, error) {
+	if len(idsOrSlugs) == 0 {
+		return []ProjectDetails{}, nil
+	}
+
+	idsJSON, err := json.Marshal(idsOrSlugs)
+	if err != nil {
+		return nil, fmt.Errorf("marshal project IDs: %w", err)
+	}
+	path := "/projects?" + url.Values{"ids": {string(idsJSON)}}.Encode()
+
+	slog.Debug("fetching projects",
+		"count", len(idsOrSlugs))
+
+	// Execute request
+	resp, err := c.doRequest(ctx, "GET", path, nil)
+	if err != nil {
+		return nil, fmt.Errorf("get projects request: %w", err)
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	// Check response
+	if err := checkResponse(resp); err != nil {
+		return nil, err
+	}
+
+	// Decode response
+	var projects []ProjectDetails
+	if err := json.NewDecoder(resp.Body).Decode(&projects); err != nil {
+		return nil, fmt.Errorf("decode response: %w", err)
+	}
+
+	return projects, nil
+}
@@ -31,6 +31,8 @@ func TestClient_GetProject(t *testing.T) {
 				Versions:    []string{"version1", "version2"},
 				Downloads:   1000000,
 				IconURL:     "https://example.com/icon.png",
+				ClientSide:  SideOptional,
+				ServerSide:  SideRequired,
 			},
 			expectedError: false,
 		},
@@ -82,6 +84,8 @@ func TestClient_GetProject(t *testing.T) {
 			assert.Equal(t, tt.serverResponse.Description, project.Description)
 			assert.Equal(t, len(tt.serverResponse.Versions), len(project.Versions))
 			assert.Equal(t, tt.serverResponse.Downloads, project.Downloads)
+			assert.Equal(t, tt.serverResponse.ClientSide, project.ClientSide)
+			assert.Equal(t, tt.serverResponse.ServerSide, project.ServerSide)
 		})
 	}
 }
@@ -138,3 +142,33 @@ func TestClient_GetProject_ServerError(t *testing.T) {
 	assert.ErrorAs(t, err, &apiErr)
 	assert.Equal(t, http.StatusInternalServerError, apiErr.StatusCode)
 }
+
+func TestClient_GetProjects(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/projects", r.URL.Path)
+		assert.Equal(t, `["AAAA","BBBB"]`, r.URL.Query().Get("ids"))
+		_ = json.NewEncoder(w).Encode([]ProjectDetails{{ID: "AAAA", Slug: "lithium"}})
+	}))
+	defer server.Close()
+
+	client := NewClient(&Config{BaseURL: server.URL})
+
+	projects, err := client.GetProjects(context.Background(), []string{"AAAA", "BBBB"})
+
+	require.NoError(t, err)
+	require.Len(t, projects, 1)
+	assert.Equal(t, "lithium", projects[0].Slug)
+
+	// No IDs, no request
+	projects, err = client.GetProjects(context.Background(), nil)
+	require.NoError(t, err)
+	assert.Empty(t, projects)
+}
+
+func TestRunsOnServer(t *testing.T) {
+	assert.True(t, RunsOnServer(SideRequired))
+	assert.True(t, RunsOnServer(SideOptional))
+	assert.True(t, RunsOnServer(SideUnknown))
+	assert.True(t, RunsOnServer(""))
+	assert.False(t, RunsOnServer(SideUnsupported))
+}
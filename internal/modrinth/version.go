@@ -1,6 +1,7 @@
 package modrinth
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
@@ -71,6 +72,120 @@ func (c *Client) GetVersions(ctx context.Context, projectID string, filter *Vers
 	return versions, nil
 }
 
+// GetVersion fetches a version by ID.
+func (c *Client) GetVersion(ctx context.Context, versionID string) (*Version, error) {
+	if versionID == "" {
+		return nil, fmt.Errorf("version ID cannot be empty")
+	}
+
+	slog.Debug("fetching version",
+		"version_id", versionID)
+
+	// Execute request
+	resp, err := c.doRequest(ctx, "GET", "/version/"+versionID, nil)
+	if err != nil {
+		return nil, fmt.Errorf("get version request: %w", err)
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	// Check response
+	if err := checkResponse(resp); err != nil {
+		return nil, err
+	}
+
+	// Decode response
+	var version Version
+	if err := json.NewDecoder(resp.Body).Decode(&version); err != nil {
+		return nil, fmt.Errorf("decode response: %w", err)
+	}
+
+	return &version, nil
+}
+
+// GetVersionsByIDs fetches several versions by ID in one request. Versions
+// that do not exist are left out.
+func (c *Client) GetVersionsByIDs(ctx context.Context, versionIDs []string) ([]Version, error) {
+	if len(versionIDs) == 0 {
+		return []Version{}, nil
+	}
+
+	idsJSON, err := json.Marshal(versionIDs)
+	if err != nil {
+		return nil, fmt.Errorf("marshal version IDs: %w", err)
+	}
+	path := "/versions?" + url.Values{"ids": {string(idsJSON)}}.Encode()
+
+	slog.Debug("fetching versions",
+		"count", len(versionIDs))
+
+	// Execute request
+	resp, err := c.doRequest(ctx, "GET", path, nil)
+	if err != nil {
+		return nil, fmt.Errorf("get versions request: %w", err)
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	// Check response
+	if err := checkResponse(resp); err != nil {
+		return nil, err
+	}
+
+	// Decode response
+	var versions []Version
+	if err := json.NewDecoder(resp.Body).Decode(&versions); err != nil {
+		return nil, fmt.Errorf("decode response: %w", err)
+	}
+
+	return versions, nil
+}
+
+// GetVersionsByHashes looks up the versions that contain files with the
+// given hashes in one request. algorithm is "sha512" or "sha1". The result
+// maps each known hash to its version; unknown hashes are left out.
+func (c *Client) GetVersionsByHashes(ctx context.Context, hashes []string, algorithm string) (map[string]Version, error) {
+	if len(hashes) == 0 {
+		return map[string]Version{}, nil
+	}
+
+	body, err := json.Marshal(map[string]interface{}{
+		"hashes":    hashes,
+		"algorithm": algorithm,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("marshal request: %w", err)
+	}
+
+	slog.Debug("looking up versions by hash",
+		"count", len(hashes),
+		"algorithm", algorithm)
+
+	// Execute request
+	resp, err := c.doRequest(ctx, "POST", "/version_files", bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("get versions by hashes request: %w", err)
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	// Check response
+	if err := checkResponse(resp); err != nil {
+		return nil, err
+	}
+
+	// Decode response
+	versions := map[string]Version{}
+	if err := json.NewDecoder(resp.Body).Decode(&versions); err != nil {
+		return nil, fmt.Errorf("decode response: %w", err)
+	}
+
+	return versions, nil
+}
+
 // FindCompatibleVersion finds the best compatible version for the given Minecraft and loader versions.
 // Returns the latest compatible version if found.
 func (c *Client) FindCompatibleVersion(ctx context.Context, projectID, minecraftVersion, loaderVersion string) (*Version, error) {
@@ -166,30 +281,40 @@ func (c *Client) ResolveDependencies(ctx context.Context, version *Version, mine
 func (c *Client) resolveDepsRecursive(ctx context.Context, version *Version, minecraftVersion string, seen map[string]bool, resolved []Version) ([]Version, error) {
 	for _, dep := range version.Dependencies {
 		// Skip if not required
-		if dep.DependencyType != "required" {
+		if dep.DependencyType != DependencyRequired {
 			continue
 		}
 
-		// Skip if no project ID
-		if dep.ProjectID == "" {
+		// Skip if neither project nor version is given
+		if dep.ProjectID == "" && dep.VersionID == "" {
 			continue
 		}
 
 		// Check for circular dependency
-		if seen[dep.ProjectID] {
+		if dep.ProjectID != "" && seen[dep.ProjectID] {
 			slog.Debug("circular dependency detected, skipping",
 				"project_id", dep.ProjectID)
 			continue
 		}
 
-		seen[dep.ProjectID] = true
-
-		// Find compatible version for dependency
-		depVersion, err := c.FindCompatibleVersion(ctx, dep.ProjectID, minecraftVersion, "")
+		// A dependency pinned to a version gets that version, others the
+		// latest compatible one
+		var depVersion *Version
+		var err error
+		if dep.VersionID != "" {
+			depVersion, err = c.GetVersion(ctx, dep.VersionID)
+		} else {
+			depVersion, err = c.FindCompatibleVersion(ctx, dep.ProjectID, minecraftVersion, "")
+		}
 		if err != nil {
-			return nil, fmt.Errorf("resolve dependency %s: %w", dep.ProjectID, err)
+			return nil, fmt.Errorf("resolve dependency %s: %w", dependencyRef(dep), err)
+		}
+		if seen[depVersion.ProjectID] {
+			continue
 		}
 
+		seen[depVersion.ProjectID] = true
+
 		resolved = append(resolved, *depVersion)
 
 		// Recursively resolve dependencies of this dependency
@@ -201,3 +326,12 @@ func (c *Client) resolveDepsRecursive(ctx context.Context, version *Version, min
 
 	return resolved, nil
 }
+
+// dependencyRef returns the project ID of a dependency, or its version ID
+// for dependencies on a version only.
+func dependencyRef(dep Dependency) string {
+	if dep.ProjectID != "" {
+		return dep.ProjectID
+	}
+	return dep.VersionID
+}
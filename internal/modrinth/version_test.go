@@ -441,3 +441,93 @@ func TestClient_ResolveDependencies(t *testing.T) {
 		})
 	}
 }
+
+func TestClient_GetVersion(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/version/v1" {
+			http.NotFound(w, r)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(Version{ID: "v1", ProjectID: "AAAA"})
+	}))
+	defer server.Close()
+
+	client := NewClient(&Config{BaseURL: server.URL})
+
+	version, err := client.GetVersion(context.Background(), "v1")
+	require.NoError(t, err)
+	assert.Equal(t, "AAAA", version.ProjectID)
+
+	_, err = client.GetVersion(context.Background(), "missing")
+	assert.ErrorIs(t, err, ErrProjectNotFound)
+}
+
+func TestClient_GetVersionsByHashes(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/version_files", r.URL.Path)
+
+		var req struct {
+			Hashes    []string `json:"hashes"`
+			Algorithm string   `json:"algorithm"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		assert.Equal(t, []string{"aaa", "bbb"}, req.Hashes)
+		assert.Equal(t, "sha512", req.Algorithm)
+
+		_ = json.NewEncoder(w).Encode(map[string]Version{"aaa": {ID: "v1", ProjectID: "AAAA"}})
+	}))
+	defer server.Close()
+
+	client := NewClient(&Config{BaseURL: server.URL})
+
+	versions, err := client.GetVersionsByHashes(context.Background(), []string{"aaa", "bbb"}, "sha512")
+
+	require.NoError(t, err)
+	require.Len(t, versions, 1)
+	assert.Equal(t, "AAAA", versions["aaa"].ProjectID)
+}
+
+func TestClient_GetVersionsByIDs(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/versions", r.URL.Path)
+		assert.Equal(t, `["v1","v2"]`, r.URL.Query().Get("ids"))
+		_ = json.NewEncoder(w).Encode([]Version{{ID: "v1", ProjectID: "AAAA"}})
+	}))
+	defer server.Close()
+
+	client := NewClient(&Config{BaseURL: server.URL})
+
+	versions, err := client.GetVersionsByIDs(context.Background(), []string{"v1", "v2"})
+	require.NoError(t, err)
+	require.Len(t, versions, 1)
+	assert.Equal(t, "AAAA", versions[0].ProjectID)
+}
+
+func TestClient_ResolveDependencies_PinnedVersion(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Pinned dependencies are fetched by version, not resolved to the
+		// latest compatible one
+		if r.URL.Path != "/version/dep1-old" {
+			http.NotFound(w, r)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(Version{ID: "dep1-old", ProjectID: "dep1"})
+	}))
+	defer server.Close()
+
+	client := NewClient(&Config{BaseURL: server.URL})
+
+	root := &Version{
+		ID:        "root",
+		ProjectID: "root-project",
+		Dependencies: []Dependency{
+			{ProjectID: "dep1", VersionID: "dep1-old", DependencyType: DependencyRequired},
+		},
+	}
+
+	resolved, err := client.ResolveDependencies(context.Background(), root, "1.21.1")
+	require.NoError(t, err)
+	require.Len(t, resolved, 1)
+	assert.Equal(t, "dep1-old", resolved[0].ID)
+}
@@ -0,0 +1,313 @@
+package mods
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/steviee/go-mc/internal/state"
+)
+
+// Cache is a content-addressed store of mod jars shared by all servers.
+// Jars are keyed by their SHA-512 hash, so a jar is downloaded once no
+// matter how many servers use it. The modification time of a cached jar is
+// the last time it was used, which drives LRU pruning.
+//
+// Layout: <dir>/<first two hex digits>/<sha512>.jar
+type Cache struct {
+	dir string
+	now func() time.Time
+}
+
+// CacheEntry is a jar in the cache.
+type CacheEntry struct {
+	SHA512   string    `json:"sha512"`
+	Path     string    `json:"path"`
+	Size     int64     `json:"size_bytes"`
+	LastUsed time.Time `json:"last_used"`
+
+	// Referenced is true when the jar is installed on any server
+	Referenced bool `json:"referenced"`
+
+	// Filename is the name of the jar on the servers it is installed on
+	Filename string `json:"filename,omitempty"`
+}
+
+// NewCache creates a cache in dir. A leading "~/" is expanded to the home
+// directory.
+func NewCache(dir string) (*Cache, error) {
+	if dir == "" {
+		return nil, fmt.Errorf("cache directory cannot be empty")
+	}
+
+	dir, err := state.ExpandHome(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Cache{dir: dir, now: time.Now}, nil
+}
+
+// NewCacheFromConfig creates the cache configured by mods.cache_dir.
+func NewCacheFromConfig(cfg *state.Config) (*Cache, error) {
+	return NewCache(cfg.Mods.CacheDir)
+}
+
+// Dir returns the directory of the cache.
+func (c *Cache) Dir() string {
+	return c.dir
+}
+
+// path returns the path of the jar with a hash in the cache.
+func (c *Cache) path(sha512 string) (string, error) {
+	sha512 = strings.ToLower(sha512)
+	if len(sha512) != 128 {
+		return "", fmt.Errorf("invalid sha512 %q", sha512)
+	}
+	if _, err := hex.DecodeString(sha512); err != nil {
+		return "", fmt.Errorf("invalid sha512 %q", sha512)
+	}
+	return filepath.Join(c.dir, sha512[:2], sha512+".jar"), nil
+}
+
+// Link places the cached jar with a hash at destPath, as a hardlink where
+// the cache and destPath share a filesystem and as a copy otherwise (which
+// the kernel turns into a reflink on filesystems that support them).
+// It returns false if the jar is not cached. A cached jar that no longer
+// matches its hash is evicted and reported as not cached.
+func (c *Cache) Link(sha512, destPath string) (bool, error) {
+	path, err := c.path(sha512)
+	if err != nil {
+		return false, err
+	}
+
+	if err := VerifyFile(path, Checksum{SHA512: sha512}); err != nil {
+		if errors.Is(err, ErrChecksumMismatch) {
+			slog.Warn("evicting corrupted jar from mod cache", "path", path, "error", err)
+			_ = os.Remove(path)
+			return false, nil
+		}
+		if errors.Is(err, os.ErrNotExist) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	if err := placeFile(path, destPath); err != nil {
+		return false, err
+	}
+	c.touch(path)
+
+	return true, nil
+}
+
+// Add stores the jar at srcPath in the cache under its SHA-512 hash, which
+// the caller has verified.
+func (c *Cache) Add(srcPath, sha512 string) error {
+	path, err := c.path(sha512)
+	if err != nil {
+		return err
+	}
+
+	if _, err := os.Stat(path); err == nil {
+		c.touch(path)
+		return nil
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0750); err != nil {
+		return fmt.Errorf("create cache directory: %w", err)
+	}
+	if err := placeFile(srcPath, path); err != nil {
+		return err
+	}
+	c.touch(path)
+
+	return nil
+}
+
+// touch marks a cached jar as used now.
+func (c *Cache) touch(path string) {
+	now := c.now()
+	_ = os.Chtimes(path, now, now)
+}
+
+// List returns the jars in the cache, least recently used first. Jars
+// installed on any server are marked as referenced.
+func (c *Cache) List(ctx context.Context) ([]CacheEntry, error) {
+	referenced, err := ReferencedHashes(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	entries := []CacheEntry{}
+	err = filepath.WalkDir(c.dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if errors.Is(err, os.ErrNotExist) && path == c.dir {
+				return fs.SkipDir
+			}
+			return err
+		}
+		sha512, ok := strings.CutSuffix(d.Name(), ".jar")
+		if d.IsDir() || !ok || len(sha512) != 128 {
+			return nil
+		}
+
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		filename, ok := referenced[sha512]
+		entries = append(entries, CacheEntry{
+			SHA512:     sha512,
+			Path:       path,
+			Size:       info.Size(),
+			LastUsed:   info.ModTime(),
+			Referenced: ok,
+			Filename:   filename,
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("list mod cache: %w", err)
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool { return entries[i].LastUsed.Before(entries[j].LastUsed) })
+	return entries, nil
+}
+
+// Size returns the number of jars in the cache and their total size.
+func (c *Cache) Size(ctx context.Context) (int, int64, error) {
+	entries, err := c.List(ctx)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	var total int64
+	for _, e := range entries {
+		total += e.Size
+	}
+	return len(entries), total, nil
+}
+
+// Prune removes the least recently used jars until the cache is no larger
+// than maxSize bytes; a maxSize of 0 empties it. With keepReferenced, jars
+// installed on any server are never removed. It returns the removed jars.
+func (c *Cache) Prune(ctx context.Context, maxSize int64, keepReferenced bool) ([]CacheEntry, error) {
+	entries, err := c.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var total int64
+	for _, e := range entries {
+		total += e.Size
+	}
+
+	removed := []CacheEntry{}
+	for _, e := range entries {
+		if total <= maxSize {
+			break
+		}
+		if keepReferenced && e.Referenced {
+			continue
+		}
+
+		if err := os.Remove(e.Path); err != nil && !os.IsNotExist(err) {
+			return removed, fmt.Errorf("remove %s: %w", e.Path, err)
+		}
+		total -= e.Size
+		removed = append(removed, e)
+
+		// Remove the prefix directory once it is empty
+		_ = os.Remove(filepath.Dir(e.Path))
+	}
+
+	return removed, nil
+}
+
+// ReferencedHashes returns the SHA-512 hashes of the mods installed on all
+// servers, mapped to their filenames.
+func ReferencedHashes(ctx context.Context) (map[string]string, error) {
+	names, err := state.ListServers(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("list servers: %w", err)
+	}
+
+	hashes := make(map[string]string)
+	for _, name := range names {
+		serverState, err := state.LoadServerState(ctx, name)
+		if err != nil {
+			return nil, fmt.Errorf("load server %q: %w", name, err)
+		}
+		for _, mod := range serverState.Mods {
+			if mod.SHA512 != "" {
+				hashes[strings.ToLower(mod.SHA512)] = mod.Filename
+			}
+		}
+	}
+
+	return hashes, nil
+}
+
+// placeFile atomically places the content of src at dest, hardlinking it if
+// possible and copying it otherwise.
+func placeFile(src, dest string) error {
+	tmp, err := os.CreateTemp(filepath.Dir(dest), "."+filepath.Base(dest)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("create file: %w", err)
+	}
+	tmpPath := tmp.Name()
+	_ = tmp.Close()
+	defer func() {
+		_ = os.Remove(tmpPath)
+	}()
+
+	// os.Link refuses to replace the placeholder
+	if err := os.Remove(tmpPath); err != nil {
+		return fmt.Errorf("create file: %w", err)
+	}
+	if err := os.Link(src, tmpPath); err != nil {
+		if err := copyFile(src, tmpPath); err != nil {
+			return err
+		}
+	}
+
+	if err := os.Rename(tmpPath, dest); err != nil {
+		return fmt.Errorf("move file into place: %w", err)
+	}
+	return nil
+}
+
+// copyFile copies src to a new file dest. On Linux the copy uses
+// copy_file_range, which reflinks on filesystems that support it.
+func copyFile(src, dest string) error {
+	in, err := os.Open(src) //nolint:gosec // G304: src is a mod jar managed by go-mc
+	if err != nil {
+		return fmt.Errorf("open %s: %w", src, err)
+	}
+	defer func() {
+		_ = in.Close()
+	}()
+
+	out, err := os.OpenFile(dest, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644) //nolint:gosec // G302: jars are read by the container
+	if err != nil {
+		return fmt.Errorf("create file: %w", err)
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		_ = out.Close()
+		return fmt.Errorf("copy %s: %w", src, err)
+	}
+	if err := out.Close(); err != nil {
+		return fmt.Errorf("copy %s: %w", src, err)
+	}
+	return nil
+}
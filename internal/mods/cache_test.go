@@ -0,0 +1,166 @@
+package mods
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/steviee/go-mc/internal/modrinth"
+	"github.com/steviee/go-mc/internal/state"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestCache creates a cache in a temporary directory with a fake clock
+func newTestCache(t *testing.T) (*Cache, *time.Time) {
+	t.Helper()
+
+	cache, err := NewCache(t.TempDir())
+	require.NoError(t, err)
+
+	now := time.Date(2025, 1, 2, 12, 0, 0, 0, time.UTC)
+	cache.now = func() time.Time { return now }
+	return cache, &now
+}
+
+// addJar adds a jar with content to the cache and returns its SHA-512
+func addJar(t *testing.T, cache *Cache, content string) string {
+	t.Helper()
+
+	src := filepath.Join(t.TempDir(), "mod.jar")
+	require.NoError(t, os.WriteFile(src, []byte(content), 0644))
+
+	sha := checksumOf([]byte(content)).SHA512
+	require.NoError(t, cache.Add(src, sha))
+	return sha
+}
+
+func TestNewCache_ExpandsHome(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+
+	cache, err := NewCache("~/.cache/go-mc/mods/")
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(home, ".cache", "go-mc", "mods"), cache.Dir())
+
+	_, err = NewCache("")
+	assert.Error(t, err)
+}
+
+func TestCache_Link(t *testing.T) {
+	cache, _ := newTestCache(t)
+	sha := addJar(t, cache, "lithium")
+
+	dest := filepath.Join(t.TempDir(), "lithium.jar")
+	cached, err := cache.Link(sha, dest)
+	require.NoError(t, err)
+	assert.True(t, cached)
+
+	content, err := os.ReadFile(dest)
+	require.NoError(t, err)
+	assert.Equal(t, "lithium", string(content))
+
+	// Not cached
+	cached, err = cache.Link(checksumOf([]byte("sodium")).SHA512, dest)
+	require.NoError(t, err)
+	assert.False(t, cached)
+
+	// Not a hash
+	_, err = cache.Link("../../etc/passwd", dest)
+	assert.Error(t, err)
+}
+
+func TestCache_LinkEvictsCorruptedJar(t *testing.T) {
+	cache, _ := newTestCache(t)
+	sha := addJar(t, cache, "lithium")
+
+	path, err := cache.path(sha)
+	require.NoError(t, err)
+	require.NoError(t, os.Remove(path))
+	require.NoError(t, os.WriteFile(path, []byte("corrupted"), 0644))
+
+	cached, err := cache.Link(sha, filepath.Join(t.TempDir(), "lithium.jar"))
+	require.NoError(t, err)
+	assert.False(t, cached)
+	assert.NoFileExists(t, path)
+}
+
+func TestCache_Prune(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	require.NoError(t, state.InitDirs())
+
+	cache, now := newTestCache(t)
+	oldest := addJar(t, cache, "oldest")
+	*now = now.Add(time.Hour)
+	used := addJar(t, cache, "used")
+	*now = now.Add(time.Hour)
+	newest := addJar(t, cache, "newest")
+
+	// A server has the used jar installed
+	serverState := state.NewServerState("survival")
+	serverState.Mods = []state.ModInfo{{Slug: "used", Filename: "used-1.0.jar", SHA512: used}}
+	require.NoError(t, state.SaveServerState(context.Background(), serverState))
+	require.NoError(t, state.RegisterServer(context.Background(), "survival"))
+
+	entries, err := cache.List(context.Background())
+	require.NoError(t, err)
+	require.Len(t, entries, 3)
+	assert.Equal(t, []string{oldest, used, newest}, []string{entries[0].SHA512, entries[1].SHA512, entries[2].SHA512}, "least recently used first")
+	assert.True(t, entries[1].Referenced)
+	assert.Equal(t, "used-1.0.jar", entries[1].Filename)
+
+	// Shrinking to one jar keeps the newest, but not the used one
+	removed, err := cache.Prune(context.Background(), 6, false)
+	require.NoError(t, err)
+	require.Len(t, removed, 2)
+	assert.Equal(t, oldest, removed[0].SHA512)
+	assert.Equal(t, used, removed[1].SHA512)
+
+	// Referenced jars are kept
+	addJar(t, cache, "used")
+	removed, err = cache.Prune(context.Background(), 0, true)
+	require.NoError(t, err)
+	require.Len(t, removed, 1)
+	assert.Equal(t, newest, removed[0].SHA512)
+
+	count, size, err := cache.Size(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 1, count)
+	assert.Equal(t, int64(len("used")), size)
+}
+
+func TestDownloadFile_UsesCache(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		_, _ = w.Write([]byte("lithium"))
+	}))
+	defer server.Close()
+
+	cache, _ := newTestCache(t)
+	installer := NewInstaller()
+	installer.SetCache(cache)
+
+	sum := checksumOf([]byte("lithium"))
+	file := modrinth.File{
+		URL:      server.URL,
+		Filename: "lithium.jar",
+		Size:     sum.Size,
+		Hashes:   modrinth.Hashes{SHA512: sum.SHA512, SHA1: sum.SHA1},
+	}
+
+	// The first server downloads the jar, the second takes it from the cache
+	for _, dir := range []string{t.TempDir(), t.TempDir()} {
+		dest := filepath.Join(dir, "lithium.jar")
+		require.NoError(t, installer.DownloadFile(context.Background(), file, dest))
+
+		content, err := os.ReadFile(dest)
+		require.NoError(t, err)
+		assert.Equal(t, "lithium", string(content))
+	}
+	assert.Equal(t, 1, requests)
+}
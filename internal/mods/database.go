@@ -100,6 +100,17 @@ func GetMod(slug string) (ModInfo, error) {
 	return mod, nil
 }
 
+// GetModByProjectID retrieves a mod from the known mods database by its
+// Modrinth project ID.
+func GetModByProjectID(projectID string) (ModInfo, bool) {
+	for _, mod := range KnownMods {
+		if mod.ModrinthID == projectID {
+			return mod, true
+		}
+	}
+	return ModInfo{}, false
+}
+
 // RequiresPort returns true if the mod requires a port to be allocated.
 // Mods with DefaultPort > 0 need network port configuration.
 func (m ModInfo) RequiresPort() bool {
@@ -2,6 +2,7 @@ package mods
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"log/slog"
@@ -19,6 +20,18 @@ import (
 type Installer struct {
 	modrinthClient *modrinth.Client
 	httpClient     *http.Client
+
+	// autoResolveDependencies controls whether dependencies are installed automatically
+	autoResolveDependencies bool
+
+	// cache holds jars downloaded for any server; nil disables it
+	cache *Cache
+
+	// force installs mods despite conflicts
+	force bool
+
+	// maxPorts caps the number of allocated ports; 0 means no limit
+	maxPorts int
 }
 
 // NewInstaller creates a new mod installer.
@@ -26,22 +39,72 @@ type Installer struct {
 // and download mod files.
 func NewInstaller() *Installer {
 	return &Installer{
-		modrinthClient: modrinth.NewClient(nil),
-		httpClient:     &http.Client{},
+		modrinthClient:          modrinth.NewClient(nil),
+		httpClient:              &http.Client{},
+		autoResolveDependencies: true,
+	}
+}
+
+// NewInstallerFromConfig creates a mod installer configured by the mods
+// section of the config: dependency resolution and the jar cache. Ports for
+// mods are capped by limits.max_ports.
+func NewInstallerFromConfig(cfg *state.Config) *Installer {
+	installer := NewInstaller()
+	installer.SetAutoResolveDependencies(cfg.Mods.AutoResolveDependencies)
+	installer.SetMaxPorts(cfg.Limits.MaxPorts)
+
+	cache, err := NewCacheFromConfig(cfg)
+	if err != nil {
+		slog.Warn("mod cache disabled", "error", err)
+		return installer
 	}
+	installer.SetCache(cache)
+
+	return installer
+}
+
+// SetAutoResolveDependencies enables or disables automatic dependency installation.
+// When disabled, InstallMods installs only the requested mods. Enabled by default.
+func (i *Installer) SetAutoResolveDependencies(enabled bool) {
+	i.autoResolveDependencies = enabled
+}
+
+// SetCache sets the jar cache read before downloading and filled after.
+// A nil cache disables caching.
+func (i *Installer) SetCache(cache *Cache) {
+	i.cache = cache
+}
+
+// SetMaxPorts sets the maximum number of allocated ports. Installing a mod
+// that needs a port fails once the limit is reached. 0 disables the limit.
+func (i *Installer) SetMaxPorts(maxPorts int) {
+	i.maxPorts = maxPorts
+}
+
+// SetForce enables installing mods that conflict with each other or with
+// installed mods, and client-only mods. Disabled by default.
+func (i *Installer) SetForce(force bool) {
+	i.force = force
 }
 
 // InstallMods installs a list of mods (by slug) to a server.
-// It automatically resolves and installs dependencies using the curated mod database.
-// Dependencies are installed before the mods that require them.
+// Mods are looked up in the curated database first and on Modrinth
+// otherwise, so any Modrinth project can be installed; the curated database
+// adds ports and config hints on top. Dependencies are installed before the
+// mods that require them.
 //
 // The method:
 //  1. Loads the server state to get Minecraft version and mods directory
-//  2. Resolves dependencies from the curated database
-//  3. Downloads each mod file from Modrinth
-//  4. Saves mod metadata to the server state
+//  2. Picks a version of each mod compatible with the server
+//  3. Resolves its curated and required Modrinth dependencies
+//  4. Checks the mods and the installed mods for conflicts and refuses
+//     client-only mods (server_side: unsupported)
+//  5. Downloads each mod file from Modrinth
+//  6. Saves mod metadata to the server state
 //
 // Returns a list of installed mod slugs (including dependencies) and any error.
+// Conflicts fail the install with a *ConflictError unless force is set; see
+// Install for the optional dependencies and the conflicts installed anyway.
 //
 // Example:
 //
@@ -49,6 +112,17 @@ func NewInstaller() *Installer {
 //	installed, err := installer.InstallMods(ctx, "my-server", []string{"lithium"})
 //	// installed will be ["fabric-api", "lithium"] since lithium depends on fabric-api
 func (i *Installer) InstallMods(ctx context.Context, serverName string, modSlugs []string) ([]string, error) {
+	result, err := i.Install(ctx, serverName, modSlugs)
+	if result == nil {
+		return nil, err
+	}
+	return result.Installed, err
+}
+
+// Install installs mods like InstallMods and also returns the optional
+// dependencies of the installed mods that are missing and, with force, the
+// conflicts the mods were installed despite.
+func (i *Installer) Install(ctx context.Context, serverName string, modSlugs []string) (*InstallResult, error) {
 	// Load server state
 	serverState, err := state.LoadServerState(ctx, serverName)
 	if err != nil {
@@ -71,89 +145,244 @@ func (i *Installer) InstallMods(ctx context.Context, serverName string, modSlugs
 		"mods", modSlugs,
 		"mods_dir", modsDir)
 
-	// Resolve dependencies to get full list of mods to install
-	allModSlugs, err := ResolveDependencies(modSlugs)
+	// Resolve versions and dependencies to get full list of mods to install
+	plan, err := i.planMods(ctx, serverState, modSlugs)
 	if err != nil {
 		return nil, fmt.Errorf("resolve dependencies: %w", err)
 	}
 
-	slog.Debug("dependencies resolved",
-		"requested", modSlugs,
-		"total", allModSlugs)
+	result := &InstallResult{Installed: []string{}, Suggestions: []Suggestion{}, Conflicts: []Conflict{}}
+	if len(plan) == 0 {
+		return result, nil
+	}
 
-	installed := []string{}
+	// Refuse conflicting mods unless forced
+	conflicts, suggestions, err := i.checkPlan(ctx, serverState, plan)
+	if err != nil {
+		return nil, fmt.Errorf("check conflicts: %w", err)
+	}
+	if len(conflicts) > 0 && !i.force {
+		return nil, &ConflictError{Conflicts: conflicts}
+	}
+	for _, c := range conflicts {
+		slog.Warn("installing despite conflict", "conflict", c.Message)
+	}
+	result.Suggestions = suggestions
+	result.Conflicts = conflicts
 
 	// Install each mod
-	for _, slug := range allModSlugs {
-		// Check if already installed
-		if i.isModInstalled(serverState, slug) {
-			slog.Debug("mod already installed, skipping", "slug", slug)
-			continue
-		}
-
-		// Install the mod
-		modInfo, err := i.installSingleMod(ctx, serverState, slug, modsDir)
+	for _, mod := range plan {
+		modInfo, err := i.installSingleMod(ctx, serverState, mod, modsDir)
 		if err != nil {
-			return installed, fmt.Errorf("install mod %q: %w", slug, err)
+			return result, fmt.Errorf("install mod %q: %w", mod.Slug, err)
 		}
 
 		// Add to server state
 		serverState.Mods = append(serverState.Mods, modInfo)
-		installed = append(installed, slug)
+		result.Installed = append(result.Installed, mod.Slug)
 
 		slog.Info("mod installed",
-			"slug", slug,
+			"slug", mod.Slug,
 			"version", modInfo.Version,
 			"filename", modInfo.Filename)
 	}
 
 	// Save updated server state
-	if len(installed) > 0 {
+	if len(result.Installed) > 0 {
 		if err := state.SaveServerState(ctx, serverState); err != nil {
-			return installed, fmt.Errorf("save server state: %w", err)
+			return result, fmt.Errorf("save server state: %w", err)
 		}
 	}
 
-	return installed, nil
+	return result, nil
+}
+
+// plannedMod is a mod to install with the version picked for the server.
+type plannedMod struct {
+	Slug      string
+	Name      string
+	ProjectID string
+
+	// Curated is the entry of the mod in KnownMods, nil for other projects
+	Curated *ModInfo
+
+	// ServerSide is the server_side support of the project on Modrinth,
+	// empty for curated mods
+	ServerSide string
+
+	Version *modrinth.Version
+
+	// Dependencies are the slugs of the mods it requires
+	Dependencies []string
 }
 
-// installSingleMod installs a single mod and returns its ModInfo.
-// It queries the Modrinth API to find a compatible version, downloads the file,
-// allocates ports if needed, and returns the mod metadata for storage in the server state.
-func (i *Installer) installSingleMod(ctx context.Context, serverState *state.ServerState, slug string, modsDir string) (state.ModInfo, error) {
-	// Get mod metadata from database
-	dbMod, err := GetMod(slug)
+// planMods resolves mods to the versions to install, in installation order
+// with dependencies first. Mods that are already installed are left out.
+//
+// Dependencies come from the curated database and from the required
+// dependencies of the picked versions on Modrinth, unless automatic
+// dependency resolution is disabled.
+func (i *Installer) planMods(ctx context.Context, serverState *state.ServerState, modSlugs []string) ([]plannedMod, error) {
+	minecraftVersion := serverState.Minecraft.Version
+	plan := []plannedMod{}
+	slugs := make(map[string]string) // project ID -> slug of seen mods
+
+	// add plans a mod with a picked version after its dependencies
+	var add func(mod plannedMod) error
+	var addSlug func(ref string) error
+
+	add = func(mod plannedMod) error {
+		if _, seen := slugs[mod.ProjectID]; seen {
+			return nil
+		}
+		slugs[mod.ProjectID] = mod.Slug
+
+		if i.isModInstalled(serverState, mod.Slug) || isProjectInstalled(serverState, mod.ProjectID) {
+			slog.Debug("mod already installed, skipping", "slug", mod.Slug)
+			return nil
+		}
+
+		if i.autoResolveDependencies {
+			if mod.Curated != nil {
+				for _, dep := range mod.Curated.Dependencies {
+					if err := addSlug(dep); err != nil {
+						return fmt.Errorf("dependency %q of %q: %w", dep, mod.Slug, err)
+					}
+					mod.Dependencies = appendUnique(mod.Dependencies, dep)
+				}
+			}
+
+			// The required dependencies on Modrinth, transitively; the
+			// deepest come last
+			deps, err := i.modrinthClient.ResolveDependencies(ctx, mod.Version, minecraftVersion)
+			if err != nil {
+				return fmt.Errorf("dependencies of %q: %w", mod.Slug, err)
+			}
+			for j := len(deps) - 1; j >= 0; j-- {
+				dep, err := i.lookupMod(ctx, deps[j].ProjectID)
+				if err != nil {
+					return fmt.Errorf("dependency of %q: %w", mod.Slug, err)
+				}
+				dep.Version = &deps[j]
+				if err := add(dep); err != nil {
+					return err
+				}
+			}
+			for _, dep := range mod.Version.Dependencies {
+				if slug, ok := slugs[dep.ProjectID]; ok && dep.DependencyType == modrinth.DependencyRequired {
+					mod.Dependencies = appendUnique(mod.Dependencies, slug)
+				}
+			}
+		}
+
+		plan = append(plan, mod)
+		return nil
+	}
+
+	addSlug = func(ref string) error {
+		mod, err := i.lookupMod(ctx, ref)
+		if err != nil {
+			return err
+		}
+		if _, seen := slugs[mod.ProjectID]; seen {
+			return nil
+		}
+		if i.isModInstalled(serverState, mod.Slug) || isProjectInstalled(serverState, mod.ProjectID) {
+			slugs[mod.ProjectID] = mod.Slug
+			slog.Debug("mod already installed, skipping", "slug", mod.Slug)
+			return nil
+		}
+
+		mod.Version, err = i.modrinthClient.FindCompatibleVersion(ctx, mod.ProjectID, minecraftVersion, "")
+		if err != nil {
+			return fmt.Errorf("find compatible version of %q for Minecraft %s: %w", mod.Slug, minecraftVersion, err)
+		}
+		return add(mod)
+	}
+
+	for _, slug := range modSlugs {
+		if err := addSlug(slug); err != nil {
+			return nil, err
+		}
+	}
+
+	slog.Debug("dependencies resolved",
+		"requested", modSlugs,
+		"total", len(plan))
+
+	return plan, nil
+}
+
+// lookupMod finds a mod by slug or Modrinth project ID, in the curated
+// database first and on Modrinth otherwise.
+func (i *Installer) lookupMod(ctx context.Context, ref string) (plannedMod, error) {
+	if curated, err := GetMod(ref); err == nil {
+		return curatedMod(curated), nil
+	}
+	if curated, ok := GetModByProjectID(ref); ok {
+		return curatedMod(curated), nil
+	}
+
+	project, err := i.modrinthClient.GetProject(ctx, ref)
 	if err != nil {
-		return state.ModInfo{}, err
+		if errors.Is(err, modrinth.ErrProjectNotFound) {
+			return plannedMod{}, fmt.Errorf("unknown mod: %q is not a Modrinth project", ref)
+		}
+		return plannedMod{}, fmt.Errorf("look up %q on Modrinth: %w", ref, err)
+	}
+
+	// Curated mods referenced by another slug keep their overlay
+	if curated, ok := GetModByProjectID(project.ID); ok {
+		return curatedMod(curated), nil
 	}
 
+	return plannedMod{Slug: project.Slug, Name: project.Title, ProjectID: project.ID, ServerSide: project.ServerSide}, nil
+}
+
+// curatedMod plans a mod of the curated database.
+func curatedMod(curated ModInfo) plannedMod {
+	return plannedMod{
+		Slug:      curated.Slug,
+		Name:      curated.Name,
+		ProjectID: curated.ModrinthID,
+		Curated:   &curated,
+	}
+}
+
+// appendUnique appends a slug unless it is already present.
+func appendUnique(slugs []string, slug string) []string {
+	for _, s := range slugs {
+		if s == slug {
+			return slugs
+		}
+	}
+	return append(slugs, slug)
+}
+
+// installSingleMod installs a planned mod and returns its ModInfo.
+// It downloads the file of the picked version, allocates ports for curated
+// mods that need one, and returns the mod metadata for storage in the
+// server state.
+func (i *Installer) installSingleMod(ctx context.Context, serverState *state.ServerState, mod plannedMod, modsDir string) (state.ModInfo, error) {
 	// Allocate port if mod requires one
 	allocatedPort := 0
-	if dbMod.RequiresPort() {
-		port, err := allocateModPort(ctx, dbMod.DefaultPort)
+	protocol := ""
+	if mod.Curated != nil && mod.Curated.RequiresPort() {
+		port, err := allocateModPort(ctx, mod.Curated.DefaultPort, i.maxPorts)
 		if err != nil {
-			return state.ModInfo{}, fmt.Errorf("allocate port for %s: %w", slug, err)
+			return state.ModInfo{}, fmt.Errorf("allocate port for %s: %w", mod.Slug, err)
 		}
 		allocatedPort = port
+		protocol = mod.Curated.Protocol
 
 		slog.Info("allocated port for mod",
-			"mod", slug,
+			"mod", mod.Slug,
 			"port", port,
-			"protocol", dbMod.Protocol)
-	}
-
-	// Find compatible version from Modrinth
-	version, err := i.modrinthClient.FindCompatibleVersion(ctx, dbMod.ModrinthID, serverState.Minecraft.Version, "")
-	if err != nil {
-		// Release port if we allocated one
-		if allocatedPort > 0 {
-			_ = state.ReleasePort(ctx, allocatedPort)
-		}
-		return state.ModInfo{}, fmt.Errorf("find compatible version: %w", err)
+			"protocol", protocol)
 	}
 
 	// Get primary file
-	file, err := modrinth.GetPrimaryFile(version)
+	file, err := modrinth.GetPrimaryFile(mod.Version)
 	if err != nil {
 		// Release port if we allocated one
 		if allocatedPort > 0 {
@@ -164,7 +393,7 @@ func (i *Installer) installSingleMod(ctx context.Context, serverState *state.Ser
 
 	// Download file
 	destPath := filepath.Join(modsDir, file.Filename)
-	if err := i.DownloadFile(ctx, file.URL, destPath); err != nil {
+	if err := i.DownloadFile(ctx, *file, destPath); err != nil {
 		// Release port if we allocated one
 		if allocatedPort > 0 {
 			_ = state.ReleasePort(ctx, allocatedPort)
@@ -174,29 +403,49 @@ func (i *Installer) installSingleMod(ctx context.Context, serverState *state.Ser
 
 	// Create ModInfo for state
 	modInfo := state.ModInfo{
-		Name:         dbMod.Name,
-		Slug:         slug,
-		Version:      version.VersionNumber,
-		ProjectID:    dbMod.ModrinthID,
-		VersionID:    version.ID,
+		Name:         mod.Name,
+		Slug:         mod.Slug,
+		Version:      mod.Version.VersionNumber,
+		ProjectID:    mod.ProjectID,
+		VersionID:    mod.Version.ID,
 		URL:          file.URL,
 		Filename:     file.Filename,
-		SHA512:       "", // Modrinth API doesn't provide SHA512 in the file struct
+		SHA512:       file.Hashes.SHA512,
 		SizeBytes:    file.Size,
-		Dependencies: dbMod.Dependencies,
+		Dependencies: mod.Dependencies,
 		Port:         allocatedPort,
-		Protocol:     dbMod.Protocol,
+		Protocol:     protocol,
+		ServerSide:   mod.ServerSide,
 	}
 
 	return modInfo, nil
 }
 
-// DownloadFile downloads a file from URL to destination path.
-// It uses an HTTP GET request with context support for cancellation.
+// DownloadFile downloads a Modrinth file to the destination path.
+// The file is streamed to a temporary file next to the destination, verified
+// against the hash and size Modrinth published for it, and then renamed into
+// place, so a failed or tampered download never replaces an existing jar.
+// A mismatch wraps ErrChecksumMismatch.
+// Jars found in the cache are linked from there instead of downloaded, and
+// downloaded jars are added to it.
 // This method is exported for use by the update command.
-func (i *Installer) DownloadFile(ctx context.Context, url, destPath string) error {
+func (i *Installer) DownloadFile(ctx context.Context, file modrinth.File, destPath string) error {
+	// Use the cached jar if there is one
+	if i.cache != nil && file.Hashes.SHA512 != "" {
+		cached, err := i.cache.Link(file.Hashes.SHA512, destPath)
+		if err != nil {
+			slog.Warn("mod cache unavailable", "error", err)
+		}
+		if cached {
+			slog.Debug("file taken from mod cache",
+				"filename", file.Filename,
+				"destination", destPath)
+			return nil
+		}
+	}
+
 	// Create HTTP request
-	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	req, err := http.NewRequestWithContext(ctx, "GET", file.URL, nil)
 	if err != nil {
 		return fmt.Errorf("create request: %w", err)
 	}
@@ -214,23 +463,46 @@ func (i *Installer) DownloadFile(ctx context.Context, url, destPath string) erro
 		return fmt.Errorf("unexpected status: %d", resp.StatusCode)
 	}
 
-	// Create destination file
-	out, err := os.Create(destPath)
+	// Create temporary file in the destination directory, so the rename is atomic
+	out, err := os.CreateTemp(filepath.Dir(destPath), "."+filepath.Base(destPath)+".*.tmp")
 	if err != nil {
 		return fmt.Errorf("create file: %w", err)
 	}
+	tmpPath := out.Name()
 	defer func() {
 		_ = out.Close()
+		_ = os.Remove(tmpPath)
 	}()
 
-	// Copy content
-	if _, err := io.Copy(out, resp.Body); err != nil {
+	// Copy content while hashing it
+	v := newVerifier(ChecksumOf(file))
+	if _, err := io.Copy(io.MultiWriter(out, v), resp.Body); err != nil {
 		return fmt.Errorf("write file: %w", err)
 	}
+	if err := v.Verify(); err != nil {
+		return fmt.Errorf("verify %s: %w", file.Filename, err)
+	}
+
+	if err := out.Close(); err != nil {
+		return fmt.Errorf("write file: %w", err)
+	}
+	if err := os.Chmod(tmpPath, 0644); err != nil {
+		return fmt.Errorf("set file permissions: %w", err)
+	}
+	if err := os.Rename(tmpPath, destPath); err != nil {
+		return fmt.Errorf("move file into place: %w", err)
+	}
 
 	slog.Debug("file downloaded",
-		"url", url,
-		"destination", destPath)
+		"url", file.URL,
+		"destination", destPath,
+		"sha512", v.SHA512())
+
+	if i.cache != nil {
+		if err := i.cache.Add(destPath, v.SHA512()); err != nil {
+			slog.Warn("failed to add file to mod cache", "filename", file.Filename, "error", err)
+		}
+	}
 
 	return nil
 }
@@ -245,6 +517,17 @@ func (i *Installer) isModInstalled(serverState *state.ServerState, slug string)
 	return false
 }
 
+// isProjectInstalled checks if a Modrinth project is already installed,
+// whatever slug it was installed under.
+func isProjectInstalled(serverState *state.ServerState, projectID string) bool {
+	for _, mod := range serverState.Mods {
+		if projectID != "" && mod.ProjectID == projectID {
+			return true
+		}
+	}
+	return false
+}
+
 // getModsDir returns the mods directory path for a server.
 // The mods directory is located parallel to the data volume, not inside it.
 // For example: ~/.local/share/go-mc/servers/myserver/mods
@@ -295,8 +578,15 @@ func (i *Installer) EnsureFabricAPI(ctx context.Context, serverName string) erro
 }
 
 // allocateModPort allocates a port for a mod, trying the preferred port first,
-// then finding the next available port if the preferred one is taken.
-func allocateModPort(ctx context.Context, preferredPort int) (int, error) {
+// then finding the next available port if the preferred one is taken. It
+// fails if maxPorts ports are already allocated, unless maxPorts is 0.
+func allocateModPort(ctx context.Context, preferredPort, maxPorts int) (int, error) {
+	if maxPorts > 0 {
+		if err := state.CheckPortLimit(ctx, 1, maxPorts); err != nil {
+			return 0, err
+		}
+	}
+
 	// Try preferred port first
 	allocated, err := state.IsPortAllocated(ctx, preferredPort)
 	if err != nil {
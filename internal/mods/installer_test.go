@@ -2,13 +2,16 @@ package mods
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
+	"github.com/steviee/go-mc/internal/modrinth"
 	"github.com/steviee/go-mc/internal/state"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -20,6 +23,17 @@ func TestNewInstaller(t *testing.T) {
 	require.NotNil(t, installer)
 	assert.NotNil(t, installer.modrinthClient)
 	assert.NotNil(t, installer.httpClient)
+	assert.True(t, installer.autoResolveDependencies)
+}
+
+func TestSetAutoResolveDependencies(t *testing.T) {
+	installer := NewInstaller()
+
+	installer.SetAutoResolveDependencies(false)
+	assert.False(t, installer.autoResolveDependencies)
+
+	installer.SetAutoResolveDependencies(true)
+	assert.True(t, installer.autoResolveDependencies)
 }
 
 func TestGetModsDir(t *testing.T) {
@@ -141,7 +155,12 @@ func TestDownloadFile(t *testing.T) {
 	installer := NewInstaller()
 	ctx := context.Background()
 
-	err := installer.DownloadFile(ctx, server.URL, destPath)
+	want := checksumOf(testContent)
+	err := installer.DownloadFile(ctx, modrinth.File{
+		URL:    server.URL,
+		Size:   want.Size,
+		Hashes: modrinth.Hashes{SHA512: want.SHA512, SHA1: want.SHA1},
+	}, destPath)
 	require.NoError(t, err)
 
 	// Verify file was created
@@ -153,6 +172,38 @@ func TestDownloadFile(t *testing.T) {
 	assert.Equal(t, testContent, content)
 }
 
+func TestDownloadFile_ChecksumMismatch(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("tampered content"))
+	}))
+	defer server.Close()
+
+	// An earlier version of the mod is kept on a failed download
+	tmpDir := t.TempDir()
+	destPath := filepath.Join(tmpDir, "test-mod.jar")
+	require.NoError(t, os.WriteFile(destPath, []byte("old content"), 0644))
+
+	installer := NewInstaller()
+	ctx := context.Background()
+
+	want := checksumOf([]byte("test mod file content"))
+	err := installer.DownloadFile(ctx, modrinth.File{
+		URL:      server.URL,
+		Filename: "test-mod.jar",
+		Hashes:   modrinth.Hashes{SHA512: want.SHA512, SHA1: want.SHA1},
+	}, destPath)
+	require.ErrorIs(t, err, ErrChecksumMismatch)
+
+	content, err := os.ReadFile(destPath)
+	require.NoError(t, err)
+	assert.Equal(t, "old content", string(content))
+
+	// No temporary file is left behind
+	entries, err := os.ReadDir(tmpDir)
+	require.NoError(t, err)
+	assert.Len(t, entries, 1)
+}
+
 func TestDownloadFile_HTTPError(t *testing.T) {
 	// Create a test HTTP server that returns 404
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -166,7 +217,7 @@ func TestDownloadFile_HTTPError(t *testing.T) {
 	installer := NewInstaller()
 	ctx := context.Background()
 
-	err := installer.DownloadFile(ctx, server.URL, destPath)
+	err := installer.DownloadFile(ctx, modrinth.File{URL: server.URL}, destPath)
 	require.Error(t, err)
 	assert.Contains(t, err.Error(), "unexpected status")
 }
@@ -188,7 +239,7 @@ func TestDownloadFile_ContextCanceled(t *testing.T) {
 	ctx, cancel := context.WithCancel(context.Background())
 	cancel()
 
-	err := installer.DownloadFile(ctx, server.URL, destPath)
+	err := installer.DownloadFile(ctx, modrinth.File{URL: server.URL}, destPath)
 	require.Error(t, err)
 	assert.Contains(t, err.Error(), "context canceled")
 }
@@ -206,7 +257,7 @@ func TestDownloadFile_InvalidPath(t *testing.T) {
 	// Try to write to a directory that doesn't exist and can't be created
 	destPath := "/nonexistent/directory/that/cannot/be/created/test-mod.jar"
 
-	err := installer.DownloadFile(ctx, server.URL, destPath)
+	err := installer.DownloadFile(ctx, modrinth.File{URL: server.URL}, destPath)
 	require.Error(t, err)
 	assert.Contains(t, err.Error(), "create file")
 }
@@ -226,8 +277,9 @@ func TestInstallMods_UnknownMod(t *testing.T) {
 	require.NoError(t, err)
 
 	installer := NewInstaller()
+	installer.modrinthClient = newFakeModrinth(t, nil)
 
-	// Try to install a mod that doesn't exist in the database
+	// Try to install a mod that exists neither in the database nor on Modrinth
 	_, err = installer.InstallMods(ctx, "test-server", []string{"nonexistent-mod"})
 	require.Error(t, err)
 	assert.Contains(t, err.Error(), "unknown mod")
@@ -394,3 +446,160 @@ func TestInstallMods_WithPortAllocation(t *testing.T) {
 	assert.Equal(t, 24454, voiceChatMod.Port)
 	assert.Equal(t, "udp", voiceChatMod.Protocol)
 }
+
+func TestAllocateModPort_PortLimit(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	require.NoError(t, state.InitDirs())
+
+	ctx := context.Background()
+	require.NoError(t, state.AllocatePort(ctx, 24454))
+
+	// The preferred port is taken and the limit is reached
+	_, err := allocateModPort(ctx, 24454, 1)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "limits.max_ports")
+
+	port, err := allocateModPort(ctx, 24454, 2)
+	require.NoError(t, err)
+	assert.Equal(t, 24455, port)
+
+	// No limit
+	port, err = allocateModPort(ctx, 24454, 0)
+	require.NoError(t, err)
+	assert.Equal(t, 24456, port)
+}
+
+// fakeProject is a project served by a fake Modrinth API
+type fakeProject struct {
+	modrinth.ProjectDetails
+	Version modrinth.Version
+}
+
+// newFakeModrinth serves projects, their only version and its file from a
+// fake Modrinth API, also looked up in bulk by ID; other projects are not
+// found
+func newFakeModrinth(t *testing.T, projects []fakeProject) *modrinth.Client {
+	t.Helper()
+
+	var srv *httptest.Server
+	srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasPrefix(r.URL.Path, "/files/") {
+			_, _ = w.Write([]byte("jar"))
+			return
+		}
+
+		// Bulk lookups of versions and projects by ID
+		if r.URL.Path == "/versions" || r.URL.Path == "/projects" {
+			var ids []string
+			_ = json.Unmarshal([]byte(r.URL.Query().Get("ids")), &ids)
+			versions := []modrinth.Version{}
+			found := []modrinth.ProjectDetails{}
+			for _, p := range projects {
+				for _, id := range ids {
+					if id == p.Version.ID {
+						version := p.Version
+						version.ProjectID = p.ID
+						versions = append(versions, version)
+					}
+					if id == p.ID {
+						found = append(found, p.ProjectDetails)
+					}
+				}
+			}
+			if r.URL.Path == "/versions" {
+				_ = json.NewEncoder(w).Encode(versions)
+			} else {
+				_ = json.NewEncoder(w).Encode(found)
+			}
+			return
+		}
+
+		ref := strings.TrimPrefix(r.URL.Path, "/project/")
+		ref, versions := strings.CutSuffix(ref, "/version")
+		for _, p := range projects {
+			if ref != p.ID && ref != p.Slug {
+				continue
+			}
+			if !versions {
+				_ = json.NewEncoder(w).Encode(p.ProjectDetails)
+				return
+			}
+			version := p.Version
+			version.ProjectID = p.ID
+			sum := checksumOf([]byte("jar"))
+			version.Files = []modrinth.File{{
+				URL:      srv.URL + "/files/" + p.Slug + ".jar",
+				Filename: p.Slug + ".jar",
+				Primary:  true,
+				Size:     sum.Size,
+				Hashes:   modrinth.Hashes{SHA512: sum.SHA512, SHA1: sum.SHA1},
+			}}
+			_ = json.NewEncoder(w).Encode([]modrinth.Version{version})
+			return
+		}
+		http.NotFound(w, r)
+	}))
+	t.Cleanup(srv.Close)
+
+	return modrinth.NewClient(&modrinth.Config{BaseURL: srv.URL})
+}
+
+func TestInstallMods_ModrinthProject(t *testing.T) {
+	tmpDir := t.TempDir()
+	t.Setenv("GO_MC_STATE_DIR", tmpDir)
+
+	serverState := state.NewServerState("test-server")
+	serverState.Minecraft.Version = "1.21.1"
+	serverState.Volumes.Data = filepath.Join(tmpDir, "data")
+
+	ctx := context.Background()
+	require.NoError(t, state.SaveServerState(ctx, serverState))
+
+	required := func(projectID string) []modrinth.Dependency {
+		return []modrinth.Dependency{{ProjectID: projectID, DependencyType: "required"}}
+	}
+
+	installer := NewInstaller()
+	installer.modrinthClient = newFakeModrinth(t, []fakeProject{
+		{
+			ProjectDetails: modrinth.ProjectDetails{ID: "AAAA", Slug: "appleskin", Title: "AppleSkin"},
+			Version:        modrinth.Version{ID: "v-appleskin", VersionNumber: "3.0.5", Dependencies: required("BBBB")},
+		},
+		{
+			// An uncurated dependency that requires a curated one
+			ProjectDetails: modrinth.ProjectDetails{ID: "BBBB", Slug: "cloth-config", Title: "Cloth Config API"},
+			Version:        modrinth.Version{ID: "v-cloth", VersionNumber: "15.0.140", Dependencies: required("P7dR8mSH")},
+		},
+		{
+			ProjectDetails: modrinth.ProjectDetails{ID: "P7dR8mSH", Slug: "fabric-api", Title: "Fabric API"},
+			Version:        modrinth.Version{ID: "v-fabric", VersionNumber: "0.100.0"},
+		},
+	})
+
+	installed, err := installer.InstallMods(ctx, "test-server", []string{"appleskin"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"fabric-api", "cloth-config", "appleskin"}, installed, "dependencies first")
+
+	saved, err := state.LoadServerState(ctx, "test-server")
+	require.NoError(t, err)
+	require.Len(t, saved.Mods, 3)
+
+	appleskin := saved.Mods[2]
+	assert.Equal(t, "AppleSkin", appleskin.Name)
+	assert.Equal(t, "AAAA", appleskin.ProjectID)
+	assert.Equal(t, "v-appleskin", appleskin.VersionID)
+	assert.Equal(t, "3.0.5", appleskin.Version)
+	assert.Equal(t, "appleskin.jar", appleskin.Filename)
+	assert.Equal(t, checksumOf([]byte("jar")).SHA512, appleskin.SHA512)
+	assert.Equal(t, []string{"cloth-config"}, appleskin.Dependencies)
+	assert.Equal(t, []string{"fabric-api"}, saved.Mods[1].Dependencies)
+	assert.FileExists(t, filepath.Join(tmpDir, "mods", "appleskin.jar"))
+
+	// The curated overlay names Fabric API
+	assert.Equal(t, "Fabric API", saved.Mods[0].Name)
+
+	// Installed mods are skipped
+	installed, err = installer.InstallMods(ctx, "test-server", []string{"appleskin", "cloth-config"})
+	require.NoError(t, err)
+	assert.Empty(t, installed)
+}
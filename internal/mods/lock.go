@@ -0,0 +1,386 @@
+package mods
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/steviee/go-mc/internal/modrinth"
+	"github.com/steviee/go-mc/internal/state"
+	"gopkg.in/yaml.v3"
+)
+
+// LockfileVersion is the version of the lockfile format
+const LockfileVersion = 1
+
+// ErrInvalidLockfile is returned when a lockfile cannot be used.
+var ErrInvalidLockfile = errors.New("invalid lockfile")
+
+// Lockfile freezes the mod set of a server so it can be reproduced exactly
+// on another server or after a wipe.
+type Lockfile struct {
+	Version   int         `yaml:"version"`
+	Minecraft string      `yaml:"minecraft"`
+	Mods      []LockedMod `yaml:"mods"`
+}
+
+// LockedMod is a mod pinned by a lockfile.
+type LockedMod struct {
+	Slug         string   `yaml:"slug"`
+	Name         string   `yaml:"name"`
+	ProjectID    string   `yaml:"project_id,omitempty"`
+	VersionID    string   `yaml:"version_id,omitempty"`
+	Version      string   `yaml:"version,omitempty"`
+	Filename     string   `yaml:"filename"`
+	URL          string   `yaml:"url"`
+	SHA512       string   `yaml:"sha512"`
+	SHA1         string   `yaml:"sha1"`
+	SizeBytes    int64    `yaml:"size_bytes"`
+	Dependencies []string `yaml:"dependencies,omitempty"`
+}
+
+// SyncResult describes how syncing changed the mods of a server.
+type SyncResult struct {
+	Installed []string `json:"installed"`
+	Updated   []string `json:"updated"`
+	Removed   []string `json:"removed"`
+	Unchanged int      `json:"unchanged"`
+}
+
+// Lock builds the lockfile of a server's mods. The jars on disk are hashed,
+// so the lockfile pins exactly what the server runs; jars that are missing
+// or no longer match the hash recorded at install are an error.
+func Lock(serverState *state.ServerState) (*Lockfile, error) {
+	modsDir, err := getModsDir(serverState)
+	if err != nil {
+		return nil, fmt.Errorf("get mods directory: %w", err)
+	}
+
+	lf := &Lockfile{
+		Version:   LockfileVersion,
+		Minecraft: serverState.Minecraft.Version,
+		Mods:      []LockedMod{},
+	}
+
+	for _, mod := range serverState.Mods {
+		if mod.URL == "" {
+			return nil, fmt.Errorf("mod %q has no download URL", mod.Slug)
+		}
+
+		v, err := hashFile(filepath.Join(modsDir, mod.Filename), Checksum{SHA512: mod.SHA512, Size: mod.SizeBytes})
+		if err != nil {
+			return nil, fmt.Errorf("mod %q: %w", mod.Slug, err)
+		}
+
+		locked := LockedMod{
+			Slug:      mod.Slug,
+			Name:      mod.Name,
+			ProjectID: mod.ProjectID,
+			VersionID: mod.VersionID,
+			Version:   mod.Version,
+			Filename:  mod.Filename,
+			URL:       mod.URL,
+			SHA512:    v.SHA512(),
+			SHA1:      v.SHA1(),
+			SizeBytes: v.size,
+		}
+		if len(mod.Dependencies) > 0 {
+			locked.Dependencies = mod.Dependencies
+		}
+		lf.Mods = append(lf.Mods, locked)
+	}
+
+	// Sorted, so lockfiles of the same mod set are identical
+	sort.Slice(lf.Mods, func(i, j int) bool { return lf.Mods[i].Slug < lf.Mods[j].Slug })
+
+	return lf, nil
+}
+
+// Validate checks the format of the lockfile, that slugs and filenames are
+// unique, that every mod can be downloaded and verified, and that all
+// dependencies are locked too.
+func (lf *Lockfile) Validate() error {
+	if lf.Version != LockfileVersion {
+		return fmt.Errorf("%w: unsupported version %d", ErrInvalidLockfile, lf.Version)
+	}
+	if lf.Minecraft == "" {
+		return fmt.Errorf("%w: no Minecraft version", ErrInvalidLockfile)
+	}
+
+	slugs := make(map[string]bool)
+	filenames := make(map[string]bool)
+	for _, mod := range lf.Mods {
+		switch {
+		case mod.Slug == "":
+			return fmt.Errorf("%w: mod without slug", ErrInvalidLockfile)
+		case slugs[mod.Slug]:
+			return fmt.Errorf("%w: mod %q is locked twice", ErrInvalidLockfile, mod.Slug)
+		case mod.Filename == "" || strings.ContainsAny(mod.Filename, `/\`) || strings.HasPrefix(mod.Filename, "."):
+			return fmt.Errorf("%w: mod %q has invalid filename %q", ErrInvalidLockfile, mod.Slug, mod.Filename)
+		case filenames[mod.Filename]:
+			return fmt.Errorf("%w: filename %q is locked twice", ErrInvalidLockfile, mod.Filename)
+		case mod.URL == "":
+			return fmt.Errorf("%w: mod %q has no URL", ErrInvalidLockfile, mod.Slug)
+		case len(mod.SHA512) != 128:
+			return fmt.Errorf("%w: mod %q has no valid sha512", ErrInvalidLockfile, mod.Slug)
+		}
+		slugs[mod.Slug] = true
+		filenames[mod.Filename] = true
+	}
+
+	for _, mod := range lf.Mods {
+		for _, dep := range mod.Dependencies {
+			if !slugs[dep] {
+				return fmt.Errorf("%w: mod %q depends on %q, which is not locked", ErrInvalidLockfile, mod.Slug, dep)
+			}
+		}
+	}
+
+	return nil
+}
+
+// ReadLockfile reads and validates a lockfile.
+func ReadLockfile(path string) (*Lockfile, error) {
+	data, err := os.ReadFile(path) //nolint:gosec // G304: path is given by the user
+	if err != nil {
+		return nil, fmt.Errorf("read lockfile: %w", err)
+	}
+
+	var lf Lockfile
+	if err := yaml.Unmarshal(data, &lf); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidLockfile, err)
+	}
+	if err := lf.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &lf, nil
+}
+
+// WriteLockfile validates and atomically writes a lockfile.
+func WriteLockfile(path string, lf *Lockfile) error {
+	if err := lf.Validate(); err != nil {
+		return err
+	}
+
+	data, err := yaml.Marshal(lf)
+	if err != nil {
+		return fmt.Errorf("marshal lockfile: %w", err)
+	}
+
+	if err := state.AtomicWrite(path, data, 0644); err != nil {
+		return fmt.Errorf("write lockfile: %w", err)
+	}
+
+	return nil
+}
+
+// Sync makes the mods of a server exactly the set of a lockfile. Locked mods
+// that are missing or installed in another version are downloaded and
+// verified against the lockfile, mods and jars the lockfile does not list
+// are removed, and the server state is rewritten to match.
+//
+// Before anything changes, jars that are already at their locked version are
+// hashed; a jar that drifted from its hash fails the sync with
+// ErrChecksumMismatch so drift is never silently papered over.
+func (i *Installer) Sync(ctx context.Context, serverName string, lf *Lockfile) (*SyncResult, error) {
+	if err := lf.Validate(); err != nil {
+		return nil, err
+	}
+
+	// Load server state
+	serverState, err := state.LoadServerState(ctx, serverName)
+	if err != nil {
+		return nil, fmt.Errorf("load server state: %w", err)
+	}
+
+	if serverState.Minecraft.Version != lf.Minecraft {
+		return nil, fmt.Errorf("lockfile is for Minecraft %s, server runs %s", lf.Minecraft, serverState.Minecraft.Version)
+	}
+
+	modsDir, err := getModsDir(serverState)
+	if err != nil {
+		return nil, fmt.Errorf("get mods directory: %w", err)
+	}
+
+	// Ensure mods directory exists
+	if err := os.MkdirAll(modsDir, 0755); err != nil {
+		return nil, fmt.Errorf("create mods directory: %w", err)
+	}
+
+	installed := make(map[string]state.ModInfo)
+	for _, mod := range serverState.Mods {
+		installed[mod.Slug] = mod
+	}
+
+	// Check for drift before changing anything
+	for _, locked := range lf.Mods {
+		mod, ok := installed[locked.Slug]
+		if !ok || !isLockedVersion(mod, locked) {
+			continue
+		}
+		err := VerifyFile(filepath.Join(modsDir, locked.Filename), lockedChecksum(locked))
+		if errors.Is(err, ErrChecksumMismatch) {
+			return nil, fmt.Errorf("mod %q drifted from the lockfile: %w", locked.Slug, err)
+		}
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			return nil, fmt.Errorf("verify mod %q: %w", locked.Slug, err)
+		}
+	}
+
+	result := &SyncResult{Installed: []string{}, Updated: []string{}, Removed: []string{}}
+	mods := make([]state.ModInfo, 0, len(lf.Mods))
+	keep := make(map[string]bool)
+
+	for _, locked := range lf.Mods {
+		keep[locked.Filename] = true
+		destPath := filepath.Join(modsDir, locked.Filename)
+		mod, ok := installed[locked.Slug]
+
+		switch {
+		case ok && isLockedVersion(mod, locked):
+			// Re-download a jar that went missing
+			if _, err := os.Stat(destPath); errors.Is(err, os.ErrNotExist) {
+				if err := i.DownloadFile(ctx, lockedFile(locked), destPath); err != nil {
+					return result, fmt.Errorf("download mod %q: %w", locked.Slug, err)
+				}
+			}
+			mods = append(mods, lockedModInfo(locked, mod.Port, mod.Protocol))
+			result.Unchanged++
+
+		case ok:
+			// Another version; keep the port of the installed one
+			if err := i.DownloadFile(ctx, lockedFile(locked), destPath); err != nil {
+				return result, fmt.Errorf("download mod %q: %w", locked.Slug, err)
+			}
+			if mod.Filename != locked.Filename {
+				if err := os.Remove(filepath.Join(modsDir, mod.Filename)); err != nil && !os.IsNotExist(err) {
+					slog.Warn("failed to remove old mod file", "file", mod.Filename, "error", err)
+				}
+			}
+			mods = append(mods, lockedModInfo(locked, mod.Port, mod.Protocol))
+			result.Updated = append(result.Updated, locked.Slug)
+
+		default:
+			modInfo, err := i.installSingleMod(ctx, serverState, lockedPlan(locked), modsDir)
+			if err != nil {
+				return result, fmt.Errorf("install mod %q: %w", locked.Slug, err)
+			}
+			mods = append(mods, modInfo)
+			result.Installed = append(result.Installed, locked.Slug)
+		}
+
+		delete(installed, locked.Slug)
+	}
+
+	// Remove mods the lockfile does not list
+	for _, mod := range serverState.Mods {
+		if _, ok := installed[mod.Slug]; !ok {
+			continue
+		}
+		if !keep[mod.Filename] {
+			if err := os.Remove(filepath.Join(modsDir, mod.Filename)); err != nil && !os.IsNotExist(err) {
+				return result, fmt.Errorf("remove mod %q: %w", mod.Slug, err)
+			}
+		}
+		if mod.Port > 0 {
+			_ = state.ReleasePort(ctx, mod.Port)
+		}
+		result.Removed = append(result.Removed, mod.Slug)
+	}
+
+	// Remove jars no mod owns
+	entries, err := os.ReadDir(modsDir)
+	if err != nil {
+		return result, fmt.Errorf("read mods directory: %w", err)
+	}
+	for _, e := range entries {
+		if e.IsDir() || keep[e.Name()] || !strings.HasSuffix(e.Name(), ".jar") {
+			continue
+		}
+		if err := os.Remove(filepath.Join(modsDir, e.Name())); err != nil && !os.IsNotExist(err) {
+			return result, fmt.Errorf("remove %s: %w", e.Name(), err)
+		}
+		if !containsFilename(serverState.Mods, e.Name()) {
+			result.Removed = append(result.Removed, e.Name())
+		}
+	}
+
+	serverState.Mods = mods
+	if err := state.SaveServerState(ctx, serverState); err != nil {
+		return result, fmt.Errorf("save server state: %w", err)
+	}
+
+	return result, nil
+}
+
+// isLockedVersion reports whether an installed mod is the locked one.
+func isLockedVersion(mod state.ModInfo, locked LockedMod) bool {
+	return mod.Filename == locked.Filename && strings.EqualFold(mod.SHA512, locked.SHA512)
+}
+
+// containsFilename reports whether any mod is installed as filename.
+func containsFilename(mods []state.ModInfo, filename string) bool {
+	for _, mod := range mods {
+		if mod.Filename == filename {
+			return true
+		}
+	}
+	return false
+}
+
+// lockedChecksum returns the checksum of a locked mod.
+func lockedChecksum(locked LockedMod) Checksum {
+	return Checksum{SHA512: locked.SHA512, SHA1: locked.SHA1, Size: locked.SizeBytes}
+}
+
+// lockedFile converts a locked mod to a Modrinth file to download.
+func lockedFile(locked LockedMod) modrinth.File {
+	return modrinth.File{
+		URL:      locked.URL,
+		Filename: locked.Filename,
+		Primary:  true,
+		Size:     locked.SizeBytes,
+		Hashes:   modrinth.Hashes{SHA512: locked.SHA512, SHA1: locked.SHA1},
+	}
+}
+
+// lockedPlan plans the install of a locked mod; curated mods that need a
+// port get one allocated.
+func lockedPlan(locked LockedMod) plannedMod {
+	mod := plannedMod{Slug: locked.Slug, Name: locked.Name, ProjectID: locked.ProjectID}
+	if curated, ok := GetModByProjectID(locked.ProjectID); locked.ProjectID != "" && ok {
+		mod.Curated = &curated
+	}
+	mod.Dependencies = locked.Dependencies
+	mod.Version = &modrinth.Version{
+		ID:            locked.VersionID,
+		ProjectID:     locked.ProjectID,
+		VersionNumber: locked.Version,
+		Files:         []modrinth.File{lockedFile(locked)},
+	}
+	return mod
+}
+
+// lockedModInfo returns the server state of a locked mod.
+func lockedModInfo(locked LockedMod, port int, protocol string) state.ModInfo {
+	return state.ModInfo{
+		Name:         locked.Name,
+		Slug:         locked.Slug,
+		Version:      locked.Version,
+		ProjectID:    locked.ProjectID,
+		VersionID:    locked.VersionID,
+		URL:          locked.URL,
+		Filename:     locked.Filename,
+		SHA512:       locked.SHA512,
+		SizeBytes:    locked.SizeBytes,
+		Dependencies: locked.Dependencies,
+		Port:         port,
+		Protocol:     protocol,
+	}
+}
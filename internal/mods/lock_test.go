@@ -0,0 +1,239 @@
+package mods
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/steviee/go-mc/internal/state"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newJarServer serves each jar under /files/<name> with content <name>
+func newJarServer(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/files/"), ".jar")))
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+// lockedJar locks a mod whose jar has content slug, served by srv
+func lockedJar(srv *httptest.Server, slug string, deps ...string) LockedMod {
+	sum := checksumOf([]byte(slug))
+	return LockedMod{
+		Slug:         slug,
+		Name:         slug,
+		ProjectID:    "id-" + slug,
+		VersionID:    "v-" + slug,
+		Filename:     slug + ".jar",
+		URL:          srv.URL + "/files/" + slug + ".jar",
+		SHA512:       sum.SHA512,
+		SHA1:         sum.SHA1,
+		SizeBytes:    sum.Size,
+		Dependencies: deps,
+	}
+}
+
+// saveLockServer saves a server with mods and jars and returns its mods
+// directory
+func saveLockServer(t *testing.T, mods []state.ModInfo, jars map[string]string) string {
+	t.Helper()
+
+	serverState := savePackServer(t)
+	modsDir := filepath.Join(filepath.Dir(serverState.Volumes.Data), "mods")
+	require.NoError(t, os.MkdirAll(modsDir, 0750))
+	for name, content := range jars {
+		require.NoError(t, os.WriteFile(filepath.Join(modsDir, name), []byte(content), 0600))
+	}
+
+	serverState.Mods = mods
+	require.NoError(t, state.SaveServerState(context.Background(), serverState))
+	return modsDir
+}
+
+func TestLock(t *testing.T) {
+	saveLockServer(t, []state.ModInfo{
+		{Slug: "sodium", Filename: "sodium.jar", URL: "https://cdn/sodium.jar", SHA512: checksumOf([]byte("sodium")).SHA512, Dependencies: []string{"fabric-api"}},
+		{Slug: "fabric-api", Filename: "fabric-api.jar", URL: "https://cdn/fabric-api.jar", ProjectID: "P7dR8mSH", VersionID: "v1"},
+	}, map[string]string{
+		"sodium.jar":     "sodium",
+		"fabric-api.jar": "fabric-api",
+	})
+
+	serverState, err := state.LoadServerState(context.Background(), "pack-server")
+	require.NoError(t, err)
+
+	lf, err := Lock(serverState)
+	require.NoError(t, err)
+	require.NoError(t, lf.Validate())
+
+	assert.Equal(t, LockfileVersion, lf.Version)
+	assert.Equal(t, "1.21.1", lf.Minecraft)
+	require.Len(t, lf.Mods, 2)
+
+	// Sorted by slug, hashed from disk
+	sum := checksumOf([]byte("fabric-api"))
+	assert.Equal(t, LockedMod{
+		Slug:      "fabric-api",
+		ProjectID: "P7dR8mSH",
+		VersionID: "v1",
+		Filename:  "fabric-api.jar",
+		URL:       "https://cdn/fabric-api.jar",
+		SHA512:    sum.SHA512,
+		SHA1:      sum.SHA1,
+		SizeBytes: sum.Size,
+	}, lf.Mods[0])
+	assert.Equal(t, []string{"fabric-api"}, lf.Mods[1].Dependencies)
+
+	// Round trip
+	path := filepath.Join(t.TempDir(), "mods.lock")
+	require.NoError(t, WriteLockfile(path, lf))
+	read, err := ReadLockfile(path)
+	require.NoError(t, err)
+	assert.Equal(t, lf, read)
+}
+
+func TestLock_Drift(t *testing.T) {
+	saveLockServer(t, []state.ModInfo{
+		{Slug: "sodium", Filename: "sodium.jar", URL: "https://cdn/sodium.jar", SHA512: checksumOf([]byte("sodium")).SHA512},
+	}, map[string]string{
+		"sodium.jar": "tampered",
+	})
+
+	serverState, err := state.LoadServerState(context.Background(), "pack-server")
+	require.NoError(t, err)
+
+	_, err = Lock(serverState)
+	assert.ErrorIs(t, err, ErrChecksumMismatch)
+}
+
+func TestLockfile_Validate(t *testing.T) {
+	srv := newJarServer(t)
+	valid := func() *Lockfile {
+		return &Lockfile{
+			Version:   LockfileVersion,
+			Minecraft: "1.21.1",
+			Mods:      []LockedMod{lockedJar(srv, "fabric-api"), lockedJar(srv, "sodium", "fabric-api")},
+		}
+	}
+	require.NoError(t, valid().Validate())
+
+	tests := []struct {
+		name   string
+		modify func(*Lockfile)
+	}{
+		{"version", func(lf *Lockfile) { lf.Version = 2 }},
+		{"no minecraft", func(lf *Lockfile) { lf.Minecraft = "" }},
+		{"duplicate slug", func(lf *Lockfile) { lf.Mods[1].Slug = "fabric-api" }},
+		{"duplicate filename", func(lf *Lockfile) { lf.Mods[1].Filename = "fabric-api.jar" }},
+		{"path in filename", func(lf *Lockfile) { lf.Mods[0].Filename = "../fabric-api.jar" }},
+		{"no url", func(lf *Lockfile) { lf.Mods[0].URL = "" }},
+		{"no sha512", func(lf *Lockfile) { lf.Mods[0].SHA512 = "" }},
+		{"unlocked dependency", func(lf *Lockfile) { lf.Mods[1].Dependencies = []string{"cloth-config"} }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lf := valid()
+			tt.modify(lf)
+			assert.ErrorIs(t, lf.Validate(), ErrInvalidLockfile)
+		})
+	}
+}
+
+func TestSync(t *testing.T) {
+	srv := newJarServer(t)
+	fabric := lockedJar(srv, "fabric-api")
+	sodium := lockedJar(srv, "sodium", "fabric-api")
+	lithium := lockedJar(srv, "lithium")
+
+	modsDir := saveLockServer(t, []state.ModInfo{
+		// At its locked version
+		{Slug: "fabric-api", Filename: "fabric-api.jar", SHA512: fabric.SHA512},
+		// Another version
+		{Slug: "sodium", Filename: "sodium-old.jar", SHA512: checksumOf([]byte("old")).SHA512},
+		// Not locked
+		{Slug: "phosphor", Filename: "phosphor.jar"},
+	}, map[string]string{
+		"fabric-api.jar": "fabric-api",
+		"sodium-old.jar": "old",
+		"phosphor.jar":   "phosphor",
+		"stray.jar":      "stray",
+		"notes.txt":      "kept",
+	})
+
+	lf := &Lockfile{Version: LockfileVersion, Minecraft: "1.21.1", Mods: []LockedMod{fabric, lithium, sodium}}
+
+	ctx := context.Background()
+	result, err := NewInstaller().Sync(ctx, "pack-server", lf)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"lithium"}, result.Installed)
+	assert.Equal(t, []string{"sodium"}, result.Updated)
+	assert.Equal(t, []string{"phosphor", "stray.jar"}, result.Removed)
+	assert.Equal(t, 1, result.Unchanged)
+
+	entries, err := os.ReadDir(modsDir)
+	require.NoError(t, err)
+	names := []string{}
+	for _, e := range entries {
+		names = append(names, e.Name())
+	}
+	assert.ElementsMatch(t, []string{"fabric-api.jar", "lithium.jar", "sodium.jar", "notes.txt"}, names)
+	require.NoError(t, VerifyFile(filepath.Join(modsDir, "sodium.jar"), lockedChecksum(sodium)))
+
+	serverState, err := state.LoadServerState(ctx, "pack-server")
+	require.NoError(t, err)
+	require.Len(t, serverState.Mods, 3)
+	for i, locked := range lf.Mods {
+		assert.Equal(t, locked.Slug, serverState.Mods[i].Slug)
+		assert.Equal(t, locked.SHA512, serverState.Mods[i].SHA512)
+		assert.Equal(t, locked.VersionID, serverState.Mods[i].VersionID)
+	}
+
+	// A second sync changes nothing
+	result, err = NewInstaller().Sync(ctx, "pack-server", lf)
+	require.NoError(t, err)
+	assert.Empty(t, result.Installed)
+	assert.Empty(t, result.Updated)
+	assert.Empty(t, result.Removed)
+	assert.Equal(t, 3, result.Unchanged)
+}
+
+func TestSync_Drift(t *testing.T) {
+	srv := newJarServer(t)
+	fabric := lockedJar(srv, "fabric-api")
+
+	modsDir := saveLockServer(t, []state.ModInfo{
+		{Slug: "fabric-api", Filename: "fabric-api.jar", SHA512: fabric.SHA512},
+		{Slug: "phosphor", Filename: "phosphor.jar"},
+	}, map[string]string{
+		"fabric-api.jar": "tampered",
+		"phosphor.jar":   "phosphor",
+	})
+
+	lf := &Lockfile{Version: LockfileVersion, Minecraft: "1.21.1", Mods: []LockedMod{fabric}}
+	_, err := NewInstaller().Sync(context.Background(), "pack-server", lf)
+	assert.ErrorIs(t, err, ErrChecksumMismatch)
+
+	// Nothing changed
+	assert.FileExists(t, filepath.Join(modsDir, "phosphor.jar"))
+	serverState, err := state.LoadServerState(context.Background(), "pack-server")
+	require.NoError(t, err)
+	assert.Len(t, serverState.Mods, 2)
+}
+
+func TestSync_MinecraftMismatch(t *testing.T) {
+	saveLockServer(t, nil, nil)
+
+	lf := &Lockfile{Version: LockfileVersion, Minecraft: "1.20.4", Mods: []LockedMod{}}
+	_, err := NewInstaller().Sync(context.Background(), "pack-server", lf)
+	assert.ErrorContains(t, err, "lockfile is for Minecraft 1.20.4")
+}
@@ -0,0 +1,277 @@
+package mods
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/steviee/go-mc/internal/modrinth"
+	"github.com/steviee/go-mc/internal/mrpack"
+	"github.com/steviee/go-mc/internal/state"
+)
+
+// PackResult describes what installing a pack put on a server.
+type PackResult struct {
+	// Installed are the slugs of the installed mods
+	Installed []string
+
+	// Files is the number of other files downloaded into the data directory
+	Files int
+
+	// Overrides is the number of override files copied
+	Overrides int
+
+	// Skipped is the number of files the pack marks as unsupported on servers
+	Skipped int
+}
+
+// PackOptions describes a pack to export.
+type PackOptions struct {
+	Name                string
+	VersionID           string
+	Summary             string
+	FabricLoaderVersion string
+}
+
+// InstallPack installs the server side of a Modrinth pack on a server.
+// Files marked unsupported on servers are skipped. Mods go to the server's
+// mods directory and are recorded in its state; other files and the
+// overrides go to its data directory. Every download is verified against the
+// hashes in the pack.
+//
+// Mods are looked up on Modrinth by hash, so they are recorded with their
+// project and version and can be updated later; curated mods that need a
+// port get one allocated. Mods Modrinth does not know are recorded by
+// filename.
+func (i *Installer) InstallPack(ctx context.Context, serverName string, pack *mrpack.Pack) (*PackResult, error) {
+	// Load server state
+	serverState, err := state.LoadServerState(ctx, serverName)
+	if err != nil {
+		return nil, fmt.Errorf("load server state: %w", err)
+	}
+
+	modsDir, err := getModsDir(serverState)
+	if err != nil {
+		return nil, fmt.Errorf("get mods directory: %w", err)
+	}
+
+	// Ensure mods directory exists
+	if err := os.MkdirAll(modsDir, 0755); err != nil {
+		return nil, fmt.Errorf("create mods directory: %w", err)
+	}
+
+	files := pack.Index.ServerFiles()
+	result := &PackResult{
+		Installed: []string{},
+		Skipped:   len(pack.Index.Files) - len(files),
+	}
+
+	slog.Info("installing pack",
+		"server", serverName,
+		"pack", pack.Index.Name,
+		"version", pack.Index.VersionID,
+		"files", len(files),
+		"skipped", result.Skipped)
+
+	// Split mods from other files
+	var modFiles []mrpack.File
+	for _, f := range files {
+		if isPackMod(f.Path) {
+			modFiles = append(modFiles, f)
+			continue
+		}
+
+		dest := instancePath(serverState, modsDir, f.Path)
+		if err := os.MkdirAll(filepath.Dir(dest), 0750); err != nil {
+			return result, fmt.Errorf("create directory for %s: %w", f.Path, err)
+		}
+		if err := i.DownloadFile(ctx, packFile(f), dest); err != nil {
+			return result, fmt.Errorf("download %s: %w", f.Path, err)
+		}
+		result.Files++
+	}
+
+	plan := i.planPackMods(ctx, modFiles)
+
+	// Install mods
+	for _, mod := range plan {
+		if i.isModInstalled(serverState, mod.Slug) || isProjectInstalled(serverState, mod.ProjectID) {
+			slog.Debug("mod already installed, skipping", "slug", mod.Slug)
+			continue
+		}
+
+		modInfo, err := i.installSingleMod(ctx, serverState, mod, modsDir)
+		if err != nil {
+			return result, fmt.Errorf("install mod %q: %w", mod.Slug, err)
+		}
+		serverState.Mods = append(serverState.Mods, modInfo)
+		result.Installed = append(result.Installed, mod.Slug)
+	}
+
+	// Copy overrides
+	result.Overrides, err = pack.ExtractOverrides(func(rel string) string {
+		return instancePath(serverState, modsDir, rel)
+	})
+	if err != nil {
+		return result, fmt.Errorf("extract overrides: %w", err)
+	}
+
+	if err := state.SaveServerState(ctx, serverState); err != nil {
+		return result, fmt.Errorf("save server state: %w", err)
+	}
+
+	return result, nil
+}
+
+// planPackMods identifies the mods of a pack on Modrinth. Lookups that fail
+// leave the mods to be recorded by filename.
+func (i *Installer) planPackMods(ctx context.Context, files []mrpack.File) []plannedMod {
+	hashes := make([]string, 0, len(files))
+	for _, f := range files {
+		hashes = append(hashes, strings.ToLower(f.Hashes.SHA512))
+	}
+
+	versions, err := i.modrinthClient.GetVersionsByHashes(ctx, hashes, "sha512")
+	if err != nil {
+		slog.Warn("failed to look up pack mods on Modrinth", "error", err)
+		versions = map[string]modrinth.Version{}
+	}
+
+	projectIDs := []string{}
+	seen := make(map[string]bool)
+	for _, v := range versions {
+		if !seen[v.ProjectID] {
+			seen[v.ProjectID] = true
+			projectIDs = append(projectIDs, v.ProjectID)
+		}
+	}
+	projects := make(map[string]modrinth.ProjectDetails)
+	if found, err := i.modrinthClient.GetProjects(ctx, projectIDs); err != nil {
+		slog.Warn("failed to look up pack projects on Modrinth", "error", err)
+	} else {
+		for _, p := range found {
+			projects[p.ID] = p
+		}
+	}
+
+	plan := make([]plannedMod, 0, len(files))
+	slugs := make(map[string]string) // project ID -> slug
+	for _, f := range files {
+		filename := path.Base(f.Path)
+		mod := plannedMod{
+			Slug: strings.TrimSuffix(filename, ".jar"),
+			Name: filename,
+		}
+		version := modrinth.Version{}
+
+		if v, ok := versions[strings.ToLower(f.Hashes.SHA512)]; ok {
+			version = v
+			mod.ProjectID = v.ProjectID
+			if p, ok := projects[v.ProjectID]; ok {
+				mod.Slug, mod.Name = p.Slug, p.Title
+			}
+			if curated, ok := GetModByProjectID(v.ProjectID); ok {
+				mod = curatedMod(curated)
+			}
+			slugs[mod.ProjectID] = mod.Slug
+		}
+
+		// Install the file of the pack, not the primary file of the version
+		mod.Version = &modrinth.Version{
+			ID:            version.ID,
+			ProjectID:     version.ProjectID,
+			VersionNumber: version.VersionNumber,
+			Dependencies:  version.Dependencies,
+			Files:         []modrinth.File{packFile(f)},
+		}
+		plan = append(plan, mod)
+	}
+
+	// Record the dependencies within the pack
+	for j := range plan {
+		for _, dep := range plan[j].Version.Dependencies {
+			if slug, ok := slugs[dep.ProjectID]; ok && dep.DependencyType == modrinth.DependencyRequired {
+				plan[j].Dependencies = appendUnique(plan[j].Dependencies, slug)
+			}
+		}
+	}
+
+	return plan
+}
+
+// ExportPack builds the index of a Modrinth pack with the mods installed on a
+// server. The jars on disk are hashed, so the pack holds exactly what the
+// server runs; jars that are missing or no longer match the hash recorded
+// at install are an error.
+func ExportPack(serverState *state.ServerState, opts PackOptions) (*mrpack.Index, error) {
+	modsDir, err := getModsDir(serverState)
+	if err != nil {
+		return nil, fmt.Errorf("get mods directory: %w", err)
+	}
+
+	idx := &mrpack.Index{
+		FormatVersion: mrpack.FormatVersion,
+		Game:          mrpack.Game,
+		VersionID:     opts.VersionID,
+		Name:          opts.Name,
+		Summary:       opts.Summary,
+		Files:         []mrpack.File{},
+		Dependencies: map[string]string{
+			mrpack.DependencyMinecraft: serverState.Minecraft.Version,
+		},
+	}
+	if opts.FabricLoaderVersion != "" {
+		idx.Dependencies[mrpack.DependencyFabricLoader] = opts.FabricLoaderVersion
+	}
+
+	for _, mod := range serverState.Mods {
+		if mod.URL == "" {
+			return nil, fmt.Errorf("mod %q has no download URL", mod.Slug)
+		}
+
+		v, err := hashFile(filepath.Join(modsDir, mod.Filename), Checksum{SHA512: mod.SHA512, Size: mod.SizeBytes})
+		if err != nil {
+			return nil, fmt.Errorf("mod %q: %w", mod.Slug, err)
+		}
+
+		idx.Files = append(idx.Files, mrpack.File{
+			Path:      "mods/" + mod.Filename,
+			Hashes:    mrpack.Hashes{SHA1: v.SHA1(), SHA512: v.SHA512()},
+			Downloads: []string{mod.URL},
+			FileSize:  v.size,
+		})
+	}
+
+	return idx, nil
+}
+
+// isPackMod reports whether a file of a pack is a mod jar.
+func isPackMod(p string) bool {
+	rel, ok := strings.CutPrefix(p, "mods/")
+	return ok && !strings.Contains(rel, "/") && strings.HasSuffix(rel, ".jar")
+}
+
+// instancePath maps a path relative to the instance to the server: mods/
+// is the server's mods directory, everything else lives in its data
+// directory.
+func instancePath(serverState *state.ServerState, modsDir, rel string) string {
+	if rest, ok := strings.CutPrefix(rel, "mods/"); ok {
+		return filepath.Join(modsDir, filepath.FromSlash(rest))
+	}
+	return filepath.Join(serverState.Volumes.Data, filepath.FromSlash(rel))
+}
+
+// packFile converts a file of a pack to a Modrinth file to download.
+func packFile(f mrpack.File) modrinth.File {
+	return modrinth.File{
+		URL:      f.Downloads[0],
+		Filename: path.Base(f.Path),
+		Primary:  true,
+		Size:     f.FileSize,
+		Hashes:   modrinth.Hashes{SHA1: f.Hashes.SHA1, SHA512: f.Hashes.SHA512},
+	}
+}
@@ -0,0 +1,192 @@
+package mods
+
+import (
+	"archive/zip"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/steviee/go-mc/internal/modrinth"
+	"github.com/steviee/go-mc/internal/mrpack"
+	"github.com/steviee/go-mc/internal/state"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newPackServer serves "jar" for every download; Modrinth lookups fail, so
+// pack mods are recorded by filename
+func newPackServer(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/version_files" {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write([]byte("jar"))
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+// packFileFor describes a file of a pack served by srv
+func packFileFor(srv *httptest.Server, path string, env *mrpack.Env) mrpack.File {
+	sum := checksumOf([]byte("jar"))
+	return mrpack.File{
+		Path:      path,
+		Hashes:    mrpack.Hashes{SHA1: sum.SHA1, SHA512: sum.SHA512},
+		Env:       env,
+		Downloads: []string{srv.URL + "/files/" + filepath.Base(path)},
+		FileSize:  sum.Size,
+	}
+}
+
+// openTestPack writes a pack with an index and overrides and opens it
+func openTestPack(t *testing.T, idx *mrpack.Index, overrides map[string]string) *mrpack.Pack {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "pack.mrpack")
+	out, err := os.Create(path) //nolint:gosec // G304: test file
+	require.NoError(t, err)
+
+	zw := zip.NewWriter(out)
+	f, err := zw.Create(mrpack.IndexFile)
+	require.NoError(t, err)
+	require.NoError(t, json.NewEncoder(f).Encode(idx))
+	for name, content := range overrides {
+		f, err := zw.Create(name)
+		require.NoError(t, err)
+		_, err = f.Write([]byte(content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, zw.Close())
+	require.NoError(t, out.Close())
+
+	pack, err := mrpack.Open(path)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = pack.Close() })
+	return pack
+}
+
+// savePackServer saves a server to install a pack on
+func savePackServer(t *testing.T) *state.ServerState {
+	t.Helper()
+
+	tmpDir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	require.NoError(t, state.InitDirs())
+
+	serverState := state.NewServerState("pack-server")
+	serverState.Minecraft.Version = "1.21.1"
+	serverState.Volumes.Data = filepath.Join(tmpDir, "data")
+	require.NoError(t, state.SaveServerState(context.Background(), serverState))
+	return serverState
+}
+
+func TestInstallPack(t *testing.T) {
+	serverState := savePackServer(t)
+	srv := newPackServer(t)
+
+	pack := openTestPack(t, &mrpack.Index{
+		FormatVersion: mrpack.FormatVersion,
+		Game:          mrpack.Game,
+		VersionID:     "1.0.0",
+		Name:          "Test Pack",
+		Files: []mrpack.File{
+			packFileFor(srv, "mods/coolmod.jar", nil),
+			packFileFor(srv, "mods/shader.jar", &mrpack.Env{Client: mrpack.EnvRequired, Server: mrpack.EnvUnsupported}),
+			packFileFor(srv, "config/extra.json", &mrpack.Env{Client: mrpack.EnvOptional, Server: mrpack.EnvRequired}),
+		},
+		Dependencies: map[string]string{mrpack.DependencyMinecraft: "1.21.1"},
+	}, map[string]string{
+		"overrides/server.properties":        "motd=client",
+		"server-overrides/server.properties": "motd=server",
+		"overrides/mods/bundled.jar":         "bundled",
+	})
+
+	installer := NewInstaller()
+	installer.modrinthClient = modrinth.NewClient(&modrinth.Config{BaseURL: srv.URL})
+
+	ctx := context.Background()
+	result, err := installer.InstallPack(ctx, "pack-server", pack)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"coolmod"}, result.Installed)
+	assert.Equal(t, 1, result.Files)
+	assert.Equal(t, 3, result.Overrides)
+	assert.Equal(t, 1, result.Skipped)
+
+	modsDir := filepath.Join(filepath.Dir(serverState.Volumes.Data), "mods")
+	assert.FileExists(t, filepath.Join(modsDir, "coolmod.jar"))
+	assert.FileExists(t, filepath.Join(modsDir, "bundled.jar"))
+	assert.NoFileExists(t, filepath.Join(modsDir, "shader.jar"))
+	assert.FileExists(t, filepath.Join(serverState.Volumes.Data, "config", "extra.json"))
+
+	content, err := os.ReadFile(filepath.Join(serverState.Volumes.Data, "server.properties")) //nolint:gosec // G304: test file
+	require.NoError(t, err)
+	assert.Equal(t, "motd=server", string(content))
+
+	loaded, err := state.LoadServerState(ctx, "pack-server")
+	require.NoError(t, err)
+	require.Len(t, loaded.Mods, 1)
+	assert.Equal(t, "coolmod.jar", loaded.Mods[0].Filename)
+	assert.Equal(t, checksumOf([]byte("jar")).SHA512, loaded.Mods[0].SHA512)
+}
+
+func TestInstallPack_ChecksumMismatch(t *testing.T) {
+	savePackServer(t)
+	srv := newPackServer(t)
+
+	file := packFileFor(srv, "mods/coolmod.jar", nil)
+	file.Hashes.SHA512 = checksumOf([]byte("other")).SHA512
+
+	pack := openTestPack(t, &mrpack.Index{
+		FormatVersion: mrpack.FormatVersion,
+		Game:          mrpack.Game,
+		Name:          "Test Pack",
+		Files:         []mrpack.File{file},
+		Dependencies:  map[string]string{mrpack.DependencyMinecraft: "1.21.1"},
+	}, nil)
+
+	installer := NewInstaller()
+	installer.modrinthClient = modrinth.NewClient(&modrinth.Config{BaseURL: srv.URL})
+
+	_, err := installer.InstallPack(context.Background(), "pack-server", pack)
+	assert.ErrorIs(t, err, ErrChecksumMismatch)
+}
+
+func TestExportPack(t *testing.T) {
+	serverState := savePackServer(t)
+	modsDir := filepath.Join(filepath.Dir(serverState.Volumes.Data), "mods")
+	require.NoError(t, os.MkdirAll(modsDir, 0750))
+	require.NoError(t, os.WriteFile(filepath.Join(modsDir, "lithium.jar"), []byte("jar"), 0600))
+
+	sum := checksumOf([]byte("jar"))
+	serverState.Mods = []state.ModInfo{{
+		Slug:      "lithium",
+		Filename:  "lithium.jar",
+		URL:       "https://cdn.modrinth.com/lithium.jar",
+		SHA512:    sum.SHA512,
+		SizeBytes: sum.Size,
+	}}
+
+	idx, err := ExportPack(serverState, PackOptions{Name: "pack-server", VersionID: "1.0.0", FabricLoaderVersion: "0.16.9"})
+	require.NoError(t, err)
+	require.NoError(t, idx.Validate())
+
+	assert.Equal(t, "1.21.1", idx.MinecraftVersion())
+	assert.Equal(t, "0.16.9", idx.Dependencies[mrpack.DependencyFabricLoader])
+	require.Len(t, idx.Files, 1)
+	assert.Equal(t, "mods/lithium.jar", idx.Files[0].Path)
+	assert.Equal(t, sum.SHA1, idx.Files[0].Hashes.SHA1)
+	assert.Equal(t, sum.SHA512, idx.Files[0].Hashes.SHA512)
+
+	// A jar changed since install is not exported
+	require.NoError(t, os.WriteFile(filepath.Join(modsDir, "lithium.jar"), []byte("tampered"), 0600))
+	_, err = ExportPack(serverState, PackOptions{Name: "pack-server"})
+	assert.ErrorIs(t, err, ErrChecksumMismatch)
+}
@@ -0,0 +1,293 @@
+package mods
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sort"
+	"strings"
+
+	"github.com/steviee/go-mc/internal/modrinth"
+	"github.com/steviee/go-mc/internal/state"
+)
+
+// Kinds of conflicts
+const (
+	// ConflictIncompatible is a mod declaring another incompatible
+	ConflictIncompatible = "incompatible"
+
+	// ConflictVersion is a mod requiring another version of a mod than the
+	// one installed or required elsewhere
+	ConflictVersion = "version"
+
+	// ConflictClientOnly is a mod that does not run on servers
+	ConflictClientOnly = "client-only"
+)
+
+// ErrConflict is returned when mods to install conflict with each other,
+// with installed mods or with the server.
+var ErrConflict = errors.New("mod conflict")
+
+// Conflict is a conflict between two mods.
+type Conflict struct {
+	Kind string `json:"kind"`
+
+	// Mod is the slug of the mod that declares the conflict
+	Mod string `json:"mod"`
+
+	// With is the slug (or project ID) of the mod it conflicts with, empty
+	// for client-only mods
+	With string `json:"with,omitempty"`
+
+	Message string `json:"message"`
+}
+
+// Suggestion is an optional dependency of a mod to install that is not
+// installed.
+type Suggestion struct {
+	Slug        string   `json:"slug"`
+	Name        string   `json:"name,omitempty"`
+	SuggestedBy []string `json:"suggested_by"`
+}
+
+// ConflictError lists the conflicts that stopped an install. It wraps
+// ErrConflict.
+type ConflictError struct {
+	Conflicts []Conflict
+}
+
+// Error lists the conflicts, one per line.
+func (e *ConflictError) Error() string {
+	lines := make([]string, 0, len(e.Conflicts)+1)
+	lines = append(lines, fmt.Sprintf("%d mod conflict(s):", len(e.Conflicts)))
+	for _, c := range e.Conflicts {
+		lines = append(lines, "  - "+c.Message)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Unwrap returns ErrConflict.
+func (e *ConflictError) Unwrap() error {
+	return ErrConflict
+}
+
+// InstallResult describes an install.
+type InstallResult struct {
+	// Installed are the slugs of the installed mods, dependencies first
+	Installed []string
+
+	// Suggestions are optional dependencies of the installed mods
+	Suggestions []Suggestion
+
+	// Conflicts are the conflicts installed anyway with force
+	Conflicts []Conflict
+}
+
+// modNode is a mod in the dependency graph: a mod to install or an
+// installed one.
+type modNode struct {
+	Slug          string
+	ProjectID     string
+	VersionID     string
+	VersionNumber string
+	Dependencies  []modrinth.Dependency
+	ServerSide    string
+
+	// Planned is true for mods to install
+	Planned bool
+}
+
+// describe names the mod and its version in messages.
+func (n *modNode) describe() string {
+	if n.VersionNumber == "" {
+		return n.Slug
+	}
+	return n.Slug + " " + n.VersionNumber
+}
+
+// checkPlan builds the dependency graph of the mods to install and the
+// installed mods and returns the conflicts that involve a mod to install,
+// including mods to install that do not run on servers, and the optional
+// dependencies of the mods to install that are missing.
+//
+// The Modrinth dependencies of installed mods are fetched by version ID in
+// one request; installed mods without a version ID only take part as
+// targets of the dependencies of other mods.
+func (i *Installer) checkPlan(ctx context.Context, serverState *state.ServerState, plan []plannedMod) ([]Conflict, []Suggestion, error) {
+	nodes := make([]*modNode, 0, len(plan)+len(serverState.Mods))
+	for _, mod := range plan {
+		node := &modNode{Slug: mod.Slug, ProjectID: mod.ProjectID, ServerSide: mod.ServerSide, Planned: true}
+		if mod.Version != nil {
+			node.VersionID = mod.Version.ID
+			node.VersionNumber = mod.Version.VersionNumber
+			node.Dependencies = mod.Version.Dependencies
+		}
+		nodes = append(nodes, node)
+	}
+
+	versionIDs := []string{}
+	for _, mod := range serverState.Mods {
+		if mod.VersionID != "" {
+			versionIDs = append(versionIDs, mod.VersionID)
+		}
+	}
+	versions, err := i.modrinthClient.GetVersionsByIDs(ctx, versionIDs)
+	if err != nil {
+		return nil, nil, fmt.Errorf("get installed versions: %w", err)
+	}
+	deps := make(map[string][]modrinth.Dependency, len(versions))
+	for _, v := range versions {
+		deps[v.ID] = v.Dependencies
+	}
+	for _, mod := range serverState.Mods {
+		nodes = append(nodes, &modNode{
+			Slug:          mod.Slug,
+			ProjectID:     mod.ProjectID,
+			VersionID:     mod.VersionID,
+			VersionNumber: mod.Version,
+			Dependencies:  deps[mod.VersionID],
+		})
+	}
+
+	byProject := make(map[string]*modNode)
+	byVersion := make(map[string]*modNode)
+	for _, n := range nodes {
+		if n.ProjectID != "" {
+			byProject[n.ProjectID] = n
+		}
+		if n.VersionID != "" {
+			byVersion[n.VersionID] = n
+		}
+	}
+
+	conflicts := []Conflict{}
+	reported := make(map[string]bool)
+	report := func(c Conflict) {
+		pair := []string{c.Mod, c.With}
+		sort.Strings(pair)
+		key := c.Kind + "\x00" + pair[0] + "\x00" + pair[1]
+		if !reported[key] {
+			reported[key] = true
+			conflicts = append(conflicts, c)
+		}
+	}
+
+	type requirement struct {
+		versionID string
+		by        *modNode
+	}
+	required := make(map[string]requirement) // project ID -> first pinned requirement
+	suggested := make(map[string][]string)   // project ID -> slugs suggesting it
+
+	for _, n := range nodes {
+		if n.Planned && !modrinth.RunsOnServer(n.ServerSide) {
+			report(Conflict{
+				Kind:    ConflictClientOnly,
+				Mod:     n.Slug,
+				Message: fmt.Sprintf("%s is client-side only (server_side: %s) and does not run on servers", n.describe(), n.ServerSide),
+			})
+		}
+
+		for _, dep := range n.Dependencies {
+			target := byProject[dep.ProjectID]
+			if dep.ProjectID == "" {
+				target = byVersion[dep.VersionID]
+			}
+			involved := n.Planned || (target != nil && target.Planned)
+
+			switch dep.DependencyType {
+			case modrinth.DependencyIncompatible:
+				if target == nil || target == n || !involved {
+					continue
+				}
+				if dep.VersionID != "" && dep.VersionID != target.VersionID {
+					continue
+				}
+				report(Conflict{
+					Kind:    ConflictIncompatible,
+					Mod:     n.Slug,
+					With:    target.Slug,
+					Message: fmt.Sprintf("%s declares it is incompatible with %s", n.describe(), target.describe()),
+				})
+
+			case modrinth.DependencyRequired:
+				if dep.VersionID == "" || dep.ProjectID == "" {
+					continue
+				}
+				if target != nil {
+					if involved && target.VersionID != "" && target.VersionID != dep.VersionID {
+						report(Conflict{
+							Kind:    ConflictVersion,
+							Mod:     n.Slug,
+							With:    target.Slug,
+							Message: fmt.Sprintf("%s requires version %s of %s, but %s is %s", n.describe(), dep.VersionID, target.Slug, target.describe(), nodeState(target)),
+						})
+					}
+					continue
+				}
+				other, ok := required[dep.ProjectID]
+				if !ok {
+					required[dep.ProjectID] = requirement{versionID: dep.VersionID, by: n}
+					continue
+				}
+				if other.versionID != dep.VersionID && (n.Planned || other.by.Planned) {
+					report(Conflict{
+						Kind:    ConflictVersion,
+						Mod:     n.Slug,
+						With:    other.by.Slug,
+						Message: fmt.Sprintf("%s requires version %s of %s, but %s requires version %s", n.describe(), dep.VersionID, dep.ProjectID, other.by.describe(), other.versionID),
+					})
+				}
+
+			case modrinth.DependencyOptional:
+				if n.Planned && target == nil && dep.ProjectID != "" {
+					suggested[dep.ProjectID] = appendUnique(suggested[dep.ProjectID], n.Slug)
+				}
+			}
+		}
+	}
+
+	return conflicts, i.suggestions(ctx, suggested), nil
+}
+
+// nodeState tells whether a mod is installed or about to be.
+func nodeState(n *modNode) string {
+	if n.Planned {
+		return "about to be installed"
+	}
+	return "installed"
+}
+
+// suggestions names the suggested projects and leaves out client-only ones.
+// Projects that cannot be looked up are suggested by project ID.
+func (i *Installer) suggestions(ctx context.Context, suggested map[string][]string) []Suggestion {
+	ids := make([]string, 0, len(suggested))
+	for id := range suggested {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	projects := make(map[string]modrinth.ProjectDetails)
+	if found, err := i.modrinthClient.GetProjects(ctx, ids); err != nil {
+		slog.Warn("failed to look up suggested mods on Modrinth", "error", err)
+	} else {
+		for _, p := range found {
+			projects[p.ID] = p
+		}
+	}
+
+	result := make([]Suggestion, 0, len(ids))
+	for _, id := range ids {
+		s := Suggestion{Slug: id, SuggestedBy: suggested[id]}
+		if p, ok := projects[id]; ok {
+			if !modrinth.RunsOnServer(p.ServerSide) {
+				continue
+			}
+			s.Slug, s.Name = p.Slug, p.Title
+		}
+		result = append(result, s)
+	}
+	sort.SliceStable(result, func(a, b int) bool { return result[a].Slug < result[b].Slug })
+	return result
+}
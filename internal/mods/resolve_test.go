@@ -0,0 +1,189 @@
+package mods
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/steviee/go-mc/internal/modrinth"
+	"github.com/steviee/go-mc/internal/state"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// saveServerWithSlowmod saves a server with slowmod 1.0 installed
+func saveServerWithSlowmod(t *testing.T) *state.ServerState {
+	t.Helper()
+
+	tmpDir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	require.NoError(t, state.InitDirs())
+
+	serverState := state.NewServerState("test-server")
+	serverState.Minecraft.Version = "1.21.1"
+	serverState.Volumes.Data = filepath.Join(tmpDir, "data")
+	serverState.Mods = []state.ModInfo{
+		{Slug: "slowmod", ProjectID: "SSSS", VersionID: "v-slow", Version: "1.0", Filename: "slowmod.jar"},
+	}
+	require.NoError(t, state.SaveServerState(context.Background(), serverState))
+	return serverState
+}
+
+func TestInstall_Incompatible(t *testing.T) {
+	saveServerWithSlowmod(t)
+
+	fake := []fakeProject{
+		{
+			ProjectDetails: modrinth.ProjectDetails{ID: "FFFF", Slug: "fastmod", Title: "Fastmod"},
+			Version: modrinth.Version{ID: "v-fast", VersionNumber: "2.0", Dependencies: []modrinth.Dependency{
+				{ProjectID: "SSSS", DependencyType: modrinth.DependencyIncompatible},
+				{ProjectID: "HHHH", DependencyType: modrinth.DependencyOptional},
+			}},
+		},
+		{
+			ProjectDetails: modrinth.ProjectDetails{ID: "SSSS", Slug: "slowmod", Title: "Slowmod"},
+			Version:        modrinth.Version{ID: "v-slow", VersionNumber: "1.0"},
+		},
+		{
+			ProjectDetails: modrinth.ProjectDetails{ID: "HHHH", Slug: "helper", Title: "Helper"},
+			Version:        modrinth.Version{ID: "v-help", VersionNumber: "0.1"},
+		},
+	}
+
+	ctx := context.Background()
+	installer := NewInstaller()
+	installer.modrinthClient = newFakeModrinth(t, fake)
+
+	_, err := installer.Install(ctx, "test-server", []string{"fastmod"})
+	require.ErrorIs(t, err, ErrConflict)
+	assert.Contains(t, err.Error(), "fastmod 2.0 declares it is incompatible with slowmod 1.0")
+
+	serverState, err := state.LoadServerState(ctx, "test-server")
+	require.NoError(t, err)
+	assert.Len(t, serverState.Mods, 1)
+
+	// Forced
+	installer.SetForce(true)
+	result, err := installer.Install(ctx, "test-server", []string{"fastmod"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"fastmod"}, result.Installed)
+	require.Len(t, result.Conflicts, 1)
+	assert.Equal(t, ConflictIncompatible, result.Conflicts[0].Kind)
+	assert.Equal(t, []Suggestion{{Slug: "helper", Name: "Helper", SuggestedBy: []string{"fastmod"}}}, result.Suggestions)
+}
+
+func TestCheckPlan(t *testing.T) {
+	serverState := saveServerWithSlowmod(t)
+
+	installer := NewInstaller()
+	installer.modrinthClient = newFakeModrinth(t, []fakeProject{
+		{
+			// The installed version declares the conflict too
+			ProjectDetails: modrinth.ProjectDetails{ID: "SSSS", Slug: "slowmod"},
+			Version: modrinth.Version{ID: "v-slow", VersionNumber: "1.0", Dependencies: []modrinth.Dependency{
+				{ProjectID: "FFFF", DependencyType: modrinth.DependencyIncompatible},
+			}},
+		},
+	})
+
+	plan := []plannedMod{
+		{
+			Slug:      "fastmod",
+			ProjectID: "FFFF",
+			Version: &modrinth.Version{ID: "v-fast", VersionNumber: "2.0", Dependencies: []modrinth.Dependency{
+				{ProjectID: "SSSS", DependencyType: modrinth.DependencyIncompatible},
+				{ProjectID: "HHHH", VersionID: "v-help-1", DependencyType: modrinth.DependencyRequired},
+			}},
+		},
+		{
+			Slug:      "extras",
+			ProjectID: "EEEE",
+			Version: &modrinth.Version{ID: "v-extras", VersionNumber: "1.0", Dependencies: []modrinth.Dependency{
+				{ProjectID: "SSSS", VersionID: "v-slow-old", DependencyType: modrinth.DependencyRequired},
+				{ProjectID: "HHHH", VersionID: "v-help-2", DependencyType: modrinth.DependencyRequired},
+			}},
+		},
+	}
+
+	conflicts, suggestions, err := installer.checkPlan(context.Background(), serverState, plan)
+	require.NoError(t, err)
+	assert.Empty(t, suggestions)
+
+	assert.Equal(t, []Conflict{
+		{
+			Kind:    ConflictIncompatible,
+			Mod:     "fastmod",
+			With:    "slowmod",
+			Message: "fastmod 2.0 declares it is incompatible with slowmod 1.0",
+		},
+		{
+			Kind:    ConflictVersion,
+			Mod:     "extras",
+			With:    "slowmod",
+			Message: "extras 1.0 requires version v-slow-old of slowmod, but slowmod 1.0 is installed",
+		},
+		{
+			Kind:    ConflictVersion,
+			Mod:     "extras",
+			With:    "fastmod",
+			Message: "extras 1.0 requires version v-help-2 of HHHH, but fastmod 2.0 requires version v-help-1",
+		},
+	}, conflicts)
+}
+
+func TestCheckPlan_NoConflicts(t *testing.T) {
+	serverState := saveServerWithSlowmod(t)
+
+	installer := NewInstaller()
+	installer.modrinthClient = newFakeModrinth(t, []fakeProject{
+		{
+			ProjectDetails: modrinth.ProjectDetails{ID: "SSSS", Slug: "slowmod"},
+			Version:        modrinth.Version{ID: "v-slow", VersionNumber: "1.0"},
+		},
+	})
+
+	// Requiring the installed version and declaring a version of slowmod
+	// that is not installed incompatible is fine
+	plan := []plannedMod{{
+		Slug:      "fastmod",
+		ProjectID: "FFFF",
+		Version: &modrinth.Version{ID: "v-fast", Dependencies: []modrinth.Dependency{
+			{ProjectID: "SSSS", VersionID: "v-slow", DependencyType: modrinth.DependencyRequired},
+			{ProjectID: "SSSS", VersionID: "v-slow-old", DependencyType: modrinth.DependencyIncompatible},
+		}},
+	}}
+
+	conflicts, _, err := installer.checkPlan(context.Background(), serverState, plan)
+	require.NoError(t, err)
+	assert.Empty(t, conflicts)
+}
+
+func TestInstall_ClientOnly(t *testing.T) {
+	saveServerWithSlowmod(t)
+
+	ctx := context.Background()
+	installer := NewInstaller()
+	installer.modrinthClient = newFakeModrinth(t, []fakeProject{
+		{
+			ProjectDetails: modrinth.ProjectDetails{ID: "IIII", Slug: "iris", Title: "Iris", ServerSide: modrinth.SideUnsupported},
+			Version:        modrinth.Version{ID: "v-iris", VersionNumber: "1.7"},
+		},
+	})
+
+	_, err := installer.Install(ctx, "test-server", []string{"iris"})
+	require.ErrorIs(t, err, ErrConflict)
+	assert.Contains(t, err.Error(), "iris 1.7 is client-side only")
+
+	installer.SetForce(true)
+	result, err := installer.Install(ctx, "test-server", []string{"iris"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"iris"}, result.Installed)
+	require.Len(t, result.Conflicts, 1)
+	assert.Equal(t, ConflictClientOnly, result.Conflicts[0].Kind)
+
+	// The support is recorded
+	serverState, err := state.LoadServerState(ctx, "test-server")
+	require.NoError(t, err)
+	require.Len(t, serverState.Mods, 2)
+	assert.Equal(t, modrinth.SideUnsupported, serverState.Mods[1].ServerSide)
+}
@@ -0,0 +1,67 @@
+package mods
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/steviee/go-mc/internal/modrinth"
+	"github.com/steviee/go-mc/internal/state"
+)
+
+// ClientOnlyMods returns the slugs of the installed mods that do not run on
+// servers (server_side: unsupported), in the order of the server state.
+//
+// Only the support recorded at install time is used, so no request is made.
+// Mods installed before it was recorded are not flagged until
+// RefreshServerSide looks them up.
+func ClientOnlyMods(serverState *state.ServerState) []string {
+	clientOnly := []string{}
+	for _, mod := range serverState.Mods {
+		if !modrinth.RunsOnServer(mod.ServerSide) {
+			clientOnly = append(clientOnly, mod.Slug)
+		}
+	}
+	return clientOnly
+}
+
+// RefreshServerSide looks up the server_side support of the installed
+// Modrinth mods that have none recorded, in one request, and records it in
+// the server state. It returns the number of mods updated.
+func (i *Installer) RefreshServerSide(ctx context.Context, serverState *state.ServerState) (int, error) {
+	ids := []string{}
+	for _, mod := range serverState.Mods {
+		if mod.ServerSide == "" && mod.ProjectID != "" {
+			ids = append(ids, mod.ProjectID)
+		}
+	}
+	if len(ids) == 0 {
+		return 0, nil
+	}
+
+	projects, err := i.modrinthClient.GetProjects(ctx, ids)
+	if err != nil {
+		return 0, fmt.Errorf("look up mods on Modrinth: %w", err)
+	}
+
+	sides := make(map[string]string, len(projects)) // project ID -> server_side
+	for _, p := range projects {
+		sides[p.ID] = p.ServerSide
+	}
+
+	updated := 0
+	for j := range serverState.Mods {
+		mod := &serverState.Mods[j]
+		if side := sides[mod.ProjectID]; mod.ServerSide == "" && side != "" {
+			mod.ServerSide = side
+			updated++
+		}
+	}
+	if updated == 0 {
+		return 0, nil
+	}
+
+	if err := state.SaveServerState(ctx, serverState); err != nil {
+		return 0, fmt.Errorf("save server state: %w", err)
+	}
+	return updated, nil
+}
@@ -0,0 +1,57 @@
+package mods
+
+import (
+	"context"
+	"testing"
+
+	"github.com/steviee/go-mc/internal/modrinth"
+	"github.com/steviee/go-mc/internal/state"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClientOnlyMods(t *testing.T) {
+	serverState := state.NewServerState("test-server")
+	serverState.Mods = []state.ModInfo{
+		{Slug: "iris", ProjectID: "IIII", ServerSide: modrinth.SideUnsupported},
+		{Slug: "fabric-api", ProjectID: "P7dR8mSH", ServerSide: modrinth.SideRequired},
+		// Not recorded
+		{Slug: "sodium", ProjectID: "AAAA"},
+	}
+
+	assert.Equal(t, []string{"iris"}, ClientOnlyMods(serverState))
+}
+
+func TestRefreshServerSide(t *testing.T) {
+	serverState := saveServerWithSlowmod(t)
+	serverState.Mods = []state.ModInfo{
+		// Recorded at install time
+		{Slug: "iris", ProjectID: "IIII", ServerSide: modrinth.SideUnsupported},
+		// Looked up
+		{Slug: "lithium", ProjectID: "BBBB"},
+		{Slug: "sodium", ProjectID: "AAAA"},
+		// Unknown
+		{Slug: "custom"},
+	}
+
+	installer := NewInstaller()
+	installer.modrinthClient = newFakeModrinth(t, []fakeProject{
+		{ProjectDetails: modrinth.ProjectDetails{ID: "AAAA", Slug: "sodium", ServerSide: modrinth.SideUnsupported}},
+		{ProjectDetails: modrinth.ProjectDetails{ID: "BBBB", Slug: "lithium", ServerSide: modrinth.SideOptional}},
+	})
+
+	updated, err := installer.RefreshServerSide(context.Background(), serverState)
+	require.NoError(t, err)
+	assert.Equal(t, 2, updated)
+	assert.Equal(t, []string{"iris", "sodium"}, ClientOnlyMods(serverState))
+
+	// The support is recorded
+	saved, err := state.LoadServerState(context.Background(), serverState.Name)
+	require.NoError(t, err)
+	assert.Equal(t, modrinth.SideOptional, saved.Mods[1].ServerSide)
+
+	// Nothing left to look up
+	updated, err = installer.RefreshServerSide(context.Background(), saved)
+	require.NoError(t, err)
+	assert.Zero(t, updated)
+}
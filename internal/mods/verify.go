@@ -0,0 +1,119 @@
+package mods
+
+import (
+	"crypto/sha1"
+	"crypto/sha512"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/steviee/go-mc/internal/modrinth"
+)
+
+// ErrChecksumMismatch is returned when a mod file does not match the hash or
+// size Modrinth published for it.
+var ErrChecksumMismatch = errors.New("checksum mismatch")
+
+// Checksum is the expected content of a mod file. Empty fields are not
+// checked; SHA-512 is preferred over SHA-1 when both are set.
+type Checksum struct {
+	SHA512 string
+	SHA1   string
+	Size   int64
+}
+
+// ChecksumOf returns the checksum Modrinth published for a file.
+func ChecksumOf(file modrinth.File) Checksum {
+	return Checksum{SHA512: file.Hashes.SHA512, SHA1: file.Hashes.SHA1, Size: file.Size}
+}
+
+// IsZero reports whether the checksum checks nothing.
+func (c Checksum) IsZero() bool {
+	return c.SHA512 == "" && c.SHA1 == "" && c.Size == 0
+}
+
+// verifier hashes content written to it and checks it against a checksum.
+type verifier struct {
+	want   Checksum
+	sha512 hash.Hash
+	sha1   hash.Hash
+	size   int64
+}
+
+// newVerifier creates a verifier for the expected checksum.
+func newVerifier(want Checksum) *verifier {
+	return &verifier{
+		want:   want,
+		sha512: sha512.New(),
+		sha1:   sha1.New(),
+	}
+}
+
+// Write hashes p.
+func (v *verifier) Write(p []byte) (int, error) {
+	_, _ = v.sha512.Write(p)
+	_, _ = v.sha1.Write(p)
+	v.size += int64(len(p))
+	return len(p), nil
+}
+
+// SHA512 returns the hex-encoded SHA-512 of the content written so far.
+func (v *verifier) SHA512() string {
+	return hex.EncodeToString(v.sha512.Sum(nil))
+}
+
+// SHA1 returns the hex-encoded SHA-1 of the content written so far.
+func (v *verifier) SHA1() string {
+	return hex.EncodeToString(v.sha1.Sum(nil))
+}
+
+// Verify checks the content written so far. A mismatch wraps
+// ErrChecksumMismatch.
+func (v *verifier) Verify() error {
+	if v.want.Size > 0 && v.size != v.want.Size {
+		return fmt.Errorf("%w: size is %d bytes, expected %d", ErrChecksumMismatch, v.size, v.want.Size)
+	}
+
+	switch {
+	case v.want.SHA512 != "":
+		if have := v.SHA512(); !strings.EqualFold(have, v.want.SHA512) {
+			return fmt.Errorf("%w: sha512 is %s, expected %s", ErrChecksumMismatch, have, v.want.SHA512)
+		}
+	case v.want.SHA1 != "":
+		if have := v.SHA1(); !strings.EqualFold(have, v.want.SHA1) {
+			return fmt.Errorf("%w: sha1 is %s, expected %s", ErrChecksumMismatch, have, v.want.SHA1)
+		}
+	}
+
+	return nil
+}
+
+// VerifyFile hashes a file on disk and checks it against a checksum.
+// A mismatch wraps ErrChecksumMismatch; a missing file wraps os.ErrNotExist.
+func VerifyFile(path string, want Checksum) error {
+	_, err := hashFile(path, want)
+	return err
+}
+
+// hashFile hashes a file on disk, checks it against a checksum and returns
+// the verifier holding its hashes.
+func hashFile(path string, want Checksum) (*verifier, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+
+	v := newVerifier(want)
+	if _, err := io.Copy(v, f); err != nil {
+		return nil, fmt.Errorf("read %s: %w", path, err)
+	}
+
+	return v, v.Verify()
+}
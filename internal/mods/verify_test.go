@@ -0,0 +1,66 @@
+package mods
+
+import (
+	"crypto/sha1"
+	"crypto/sha512"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// checksumOf returns the checksum of content
+func checksumOf(content []byte) Checksum {
+	sum512 := sha512.Sum512(content)
+	sum1 := sha1.Sum(content)
+	return Checksum{
+		SHA512: hex.EncodeToString(sum512[:]),
+		SHA1:   hex.EncodeToString(sum1[:]),
+		Size:   int64(len(content)),
+	}
+}
+
+func TestVerifyFile(t *testing.T) {
+	content := []byte("mod jar content")
+	path := filepath.Join(t.TempDir(), "mod.jar")
+	require.NoError(t, os.WriteFile(path, content, 0644))
+
+	good := checksumOf(content)
+	bad := checksumOf([]byte("other content"))
+
+	tests := []struct {
+		name     string
+		want     Checksum
+		mismatch string
+	}{
+		{name: "sha512 and size", want: good},
+		{name: "sha1 only", want: Checksum{SHA1: good.SHA1}},
+		{name: "uppercase hash", want: Checksum{SHA512: strings.ToUpper(good.SHA512)}},
+		{name: "nothing to check", want: Checksum{}},
+		{name: "sha512 preferred", want: Checksum{SHA512: good.SHA512, SHA1: bad.SHA1}},
+		{name: "sha512 mismatch", want: Checksum{SHA512: bad.SHA512}, mismatch: "sha512 is " + good.SHA512},
+		{name: "sha1 mismatch", want: Checksum{SHA1: bad.SHA1}, mismatch: "expected " + bad.SHA1},
+		{name: "size mismatch", want: Checksum{SHA512: good.SHA512, Size: 3}, mismatch: "size is 15 bytes, expected 3"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := VerifyFile(path, tt.want)
+			if tt.mismatch == "" {
+				assert.NoError(t, err)
+				return
+			}
+			require.ErrorIs(t, err, ErrChecksumMismatch)
+			assert.Contains(t, err.Error(), tt.mismatch)
+		})
+	}
+}
+
+func TestVerifyFile_Missing(t *testing.T) {
+	err := VerifyFile(filepath.Join(t.TempDir(), "missing.jar"), Checksum{})
+	assert.ErrorIs(t, err, os.ErrNotExist)
+}
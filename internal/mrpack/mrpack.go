@@ -0,0 +1,272 @@
+// Package mrpack reads and writes Modrinth modpacks (.mrpack): a zip with a
+// modrinth.index.json listing the files to download, and overrides copied
+// over the instance as they are.
+package mrpack
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	// IndexFile is the name of the index in a pack
+	IndexFile = "modrinth.index.json"
+
+	// FormatVersion is the version of the pack format this package supports
+	FormatVersion = 1
+
+	// Game is the only game Modrinth packs are made for
+	Game = "minecraft"
+
+	// Override directories; server overrides are applied after overrides
+	OverridesDir       = "overrides"
+	ServerOverridesDir = "server-overrides"
+	ClientOverridesDir = "client-overrides"
+)
+
+// Keys of the dependencies of a pack
+const (
+	DependencyMinecraft    = "minecraft"
+	DependencyFabricLoader = "fabric-loader"
+	DependencyQuiltLoader  = "quilt-loader"
+	DependencyForge        = "forge"
+	DependencyNeoForge     = "neoforge"
+)
+
+// Values of the env of a file
+const (
+	EnvRequired    = "required"
+	EnvOptional    = "optional"
+	EnvUnsupported = "unsupported"
+)
+
+// ErrInvalidPack is returned when a file is not a valid Modrinth pack.
+var ErrInvalidPack = errors.New("invalid mrpack")
+
+// Index is the modrinth.index.json of a pack.
+type Index struct {
+	FormatVersion int               `json:"formatVersion"`
+	Game          string            `json:"game"`
+	VersionID     string            `json:"versionId"`
+	Name          string            `json:"name"`
+	Summary       string            `json:"summary,omitempty"`
+	Files         []File            `json:"files"`
+	Dependencies  map[string]string `json:"dependencies"`
+}
+
+// File is a file the pack downloads.
+type File struct {
+	Path      string   `json:"path"` // Relative to the instance, e.g. "mods/lithium.jar"
+	Hashes    Hashes   `json:"hashes"`
+	Env       *Env     `json:"env,omitempty"`
+	Downloads []string `json:"downloads"`
+	FileSize  int64    `json:"fileSize"`
+}
+
+// Hashes holds the hex-encoded hashes of a file.
+type Hashes struct {
+	SHA1   string `json:"sha1"`
+	SHA512 string `json:"sha512"`
+}
+
+// Env tells on which side a file is needed.
+type Env struct {
+	Client string `json:"client"`
+	Server string `json:"server"`
+}
+
+// OnServer reports whether the file belongs on a server. Files without env
+// are needed on both sides.
+func (f File) OnServer() bool {
+	return f.Env == nil || f.Env.Server != EnvUnsupported
+}
+
+// Pack is an open pack.
+type Pack struct {
+	Index Index
+
+	zip *zip.ReadCloser
+}
+
+// Open opens and validates the pack at path.
+func Open(path string) (*Pack, error) {
+	r, err := zip.OpenReader(path)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidPack, err)
+	}
+
+	pack := &Pack{zip: r}
+	if err := pack.readIndex(); err != nil {
+		_ = r.Close()
+		return nil, err
+	}
+
+	return pack, nil
+}
+
+// Close closes the pack.
+func (p *Pack) Close() error {
+	return p.zip.Close()
+}
+
+// readIndex reads and validates the index of the pack.
+func (p *Pack) readIndex() error {
+	f, err := p.zip.Open(IndexFile)
+	if err != nil {
+		return fmt.Errorf("%w: no %s", ErrInvalidPack, IndexFile)
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+
+	if err := json.NewDecoder(f).Decode(&p.Index); err != nil {
+		return fmt.Errorf("%w: parse %s: %v", ErrInvalidPack, IndexFile, err)
+	}
+
+	return p.Index.Validate()
+}
+
+// Validate checks the format, the game and that all paths stay inside the
+// instance.
+func (idx *Index) Validate() error {
+	if idx.FormatVersion != FormatVersion {
+		return fmt.Errorf("%w: unsupported format version %d", ErrInvalidPack, idx.FormatVersion)
+	}
+	if idx.Game != Game {
+		return fmt.Errorf("%w: unsupported game %q", ErrInvalidPack, idx.Game)
+	}
+	if idx.Dependencies[DependencyMinecraft] == "" {
+		return fmt.Errorf("%w: no %s dependency", ErrInvalidPack, DependencyMinecraft)
+	}
+
+	for _, f := range idx.Files {
+		if !safePath(f.Path) {
+			return fmt.Errorf("%w: file path %q leaves the instance", ErrInvalidPack, f.Path)
+		}
+		if len(f.Downloads) == 0 {
+			return fmt.Errorf("%w: file %q has no downloads", ErrInvalidPack, f.Path)
+		}
+		if f.Hashes.SHA1 == "" || f.Hashes.SHA512 == "" {
+			return fmt.Errorf("%w: file %q lacks sha1 or sha512", ErrInvalidPack, f.Path)
+		}
+	}
+
+	return nil
+}
+
+// MinecraftVersion returns the Minecraft version the pack is made for.
+func (idx *Index) MinecraftVersion() string {
+	return idx.Dependencies[DependencyMinecraft]
+}
+
+// FabricLoaderVersion returns the Fabric loader version the pack pins. It
+// fails for packs made for another mod loader.
+func (idx *Index) FabricLoaderVersion() (string, error) {
+	for _, loader := range []string{DependencyQuiltLoader, DependencyForge, DependencyNeoForge} {
+		if _, ok := idx.Dependencies[loader]; ok {
+			return "", fmt.Errorf("pack requires %s; only Fabric packs are supported", loader)
+		}
+	}
+	return idx.Dependencies[DependencyFabricLoader], nil
+}
+
+// ServerFiles returns the files that belong on a server.
+func (idx *Index) ServerFiles() []File {
+	files := []File{}
+	for _, f := range idx.Files {
+		if f.OnServer() {
+			files = append(files, f)
+		}
+	}
+	return files
+}
+
+// ExtractOverrides copies the overrides and then the server overrides of the
+// pack into the instance. dest maps a path relative to the instance to the
+// path to write it to. It returns the number of files written.
+func (p *Pack) ExtractOverrides(dest func(rel string) string) (int, error) {
+	count := 0
+	for _, dir := range []string{OverridesDir, ServerOverridesDir} {
+		for _, f := range p.zip.File {
+			rel, ok := strings.CutPrefix(f.Name, dir+"/")
+			if !ok || rel == "" || f.FileInfo().IsDir() {
+				continue
+			}
+			if !safePath(rel) {
+				return count, fmt.Errorf("%w: override %q leaves the instance", ErrInvalidPack, f.Name)
+			}
+
+			if err := extractFile(f, dest(rel)); err != nil {
+				return count, err
+			}
+			count++
+		}
+	}
+	return count, nil
+}
+
+// extractFile writes a file of the zip to target.
+func extractFile(f *zip.File, target string) error {
+	if err := os.MkdirAll(filepath.Dir(target), 0750); err != nil {
+		return fmt.Errorf("create directory for %s: %w", f.Name, err)
+	}
+
+	in, err := f.Open()
+	if err != nil {
+		return fmt.Errorf("open %s: %w", f.Name, err)
+	}
+	defer func() {
+		_ = in.Close()
+	}()
+
+	out, err := os.OpenFile(target, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0644) //nolint:gosec // G302: files are read by the container
+	if err != nil {
+		return fmt.Errorf("create %s: %w", target, err)
+	}
+	if _, err := io.Copy(out, in); err != nil { //nolint:gosec // G110: overrides are copied in full by design
+		_ = out.Close()
+		return fmt.Errorf("extract %s: %w", f.Name, err)
+	}
+	if err := out.Close(); err != nil {
+		return fmt.Errorf("extract %s: %w", f.Name, err)
+	}
+	return nil
+}
+
+// Write writes a pack with the given index and no overrides.
+func Write(w io.Writer, idx *Index) error {
+	if err := idx.Validate(); err != nil {
+		return err
+	}
+
+	zw := zip.NewWriter(w)
+	f, err := zw.Create(IndexFile)
+	if err != nil {
+		return fmt.Errorf("create %s: %w", IndexFile, err)
+	}
+
+	enc := json.NewEncoder(f)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(idx); err != nil {
+		return fmt.Errorf("write %s: %w", IndexFile, err)
+	}
+
+	return zw.Close()
+}
+
+// safePath reports whether a slash-separated path stays inside the
+// directory it is relative to.
+func safePath(p string) bool {
+	if p == "" || strings.Contains(p, "\\") || path.IsAbs(p) {
+		return false
+	}
+	clean := path.Clean(p)
+	return clean != ".." && !strings.HasPrefix(clean, "../")
+}
@@ -0,0 +1,205 @@
+package mrpack
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testIndex returns a valid index with a mod for both sides and a client-only
+// mod
+func testIndex() *Index {
+	return &Index{
+		FormatVersion: FormatVersion,
+		Game:          Game,
+		VersionID:     "1.0.0",
+		Name:          "Test Pack",
+		Files: []File{
+			{
+				Path:      "mods/lithium.jar",
+				Hashes:    Hashes{SHA1: "a", SHA512: "b"},
+				Downloads: []string{"https://cdn.modrinth.com/lithium.jar"},
+				FileSize:  3,
+			},
+			{
+				Path:      "mods/sodium.jar",
+				Hashes:    Hashes{SHA1: "c", SHA512: "d"},
+				Env:       &Env{Client: EnvRequired, Server: EnvUnsupported},
+				Downloads: []string{"https://cdn.modrinth.com/sodium.jar"},
+				FileSize:  3,
+			},
+		},
+		Dependencies: map[string]string{
+			DependencyMinecraft:    "1.21.1",
+			DependencyFabricLoader: "0.16.9",
+		},
+	}
+}
+
+// writePack writes a pack with an index and extra files to a temporary
+// directory and returns its path
+func writePack(t *testing.T, idx *Index, files map[string]string) string {
+	t.Helper()
+
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+
+	f, err := zw.Create(IndexFile)
+	require.NoError(t, err)
+	require.NoError(t, json.NewEncoder(f).Encode(idx))
+
+	for name, content := range files {
+		f, err := zw.Create(name)
+		require.NoError(t, err)
+		_, err = f.Write([]byte(content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, zw.Close())
+
+	path := filepath.Join(t.TempDir(), "pack.mrpack")
+	require.NoError(t, os.WriteFile(path, buf.Bytes(), 0600))
+	return path
+}
+
+func TestOpen(t *testing.T) {
+	pack, err := Open(writePack(t, testIndex(), nil))
+	require.NoError(t, err)
+	defer func() { _ = pack.Close() }()
+
+	assert.Equal(t, "Test Pack", pack.Index.Name)
+	assert.Equal(t, "1.21.1", pack.Index.MinecraftVersion())
+
+	loader, err := pack.Index.FabricLoaderVersion()
+	require.NoError(t, err)
+	assert.Equal(t, "0.16.9", loader)
+}
+
+func TestOpen_Invalid(t *testing.T) {
+	notZip := filepath.Join(t.TempDir(), "pack.mrpack")
+	require.NoError(t, os.WriteFile(notZip, []byte("not a zip"), 0600))
+
+	_, err := Open(notZip)
+	assert.ErrorIs(t, err, ErrInvalidPack)
+}
+
+func TestIndex_Validate(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(*Index)
+	}{
+		{"format version", func(idx *Index) { idx.FormatVersion = 2 }},
+		{"game", func(idx *Index) { idx.Game = "terraria" }},
+		{"no minecraft", func(idx *Index) { delete(idx.Dependencies, DependencyMinecraft) }},
+		{"parent path", func(idx *Index) { idx.Files[0].Path = "../evil.jar" }},
+		{"nested parent path", func(idx *Index) { idx.Files[0].Path = "mods/../../evil.jar" }},
+		{"absolute path", func(idx *Index) { idx.Files[0].Path = "/etc/passwd" }},
+		{"backslash", func(idx *Index) { idx.Files[0].Path = `mods\..\..\evil.jar` }},
+		{"no downloads", func(idx *Index) { idx.Files[0].Downloads = nil }},
+		{"no sha512", func(idx *Index) { idx.Files[0].Hashes.SHA512 = "" }},
+	}
+
+	require.NoError(t, testIndex().Validate())
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			idx := testIndex()
+			tt.modify(idx)
+			assert.ErrorIs(t, idx.Validate(), ErrInvalidPack)
+		})
+	}
+}
+
+func TestIndex_FabricLoaderVersion_OtherLoader(t *testing.T) {
+	idx := testIndex()
+	delete(idx.Dependencies, DependencyFabricLoader)
+	idx.Dependencies[DependencyForge] = "47.2.0"
+
+	_, err := idx.FabricLoaderVersion()
+	assert.Error(t, err)
+}
+
+func TestIndex_ServerFiles(t *testing.T) {
+	idx := testIndex()
+	idx.Files = append(idx.Files, File{
+		Path:      "config/optional.json",
+		Env:       &Env{Client: EnvOptional, Server: EnvOptional},
+		Downloads: []string{"https://example.com/optional.json"},
+	})
+
+	files := idx.ServerFiles()
+
+	require.Len(t, files, 2)
+	assert.Equal(t, "mods/lithium.jar", files[0].Path)
+	assert.Equal(t, "config/optional.json", files[1].Path)
+}
+
+func TestPack_ExtractOverrides(t *testing.T) {
+	pack, err := Open(writePack(t, testIndex(), map[string]string{
+		"overrides/config/a.json":            "shared",
+		"overrides/server.properties":        "motd=client",
+		"server-overrides/server.properties": "motd=server",
+		"client-overrides/options.txt":       "client only",
+	}))
+	require.NoError(t, err)
+	defer func() { _ = pack.Close() }()
+
+	dir := t.TempDir()
+	count, err := pack.ExtractOverrides(func(rel string) string {
+		return filepath.Join(dir, filepath.FromSlash(rel))
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 3, count)
+
+	content, err := os.ReadFile(filepath.Join(dir, "config", "a.json"))
+	require.NoError(t, err)
+	assert.Equal(t, "shared", string(content))
+
+	// Server overrides win over overrides
+	content, err = os.ReadFile(filepath.Join(dir, "server.properties"))
+	require.NoError(t, err)
+	assert.Equal(t, "motd=server", string(content))
+
+	assert.NoFileExists(t, filepath.Join(dir, "options.txt"))
+}
+
+func TestPack_ExtractOverrides_ZipSlip(t *testing.T) {
+	pack, err := Open(writePack(t, testIndex(), map[string]string{
+		"overrides/../../evil.txt": "evil",
+	}))
+	require.NoError(t, err)
+	defer func() { _ = pack.Close() }()
+
+	dir := t.TempDir()
+	_, err = pack.ExtractOverrides(func(rel string) string {
+		return filepath.Join(dir, filepath.FromSlash(rel))
+	})
+	assert.ErrorIs(t, err, ErrInvalidPack)
+}
+
+func TestWrite(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, Write(&buf, testIndex()))
+
+	path := filepath.Join(t.TempDir(), "out.mrpack")
+	require.NoError(t, os.WriteFile(path, buf.Bytes(), 0600))
+
+	pack, err := Open(path)
+	require.NoError(t, err)
+	defer func() { _ = pack.Close() }()
+
+	assert.Equal(t, *testIndex(), pack.Index)
+}
+
+func TestWrite_Invalid(t *testing.T) {
+	idx := testIndex()
+	idx.Game = ""
+
+	var buf bytes.Buffer
+	assert.ErrorIs(t, Write(&buf, idx), ErrInvalidPack)
+}
@@ -0,0 +1,322 @@
+// Package rcon implements a client for the Source RCON protocol as spoken by
+// Minecraft servers.
+package rcon
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+)
+
+const (
+	// DefaultDialTimeout is the default timeout for establishing a connection.
+	DefaultDialTimeout = 5 * time.Second
+
+	// DefaultTimeout is the default timeout for a single command round trip.
+	DefaultTimeout = 10 * time.Second
+
+	// DefaultMaxRetries is the default number of reconnect attempts per command.
+	DefaultMaxRetries = 1
+)
+
+// Config holds client configuration.
+type Config struct {
+	Address     string
+	Password    string
+	DialTimeout time.Duration
+	Timeout     time.Duration
+	MaxRetries  int
+}
+
+// Client is an RCON client. It is safe for concurrent use; commands are
+// serialized over a single connection.
+type Client struct {
+	address     string
+	password    string
+	dialTimeout time.Duration
+	timeout     time.Duration
+	maxRetries  int
+
+	mu     sync.Mutex
+	conn   net.Conn
+	nextID int32
+	closed bool
+}
+
+// NewClient creates a new RCON client. The connection is established lazily
+// on the first command, or explicitly via Connect.
+func NewClient(config *Config) *Client {
+	if config == nil {
+		config = &Config{}
+	}
+
+	if config.DialTimeout == 0 {
+		config.DialTimeout = DefaultDialTimeout
+	}
+
+	if config.Timeout == 0 {
+		config.Timeout = DefaultTimeout
+	}
+
+	if config.MaxRetries < 0 {
+		config.MaxRetries = 0
+	}
+
+	return &Client{
+		address:     config.Address,
+		password:    config.Password,
+		dialTimeout: config.DialTimeout,
+		timeout:     config.Timeout,
+		maxRetries:  config.MaxRetries,
+	}
+}
+
+// Dial creates a client with default settings and authenticates against the
+// server at address.
+func Dial(ctx context.Context, address, password string) (*Client, error) {
+	c := NewClient(&Config{
+		Address:    address,
+		Password:   password,
+		MaxRetries: DefaultMaxRetries,
+	})
+
+	if err := c.Connect(ctx); err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
+
+// Address returns the server address the client talks to.
+func (c *Client) Address() string {
+	return c.address
+}
+
+// Connect establishes and authenticates the connection. Calling Connect on an
+// already connected client is a no-op.
+func (c *Client) Connect(ctx context.Context) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.closed {
+		return ErrNotConnected
+	}
+
+	return c.ensureConnected(ctx)
+}
+
+// Execute runs a command on the server and returns its full response,
+// reassembling responses that span multiple packets. If the connection
+// times out or drops, the client reconnects and retries the command up to
+// MaxRetries times.
+func (c *Client) Execute(ctx context.Context, command string) (string, error) {
+	if len(command) > MaxCommandLength {
+		return "", fmt.Errorf("%w: %d bytes (max %d)", ErrCommandTooLong, len(command), MaxCommandLength)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.closed {
+		return "", ErrNotConnected
+	}
+
+	var lastErr error
+	for attempt := 0; attempt <= c.maxRetries; attempt++ {
+		if attempt > 0 {
+			slog.Debug("reconnecting to rcon server",
+				"address", c.address,
+				"attempt", attempt,
+				"error", lastErr)
+		}
+
+		if err := c.ensureConnected(ctx); err != nil {
+			if errors.Is(err, ErrAuthFailed) || ctx.Err() != nil {
+				return "", err
+			}
+			lastErr = err
+			continue
+		}
+
+		response, err := c.exchange(ctx, command)
+		if err == nil {
+			return response, nil
+		}
+
+		c.resetConn()
+
+		if ctx.Err() != nil {
+			return "", ctx.Err()
+		}
+
+		if !isRetryable(err) {
+			return "", err
+		}
+		lastErr = err
+	}
+
+	return "", fmt.Errorf("execute %q: %w", command, lastErr)
+}
+
+// Close closes the connection. The client cannot be used afterwards.
+func (c *Client) Close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.closed = true
+	if c.conn == nil {
+		return nil
+	}
+
+	err := c.conn.Close()
+	c.conn = nil
+	return err
+}
+
+// ensureConnected dials and authenticates if there is no live connection.
+// The caller must hold c.mu.
+func (c *Client) ensureConnected(ctx context.Context) error {
+	if c.conn != nil {
+		return nil
+	}
+
+	dialer := &net.Dialer{Timeout: c.dialTimeout}
+	conn, err := dialer.DialContext(ctx, "tcp", c.address)
+	if err != nil {
+		return fmt.Errorf("connect to rcon server %s: %w", c.address, err)
+	}
+
+	c.conn = conn
+	if err := c.authenticate(ctx); err != nil {
+		c.resetConn()
+		return err
+	}
+
+	slog.Debug("connected to rcon server", "address", c.address)
+	return nil
+}
+
+// authenticate sends the auth packet and waits for the server's verdict.
+// The caller must hold c.mu.
+func (c *Client) authenticate(ctx context.Context) error {
+	stop := c.armDeadline(ctx)
+	defer stop()
+
+	id := c.newID()
+	if err := WritePacket(c.conn, Packet{ID: id, Type: TypeAuth, Body: c.password}); err != nil {
+		return fmt.Errorf("send auth: %w", err)
+	}
+
+	for {
+		p, err := ReadPacket(c.conn)
+		if err != nil {
+			return fmt.Errorf("read auth response: %w", err)
+		}
+
+		// Some servers send an empty response value before the auth response.
+		if p.Type != TypeAuthResponse {
+			continue
+		}
+
+		if p.ID == -1 {
+			return ErrAuthFailed
+		}
+
+		if p.ID == id {
+			return nil
+		}
+	}
+}
+
+// exchange sends a command followed by a sentinel packet and collects all
+// response packets until the sentinel is echoed back. The caller must hold
+// c.mu.
+func (c *Client) exchange(ctx context.Context, command string) (string, error) {
+	stop := c.armDeadline(ctx)
+	defer stop()
+
+	id := c.newID()
+	sentinel := c.newID()
+
+	if err := WritePacket(c.conn, Packet{ID: id, Type: TypeExecCommand, Body: command}); err != nil {
+		return "", err
+	}
+
+	// The server answers packets in order, so the reply to this invalid
+	// request marks the end of a possibly fragmented command response.
+	if err := WritePacket(c.conn, Packet{ID: sentinel, Type: TypeResponseValue}); err != nil {
+		return "", err
+	}
+
+	var sb strings.Builder
+	for {
+		p, err := ReadPacket(c.conn)
+		if err != nil {
+			return "", err
+		}
+
+		switch p.ID {
+		case id:
+			sb.WriteString(p.Body)
+		case sentinel:
+			return sb.String(), nil
+		case -1:
+			return "", ErrAuthFailed
+		}
+	}
+}
+
+// armDeadline applies the command timeout to the connection and interrupts
+// blocked I/O when ctx is cancelled. The returned function disarms it.
+func (c *Client) armDeadline(ctx context.Context) func() {
+	deadline := time.Now().Add(c.timeout)
+	if d, ok := ctx.Deadline(); ok && d.Before(deadline) {
+		deadline = d
+	}
+	_ = c.conn.SetDeadline(deadline)
+
+	conn := c.conn
+	stop := context.AfterFunc(ctx, func() {
+		_ = conn.SetDeadline(time.Unix(1, 0))
+	})
+
+	return func() { stop() }
+}
+
+// newID returns the next request ID, skipping the reserved auth-failure ID.
+func (c *Client) newID() int32 {
+	c.nextID++
+	if c.nextID <= 0 {
+		c.nextID = 1
+	}
+	return c.nextID
+}
+
+// resetConn drops the current connection so the next command reconnects.
+func (c *Client) resetConn() {
+	if c.conn != nil {
+		_ = c.conn.Close()
+		c.conn = nil
+	}
+}
+
+// isRetryable reports whether err indicates a dead or stalled connection.
+func isRetryable(err error) bool {
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return true
+	}
+
+	return errors.Is(err, io.EOF) ||
+		errors.Is(err, io.ErrUnexpectedEOF) ||
+		errors.Is(err, net.ErrClosed) ||
+		errors.Is(err, syscall.ECONNRESET) ||
+		errors.Is(err, syscall.EPIPE)
+}
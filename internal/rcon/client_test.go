@@ -0,0 +1,196 @@
+package rcon_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/steviee/go-mc/internal/rcon"
+	"github.com/steviee/go-mc/internal/rcon/rcontest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDial(t *testing.T) {
+	srv := rcontest.NewServer(t, "secret", nil)
+
+	t.Run("valid password", func(t *testing.T) {
+		client, err := rcon.Dial(context.Background(), srv.Addr(), "secret")
+		require.NoError(t, err)
+		defer func() { _ = client.Close() }()
+
+		assert.Equal(t, srv.Addr(), client.Address())
+	})
+
+	t.Run("invalid password", func(t *testing.T) {
+		_, err := rcon.Dial(context.Background(), srv.Addr(), "wrong")
+		assert.ErrorIs(t, err, rcon.ErrAuthFailed)
+	})
+
+	t.Run("unreachable server", func(t *testing.T) {
+		closed := rcontest.NewServer(t, "secret", nil)
+		addr := closed.Addr()
+		closed.Close()
+
+		_, err := rcon.Dial(context.Background(), addr, "secret")
+		assert.Error(t, err)
+	})
+}
+
+func TestClient_Execute(t *testing.T) {
+	srv := rcontest.NewServer(t, "secret", func(command string) string {
+		switch command {
+		case "list":
+			return "There are 0 of a max of 20 players online: "
+		case "help":
+			return strings.Repeat("/command\n", 1500)
+		default:
+			return ""
+		}
+	})
+
+	client, err := rcon.Dial(context.Background(), srv.Addr(), "secret")
+	require.NoError(t, err)
+	defer func() { _ = client.Close() }()
+
+	tests := []struct {
+		name    string
+		command string
+		want    string
+	}{
+		{
+			name:    "single packet response",
+			command: "list",
+			want:    "There are 0 of a max of 20 players online: ",
+		},
+		{
+			name:    "multi packet response",
+			command: "help",
+			want:    strings.Repeat("/command\n", 1500),
+		},
+		{
+			name:    "empty response",
+			command: "save-all",
+			want:    "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := client.Execute(context.Background(), tt.command)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	assert.Equal(t, []string{"list", "help", "save-all"}, srv.Commands())
+}
+
+func TestClient_ExecuteLazyConnect(t *testing.T) {
+	srv := rcontest.NewServer(t, "secret", nil)
+
+	client := rcon.NewClient(&rcon.Config{Address: srv.Addr(), Password: "secret"})
+	defer func() { _ = client.Close() }()
+
+	got, err := client.Execute(context.Background(), "say hello")
+	require.NoError(t, err)
+	assert.Equal(t, "say hello", got)
+}
+
+func TestClient_ExecuteCommandTooLong(t *testing.T) {
+	client := rcon.NewClient(&rcon.Config{Address: "127.0.0.1:1", Password: "secret"})
+
+	_, err := client.Execute(context.Background(), strings.Repeat("a", rcon.MaxCommandLength+1))
+	assert.ErrorIs(t, err, rcon.ErrCommandTooLong)
+}
+
+func TestClient_ReconnectOnDrop(t *testing.T) {
+	srv := rcontest.NewServer(t, "secret", nil)
+
+	client := rcon.NewClient(&rcon.Config{
+		Address:    srv.Addr(),
+		Password:   "secret",
+		MaxRetries: 1,
+	})
+	defer func() { _ = client.Close() }()
+
+	require.NoError(t, client.Connect(context.Background()))
+
+	srv.DropNext(1)
+	got, err := client.Execute(context.Background(), "list")
+	require.NoError(t, err)
+	assert.Equal(t, "list", got)
+	assert.Equal(t, []string{"list", "list"}, srv.Commands())
+}
+
+func TestClient_ReconnectOnTimeout(t *testing.T) {
+	srv := rcontest.NewServer(t, "secret", nil)
+
+	client := rcon.NewClient(&rcon.Config{
+		Address:    srv.Addr(),
+		Password:   "secret",
+		Timeout:    200 * time.Millisecond,
+		MaxRetries: 1,
+	})
+	defer func() { _ = client.Close() }()
+
+	srv.StallNext(1)
+	got, err := client.Execute(context.Background(), "list")
+	require.NoError(t, err)
+	assert.Equal(t, "list", got)
+}
+
+func TestClient_RetriesExhausted(t *testing.T) {
+	srv := rcontest.NewServer(t, "secret", nil)
+
+	client := rcon.NewClient(&rcon.Config{
+		Address:  srv.Addr(),
+		Password: "secret",
+	})
+	defer func() { _ = client.Close() }()
+
+	srv.DropNext(1)
+	_, err := client.Execute(context.Background(), "list")
+	assert.Error(t, err)
+
+	// The next command reconnects transparently.
+	got, err := client.Execute(context.Background(), "list")
+	require.NoError(t, err)
+	assert.Equal(t, "list", got)
+}
+
+func TestClient_ContextCancel(t *testing.T) {
+	srv := rcontest.NewServer(t, "secret", nil)
+
+	client := rcon.NewClient(&rcon.Config{
+		Address:    srv.Addr(),
+		Password:   "secret",
+		MaxRetries: 3,
+	})
+	defer func() { _ = client.Close() }()
+
+	require.NoError(t, client.Connect(context.Background()))
+
+	srv.StallNext(1)
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, err := client.Execute(ctx, "list")
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Less(t, time.Since(start), 5*time.Second)
+}
+
+func TestClient_Close(t *testing.T) {
+	srv := rcontest.NewServer(t, "secret", nil)
+
+	client, err := rcon.Dial(context.Background(), srv.Addr(), "secret")
+	require.NoError(t, err)
+
+	require.NoError(t, client.Close())
+
+	_, err = client.Execute(context.Background(), "list")
+	assert.ErrorIs(t, err, rcon.ErrNotConnected)
+	assert.ErrorIs(t, client.Connect(context.Background()), rcon.ErrNotConnected)
+}
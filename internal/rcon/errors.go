@@ -0,0 +1,18 @@
+package rcon
+
+import "errors"
+
+// Sentinel errors for RCON operations.
+var (
+	// ErrAuthFailed is returned when the server rejects the RCON password.
+	ErrAuthFailed = errors.New("rcon authentication failed")
+
+	// ErrInvalidPacket is returned when a malformed packet is received.
+	ErrInvalidPacket = errors.New("invalid rcon packet")
+
+	// ErrCommandTooLong is returned when a command exceeds MaxCommandLength.
+	ErrCommandTooLong = errors.New("rcon command too long")
+
+	// ErrNotConnected is returned when the client has been closed.
+	ErrNotConnected = errors.New("rcon client not connected")
+)
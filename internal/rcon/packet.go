@@ -0,0 +1,94 @@
+package rcon
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+)
+
+// Packet types defined by the Source RCON protocol.
+const (
+	// TypeResponseValue is sent by the server in reply to a command.
+	TypeResponseValue int32 = 0
+
+	// TypeExecCommand asks the server to execute a command.
+	TypeExecCommand int32 = 2
+
+	// TypeAuthResponse is sent by the server in reply to an auth request.
+	// It shares its numeric value with TypeExecCommand.
+	TypeAuthResponse int32 = 2
+
+	// TypeAuth authenticates the connection with the RCON password.
+	TypeAuth int32 = 3
+)
+
+const (
+	// headerSize is the size of the ID and type fields.
+	headerSize = 8
+
+	// paddingSize is the size of the body terminator and trailing empty string.
+	paddingSize = 2
+
+	// MaxCommandLength is the longest command body the Minecraft server accepts.
+	MaxCommandLength = 1446
+
+	// maxPacketSize bounds the size of a single packet read from the wire.
+	// Minecraft splits responses into 4096-byte bodies.
+	maxPacketSize = 4096 + headerSize + paddingSize
+)
+
+// Packet is a single Source RCON packet.
+type Packet struct {
+	ID   int32
+	Type int32
+	Body string
+}
+
+// WritePacket encodes a packet and writes it to w.
+func WritePacket(w io.Writer, p Packet) error {
+	size := int32(headerSize + len(p.Body) + paddingSize)
+
+	buf := bytes.NewBuffer(make([]byte, 0, size+4))
+	_ = binary.Write(buf, binary.LittleEndian, size)
+	_ = binary.Write(buf, binary.LittleEndian, p.ID)
+	_ = binary.Write(buf, binary.LittleEndian, p.Type)
+	buf.WriteString(p.Body)
+	buf.Write([]byte{0, 0})
+
+	if _, err := w.Write(buf.Bytes()); err != nil {
+		return fmt.Errorf("write packet: %w", err)
+	}
+
+	return nil
+}
+
+// ReadPacket reads and decodes a single packet from r.
+func ReadPacket(r io.Reader) (Packet, error) {
+	var size int32
+	if err := binary.Read(r, binary.LittleEndian, &size); err != nil {
+		return Packet{}, err
+	}
+
+	if size < headerSize+paddingSize || size > maxPacketSize {
+		return Packet{}, fmt.Errorf("%w: size %d", ErrInvalidPacket, size)
+	}
+
+	data := make([]byte, size)
+	if _, err := io.ReadFull(r, data); err != nil {
+		return Packet{}, err
+	}
+
+	p := Packet{
+		ID:   int32(binary.LittleEndian.Uint32(data[0:4])),
+		Type: int32(binary.LittleEndian.Uint32(data[4:8])),
+	}
+
+	body := data[headerSize:]
+	if !bytes.HasSuffix(body, []byte{0, 0}) {
+		return Packet{}, fmt.Errorf("%w: missing terminator", ErrInvalidPacket)
+	}
+	p.Body = string(body[:len(body)-paddingSize])
+
+	return p, nil
+}
@@ -0,0 +1,111 @@
+package rcon
+
+import (
+	"bytes"
+	"encoding/binary"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPacketRoundTrip(t *testing.T) {
+	tests := []struct {
+		name   string
+		packet Packet
+	}{
+		{
+			name:   "auth packet",
+			packet: Packet{ID: 1, Type: TypeAuth, Body: "secret"},
+		},
+		{
+			name:   "empty body",
+			packet: Packet{ID: 42, Type: TypeResponseValue},
+		},
+		{
+			name:   "negative id",
+			packet: Packet{ID: -1, Type: TypeAuthResponse},
+		},
+		{
+			name:   "max response body",
+			packet: Packet{ID: 7, Type: TypeResponseValue, Body: strings.Repeat("x", 4096)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			require.NoError(t, WritePacket(&buf, tt.packet))
+
+			got, err := ReadPacket(&buf)
+			require.NoError(t, err)
+			assert.Equal(t, tt.packet, got)
+			assert.Zero(t, buf.Len())
+		})
+	}
+}
+
+func TestWritePacket_Encoding(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, WritePacket(&buf, Packet{ID: 3, Type: TypeExecCommand, Body: "list"}))
+
+	want := []byte{
+		14, 0, 0, 0, // size
+		3, 0, 0, 0, // id
+		2, 0, 0, 0, // type
+		'l', 'i', 's', 't',
+		0, 0,
+	}
+	assert.Equal(t, want, buf.Bytes())
+}
+
+func TestReadPacket_Invalid(t *testing.T) {
+	tests := []struct {
+		name string
+		data func() []byte
+	}{
+		{
+			name: "size too small",
+			data: func() []byte {
+				var buf bytes.Buffer
+				_ = binary.Write(&buf, binary.LittleEndian, int32(4))
+				return buf.Bytes()
+			},
+		},
+		{
+			name: "size too large",
+			data: func() []byte {
+				var buf bytes.Buffer
+				_ = binary.Write(&buf, binary.LittleEndian, int32(maxPacketSize+1))
+				return buf.Bytes()
+			},
+		},
+		{
+			name: "missing terminator",
+			data: func() []byte {
+				var buf bytes.Buffer
+				_ = binary.Write(&buf, binary.LittleEndian, int32(10))
+				_ = binary.Write(&buf, binary.LittleEndian, int32(1))
+				_ = binary.Write(&buf, binary.LittleEndian, int32(0))
+				buf.Write([]byte{'a', 'b'})
+				return buf.Bytes()
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ReadPacket(bytes.NewReader(tt.data()))
+			assert.ErrorIs(t, err, ErrInvalidPacket)
+		})
+	}
+}
+
+func TestReadPacket_Truncated(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, WritePacket(&buf, Packet{ID: 1, Type: TypeExecCommand, Body: "say hi"}))
+
+	_, err := ReadPacket(bytes.NewReader(buf.Bytes()[:buf.Len()-3]))
+	assert.Error(t, err)
+}
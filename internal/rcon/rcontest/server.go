@@ -0,0 +1,227 @@
+// Package rcontest provides an in-process RCON server for tests.
+package rcontest
+
+import (
+	"fmt"
+	"io"
+	"net"
+	"sync"
+	"testing"
+
+	"github.com/steviee/go-mc/internal/rcon"
+)
+
+// maxResponseBody is the body size at which Minecraft splits responses.
+const maxResponseBody = 4096
+
+// action describes how the server reacts to a received command.
+type action int
+
+const (
+	actionRespond action = iota
+	actionDrop
+	actionStall
+)
+
+// HandlerFunc produces the response for a command.
+type HandlerFunc func(command string) string
+
+// Server is a fake Minecraft RCON server listening on localhost.
+type Server struct {
+	Password string
+
+	listener net.Listener
+	handler  HandlerFunc
+
+	mu       sync.Mutex
+	commands []string
+	conns    []net.Conn
+	drop     int
+	stall    int
+	wg       sync.WaitGroup
+}
+
+// NewServer starts a fake RCON server and registers its shutdown with t.
+// A nil handler echoes every command back.
+func NewServer(t testing.TB, password string, handler HandlerFunc) *Server {
+	t.Helper()
+
+	if handler == nil {
+		handler = func(command string) string { return command }
+	}
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("rcontest: listen: %v", err)
+	}
+
+	s := &Server{
+		Password: password,
+		listener: listener,
+		handler:  handler,
+	}
+
+	s.wg.Add(1)
+	go s.serve()
+
+	t.Cleanup(s.Close)
+
+	return s
+}
+
+// Addr returns the host:port the server listens on.
+func (s *Server) Addr() string {
+	return s.listener.Addr().String()
+}
+
+// Port returns the TCP port the server listens on.
+func (s *Server) Port() int {
+	return s.listener.Addr().(*net.TCPAddr).Port
+}
+
+// Commands returns all commands received so far, in order.
+func (s *Server) Commands() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]string(nil), s.commands...)
+}
+
+// DropNext makes the server close the connection instead of answering the
+// next n commands.
+func (s *Server) DropNext(n int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.drop = n
+}
+
+// StallNext makes the server swallow the next n commands without answering,
+// leaving the client to time out.
+func (s *Server) StallNext(n int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.stall = n
+}
+
+// Close stops the server and closes all open connections.
+func (s *Server) Close() {
+	_ = s.listener.Close()
+
+	s.mu.Lock()
+	for _, conn := range s.conns {
+		_ = conn.Close()
+	}
+	s.mu.Unlock()
+
+	s.wg.Wait()
+}
+
+func (s *Server) serve() {
+	defer s.wg.Done()
+
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			return
+		}
+
+		s.mu.Lock()
+		s.conns = append(s.conns, conn)
+		s.mu.Unlock()
+
+		s.wg.Add(1)
+		go s.handle(conn)
+	}
+}
+
+func (s *Server) handle(conn net.Conn) {
+	defer s.wg.Done()
+	defer func() { _ = conn.Close() }()
+
+	authed := false
+	for {
+		p, err := rcon.ReadPacket(conn)
+		if err != nil {
+			return
+		}
+
+		switch p.Type {
+		case rcon.TypeAuth:
+			id := p.ID
+			if p.Body != s.Password {
+				id = -1
+			} else {
+				authed = true
+			}
+			if err := rcon.WritePacket(conn, rcon.Packet{ID: id, Type: rcon.TypeAuthResponse}); err != nil {
+				return
+			}
+
+		case rcon.TypeExecCommand:
+			if !authed {
+				_ = rcon.WritePacket(conn, rcon.Packet{ID: -1, Type: rcon.TypeAuthResponse})
+				return
+			}
+
+			switch s.record(p.Body) {
+			case actionDrop:
+				return
+			case actionStall:
+				_, _ = io.Copy(io.Discard, conn)
+				return
+			}
+
+			if err := s.respond(conn, p.ID, s.handler(p.Body)); err != nil {
+				return
+			}
+
+		default:
+			// Mirror Minecraft's reply to unknown packet types, which
+			// clients use to detect the end of a fragmented response.
+			body := fmt.Sprintf("Unknown request %x", p.Type)
+			if err := rcon.WritePacket(conn, rcon.Packet{ID: p.ID, Type: rcon.TypeResponseValue, Body: body}); err != nil {
+				return
+			}
+		}
+	}
+}
+
+// record stores a command and decides how the server reacts to it.
+func (s *Server) record(command string) action {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.commands = append(s.commands, command)
+
+	if s.drop > 0 {
+		s.drop--
+		return actionDrop
+	}
+
+	if s.stall > 0 {
+		s.stall--
+		return actionStall
+	}
+
+	return actionRespond
+}
+
+func (s *Server) respond(conn net.Conn, id int32, response string) error {
+	for {
+		chunk := response
+		if len(chunk) > maxResponseBody {
+			chunk = chunk[:maxResponseBody]
+		}
+		response = response[len(chunk):]
+
+		if err := rcon.WritePacket(conn, rcon.Packet{ID: id, Type: rcon.TypeResponseValue, Body: chunk}); err != nil {
+			return err
+		}
+
+		if response == "" {
+			return nil
+		}
+	}
+}
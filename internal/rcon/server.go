@@ -0,0 +1,28 @@
+package rcon
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+	"time"
+
+	"github.com/steviee/go-mc/internal/state"
+)
+
+// DefaultHost is the address RCON ports are published on.
+const DefaultHost = "127.0.0.1"
+
+// NewServerClient creates a client for a managed server using the RCON port
+// and password recorded in its state.
+func NewServerClient(serverState *state.ServerState, timeout time.Duration) (*Client, error) {
+	if serverState.Minecraft.RconPort == 0 || serverState.Minecraft.RconPassword == "" {
+		return nil, fmt.Errorf("server '%s' has no RCON configuration", serverState.Name)
+	}
+
+	return NewClient(&Config{
+		Address:    net.JoinHostPort(DefaultHost, strconv.Itoa(serverState.Minecraft.RconPort)),
+		Password:   serverState.Minecraft.RconPassword,
+		Timeout:    timeout,
+		MaxRetries: DefaultMaxRetries,
+	}), nil
+}
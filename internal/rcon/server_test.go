@@ -0,0 +1,55 @@
+package rcon
+
+import (
+	"testing"
+	"time"
+
+	"github.com/steviee/go-mc/internal/state"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewServerClient(t *testing.T) {
+	tests := []struct {
+		name     string
+		port     int
+		password string
+		wantErr  bool
+		wantAddr string
+	}{
+		{
+			name:     "valid configuration",
+			port:     25575,
+			password: "secret",
+			wantAddr: "127.0.0.1:25575",
+		},
+		{
+			name:     "missing port",
+			password: "secret",
+			wantErr:  true,
+		},
+		{
+			name:    "missing password",
+			port:    25575,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			serverState := state.NewServerState("myserver")
+			serverState.Minecraft.RconPort = tt.port
+			serverState.Minecraft.RconPassword = tt.password
+
+			client, err := NewServerClient(serverState, time.Second)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantAddr, client.Address())
+			assert.Equal(t, time.Second, client.timeout)
+			assert.Equal(t, DefaultMaxRetries, client.maxRetries)
+		})
+	}
+}
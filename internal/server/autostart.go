@@ -0,0 +1,78 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/steviee/go-mc/internal/lifecycle"
+	"github.com/steviee/go-mc/internal/state"
+	"github.com/steviee/go-mc/internal/systemd"
+)
+
+// daemonReload reloads the systemd user instance. Replaced in tests.
+var daemonReload = systemd.DaemonReload
+
+// AutostartUnit returns the Quadlet unit that starts the container of a
+// server on boot, after the servers in after.
+func AutostartUnit(serverState *state.ServerState, after []string) *systemd.ContainerUnit {
+	return &systemd.ContainerUnit{
+		Description: fmt.Sprintf("Minecraft server %s (go-mc)", serverState.Name),
+		Container:   ContainerConfig(serverState),
+		After:       after,
+		StopTimeout: lifecycle.DefaultStopTimeout,
+	}
+}
+
+// SyncAutostart writes the Quadlet units of all servers with autostart
+// enabled, removes the units of the others and reloads systemd. A server is
+// started after all servers with a lower autostart order.
+func SyncAutostart(ctx context.Context) error {
+	names, err := state.ListServers(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list servers: %w", err)
+	}
+
+	enabled := []*state.ServerState{}
+	for _, name := range names {
+		serverState, err := state.LoadServerState(ctx, name)
+		if err != nil {
+			return fmt.Errorf("failed to load server %q: %w", name, err)
+		}
+		if serverState.Autostart.Enabled {
+			enabled = append(enabled, serverState)
+		}
+	}
+	sort.SliceStable(enabled, func(i, j int) bool {
+		return enabled[i].Autostart.Order < enabled[j].Autostart.Order
+	})
+
+	keep := make(map[string]bool, len(enabled))
+	for _, serverState := range enabled {
+		after := []string{}
+		for _, other := range enabled {
+			if other.Autostart.Order < serverState.Autostart.Order {
+				after = append(after, other.Name)
+			}
+		}
+
+		if _, err := systemd.WriteUnit(serverState.Name, AutostartUnit(serverState, after).Render()); err != nil {
+			return fmt.Errorf("server %q: %w", serverState.Name, err)
+		}
+		keep[serverState.Name] = true
+	}
+
+	installed, err := systemd.InstalledUnits()
+	if err != nil {
+		return err
+	}
+	for _, name := range installed {
+		if !keep[name] {
+			if err := systemd.RemoveUnit(name); err != nil {
+				return fmt.Errorf("server %q: %w", name, err)
+			}
+		}
+	}
+
+	return daemonReload(ctx)
+}
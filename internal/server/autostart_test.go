@@ -0,0 +1,117 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/steviee/go-mc/internal/container"
+	"github.com/steviee/go-mc/internal/state"
+	"github.com/steviee/go-mc/internal/systemd"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// stubDaemonReload counts reloads instead of talking to systemd
+func stubDaemonReload(t *testing.T) *int {
+	t.Helper()
+
+	reloads := 0
+	orig := daemonReload
+	daemonReload = func(ctx context.Context) error {
+		reloads++
+		return nil
+	}
+	t.Cleanup(func() { daemonReload = orig })
+
+	return &reloads
+}
+
+// saveAutostart registers a server with the given autostart settings
+func saveAutostart(t *testing.T, name string, autostart state.AutostartConfig) {
+	t.Helper()
+
+	serverState := state.NewServerState(name)
+	serverState.Autostart = autostart
+	require.NoError(t, state.SaveServerState(context.Background(), serverState))
+	require.NoError(t, state.RegisterServer(context.Background(), name))
+}
+
+// readUnit returns the Quadlet file of a server
+func readUnit(t *testing.T, name string) string {
+	t.Helper()
+
+	path, err := systemd.UnitPath(name)
+	require.NoError(t, err)
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	return string(data)
+}
+
+func TestAutostartUnit(t *testing.T) {
+	serverState := newServer(t)
+	serverState.Restart = state.RestartPolicy{Policy: state.RestartOnFailure, MaxRetries: 3}
+
+	unit := AutostartUnit(serverState, []string{"lobby"})
+	assert.Equal(t, ContainerConfig(serverState), unit.Container)
+	assert.Equal(t, []string{"lobby"}, unit.After)
+	assert.Contains(t, unit.Render(), "Restart=on-failure\n")
+}
+
+func TestSyncAutostart(t *testing.T) {
+	newServer(t)
+	reloads := stubDaemonReload(t)
+
+	saveAutostart(t, "lobby", state.AutostartConfig{Enabled: true})
+	saveAutostart(t, "survival", state.AutostartConfig{Enabled: true, Order: 10})
+	saveAutostart(t, "creative", state.AutostartConfig{Enabled: true, Order: 10})
+	saveAutostart(t, "test", state.AutostartConfig{})
+
+	// A unit left behind by a server that no longer starts on boot
+	_, err := systemd.WriteUnit("old", "")
+	require.NoError(t, err)
+
+	require.NoError(t, SyncAutostart(context.Background()))
+	assert.Equal(t, 1, *reloads)
+
+	installed, err := systemd.InstalledUnits()
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"lobby", "survival", "creative"}, installed)
+
+	assert.Contains(t, readUnit(t, "lobby"), "After=network-online.target\n")
+	assert.Contains(t, readUnit(t, "survival"), "After=network-online.target go-mc-lobby.service\n")
+	assert.Contains(t, readUnit(t, "creative"), "After=network-online.target go-mc-lobby.service\n")
+	assert.Contains(t, readUnit(t, "survival"), "ContainerName=survival\n")
+}
+
+func TestRecreateContainer_Autostart(t *testing.T) {
+	serverState := newServer(t)
+	require.NoError(t, state.RegisterServer(context.Background(), "survival"))
+	reloads := stubDaemonReload(t)
+
+	serverState.Autostart.Enabled = true
+	serverState.ContainerID = "survival"
+	client := &fakeClient{}
+
+	require.NoError(t, RecreateContainer(context.Background(), client, serverState))
+
+	// Tracked by name, as systemd replaces the container on boot
+	assert.Equal(t, "survival", serverState.ContainerID)
+	assert.Equal(t, 1, *reloads)
+	assert.Contains(t, readUnit(t, "survival"), "PublishPort=0.0.0.0:25566:25565/tcp\n")
+}
+
+func TestReconcile_AutostartMissingContainer(t *testing.T) {
+	serverState := newServer(t)
+	require.NoError(t, state.RegisterServer(context.Background(), "survival"))
+	stubDaemonReload(t)
+
+	serverState.Autostart.Enabled = true
+	client := &fakeClient{inspErr: fmt.Errorf("%w: survival", container.ErrContainerNotFound)}
+
+	result, err := Reconcile(context.Background(), client, serverState)
+	require.NoError(t, err)
+	assert.True(t, result.Recreated)
+	assert.Len(t, client.created, 1)
+}
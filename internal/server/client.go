@@ -0,0 +1,43 @@
+package server
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/steviee/go-mc/internal/container"
+	"github.com/steviee/go-mc/internal/state"
+)
+
+// ClientConfig returns the container client configuration for the
+// container.runtime and container.socket settings.
+func ClientConfig(cfg *state.Config) (*container.Config, error) {
+	socket, err := state.ExpandHome(cfg.Container.Socket)
+	if err != nil {
+		return nil, fmt.Errorf("invalid container socket: %w", err)
+	}
+
+	clientCfg := container.DefaultConfig()
+	clientCfg.Runtime = cfg.Container.Runtime
+	clientCfg.SocketPath = socket
+	return clientCfg, nil
+}
+
+// NewContainerClient connects to the container runtime configured by the
+// user.
+func NewContainerClient(ctx context.Context, cfg *state.Config) (container.Client, error) {
+	clientCfg, err := ClientConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return container.NewClient(ctx, clientCfg)
+}
+
+// ConnectContainerRuntime resolves the config and connects to the configured
+// container runtime, for commands that do not otherwise need the config.
+func ConnectContainerRuntime(ctx context.Context) (container.Client, error) {
+	cfg, err := state.ResolveConfig(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return NewContainerClient(ctx, cfg)
+}
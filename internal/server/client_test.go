@@ -0,0 +1,37 @@
+package server
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/steviee/go-mc/internal/state"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClientConfig(t *testing.T) {
+	t.Run("defaults detect the runtime", func(t *testing.T) {
+		clientCfg, err := ClientConfig(state.DefaultConfig())
+		require.NoError(t, err)
+
+		assert.Equal(t, "auto", clientCfg.Runtime)
+		assert.Empty(t, clientCfg.SocketPath)
+	})
+
+	t.Run("runtime and socket come from config", func(t *testing.T) {
+		home, err := os.UserHomeDir()
+		require.NoError(t, err)
+
+		cfg := state.DefaultConfig()
+		cfg.Container.Runtime = "docker"
+		cfg.Container.Socket = "~/.docker/run/docker.sock"
+
+		clientCfg, err := ClientConfig(cfg)
+		require.NoError(t, err)
+
+		assert.Equal(t, "docker", clientCfg.Runtime)
+		assert.Equal(t, filepath.Join(home, ".docker/run/docker.sock"), clientCfg.SocketPath)
+		assert.NotZero(t, clientCfg.Timeout)
+	})
+}
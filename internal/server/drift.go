@@ -0,0 +1,204 @@
+package server
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/docker/go-units"
+	"github.com/steviee/go-mc/internal/container"
+	"github.com/steviee/go-mc/internal/state"
+)
+
+// managedEnv are the environment variables go-mc sets, or set in earlier
+// versions. Other variables come from the image and are not compared.
+var managedEnv = []string{
+	"TYPE",
+	"EULA",
+	"VERSION",
+	"FABRIC_LOADER_VERSION",
+	"MEMORY",
+	"RCON_PASSWORD",
+	"ENABLE_RCON",
+	"RCON_PORT",
+	"ENABLE_QUERY",
+	"QUERY_PORT",
+}
+
+// labelPrefix marks the labels go-mc sets. Other labels come from the image
+// or from systemd.
+const labelPrefix = "go-mc."
+
+// Difference is a setting in which a container differs from the spec of its
+// server. An empty Want means the container has a setting the spec does not;
+// an empty Have means it lacks one.
+type Difference struct {
+	Field string // e.g. "image", "env MEMORY", "mount /data/mods"
+	Want  string // Value in the spec
+	Have  string // Value in the container
+}
+
+// Drift compares the container of a server with the spec derived from its
+// state: image, environment, labels, mounts, ports, restart policy and
+// resource limits. It returns the differences sorted by field; none means
+// the container is up to date.
+func Drift(serverState *state.ServerState, info *container.ContainerInfo) []Difference {
+	want := ContainerConfig(serverState)
+	diffs := []Difference{}
+
+	add := func(field, want, have string) {
+		if want != have {
+			diffs = append(diffs, Difference{Field: field, Want: want, Have: have})
+		}
+	}
+
+	add("image", normalizeImage(want.Image), normalizeImage(info.Image))
+
+	for _, key := range managedEnv {
+		add("env "+key, want.Env[key], info.Env[key])
+	}
+
+	for key, value := range want.Labels {
+		add("label "+key, value, info.Labels[key])
+	}
+	for key, value := range info.Labels {
+		if _, ok := want.Labels[key]; !ok && strings.HasPrefix(key, labelPrefix) {
+			add("label "+key, "", value)
+		}
+	}
+
+	mounts := make(map[string]string, len(info.Mounts))
+	for _, m := range info.Mounts {
+		mounts[m.Destination] = m.Source
+	}
+	for source, destination := range want.Volumes {
+		add("mount "+destination, source, mounts[destination])
+		delete(mounts, destination)
+	}
+	for destination, source := range mounts {
+		add("mount "+destination, "", source)
+	}
+
+	diffs = append(diffs, portDrift(want.Ports, info.Ports)...)
+
+	policy, retries := RestartPolicy(serverState)
+	add("restart policy", formatRestart(policy, retries), formatRestart(info.RestartPolicy, info.RestartRetries))
+
+	diffs = append(diffs, resourceDrift(want, info.Resources)...)
+
+	sort.SliceStable(diffs, func(i, j int) bool { return diffs[i].Field < diffs[j].Field })
+	return diffs
+}
+
+// portDrift returns the ports only one of the spec and the container
+// publishes.
+func portDrift(want, have []container.PortMapping) []Difference {
+	diffs := []Difference{}
+	for _, p := range want {
+		if !containsPort(have, p) {
+			diffs = append(diffs, Difference{Field: "port", Want: p.String()})
+		}
+	}
+	for _, p := range have {
+		if !containsPort(want, p) {
+			diffs = append(diffs, Difference{Field: "port", Have: p.String()})
+		}
+	}
+	return diffs
+}
+
+// containsPort reports whether ports include a mapping.
+func containsPort(ports []container.PortMapping, port container.PortMapping) bool {
+	for _, p := range ports {
+		if container.SamePorts([]container.PortMapping{p}, []container.PortMapping{port}) {
+			return true
+		}
+	}
+	return false
+}
+
+// resourceDrift compares the resource limits of the spec with those of the
+// container. CPU shares and the PID limit are only compared when set, as
+// Podman applies its own defaults otherwise; swap only when limited.
+func resourceDrift(want *container.ContainerConfig, have container.Resources) []Difference {
+	diffs := []Difference{}
+	add := func(field, want, have string) {
+		if want != have {
+			diffs = append(diffs, Difference{Field: field, Want: want, Have: have})
+		}
+	}
+
+	add("cpus", formatCPUs(want.CPUQuota), formatCPUs(have.CPUQuota))
+	if want.CPUShares != 0 {
+		add("cpu shares", strconv.FormatUint(want.CPUShares, 10), formatLimit(int64(have.CPUShares)))
+	}
+	if want.PidsLimit != 0 {
+		add("pids limit", strconv.FormatInt(want.PidsLimit, 10), formatLimit(have.PidsLimit))
+	}
+	add("io weight", formatLimit(int64(want.IOWeight)), formatLimit(int64(have.IOWeight)))
+
+	var memory, swap int64
+	if want.Memory != "" {
+		memory, _ = units.RAMInBytes(want.Memory)
+	}
+	if want.MemorySwap != "" {
+		swap, _ = units.RAMInBytes(want.MemorySwap)
+	}
+	add("memory limit", formatBytes(memory), formatBytes(have.Memory))
+	if swap != 0 {
+		add("memory+swap limit", formatBytes(swap), formatBytes(have.MemorySwap))
+	}
+
+	return diffs
+}
+
+// normalizeImage expands an image reference the way Podman reports it, so
+// "itzg/minecraft-server" and "docker.io/itzg/minecraft-server:latest"
+// compare equal.
+func normalizeImage(image string) string {
+	image = strings.TrimPrefix(image, "docker.io/")
+	image = strings.TrimPrefix(image, "library/")
+
+	name := image[strings.LastIndex(image, "/")+1:]
+	if image != "" && !strings.ContainsAny(name, ":@") {
+		image += ":latest"
+	}
+	return image
+}
+
+// formatRestart formats a restart policy with its retries; "no" is shown
+// as no policy.
+func formatRestart(policy string, retries uint) string {
+	switch {
+	case policy == "" || policy == state.RestartNo:
+		return ""
+	case retries > 0:
+		return policy + ":" + strconv.FormatUint(uint64(retries), 10)
+	default:
+		return policy
+	}
+}
+
+// formatCPUs formats a CPU quota as a number of CPUs.
+func formatCPUs(quota int64) string {
+	if quota <= 0 {
+		return ""
+	}
+	return strconv.FormatFloat(float64(quota)/container.CPUPeriod, 'g', -1, 64)
+}
+
+// formatLimit formats a limit; 0 is shown as no limit.
+func formatLimit(limit int64) string {
+	if limit <= 0 {
+		return ""
+	}
+	return strconv.FormatInt(limit, 10)
+}
+
+// formatBytes formats a size limit; 0 is shown as no limit.
+func formatBytes(size int64) string {
+	if size <= 0 {
+		return ""
+	}
+	return units.BytesSize(float64(size))
+}
@@ -0,0 +1,75 @@
+package server
+
+import (
+	"testing"
+
+	"github.com/steviee/go-mc/internal/container"
+	"github.com/steviee/go-mc/internal/state"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDrift_UpToDate(t *testing.T) {
+	serverState := newServer(t)
+	serverState.Minecraft.Memory = "4G"
+	serverState.Resources = state.ResourcesConfig{CPUs: 1.5, MemorySwap: "8G"}
+
+	info := inspected(serverState, "running")
+	info.Image = "docker.io/itzg/minecraft-server:java21"
+	info.Resources = container.Resources{Memory: 5 << 30, MemorySwap: 8 << 30, CPUQuota: 150000, CPUShares: 1024, PidsLimit: 2048}
+
+	assert.Empty(t, Drift(serverState, info))
+}
+
+// A container recreated by 'servers update' before the spec was shared
+func TestDrift_LegacyUpdate(t *testing.T) {
+	serverState := newServer(t)
+	info := inspected(serverState, "exited")
+
+	info.Image = "itzg/minecraft-server:latest"
+	info.Env["RCON_PORT"] = "25576"
+	delete(info.Labels, "go-mc.server")
+	delete(info.Labels, "go-mc.version")
+	info.Labels["go-mc.server.name"] = "survival"
+	info.Mounts = info.Mounts[:0]
+	info.Mounts = append(info.Mounts, container.Mount{Source: serverState.Volumes.Data, Destination: "/data"})
+
+	assert.Equal(t, []Difference{
+		{Field: "env RCON_PORT", Have: "25576"},
+		{Field: "image", Want: "itzg/minecraft-server:java21", Have: "itzg/minecraft-server:latest"},
+		{Field: "label go-mc.server", Want: "survival"},
+		{Field: "label go-mc.server.name", Have: "survival"},
+		{Field: "label go-mc.version", Want: "1.21.1"},
+		{Field: "mount /data/mods", Want: ModsDir(serverState)},
+	}, Drift(serverState, info))
+}
+
+func TestDrift_Resources(t *testing.T) {
+	serverState := newServer(t)
+	serverState.Resources = state.ResourcesConfig{CPUs: 2, CPUShares: 512, PidsLimit: 4096, IOWeight: 100, MemorySwap: "8G"}
+
+	info := inspected(serverState, "exited")
+	info.Resources = container.Resources{CPUQuota: 50000, PidsLimit: 2048}
+
+	assert.Equal(t, []Difference{
+		{Field: "cpu shares", Want: "512"},
+		{Field: "cpus", Want: "2", Have: "0.5"},
+		{Field: "io weight", Want: "100"},
+		{Field: "memory limit", Want: "5GiB"},
+		{Field: "memory+swap limit", Want: "8GiB"},
+		{Field: "pids limit", Want: "4096", Have: "2048"},
+	}, Drift(serverState, info))
+}
+
+func TestNormalizeImage(t *testing.T) {
+	tests := map[string]string{
+		"itzg/minecraft-server":                  "itzg/minecraft-server:latest",
+		"docker.io/itzg/minecraft-server:java21": "itzg/minecraft-server:java21",
+		"docker.io/library/alpine":               "alpine:latest",
+		"localhost:5000/mc":                      "localhost:5000/mc:latest",
+		"ghcr.io/x/mc@sha256:abc":                "ghcr.io/x/mc@sha256:abc",
+		"":                                       "",
+	}
+	for image, want := range tests {
+		assert.Equal(t, want, normalizeImage(image), image)
+	}
+}
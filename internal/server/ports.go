@@ -0,0 +1,120 @@
+package server
+
+import (
+	"github.com/steviee/go-mc/internal/container"
+	"github.com/steviee/go-mc/internal/mods"
+	"github.com/steviee/go-mc/internal/state"
+)
+
+const (
+	// GameContainerPort is the game port inside the container.
+	GameContainerPort = 25565
+
+	// RconContainerPort is the RCON port inside the container.
+	RconContainerPort = 25575
+
+	// QueryContainerPort is the UDP port of the GameSpy4 query inside the
+	// container. It shares its number with the game port, as in vanilla.
+	QueryContainerPort = 25565
+)
+
+// Port states reported by PortStatus.
+const (
+	PortPublished = "published" // the container publishes the port
+	PortPending   = "pending"   // published once the container is recreated
+	PortUnknown   = "unknown"   // the container could not be inspected
+)
+
+// Port is a port a server publishes, named after what uses it: "game",
+// "rcon" or the slug of a mod.
+type Port struct {
+	Name string
+	container.PortMapping
+}
+
+// Ports returns the ports a server needs: the game, RCON and query ports and
+// the ports allocated to its mods.
+func Ports(serverState *state.ServerState) []Port {
+	ports := []Port{}
+
+	if serverState.Minecraft.GamePort > 0 {
+		ports = append(ports, Port{Name: "game", PortMapping: container.PortMapping{
+			HostPort:      serverState.Minecraft.GamePort,
+			ContainerPort: GameContainerPort,
+			Protocol:      container.ProtocolTCP,
+		}})
+	}
+	if serverState.Minecraft.RconPort > 0 {
+		ports = append(ports, Port{Name: "rcon", PortMapping: container.PortMapping{
+			HostPort:      serverState.Minecraft.RconPort,
+			ContainerPort: RconContainerPort,
+			Protocol:      container.ProtocolTCP,
+		}})
+	}
+	if serverState.Minecraft.QueryPort > 0 {
+		ports = append(ports, Port{Name: "query", PortMapping: container.PortMapping{
+			HostPort:      serverState.Minecraft.QueryPort,
+			ContainerPort: QueryContainerPort,
+			Protocol:      container.ProtocolUDP,
+		}})
+	}
+
+	return append(ports, ModPorts(serverState)...)
+}
+
+// ModPorts returns the ports allocated to a server's mods.
+//
+// Mods listen on their default port inside the container, so a mod whose
+// preferred host port was taken by another server is mapped from the
+// allocated host port to the default port.
+func ModPorts(serverState *state.ServerState) []Port {
+	ports := []Port{}
+
+	for _, mod := range serverState.Mods {
+		if mod.Port <= 0 {
+			continue
+		}
+
+		containerPort := mod.Port
+		if known, err := mods.GetMod(mod.Slug); err == nil && known.RequiresPort() {
+			containerPort = known.DefaultPort
+		}
+
+		protocol := mod.Protocol
+		if protocol == "" {
+			protocol = container.ProtocolTCP
+		}
+
+		ports = append(ports, Port{Name: mod.Slug, PortMapping: container.PortMapping{
+			HostPort:      mod.Port,
+			ContainerPort: containerPort,
+			Protocol:      protocol,
+		}})
+	}
+
+	return ports
+}
+
+// PortMappings returns the port mappings of the ports a server needs.
+func PortMappings(serverState *state.ServerState) []container.PortMapping {
+	ports := Ports(serverState)
+	mappings := make([]container.PortMapping, 0, len(ports))
+	for _, p := range ports {
+		mappings = append(mappings, p.PortMapping)
+	}
+	return mappings
+}
+
+// PortStatus reports whether a container publishes a port. A nil container
+// means it could not be inspected.
+func PortStatus(port container.PortMapping, info *container.ContainerInfo) string {
+	if info == nil {
+		return PortUnknown
+	}
+	for _, published := range info.Ports {
+		if container.SamePorts([]container.PortMapping{port}, []container.PortMapping{published}) {
+			return PortPublished
+		}
+	}
+	return PortPending
+}
@@ -0,0 +1,37 @@
+package server
+
+import (
+	"testing"
+
+	"github.com/steviee/go-mc/internal/container"
+	"github.com/steviee/go-mc/internal/state"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPorts(t *testing.T) {
+	serverState := newServer(t)
+
+	// Voice chat got 24455 on the host but listens on its default port
+	assert.Equal(t, []Port{
+		{Name: "game", PortMapping: container.PortMapping{HostPort: 25566, ContainerPort: 25565, Protocol: "tcp"}},
+		{Name: "rcon", PortMapping: container.PortMapping{HostPort: 25576, ContainerPort: 25575, Protocol: "tcp"}},
+		{Name: "query", PortMapping: container.PortMapping{HostPort: 25666, ContainerPort: 25565, Protocol: "udp"}},
+		{Name: "simple-voice-chat", PortMapping: container.PortMapping{HostPort: 24455, ContainerPort: 24454, Protocol: "udp"}},
+	}, Ports(serverState))
+
+	// Unknown mods listen on their allocated port; TCP is the default
+	serverState.Mods = []state.ModInfo{{Slug: "custom-map", Port: 8123}}
+	assert.Equal(t, []Port{
+		{Name: "custom-map", PortMapping: container.PortMapping{HostPort: 8123, ContainerPort: 8123, Protocol: "tcp"}},
+	}, ModPorts(serverState))
+}
+
+func TestPortStatus(t *testing.T) {
+	voice := container.PortMapping{HostPort: 24455, ContainerPort: 24454, Protocol: "udp"}
+
+	assert.Equal(t, PortUnknown, PortStatus(voice, nil))
+	assert.Equal(t, PortPending, PortStatus(voice, &container.ContainerInfo{}))
+	assert.Equal(t, PortPublished, PortStatus(voice, &container.ContainerInfo{
+		Ports: []container.PortMapping{{HostIP: "0.0.0.0", HostPort: 24455, ContainerPort: 24454, Protocol: "udp"}},
+	}))
+}
@@ -0,0 +1,74 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/steviee/go-mc/internal/container"
+	"github.com/steviee/go-mc/internal/state"
+)
+
+// ReconcileResult describes the outcome of Reconcile.
+type ReconcileResult struct {
+	// Ports are the ports the server needs.
+	Ports []Port
+
+	// Differences are the settings in which the container differed from
+	// the spec of the server.
+	Differences []Difference
+
+	// Recreated is true when the container was recreated from the spec.
+	Recreated bool
+
+	// Pending is true when the running container has drifted. The spec is
+	// applied the next time the server is started or restarted.
+	Pending bool
+}
+
+// Reconcile keeps the container of a server in line with the spec derived
+// from its state. Podman fixes the image, environment, mounts, ports,
+// restart policy and resource limits at creation, so a stopped container
+// that has drifted is recreated; a running container is left alone and the
+// change is reported as pending. A missing container of a server with
+// autostart enabled is recreated, as systemd removes it when the unit stops.
+func Reconcile(ctx context.Context, client container.Client, serverState *state.ServerState) (*ReconcileResult, error) {
+	result := &ReconcileResult{Ports: Ports(serverState), Differences: []Difference{}}
+
+	if serverState.ContainerID == "" {
+		return result, nil
+	}
+
+	info, err := client.InspectContainer(ctx, serverState.ContainerID)
+	if err != nil {
+		if errors.Is(err, container.ErrContainerNotFound) && serverState.Autostart.Enabled {
+			// systemd removes the container when the unit stops
+			if err := RecreateContainer(ctx, client, serverState); err != nil {
+				return nil, err
+			}
+			result.Recreated = true
+			return result, nil
+		}
+		if errors.Is(err, container.ErrContainerNotFound) {
+			return nil, fmt.Errorf("container of server %q is missing: %w", serverState.Name, err)
+		}
+		return nil, fmt.Errorf("failed to inspect container: %w", err)
+	}
+
+	result.Differences = Drift(serverState, info)
+	if len(result.Differences) == 0 {
+		return result, nil
+	}
+
+	if info.State == "running" {
+		result.Pending = true
+		return result, nil
+	}
+
+	if err := RecreateContainer(ctx, client, serverState); err != nil {
+		return nil, err
+	}
+	result.Recreated = true
+
+	return result, nil
+}
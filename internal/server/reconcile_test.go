@@ -0,0 +1,164 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/steviee/go-mc/internal/container"
+	"github.com/steviee/go-mc/internal/state"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// inspected returns the container of a server as Podman reports it after
+// creating it from the spec
+func inspected(serverState *state.ServerState, status string) *container.ContainerInfo {
+	config := ContainerConfig(serverState)
+
+	info := &container.ContainerInfo{
+		State:         status,
+		Image:         config.Image,
+		Env:           map[string]string{"PATH": "/usr/bin", "JAVA_HOME": "/opt/java"},
+		Labels:        map[string]string{"org.opencontainers.image.title": "minecraft-server"},
+		Ports:         append([]container.PortMapping(nil), config.Ports...),
+		RestartPolicy: config.RestartPolicy,
+		Resources:     container.Resources{PidsLimit: 2048},
+	}
+	if info.RestartPolicy == "" {
+		info.RestartPolicy = state.RestartNo
+	}
+	for key, value := range config.Env {
+		info.Env[key] = value
+	}
+	for key, value := range config.Labels {
+		info.Labels[key] = value
+	}
+	for source, destination := range config.Volumes {
+		info.Mounts = append(info.Mounts, container.Mount{Source: source, Destination: destination})
+	}
+
+	return info
+}
+
+func TestReconcile(t *testing.T) {
+	withoutVoice := []container.PortMapping{
+		{HostPort: 25566, ContainerPort: 25565, Protocol: "tcp"},
+		{HostPort: 25576, ContainerPort: 25575, Protocol: "tcp"},
+		{HostPort: 25666, ContainerPort: 25565, Protocol: "udp"},
+	}
+
+	tests := []struct {
+		name          string
+		status        string
+		ports         []container.PortMapping
+		wantRecreated bool
+		wantPending   bool
+	}{
+		{
+			name:   "up to date",
+			status: "exited",
+		},
+		{
+			name:          "stopped container is recreated",
+			status:        "exited",
+			ports:         withoutVoice,
+			wantRecreated: true,
+		},
+		{
+			name:        "running container is pending",
+			status:      "running",
+			ports:       withoutVoice,
+			wantPending: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			serverState := newServer(t)
+			info := inspected(serverState, tt.status)
+			if tt.ports != nil {
+				info.Ports = tt.ports
+			}
+			client := &fakeClient{info: info}
+
+			result, err := Reconcile(context.Background(), client, serverState)
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.wantRecreated, result.Recreated)
+			assert.Equal(t, tt.wantPending, result.Pending)
+			assert.Len(t, result.Ports, 4)
+
+			if tt.wantRecreated {
+				require.Len(t, client.created, 1)
+				assert.Equal(t, PortMappings(serverState), client.created[0].Ports)
+				assert.Equal(t, "new-1", serverState.ContainerID)
+				assert.Equal(t, []Difference{{Field: "port", Want: "24455->24454/udp"}}, result.Differences)
+			} else {
+				assert.Empty(t, client.created)
+				assert.Equal(t, "old", serverState.ContainerID)
+			}
+		})
+	}
+}
+
+func TestReconcile_RestartPolicy(t *testing.T) {
+	serverState := newServer(t)
+	info := inspected(serverState, "exited")
+	serverState.Restart = state.RestartPolicy{Policy: state.RestartOnFailure, MaxRetries: 3}
+	client := &fakeClient{info: info}
+
+	result, err := Reconcile(context.Background(), client, serverState)
+	require.NoError(t, err)
+	assert.True(t, result.Recreated)
+	require.Len(t, client.created, 1)
+	assert.Equal(t, "on-failure", client.created[0].RestartPolicy)
+
+	// Podman reports "no" for containers without a policy
+	serverState.Restart = state.RestartPolicy{}
+	result, err = Reconcile(context.Background(), &fakeClient{info: info}, serverState)
+	require.NoError(t, err)
+	assert.False(t, result.Recreated)
+}
+
+func TestReconcile_Resources(t *testing.T) {
+	serverState := newServer(t)
+	info := inspected(serverState, "exited")
+	serverState.Resources = state.ResourcesConfig{CPUs: 2, PidsLimit: 4096}
+	client := &fakeClient{info: info}
+
+	result, err := Reconcile(context.Background(), client, serverState)
+	require.NoError(t, err)
+	assert.True(t, result.Recreated)
+	require.Len(t, client.created, 1)
+	assert.Equal(t, int64(200000), client.created[0].CPUQuota)
+
+	// Podman applies its own PID limit when none is set
+	serverState.Resources = state.ResourcesConfig{}
+	result, err = Reconcile(context.Background(), &fakeClient{info: info}, serverState)
+	require.NoError(t, err)
+	assert.False(t, result.Recreated)
+
+	// A running container keeps its limits until it is stopped
+	serverState.Resources = state.ResourcesConfig{IOWeight: 100}
+	info.State = "running"
+	result, err = Reconcile(context.Background(), &fakeClient{info: info}, serverState)
+	require.NoError(t, err)
+	assert.True(t, result.Pending)
+}
+
+func TestReconcile_Errors(t *testing.T) {
+	serverState := newServer(t)
+
+	_, err := Reconcile(context.Background(), &fakeClient{inspErr: fmt.Errorf("%w: old", container.ErrContainerNotFound)}, serverState)
+	assert.ErrorContains(t, err, "is missing")
+
+	_, err = Reconcile(context.Background(), &fakeClient{inspErr: fmt.Errorf("connection refused")}, serverState)
+	assert.ErrorContains(t, err, "failed to inspect container")
+
+	// Servers without a container have nothing to reconcile
+	serverState.ContainerID = ""
+	result, err := Reconcile(context.Background(), &fakeClient{}, serverState)
+	require.NoError(t, err)
+	assert.False(t, result.Recreated)
+}
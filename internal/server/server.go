@@ -0,0 +1,162 @@
+// Package server derives the container of a managed server from its state
+// and keeps existing containers in line with it.
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"path/filepath"
+	"strconv"
+
+	"github.com/steviee/go-mc/internal/container"
+	"github.com/steviee/go-mc/internal/state"
+)
+
+// ModsDir returns the mods directory of a server. It lives next to the data
+// directory and is mounted over /data/mods.
+func ModsDir(serverState *state.ServerState) string {
+	return filepath.Join(filepath.Dir(serverState.Volumes.Data), "mods")
+}
+
+// ContainerConfig returns the container configuration of a server.
+func ContainerConfig(serverState *state.ServerState) *container.ContainerConfig {
+	image := serverState.Image
+	if image == "" {
+		image = state.DefaultConfig().Container.Image
+	}
+
+	env := map[string]string{
+		"TYPE":          "FABRIC",
+		"EULA":          "TRUE",
+		"VERSION":       serverState.Minecraft.Version,
+		"MEMORY":        serverState.Minecraft.Memory,
+		"RCON_PASSWORD": serverState.Minecraft.RconPassword,
+		"ENABLE_RCON":   "true",
+	}
+	if serverState.Minecraft.FabricLoaderVersion != "" {
+		env["FABRIC_LOADER_VERSION"] = serverState.Minecraft.FabricLoaderVersion
+	}
+	if serverState.Minecraft.QueryPort > 0 {
+		// Written to enable-query and query.port of server.properties
+		env["ENABLE_QUERY"] = "true"
+		env["QUERY_PORT"] = strconv.Itoa(QueryContainerPort)
+	}
+
+	restartPolicy, restartRetries := RestartPolicy(serverState)
+
+	config := &container.ContainerConfig{
+		Name:  serverState.Name,
+		Image: image,
+		Env:   env,
+		Ports: PortMappings(serverState),
+		Volumes: map[string]string{
+			serverState.Volumes.Data: "/data",
+			ModsDir(serverState):     "/data/mods",
+		},
+		Labels: map[string]string{
+			"go-mc.server":  serverState.Name,
+			"go-mc.version": serverState.Minecraft.Version,
+			"go-mc.managed": "true",
+		},
+		RestartPolicy:  restartPolicy,
+		RestartRetries: restartRetries,
+	}
+	setResources(config, serverState)
+
+	return config
+}
+
+// setResources applies the resource limits of a server to its container.
+// A swap limit also limits the container memory, as Podman only limits
+// swap together with memory.
+func setResources(config *container.ContainerConfig, serverState *state.ServerState) {
+	resources := serverState.Resources
+
+	config.CPUQuota = int64(resources.CPUs * container.CPUPeriod)
+	config.CPUShares = resources.CPUShares
+	config.PidsLimit = resources.PidsLimit
+	config.IOWeight = resources.IOWeight
+
+	if resources.MemorySwap != "" {
+		if limit, err := state.ContainerMemoryLimit(serverState.Minecraft.Memory); err == nil {
+			config.Memory = strconv.FormatInt(limit, 10)
+			config.MemorySwap = resources.MemorySwap
+		}
+	}
+}
+
+// RestartPolicy returns the container restart policy and retries of a
+// server. Podman restarts a crashed container right away; the backoff of the
+// policy is applied by go-mc watch only.
+func RestartPolicy(serverState *state.ServerState) (string, uint) {
+	switch policy := serverState.Restart; policy.Name() {
+	case state.RestartOnFailure:
+		return state.RestartOnFailure, uint(policy.MaxRetries)
+	case state.RestartAlways:
+		return state.RestartAlways, 0
+	default:
+		return "", 0
+	}
+}
+
+// pullPolicy returns the configured image pull policy, or the default one if
+// the configuration cannot be read.
+func pullPolicy(ctx context.Context) string {
+	cfg, err := state.ResolveConfig(ctx)
+	if err != nil {
+		slog.Warn("failed to load config, using default pull policy", "error", err)
+		return state.DefaultConfig().Container.PullPolicy
+	}
+	return cfg.Container.PullPolicy
+}
+
+// RecreateContainer replaces the container of a server with a new one built
+// from its state. Volumes are kept. The new container is created stopped and
+// its ID is saved to the server state. The autostart unit of the server is
+// updated to match.
+func RecreateContainer(ctx context.Context, client container.Client, serverState *state.ServerState) error {
+	if serverState.ContainerID != "" {
+		err := client.RemoveContainer(ctx, serverState.ContainerID, &container.RemoveOptions{Force: true})
+		if err != nil && !errors.Is(err, container.ErrContainerNotFound) {
+			return fmt.Errorf("failed to remove old container: %w", err)
+		}
+	}
+
+	config := ContainerConfig(serverState)
+	config.PullPolicy = pullPolicy(ctx)
+
+	containerID, err := client.CreateContainer(ctx, config)
+	if err != nil {
+		return fmt.Errorf("failed to create container: %w", err)
+	}
+
+	slog.Debug("container recreated",
+		"server", serverState.Name,
+		"old_id", serverState.ContainerID,
+		"new_id", containerID)
+
+	serverState.ContainerID = ContainerRef(serverState, containerID)
+	if err := state.SaveServerState(ctx, serverState); err != nil {
+		return fmt.Errorf("failed to save server state: %w", err)
+	}
+
+	if serverState.Autostart.Enabled {
+		if err := SyncAutostart(ctx); err != nil {
+			return fmt.Errorf("failed to update autostart unit: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// ContainerRef returns how the container of a server is referred to in its
+// state. systemd replaces the container of a server with autostart enabled
+// on every boot, so it is referred to by its name rather than by its ID.
+func ContainerRef(serverState *state.ServerState, containerID string) string {
+	if serverState.Autostart.Enabled {
+		return serverState.Name
+	}
+	return containerID
+}
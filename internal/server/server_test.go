@@ -0,0 +1,182 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/steviee/go-mc/internal/container"
+	"github.com/steviee/go-mc/internal/state"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeClient records container changes. It embeds the interface so only
+// the methods used by this package need an implementation.
+type fakeClient struct {
+	container.Client
+
+	info      *container.ContainerInfo
+	inspErr   error
+	removeErr error
+
+	removed []string
+	created []*container.ContainerConfig
+}
+
+func (c *fakeClient) InspectContainer(ctx context.Context, containerID string) (*container.ContainerInfo, error) {
+	if c.inspErr != nil {
+		return nil, c.inspErr
+	}
+	return c.info, nil
+}
+
+func (c *fakeClient) RemoveContainer(ctx context.Context, containerID string, opts *container.RemoveOptions) error {
+	if c.removeErr != nil {
+		return c.removeErr
+	}
+	c.removed = append(c.removed, containerID)
+	return nil
+}
+
+func (c *fakeClient) CreateContainer(ctx context.Context, config *container.ContainerConfig) (string, error) {
+	c.created = append(c.created, config)
+	return fmt.Sprintf("new-%d", len(c.created)), nil
+}
+
+// newServer saves a server with game, RCON, query and voice chat ports.
+func newServer(t *testing.T) *state.ServerState {
+	t.Helper()
+
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	require.NoError(t, state.InitDirs())
+
+	serverState := state.NewServerState("survival")
+	serverState.ContainerID = "old"
+	serverState.Image = "docker.io/itzg/minecraft-server:java21"
+	serverState.Minecraft.Version = "1.21.1"
+	serverState.Minecraft.Memory = "4G"
+	serverState.Minecraft.GamePort = 25566
+	serverState.Minecraft.RconPort = 25576
+	serverState.Minecraft.RconPassword = "secret"
+	serverState.Minecraft.QueryPort = 25666
+	serverState.Volumes.Data = filepath.Join(t.TempDir(), "survival", "data")
+	serverState.Mods = []state.ModInfo{
+		{Slug: "fabric-api"},
+		{Slug: "simple-voice-chat", Port: 24455, Protocol: "udp"},
+	}
+	require.NoError(t, state.SaveServerState(context.Background(), serverState))
+
+	return serverState
+}
+
+func TestContainerConfig(t *testing.T) {
+	serverState := newServer(t)
+
+	config := ContainerConfig(serverState)
+
+	assert.Equal(t, "survival", config.Name)
+	assert.Equal(t, "docker.io/itzg/minecraft-server:java21", config.Image)
+	assert.Equal(t, "1.21.1", config.Env["VERSION"])
+	assert.Equal(t, "secret", config.Env["RCON_PASSWORD"])
+	assert.NotContains(t, config.Env, "FABRIC_LOADER_VERSION")
+	assert.Equal(t, "true", config.Env["ENABLE_QUERY"])
+	assert.Equal(t, "25565", config.Env["QUERY_PORT"])
+	assert.Equal(t, map[string]string{
+		serverState.Volumes.Data: "/data",
+		ModsDir(serverState):     "/data/mods",
+	}, config.Volumes)
+	assert.Equal(t, "survival", config.Labels["go-mc.server"])
+	assert.Equal(t, PortMappings(serverState), config.Ports)
+
+	// Servers without a saved image use the default one
+	serverState.Image = ""
+	serverState.Minecraft.FabricLoaderVersion = "0.16.5"
+	config = ContainerConfig(serverState)
+	assert.Equal(t, state.DefaultConfig().Container.Image, config.Image)
+	assert.Equal(t, "0.16.5", config.Env["FABRIC_LOADER_VERSION"])
+
+	// Servers created without a query port keep the query disabled
+	serverState.Minecraft.QueryPort = 0
+	config = ContainerConfig(serverState)
+	assert.NotContains(t, config.Env, "ENABLE_QUERY")
+}
+
+func TestContainerConfig_RestartPolicy(t *testing.T) {
+	serverState := newServer(t)
+
+	config := ContainerConfig(serverState)
+	assert.Empty(t, config.RestartPolicy)
+
+	serverState.Restart = state.RestartPolicy{Policy: state.RestartOnFailure, MaxRetries: 3, Backoff: time.Minute}
+	config = ContainerConfig(serverState)
+	assert.Equal(t, "on-failure", config.RestartPolicy)
+	assert.Equal(t, uint(3), config.RestartRetries)
+
+	serverState.Restart = state.RestartPolicy{Policy: state.RestartAlways}
+	config = ContainerConfig(serverState)
+	assert.Equal(t, "always", config.RestartPolicy)
+	assert.Zero(t, config.RestartRetries)
+}
+
+func TestContainerConfig_Resources(t *testing.T) {
+	serverState := newServer(t)
+
+	config := ContainerConfig(serverState)
+	assert.Empty(t, config.Memory)
+	assert.Zero(t, config.CPUQuota)
+
+	serverState.Minecraft.Memory = "4G"
+	serverState.Resources = state.ResourcesConfig{
+		CPUs:       1.5,
+		CPUShares:  512,
+		PidsLimit:  4096,
+		MemorySwap: "8G",
+		IOWeight:   200,
+	}
+	config = ContainerConfig(serverState)
+	assert.Equal(t, int64(150000), config.CPUQuota)
+	assert.Equal(t, uint64(512), config.CPUShares)
+	assert.Equal(t, int64(4096), config.PidsLimit)
+	assert.Equal(t, uint16(200), config.IOWeight)
+	assert.Equal(t, "5368709120", config.Memory, "heap plus a quarter")
+	assert.Equal(t, "8G", config.MemorySwap)
+}
+
+func TestRecreateContainer(t *testing.T) {
+	serverState := newServer(t)
+	client := &fakeClient{}
+
+	require.NoError(t, RecreateContainer(context.Background(), client, serverState))
+
+	assert.Equal(t, []string{"old"}, client.removed)
+	require.Len(t, client.created, 1)
+	assert.Equal(t, "missing", client.created[0].PullPolicy, "default container.pull_policy")
+	assert.Equal(t, "new-1", serverState.ContainerID)
+
+	saved, err := state.LoadServerState(context.Background(), "survival")
+	require.NoError(t, err)
+	assert.Equal(t, "new-1", saved.ContainerID)
+}
+
+func TestRecreateContainer_Errors(t *testing.T) {
+	t.Run("missing container is replaced", func(t *testing.T) {
+		serverState := newServer(t)
+		client := &fakeClient{removeErr: fmt.Errorf("%w: old", container.ErrContainerNotFound)}
+
+		require.NoError(t, RecreateContainer(context.Background(), client, serverState))
+		assert.Equal(t, "new-1", serverState.ContainerID)
+	})
+
+	t.Run("remove failure keeps the container", func(t *testing.T) {
+		serverState := newServer(t)
+		client := &fakeClient{removeErr: fmt.Errorf("permission denied")}
+
+		err := RecreateContainer(context.Background(), client, serverState)
+		assert.ErrorContains(t, err, "failed to remove old container")
+		assert.Empty(t, client.created)
+		assert.Equal(t, "old", serverState.ContainerID)
+	})
+}
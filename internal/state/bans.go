@@ -0,0 +1,453 @@
+package state
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// GlobalBanScope is the scope of bans that apply to every server.
+const GlobalBanScope = "global"
+
+// BanList holds the player and IP bans of one scope: a single server or,
+// for GlobalBanScope, every server.
+type BanList struct {
+	Scope     string      `yaml:"scope"`
+	UpdatedAt time.Time   `yaml:"updated_at"`
+	Players   []PlayerBan `yaml:"players"`
+	IPs       []IPBan     `yaml:"ips"`
+}
+
+// BanInfo holds the details shared by player and IP bans.
+type BanInfo struct {
+	Reason  string    `yaml:"reason,omitempty"`
+	Source  string    `yaml:"source"`
+	Created time.Time `yaml:"created"`
+
+	// Expires is nil for permanent bans.
+	Expires *time.Time `yaml:"expires,omitempty"`
+}
+
+// PlayerBan represents a banned player.
+type PlayerBan struct {
+	UUID    string `yaml:"uuid"`
+	Name    string `yaml:"name"`
+	BanInfo `yaml:",inline"`
+}
+
+// IPBan represents a banned IP address.
+type IPBan struct {
+	IP      string `yaml:"ip"`
+	BanInfo `yaml:",inline"`
+}
+
+// Expired reports whether a temporary ban has run out at the given time.
+func (b BanInfo) Expired(now time.Time) bool {
+	return b.Expires != nil && !now.Before(*b.Expires)
+}
+
+// NewBanList creates an empty ban list for a scope.
+func NewBanList(scope string) *BanList {
+	return &BanList{
+		Scope:     scope,
+		UpdatedAt: time.Now(),
+		Players:   []PlayerBan{},
+		IPs:       []IPBan{},
+	}
+}
+
+// FindPlayer returns the ban of a player by name (case-insensitive) or UUID.
+func (l *BanList) FindPlayer(player string) (*PlayerBan, bool) {
+	for i := range l.Players {
+		ban := &l.Players[i]
+		if strings.EqualFold(ban.Name, player) || strings.EqualFold(ban.UUID, player) {
+			return ban, true
+		}
+	}
+	return nil, false
+}
+
+// FindIP returns the ban of an IP address.
+func (l *BanList) FindIP(ip string) (*IPBan, bool) {
+	for i := range l.IPs {
+		if l.IPs[i].IP == ip {
+			return &l.IPs[i], true
+		}
+	}
+	return nil, false
+}
+
+// ValidateBanScope validates a ban scope: GlobalBanScope or a server name.
+func ValidateBanScope(scope string) error {
+	if scope == GlobalBanScope {
+		return nil
+	}
+	if err := ValidateServerName(scope); err != nil {
+		return fmt.Errorf("invalid ban scope: %w", err)
+	}
+	return nil
+}
+
+// LoadBanList loads the ban list of a scope from its YAML file.
+// A scope without a file has no bans.
+// If the file is corrupted, it backs up the corrupted file and returns an error.
+func LoadBanList(ctx context.Context, scope string) (*BanList, error) {
+	if err := ValidateBanScope(scope); err != nil {
+		return nil, err
+	}
+
+	banPath, err := GetBanListPath(scope)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get ban list path: %w", err)
+	}
+
+	//nolint:gosec // G304: banPath is generated by GetBanListPath(), not user input
+	data, err := os.ReadFile(banPath)
+	if os.IsNotExist(err) {
+		return NewBanList(scope), nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read ban list: %w", err)
+	}
+
+	// Parse YAML
+	var list BanList
+	if err := yaml.Unmarshal(data, &list); err != nil {
+		// Ban list is corrupted, backup
+		backupPath := banPath + ".corrupted"
+		if backupErr := os.Rename(banPath, backupPath); backupErr != nil {
+			return nil, fmt.Errorf("ban list is corrupted and failed to create backup: %w (original error: %v)", backupErr, err)
+		}
+
+		return nil, fmt.Errorf("ban list was corrupted and backed up to %s: %w", backupPath, err)
+	}
+
+	if list.Players == nil {
+		list.Players = []PlayerBan{}
+	}
+	if list.IPs == nil {
+		list.IPs = []IPBan{}
+	}
+
+	if err := ValidateBanList(&list); err != nil {
+		return nil, fmt.Errorf("invalid ban list: %w", err)
+	}
+
+	return &list, nil
+}
+
+// SaveBanList saves a ban list to its YAML file using atomic writes.
+func SaveBanList(ctx context.Context, list *BanList) error {
+	if list == nil {
+		return fmt.Errorf("ban list cannot be nil")
+	}
+
+	if err := ValidateBanList(list); err != nil {
+		return fmt.Errorf("invalid ban list: %w", err)
+	}
+
+	banPath, err := GetBanListPath(list.Scope)
+	if err != nil {
+		return fmt.Errorf("failed to get ban list path: %w", err)
+	}
+
+	// Update timestamp
+	list.UpdatedAt = time.Now()
+
+	data, err := yaml.Marshal(list)
+	if err != nil {
+		return fmt.Errorf("failed to marshal ban list: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(banPath), 0750); err != nil {
+		return fmt.Errorf("failed to create bans directory: %w", err)
+	}
+
+	if err := AtomicWrite(banPath, data, 0644); err != nil {
+		return fmt.Errorf("failed to write ban list: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteBanList deletes the ban list of a scope. A missing list is not an error.
+func DeleteBanList(ctx context.Context, scope string) error {
+	if err := ValidateBanScope(scope); err != nil {
+		return err
+	}
+
+	banPath, err := GetBanListPath(scope)
+	if err != nil {
+		return fmt.Errorf("failed to get ban list path: %w", err)
+	}
+
+	if err := os.Remove(banPath); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to delete ban list: %w", err)
+	}
+
+	return nil
+}
+
+// lockBanList locks the ban list of a scope, so changes by commands and by
+// go-mc watch lifting expired bans do not overwrite each other.
+func lockBanList(scope string) (*FileLock, error) {
+	if err := ValidateBanScope(scope); err != nil {
+		return nil, err
+	}
+
+	banPath, err := GetBanListPath(scope)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get ban list path: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(banPath), 0750); err != nil {
+		return nil, fmt.Errorf("failed to create bans directory: %w", err)
+	}
+
+	lock, err := LockFile(banPath + ".lock")
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire lock: %w", err)
+	}
+	return lock, nil
+}
+
+// ValidateBanList validates a ban list.
+func ValidateBanList(list *BanList) error {
+	if list == nil {
+		return fmt.Errorf("ban list cannot be nil")
+	}
+
+	if err := ValidateBanScope(list.Scope); err != nil {
+		return err
+	}
+
+	seenUUIDs := make(map[string]bool)
+	for _, ban := range list.Players {
+		if err := ValidateUUID(ban.UUID); err != nil {
+			return fmt.Errorf("invalid player UUID: %w", err)
+		}
+		if err := ValidatePlayerName(ban.Name); err != nil {
+			return fmt.Errorf("invalid player name: %w", err)
+		}
+		if err := validateBanInfo(ban.BanInfo); err != nil {
+			return fmt.Errorf("invalid ban of %s: %w", ban.Name, err)
+		}
+
+		if seenUUIDs[ban.UUID] {
+			return fmt.Errorf("duplicate player UUID: %s", ban.UUID)
+		}
+		seenUUIDs[ban.UUID] = true
+	}
+
+	seenIPs := make(map[string]bool)
+	for _, ban := range list.IPs {
+		if err := ValidateIP(ban.IP); err != nil {
+			return err
+		}
+		if err := validateBanInfo(ban.BanInfo); err != nil {
+			return fmt.Errorf("invalid ban of %s: %w", ban.IP, err)
+		}
+
+		if seenIPs[ban.IP] {
+			return fmt.Errorf("duplicate IP address: %s", ban.IP)
+		}
+		seenIPs[ban.IP] = true
+	}
+
+	return nil
+}
+
+// validateBanInfo validates the details of a ban.
+func validateBanInfo(info BanInfo) error {
+	if info.Source == "" {
+		return fmt.Errorf("ban source cannot be empty")
+	}
+	if info.Expires != nil && !info.Expires.After(info.Created) {
+		return fmt.Errorf("ban must expire after it was created")
+	}
+	return nil
+}
+
+// AddPlayerBan bans a player in a scope. Banning a player again replaces the
+// previous ban, which updates its reason and expiry. The ban list is locked
+// while it is changed, as are all changes below.
+func AddPlayerBan(ctx context.Context, scope string, ban PlayerBan) error {
+	lock, err := lockBanList(scope)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = lock.Unlock() }()
+
+	list, err := LoadBanList(ctx, scope)
+	if err != nil {
+		return err
+	}
+
+	if ban.Created.IsZero() {
+		ban.Created = time.Now()
+	}
+
+	players := make([]PlayerBan, 0, len(list.Players)+1)
+	for _, existing := range list.Players {
+		if existing.UUID != ban.UUID {
+			players = append(players, existing)
+		}
+	}
+	list.Players = append(players, ban)
+
+	return SaveBanList(ctx, list)
+}
+
+// RemovePlayerBan lifts the ban of a player, by name or UUID, in a scope and
+// returns the removed ban.
+func RemovePlayerBan(ctx context.Context, scope, player string) (*PlayerBan, error) {
+	lock, err := lockBanList(scope)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = lock.Unlock() }()
+
+	list, err := LoadBanList(ctx, scope)
+	if err != nil {
+		return nil, err
+	}
+
+	existing, ok := list.FindPlayer(player)
+	if !ok {
+		return nil, fmt.Errorf("player %q is not banned", player)
+	}
+	removed := *existing
+
+	players := make([]PlayerBan, 0, len(list.Players))
+	for _, ban := range list.Players {
+		if ban.UUID != removed.UUID {
+			players = append(players, ban)
+		}
+	}
+	list.Players = players
+
+	if err := SaveBanList(ctx, list); err != nil {
+		return nil, err
+	}
+
+	return &removed, nil
+}
+
+// AddIPBan bans an IP address in a scope. Banning an address again replaces
+// the previous ban.
+func AddIPBan(ctx context.Context, scope string, ban IPBan) error {
+	if err := ValidateIP(ban.IP); err != nil {
+		return err
+	}
+
+	lock, err := lockBanList(scope)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = lock.Unlock() }()
+
+	list, err := LoadBanList(ctx, scope)
+	if err != nil {
+		return err
+	}
+
+	if ban.Created.IsZero() {
+		ban.Created = time.Now()
+	}
+
+	ips := make([]IPBan, 0, len(list.IPs)+1)
+	for _, existing := range list.IPs {
+		if existing.IP != ban.IP {
+			ips = append(ips, existing)
+		}
+	}
+	list.IPs = append(ips, ban)
+
+	return SaveBanList(ctx, list)
+}
+
+// RemoveIPBan lifts the ban of an IP address in a scope and returns the
+// removed ban.
+func RemoveIPBan(ctx context.Context, scope, ip string) (*IPBan, error) {
+	lock, err := lockBanList(scope)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = lock.Unlock() }()
+
+	list, err := LoadBanList(ctx, scope)
+	if err != nil {
+		return nil, err
+	}
+
+	existing, ok := list.FindIP(ip)
+	if !ok {
+		return nil, fmt.Errorf("IP address %q is not banned", ip)
+	}
+	removed := *existing
+
+	ips := make([]IPBan, 0, len(list.IPs))
+	for _, ban := range list.IPs {
+		if ban.IP != removed.IP {
+			ips = append(ips, ban)
+		}
+	}
+	list.IPs = ips
+
+	if err := SaveBanList(ctx, list); err != nil {
+		return nil, err
+	}
+
+	return &removed, nil
+}
+
+// PruneExpiredBans removes the bans of a scope that have expired at now and
+// returns them as a list. The file is only rewritten if something expired.
+func PruneExpiredBans(ctx context.Context, scope string, now time.Time) (*BanList, error) {
+	lock, err := lockBanList(scope)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = lock.Unlock() }()
+
+	list, err := LoadBanList(ctx, scope)
+	if err != nil {
+		return nil, err
+	}
+
+	expired := NewBanList(scope)
+	players := make([]PlayerBan, 0, len(list.Players))
+	for _, ban := range list.Players {
+		if ban.Expired(now) {
+			expired.Players = append(expired.Players, ban)
+		} else {
+			players = append(players, ban)
+		}
+	}
+
+	ips := make([]IPBan, 0, len(list.IPs))
+	for _, ban := range list.IPs {
+		if ban.Expired(now) {
+			expired.IPs = append(expired.IPs, ban)
+		} else {
+			ips = append(ips, ban)
+		}
+	}
+
+	if len(expired.Players) == 0 && len(expired.IPs) == 0 {
+		return expired, nil
+	}
+
+	list.Players = players
+	list.IPs = ips
+	if err := SaveBanList(ctx, list); err != nil {
+		return nil, err
+	}
+
+	return expired, nil
+}
@@ -0,0 +1,267 @@
+package state
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testBanUUID  = "069a79f4-44e9-4726-a5be-fca90e38aaf5"
+	testBanUUID2 = "853c80ef-3c37-49fd-aa49-938b674adae6"
+)
+
+func TestBanInfo_Expired(t *testing.T) {
+	now := time.Now()
+	past := now.Add(-time.Minute)
+	future := now.Add(time.Hour)
+
+	assert.False(t, BanInfo{}.Expired(now), "permanent bans never expire")
+	assert.True(t, BanInfo{Expires: &past}.Expired(now))
+	assert.True(t, BanInfo{Expires: &now}.Expired(now))
+	assert.False(t, BanInfo{Expires: &future}.Expired(now))
+}
+
+func TestLoadBanList_NotExists(t *testing.T) {
+	cleanup := setupTestEnv(t)
+	defer cleanup()
+
+	list, err := LoadBanList(context.Background(), GlobalBanScope)
+	require.NoError(t, err)
+	assert.Equal(t, GlobalBanScope, list.Scope)
+	assert.Empty(t, list.Players)
+	assert.Empty(t, list.IPs)
+}
+
+func TestLoadBanList_InvalidScope(t *testing.T) {
+	cleanup := setupTestEnv(t)
+	defer cleanup()
+
+	_, err := LoadBanList(context.Background(), "bad name")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid ban scope")
+}
+
+func TestLoadBanList_Corrupted(t *testing.T) {
+	cleanup := setupTestEnv(t)
+	defer cleanup()
+
+	banPath, err := GetBanListPath("survival")
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(banPath, []byte("players: {[}]"), 0644))
+
+	_, err = LoadBanList(context.Background(), "survival")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "corrupted and backed up")
+
+	_, err = os.Stat(banPath + ".corrupted")
+	assert.NoError(t, err)
+}
+
+func TestAddPlayerBan(t *testing.T) {
+	cleanup := setupTestEnv(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	expires := time.Now().Add(24 * time.Hour)
+
+	err := AddPlayerBan(ctx, "survival", PlayerBan{
+		UUID:    testBanUUID,
+		Name:    "Notch",
+		BanInfo: BanInfo{Reason: "griefing", Source: "go-mc"},
+	})
+	require.NoError(t, err)
+
+	// Banning again replaces the ban
+	err = AddPlayerBan(ctx, "survival", PlayerBan{
+		UUID:    testBanUUID,
+		Name:    "Notch",
+		BanInfo: BanInfo{Reason: "spam", Source: "go-mc", Expires: &expires},
+	})
+	require.NoError(t, err)
+
+	list, err := LoadBanList(ctx, "survival")
+	require.NoError(t, err)
+	require.Len(t, list.Players, 1)
+	assert.Equal(t, "spam", list.Players[0].Reason)
+	assert.False(t, list.Players[0].Created.IsZero())
+	require.NotNil(t, list.Players[0].Expires)
+	assert.WithinDuration(t, expires, *list.Players[0].Expires, time.Second)
+
+	// Other scopes are unaffected
+	global, err := LoadBanList(ctx, GlobalBanScope)
+	require.NoError(t, err)
+	assert.Empty(t, global.Players)
+}
+
+func TestAddPlayerBan_Invalid(t *testing.T) {
+	cleanup := setupTestEnv(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	tests := []struct {
+		name   string
+		ban    PlayerBan
+		errMsg string
+	}{
+		{
+			name:   "invalid UUID",
+			ban:    PlayerBan{UUID: "nope", Name: "Notch", BanInfo: BanInfo{Source: "go-mc"}},
+			errMsg: "invalid player UUID",
+		},
+		{
+			name:   "missing source",
+			ban:    PlayerBan{UUID: testBanUUID, Name: "Notch"},
+			errMsg: "ban source cannot be empty",
+		},
+		{
+			name: "expires before creation",
+			ban: PlayerBan{UUID: testBanUUID, Name: "Notch", BanInfo: BanInfo{
+				Source:  "go-mc",
+				Created: time.Now(),
+				Expires: func() *time.Time { t := time.Now().Add(-time.Hour); return &t }(),
+			}},
+			errMsg: "must expire after",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := AddPlayerBan(ctx, GlobalBanScope, tt.ban)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.errMsg)
+		})
+	}
+}
+
+func TestRemovePlayerBan(t *testing.T) {
+	cleanup := setupTestEnv(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	require.NoError(t, AddPlayerBan(ctx, GlobalBanScope, PlayerBan{UUID: testBanUUID, Name: "Notch", BanInfo: BanInfo{Source: "go-mc"}}))
+	require.NoError(t, AddPlayerBan(ctx, GlobalBanScope, PlayerBan{UUID: testBanUUID2, Name: "jeb_", BanInfo: BanInfo{Source: "go-mc"}}))
+
+	removed, err := RemovePlayerBan(ctx, GlobalBanScope, "notch")
+	require.NoError(t, err)
+	assert.Equal(t, "Notch", removed.Name)
+
+	removed, err = RemovePlayerBan(ctx, GlobalBanScope, testBanUUID2)
+	require.NoError(t, err)
+	assert.Equal(t, "jeb_", removed.Name)
+
+	_, err = RemovePlayerBan(ctx, GlobalBanScope, "Notch")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "is not banned")
+}
+
+func TestIPBans(t *testing.T) {
+	cleanup := setupTestEnv(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	err := AddIPBan(ctx, "survival", IPBan{IP: "not-an-ip", BanInfo: BanInfo{Source: "go-mc"}})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid IP address")
+
+	require.NoError(t, AddIPBan(ctx, "survival", IPBan{IP: "203.0.113.7", BanInfo: BanInfo{Source: "go-mc"}}))
+
+	list, err := LoadBanList(ctx, "survival")
+	require.NoError(t, err)
+	ban, ok := list.FindIP("203.0.113.7")
+	require.True(t, ok)
+	assert.Equal(t, "go-mc", ban.Source)
+
+	removed, err := RemoveIPBan(ctx, "survival", "203.0.113.7")
+	require.NoError(t, err)
+	assert.Equal(t, "203.0.113.7", removed.IP)
+
+	_, err = RemoveIPBan(ctx, "survival", "203.0.113.7")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "is not banned")
+}
+
+func TestPruneExpiredBans(t *testing.T) {
+	cleanup := setupTestEnv(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	created := time.Now().Add(-2 * time.Hour)
+	expired := time.Now().Add(-time.Hour)
+	future := time.Now().Add(time.Hour)
+
+	require.NoError(t, AddPlayerBan(ctx, "survival", PlayerBan{UUID: testBanUUID, Name: "Notch",
+		BanInfo: BanInfo{Source: "go-mc", Created: created, Expires: &expired}}))
+	require.NoError(t, AddPlayerBan(ctx, "survival", PlayerBan{UUID: testBanUUID2, Name: "jeb_",
+		BanInfo: BanInfo{Source: "go-mc", Created: created, Expires: &future}}))
+	require.NoError(t, AddIPBan(ctx, "survival", IPBan{IP: "203.0.113.7",
+		BanInfo: BanInfo{Source: "go-mc", Created: created, Expires: &expired}}))
+
+	pruned, err := PruneExpiredBans(ctx, "survival", time.Now())
+	require.NoError(t, err)
+	require.Len(t, pruned.Players, 1)
+	assert.Equal(t, "Notch", pruned.Players[0].Name)
+	require.Len(t, pruned.IPs, 1)
+
+	list, err := LoadBanList(ctx, "survival")
+	require.NoError(t, err)
+	require.Len(t, list.Players, 1)
+	assert.Equal(t, "jeb_", list.Players[0].Name)
+	assert.Empty(t, list.IPs)
+}
+
+func TestBans_ConcurrentChanges(t *testing.T) {
+	cleanup := setupTestEnv(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	created := time.Now().Add(-2 * time.Hour)
+	expired := time.Now().Add(-time.Hour)
+	require.NoError(t, AddPlayerBan(ctx, "survival", PlayerBan{UUID: testBanUUID, Name: "Notch",
+		BanInfo: BanInfo{Source: "go-mc", Created: created, Expires: &expired}}))
+
+	// Bans added while go-mc watch prunes expired bans are kept
+	done := make(chan error, 20)
+	for i := 1; i <= 10; i++ {
+		go func(n int) {
+			done <- AddIPBan(ctx, "survival", IPBan{IP: fmt.Sprintf("203.0.113.%d", n), BanInfo: BanInfo{Source: "go-mc"}})
+		}(i)
+		go func() {
+			_, err := PruneExpiredBans(ctx, "survival", time.Now())
+			done <- err
+		}()
+	}
+	for i := 0; i < 20; i++ {
+		require.NoError(t, <-done)
+	}
+
+	list, err := LoadBanList(ctx, "survival")
+	require.NoError(t, err)
+	assert.Empty(t, list.Players)
+	assert.Len(t, list.IPs, 10)
+}
+
+func TestDeleteBanList(t *testing.T) {
+	cleanup := setupTestEnv(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	// Deleting a missing list is fine
+	require.NoError(t, DeleteBanList(ctx, "survival"))
+
+	require.NoError(t, AddIPBan(ctx, "survival", IPBan{IP: "203.0.113.7", BanInfo: BanInfo{Source: "go-mc"}}))
+	require.NoError(t, DeleteBanList(ctx, "survival"))
+
+	list, err := LoadBanList(ctx, "survival")
+	require.NoError(t, err)
+	assert.Empty(t, list.IPs)
+}
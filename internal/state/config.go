@@ -23,8 +23,8 @@ type Config struct {
 
 // ContainerConfig holds container runtime configuration.
 type ContainerConfig struct {
-	Runtime    string `yaml:"runtime"`
-	Socket     string `yaml:"socket"`
+	Runtime    string `yaml:"runtime"` // podman, docker or auto
+	Socket     string `yaml:"socket"`  // explicit runtime socket, detected when empty
 	Image      string `yaml:"image"`
 	PullPolicy string `yaml:"pull_policy"`
 }
@@ -32,7 +32,6 @@ type ContainerConfig struct {
 // DefaultsConfig holds default values for new servers.
 type DefaultsConfig struct {
 	Memory              string `yaml:"memory"`
-	JavaVersion         int    `yaml:"java_version"`
 	MinecraftVersion    string `yaml:"minecraft_version"`
 	FabricLoaderVersion string `yaml:"fabric_loader_version"`
 }
@@ -41,12 +40,13 @@ type DefaultsConfig struct {
 type PortsConfig struct {
 	GamePortStart      int `yaml:"game_port_start"`
 	RconPortStart      int `yaml:"rcon_port_start"`
+	QueryPortStart     int `yaml:"query_port_start"`
 	RconPasswordLength int `yaml:"rcon_password_length"`
 }
 
 // BackupsConfig holds backup configuration.
 type BackupsConfig struct {
-	Directory              string `yaml:"directory"`
+	Directory              string `yaml:"directory"` // archive directory, GetArchivesDir() when empty
 	Compress               bool   `yaml:"compress"`
 	KeepCount              int    `yaml:"keep_count"`
 	AutoBackupBeforeUpdate bool   `yaml:"auto_backup_before_update"`
@@ -72,33 +72,36 @@ type LoggingConfig struct {
 
 // LimitsConfig holds resource limits.
 type LimitsConfig struct {
-	MaxServers         int    `yaml:"max_servers"`
-	MaxMemoryPerServer string `yaml:"max_memory_per_server"`
-	MaxPorts           int    `yaml:"max_ports"`
+	MaxServers             int    `yaml:"max_servers"`
+	MaxMemoryPerServer     string `yaml:"max_memory_per_server"`
+	MaxMemorySwapPerServer string `yaml:"max_memory_swap_per_server"`
+	MaxCPUsPerServer       int    `yaml:"max_cpus_per_server"`
+	MaxPidsPerServer       int    `yaml:"max_pids_per_server"`
+	MaxPorts               int    `yaml:"max_ports"`
 }
 
 // DefaultConfig returns a Config with sensible default values.
 func DefaultConfig() *Config {
 	return &Config{
 		Container: ContainerConfig{
-			Runtime:    "podman",
+			Runtime:    "auto",
 			Socket:     "",
 			Image:      "ghcr.io/itzg/minecraft-server:latest",
 			PullPolicy: "missing",
 		},
 		Defaults: DefaultsConfig{
 			Memory:              "2G",
-			JavaVersion:         21,
 			MinecraftVersion:    "latest",
 			FabricLoaderVersion: "latest",
 		},
 		Ports: PortsConfig{
 			GamePortStart:      25565,
 			RconPortStart:      25575,
+			QueryPortStart:     25665,
 			RconPasswordLength: 16,
 		},
 		Backups: BackupsConfig{
-			Directory:              "~/.config/go-mc/backups/archives/",
+			Directory:              "",
 			Compress:               true,
 			KeepCount:              5,
 			AutoBackupBeforeUpdate: true,
@@ -116,9 +119,12 @@ func DefaultConfig() *Config {
 			File:  "~/.config/go-mc/go-mc.log",
 		},
 		Limits: LimitsConfig{
-			MaxServers:         50,
-			MaxMemoryPerServer: "16G",
-			MaxPorts:           100,
+			MaxServers:             50,
+			MaxMemoryPerServer:     "16G",
+			MaxMemorySwapPerServer: "32G",
+			MaxCPUsPerServer:       8,
+			MaxPidsPerServer:       8192,
+			MaxPorts:               100,
 		},
 	}
 }
@@ -149,9 +155,9 @@ func LoadConfig(ctx context.Context) (*Config, error) {
 		return nil, fmt.Errorf("failed to read config file: %w", err)
 	}
 
-	// Parse YAML
-	var cfg Config
-	if err := yaml.Unmarshal(data, &cfg); err != nil {
+	// Parse YAML on top of the defaults so that partial files are valid
+	cfg := DefaultConfig()
+	if err := yaml.Unmarshal(data, cfg); err != nil {
 		// Config file is corrupted, backup and create fresh
 		backupPath := configPath + ".corrupted"
 		if backupErr := os.Rename(configPath, backupPath); backupErr != nil {
@@ -168,11 +174,59 @@ func LoadConfig(ctx context.Context) (*Config, error) {
 	}
 
 	// Validate config
-	if err := ValidateConfig(&cfg); err != nil {
+	if err := ValidateConfig(cfg); err != nil {
 		return nil, fmt.Errorf("invalid config: %w", err)
 	}
 
-	return &cfg, nil
+	return cfg, nil
+}
+
+// ParseConfig parses YAML config data on top of the defaults and validates the result.
+func ParseConfig(data []byte) (*Config, error) {
+	cfg := DefaultConfig()
+	if err := yaml.Unmarshal(data, cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse config: %w", err)
+	}
+
+	if err := ValidateConfig(cfg); err != nil {
+		return nil, fmt.Errorf("invalid config: %w", err)
+	}
+
+	return cfg, nil
+}
+
+// ResolveConfig returns the effective configuration: the config file on top of
+// the defaults, with GOMC_* environment variables applied over it. Unlike
+// LoadConfig it never writes to disk, so a missing file simply yields the
+// defaults. Commands apply their explicitly set flags on top of the result.
+func ResolveConfig(ctx context.Context) (*Config, error) {
+	configPath, err := GetConfigPath()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get config path: %w", err)
+	}
+
+	cfg := DefaultConfig()
+
+	//nolint:gosec // G304: configPath is generated by GetConfigPath(), not user input
+	data, err := os.ReadFile(configPath)
+	switch {
+	case err == nil:
+		if err := yaml.Unmarshal(data, cfg); err != nil {
+			return nil, fmt.Errorf("failed to parse config file %s: %w", configPath, err)
+		}
+	case !os.IsNotExist(err):
+		return nil, fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	if err := ApplyEnvOverrides(cfg); err != nil {
+		return nil, fmt.Errorf("invalid environment override: %w", err)
+	}
+
+	if err := ValidateConfig(cfg); err != nil {
+		return nil, fmt.Errorf("invalid config: %w", err)
+	}
+
+	return cfg, nil
 }
 
 // SaveConfig saves the configuration to the config file using atomic writes.
@@ -212,23 +266,27 @@ func ValidateConfig(cfg *Config) error {
 	}
 
 	// Validate container config
-	if cfg.Container.Runtime != "podman" && cfg.Container.Runtime != "docker" {
-		return fmt.Errorf("invalid container runtime: %q (must be podman or docker)", cfg.Container.Runtime)
+	switch cfg.Container.Runtime {
+	case "podman", "docker", "auto":
+	default:
+		return fmt.Errorf("invalid container runtime: %q (must be podman, docker, or auto)", cfg.Container.Runtime)
 	}
 
 	if cfg.Container.Image == "" {
 		return fmt.Errorf("container image cannot be empty")
 	}
 
+	switch cfg.Container.PullPolicy {
+	case "always", "missing", "newer", "never":
+	default:
+		return fmt.Errorf("invalid container pull policy: %q (must be always, missing, newer, or never)", cfg.Container.PullPolicy)
+	}
+
 	// Validate defaults
 	if err := ValidateMemory(cfg.Defaults.Memory); err != nil {
 		return fmt.Errorf("invalid default memory: %w", err)
 	}
 
-	if err := ValidateJavaVersion(cfg.Defaults.JavaVersion); err != nil {
-		return fmt.Errorf("invalid default Java version: %w", err)
-	}
-
 	// Validate ports
 	if err := ValidatePort(cfg.Ports.GamePortStart); err != nil {
 		return fmt.Errorf("invalid game port start: %w", err)
@@ -238,6 +296,10 @@ func ValidateConfig(cfg *Config) error {
 		return fmt.Errorf("invalid rcon port start: %w", err)
 	}
 
+	if err := ValidatePort(cfg.Ports.QueryPortStart); err != nil {
+		return fmt.Errorf("invalid query port start: %w", err)
+	}
+
 	if cfg.Ports.RconPasswordLength < 8 || cfg.Ports.RconPasswordLength > 32 {
 		return fmt.Errorf("rcon password length must be between 8 and 32, got %d", cfg.Ports.RconPasswordLength)
 	}
@@ -274,6 +336,18 @@ func ValidateConfig(cfg *Config) error {
 		return fmt.Errorf("invalid max memory per server: %w", err)
 	}
 
+	if err := ValidateMemory(cfg.Limits.MaxMemorySwapPerServer); err != nil {
+		return fmt.Errorf("invalid max memory swap per server: %w", err)
+	}
+
+	if cfg.Limits.MaxCPUsPerServer < 1 {
+		return fmt.Errorf("max CPUs per server must be >= 1, got %d", cfg.Limits.MaxCPUsPerServer)
+	}
+
+	if cfg.Limits.MaxPidsPerServer < 1 {
+		return fmt.Errorf("max pids per server must be >= 1, got %d", cfg.Limits.MaxPidsPerServer)
+	}
+
 	if cfg.Limits.MaxPorts < 1 {
 		return fmt.Errorf("max ports must be >= 1, got %d", cfg.Limits.MaxPorts)
 	}
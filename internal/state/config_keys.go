@@ -0,0 +1,190 @@
+package state
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ConfigEnvPrefix is the prefix of environment variables that override config values.
+// The variable for a key is the prefix followed by the upper-cased key with dots
+// replaced by underscores, e.g. GOMC_DEFAULTS_MEMORY for defaults.memory.
+const ConfigEnvPrefix = "GOMC_"
+
+// ErrUnknownConfigKey is returned when a dotted key does not address a config value.
+var ErrUnknownConfigKey = errors.New("unknown config key")
+
+var durationType = reflect.TypeOf(time.Duration(0))
+
+// memoryConfigKeys are string keys that hold memory sizes
+var memoryConfigKeys = map[string]bool{
+	"defaults.memory":                   true,
+	"limits.max_memory_per_server":      true,
+	"limits.max_memory_swap_per_server": true,
+}
+
+// ConfigKeys returns all dotted config keys (e.g. "defaults.memory") in sorted order.
+func ConfigKeys() []string {
+	var keys []string
+
+	cfgType := reflect.TypeOf(Config{})
+	for i := 0; i < cfgType.NumField(); i++ {
+		section := cfgType.Field(i)
+		for j := 0; j < section.Type.NumField(); j++ {
+			keys = append(keys, yamlName(section)+"."+yamlName(section.Type.Field(j)))
+		}
+	}
+
+	sort.Strings(keys)
+	return keys
+}
+
+// ConfigEnvVar returns the name of the environment variable that overrides a key.
+func ConfigEnvVar(key string) string {
+	return ConfigEnvPrefix + strings.ToUpper(strings.ReplaceAll(key, ".", "_"))
+}
+
+// GetConfigValue returns the value of a dotted config key.
+func GetConfigValue(cfg *Config, key string) (interface{}, error) {
+	field, err := configField(cfg, key)
+	if err != nil {
+		return nil, err
+	}
+	return field.Interface(), nil
+}
+
+// SetConfigValue parses value according to the type of the key and stores it.
+// Durations use Go syntax ("500ms", "2s") and memory sizes are normalized
+// ("4g" becomes "4G"). The config is not validated; call ValidateConfig afterwards.
+func SetConfigValue(cfg *Config, key, value string) error {
+	field, err := configField(cfg, key)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case field.Type() == durationType:
+		d, err := time.ParseDuration(value)
+		if err != nil {
+			return fmt.Errorf("invalid duration for %s: %q", key, value)
+		}
+		field.SetInt(int64(d))
+
+	case field.Kind() == reflect.Int:
+		n, err := strconv.Atoi(value)
+		if err != nil {
+			return fmt.Errorf("invalid integer for %s: %q", key, value)
+		}
+		field.SetInt(int64(n))
+
+	case field.Kind() == reflect.Bool:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return fmt.Errorf("invalid boolean for %s: %q", key, value)
+		}
+		field.SetBool(b)
+
+	case memoryConfigKeys[key]:
+		memory, err := NormalizeMemory(value)
+		if err != nil {
+			return fmt.Errorf("invalid memory size for %s: %w", key, err)
+		}
+		field.SetString(memory)
+
+	case field.Kind() == reflect.String:
+		field.SetString(value)
+
+	default:
+		return fmt.Errorf("unsupported type %s for %s", field.Type(), key)
+	}
+
+	return nil
+}
+
+// ResetConfigValue restores the default value of a dotted key.
+func ResetConfigValue(cfg *Config, key string) error {
+	field, err := configField(cfg, key)
+	if err != nil {
+		return err
+	}
+
+	defaultField, err := configField(DefaultConfig(), key)
+	if err != nil {
+		return err
+	}
+
+	field.Set(defaultField)
+	return nil
+}
+
+// ApplyEnvOverrides overrides config values with GOMC_* environment variables.
+func ApplyEnvOverrides(cfg *Config) error {
+	for _, key := range ConfigKeys() {
+		value, ok := os.LookupEnv(ConfigEnvVar(key))
+		if !ok {
+			continue
+		}
+
+		if err := SetConfigValue(cfg, key, value); err != nil {
+			return fmt.Errorf("%s: %w", ConfigEnvVar(key), err)
+		}
+	}
+
+	return nil
+}
+
+// configField returns the settable struct field addressed by a dotted key.
+func configField(cfg *Config, key string) (reflect.Value, error) {
+	if cfg == nil {
+		return reflect.Value{}, fmt.Errorf("config cannot be nil")
+	}
+
+	parts := strings.Split(key, ".")
+	if len(parts) != 2 {
+		return reflect.Value{}, fmt.Errorf("%w: %q", ErrUnknownConfigKey, key)
+	}
+
+	v := reflect.ValueOf(cfg).Elem()
+	for _, part := range parts {
+		field, ok := fieldByYAMLName(v, part)
+		if !ok {
+			return reflect.Value{}, fmt.Errorf("%w: %q", ErrUnknownConfigKey, key)
+		}
+		v = field
+	}
+
+	if v.Kind() == reflect.Struct {
+		return reflect.Value{}, fmt.Errorf("%w: %q", ErrUnknownConfigKey, key)
+	}
+
+	return v, nil
+}
+
+// fieldByYAMLName finds the field of struct v whose YAML name is name.
+func fieldByYAMLName(v reflect.Value, name string) (reflect.Value, bool) {
+	if v.Kind() != reflect.Struct {
+		return reflect.Value{}, false
+	}
+
+	for i := 0; i < v.NumField(); i++ {
+		if yamlName(v.Type().Field(i)) == name {
+			return v.Field(i), true
+		}
+	}
+
+	return reflect.Value{}, false
+}
+
+// yamlName returns the YAML key of a struct field.
+func yamlName(field reflect.StructField) string {
+	name, _, _ := strings.Cut(field.Tag.Get("yaml"), ",")
+	if name == "" {
+		return strings.ToLower(field.Name)
+	}
+	return name
+}
@@ -0,0 +1,130 @@
+package state
+
+import (
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfigKeys(t *testing.T) {
+	keys := ConfigKeys()
+
+	assert.True(t, sort.StringsAreSorted(keys))
+	assert.Contains(t, keys, "container.image")
+	assert.Contains(t, keys, "defaults.memory")
+	assert.Contains(t, keys, "backups.keep_count")
+	assert.Contains(t, keys, "tui.refresh_interval")
+	assert.Contains(t, keys, "mods.auto_resolve_dependencies")
+	assert.NotContains(t, keys, "defaults")
+
+	// Every key must be readable
+	cfg := DefaultConfig()
+	for _, key := range keys {
+		_, err := GetConfigValue(cfg, key)
+		assert.NoError(t, err, key)
+	}
+}
+
+func TestConfigEnvVar(t *testing.T) {
+	assert.Equal(t, "GOMC_DEFAULTS_MEMORY", ConfigEnvVar("defaults.memory"))
+	assert.Equal(t, "GOMC_TUI_REFRESH_INTERVAL", ConfigEnvVar("tui.refresh_interval"))
+}
+
+func TestGetConfigValue(t *testing.T) {
+	cfg := DefaultConfig()
+
+	tests := []struct {
+		key     string
+		want    interface{}
+		wantErr bool
+	}{
+		{key: "defaults.memory", want: "2G"},
+		{key: "ports.game_port_start", want: 25565},
+		{key: "backups.compress", want: true},
+		{key: "tui.refresh_interval", want: time.Second},
+		{key: "defaults", wantErr: true},
+		{key: "defaults.unknown", wantErr: true},
+		{key: "defaults.memory.extra", wantErr: true},
+		{key: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.key, func(t *testing.T) {
+			got, err := GetConfigValue(cfg, tt.key)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, ErrUnknownConfigKey)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestSetConfigValue(t *testing.T) {
+	tests := []struct {
+		name    string
+		key     string
+		value   string
+		want    interface{}
+		wantErr string
+	}{
+		{name: "string", key: "container.image", value: "example.com/mc:1", want: "example.com/mc:1"},
+		{name: "int", key: "backups.keep_count", value: "10", want: 10},
+		{name: "bool", key: "backups.compress", value: "false", want: false},
+		{name: "duration", key: "tui.refresh_interval", value: "500ms", want: 500 * time.Millisecond},
+		{name: "memory", key: "defaults.memory", value: "4G", want: "4G"},
+		{name: "memory normalized", key: "limits.max_memory_per_server", value: "16gb", want: "16G"},
+		{name: "invalid memory", key: "defaults.memory", value: "4.5G", wantErr: "invalid memory size"},
+		{name: "invalid int", key: "backups.keep_count", value: "ten", wantErr: "invalid integer"},
+		{name: "invalid bool", key: "backups.compress", value: "maybe", wantErr: "invalid boolean"},
+		{name: "invalid duration", key: "tui.refresh_interval", value: "soon", wantErr: "invalid duration"},
+		{name: "unknown key", key: "nope.nope", value: "1", wantErr: "unknown config key"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := DefaultConfig()
+
+			err := SetConfigValue(cfg, tt.key, tt.value)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+
+			got, err := GetConfigValue(cfg, tt.key)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestApplyEnvOverrides(t *testing.T) {
+	t.Setenv("GOMC_DEFAULTS_MEMORY", "4G")
+	t.Setenv("GOMC_PORTS_GAME_PORT_START", "30000")
+
+	cfg := DefaultConfig()
+	require.NoError(t, ApplyEnvOverrides(cfg))
+
+	assert.Equal(t, "4G", cfg.Defaults.Memory)
+	assert.Equal(t, 30000, cfg.Ports.GamePortStart)
+	assert.Equal(t, 16, cfg.Ports.RconPasswordLength)
+}
+
+func TestResetConfigValue(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.Defaults.Memory = "8G"
+	cfg.TUI.RefreshInterval = 5 * time.Second
+
+	require.NoError(t, ResetConfigValue(cfg, "defaults.memory"))
+	assert.Equal(t, "2G", cfg.Defaults.Memory)
+	assert.Equal(t, 5*time.Second, cfg.TUI.RefreshInterval)
+
+	err := ResetConfigValue(cfg, "defaults.nope")
+	assert.ErrorIs(t, err, ErrUnknownConfigKey)
+}
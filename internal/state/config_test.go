@@ -3,6 +3,7 @@ package state
 import (
 	"context"
 	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
@@ -14,17 +15,20 @@ func TestDefaultConfig(t *testing.T) {
 	cfg := DefaultConfig()
 
 	require.NotNil(t, cfg)
-	assert.Equal(t, "podman", cfg.Container.Runtime)
+	assert.Equal(t, "auto", cfg.Container.Runtime)
 	assert.Equal(t, "2G", cfg.Defaults.Memory)
-	assert.Equal(t, 21, cfg.Defaults.JavaVersion)
 	assert.Equal(t, 25565, cfg.Ports.GamePortStart)
 	assert.Equal(t, 25575, cfg.Ports.RconPortStart)
+	assert.Equal(t, 25665, cfg.Ports.QueryPortStart)
 	assert.Equal(t, 16, cfg.Ports.RconPasswordLength)
 	assert.Equal(t, true, cfg.Backups.Compress)
 	assert.Equal(t, 5, cfg.Backups.KeepCount)
 	assert.Equal(t, 1*time.Second, cfg.TUI.RefreshInterval)
 	assert.Equal(t, "info", cfg.Logging.Level)
 	assert.Equal(t, 50, cfg.Limits.MaxServers)
+	assert.Equal(t, 8, cfg.Limits.MaxCPUsPerServer)
+	assert.Equal(t, 8192, cfg.Limits.MaxPidsPerServer)
+	assert.Equal(t, "32G", cfg.Limits.MaxMemorySwapPerServer)
 }
 
 func TestLoadConfig_CreatesDefaultIfMissing(t *testing.T) {
@@ -45,7 +49,7 @@ func TestLoadConfig_CreatesDefaultIfMissing(t *testing.T) {
 	require.NotNil(t, cfg)
 
 	// Verify config was created with defaults
-	assert.Equal(t, "podman", cfg.Container.Runtime)
+	assert.Equal(t, "auto", cfg.Container.Runtime)
 	assert.Equal(t, "2G", cfg.Defaults.Memory)
 
 	// Verify config file was created
@@ -120,7 +124,7 @@ func TestLoadConfig_RecorversFromCorruption(t *testing.T) {
 	require.NoError(t, err)
 
 	// Verify new config has defaults
-	assert.Equal(t, "podman", cfg.Container.Runtime)
+	assert.Equal(t, "auto", cfg.Container.Runtime)
 	assert.Equal(t, "2G", cfg.Defaults.Memory)
 }
 
@@ -203,24 +207,24 @@ func TestValidateConfig(t *testing.T) {
 			errMsg:  "container image cannot be empty",
 		},
 		{
-			name: "invalid default memory",
+			name: "invalid pull policy",
 			cfg: func() *Config {
 				cfg := DefaultConfig()
-				cfg.Defaults.Memory = "invalid"
+				cfg.Container.PullPolicy = "sometimes"
 				return cfg
 			}(),
 			wantErr: true,
-			errMsg:  "invalid default memory",
+			errMsg:  "invalid container pull policy",
 		},
 		{
-			name: "invalid Java version",
+			name: "invalid default memory",
 			cfg: func() *Config {
 				cfg := DefaultConfig()
-				cfg.Defaults.JavaVersion = 99
+				cfg.Defaults.Memory = "invalid"
 				return cfg
 			}(),
 			wantErr: true,
-			errMsg:  "invalid default Java version",
+			errMsg:  "invalid default memory",
 		},
 		{
 			name: "invalid game port",
@@ -242,6 +246,16 @@ func TestValidateConfig(t *testing.T) {
 			wantErr: true,
 			errMsg:  "invalid rcon port start",
 		},
+		{
+			name: "invalid query port",
+			cfg: func() *Config {
+				cfg := DefaultConfig()
+				cfg.Ports.QueryPortStart = -1
+				return cfg
+			}(),
+			wantErr: true,
+			errMsg:  "invalid query port start",
+		},
 		{
 			name: "rcon password too short",
 			cfg: func() *Config {
@@ -302,6 +316,36 @@ func TestValidateConfig(t *testing.T) {
 			wantErr: true,
 			errMsg:  "invalid max memory per server",
 		},
+		{
+			name: "invalid max memory swap per server",
+			cfg: func() *Config {
+				cfg := DefaultConfig()
+				cfg.Limits.MaxMemorySwapPerServer = "lots"
+				return cfg
+			}(),
+			wantErr: true,
+			errMsg:  "invalid max memory swap per server",
+		},
+		{
+			name: "max CPUs per server too low",
+			cfg: func() *Config {
+				cfg := DefaultConfig()
+				cfg.Limits.MaxCPUsPerServer = 0
+				return cfg
+			}(),
+			wantErr: true,
+			errMsg:  "max CPUs per server must be >= 1",
+		},
+		{
+			name: "max pids per server too low",
+			cfg: func() *Config {
+				cfg := DefaultConfig()
+				cfg.Limits.MaxPidsPerServer = 0
+				return cfg
+			}(),
+			wantErr: true,
+			errMsg:  "max pids per server must be >= 1",
+		},
 		{
 			name: "max ports too low",
 			cfg: func() *Config {
@@ -404,3 +448,134 @@ func TestConfig_ConcurrentReads(t *testing.T) {
 		<-done
 	}
 }
+
+func TestLoadConfig_PartialFileUsesDefaults(t *testing.T) {
+	tmpDir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", tmpDir)
+	require.NoError(t, InitDirs())
+
+	configPath, err := GetConfigPath()
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(configPath, []byte("defaults:\n  memory: 4G\n"), 0644))
+
+	cfg, err := LoadConfig(context.Background())
+	require.NoError(t, err)
+
+	assert.Equal(t, "4G", cfg.Defaults.Memory)
+	assert.Equal(t, "auto", cfg.Container.Runtime)
+	assert.Equal(t, 25565, cfg.Ports.GamePortStart)
+}
+
+func TestParseConfig(t *testing.T) {
+	cfg, err := ParseConfig([]byte("defaults:\n  memory: 4G\n"))
+	require.NoError(t, err)
+	assert.Equal(t, "4G", cfg.Defaults.Memory)
+	assert.Equal(t, 25565, cfg.Ports.GamePortStart)
+
+	_, err = ParseConfig([]byte("defaults: [\n"))
+	assert.ErrorContains(t, err, "failed to parse config")
+
+	_, err = ParseConfig([]byte("defaults:\n  memory: lots\n"))
+	assert.ErrorContains(t, err, "invalid config")
+}
+
+func TestResolveConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		file    string
+		env     map[string]string
+		wantErr string
+		check   func(t *testing.T, cfg *Config)
+	}{
+		{
+			name: "no file yields defaults",
+			check: func(t *testing.T, cfg *Config) {
+				assert.Equal(t, DefaultConfig(), cfg)
+			},
+		},
+		{
+			name: "file overrides defaults",
+			file: "defaults:\n  memory: 4G\nports:\n  game_port_start: 30000\n",
+			check: func(t *testing.T, cfg *Config) {
+				assert.Equal(t, "4G", cfg.Defaults.Memory)
+				assert.Equal(t, 30000, cfg.Ports.GamePortStart)
+				assert.Equal(t, 16, cfg.Ports.RconPasswordLength)
+			},
+		},
+		{
+			name: "environment overrides file",
+			file: "defaults:\n  memory: 4G\n",
+			env: map[string]string{
+				"GOMC_DEFAULTS_MEMORY":      "8G",
+				"GOMC_BACKUPS_KEEP_COUNT":   "10",
+				"GOMC_BACKUPS_COMPRESS":     "false",
+				"GOMC_TUI_REFRESH_INTERVAL": "5s",
+			},
+			check: func(t *testing.T, cfg *Config) {
+				assert.Equal(t, "8G", cfg.Defaults.Memory)
+				assert.Equal(t, 10, cfg.Backups.KeepCount)
+				assert.False(t, cfg.Backups.Compress)
+				assert.Equal(t, 5*time.Second, cfg.TUI.RefreshInterval)
+			},
+		},
+		{
+			name:    "invalid environment value",
+			env:     map[string]string{"GOMC_BACKUPS_KEEP_COUNT": "many"},
+			wantErr: "GOMC_BACKUPS_KEEP_COUNT",
+		},
+		{
+			name:    "environment value fails validation",
+			env:     map[string]string{"GOMC_BACKUPS_KEEP_COUNT": "-1"},
+			wantErr: "backup keep count",
+		},
+		{
+			name:    "corrupted file",
+			file:    "defaults: [unclosed\n",
+			wantErr: "failed to parse config file",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+			for k, v := range tt.env {
+				t.Setenv(k, v)
+			}
+
+			configPath, err := GetConfigPath()
+			require.NoError(t, err)
+
+			if tt.file != "" {
+				require.NoError(t, InitDirs())
+				require.NoError(t, os.WriteFile(configPath, []byte(tt.file), 0644))
+			}
+
+			cfg, err := ResolveConfig(context.Background())
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			tt.check(t, cfg)
+
+			// Resolving must never create the config file
+			if tt.file == "" {
+				_, err := os.Stat(configPath)
+				assert.True(t, os.IsNotExist(err))
+			}
+		})
+	}
+}
+
+func TestResolveConfig_ConfigFileEnv(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	configPath := filepath.Join(t.TempDir(), "custom.yaml")
+	require.NoError(t, os.WriteFile(configPath, []byte("container:\n  image: example.com/minecraft:1\n"), 0644))
+	t.Setenv(ConfigFileEnv, configPath)
+
+	cfg, err := ResolveConfig(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "example.com/minecraft:1", cfg.Container.Image)
+}
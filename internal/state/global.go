@@ -235,6 +235,22 @@ func GetNextAvailablePort(ctx context.Context, startPort int) (int, error) {
 	return 0, fmt.Errorf("no available ports starting from %d", startPort)
 }
 
+// CheckPortLimit returns an error if allocating count more ports would take
+// the number of allocated ports past maxPorts.
+func CheckPortLimit(ctx context.Context, count, maxPorts int) error {
+	state, err := LoadGlobalState(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to load state: %w", err)
+	}
+
+	if len(state.AllocatedPorts)+count > maxPorts {
+		return fmt.Errorf("port limit reached (%d of %d allocated, %d more needed, see limits.max_ports)",
+			len(state.AllocatedPorts), maxPorts, count)
+	}
+
+	return nil
+}
+
 // RegisterServer registers a server in the global state.
 // It uses file locking to ensure thread-safety.
 func RegisterServer(ctx context.Context, name string) error {
@@ -250,6 +250,22 @@ func TestGetNextAvailablePort_StartFromMiddle(t *testing.T) {
 	assert.Equal(t, 30000, port)
 }
 
+func TestCheckPortLimit(t *testing.T) {
+	cleanup := setupTestEnv(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	require.NoError(t, AllocatePort(ctx, 25565))
+	require.NoError(t, AllocatePort(ctx, 25566))
+
+	assert.NoError(t, CheckPortLimit(ctx, 1, 3))
+
+	err := CheckPortLimit(ctx, 2, 3)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "limits.max_ports")
+}
+
 func TestRegisterServer(t *testing.T) {
 	cleanup := setupTestEnv(t)
 	defer cleanup()
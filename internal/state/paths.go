@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 )
 
 const (
@@ -13,12 +14,18 @@ const (
 	// SubdirectoryNames
 	ServersSubdir    = "servers"
 	WhitelistsSubdir = "whitelists"
+	BansSubdir       = "bans"
 	BackupsSubdir    = "backups"
 	ArchivesSubdir   = "archives"
+	HistorySubdir    = "history"
 
 	// File names
-	ConfigFileName = "config.yaml"
-	StateFileName  = "state.yaml"
+	ConfigFileName    = "config.yaml"
+	StateFileName     = "state.yaml"
+	WatchLockFileName = "watch.lock"
+
+	// ConfigFileEnv overrides the config file location (set by the --config flag)
+	ConfigFileEnv = "GOMC_CONFIG"
 )
 
 // GetConfigDir returns the path to the go-mc configuration directory.
@@ -55,6 +62,15 @@ func GetWhitelistsDir() (string, error) {
 	return filepath.Join(configDir, WhitelistsSubdir), nil
 }
 
+// GetBansDir returns the path to the ban lists directory.
+func GetBansDir() (string, error) {
+	configDir, err := GetConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(configDir, BansSubdir), nil
+}
+
 // GetBackupsDir returns the path to the backups directory.
 func GetBackupsDir() (string, error) {
 	configDir, err := GetConfigDir()
@@ -73,8 +89,47 @@ func GetArchivesDir() (string, error) {
 	return filepath.Join(backupsDir, ArchivesSubdir), nil
 }
 
+// ResolveArchivesDir returns the backup archives directory configured by
+// backups.directory, or GetArchivesDir() when it is not set.
+func ResolveArchivesDir(cfg *Config) (string, error) {
+	if cfg == nil || cfg.Backups.Directory == "" {
+		return GetArchivesDir()
+	}
+	return ExpandHome(cfg.Backups.Directory)
+}
+
+// ExpandHome replaces a leading "~/" of a configured path with the user's
+// home directory and cleans the path. Empty paths are returned unchanged.
+func ExpandHome(path string) (string, error) {
+	if path == "" {
+		return "", nil
+	}
+	if rest, ok := strings.CutPrefix(path, "~/"); ok {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", fmt.Errorf("failed to get user home directory: %w", err)
+		}
+		path = filepath.Join(home, rest)
+	}
+	return filepath.Clean(path), nil
+}
+
+// GetHistoryDir returns the path to the console history directory.
+func GetHistoryDir() (string, error) {
+	configDir, err := GetConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(configDir, HistorySubdir), nil
+}
+
 // GetConfigPath returns the path to the main configuration file.
+// The GOMC_CONFIG environment variable takes precedence over the default location.
 func GetConfigPath() (string, error) {
+	if path := os.Getenv(ConfigFileEnv); path != "" {
+		return path, nil
+	}
+
 	configDir, err := GetConfigDir()
 	if err != nil {
 		return "", err
@@ -91,6 +146,15 @@ func GetStatePath() (string, error) {
 	return filepath.Join(configDir, StateFileName), nil
 }
 
+// GetWatchLockPath returns the path to the lock file held by go-mc watch.
+func GetWatchLockPath() (string, error) {
+	configDir, err := GetConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(configDir, WatchLockFileName), nil
+}
+
 // GetServerPath returns the path to a specific server's state file.
 func GetServerPath(name string) (string, error) {
 	if name == "" {
@@ -115,6 +179,22 @@ func GetWhitelistPath(name string) (string, error) {
 	return filepath.Join(whitelistsDir, name+".yaml"), nil
 }
 
+// GetBanListPath returns the path to a ban list file. The global list lives
+// at bans/global.yaml, server lists at bans/servers/<name>.yaml.
+func GetBanListPath(scope string) (string, error) {
+	if scope == "" {
+		return "", fmt.Errorf("ban scope cannot be empty")
+	}
+	bansDir, err := GetBansDir()
+	if err != nil {
+		return "", err
+	}
+	if scope == GlobalBanScope {
+		return filepath.Join(bansDir, GlobalBanScope+".yaml"), nil
+	}
+	return filepath.Join(bansDir, ServersSubdir, scope+".yaml"), nil
+}
+
 // GetServerBackupDir returns the path to a specific server's backup directory.
 func GetServerBackupDir(serverName string) (string, error) {
 	if serverName == "" {
@@ -127,6 +207,18 @@ func GetServerBackupDir(serverName string) (string, error) {
 	return filepath.Join(backupsDir, serverName), nil
 }
 
+// GetConsoleHistoryPath returns the path to a specific server's console history file.
+func GetConsoleHistoryPath(serverName string) (string, error) {
+	if serverName == "" {
+		return "", fmt.Errorf("server name cannot be empty")
+	}
+	historyDir, err := GetHistoryDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(historyDir, serverName), nil
+}
+
 // InitDirs initializes the complete directory structure for go-mc.
 // It creates all necessary directories with proper permissions.
 func InitDirs() error {
@@ -153,6 +245,12 @@ func InitDirs() error {
 	}
 	dirs = append(dirs, whitelistsDir)
 
+	bansDir, err := GetBansDir()
+	if err != nil {
+		return fmt.Errorf("failed to get bans dir: %w", err)
+	}
+	dirs = append(dirs, bansDir, filepath.Join(bansDir, ServersSubdir))
+
 	archivesDir, err := GetArchivesDir()
 	if err != nil {
 		return fmt.Errorf("failed to get archives dir: %w", err)
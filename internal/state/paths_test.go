@@ -71,6 +71,34 @@ func TestGetArchivesDir(t *testing.T) {
 	assert.Contains(t, dir, "go-mc/backups/archives")
 }
 
+func TestResolveArchivesDir(t *testing.T) {
+	home, err := os.UserHomeDir()
+	require.NoError(t, err)
+	defaultDir, err := GetArchivesDir()
+	require.NoError(t, err)
+
+	tests := []struct {
+		name      string
+		directory string
+		want      string
+	}{
+		{name: "default", directory: "", want: defaultDir},
+		{name: "absolute", directory: "/srv/backups/", want: "/srv/backups"},
+		{name: "home", directory: "~/backups", want: filepath.Join(home, "backups")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := DefaultConfig()
+			cfg.Backups.Directory = tt.directory
+
+			dir, err := ResolveArchivesDir(cfg)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, dir)
+		})
+	}
+}
+
 func TestGetConfigPath(t *testing.T) {
 	path, err := GetConfigPath()
 	require.NoError(t, err)
@@ -83,6 +111,12 @@ func TestGetStatePath(t *testing.T) {
 	assert.Contains(t, path, "go-mc/state.yaml")
 }
 
+func TestGetWatchLockPath(t *testing.T) {
+	path, err := GetWatchLockPath()
+	require.NoError(t, err)
+	assert.Contains(t, path, "go-mc/watch.lock")
+}
+
 func TestGetServerPath(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -188,6 +222,86 @@ func TestGetServerBackupDir(t *testing.T) {
 	}
 }
 
+func TestGetHistoryDir(t *testing.T) {
+	dir, err := GetHistoryDir()
+	require.NoError(t, err)
+	assert.Contains(t, dir, "go-mc/history")
+}
+
+func TestGetConsoleHistoryPath(t *testing.T) {
+	tests := []struct {
+		name       string
+		serverName string
+		wantErr    bool
+		errMsg     string
+	}{
+		{
+			name:       "valid server name",
+			serverName: "survival",
+			wantErr:    false,
+		},
+		{
+			name:       "empty server name",
+			serverName: "",
+			wantErr:    true,
+			errMsg:     "server name cannot be empty",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path, err := GetConsoleHistoryPath(tt.serverName)
+
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errMsg)
+			} else {
+				require.NoError(t, err)
+				assert.Contains(t, path, "go-mc/history/"+tt.serverName)
+			}
+		})
+	}
+}
+
+func TestGetBanListPath(t *testing.T) {
+	tests := []struct {
+		name    string
+		scope   string
+		want    string
+		wantErr bool
+	}{
+		{
+			name:  "global scope",
+			scope: GlobalBanScope,
+			want:  "go-mc/bans/global.yaml",
+		},
+		{
+			name:  "server scope",
+			scope: "survival",
+			want:  "go-mc/bans/servers/survival.yaml",
+		},
+		{
+			name:    "empty scope",
+			scope:   "",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path, err := GetBanListPath(tt.scope)
+
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), "ban scope cannot be empty")
+			} else {
+				require.NoError(t, err)
+				assert.Contains(t, path, tt.want)
+			}
+		})
+	}
+}
+
 func TestInitDirs(t *testing.T) {
 	// Use temp directory for testing
 	tmpDir := t.TempDir()
@@ -205,6 +319,7 @@ func TestInitDirs(t *testing.T) {
 		filepath.Join(tmpDir, "go-mc"),
 		filepath.Join(tmpDir, "go-mc", "servers"),
 		filepath.Join(tmpDir, "go-mc", "whitelists"),
+		filepath.Join(tmpDir, "go-mc", "bans", "servers"),
 		filepath.Join(tmpDir, "go-mc", "backups", "archives"),
 	}
 
@@ -0,0 +1,96 @@
+package state
+
+import (
+	"fmt"
+
+	"github.com/docker/go-units"
+)
+
+// Ranges of the relative weights accepted by the container runtime.
+const (
+	MinCPUShares = 2
+	MaxCPUShares = 262144
+	MinIOWeight  = 10
+	MaxIOWeight  = 1000
+)
+
+// jvmOverhead is the least memory the container gets on top of the heap,
+// for the JVM itself, threads and native buffers.
+const jvmOverhead = 512 * 1024 * 1024
+
+// ResourcesConfig holds the container resource limits of a server. Zero
+// values leave the runtime default.
+type ResourcesConfig struct {
+	CPUs       float64 `yaml:"cpus,omitempty"`        // CPU cores the server may use, e.g. 1.5
+	CPUShares  uint64  `yaml:"cpu_shares,omitempty"`  // Relative CPU weight under contention (runtime default 1024)
+	PidsLimit  int64   `yaml:"pids_limit,omitempty"`  // Maximum processes and threads (runtime default 2048 when rootless)
+	MemorySwap string  `yaml:"memory_swap,omitempty"` // Memory plus swap, e.g. 6G; also limits the container memory
+	IOWeight   uint16  `yaml:"io_weight,omitempty"`   // Relative block I/O weight, 10-1000
+}
+
+// ContainerMemoryLimit returns the memory limit of the container of a
+// server with the given heap size: the heap plus a quarter, and at least
+// 512M more, for the JVM itself.
+func ContainerMemoryLimit(memory string) (int64, error) {
+	heap, err := units.RAMInBytes(memory)
+	if err != nil {
+		return 0, fmt.Errorf("invalid memory format: %q: %w", memory, err)
+	}
+	return heap + max(heap/4, jvmOverhead), nil
+}
+
+// ValidateResources validates the resource limits of a server whose heap
+// is memory.
+func ValidateResources(r ResourcesConfig, memory string) error {
+	if r.CPUs < 0 {
+		return fmt.Errorf("cpus must be 0 or greater, got %g", r.CPUs)
+	}
+	if r.CPUShares != 0 && (r.CPUShares < MinCPUShares || r.CPUShares > MaxCPUShares) {
+		return fmt.Errorf("cpu shares must be between %d and %d, got %d", MinCPUShares, MaxCPUShares, r.CPUShares)
+	}
+	if r.PidsLimit < 0 {
+		return fmt.Errorf("pids limit must be 0 or greater, got %d", r.PidsLimit)
+	}
+	if r.IOWeight != 0 && (r.IOWeight < MinIOWeight || r.IOWeight > MaxIOWeight) {
+		return fmt.Errorf("io weight must be between %d and %d, got %d", MinIOWeight, MaxIOWeight, r.IOWeight)
+	}
+
+	if r.MemorySwap != "" {
+		if err := ValidateMemory(r.MemorySwap); err != nil {
+			return fmt.Errorf("invalid memory swap: %w", err)
+		}
+		if memory != "" {
+			limit, err := ContainerMemoryLimit(memory)
+			if err != nil {
+				return err
+			}
+			swap, err := units.RAMInBytes(r.MemorySwap)
+			if err != nil {
+				return fmt.Errorf("invalid memory swap: %q: %w", r.MemorySwap, err)
+			}
+			if swap < limit {
+				return fmt.Errorf("memory swap %s is below the container memory limit of %s for %s of memory",
+					r.MemorySwap, units.BytesSize(float64(limit)), memory)
+			}
+		}
+	}
+
+	return nil
+}
+
+// ValidateResourceLimits validates that resource limits do not exceed the
+// per-server limits of the configuration.
+func ValidateResourceLimits(r ResourcesConfig, limits LimitsConfig) error {
+	if r.CPUs > float64(limits.MaxCPUsPerServer) {
+		return fmt.Errorf("cpus %g exceeds the limit of %d (see limits.max_cpus_per_server)", r.CPUs, limits.MaxCPUsPerServer)
+	}
+	if r.PidsLimit > int64(limits.MaxPidsPerServer) {
+		return fmt.Errorf("pids limit %d exceeds the limit of %d (see limits.max_pids_per_server)", r.PidsLimit, limits.MaxPidsPerServer)
+	}
+	if r.MemorySwap != "" {
+		if err := ValidateMemoryLimit(r.MemorySwap, limits.MaxMemorySwapPerServer); err != nil {
+			return fmt.Errorf("invalid memory swap: %w (see limits.max_memory_swap_per_server)", err)
+		}
+	}
+	return nil
+}
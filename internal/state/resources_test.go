@@ -0,0 +1,83 @@
+package state
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestContainerMemoryLimit(t *testing.T) {
+	tests := map[string]int64{
+		"1G":  1<<30 + 512<<20, // at least 512M on top of the heap
+		"4G":  5 << 30,         // a quarter on top of the heap
+		"16G": 20 << 30,
+	}
+	for memory, want := range tests {
+		limit, err := ContainerMemoryLimit(memory)
+		require.NoError(t, err, memory)
+		assert.Equal(t, want, limit, memory)
+	}
+
+	_, err := ContainerMemoryLimit("lots")
+	assert.ErrorContains(t, err, "invalid memory format")
+}
+
+func TestValidateResources(t *testing.T) {
+	valid := []ResourcesConfig{
+		{},
+		{CPUs: 1.5, CPUShares: 1024, PidsLimit: 4096, IOWeight: 500},
+		{MemorySwap: "5G"},
+		{MemorySwap: "8G"},
+	}
+	for _, r := range valid {
+		assert.NoError(t, ValidateResources(r, "4G"), "%+v", r)
+	}
+
+	invalid := map[string]ResourcesConfig{
+		"cpus must be 0 or greater":        {CPUs: -1},
+		"cpu shares must be between 2":     {CPUShares: 1},
+		"pids limit must be 0 or greater":  {PidsLimit: -1},
+		"io weight must be between 10":     {IOWeight: 5000},
+		"invalid memory swap":              {MemorySwap: "lots"},
+		"below the container memory limit": {MemorySwap: "4G"},
+	}
+	for want, r := range invalid {
+		assert.ErrorContains(t, ValidateResources(r, "4G"), want)
+	}
+}
+
+func TestValidateResourceLimits(t *testing.T) {
+	limits := DefaultConfig().Limits
+
+	assert.NoError(t, ValidateResourceLimits(ResourcesConfig{CPUs: 8, PidsLimit: 8192, MemorySwap: "32G"}, limits))
+
+	invalid := map[string]ResourcesConfig{
+		"max_cpus_per_server":        {CPUs: 8.5},
+		"max_pids_per_server":        {PidsLimit: 10000},
+		"max_memory_swap_per_server": {MemorySwap: "64G"},
+	}
+	for want, r := range invalid {
+		assert.ErrorContains(t, ValidateResourceLimits(r, limits), want)
+	}
+}
+
+func TestServerState_ResourcesRoundTrip(t *testing.T) {
+	cleanup := setupTestEnv(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	serverState := NewServerState("survival")
+	serverState.Minecraft.Memory = "4G"
+	serverState.Resources = ResourcesConfig{CPUs: 2, MemorySwap: "8G", IOWeight: 100}
+	require.NoError(t, SaveServerState(ctx, serverState))
+
+	loaded, err := LoadServerState(ctx, "survival")
+	require.NoError(t, err)
+	assert.Equal(t, serverState.Resources, loaded.Resources)
+
+	// Swap below the container memory limit is rejected on save
+	serverState.Resources.MemorySwap = "2G"
+	assert.ErrorContains(t, SaveServerState(ctx, serverState), "invalid resources")
+}
@@ -0,0 +1,136 @@
+package state
+
+import (
+	"fmt"
+	"time"
+)
+
+// Restart policies of a server.
+const (
+	// RestartNo never restarts the server automatically.
+	RestartNo = "no"
+
+	// RestartOnFailure restarts the server when it exits with a non-zero
+	// code or hangs, up to MaxRetries consecutive times.
+	RestartOnFailure = "on-failure"
+
+	// RestartAlways restarts the server whenever it exits or hangs, unless it
+	// was stopped with go-mc.
+	RestartAlways = "always"
+)
+
+// RestartPolicies lists the valid restart policies.
+var RestartPolicies = []string{RestartNo, RestartOnFailure, RestartAlways}
+
+const (
+	// DefaultRestartBackoff is the delay before the first automatic restart.
+	DefaultRestartBackoff = 10 * time.Second
+
+	// MaxRestartBackoff caps the delay between consecutive restarts.
+	MaxRestartBackoff = 5 * time.Minute
+
+	// MaxRestartRecords is how many restarts are kept in the server state.
+	MaxRestartRecords = 20
+)
+
+// Causes of an automatic restart.
+const (
+	// RestartCauseExit means the server process exited.
+	RestartCauseExit = "exit"
+
+	// RestartCauseHang means the container was running but the server did
+	// not answer server list pings.
+	RestartCauseHang = "hang"
+)
+
+// Who restarted a server.
+const (
+	RestartedByPodman = "podman"
+	RestartedByWatch  = "watch"
+)
+
+// RestartPolicy controls how a server that crashed or hangs is restarted.
+type RestartPolicy struct {
+	Policy     string        `yaml:"policy,omitempty"`      // "no" (default), "on-failure" or "always"
+	MaxRetries int           `yaml:"max_retries,omitempty"` // Consecutive restarts before giving up (0 = unlimited)
+	Backoff    time.Duration `yaml:"backoff,omitempty"`     // Delay before the first restart, doubled for each further one
+}
+
+// Name returns the policy, RestartNo when unset.
+func (p RestartPolicy) Name() string {
+	if p.Policy == "" {
+		return RestartNo
+	}
+	return p.Policy
+}
+
+// Delay returns how long to wait before a restart after the given number of
+// consecutive restarts.
+func (p RestartPolicy) Delay(restarts int) time.Duration {
+	delay := p.Backoff
+	if delay <= 0 {
+		delay = DefaultRestartBackoff
+	}
+	for i := 0; i < restarts && delay < MaxRestartBackoff; i++ {
+		delay *= 2
+	}
+	return min(delay, MaxRestartBackoff)
+}
+
+// RestartRecord records an automatic restart of a server.
+type RestartRecord struct {
+	Time        time.Time `yaml:"time"`
+	By          string    `yaml:"by"`                     // "podman" or "watch"
+	Cause       string    `yaml:"cause"`                  // "exit" or "hang"
+	ExitCode    int       `yaml:"exit_code,omitempty"`    // Exit code of the crashed run
+	CrashReport string    `yaml:"crash_report,omitempty"` // File name in crash-reports/ written by the crashed run
+}
+
+// String describes the restart, e.g. "exit code 1 (crash-2025-01-02_12.00.00-server.txt)".
+func (r RestartRecord) String() string {
+	var cause string
+	switch r.Cause {
+	case RestartCauseHang:
+		cause = "hang (no ping response)"
+	default:
+		cause = fmt.Sprintf("exit code %d", r.ExitCode)
+	}
+	if r.CrashReport != "" {
+		cause += " (" + r.CrashReport + ")"
+	}
+	return cause
+}
+
+// RecordRestart appends a restart to the history of a server, dropping the
+// oldest records beyond MaxRestartRecords.
+func (s *ServerState) RecordRestart(record RestartRecord) {
+	s.Restarts = append(s.Restarts, record)
+	if len(s.Restarts) > MaxRestartRecords {
+		s.Restarts = s.Restarts[len(s.Restarts)-MaxRestartRecords:]
+	}
+}
+
+// ValidateRestartPolicy validates a restart policy.
+func ValidateRestartPolicy(policy RestartPolicy) error {
+	valid := policy.Policy == ""
+	for _, p := range RestartPolicies {
+		if policy.Policy == p {
+			valid = true
+		}
+	}
+	if !valid {
+		return fmt.Errorf("unknown restart policy %q (valid: no, on-failure, always)", policy.Policy)
+	}
+
+	if policy.MaxRetries < 0 {
+		return fmt.Errorf("max retries must not be negative, got %d", policy.MaxRetries)
+	}
+	if policy.MaxRetries > 0 && policy.Name() != RestartOnFailure {
+		return fmt.Errorf("max retries only apply to the on-failure policy")
+	}
+	if policy.Backoff < 0 {
+		return fmt.Errorf("backoff must not be negative, got %s", policy.Backoff)
+	}
+
+	return nil
+}
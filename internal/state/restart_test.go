@@ -0,0 +1,84 @@
+package state
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRestartPolicy_Delay(t *testing.T) {
+	policy := RestartPolicy{Policy: RestartOnFailure}
+	assert.Equal(t, 10*time.Second, policy.Delay(0))
+	assert.Equal(t, 20*time.Second, policy.Delay(1))
+	assert.Equal(t, 40*time.Second, policy.Delay(2))
+	assert.Equal(t, MaxRestartBackoff, policy.Delay(10))
+
+	policy.Backoff = 3 * time.Second
+	assert.Equal(t, 6*time.Second, policy.Delay(1))
+}
+
+func TestValidateRestartPolicy(t *testing.T) {
+	valid := []RestartPolicy{
+		{},
+		{Policy: RestartNo},
+		{Policy: RestartAlways, Backoff: time.Minute},
+		{Policy: RestartOnFailure, MaxRetries: 5},
+	}
+	for _, policy := range valid {
+		assert.NoError(t, ValidateRestartPolicy(policy), "%+v", policy)
+	}
+
+	invalid := map[string]RestartPolicy{
+		"unknown restart policy":       {Policy: "unless-stopped"},
+		"must not be negative, got -1": {Policy: RestartOnFailure, MaxRetries: -1},
+		"only apply to the on-failure": {Policy: RestartAlways, MaxRetries: 3},
+		"backoff must not be negative": {Policy: RestartAlways, Backoff: -time.Second},
+	}
+	for want, policy := range invalid {
+		assert.ErrorContains(t, ValidateRestartPolicy(policy), want)
+	}
+}
+
+func TestRecordRestart(t *testing.T) {
+	serverState := NewServerState("survival")
+	for i := 0; i < MaxRestartRecords+5; i++ {
+		serverState.RecordRestart(RestartRecord{By: RestartedByWatch, Cause: RestartCauseExit, ExitCode: i})
+	}
+
+	require.Len(t, serverState.Restarts, MaxRestartRecords)
+	assert.Equal(t, 5, serverState.Restarts[0].ExitCode)
+	assert.Equal(t, MaxRestartRecords+4, serverState.Restarts[MaxRestartRecords-1].ExitCode)
+}
+
+func TestRestartRecord_String(t *testing.T) {
+	assert.Equal(t, "exit code 1 (crash-2025-01-02_12.00.00-server.txt)",
+		RestartRecord{Cause: RestartCauseExit, ExitCode: 1, CrashReport: "crash-2025-01-02_12.00.00-server.txt"}.String())
+	assert.Equal(t, "hang (no ping response)", RestartRecord{Cause: RestartCauseHang}.String())
+}
+
+func TestServerState_RestartRoundTrip(t *testing.T) {
+	cleanup := setupTestEnv(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	at := time.Date(2025, 1, 2, 12, 0, 0, 0, time.UTC)
+
+	serverState := NewServerState("survival")
+	serverState.Restart = RestartPolicy{Policy: RestartOnFailure, MaxRetries: 3, Backoff: 30 * time.Second}
+	serverState.RecordRestart(RestartRecord{Time: at, By: RestartedByPodman, Cause: RestartCauseExit, ExitCode: 137})
+	require.NoError(t, SaveServerState(ctx, serverState))
+
+	loaded, err := LoadServerState(ctx, "survival")
+	require.NoError(t, err)
+	assert.Equal(t, serverState.Restart, loaded.Restart)
+	assert.Equal(t, serverState.Restarts, loaded.Restarts)
+
+	// An invalid policy is rejected on save
+	serverState.Restart.Policy = "sometimes"
+	err = SaveServerState(ctx, serverState)
+	assert.ErrorContains(t, err, fmt.Sprintf("invalid restart policy: unknown restart policy %q", "sometimes"))
+}
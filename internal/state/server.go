@@ -33,6 +33,12 @@ type ServerState struct {
 	Mods      []ModInfo       `yaml:"mods"`
 	Ops       []OpInfo        `yaml:"ops"`
 
+	Restart  RestartPolicy   `yaml:"restart,omitempty"`
+	Restarts []RestartRecord `yaml:"restarts,omitempty"` // Automatic restarts, oldest first
+
+	Autostart AutostartConfig `yaml:"autostart,omitempty"`
+	Resources ResourcesConfig `yaml:"resources,omitempty"`
+
 	CreatedAt   time.Time `yaml:"created_at"`
 	UpdatedAt   time.Time `yaml:"updated_at"`
 	LastStarted time.Time `yaml:"last_started,omitempty"`
@@ -48,6 +54,7 @@ type MinecraftConfig struct {
 	GamePort            int    `yaml:"game_port"`
 	RconPort            int    `yaml:"rcon_port"`
 	RconPassword        string `yaml:"rcon_password"`
+	QueryPort           int    `yaml:"query_port,omitempty"` // UDP port of the GameSpy4 query (0 if disabled)
 }
 
 // VolumesConfig holds volume mount configuration.
@@ -63,6 +70,12 @@ type WhitelistConfig struct {
 	EnforceOnJoin bool     `yaml:"enforce_on_join"`
 }
 
+// AutostartConfig holds whether a server is started on boot by systemd.
+type AutostartConfig struct {
+	Enabled bool `yaml:"enabled"`
+	Order   int  `yaml:"order,omitempty"` // Servers with a lower order start first
+}
+
 // ModInfo represents information about an installed mod.
 type ModInfo struct {
 	Name         string   `yaml:"name"`
@@ -75,8 +88,9 @@ type ModInfo struct {
 	SHA512       string   `yaml:"sha512"`
 	SizeBytes    int64    `yaml:"size_bytes"`
 	Dependencies []string `yaml:"dependencies"`
-	Port         int      `yaml:"port,omitempty"`     // Port allocated for this mod (0 if no port needed)
-	Protocol     string   `yaml:"protocol,omitempty"` // Protocol: "tcp", "udp", or "" if no port
+	Port         int      `yaml:"port,omitempty"`        // Port allocated for this mod (0 if no port needed)
+	Protocol     string   `yaml:"protocol,omitempty"`    // Protocol: "tcp", "udp", or "" if no port
+	ServerSide   string   `yaml:"server_side,omitempty"` // Modrinth server_side support, "" if not recorded
 }
 
 // OpInfo represents an operator.
@@ -323,6 +337,24 @@ func ValidateServerState(state *ServerState) error {
 		}
 	}
 
+	if state.Minecraft.QueryPort != 0 {
+		if err := ValidatePort(state.Minecraft.QueryPort); err != nil {
+			return fmt.Errorf("invalid query port: %w", err)
+		}
+	}
+
+	if err := ValidateRestartPolicy(state.Restart); err != nil {
+		return fmt.Errorf("invalid restart policy: %w", err)
+	}
+
+	if err := ValidateResources(state.Resources, state.Minecraft.Memory); err != nil {
+		return fmt.Errorf("invalid resources: %w", err)
+	}
+
+	if state.Autostart.Order < 0 {
+		return fmt.Errorf("invalid autostart order: %d (must be 0 or greater)", state.Autostart.Order)
+	}
+
 	// Validate whitelist names
 	for _, listName := range state.Whitelist.Lists {
 		if err := ValidateWhitelistName(listName); err != nil {
@@ -303,6 +303,26 @@ func TestValidateServerState(t *testing.T) {
 			wantErr: true,
 			errMsg:  "invalid RCON port",
 		},
+		{
+			name: "invalid query port",
+			state: func() *ServerState {
+				s := NewServerState("survival")
+				s.Minecraft.QueryPort = 70000
+				return s
+			}(),
+			wantErr: true,
+			errMsg:  "invalid query port",
+		},
+		{
+			name: "invalid autostart order",
+			state: func() *ServerState {
+				s := NewServerState("survival")
+				s.Autostart = AutostartConfig{Enabled: true, Order: -1}
+				return s
+			}(),
+			wantErr: true,
+			errMsg:  "invalid autostart order",
+		},
 		{
 			name: "invalid whitelist name",
 			state: func() *ServerState {
@@ -2,8 +2,11 @@ package state
 
 import (
 	"fmt"
+	"net"
 	"regexp"
 	"strings"
+
+	"github.com/docker/go-units"
 )
 
 var (
@@ -15,6 +18,9 @@ var (
 
 	// memoryRegex validates memory format (e.g., "2G", "512M", "4096M")
 	memoryRegex = regexp.MustCompile(`^[0-9]+[MGT]$`)
+
+	// memoryInputRegex matches lenient memory input (e.g., "4g", "4GB", "512MiB")
+	memoryInputRegex = regexp.MustCompile(`^([0-9]+)\s*([mgtMGT])(?:[iI]?[bB])?$`)
 )
 
 // ValidateServerName validates a server name.
@@ -76,6 +82,42 @@ func ValidateMemory(memory string) error {
 	return nil
 }
 
+// NormalizeMemory converts lenient memory input such as "4g", "4GB" or "512MiB"
+// into the canonical format accepted by ValidateMemory ("4G", "512M").
+func NormalizeMemory(memory string) (string, error) {
+	m := memoryInputRegex.FindStringSubmatch(strings.TrimSpace(memory))
+	if m == nil {
+		return "", fmt.Errorf("invalid memory format: %q (expected format: 512M, 2G, etc.)", memory)
+	}
+	return m[1] + strings.ToUpper(m[2]), nil
+}
+
+// ValidateMemoryLimit validates that a memory size does not exceed a limit.
+// Both values must be valid memory sizes.
+func ValidateMemoryLimit(memory, limit string) error {
+	if err := ValidateMemory(memory); err != nil {
+		return err
+	}
+	if err := ValidateMemory(limit); err != nil {
+		return fmt.Errorf("invalid memory limit: %w", err)
+	}
+
+	memoryBytes, err := units.RAMInBytes(memory)
+	if err != nil {
+		return fmt.Errorf("invalid memory format: %q: %w", memory, err)
+	}
+	limitBytes, err := units.RAMInBytes(limit)
+	if err != nil {
+		return fmt.Errorf("invalid memory limit: %q: %w", limit, err)
+	}
+
+	if memoryBytes > limitBytes {
+		return fmt.Errorf("memory %s exceeds the limit of %s", memory, limit)
+	}
+
+	return nil
+}
+
 // ValidateVersion validates a Minecraft version string.
 // This is a basic check that the version is not empty.
 // More sophisticated validation could check against known versions.
@@ -137,6 +179,19 @@ func ValidatePlayerName(name string) error {
 	return nil
 }
 
+// ValidateIP validates an IPv4 or IPv6 address.
+func ValidateIP(ip string) error {
+	if ip == "" {
+		return fmt.Errorf("IP address cannot be empty")
+	}
+
+	if net.ParseIP(ip) == nil {
+		return fmt.Errorf("invalid IP address: %q", ip)
+	}
+
+	return nil
+}
+
 // ValidateJavaVersion validates a Java version number.
 // Valid values: 8, 11, 17, 21, etc.
 func ValidateJavaVersion(version int) error {
@@ -255,6 +255,60 @@ func TestValidateMemory(t *testing.T) {
 	}
 }
 
+func TestValidateMemoryLimit(t *testing.T) {
+	tests := []struct {
+		name    string
+		memory  string
+		limit   string
+		wantErr bool
+		errMsg  string
+	}{
+		{
+			name:   "below limit",
+			memory: "2G",
+			limit:  "16G",
+		},
+		{
+			name:   "equal to limit",
+			memory: "16384M",
+			limit:  "16G",
+		},
+		{
+			name:    "above limit",
+			memory:  "32G",
+			limit:   "16G",
+			wantErr: true,
+			errMsg:  "exceeds the limit",
+		},
+		{
+			name:    "invalid memory",
+			memory:  "lots",
+			limit:   "16G",
+			wantErr: true,
+			errMsg:  "invalid memory format",
+		},
+		{
+			name:    "invalid limit",
+			memory:  "2G",
+			limit:   "",
+			wantErr: true,
+			errMsg:  "invalid memory limit",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateMemoryLimit(tt.memory, tt.limit)
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errMsg)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
 func TestValidateVersion(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -413,6 +467,57 @@ func TestValidatePlayerName(t *testing.T) {
 	}
 }
 
+func TestValidateIP(t *testing.T) {
+	tests := []struct {
+		name    string
+		ip      string
+		wantErr bool
+		errMsg  string
+	}{
+		{
+			name:    "IPv4",
+			ip:      "203.0.113.7",
+			wantErr: false,
+		},
+		{
+			name:    "IPv6",
+			ip:      "2001:db8::1",
+			wantErr: false,
+		},
+		{
+			name:    "empty",
+			ip:      "",
+			wantErr: true,
+			errMsg:  "IP address cannot be empty",
+		},
+		{
+			name:    "hostname",
+			ip:      "example.com",
+			wantErr: true,
+			errMsg:  "invalid IP address",
+		},
+		{
+			name:    "CIDR range",
+			ip:      "10.0.0.0/8",
+			wantErr: true,
+			errMsg:  "invalid IP address",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateIP(tt.ip)
+
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errMsg)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
 func TestValidateJavaVersion(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -551,3 +656,35 @@ func TestValidatePath(t *testing.T) {
 		})
 	}
 }
+
+func TestNormalizeMemory(t *testing.T) {
+	tests := []struct {
+		memory  string
+		want    string
+		wantErr bool
+	}{
+		{memory: "4G", want: "4G"},
+		{memory: "4g", want: "4G"},
+		{memory: "4GB", want: "4G"},
+		{memory: "512MiB", want: "512M"},
+		{memory: " 1t ", want: "1T"},
+		{memory: "512 m", want: "512M"},
+		{memory: "4.5G", wantErr: true},
+		{memory: "512K", wantErr: true},
+		{memory: "512", wantErr: true},
+		{memory: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.memory, func(t *testing.T) {
+			got, err := NormalizeMemory(tt.memory)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+			assert.NoError(t, ValidateMemory(got))
+		})
+	}
+}
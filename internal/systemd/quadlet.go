@@ -0,0 +1,178 @@
+// Package systemd generates Quadlet units that start server containers on
+// boot and manages them through the systemd user instance.
+package systemd
+
+import (
+	"fmt"
+	"math"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/steviee/go-mc/internal/container"
+)
+
+const (
+	// UnitPrefix prefixes the units of go-mc servers, so they do not clash
+	// with other units of the user.
+	UnitPrefix = "go-mc-"
+
+	// startTimeout allows for pulling the image on the first boot.
+	startTimeout = 15 * time.Minute
+)
+
+// ContainerUnit is a Quadlet unit that runs the container of a server.
+type ContainerUnit struct {
+	Description string
+	Container   *container.ContainerConfig
+
+	// After lists the servers whose units are started first.
+	After []string
+
+	// StopTimeout is how long the server gets to exit before it is killed.
+	StopTimeout time.Duration
+}
+
+// UnitFileName returns the name of the Quadlet file of a server.
+func UnitFileName(serverName string) string {
+	return UnitPrefix + serverName + ".container"
+}
+
+// ServiceName returns the name of the service systemd generates from the
+// Quadlet file of a server.
+func ServiceName(serverName string) string {
+	return UnitPrefix + serverName + ".service"
+}
+
+// Render returns the contents of the Quadlet file. The container gets the
+// same name, image, environment, ports, mounts and labels as one created
+// through the container runtime API; its restart policy is applied by
+// systemd.
+func (u *ContainerUnit) Render() string {
+	c := u.Container
+	var b strings.Builder
+
+	b.WriteString("# Generated by go-mc; changes are overwritten.\n")
+	b.WriteString("# Use 'go-mc servers autostart' to enable or disable.\n")
+
+	b.WriteString("\n[Unit]\n")
+	writeKey(&b, "Description", u.Description)
+	b.WriteString("Wants=network-online.target\n")
+	after := []string{"network-online.target"}
+	for _, name := range u.After {
+		after = append(after, ServiceName(name))
+	}
+	writeKey(&b, "After", strings.Join(after, " "))
+
+	b.WriteString("\n[Container]\n")
+	writeKey(&b, "ContainerName", c.Name)
+	writeKey(&b, "Image", c.Image)
+	for _, key := range sortedKeys(c.Env) {
+		writeKey(&b, "Environment", quote(key+"="+c.Env[key]))
+	}
+	ports := append([]container.PortMapping(nil), c.Ports...)
+	container.SortPorts(ports)
+	for _, p := range ports {
+		writeKey(&b, "PublishPort", publishPort(p))
+	}
+	for _, hostPath := range sortedKeys(c.Volumes) {
+		writeKey(&b, "Volume", quote(hostPath+":"+c.Volumes[hostPath]))
+	}
+	for _, key := range sortedKeys(c.Labels) {
+		writeKey(&b, "Label", quote(key+"="+c.Labels[key]))
+	}
+	if c.WorkingDir != "" {
+		writeKey(&b, "WorkingDir", quote(c.WorkingDir))
+	}
+	if c.Memory != "" {
+		writeKey(&b, "PodmanArgs", "--memory="+c.Memory)
+	}
+	if c.CPUQuota > 0 {
+		writeKey(&b, "PodmanArgs", "--cpu-quota="+strconv.FormatInt(c.CPUQuota, 10))
+	}
+	if c.MemorySwap != "" {
+		writeKey(&b, "PodmanArgs", "--memory-swap="+c.MemorySwap)
+	}
+	if c.CPUShares > 0 {
+		writeKey(&b, "PodmanArgs", "--cpu-shares="+strconv.FormatUint(c.CPUShares, 10))
+	}
+	if c.PidsLimit > 0 {
+		writeKey(&b, "PodmanArgs", "--pids-limit="+strconv.FormatInt(c.PidsLimit, 10))
+	}
+	if c.IOWeight > 0 {
+		writeKey(&b, "PodmanArgs", "--blkio-weight="+strconv.FormatUint(uint64(c.IOWeight), 10))
+	}
+	if len(c.Command) > 0 {
+		args := make([]string, 0, len(c.Command))
+		for _, arg := range c.Command {
+			args = append(args, quote(arg))
+		}
+		writeKey(&b, "Exec", strings.Join(args, " "))
+	}
+	if u.StopTimeout > 0 {
+		writeKey(&b, "StopTimeout", strconv.Itoa(int(math.Ceil(u.StopTimeout.Seconds()))))
+	}
+
+	b.WriteString("\n[Service]\n")
+	switch c.RestartPolicy {
+	case "on-failure", "always":
+		writeKey(&b, "Restart", c.RestartPolicy)
+	default:
+		b.WriteString("Restart=no\n")
+	}
+	writeKey(&b, "TimeoutStartSec", strconv.Itoa(int(startTimeout.Seconds())))
+
+	b.WriteString("\n[Install]\n")
+	b.WriteString("WantedBy=default.target\n")
+
+	return b.String()
+}
+
+// writeKey writes a key of a unit file. Percent signs are escaped, as
+// systemd would read them as specifiers.
+func writeKey(b *strings.Builder, key, value string) {
+	_, _ = fmt.Fprintf(b, "%s=%s\n", key, strings.ReplaceAll(value, "%", "%%"))
+}
+
+// publishPort formats a port mapping for PublishPort, like
+// "0.0.0.0:25565:25565/tcp" or "[::1]:25575:25575/tcp".
+func publishPort(p container.PortMapping) string {
+	hostIP := p.HostIP
+	if hostIP == "" {
+		hostIP = "0.0.0.0"
+	}
+	if ip := net.ParseIP(hostIP); ip != nil && ip.To4() == nil {
+		hostIP = "[" + hostIP + "]"
+	}
+
+	protocol := strings.ToLower(p.Protocol)
+	if protocol == "" {
+		protocol = container.ProtocolTCP
+	}
+
+	return fmt.Sprintf("%s:%d:%d/%s", hostIP, p.HostPort, p.ContainerPort, protocol)
+}
+
+// quote quotes a value for systemd when it contains whitespace, quotes or
+// backslashes.
+func quote(s string) string {
+	if !strings.ContainsAny(s, " \t\"'\\") {
+		return s
+	}
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	s = strings.ReplaceAll(s, `"`, `\"`)
+	return `"` + s + `"`
+}
+
+// sortedKeys returns the keys of a map in order, so units are written the
+// same way every time.
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,104 @@
+package systemd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/steviee/go-mc/internal/container"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestContainerUnit_Render(t *testing.T) {
+	unit := &ContainerUnit{
+		Description: "Minecraft server survival (go-mc)",
+		After:       []string{"lobby"},
+		StopTimeout: 30 * time.Second,
+		Container: &container.ContainerConfig{
+			Name:  "survival",
+			Image: "docker.io/itzg/minecraft-server:latest",
+			Env: map[string]string{
+				"VERSION": "1.21.1",
+				"EULA":    "TRUE",
+				"MOTD":    `A "100%" server`,
+			},
+			Ports: []container.PortMapping{
+				{HostPort: 25665, ContainerPort: 25565, Protocol: "udp"},
+				{HostPort: 25565, ContainerPort: 25565},
+				{HostIP: "::1", HostPort: 25575, ContainerPort: 25575, Protocol: "tcp"},
+			},
+			Volumes: map[string]string{
+				"/srv/survival/mods": "/data/mods",
+				"/srv/survival/data": "/data",
+			},
+			Labels:        map[string]string{"go-mc.server": "survival", "go-mc.managed": "true"},
+			RestartPolicy: "on-failure",
+		},
+	}
+
+	assert.Equal(t, `# Generated by go-mc; changes are overwritten.
+# Use 'go-mc servers autostart' to enable or disable.
+
+[Unit]
+Description=Minecraft server survival (go-mc)
+Wants=network-online.target
+After=network-online.target go-mc-lobby.service
+
+[Container]
+ContainerName=survival
+Image=docker.io/itzg/minecraft-server:latest
+Environment=EULA=TRUE
+Environment="MOTD=A \"100%%\" server"
+Environment=VERSION=1.21.1
+PublishPort=0.0.0.0:25565:25565/tcp
+PublishPort=[::1]:25575:25575/tcp
+PublishPort=0.0.0.0:25665:25565/udp
+Volume=/srv/survival/data:/data
+Volume=/srv/survival/mods:/data/mods
+Label=go-mc.managed=true
+Label=go-mc.server=survival
+StopTimeout=30
+
+[Service]
+Restart=on-failure
+TimeoutStartSec=900
+
+[Install]
+WantedBy=default.target
+`, unit.Render())
+}
+
+func TestContainerUnit_RenderOptional(t *testing.T) {
+	unit := &ContainerUnit{
+		Description: "test",
+		Container: &container.ContainerConfig{
+			Name:       "test",
+			Image:      "alpine",
+			Memory:     "2G",
+			MemorySwap: "4G",
+			CPUQuota:   50000,
+			CPUShares:  512,
+			PidsLimit:  4096,
+			IOWeight:   200,
+			WorkingDir: "/srv/my server",
+			Command:    []string{"sh", "-c", "echo hi"},
+		},
+	}
+
+	rendered := unit.Render()
+	assert.Contains(t, rendered, "After=network-online.target\n")
+	assert.Contains(t, rendered, "WorkingDir=\"/srv/my server\"\n")
+	assert.Contains(t, rendered, "PodmanArgs=--memory=2G\n")
+	assert.Contains(t, rendered, "PodmanArgs=--cpu-quota=50000\n")
+	assert.Contains(t, rendered, "PodmanArgs=--memory-swap=4G\n")
+	assert.Contains(t, rendered, "PodmanArgs=--cpu-shares=512\n")
+	assert.Contains(t, rendered, "PodmanArgs=--pids-limit=4096\n")
+	assert.Contains(t, rendered, "PodmanArgs=--blkio-weight=200\n")
+	assert.Contains(t, rendered, "Exec=sh -c \"echo hi\"\n")
+	assert.Contains(t, rendered, "Restart=no\n")
+	assert.NotContains(t, rendered, "StopTimeout")
+}
+
+func TestNames(t *testing.T) {
+	assert.Equal(t, "go-mc-survival.container", UnitFileName("survival"))
+	assert.Equal(t, "go-mc-survival.service", ServiceName("survival"))
+}
@@ -0,0 +1,159 @@
+package systemd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/steviee/go-mc/internal/state"
+)
+
+// runCommand runs a command and returns its combined output. Tests replace
+// it to avoid talking to systemd.
+var runCommand = func(ctx context.Context, name string, args ...string) ([]byte, error) {
+	return exec.CommandContext(ctx, name, args...).CombinedOutput()
+}
+
+// QuadletDir returns the directory Podman reads the Quadlet units of the
+// user from, ~/.config/containers/systemd by default.
+func QuadletDir() (string, error) {
+	configHome := os.Getenv("XDG_CONFIG_HOME")
+	if configHome == "" {
+		homeDir, err := os.UserHomeDir()
+		if err != nil {
+			return "", fmt.Errorf("failed to get user home directory: %w", err)
+		}
+		configHome = filepath.Join(homeDir, ".config")
+	}
+	return filepath.Join(configHome, "containers", "systemd"), nil
+}
+
+// UnitPath returns the path of the Quadlet file of a server.
+func UnitPath(serverName string) (string, error) {
+	dir, err := QuadletDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, UnitFileName(serverName)), nil
+}
+
+// WriteUnit writes the Quadlet file of a server and returns its path.
+func WriteUnit(serverName, content string) (string, error) {
+	path, err := UnitPath(serverName)
+	if err != nil {
+		return "", err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return "", fmt.Errorf("failed to create Quadlet directory: %w", err)
+	}
+	if err := state.AtomicWrite(path, []byte(content), 0644); err != nil {
+		return "", fmt.Errorf("failed to write unit: %w", err)
+	}
+	return path, nil
+}
+
+// RemoveUnit removes the Quadlet file of a server, if there is one.
+func RemoveUnit(serverName string) error {
+	path, err := UnitPath(serverName)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to remove unit: %w", err)
+	}
+	return nil
+}
+
+// UnitExists reports whether a server has a Quadlet file.
+func UnitExists(serverName string) (bool, error) {
+	path, err := UnitPath(serverName)
+	if err != nil {
+		return false, err
+	}
+	if _, err := os.Stat(path); err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+// InstalledUnits returns the servers that have a Quadlet file.
+func InstalledUnits() ([]string, error) {
+	dir, err := QuadletDir()
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return []string{}, nil
+		}
+		return nil, fmt.Errorf("failed to read Quadlet directory: %w", err)
+	}
+
+	names := []string{}
+	for _, entry := range entries {
+		name, ok := strings.CutPrefix(entry.Name(), UnitPrefix)
+		if !ok || entry.IsDir() {
+			continue
+		}
+		if name, ok = strings.CutSuffix(name, ".container"); ok {
+			names = append(names, name)
+		}
+	}
+	return names, nil
+}
+
+// DaemonReload makes systemd generate the services of changed Quadlet files.
+func DaemonReload(ctx context.Context) error {
+	if out, err := runCommand(ctx, "systemctl", "--user", "daemon-reload"); err != nil {
+		return fmt.Errorf("systemctl --user daemon-reload failed: %w: %s", err, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
+
+// StartService starts the service of a server. The service creates the
+// container from the Quadlet unit, replacing one of the same name, and
+// systemd restarts it according to the Restart= setting of the unit.
+func StartService(ctx context.Context, serverName string) error {
+	service := ServiceName(serverName)
+	if out, err := runCommand(ctx, "systemctl", "--user", "start", service); err != nil {
+		return fmt.Errorf("systemctl --user start %s failed: %w: %s", service, err, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
+
+// StopService stops the service of a server. Unlike a container that exits
+// on its own, a service stopped this way is not restarted by systemd,
+// whatever its Restart= setting. Stopping a service that is not running
+// succeeds.
+func StopService(ctx context.Context, serverName string) error {
+	service := ServiceName(serverName)
+	if out, err := runCommand(ctx, "systemctl", "--user", "stop", service); err != nil {
+		return fmt.Errorf("systemctl --user stop %s failed: %w: %s", service, err, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
+
+// Lingering reports whether lingering is enabled for the current user.
+// Without it, the systemd user instance and so the servers only start once
+// the user logs in.
+func Lingering(ctx context.Context) (bool, error) {
+	user := os.Getenv("USER")
+	if user == "" {
+		return false, fmt.Errorf("USER environment variable not set")
+	}
+
+	out, err := runCommand(ctx, "loginctl", "show-user", user, "--property=Linger", "--value")
+	if err != nil {
+		return false, fmt.Errorf("loginctl show-user failed: %w: %s", err, strings.TrimSpace(string(out)))
+	}
+	return strings.TrimSpace(string(out)) == "yes", nil
+}
@@ -0,0 +1,114 @@
+package systemd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// stubCommand replaces runCommand and returns the commands run
+func stubCommand(t *testing.T, out string, err error) *[][]string {
+	t.Helper()
+
+	var calls [][]string
+	orig := runCommand
+	runCommand = func(ctx context.Context, name string, args ...string) ([]byte, error) {
+		calls = append(calls, append([]string{name}, args...))
+		return []byte(out), err
+	}
+	t.Cleanup(func() { runCommand = orig })
+
+	return &calls
+}
+
+func TestUnits(t *testing.T) {
+	configHome := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", configHome)
+
+	dir, err := QuadletDir()
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(configHome, "containers", "systemd"), dir)
+
+	// Nothing installed yet
+	names, err := InstalledUnits()
+	require.NoError(t, err)
+	assert.Empty(t, names)
+
+	path, err := WriteUnit("survival", "[Container]\n")
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(dir, "go-mc-survival.container"), path)
+	_, err = WriteUnit("creative", "[Container]\n")
+	require.NoError(t, err)
+
+	// Units of other tools are left alone
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "nginx.container"), nil, 0600))
+
+	names, err = InstalledUnits()
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"survival", "creative"}, names)
+
+	exists, err := UnitExists("survival")
+	require.NoError(t, err)
+	assert.True(t, exists)
+
+	require.NoError(t, RemoveUnit("survival"))
+	require.NoError(t, RemoveUnit("survival"), "removing twice is fine")
+
+	exists, err = UnitExists("survival")
+	require.NoError(t, err)
+	assert.False(t, exists)
+}
+
+func TestDaemonReload(t *testing.T) {
+	calls := stubCommand(t, "", nil)
+	require.NoError(t, DaemonReload(context.Background()))
+	assert.Equal(t, [][]string{{"systemctl", "--user", "daemon-reload"}}, *calls)
+
+	stubCommand(t, "Failed to connect to bus\n", fmt.Errorf("exit status 1"))
+	err := DaemonReload(context.Background())
+	assert.ErrorContains(t, err, "Failed to connect to bus")
+}
+
+func TestStartService(t *testing.T) {
+	calls := stubCommand(t, "", nil)
+	require.NoError(t, StartService(context.Background(), "survival"))
+	assert.Equal(t, [][]string{{"systemctl", "--user", "start", "go-mc-survival.service"}}, *calls)
+
+	stubCommand(t, "Failed to connect to bus\n", fmt.Errorf("exit status 1"))
+	err := StartService(context.Background(), "survival")
+	assert.ErrorContains(t, err, "Failed to connect to bus")
+}
+
+func TestStopService(t *testing.T) {
+	calls := stubCommand(t, "", nil)
+	require.NoError(t, StopService(context.Background(), "survival"))
+	assert.Equal(t, [][]string{{"systemctl", "--user", "stop", "go-mc-survival.service"}}, *calls)
+
+	stubCommand(t, "Failed to connect to bus\n", fmt.Errorf("exit status 1"))
+	err := StopService(context.Background(), "survival")
+	assert.ErrorContains(t, err, "Failed to connect to bus")
+}
+
+func TestLingering(t *testing.T) {
+	t.Setenv("USER", "steve")
+
+	calls := stubCommand(t, "yes\n", nil)
+	lingering, err := Lingering(context.Background())
+	require.NoError(t, err)
+	assert.True(t, lingering)
+	assert.Equal(t, [][]string{{"loginctl", "show-user", "steve", "--property=Linger", "--value"}}, *calls)
+
+	stubCommand(t, "no\n", nil)
+	lingering, err = Lingering(context.Background())
+	require.NoError(t, err)
+	assert.False(t, lingering)
+
+	t.Setenv("USER", "")
+	_, err = Lingering(context.Background())
+	assert.ErrorContains(t, err, "USER")
+}
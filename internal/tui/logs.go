@@ -0,0 +1,135 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/steviee/go-mc/internal/container"
+)
+
+const (
+	// logsTail is how many existing lines the logs view starts with
+	logsTail = 200
+
+	// maxLogLines is how many lines the logs view keeps in memory
+	maxLogLines = 1000
+
+	// defaultLogsHeight is the number of log lines shown before the
+	// terminal size is known
+	defaultLogsHeight = 20
+)
+
+// openLogs switches to the logs view of a server and starts following its logs
+func (m Model) openLogs(server, containerID string) (Model, tea.Cmd) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	m.logsServer = server
+	m.logLines = nil
+	m.logsStream = nil
+	m.logsCancel = cancel
+
+	return m, openLogsCmd(ctx, m.containerClient, server, containerID)
+}
+
+// closeLogs stops the log stream and returns to the server list
+func (m Model) closeLogs() Model {
+	if m.logsCancel != nil {
+		m.logsCancel()
+	}
+
+	m.logsServer = ""
+	m.logLines = nil
+	m.logsStream = nil
+	m.logsCancel = nil
+
+	return m
+}
+
+// appendLogLine adds a line to the logs view, dropping the oldest lines
+// beyond maxLogLines
+func (m Model) appendLogLine(line container.LogLine) Model {
+	m.logLines = append(m.logLines, line)
+	if len(m.logLines) > maxLogLines {
+		m.logLines = m.logLines[len(m.logLines)-maxLogLines:]
+	}
+	return m
+}
+
+// openLogsCmd returns a command that opens the log stream of a container
+func openLogsCmd(ctx context.Context, client container.Client, server, containerID string) tea.Cmd {
+	return func() tea.Msg {
+		lines, err := client.Logs(ctx, containerID, container.LogOptions{
+			Follow: true,
+			Tail:   logsTail,
+		})
+
+		return logsOpenedMsg{
+			server: server,
+			lines:  lines,
+			err:    err,
+		}
+	}
+}
+
+// waitForLogLineCmd returns a command that waits for the next line of a log stream
+func waitForLogLineCmd(server string, lines <-chan container.LogLine) tea.Cmd {
+	return func() tea.Msg {
+		line, ok := <-lines
+		if !ok {
+			return logsEndedMsg{server: server}
+		}
+		if line.Err != nil {
+			return logsEndedMsg{server: server, err: line.Err}
+		}
+
+		return logLineMsg{
+			server: server,
+			line:   line,
+			lines:  lines,
+		}
+	}
+}
+
+// renderLogs renders the logs view, showing the most recent lines that fit
+func (m Model) renderLogs() string {
+	var b strings.Builder
+
+	b.WriteString(tableHeaderStyle.Render(fmt.Sprintf("Logs: %s", m.logsServer)))
+	b.WriteString("\n")
+
+	// Leave room for the header box, the title, the footer and an error
+	height := defaultLogsHeight
+	if m.height > 0 {
+		height = m.height - 8
+	}
+	if height < 1 {
+		height = 1
+	}
+
+	lines := m.logLines
+	if len(lines) > height {
+		lines = lines[len(lines)-height:]
+	}
+
+	if len(lines) == 0 {
+		b.WriteString("Waiting for log output...\n")
+	}
+
+	for _, line := range lines {
+		text := line.Format(false)
+		if runes := []rune(text); m.width > 0 && len(runes) > m.width {
+			text = string(runes[:m.width])
+		}
+
+		if line.Stream == container.LogStderr {
+			text = statusStoppedStyle.Render(text)
+		}
+
+		b.WriteString(text)
+		b.WriteString("\n")
+	}
+
+	return b.String()
+}
@@ -0,0 +1,164 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/steviee/go-mc/internal/container"
+	"github.com/steviee/go-mc/internal/state"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// saveLogsServer saves a server with a container for the logs view
+func saveLogsServer(t *testing.T, containerID string) {
+	t.Helper()
+
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	require.NoError(t, state.InitDirs())
+
+	serverState := state.NewServerState("server1")
+	serverState.ContainerID = containerID
+	require.NoError(t, state.SaveServerState(context.Background(), serverState))
+}
+
+// logStream returns a closed log stream with the given lines
+func logStream(lines ...container.LogLine) <-chan container.LogLine {
+	ch := make(chan container.LogLine, len(lines))
+	for _, line := range lines {
+		ch <- line
+	}
+	close(ch)
+	return ch
+}
+
+func TestLogsView_Follow(t *testing.T) {
+	saveLogsServer(t, "container123")
+
+	stream := logStream(
+		container.LogLine{Stream: container.LogStdout, Text: "[Server thread/INFO]: Starting"},
+		container.LogLine{Stream: container.LogStderr, Text: "WARNING: unsafe"},
+	)
+	client := &mockContainerClient{}
+	client.On("Logs", mock.Anything, "container123", container.LogOptions{Follow: true, Tail: logsTail}).
+		Return(stream, nil)
+
+	model := NewModel(client)
+	model.servers = []ServerInfo{{Name: "server1", Status: "running"}}
+
+	// Open the logs view
+	updated, cmd := model.handleKeyPress(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("l")})
+	m := updated.(Model)
+	assert.Equal(t, "server1", m.logsServer)
+	require.NotNil(t, cmd)
+
+	// Feed the stream through Update until it ends
+	msg := cmd()
+	for cmd != nil {
+		var next tea.Model
+		next, cmd = m.Update(msg)
+		m = next.(Model)
+		if cmd != nil {
+			msg = cmd()
+		}
+	}
+
+	assert.IsType(t, logsEndedMsg{}, msg)
+	require.Len(t, m.logLines, 2)
+	assert.Equal(t, container.LogStderr, m.logLines[1].Stream)
+	assert.Nil(t, m.err)
+
+	view := m.View()
+	assert.Contains(t, view, "Logs: server1")
+	assert.Contains(t, view, "[Server thread/INFO]: Starting")
+	assert.Contains(t, view, "[esc/l] back")
+	assert.NotContains(t, view, "NAME")
+	client.AssertExpectations(t)
+
+	// Esc returns to the server list
+	updated, _ = m.handleKeyPress(tea.KeyMsg{Type: tea.KeyEsc})
+	m = updated.(Model)
+	assert.Empty(t, m.logsServer)
+	assert.Empty(t, m.logLines)
+}
+
+func TestLogsView_Errors(t *testing.T) {
+	t.Run("server without container", func(t *testing.T) {
+		saveLogsServer(t, "")
+
+		model := NewModel(&mockContainerClient{})
+		model.servers = []ServerInfo{{Name: "server1", Status: "created"}}
+
+		updated, cmd := model.handleKeyPress(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("l")})
+		m := updated.(Model)
+
+		assert.Empty(t, m.logsServer)
+		assert.ErrorContains(t, m.err, "no container")
+		assert.NotNil(t, cmd)
+	})
+
+	t.Run("stream cannot be opened", func(t *testing.T) {
+		model := NewModel(&mockContainerClient{})
+		model.logsServer = "server1"
+
+		updated, _ := model.Update(logsOpenedMsg{server: "server1", err: container.ErrContainerNotFound})
+		m := updated.(Model)
+
+		assert.Empty(t, m.logsServer)
+		assert.ErrorContains(t, m.err, "logs failed for server1")
+	})
+
+	t.Run("stream fails", func(t *testing.T) {
+		model := NewModel(&mockContainerClient{})
+		model.logsServer = "server1"
+
+		cmd := waitForLogLineCmd("server1", logStream(container.LogLine{Err: fmt.Errorf("connection reset")}))
+		updated, _ := model.Update(cmd())
+		m := updated.(Model)
+
+		assert.ErrorContains(t, m.err, "connection reset")
+		assert.Equal(t, "server1", m.logsServer)
+	})
+}
+
+func TestLogsView_DropsStaleLines(t *testing.T) {
+	model := NewModel(&mockContainerClient{})
+	model.logsServer = "server2"
+
+	updated, cmd := model.Update(logLineMsg{server: "server1", line: container.LogLine{Text: "old"}})
+	m := updated.(Model)
+
+	assert.Empty(t, m.logLines)
+	assert.Nil(t, cmd)
+}
+
+func TestAppendLogLine(t *testing.T) {
+	model := NewModel(&mockContainerClient{})
+
+	for i := 0; i < maxLogLines+5; i++ {
+		*model = model.appendLogLine(container.LogLine{Text: fmt.Sprintf("line %d", i)})
+	}
+
+	require.Len(t, model.logLines, maxLogLines)
+	assert.Equal(t, "line 5", model.logLines[0].Text)
+}
+
+func TestRenderLogs_Height(t *testing.T) {
+	model := NewModel(&mockContainerClient{})
+	model.logsServer = "server1"
+	model.height = 10
+	for i := 0; i < 5; i++ {
+		model.logLines = append(model.logLines, container.LogLine{Text: fmt.Sprintf("line %d", i), Time: time.Now()})
+	}
+
+	out := model.renderLogs()
+
+	// A 10 line terminal leaves room for the last two lines
+	assert.NotContains(t, out, "line 2")
+	assert.Contains(t, out, "line 3")
+	assert.Contains(t, out, "line 4")
+}
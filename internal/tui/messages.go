@@ -1,6 +1,10 @@
 package tui
 
-import "time"
+import (
+	"time"
+
+	"github.com/steviee/go-mc/internal/container"
+)
 
 // tickMsg is sent on every auto-refresh tick
 type tickMsg time.Time
@@ -25,3 +29,23 @@ type errorMsg struct {
 
 // clearErrorMsg is sent to clear the error message
 type clearErrorMsg struct{}
+
+// logsOpenedMsg is sent when the log stream of a server is opened
+type logsOpenedMsg struct {
+	server string
+	lines  <-chan container.LogLine
+	err    error
+}
+
+// logLineMsg is sent for every line of an open log stream
+type logLineMsg struct {
+	server string
+	line   container.LogLine
+	lines  <-chan container.LogLine
+}
+
+// logsEndedMsg is sent when a log stream ends
+type logsEndedMsg struct {
+	server string
+	err    error
+}
@@ -7,8 +7,13 @@ import (
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/steviee/go-mc/internal/container"
+	"github.com/steviee/go-mc/internal/lifecycle"
+	"github.com/steviee/go-mc/internal/state"
 )
 
+// DefaultRefreshInterval is how often the dashboard refreshes by default
+const DefaultRefreshInterval = time.Second
+
 // PortInfo represents a network port used by the server
 type PortInfo struct {
 	Number   int    // Port number
@@ -76,9 +81,10 @@ type ServerInfo struct {
 	MemoryPercent    float64 // Memory usage percentage (0-100)
 	// Historical metrics for graphs
 	Metrics *MetricsHistory // Historical CPU/Memory metrics
-	// Player information (for future use)
-	PlayerCount int // Current player count
-	PlayerMax   int // Maximum players
+	// Player information from the server list ping
+	PlayersKnown bool // Whether the server answered the ping
+	PlayerCount  int  // Current player count
+	PlayerMax    int  // Maximum players
 	// Mod and port information
 	InstalledMods []ModInfo  // List of installed mods
 	Ports         []PortInfo // List of network ports
@@ -96,8 +102,14 @@ type Model struct {
 	height          int
 	containerClient container.Client
 	quitting        bool
+	refreshInterval time.Duration
 	// Metrics history map (keyed by server name)
 	metricsHistory map[string]*MetricsHistory
+	// Logs view of a single server; empty logsServer shows the server list
+	logsServer string
+	logLines   []container.LogLine
+	logsStream <-chan container.LogLine
+	logsCancel context.CancelFunc
 }
 
 // NewModel creates a new TUI model
@@ -109,20 +121,29 @@ func NewModel(client container.Client) *Model {
 		loading:         true,
 		containerClient: client,
 		metricsHistory:  make(map[string]*MetricsHistory),
+		refreshInterval: DefaultRefreshInterval,
+	}
+}
+
+// SetRefreshInterval sets how often the dashboard refreshes.
+// Non-positive values keep the current interval.
+func (m *Model) SetRefreshInterval(interval time.Duration) {
+	if interval > 0 {
+		m.refreshInterval = interval
 	}
 }
 
 // Init initializes the model
 func (m Model) Init() tea.Cmd {
 	return tea.Batch(
-		tickCmd(),
+		tickCmd(m.refreshInterval),
 		loadServersCmd(context.Background(), m.containerClient),
 	)
 }
 
-// tickCmd returns a command that sends a tick message every second
-func tickCmd() tea.Cmd {
-	return tea.Tick(time.Second, func(t time.Time) tea.Msg {
+// tickCmd returns a command that sends a tick message after the refresh interval
+func tickCmd(interval time.Duration) tea.Cmd {
+	return tea.Tick(interval, func(t time.Time) tea.Msg {
 		return tickMsg(t)
 	})
 }
@@ -156,39 +177,33 @@ func startServerCmd(ctx context.Context, client container.Client, name string, c
 	}
 }
 
-// stopServerCmd returns a command that stops a server
-func stopServerCmd(ctx context.Context, client container.Client, name string, containerID string) tea.Cmd {
+// stopServerCmd returns a command that gracefully stops a server
+func stopServerCmd(ctx context.Context, client container.Client, serverState *state.ServerState) tea.Cmd {
 	return func() tea.Msg {
-		var err error
-		if containerID != "" {
-			timeout := 30 * time.Second
-			err = client.StopContainer(ctx, containerID, &timeout)
-		} else {
-			err = fmt.Errorf("server has no container ID")
-		}
+		_, err := lifecycle.Stop(ctx, client, serverState, lifecycle.ShutdownOptions{
+			Countdown: lifecycle.DefaultCountdown,
+			Timeout:   lifecycle.DefaultStopTimeout,
+		})
 
 		return serverActionMsg{
 			action: "stop",
-			server: name,
+			server: serverState.Name,
 			err:    err,
 		}
 	}
 }
 
-// restartServerCmd returns a command that restarts a server
-func restartServerCmd(ctx context.Context, client container.Client, name string, containerID string) tea.Cmd {
+// restartServerCmd returns a command that gracefully restarts a server
+func restartServerCmd(ctx context.Context, client container.Client, serverState *state.ServerState) tea.Cmd {
 	return func() tea.Msg {
-		var err error
-		if containerID != "" {
-			timeout := 30 * time.Second
-			err = client.RestartContainer(ctx, containerID, &timeout)
-		} else {
-			err = fmt.Errorf("server has no container ID")
-		}
+		_, err := lifecycle.Restart(ctx, client, serverState, lifecycle.ShutdownOptions{
+			Countdown: lifecycle.DefaultCountdown,
+			Timeout:   lifecycle.DefaultStopTimeout,
+		})
 
 		return serverActionMsg{
 			action: "restart",
-			server: name,
+			server: serverState.Name,
 			err:    err,
 		}
 	}
@@ -93,6 +93,14 @@ func (m *mockContainerClient) GetContainerStats(ctx context.Context, containerID
 	return args.Get(0).(*container.ContainerStats), args.Error(1)
 }
 
+func (m *mockContainerClient) Logs(ctx context.Context, containerID string, opts container.LogOptions) (<-chan container.LogLine, error) {
+	args := m.Called(ctx, containerID, opts)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(<-chan container.LogLine), args.Error(1)
+}
+
 func TestNewModel(t *testing.T) {
 	client := &mockContainerClient{}
 
@@ -103,6 +111,18 @@ func TestNewModel(t *testing.T) {
 	assert.True(t, model.loading)
 	assert.Empty(t, model.servers)
 	assert.Equal(t, client, model.containerClient)
+	assert.Equal(t, DefaultRefreshInterval, model.refreshInterval)
+}
+
+func TestModel_SetRefreshInterval(t *testing.T) {
+	model := NewModel(&mockContainerClient{})
+
+	model.SetRefreshInterval(5 * time.Second)
+	assert.Equal(t, 5*time.Second, model.refreshInterval)
+
+	// Non-positive values keep the current interval
+	model.SetRefreshInterval(0)
+	assert.Equal(t, 5*time.Second, model.refreshInterval)
 }
 
 func TestModelUpdate_WindowSize(t *testing.T) {
@@ -4,13 +4,20 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"net"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/steviee/go-mc/internal/container"
+	"github.com/steviee/go-mc/internal/mcping"
 	"github.com/steviee/go-mc/internal/state"
 )
 
+// pingTimeout bounds the server list ping of each running server, so a hung
+// server cannot stall the refresh
+const pingTimeout = time.Second
+
 // loadServers loads server information from state and container runtime
 func loadServers(ctx context.Context, client container.Client) ([]ServerInfo, error) {
 	// Get list of registered servers from global state
@@ -129,6 +136,20 @@ func collectServerInfo(ctx context.Context, name string, client container.Client
 		server.MemoryUsed = "-"
 	}
 
+	// Ask the server itself for its players
+	if server.Status == "running" && serverState.Minecraft.GamePort != 0 {
+		pingCtx, cancel := context.WithTimeout(ctx, pingTimeout)
+		status, err := mcping.Ping(pingCtx, net.JoinHostPort("127.0.0.1", strconv.Itoa(serverState.Minecraft.GamePort)))
+		cancel()
+		if err == nil {
+			server.PlayersKnown = true
+			server.PlayerCount = status.Players.Online
+			server.PlayerMax = status.Players.Max
+		} else {
+			slog.Debug("server list ping failed", "server", name, "error", err)
+		}
+	}
+
 	return server, nil
 }
 
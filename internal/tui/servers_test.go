@@ -1,11 +1,17 @@
 package tui
 
 import (
+	"context"
 	"testing"
 	"time"
 
+	"github.com/steviee/go-mc/internal/container"
+	"github.com/steviee/go-mc/internal/mcping"
+	"github.com/steviee/go-mc/internal/mcping/mcpingtest"
 	"github.com/steviee/go-mc/internal/state"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 )
 
 func TestNormalizeContainerState(t *testing.T) {
@@ -127,6 +133,40 @@ func TestFormatUptime_EdgeCases(t *testing.T) {
 	assert.Contains(t, result, "d")
 }
 
+func TestCollectServerInfo_Players(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	require.NoError(t, state.InitDirs())
+
+	game := mcpingtest.NewServer(t, mcping.Status{
+		Version: mcping.Version{Name: "1.21.1", Protocol: 767},
+		Players: mcping.Players{Max: 20, Online: 4},
+	})
+
+	serverState := state.NewServerState("server1")
+	serverState.ContainerID = "container123"
+	serverState.LastStarted = time.Now().Add(-time.Hour)
+	serverState.Minecraft.GamePort = game.Port()
+	require.NoError(t, state.SaveServerState(context.Background(), serverState))
+
+	client := &mockContainerClient{}
+	client.On("InspectContainer", mock.Anything, "container123").Return(&container.ContainerInfo{State: "running"}, nil)
+	client.On("GetContainerStats", mock.Anything, "container123").Return(&container.ContainerStats{CPUPercent: 5}, nil)
+
+	server, err := collectServerInfo(context.Background(), "server1", client)
+	require.NoError(t, err)
+	assert.True(t, server.PlayersKnown)
+	assert.Equal(t, 4, server.PlayerCount)
+	assert.Equal(t, 20, server.PlayerMax)
+	assert.Equal(t, "4/20", formatPlayers(server))
+
+	// A server that does not answer shows no player counts
+	game.Close()
+	server, err = collectServerInfo(context.Background(), "server1", client)
+	require.NoError(t, err)
+	assert.False(t, server.PlayersKnown)
+	assert.Equal(t, "-", formatPlayers(server))
+}
+
 func TestDetectPorts(t *testing.T) {
 	tests := []struct {
 		name     string
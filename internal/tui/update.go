@@ -27,7 +27,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 		// Auto-refresh server list
 		return m, tea.Batch(
-			tickCmd(),
+			tickCmd(m.refreshInterval),
 			loadServersCmd(context.Background(), m.containerClient),
 		)
 
@@ -94,6 +94,36 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.err = msg.err
 		m.errorTime = time.Now()
 		return m, clearErrorCmd()
+
+	case logsOpenedMsg:
+		// Ignore streams of a logs view that was closed in the meantime
+		if msg.server != m.logsServer {
+			return m, nil
+		}
+		if msg.err != nil {
+			m = m.closeLogs()
+			m.err = fmt.Errorf("logs failed for %s: %w", msg.server, msg.err)
+			m.errorTime = time.Now()
+			return m, clearErrorCmd()
+		}
+		m.logsStream = msg.lines
+		return m, waitForLogLineCmd(msg.server, msg.lines)
+
+	case logLineMsg:
+		// Lines of an earlier stream are dropped; its context is canceled
+		if msg.server != m.logsServer || msg.lines != m.logsStream {
+			return m, nil
+		}
+		m = m.appendLogLine(msg.line)
+		return m, waitForLogLineCmd(msg.server, msg.lines)
+
+	case logsEndedMsg:
+		if msg.server != m.logsServer || msg.err == nil {
+			return m, nil
+		}
+		m.err = fmt.Errorf("logs failed for %s: %w", msg.server, msg.err)
+		m.errorTime = time.Now()
+		return m, clearErrorCmd()
 	}
 
 	return m, nil
@@ -104,10 +134,20 @@ func (m Model) handleKeyPress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	// Global keys
 	switch msg.String() {
 	case "ctrl+c", "q":
+		m = m.closeLogs()
 		m.quitting = true
 		return m, tea.Quit
 	}
 
+	// The logs view only knows how to close itself
+	if m.logsServer != "" {
+		switch msg.String() {
+		case "esc", "l":
+			return m.closeLogs(), nil
+		}
+		return m, nil
+	}
+
 	// If no servers, ignore navigation and action keys
 	if len(m.servers) == 0 {
 		return m, nil
@@ -149,13 +189,13 @@ func (m Model) handleKeyPress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			server := m.servers[m.selectedIdx]
 			if server.Status == "running" {
 				ctx := context.Background()
-				containerID, err := getServerContainerID(ctx, server.Name)
+				serverState, err := getServerState(ctx, server.Name)
 				if err != nil {
-					m.err = fmt.Errorf("failed to get container ID: %w", err)
+					m.err = fmt.Errorf("failed to load server: %w", err)
 					m.errorTime = time.Now()
 					return m, clearErrorCmd()
 				}
-				return m, stopServerCmd(ctx, m.containerClient, server.Name, containerID)
+				return m, stopServerCmd(ctx, m.containerClient, serverState)
 			}
 		}
 		return m, nil
@@ -166,22 +206,30 @@ func (m Model) handleKeyPress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			server := m.servers[m.selectedIdx]
 			if server.Status == "running" {
 				ctx := context.Background()
-				containerID, err := getServerContainerID(ctx, server.Name)
+				serverState, err := getServerState(ctx, server.Name)
 				if err != nil {
-					m.err = fmt.Errorf("failed to get container ID: %w", err)
+					m.err = fmt.Errorf("failed to load server: %w", err)
 					m.errorTime = time.Now()
 					return m, clearErrorCmd()
 				}
-				return m, restartServerCmd(ctx, m.containerClient, server.Name, containerID)
+				return m, restartServerCmd(ctx, m.containerClient, serverState)
 			}
 		}
 		return m, nil
 
 	case "l":
-		// Show logs - not implemented yet
-		m.err = fmt.Errorf("logs view not implemented yet")
-		m.errorTime = time.Now()
-		return m, clearErrorCmd()
+		// Show logs of selected server
+		if m.selectedIdx < len(m.servers) {
+			server := m.servers[m.selectedIdx]
+			containerID, err := getServerContainerID(context.Background(), server.Name)
+			if err != nil {
+				m.err = fmt.Errorf("failed to get container ID: %w", err)
+				m.errorTime = time.Now()
+				return m, clearErrorCmd()
+			}
+			return m.openLogs(server.Name, containerID)
+		}
+		return m, nil
 
 	case "d":
 		// Delete server - not implemented yet (requires confirmation)
@@ -195,14 +243,24 @@ func (m Model) handleKeyPress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 
 // getServerContainerID retrieves the container ID for a server
 func getServerContainerID(ctx context.Context, name string) (string, error) {
+	serverState, err := getServerState(ctx, name)
+	if err != nil {
+		return "", err
+	}
+
+	return serverState.ContainerID, nil
+}
+
+// getServerState loads the state of a server that has a container
+func getServerState(ctx context.Context, name string) (*state.ServerState, error) {
 	serverState, err := state.LoadServerState(ctx, name)
 	if err != nil {
-		return "", fmt.Errorf("failed to load server state: %w", err)
+		return nil, fmt.Errorf("failed to load server state: %w", err)
 	}
 
 	if serverState.ContainerID == "" {
-		return "", fmt.Errorf("server has no container")
+		return nil, fmt.Errorf("server has no container")
 	}
 
-	return serverState.ContainerID, nil
+	return serverState, nil
 }
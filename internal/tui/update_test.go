@@ -6,6 +6,7 @@ import (
 	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/steviee/go-mc/internal/state"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -167,10 +168,14 @@ func TestStopServerCmd(t *testing.T) {
 	ctx := context.Background()
 	client := &mockContainerClient{}
 
+	// Without RCON configuration the container runtime stops the server
 	timeout := 30 * time.Second
 	client.On("StopContainer", ctx, "container123", &timeout).Return(nil)
 
-	cmd := stopServerCmd(ctx, client, "test-server", "container123")
+	serverState := state.NewServerState("test-server")
+	serverState.ContainerID = "container123"
+
+	cmd := stopServerCmd(ctx, client, serverState)
 	msg := cmd()
 
 	actionMsg, ok := msg.(serverActionMsg)
@@ -186,10 +191,15 @@ func TestRestartServerCmd(t *testing.T) {
 	ctx := context.Background()
 	client := &mockContainerClient{}
 
+	// Without RCON configuration the container runtime stops the server
 	timeout := 30 * time.Second
-	client.On("RestartContainer", ctx, "container123", &timeout).Return(nil)
+	client.On("StopContainer", ctx, "container123", &timeout).Return(nil)
+	client.On("StartContainer", ctx, "container123").Return(nil)
 
-	cmd := restartServerCmd(ctx, client, "test-server", "container123")
+	serverState := state.NewServerState("test-server")
+	serverState.ContainerID = "container123"
+
+	cmd := restartServerCmd(ctx, client, serverState)
 	msg := cmd()
 
 	actionMsg, ok := msg.(serverActionMsg)
@@ -202,7 +212,7 @@ func TestRestartServerCmd(t *testing.T) {
 }
 
 func TestTickCmd(t *testing.T) {
-	cmd := tickCmd()
+	cmd := tickCmd(DefaultRefreshInterval)
 	assert.NotNil(t, cmd)
 
 	// Execute the command
@@ -286,23 +296,6 @@ func TestHandleKeyPress_RestartServer_NotRunning(t *testing.T) {
 	assert.Nil(t, cmd)
 }
 
-func TestHandleKeyPress_LogsNotImplemented(t *testing.T) {
-	client := &mockContainerClient{}
-	model := NewModel(client)
-	model.servers = []ServerInfo{
-		{Name: "server1", Status: "running"},
-	}
-	model.selectedIdx = 0
-
-	msg := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("l")}
-	updatedModel, cmd := model.handleKeyPress(msg)
-	m := updatedModel.(Model)
-
-	assert.NotNil(t, m.err)
-	assert.Contains(t, m.err.Error(), "not implemented")
-	assert.NotNil(t, cmd) // Should clear error
-}
-
 func TestHandleKeyPress_DeleteNotImplemented(t *testing.T) {
 	client := &mockContainerClient{}
 	model := NewModel(client)
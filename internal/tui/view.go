@@ -20,8 +20,10 @@ func (m Model) View() string {
 	b.WriteString(m.renderHeader())
 	b.WriteString("\n")
 
-	// Render table
-	if m.loading && len(m.servers) == 0 {
+	// Render logs view or table
+	if m.logsServer != "" {
+		b.WriteString(m.renderLogs())
+	} else if m.loading && len(m.servers) == 0 {
 		b.WriteString("\nLoading servers...\n")
 	} else if len(m.servers) == 0 {
 		b.WriteString("\nNo servers found. Create one with 'go-mc servers create <name>'\n")
@@ -88,6 +90,7 @@ func (m Model) renderTable() string {
 	statusWidth := 10
 	versionWidth := 8
 	portWidth := 5
+	playersWidth := 7
 	sparklineWidth := 12
 	cpuBarWidth := 15 // Progress bar with percentage
 	memBarWidth := 15 // Progress bar with percentage
@@ -102,11 +105,12 @@ func (m Model) renderTable() string {
 	}
 
 	// Render header row with new columns
-	headerRow := fmt.Sprintf("%-*s  %-*s  %-*s  %*s  %-*s  %-*s  %-*s  %-*s  %*s  %*s",
+	headerRow := fmt.Sprintf("%-*s  %-*s  %-*s  %*s  %*s  %-*s  %-*s  %-*s  %-*s  %*s  %*s",
 		nameWidth, "NAME",
 		statusWidth, "STATUS",
 		versionWidth, "VERSION",
 		portWidth, "PORT",
+		playersWidth, "PLAYERS",
 		sparklineWidth, "CPU TREND",
 		cpuBarWidth, "CPU",
 		sparklineWidth, "MEM TREND",
@@ -143,6 +147,7 @@ func (m Model) renderTable() string {
 			statusCol := fmt.Sprintf("%-*s", statusWidth, statusText)
 			versionCol := fmt.Sprintf("%-*s", versionWidth, server.Version)
 			portCol := fmt.Sprintf("%*d", portWidth, server.Port)
+			playersCol := fmt.Sprintf("%*s", playersWidth, formatPlayers(server))
 			modsCol := fmt.Sprintf("%*d", modsWidth, len(server.InstalledMods))
 			uptimeCol := fmt.Sprintf("%*s", uptimeWidth, uptime)
 
@@ -155,6 +160,7 @@ func (m Model) renderTable() string {
 			// Apply selected style to remaining columns
 			row += selectedRowStyle.Render(versionCol) + "  "
 			row += selectedRowStyle.Render(portCol) + "  "
+			row += selectedRowStyle.Render(playersCol) + "  "
 			row += selectedRowStyle.Render(cpuSparkline) + "  "
 			row += selectedRowStyle.Render(cpuBar) + "  "
 			row += selectedRowStyle.Render(memSparkline) + "  "
@@ -169,6 +175,7 @@ func (m Model) renderTable() string {
 			statusCol := fmt.Sprintf("%-*s", statusWidth, statusText)
 			versionCol := fmt.Sprintf("%-*s", versionWidth, server.Version)
 			portCol := fmt.Sprintf("%*d", portWidth, server.Port)
+			playersCol := fmt.Sprintf("%*s", playersWidth, formatPlayers(server))
 			modsCol := fmt.Sprintf("%*d", modsWidth, len(server.InstalledMods))
 			uptimeCol := fmt.Sprintf("%*s", uptimeWidth, uptime)
 
@@ -176,6 +183,7 @@ func (m Model) renderTable() string {
 				statusStyle.Render(statusCol) + "  " +
 				versionCol + "  " +
 				portCol + "  " +
+				playersCol + "  " +
 				cpuSparkline + "  " +
 				cpuBar + "  " +
 				memSparkline + "  " +
@@ -194,6 +202,10 @@ func (m Model) renderTable() string {
 
 // renderFooter renders the dashboard footer with action help
 func (m Model) renderFooter() string {
+	if m.logsServer != "" {
+		return footerStyle.Render("[esc/l] back  [q]uit")
+	}
+
 	actions := "[↑/↓] navigate  [s]tart  [x]top  [r]estart  [l]ogs  [d]elete  [q]uit"
 	return footerStyle.Render(actions)
 }
@@ -211,6 +223,14 @@ func formatMemory(server ServerInfo) string {
 	return "-"
 }
 
+// formatPlayers formats the player counts of a server for display
+func formatPlayers(server ServerInfo) string {
+	if !server.PlayersKnown {
+		return "-"
+	}
+	return fmt.Sprintf("%d/%d", server.PlayerCount, server.PlayerMax)
+}
+
 // formatCPU formats CPU usage percentage
 func formatCPU(server ServerInfo) string {
 	if server.Status != "running" || server.CPUPercent == 0 {